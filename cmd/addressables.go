@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addressablesProfile   string
+	addressablesClean     bool
+	addressablesFormat    string
+	addressablesLogFile   string
+	addressablesTimeout   int
+	addressablesCIMode    bool
+	addressablesTimestamp bool
+)
+
+var addressablesCmd = &cobra.Command{
+	Use:   "addressables",
+	Short: "Build Addressables content",
+	Long:  `Commands to build the Addressables content of a project and clean its build cache.`,
+}
+
+var addressablesBuildCmd = &cobra.Command{
+	Use:   "build [project]",
+	Short: "Build the Addressables content in batch mode",
+	Long: `Build the project's Addressables content with its editor in batch mode.
+
+uniforge adds a small editor script to Assets/UniForgeTemp for the duration of
+the build and runs it with -executeMethod; it is removed afterwards. The script
+selects the profile, builds the content, and runs the "Check Duplicate Bundle
+Dependencies" analyze rule. The Unity log is streamed while building, then the
+bundles with their sizes and the assets duplicated across groups are listed.
+
+The project must depend on com.unity.addressables and must not be open in
+another editor.
+
+Examples:
+  # Build with the active profile
+  uniforge addressables build
+
+  # Build with a profile from scratch
+  uniforge addressables build ./MyGame --profile Production --clean
+
+  # JSON report in CI
+  uniforge addressables build --ci --log-file addressables.log --format json`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runAddressablesBuild,
+	SilenceUsage: true,
+}
+
+var addressablesCleanCmd = &cobra.Command{
+	Use:   "clean [project]",
+	Short: "Remove the Addressables build cache",
+	Long: `Remove the Addressables build cache of a project: the built content and
+catalogs in Library/com.unity.addressables and the Scriptable Build Pipeline
+cache in Library/BuildCache. The next content build starts from scratch.
+The editor is not needed.
+
+Examples:
+  uniforge addressables clean
+  uniforge addressables clean ./MyGame`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runAddressablesClean,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(addressablesCmd)
+	addressablesCmd.AddCommand(addressablesBuildCmd)
+	addressablesCmd.AddCommand(addressablesCleanCmd)
+
+	addressablesBuildCmd.Flags().StringVar(&addressablesProfile, "profile", "", "Addressables profile to build with (default: the active profile)")
+	addressablesBuildCmd.Flags().BoolVar(&addressablesClean, "clean", false, "Remove the build cache first")
+	addressablesBuildCmd.Flags().StringVar(&addressablesFormat, "format", "table", "Output format: table, json")
+	addressablesBuildCmd.Flags().StringVar(&addressablesLogFile, "log-file", "", "Path to save log file")
+	addressablesBuildCmd.Flags().IntVar(&addressablesTimeout, "timeout", 3600, "Timeout in seconds")
+	addressablesBuildCmd.Flags().BoolVar(&addressablesCIMode, "ci", false, "CI mode (optimized output format)")
+	addressablesBuildCmd.Flags().BoolVarP(&addressablesTimestamp, "timestamp", "t", false, "Show timestamp for each line")
+}
+
+func runAddressablesBuild(cmd *cobra.Command, args []string) error {
+	if addressablesFormat != "table" && addressablesFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s", addressablesFormat)
+	}
+	projectRoot, err := projectRootArg(args, 0)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	if addressablesClean {
+		if err := cleanAddressablesCache(project); err != nil {
+			return err
+		}
+	}
+
+	ui.Info("Building Addressables content of %s with Unity %s", project.Name, project.UnityVersion)
+	report, err := unity.NewRunner(project).BuildAddressables(unity.AddressablesConfig{
+		Profile:        addressablesProfile,
+		LogFile:        addressablesLogFile,
+		TimeoutSeconds: addressablesTimeout,
+		CIMode:         addressablesCIMode,
+		ShowTimestamp:  addressablesTimestamp,
+	})
+	if report == nil {
+		return err
+	}
+
+	if addressablesFormat == "json" {
+		data, jsonErr := json.MarshalIndent(report, "", "  ")
+		if jsonErr != nil {
+			return jsonErr
+		}
+		fmt.Println(string(data))
+		return err
+	}
+
+	fmt.Println()
+	printAddressablesReport(report)
+	if err != nil {
+		return err
+	}
+	ui.Success("Built %d bundle(s) (%s) in %s", len(report.Bundles), ui.FormatBytes(report.TotalSize()), report.Duration.Round(time.Second))
+	return nil
+}
+
+// printAddressablesReport lists the bundles and the duplicated assets
+func printAddressablesReport(report *unity.AddressablesReport) {
+	if report.Profile != "" {
+		ui.Info("Profile: %s", report.Profile)
+	}
+	for _, b := range report.Bundles {
+		fmt.Printf("  %10s  %s\n", ui.FormatBytes(b.Size), pathStyle.Render(b.Path))
+	}
+	for _, w := range report.Warnings {
+		ui.Warn("%s", w)
+	}
+
+	assets, groups := report.DuplicateAssets()
+	if len(assets) == 0 {
+		return
+	}
+	ui.Warn("%d asset(s) are duplicated across groups:", len(assets))
+	for _, asset := range assets {
+		fmt.Printf("  %s %s\n", asset, pathStyle.Render("("+strings.Join(groups[asset], ", ")+")"))
+	}
+}
+
+func runAddressablesClean(cmd *cobra.Command, args []string) error {
+	projectRoot, err := projectRootArg(args, 0)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+	return cleanAddressablesCache(project)
+}
+
+func cleanAddressablesCache(project *unity.Project) error {
+	freed, err := unity.CleanAddressablesCache(project.Path)
+	if err != nil {
+		return err
+	}
+	if freed == 0 {
+		ui.Info("No Addressables build cache in %s", project.Name)
+		return nil
+	}
+	ui.Success("Removed the Addressables build cache of %s (%s)", project.Name, ui.FormatBytes(freed))
+	return nil
+}
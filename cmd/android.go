@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var androidDevice string
+
+var androidCmd = &cobra.Command{
+	Use:   "android",
+	Short: "Work with Android devices",
+	Long:  `Commands to install, launch and read logs of Unity builds on Android devices via adb.`,
+}
+
+func init() {
+	rootCmd.AddCommand(androidCmd)
+
+	androidCmd.PersistentFlags().StringVarP(&androidDevice, "device", "s", "", "Target device serial (required when multiple devices are connected)")
+}
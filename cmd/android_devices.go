@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/adb"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var androidDevicesCmd = &cobra.Command{
+	Use:   "devices",
+	Short: "List connected Android devices",
+	Long: `List Android devices known to adb.
+
+adb is located via UNIFORGE_ADB_PATH, ANDROID_HOME / ANDROID_SDK_ROOT
+(platform-tools), or PATH.
+
+Examples:
+  uniforge android devices`,
+	RunE: runAndroidDevices,
+}
+
+func init() {
+	androidCmd.AddCommand(androidDevicesCmd)
+}
+
+func runAndroidDevices(cmd *cobra.Command, args []string) error {
+	client := adb.NewClient()
+
+	devices, err := client.Devices()
+	if err != nil {
+		return err
+	}
+
+	if len(devices) == 0 {
+		ui.Muted("No Android devices connected")
+		return nil
+	}
+
+	for _, d := range devices {
+		line := fmt.Sprintf("%-24s %-12s", d.Serial, d.State)
+		if d.Model != "" {
+			line += " " + d.Model
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/adb"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	androidInstallProject string
+	androidInstallPackage string
+	androidInstallLaunch  bool
+	androidInstallLogcat  bool
+)
+
+var androidInstallCmd = &cobra.Command{
+	Use:   "install [apk|aab]",
+	Short: "Install a build on an Android device",
+	Long: `Install an APK or AAB onto a connected Android device.
+
+When no file is given, the newest .apk/.aab under the project's Build/ or
+Builds/ directory is used. AAB files are installed through bundletool
+(located via UNIFORGE_BUNDLETOOL_PATH or PATH).
+
+The package name used by --launch defaults to the Android application
+identifier in ProjectSettings.asset.
+
+Examples:
+  # Install the latest build of the current project
+  uniforge android install
+
+  # Install, launch and stream logs
+  uniforge android install --launch --logcat
+
+  # Install a specific file on a specific device
+  uniforge android install Builds/game.apk --device emulator-5554`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAndroidInstall,
+}
+
+func init() {
+	androidCmd.AddCommand(androidInstallCmd)
+
+	androidInstallCmd.Flags().StringVarP(&androidInstallProject, "project", "p", ".", "Unity project path")
+	androidInstallCmd.Flags().StringVar(&androidInstallPackage, "package", "", "Application package name (default: from ProjectSettings)")
+	androidInstallCmd.Flags().BoolVar(&androidInstallLaunch, "launch", false, "Launch the application after installing")
+	androidInstallCmd.Flags().BoolVar(&androidInstallLogcat, "logcat", false, "Stream logcat after installing")
+}
+
+func runAndroidInstall(cmd *cobra.Command, args []string) error {
+	client := adb.NewClient()
+	client.Serial = androidDevice
+
+	device, err := client.ResolveDevice()
+	if err != nil {
+		return err
+	}
+
+	var packagePath string
+	if len(args) > 0 {
+		packagePath = args[0]
+	} else {
+		packagePath, err = findLatestAndroidBuild(androidInstallProject)
+		if err != nil {
+			return err
+		}
+	}
+
+	ui.Info("Installing %s on %s...", filepath.Base(packagePath), device.Serial)
+	if err := client.Install(packagePath); err != nil {
+		return fmt.Errorf("failed to install: %w", err)
+	}
+	ui.Success("Installed %s", filepath.Base(packagePath))
+
+	if androidInstallLaunch {
+		packageName := androidInstallPackage
+		if packageName == "" {
+			settings, err := unity.LoadPlayerSettings(androidInstallProject)
+			if err != nil {
+				return fmt.Errorf("failed to determine package name (use --package): %w", err)
+			}
+			packageName = settings.ApplicationIdentifier("Android")
+		}
+
+		if androidInstallLogcat {
+			if err := client.ClearLogcat(); err != nil {
+				ui.Warn("Failed to clear logcat: %v", err)
+			}
+		}
+
+		ui.Info("Launching %s...", packageName)
+		if err := client.Launch(packageName); err != nil {
+			return fmt.Errorf("failed to launch: %w", err)
+		}
+	}
+
+	if androidInstallLogcat {
+		return streamLogcat(client)
+	}
+
+	return nil
+}
+
+// findLatestAndroidBuild returns the most recently modified .apk/.aab in the project's build directories
+func findLatestAndroidBuild(projectPath string) (string, error) {
+	var latest string
+	var latestTime int64
+
+	for _, dir := range []string{"Build", "Builds"} {
+		root := filepath.Join(projectPath, dir)
+		if _, err := os.Stat(root); err != nil {
+			continue
+		}
+
+		_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if ext != ".apk" && ext != ".aab" {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if info.ModTime().UnixNano() > latestTime {
+				latest = path
+				latestTime = info.ModTime().UnixNano()
+			}
+			return nil
+		})
+	}
+
+	if latest == "" {
+		return "", fmt.Errorf("no .apk or .aab found under %s (Build/ or Builds/)", projectPath)
+	}
+
+	ui.Debug("Found Android build", "path", latest)
+	return latest, nil
+}
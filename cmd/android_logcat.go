@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/neptaco/uniforge/pkg/adb"
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	androidLogcatClear bool
+	androidLogcatRaw   bool
+	androidLogcatTrace bool
+)
+
+var androidLogcatCmd = &cobra.Command{
+	Use:   "logcat",
+	Short: "Stream Unity logs from an Android device",
+	Long: `Stream Unity-related logcat output from a connected Android device.
+
+Only the Unity, CRASH, AndroidRuntime, DEBUG and libc tags are shown.
+Lines are colorized and filtered the same way as 'uniforge logs'.
+
+Examples:
+  # Stream logs from the only connected device
+  uniforge android logcat
+
+  # Clear the log buffer first
+  uniforge android logcat --clear
+
+  # Select a device when several are connected
+  uniforge android logcat --device emulator-5554`,
+	RunE: runAndroidLogcat,
+}
+
+func init() {
+	androidCmd.AddCommand(androidLogcatCmd)
+
+	androidLogcatCmd.Flags().BoolVarP(&androidLogcatClear, "clear", "c", false, "Clear the device log buffer before streaming")
+	androidLogcatCmd.Flags().BoolVar(&androidLogcatRaw, "raw", false, "Show raw output without colors or filtering")
+	androidLogcatCmd.Flags().BoolVar(&androidLogcatTrace, "trace", false, "Show project stack traces")
+}
+
+func runAndroidLogcat(cmd *cobra.Command, args []string) error {
+	client := adb.NewClient()
+	client.Serial = androidDevice
+
+	if _, err := client.ResolveDevice(); err != nil {
+		return err
+	}
+
+	if androidLogcatClear {
+		if err := client.ClearLogcat(); err != nil {
+			return fmt.Errorf("failed to clear logcat: %w", err)
+		}
+	}
+
+	return streamLogcat(client)
+}
+
+// streamLogcat prints logcat output through the log formatter until interrupted
+func streamLogcat(client *adb.Client) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var formatter *logger.Formatter
+	if !androidLogcatRaw {
+		formatter = logger.NewFormatter(
+			logger.WithNoColor(!ui.ColorEnabled()),
+			logger.WithHideStackTrace(true),
+			logger.WithHideAllStackTraces(!androidLogcatTrace),
+		)
+	}
+
+	fmt.Printf("Streaming logcat from %s (Ctrl+C to stop)\n\n", client.Serial)
+
+	return client.Logcat(ctx, func(entry adb.Entry) {
+		if formatter == nil {
+			fmt.Println(entry.Message)
+			return
+		}
+		if formatter.ShouldShow(entry.Message) {
+			fmt.Println(formatter.FormatLine(entry.Message))
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var assetCmd = &cobra.Command{
+	Use:   "asset",
+	Short: "Manage Unity assets",
+	Long:  `Commands for repairing and inspecting Unity assets.`,
+}
+
+func init() {
+	rootCmd.AddCommand(assetCmd)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	assetConflictsMerge         bool
+	assetConflictsEditorVersion string
+)
+
+var assetConflictsCmd = &cobra.Command{
+	Use:   "conflicts [project]",
+	Short: "Find merge conflicts in scenes, prefabs and assets",
+	Long: `Scan .unity, .prefab and .asset files for git conflict markers and report
+which objects and components each conflict touches.
+
+With --merge, conflicted files are resolved with UnityYAMLMerge (smart merge)
+from the project's editor, using the base/ours/theirs versions in the git
+index. Files that UnityYAMLMerge cannot resolve are left untouched.
+
+Exits with an error while any conflicts remain.
+
+Examples:
+  # Report conflicts in the current project
+  uniforge asset conflicts
+
+  # Try to resolve them with UnityYAMLMerge
+  uniforge asset conflicts --merge
+
+  # Use UnityYAMLMerge from a specific installed editor
+  uniforge asset conflicts --merge --editor-version 6000.0.30f1`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runAssetConflicts,
+	SilenceUsage: true,
+}
+
+func init() {
+	assetCmd.AddCommand(assetConflictsCmd)
+
+	assetConflictsCmd.Flags().BoolVar(&assetConflictsMerge, "merge", false, "Resolve conflicts with UnityYAMLMerge when available")
+	assetConflictsCmd.Flags().StringVar(&assetConflictsEditorVersion, "editor-version", "", "Editor whose UnityYAMLMerge to use (default: project's version)")
+}
+
+func runAssetConflicts(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+
+	project, err := unity.LoadProject(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	conflicts, err := ui.WithSpinner("Scanning for conflicts...", func() ([]unity.FileConflicts, error) {
+		return unity.FindYAMLConflicts(project.Path)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) == 0 {
+		ui.Success("No merge conflicts found")
+		return nil
+	}
+
+	if assetConflictsMerge {
+		conflicts = smartMergeConflicts(project, conflicts)
+		if len(conflicts) == 0 {
+			ui.Success("All conflicts resolved. Review the result and stage the files with git add")
+			return nil
+		}
+	}
+
+	ui.Error("Merge conflicts in %d files:", len(conflicts))
+	for _, file := range conflicts {
+		fmt.Printf("  %s\n", file.Path)
+		for _, hunk := range file.Hunks {
+			fmt.Printf("    lines %d-%d:\n", hunk.StartLine, hunk.EndLine)
+			if len(hunk.Objects) == 0 {
+				fmt.Printf("      (file header)\n")
+			}
+			for _, obj := range hunk.Objects {
+				fmt.Printf("      - %s\n", obj)
+			}
+		}
+	}
+	fmt.Println()
+
+	return fmt.Errorf("%d files still have merge conflicts", len(conflicts))
+}
+
+// smartMergeConflicts runs UnityYAMLMerge on each conflicted file and returns the files it could not resolve
+func smartMergeConflicts(project *unity.Project, conflicts []unity.FileConflicts) []unity.FileConflicts {
+	version := project.UnityVersion
+	if assetConflictsEditorVersion != "" {
+		version = assetConflictsEditorVersion
+	}
+
+	yamlMerge, err := unity.NewEditor(version).YAMLMergePath()
+	if err != nil {
+		ui.Warn("Smart merge unavailable for Unity %s: %v", version, err)
+		return conflicts
+	}
+	ui.Debug("Using UnityYAMLMerge", "path", yamlMerge)
+
+	var remaining []unity.FileConflicts
+	for _, file := range conflicts {
+		if err := unity.SmartMerge(yamlMerge, project.Path, file.Path); err != nil {
+			ui.Warn("%v", err)
+			remaining = append(remaining, file)
+			continue
+		}
+		ui.Success("Resolved %s", filepath.ToSlash(file.Path))
+	}
+	return remaining
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	regenGUIDsDryRun bool
+	regenGUIDsForce  bool
+)
+
+var assetRegenGUIDsCmd = &cobra.Command{
+	Use:   "regen-guids <folder>",
+	Short: "Assign fresh GUIDs to all assets in a folder",
+	Long: `Generate new GUIDs for every .meta file under a folder and rewrite all
+references to the old GUIDs inside that folder.
+
+Use this after copying a subtree from another project (or duplicating it
+within the same project) when its GUIDs collide with existing assets, as
+reported under "Duplicate GUIDs" by 'uniforge meta check'.
+
+References between assets inside the folder are preserved. References from
+assets outside the folder keep pointing to the original GUIDs.
+
+Close the Unity Editor before running this command.
+
+Examples:
+  # Show which files would be rewritten
+  uniforge asset regen-guids Assets/ImportedKit --dry-run
+
+  # Regenerate GUIDs (with confirmation)
+  uniforge asset regen-guids Assets/ImportedKit
+
+  # Without confirmation (for CI)
+  uniforge asset regen-guids Assets/ImportedKit --force`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runAssetRegenGUIDs,
+	SilenceUsage: true,
+}
+
+func init() {
+	assetCmd.AddCommand(assetRegenGUIDsCmd)
+
+	assetRegenGUIDsCmd.Flags().BoolVar(&regenGUIDsDryRun, "dry-run", false, "Show what would change without writing files")
+	assetRegenGUIDsCmd.Flags().BoolVar(&regenGUIDsForce, "force", false, "Skip confirmation (same as --yes)")
+}
+
+func runAssetRegenGUIDs(cmd *cobra.Command, args []string) error {
+	folder := args[0]
+
+	// Always scan first so the user can confirm the impact
+	preview, err := ui.WithSpinner("Scanning folder...", func() (*unity.GUIDRemapResult, error) {
+		return unity.RegenerateGUIDs(folder, true)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan folder: %w", err)
+	}
+
+	if len(preview.Remapped) == 0 {
+		ui.Info("No .meta files found in: %s", folder)
+		return nil
+	}
+
+	ui.Info("%d GUIDs will be regenerated, %d files rewritten:", len(preview.Remapped), len(preview.RewrittenFiles))
+	for _, file := range preview.RewrittenFiles {
+		fmt.Printf("  %s\n", file)
+	}
+	fmt.Println()
+
+	if regenGUIDsDryRun {
+		ui.Muted("Dry run. No files were changed.")
+		return nil
+	}
+
+	if !regenGUIDsForce {
+		ok, err := ui.Confirm("Regenerate GUIDs for these assets?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			ui.Muted("Skipped. No files were changed.")
+			return nil
+		}
+	}
+
+	result, err := ui.WithSpinner("Rewriting GUIDs...", func() (*unity.GUIDRemapResult, error) {
+		return unity.RegenerateGUIDs(folder, false)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to regenerate GUIDs: %w", err)
+	}
+
+	ui.Success("Regenerated %d GUIDs in %d files", len(result.Remapped), len(result.RewrittenFiles))
+	return nil
+}
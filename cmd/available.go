@@ -6,10 +6,11 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
 	"github.com/mattn/go-isatty"
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/spf13/cobra"
@@ -22,6 +23,8 @@ var (
 	availInstalledStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
 	availStreamStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
 	availArchStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	supportEOLStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	supportSoonStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 )
 
 var (
@@ -33,8 +36,25 @@ var (
 	availableMajor        string
 	availableLatest       bool
 	availableCount        bool
+	availableSince        string
+	availableUntil        string
+	availableSecurity     bool
+	availableRecommended  bool
+	availableFilter       string
+	availableInteractive  bool
+	availableArchive      bool
+	availableColumns      []string
 )
 
+// availableTableColumns are the columns of the table format
+var availableTableColumns = []ui.Column{
+	{Name: "version", Header: "VERSION"},
+	{Name: "stream", Header: "STREAM"},
+	{Name: "installed", Header: "INSTALLED"},
+	{Name: "arch", Header: "ARCH"},
+	{Name: "support", Header: "SUPPORT"},
+}
+
 var editorAvailableCmd = &cobra.Command{
 	Use:   "available",
 	Short: "List available Unity Editor versions for installation",
@@ -47,6 +67,9 @@ Examples:
   # JSON format for scripting
   uniforge editor available --format json
 
+  # JSON Lines (one release per line)
+  uniforge editor available --format jsonl | head -n 5
+
   # LTS versions only
   uniforge editor available --lts
 
@@ -57,7 +80,42 @@ Examples:
   uniforge editor available --latest
 
   # Show only not installed versions
-  uniforge editor available --not-installed`,
+  uniforge editor available --not-installed
+
+  # Releases published in a date range
+  uniforge editor available --since 2024-01-01 --until 2024-06-30
+
+  # Releases with a security alert
+  uniforge editor available --security
+
+  # Recommended version of each stream
+  uniforge editor available --recommended
+
+  # Include old versions only listed in the download archive
+  uniforge editor available --include-archive --major 2018
+
+  # Browse the LTS releases and install one with Enter
+  uniforge editor available --lts --interactive
+
+  # Only some columns of the table
+  uniforge editor available --lts --columns version,support
+
+  # Combine conditions with an expression
+  uniforge editor available --filter "stream==LTS && !installed && version>=2022.3.40"
+  uniforge editor available --filter "(major==2022 || major==6000) && date>=2024-06-01"
+
+Filter fields: version, stream, lts, installed, recommended, security, major,
+minor, date (YYYY-MM-DD), architecture, changeset. Operators: == != < <= > >=
+and =~ (regular expression), combined with &&, || and !. Partial versions
+compare only the parts given, so "version==2022.3" matches every 2022.3 release.
+
+Table columns (--columns): version, stream, installed, arch, support. Tables
+taller than the terminal are shown in a pager (see 'uniforge --help').
+
+Every format is printed once the whole release list is loaded and sorted by
+release date: Unity's API answers with all streams in one response, so there
+is nothing to print earlier. jsonl only changes the format, to one JSON
+object per release.`,
 	Aliases: []string{"avail"},
 	RunE:    runAvailable,
 }
@@ -65,7 +123,7 @@ Examples:
 func init() {
 	editorCmd.AddCommand(editorAvailableCmd)
 
-	editorAvailableCmd.Flags().StringVar(&availableFormat, "format", "", "Output format: table, json, tsv (auto-detected if not specified)")
+	editorAvailableCmd.Flags().StringVar(&availableFormat, "format", "", "Output format: table, json, jsonl, tsv (auto-detected if not specified)")
 	editorAvailableCmd.Flags().BoolVar(&availableLTS, "lts", false, "Show only LTS versions")
 	editorAvailableCmd.Flags().StringVar(&availableStream, "stream", "", "Filter by stream: LTS, TECH, BETA, ALPHA")
 	editorAvailableCmd.Flags().BoolVar(&availableInstalled, "installed", false, "Show only installed versions")
@@ -73,13 +131,48 @@ func init() {
 	editorAvailableCmd.Flags().StringVar(&availableMajor, "major", "", "Filter by major version (e.g., 6000, 2022)")
 	editorAvailableCmd.Flags().BoolVar(&availableLatest, "latest", false, "Show only latest version per major version")
 	editorAvailableCmd.Flags().BoolVar(&availableCount, "count", false, "Show only count of matching versions")
+	editorAvailableCmd.Flags().StringVar(&availableSince, "since", "", "Show releases published on or after date (YYYY-MM-DD)")
+	editorAvailableCmd.Flags().StringVar(&availableUntil, "until", "", "Show releases published on or before date (YYYY-MM-DD)")
+	editorAvailableCmd.Flags().BoolVar(&availableSecurity, "security", false, "Show only releases with a security alert")
+	editorAvailableCmd.Flags().BoolVar(&availableRecommended, "recommended", false, "Show only recommended releases")
+	editorAvailableCmd.Flags().BoolVarP(&availableInteractive, "interactive", "i", false, "Browse the matching releases and install one")
+	editorAvailableCmd.Flags().BoolVar(&availableArchive, "include-archive", false, "Add old versions from the Unity download archive that the release API omits")
+	editorAvailableCmd.Flags().StringSliceVar(&availableColumns, "columns", nil, "Table columns to show, in order (e.g. version,support)")
+	editorAvailableCmd.Flags().StringVar(&availableFilter, "filter", "", "Show only releases matching an expression (e.g. \"stream==LTS && !installed\")")
 }
 
 func runAvailable(cmd *cobra.Command, args []string) error {
 	ui.Debug("Fetching available Unity Editor versions")
 
+	if availableInteractive {
+		if availableFormat != "" || availableCount {
+			return errs.New(errs.Usage, "--interactive cannot be combined with --format or --count")
+		}
+		if !ui.CanPrompt() {
+			return errs.New(errs.InputRequired, "--interactive needs a terminal without --no-input")
+		}
+	}
+
+	// Check --columns and parse --filter before fetching so mistakes fail fast
+	if len(availableColumns) > 0 {
+		if availableFormat != "" && availableFormat != "table" {
+			return errs.New(errs.Usage, "--columns only applies to the table format")
+		}
+		if err := (&ui.Table{Columns: availableTableColumns}).Select(availableColumns); err != nil {
+			return err
+		}
+	}
+	var filter *hub.ReleaseFilter
+	if availableFilter != "" {
+		var err error
+		if filter, err = hub.ParseReleaseFilter(availableFilter); err != nil {
+			return err
+		}
+	}
+
 	hubClient := hub.NewClient()
 	hubClient.NoCache = viper.GetBool("no-cache")
+	hubClient.IncludeArchive = availableArchive
 
 	releases, err := fetchReleasesWithCache(hubClient)
 	if err != nil {
@@ -87,7 +180,10 @@ func runAvailable(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply filters
-	releases = filterReleases(releases)
+	releases, err = filterReleases(releases, filter)
+	if err != nil {
+		return err
+	}
 
 	// Apply --latest filter (after other filters)
 	if availableLatest {
@@ -100,13 +196,21 @@ func runAvailable(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if len(releases) == 0 {
+	// JSON output stays parseable when nothing matches
+	if len(releases) == 0 && availableFormat != "json" && availableFormat != "jsonl" {
 		ui.Info("No Unity Editor releases found")
 		return nil
 	}
 
+	if availableInteractive {
+		return hub.RunEditorBrowseTUI(hubClient, releases)
+	}
+
 	// Determine format
 	format := availableFormat
+	if format == "" && len(availableColumns) > 0 {
+		format = "table"
+	}
 	if format == "" {
 		if isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
 			format = "table"
@@ -117,11 +221,13 @@ func runAvailable(cmd *cobra.Command, args []string) error {
 
 	switch format {
 	case "json":
-		return printAvailableJSON(releases)
+		return printAvailableJSON(releases, hubClient.SupportWindows())
+	case "jsonl":
+		return printAvailableJSONL(releases, hubClient.SupportWindows())
 	case "tsv":
 		return printAvailableTSV(releases)
 	case "table":
-		return printAvailableTable(releases)
+		return printAvailableTable(releases, hubClient.SupportWindows())
 	default:
 		return fmt.Errorf("unknown format: %s", format)
 	}
@@ -137,6 +243,9 @@ func fetchReleasesWithCache(client *hub.Client) ([]hub.UnityRelease, error) {
 			if streamErr == nil && client.CheckCacheValidity(cache, currentStreams) {
 				ui.Debug("Using cached releases")
 				releases := client.ConvertCacheToReleases(cache)
+				if client.IncludeArchive {
+					releases = client.MergeArchiveReleases(releases)
+				}
 				return client.EnrichReleasesWithInstallStatus(releases), nil
 			}
 		}
@@ -159,7 +268,20 @@ func fetchReleasesWithCache(client *hub.Client) ([]hub.UnityRelease, error) {
 	return releases, nil
 }
 
-func filterReleases(releases []hub.UnityRelease) []hub.UnityRelease {
+func filterReleases(releases []hub.UnityRelease, filter *hub.ReleaseFilter) ([]hub.UnityRelease, error) {
+	since, err := parseDateFlag("since", availableSince)
+	if err != nil {
+		return nil, err
+	}
+	until, err := parseDateFlag("until", availableUntil)
+	if err != nil {
+		return nil, err
+	}
+	if !until.IsZero() {
+		// Include the whole --until day
+		until = until.AddDate(0, 0, 1)
+	}
+
 	var filtered []hub.UnityRelease
 	for _, r := range releases {
 		// --lts filter
@@ -185,9 +307,40 @@ func filterReleases(releases []hub.UnityRelease) []hub.UnityRelease {
 				continue
 			}
 		}
+		// --security filter
+		if availableSecurity && r.SecurityAlert == "" {
+			continue
+		}
+		// --recommended filter
+		if availableRecommended && !r.Recommended {
+			continue
+		}
+		// --since / --until filters (releases without a date are excluded)
+		if !since.IsZero() && (r.ReleaseDate.IsZero() || r.ReleaseDate.Before(since)) {
+			continue
+		}
+		if !until.IsZero() && (r.ReleaseDate.IsZero() || !r.ReleaseDate.Before(until)) {
+			continue
+		}
+		// --filter expression
+		if filter != nil && !filter.Match(r) {
+			continue
+		}
 		filtered = append(filtered, r)
 	}
-	return filtered
+	return filtered, nil
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag value (empty returns zero time)
+func parseDateFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s date %q (expected YYYY-MM-DD)", name, value)
+	}
+	return t, nil
 }
 
 func latestPerMajor(releases []hub.UnityRelease) []hub.UnityRelease {
@@ -231,26 +384,60 @@ func compareVersionStrings(a, b string) int {
 	return len(aParts) - len(bParts)
 }
 
-func printAvailableJSON(releases []hub.UnityRelease) error {
-	type jsonRelease struct {
-		Version      string `json:"version"`
-		Changeset    string `json:"changeset,omitempty"`
-		Stream       string `json:"stream"`
-		LTS          bool   `json:"lts"`
-		Installed    bool   `json:"installed"`
-		Architecture string `json:"architecture,omitempty"`
+// availableJSONRelease is the JSON representation of a release
+type availableJSONRelease struct {
+	Version      string `json:"version"`
+	Changeset    string `json:"changeset,omitempty"`
+	Stream       string `json:"stream"`
+	LTS          bool   `json:"lts"`
+	Installed    bool   `json:"installed"`
+	Architecture string `json:"architecture,omitempty"`
+	ReleaseDate  string `json:"releaseDate,omitempty"`
+	Recommended  bool   `json:"recommended,omitempty"`
+	Security     string `json:"securityAlert,omitempty"`
+	EOL          string `json:"eol,omitempty"` // End of support of the stream
+}
+
+func toAvailableJSON(r hub.UnityRelease, support hub.SupportWindows) availableJSONRelease {
+	var eol string
+	if window, ok := support.For(r.Version); ok {
+		eol = formatReleaseDate(window.EOL)
+	}
+	return availableJSONRelease{
+		Version:      r.Version,
+		Changeset:    r.Changeset,
+		Stream:       r.Stream,
+		LTS:          r.LTS,
+		Installed:    r.Installed,
+		Architecture: r.Architecture,
+		ReleaseDate:  formatReleaseDate(r.ReleaseDate),
+		Recommended:  r.Recommended,
+		Security:     r.SecurityAlert,
+		EOL:          eol,
 	}
+}
 
-	var output []jsonRelease
+// supportBadgeStyle colors an end of support badge by urgency
+func supportBadgeStyle(badge string) lipgloss.Style {
+	if badge == "EOL" {
+		return supportEOLStyle
+	}
+	return supportSoonStyle
+}
+
+func formatReleaseDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// printAvailableJSON writes the releases as an indented JSON array, [] when
+// there are none
+func printAvailableJSON(releases []hub.UnityRelease, support hub.SupportWindows) error {
+	output := make([]availableJSONRelease, 0, len(releases))
 	for _, r := range releases {
-		output = append(output, jsonRelease{
-			Version:      r.Version,
-			Changeset:    r.Changeset,
-			Stream:       r.Stream,
-			LTS:          r.LTS,
-			Installed:    r.Installed,
-			Architecture: r.Architecture,
-		})
+		output = append(output, toAvailableJSON(r, support))
 	}
 
 	encoder := json.NewEncoder(os.Stdout)
@@ -258,6 +445,18 @@ func printAvailableJSON(releases []hub.UnityRelease) error {
 	return encoder.Encode(output)
 }
 
+// printAvailableJSONL writes one JSON object per line
+func printAvailableJSONL(releases []hub.UnityRelease, support hub.SupportWindows) error {
+	encoder := json.NewEncoder(os.Stdout)
+	for _, r := range releases {
+		if err := encoder.Encode(toAvailableJSON(r, support)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printAvailableTSV writes one tab-separated row per release
 func printAvailableTSV(releases []hub.UnityRelease) error {
 	for _, r := range releases {
 		installed := "no"
@@ -268,12 +467,15 @@ func printAvailableTSV(releases []hub.UnityRelease) error {
 		if r.LTS {
 			lts = "LTS"
 		}
-		fmt.Printf("%s\t%s\t%s\t%s\t%s\n", r.Version, r.Stream, lts, installed, r.Changeset)
+		if _, err := fmt.Printf("%s\t%s\t%s\t%s\t%s\n", r.Version, r.Stream, lts, installed, r.Changeset); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func printAvailableTable(releases []hub.UnityRelease) error {
+func printAvailableTable(releases []hub.UnityRelease, support hub.SupportWindows) error {
+	now := time.Now()
 	rows := make([][]string, 0, len(releases))
 	for _, r := range releases {
 		stream := r.Stream
@@ -284,17 +486,14 @@ func printAvailableTable(releases []hub.UnityRelease) error {
 		if r.Installed {
 			installed = "✓"
 		}
-		rows = append(rows, []string{r.Version, stream, installed, r.Architecture})
+		rows = append(rows, []string{r.Version, stream, installed, r.Architecture, support.Badge(r.Version, now)})
 	}
 
-	t := table.New().
-		Headers("VERSION", "STREAM", "INSTALLED", "ARCH").
-		Rows(rows...).
-		Border(lipgloss.HiddenBorder()).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			if row == table.HeaderRow {
-				return headerStyle
-			}
+	t := &ui.Table{
+		Columns:     availableTableColumns,
+		Rows:        rows,
+		HeaderStyle: headerStyle,
+		Style: func(row, col int) lipgloss.Style {
 			switch col {
 			case 0:
 				return availVersionStyle
@@ -307,10 +506,14 @@ func printAvailableTable(releases []hub.UnityRelease) error {
 				return availInstalledStyle
 			case 3:
 				return availArchStyle
+			case 4:
+				return supportBadgeStyle(rows[row][col])
 			}
 			return lipgloss.NewStyle()
-		})
-
-	fmt.Println(t)
-	return nil
+		},
+	}
+	if err := t.Select(availableColumns); err != nil {
+		return err
+	}
+	return t.Print()
 }
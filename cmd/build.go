@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/neptaco/uniforge/pkg/upload"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildMatrix   bool
+	buildTargets  []string
+	buildParallel bool
+	buildOutput   string
+	buildNoUpload bool
+	buildAll      bool
+	buildNoCheck  bool
+	buildCIMode   bool
+	buildNoise    []string
+
+	buildPassStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	buildFailStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build [project]",
+	Short: "Build targets defined in the project's build matrix",
+	Long: `Build the targets defined in <project>/.uniforge/build.yaml.
+
+Each target runs Unity in batch mode with -buildTarget, -executeMethod and
+-customBuildPath <output>/<name>, so the build method knows where to put its
+artifacts. The Unity log of each target is saved to <output>/<name>/build.log
+and a summary is written to <output>/summary.json. C# compiler errors and
+warnings are printed after each target, grouped by file with repeats counted
+once, and saved to <output>/<name>/diagnostics.json. Outside --ci, noise
+(licensing, package manager, ...) is suppressed and counted; --show-noise
+shows a category again.
+
+Targets are built one after another. With parallel: true (or --parallel),
+targets using different editor versions build at the same time; since Unity
+locks an open project, extra lanes build from a copy kept in <output>/.work.
+
+build.yaml:
+  method: BuildScript.Build        # default build method
+  output: Builds                   # relative to the project (default: Builds)
+  parallel: false
+  timeout: 3600                    # per target, in seconds
+  targets:
+    - name: android
+      target: Android
+    - name: windows
+      target: StandaloneWindows64
+      args: [-development]
+    - name: webgl-2023
+      target: WebGL
+      editor: "2023.2"             # any version spec, default: project version
+      method: BuildScript.BuildWebGL
+
+Artifacts of successful targets can be uploaded to a bucket, together with
+summary.json. Set upload: at the top level for every target, or per target:
+
+  upload:
+    provider: s3                   # s3 (or S3-compatible with endpoint:) or gcs
+    bucket: my-builds
+    prefix: "{project}/{timestamp}" # also {editor}; the target name is appended
+    endpoint: https://minio.example.com
+    region: eu-west-1
+
+S3 credentials come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+AWS_SESSION_TOKEN. GCS uses GOOGLE_OAUTH_ACCESS_TOKEN, the key file named by
+GOOGLE_APPLICATION_CREDENTIALS, or 'gcloud auth application-default login'.
+
+Before building, every target is checked for what its player needs on this
+machine: the editor and its platform module, and for IL2CPP or Burst the
+native toolchain (Visual Studio C++ tools, Xcode Command Line Tools, or the
+Android NDK release the editor expects). Missing prerequisites are listed and
+nothing is built; --skip-preflight builds anyway.
+
+Examples:
+  # Build every target
+  uniforge build --matrix
+
+  # Build selected targets
+  uniforge build --target android --target windows
+
+  # Build different editor versions in parallel
+  uniforge build /path/to/project --matrix --parallel
+
+  # Build locally without uploading
+  uniforge build --matrix --no-upload
+
+  # Build on a CI runner, with annotations and noise in collapsible groups
+  uniforge build --matrix --ci
+
+  # Build every project of the workspace (.uniforge/workspace.yaml); projects
+  # without a build.yaml are skipped, and -o gets a folder per project
+  uniforge build --all --matrix -o dist`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runBuild,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+
+	buildCmd.Flags().BoolVar(&buildMatrix, "matrix", false, "Build every target in the build matrix")
+	buildCmd.Flags().StringSliceVar(&buildTargets, "target", nil, "Name of a matrix target to build (repeatable)")
+	buildCmd.Flags().BoolVar(&buildParallel, "parallel", false, "Build targets for different editor versions in parallel")
+	buildCmd.Flags().StringVarP(&buildOutput, "output", "o", "", "Output directory (overrides build.yaml)")
+	buildCmd.Flags().BoolVar(&buildNoUpload, "no-upload", false, "Do not upload artifacts to the upload destinations of build.yaml")
+	buildCmd.Flags().BoolVar(&buildNoCheck, "skip-preflight", false, "Do not check editors, modules and native toolchains before building")
+	buildCmd.Flags().BoolVar(&buildCIMode, "ci", false, "CI mode (optimized output format)")
+	buildCmd.Flags().StringSliceVar(&buildNoise, "show-noise", nil, showNoiseUsage)
+	addAllFlag(buildCmd, &buildAll)
+}
+
+func runBuild(cmd *cobra.Command, args []string) error {
+	if !buildMatrix && len(buildTargets) == 0 {
+		return fmt.Errorf("specify --matrix to build every target, or --target to select some")
+	}
+	showNoise, err := logger.ParseNoiseCategories(buildNoise)
+	if err != nil {
+		return err
+	}
+
+	if buildAll {
+		return runInWorkspace(args, func(projectPath, name string) error {
+			if !fileExistsAt(unity.BuildConfigPath(projectPath)) {
+				ui.Muted("No %s; skipped", filepath.Join(unity.ProjectConfigDir, unity.BuildConfigFile))
+				return nil
+			}
+			output := buildOutput
+			if output != "" {
+				output = filepath.Join(output, filepath.FromSlash(name))
+			}
+			return buildProject(cmd, projectPath, output, showNoise)
+		})
+	}
+
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	return buildProject(cmd, projectPath, buildOutput, showNoise)
+}
+
+// buildProject builds the selected targets of one project. output overrides
+// the output directory of build.yaml when set.
+func buildProject(cmd *cobra.Command, projectPath, output string, showNoise []logger.NoiseCategory) error {
+	project, err := unity.LoadProject(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	config, err := unity.LoadBuildConfig(project.Path)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("parallel") {
+		config.Parallel = buildParallel
+	}
+
+	targets, err := config.Select(buildTargets)
+	if err != nil {
+		return err
+	}
+	jobs, err := resolveBuildJobs(project, targets)
+	if err != nil {
+		return err
+	}
+
+	// A missing toolchain fails an IL2CPP build only after many minutes
+	if !buildNoCheck {
+		if err := preflightBuildJobs(project, jobs); err != nil {
+			return err
+		}
+	}
+
+	// Check upload credentials before spending time on builds
+	var uploaders map[string]upload.Uploader
+	if !buildNoUpload {
+		if uploaders, err = newBuildUploaders(config, targets); err != nil {
+			return err
+		}
+	}
+
+	outputDir := config.Output
+	if output != "" {
+		outputDir = output
+	}
+	if !filepath.IsAbs(outputDir) {
+		if output != "" {
+			outputDir, err = filepath.Abs(outputDir)
+			if err != nil {
+				return fmt.Errorf("failed to get absolute path: %w", err)
+			}
+		} else {
+			outputDir = filepath.Join(project.Path, outputDir)
+		}
+	}
+
+	mode := "sequentially"
+	if config.Parallel {
+		mode = "in parallel by editor version"
+	}
+	ui.Info("Building %d target(s) of %s %s", len(jobs), project.Name, mode)
+	ui.Muted("Output: %s", outputDir)
+
+	matrix := &unity.BuildMatrix{
+		Project:   project,
+		Config:    config,
+		OutputDir: outputDir,
+		CIMode:    buildCIMode,
+		ShowNoise: showNoise,
+		OnStart: func(job unity.BuildJob) {
+			ui.Info("▶ %s (%s, Unity %s)", job.Target.Name, job.Target.Target, job.Version)
+		},
+		OnFinish: func(result unity.BuildResult) {
+			if result.Succeeded() {
+				ui.Success("%s finished in %s", result.Name, result.Duration.Round(time.Second))
+			} else {
+				ui.Error("%s failed: %s", result.Name, result.Error)
+			}
+		},
+	}
+
+	start := time.Now()
+	results := matrix.Run(jobs)
+
+	failed := 0
+	for _, r := range results {
+		if !r.Succeeded() {
+			failed++
+		}
+	}
+	var buildErr error
+	if failed > 0 {
+		buildErr = fmt.Errorf("%d of %d builds failed", failed, len(results))
+	}
+
+	fmt.Println()
+	printBuildSummary(results)
+
+	// Upload URIs are part of the summary, so plan them before writing it
+	prefixes := make(map[string]string)
+	for i, r := range results {
+		u := uploaders[r.Name]
+		if u == nil || !r.Succeeded() {
+			continue
+		}
+		prefix := expandUploadPrefix(config.UploadFor(jobs[i].Target).Prefix, project, r.Version, start)
+		prefixes[r.Name] = upload.Key(prefix, r.Name)
+		results[i].Upload = u.URI(prefixes[r.Name] + "/")
+	}
+
+	summaryPath := filepath.Join(outputDir, "summary.json")
+	if data, err := json.MarshalIndent(results, "", "  "); err == nil {
+		if err := fsutil.WriteFileAtomic(summaryPath, data, 0644); err != nil {
+			ui.Warn("Failed to write build summary: %v", err)
+		}
+	}
+
+	if len(prefixes) > 0 {
+		if err := uploadBuildArtifacts(results, uploaders, prefixes, summaryPath); err != nil && buildErr == nil {
+			buildErr = err
+		}
+	}
+	notifyHook(hooks.EventBuild, project.Name, start, buildErr)
+
+	return buildErr
+}
+
+// preflightBuildJobs prints the prerequisites each job lacks on this machine and
+// fails when one of them would make a build fail
+func preflightBuildJobs(project *unity.Project, jobs []unity.BuildJob) error {
+	missing := 0
+	for _, job := range jobs {
+		for _, issue := range unity.CheckToolchain(unity.NewToolchainTarget(project.Path, job.Target.Target, job.Version)) {
+			if issue.Warning {
+				ui.Warn("%s: %s", job.Target.Name, issue.Problem)
+			} else {
+				ui.Error("%s: %s", job.Target.Name, issue.Problem)
+				missing++
+			}
+			ui.Muted("    → %s", issue.Remedy)
+		}
+	}
+	if missing > 0 {
+		return errs.WithHint(errs.New(errs.NotInstalled, "%d build prerequisite(s) missing", missing),
+			"Install what is listed above, or pass --skip-preflight to build anyway.")
+	}
+	return nil
+}
+
+// newBuildUploaders returns the uploader of every target with an upload
+// destination, by target name
+func newBuildUploaders(config *unity.BuildConfig, targets []unity.BuildTarget) (map[string]upload.Uploader, error) {
+	uploaders := make(map[string]upload.Uploader)
+	shared := make(map[upload.Config]upload.Uploader)
+	for _, t := range targets {
+		dest := config.UploadFor(t)
+		if dest == nil {
+			continue
+		}
+		// Targets sharing a destination share credentials and tokens
+		key := *dest
+		key.Prefix = ""
+		u, ok := shared[key]
+		if !ok {
+			var err error
+			if u, err = upload.New(key); err != nil {
+				return nil, fmt.Errorf("target %s: %w", t.Name, err)
+			}
+			shared[key] = u
+		}
+		uploaders[t.Name] = u
+	}
+	return uploaders, nil
+}
+
+// expandUploadPrefix fills in the placeholders of an upload prefix
+func expandUploadPrefix(prefix string, project *unity.Project, version string, start time.Time) string {
+	return strings.NewReplacer(
+		"{project}", project.Name,
+		"{editor}", version,
+		"{timestamp}", start.UTC().Format("20060102-150405"),
+	).Replace(prefix)
+}
+
+// uploadBuildArtifacts uploads the output of every planned target, and the
+// summary once per destination next to the target folders
+func uploadBuildArtifacts(results []unity.BuildResult, uploaders map[string]upload.Uploader, prefixes map[string]string, summaryPath string) error {
+	ctx := context.Background()
+	failed := 0
+	summaries := make(map[string]bool)
+
+	fmt.Println()
+	for _, r := range results {
+		prefix, ok := prefixes[r.Name]
+		if !ok {
+			continue
+		}
+		u := uploaders[r.Name]
+
+		progress := ui.NewProgress("Uploading "+r.Name, 0, ui.WithBytes())
+		objects, err := upload.Dir(ctx, u, r.OutputDir, prefix, func(o upload.Object) { progress.Add(o.Size) })
+		progress.Done(err)
+		if err != nil {
+			ui.Error("%s: %v", r.Name, err)
+			failed++
+			continue
+		}
+		ui.Success("Uploaded %s (%d files) to %s", r.Name, len(objects), r.Upload)
+
+		summaryKey := upload.Key(path.Dir(prefix), "summary.json")
+		if summaries[u.URI(summaryKey)] {
+			continue
+		}
+		summaries[u.URI(summaryKey)] = true
+		if _, err := upload.File(ctx, u, summaryPath, summaryKey); err != nil {
+			ui.Error("%v", err)
+			failed++
+			continue
+		}
+		ui.Muted("Report: %s", u.URL(summaryKey))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d upload(s) failed", failed)
+	}
+	return nil
+}
+
+// resolveBuildJobs resolves the editor version of every target
+func resolveBuildJobs(project *unity.Project, targets []unity.BuildTarget) ([]unity.BuildJob, error) {
+	var hubClient *hub.Client
+	jobs := make([]unity.BuildJob, 0, len(targets))
+	for _, t := range targets {
+		version := project.UnityVersion
+		if t.Editor != "" {
+			if hubClient == nil {
+				hubClient = hub.NewClient()
+			}
+			resolved, err := hubClient.ResolveVersionSpec(t.Editor, false)
+			if err != nil {
+				return nil, fmt.Errorf("target %s: %w", t.Name, err)
+			}
+			version = resolved
+		}
+		jobs = append(jobs, unity.BuildJob{Target: t, Version: version})
+	}
+	return jobs, nil
+}
+
+// printBuildSummary prints the consolidated pass/fail table
+func printBuildSummary(results []unity.BuildResult) {
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status := "PASS"
+		if !r.Succeeded() {
+			status = "FAIL"
+		}
+		rows = append(rows, []string{r.Name, r.Target, r.Version, status, r.Duration.Round(time.Second).String(), r.LogFile})
+	}
+
+	t := table.New().
+		Headers("NAME", "TARGET", "EDITOR", "STATUS", "DURATION", "LOG").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			switch col {
+			case 2:
+				return versionStyle
+			case 3:
+				if rows[row][col] == "PASS" {
+					return buildPassStyle
+				}
+				return buildFailStyle
+			case 5:
+				return pathStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+}
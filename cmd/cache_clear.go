@@ -12,7 +12,7 @@ var cacheClearCmd = &cobra.Command{
 	Short: "Clear the release cache",
 	Long: `Clear the cached Unity release information.
 
-This removes the local cache file that stores Unity release data.
+This removes the local cache files that store Unity release data.
 The cache will be rebuilt on the next command that fetches release information.`,
 	RunE: runCacheClear,
 }
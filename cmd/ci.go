@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Helpers for CI pipelines",
+	Long:  `Commands that prepare CI jobs for building Unity projects.`,
+}
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neptaco/uniforge/pkg/ci"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/license"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciEnvVersion   string
+	ciEnvFormat    string
+	ciEnvGitHubEnv bool
+)
+
+var ciEnvCmd = &cobra.Command{
+	Use:   "env [project]",
+	Short: "Print environment variables for a Unity build job",
+	Long: `Print the environment variables a containerized Unity build needs,
+so pipeline definitions don't hard-code machine paths.
+
+Exported variables:
+  UNITY_VERSION            Editor version (from the project or --editor-version)
+  UNITY_PATH               Editor executable for that version (an existing
+                           UNITY_PATH is kept, as set by editor container images)
+  UNITY_LICENSE_MODE       License the editor would use (serial, hub, server, build_server, none)
+  UNITY_LICENSE_FILE       Serial license file, when the mode is serial
+  UNITY_LICENSING_SERVER   Licensing server URL, when configured
+  UNITY_BUILD_SERVER       "true" when the licensing server is a Build Server
+  UPM_CACHE_ROOT           Unity Package Manager cache directory
+  UNITY_LIBRARY_PATH       The project's Library directory (worth caching between jobs)
+
+With --github-env the variables are appended to $GITHUB_ENV instead of printed,
+making them available to later steps of a GitHub Actions job.
+
+Examples:
+  # Print shell exports for the project in the current directory
+  uniforge ci env
+
+  # Load into the current shell
+  eval "$(uniforge ci env)"
+
+  # Use a specific editor version
+  uniforge ci env --editor-version 2022.3
+
+  # GitHub Actions
+  uniforge ci env --github-env
+
+  # PowerShell
+  uniforge ci env --format powershell | Invoke-Expression`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runCIEnv,
+	SilenceUsage: true,
+}
+
+func init() {
+	ciCmd.AddCommand(ciEnvCmd)
+
+	ciEnvCmd.Flags().StringVar(&ciEnvVersion, "editor-version", "", "Unity version to use instead of the project's (e.g. 2022.3.10f1, 2022.3, lts)")
+	ciEnvCmd.Flags().StringVar(&ciEnvFormat, "format", ci.FormatShell, "output format: shell, dotenv, powershell")
+	ciEnvCmd.Flags().BoolVar(&ciEnvGitHubEnv, "github-env", false, "Append to the file named by $GITHUB_ENV instead of printing")
+}
+
+func runCIEnv(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+
+	// The project is optional when a version is given explicitly
+	project, projectErr := unity.LoadProject(projectPath)
+
+	version := ""
+	switch {
+	case ciEnvVersion != "":
+		resolved, err := hub.NewClient().ResolveVersionSpec(ciEnvVersion, false)
+		if err != nil {
+			return err
+		}
+		version = resolved
+	case projectErr != nil:
+		return fmt.Errorf("failed to load project: %w (use --editor-version outside a project)", projectErr)
+	default:
+		version = project.UnityVersion
+	}
+
+	editorPath, err := unityPath(version)
+	if err != nil {
+		return err
+	}
+
+	vars := []ci.EnvVar{
+		{Name: "UNITY_VERSION", Value: version},
+		{Name: "UNITY_PATH", Value: editorPath},
+	}
+
+	status, err := license.GetStatus()
+	if err != nil {
+		return fmt.Errorf("failed to check license status: %w", err)
+	}
+	editorLicense := status.ForEditor(version)
+	vars = append(vars, ci.EnvVar{Name: "UNITY_LICENSE_MODE", Value: string(editorLicense.Mode)})
+	if editorLicense.Mode == license.LicenseTypeSerial {
+		vars = append(vars, ci.EnvVar{Name: "UNITY_LICENSE_FILE", Value: status.LicensePath})
+	}
+	if status.ServerURL != "" {
+		vars = append(vars, ci.EnvVar{Name: "UNITY_LICENSING_SERVER", Value: status.ServerURL})
+		if status.IsBuildServer {
+			vars = append(vars, ci.EnvVar{Name: "UNITY_BUILD_SERVER", Value: "true"})
+		}
+	}
+
+	vars = append(vars, ci.EnvVar{Name: "UPM_CACHE_ROOT", Value: unity.UPMCacheRoot()})
+	if project != nil {
+		vars = append(vars, ci.EnvVar{Name: "UNITY_LIBRARY_PATH", Value: filepath.Join(project.Path, "Library")})
+	}
+
+	if ciEnvGitHubEnv {
+		envFile := os.Getenv("GITHUB_ENV")
+		if envFile == "" {
+			return fmt.Errorf("GITHUB_ENV is not set (--github-env only works inside GitHub Actions)")
+		}
+		if err := ci.AppendGitHubEnv(envFile, vars); err != nil {
+			return err
+		}
+		ui.Success("Exported %d variables to $GITHUB_ENV", len(vars))
+		for _, w := range editorLicense.Warnings {
+			ui.Warn("%s", w)
+		}
+		return nil
+	}
+
+	out, err := ci.FormatEnv(vars, ciEnvFormat)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+
+	// Warnings go to stderr so that eval'd output stays clean
+	for _, w := range editorLicense.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	return nil
+}
+
+// unityPath returns the editor executable of version, or the one UNITY_PATH
+// names when it exists: container images often ship a single editor and point
+// UNITY_PATH at it
+func unityPath(version string) (string, error) {
+	if path := os.Getenv("UNITY_PATH"); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return unity.NewEditor(version).GetPath()
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/ci"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/git"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciGenerateProvider    string
+	ciGenerateTargets     []string
+	ciGenerateBuildMethod string
+	ciGenerateOutput      string
+	ciGenerateForce       bool
+)
+
+var ciGenerateCmd = &cobra.Command{
+	Use:   "generate [project]",
+	Short: "Generate a CI pipeline for a Unity project",
+	Long: `Generate a ready-to-run CI pipeline that uses uniforge to install the editor,
+activate a license, check .meta files, run EditMode tests and build every target.
+
+The Unity version is read from the project. Build targets come from --target,
+or are inferred from the modules installed for that version. The Library folder
+is cached per target. Jobs run on self-hosted runners labeled with their OS.
+
+License credentials are read from the UNITY_USERNAME, UNITY_PASSWORD and
+UNITY_SERIAL secrets (GitHub) or CI/CD variables (GitLab).
+
+Examples:
+  # Print a GitHub Actions workflow
+  uniforge ci generate
+
+  # Write it to the repository
+  uniforge ci generate -o .github/workflows/unity.yml
+
+  # GitLab CI with explicit targets
+  uniforge ci generate --provider gitlab --target Android --target iOS -o .gitlab-ci.yml
+
+  # Use a custom build method
+  uniforge ci generate --build-method MyCompany.Build.Run`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runCIGenerate,
+	SilenceUsage: true,
+}
+
+func init() {
+	ciCmd.AddCommand(ciGenerateCmd)
+
+	ciGenerateCmd.Flags().StringVar(&ciGenerateProvider, "provider", ci.ProviderGitHub, "CI provider: github, gitlab")
+	ciGenerateCmd.Flags().StringSliceVar(&ciGenerateTargets, "target", nil, "Build target or module to build (repeatable, e.g. Android, iOS, windows-il2cpp)")
+	ciGenerateCmd.Flags().StringVar(&ciGenerateBuildMethod, "build-method", "Build.Perform", "Static method that performs the build (-executeMethod)")
+	ciGenerateCmd.Flags().StringVarP(&ciGenerateOutput, "output", "o", "", "Write the pipeline to this file instead of stdout")
+	ciGenerateCmd.Flags().BoolVar(&ciGenerateForce, "force", false, "Overwrite the output file if it exists")
+}
+
+func runCIGenerate(cmd *cobra.Command, args []string) error {
+	startPath := "."
+	if len(args) > 0 {
+		startPath = args[0]
+	}
+
+	projectRoot, err := unity.FindProjectRoot(startPath)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	targets, err := ciGenerateResolveTargets(project.UnityVersion)
+	if err != nil {
+		return err
+	}
+
+	spec := ci.PipelineSpec{
+		ProjectPath:   ciProjectPathInRepo(project.Path),
+		UnityVersion:  project.UnityVersion,
+		Targets:       targets,
+		BuildMethod:   ciGenerateBuildMethod,
+		DefaultRunner: ciRunnerForHost(),
+	}
+	out, err := ci.Generate(ciGenerateProvider, spec)
+	if err != nil {
+		return err
+	}
+
+	if ciGenerateOutput == "" {
+		fmt.Print(out)
+		return nil
+	}
+
+	if _, err := os.Stat(ciGenerateOutput); err == nil && !ciGenerateForce {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", ciGenerateOutput)
+	}
+	if err := os.MkdirAll(filepath.Dir(ciGenerateOutput), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(ciGenerateOutput, []byte(out), 0644); err != nil {
+		return fmt.Errorf("failed to write pipeline: %w", err)
+	}
+
+	ui.Success("Wrote %s", ciGenerateOutput)
+	ui.Muted("Unity %s, project %s", spec.UnityVersion, spec.ProjectPath)
+	for _, t := range targets {
+		ui.Muted("  %s on %s", t.BuildTarget, t.RunnerOS)
+	}
+	return nil
+}
+
+// ciGenerateResolveTargets returns the --target targets, or the ones enabled by
+// the modules installed for version
+func ciGenerateResolveTargets(version string) ([]ci.Target, error) {
+	if len(ciGenerateTargets) > 0 {
+		var targets []ci.Target
+		for _, name := range ciGenerateTargets {
+			t, err := ci.LookupTarget(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, t)
+		}
+		return targets, nil
+	}
+
+	editors, err := hub.NewClient().ListInstalledEditors()
+	if err != nil {
+		ui.Debug("Failed to list installed editors", "error", err)
+		return nil, nil
+	}
+	for _, e := range editors {
+		if e.Version == version {
+			return ci.TargetsFromModules(e.InstalledModules), nil
+		}
+	}
+	ui.Debug("Editor not installed, generating without build targets", "version", version)
+	return nil, nil
+}
+
+// ciProjectPathInRepo returns the project path relative to its git repository root,
+// or "." when the project is not in a repository
+func ciProjectPathInRepo(projectPath string) string {
+	root, err := git.RepoRoot(projectPath)
+	if err != nil {
+		return "."
+	}
+	rel, err := filepath.Rel(root, projectPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "."
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ciRunnerForHost returns the runner OS label matching this machine
+func ciRunnerForHost() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return ci.RunnerMacOS
+	case "windows":
+		return ci.RunnerWindows
+	default:
+		return ci.RunnerLinux
+	}
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Change uniforge settings",
+	Long:  `Commands for changing settings in the config file ($HOME/.uniforge.yaml or --config).`,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
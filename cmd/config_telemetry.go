@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configTelemetryEndpoint string
+
+var configTelemetryCmd = &cobra.Command{
+	Use:   "telemetry [on|off]",
+	Short: "Turn reporting of command outcomes to your team's endpoint on or off",
+	Long: `Turn telemetry on or off, or show its status when no argument is given.
+
+Telemetry is off by default and nothing is ever sent to the uniforge authors.
+When on, uniforge posts one JSON event per command to the endpoint configured
+by your organization, so that failures across a fleet of machines can be
+tracked. An event holds the command (e.g. "uniforge editor install"), whether
+it succeeded, its exit code and error category, its duration, the OS and
+architecture, and the uniforge version. Arguments, paths, error messages, host
+and user names are never sent.
+
+The settings are telemetry and telemetry-endpoint in the config file, or
+UNIFORGE_TELEMETRY and UNIFORGE_TELEMETRY_ENDPOINT in the environment.
+
+Examples:
+  # Show whether telemetry is on
+  uniforge config telemetry
+
+  # Report to the team endpoint
+  uniforge config telemetry on --endpoint https://metrics.example.com/uniforge
+
+  # Stop reporting
+  uniforge config telemetry off`,
+	Args:         cobra.MaximumNArgs(1),
+	ValidArgs:    []string{"on", "off"},
+	RunE:         runConfigTelemetry,
+	SilenceUsage: true,
+}
+
+func init() {
+	configCmd.AddCommand(configTelemetryCmd)
+
+	configTelemetryCmd.Flags().StringVar(&configTelemetryEndpoint, "endpoint", "", "URL to post events to (required the first time telemetry is turned on)")
+}
+
+func runConfigTelemetry(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		if configTelemetryEndpoint != "" {
+			return errs.New(errs.Usage, "--endpoint is only used with 'on'")
+		}
+		printTelemetryStatus()
+		return nil
+	}
+
+	settings := make(map[string]any)
+	switch args[0] {
+	case "on":
+		endpoint := configTelemetryEndpoint
+		if endpoint == "" {
+			endpoint = viper.GetString("telemetry-endpoint")
+		}
+		if endpoint == "" {
+			return errs.WithHint(
+				errs.New(errs.Usage, "no telemetry endpoint configured"),
+				"pass the URL your organization collects events at with --endpoint",
+			)
+		}
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errs.New(errs.Usage, "invalid telemetry endpoint %q: expected an http or https URL", endpoint)
+		}
+		settings["telemetry"] = true
+		if configTelemetryEndpoint != "" {
+			settings["telemetry-endpoint"] = configTelemetryEndpoint
+		}
+		viper.Set("telemetry-endpoint", endpoint)
+	case "off":
+		if configTelemetryEndpoint != "" {
+			return errs.New(errs.Usage, "--endpoint is only used with 'on'")
+		}
+		settings["telemetry"] = false
+	default:
+		return errs.New(errs.Usage, "expected on or off, got %q", args[0])
+	}
+
+	path, err := initConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := writeConfigKeys(path, settings); err != nil {
+		return err
+	}
+	viper.Set("telemetry", settings["telemetry"])
+
+	ui.Success("Wrote %s", path)
+	printTelemetryStatus()
+	return nil
+}
+
+func printTelemetryStatus() {
+	endpoint := viper.GetString("telemetry-endpoint")
+	if !viper.GetBool("telemetry") {
+		ui.Info("Telemetry is off")
+		return
+	}
+	if endpoint == "" {
+		ui.Warn("Telemetry is on, but no endpoint is set; nothing is sent")
+		ui.Hint("Set one with: uniforge config telemetry on --endpoint <url>")
+		return
+	}
+	ui.Info("Telemetry is on")
+	fmt.Printf("  Endpoint: %s\n", endpoint)
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var crashCollectSince time.Duration
+
+var crashCmd = &cobra.Command{
+	Use:   "crash",
+	Short: "Review Unity Editor crashes",
+	Long: `Review crash bundles collected after Unity Editor crashes.
+
+When the editor crashes during 'run', 'test' or 'project compile', uniforge
+saves a bundle with the end of the log, the crash reports Unity and the OS
+wrote (dumps, Crashes folder, DiagnosticReports) and a description of the
+system, then prints the top of the crash stack. Use 'crash collect' after a
+crash in an interactive editor session.`,
+}
+
+var crashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List collected crash bundles",
+	Long: `List collected crash bundles, newest first.
+
+Examples:
+  uniforge crash list`,
+	Args:         cobra.NoArgs,
+	RunE:         runCrashList,
+	SilenceUsage: true,
+}
+
+var crashShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show a crash bundle",
+	Long: `Show the reason, stack summary and files of a crash bundle. Without an id the
+newest bundle is shown.
+
+Examples:
+  # Newest crash
+  uniforge crash show
+
+  # A specific bundle
+  uniforge crash show 20250615-143000-MyGame`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runCrashShow,
+	SilenceUsage: true,
+}
+
+var crashCollectCmd = &cobra.Command{
+	Use:   "collect [project]",
+	Short: "Collect a crash bundle after an interactive editor crash",
+	Long: `Collect the end of Editor.log and recent crash reports into a crash bundle,
+for crashes of an editor opened with 'uniforge project open' or Unity Hub.
+
+Examples:
+  # Right after the crash
+  uniforge crash collect
+
+  # Reports from the last 3 hours
+  uniforge crash collect ./MyGame --since 3h`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runCrashCollect,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(crashCmd)
+	crashCmd.AddCommand(crashListCmd)
+	crashCmd.AddCommand(crashShowCmd)
+	crashCmd.AddCommand(crashCollectCmd)
+
+	crashCollectCmd.Flags().DurationVar(&crashCollectSince, "since", time.Hour, "Include crash reports written within this time")
+}
+
+func runCrashList(cmd *cobra.Command, args []string) error {
+	bundles, err := unity.ListCrashBundles()
+	if err != nil {
+		return err
+	}
+	if len(bundles) == 0 {
+		ui.Info("No crash bundles collected")
+		return nil
+	}
+	for _, b := range bundles {
+		top := "—"
+		if len(b.Frames) > 0 {
+			top = b.Frames[0]
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", b.ID, b.EditorVersion, b.Reason, top)
+	}
+	return nil
+}
+
+func runCrashShow(cmd *cobra.Command, args []string) error {
+	id := "latest"
+	if len(args) > 0 {
+		id = args[0]
+	}
+	bundle, err := unity.LoadCrashBundle(id)
+	if err != nil {
+		return err
+	}
+
+	ui.Print("ID:      %s", bundle.ID)
+	ui.Print("Time:    %s", bundle.Time.Local().Format(time.DateTime))
+	if bundle.Project != "" {
+		ui.Print("Project: %s", bundle.Project)
+	}
+	ui.Print("")
+	unity.PrintCrashSummary(bundle)
+	ui.Print("")
+	ui.Print("Files in %s:", bundle.Dir)
+	for _, f := range bundle.Files {
+		ui.Print("  %s", filepath.FromSlash(f))
+	}
+	return nil
+}
+
+func runCrashCollect(cmd *cobra.Command, args []string) error {
+	info := unity.CrashInfo{
+		Since: time.Now().Add(-crashCollectSince),
+	}
+	if len(args) > 0 {
+		project, err := unity.LoadProject(args[0])
+		if err != nil {
+			return err
+		}
+		info.Project = project.Path
+		info.EditorVersion = project.UnityVersion
+	} else if root, err := unity.FindProjectRoot("."); err == nil {
+		if project, err := unity.LoadProject(root); err == nil {
+			info.Project = project.Path
+			info.EditorVersion = project.UnityVersion
+		}
+	}
+
+	bundle, err := unity.CollectCrash(info)
+	if err != nil {
+		return err
+	}
+	unity.PrintCrashSummary(bundle)
+	ui.Success("Collected %d file(s) into %s", len(bundle.Files), bundle.Dir)
+	return nil
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/daemon"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/sdk"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	daemonSocket string
+	daemonTTL    time.Duration
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Answer editor and project queries from a background process",
+	Long: `Run a daemon that answers queries over a local socket.
+
+IDE plugins and shell prompts ask the daemon instead of starting uniforge for
+every query, so installed editors, Unity Hub projects and resolved version
+specs are read once and reused for --ttl.
+
+The protocol is JSON-RPC 2.0, one JSON object per line, on a unix socket
+(Windows 10 and later support them too). Methods:
+  editors.list       Installed editors
+  version.resolve    {"spec": "2022.3", "includeAvailable": false}
+  projects.list      Projects registered in Unity Hub
+  daemon.refresh     Drop cached answers (e.g. after installing an editor)
+  daemon.status      Process ID, uptime and request count
+  daemon.shutdown    Stop the daemon
+
+Examples:
+  # Run the daemon in the foreground
+  uniforge daemon
+
+  # Query it
+  uniforge daemon call editors.list
+  uniforge daemon call version.resolve '{"spec": "lts"}'
+
+  # From any language, e.g. with socat
+  echo '{"jsonrpc":"2.0","id":1,"method":"projects.list"}' | socat - UNIX-CONNECT:$(uniforge daemon socket)
+
+  # Stop it
+  uniforge daemon stop`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Annotations:  map[string]string{noTelemetry: "true"},
+	RunE:         runDaemon,
+}
+
+var daemonCallCmd = &cobra.Command{
+	Use:   "call <method> [params-json]",
+	Short: "Send one request to the daemon and print the result",
+	Long: `Send one request to a running daemon and print its JSON result.
+
+Examples:
+  uniforge daemon call editors.list
+  uniforge daemon call version.resolve '{"spec": "6000", "includeAvailable": true}'`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runDaemonCall,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:          "stop",
+	Short:        "Stop the running daemon",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := daemon.Call(daemonSocket, daemon.MethodShutdown, nil, nil); err != nil {
+			return err
+		}
+		ui.Success("Daemon stopped")
+		return nil
+	},
+}
+
+var daemonSocketCmd = &cobra.Command{
+	Use:   "socket",
+	Short: "Print the daemon's socket path",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(daemonSocket)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonCallCmd)
+	daemonCmd.AddCommand(daemonStopCmd)
+	daemonCmd.AddCommand(daemonSocketCmd)
+
+	daemonCmd.PersistentFlags().StringVar(&daemonSocket, "socket", daemon.DefaultSocketPath(), "Unix socket to listen on or connect to")
+	daemonCmd.Flags().DurationVar(&daemonTTL, "ttl", daemon.DefaultTTL, "How long answers are reused before reading editors and projects again")
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	if daemonTTL <= 0 {
+		return errs.New(errs.Usage, "--ttl must be positive")
+	}
+
+	opts := sdk.DefaultOptions()
+	opts.NoCache = viper.GetBool("no-cache")
+	client, err := sdk.New(opts)
+	if err != nil {
+		return err
+	}
+
+	listener, err := daemon.Listen(daemonSocket)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(daemonSocket) }()
+
+	server := daemon.NewServer(client, daemonTTL)
+	ui.Success("Listening on %s (Ctrl+C to stop)", daemonSocket)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-server.Done():
+	case <-ctx.Done():
+	}
+	return listener.Close()
+}
+
+func runDaemonCall(cmd *cobra.Command, args []string) error {
+	var params any
+	if len(args) == 2 {
+		if !json.Valid([]byte(args[1])) {
+			return errs.New(errs.Usage, "params must be a JSON object, e.g. '{\"spec\": \"lts\"}'")
+		}
+		params = json.RawMessage(args[1])
+	}
+
+	var result json.RawMessage
+	if err := daemon.Call(daemonSocket, args[0], params, &result); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var diffFixes bool
+
+var editorDiffCmd = &cobra.Command{
+	Use:   "diff <from-version> <to-version>",
+	Short: "Show what changed between two versions of a stream",
+	Long: `Show the releases between two Unity versions of the same stream.
+
+Lists every release after <from-version> up to and including <to-version>,
+aggregated security alerts, and links to each release's notes. With --fixes,
+the "Fixes" section of each release's notes is fetched and merged.
+
+Examples:
+  # Releases between two 2022.3 patches
+  uniforge editor diff 2022.3.50f1 2022.3.62f1
+
+  # Include merged fix lists from the release notes
+  uniforge editor diff 2022.3.50f1 2022.3.62f1 --fixes`,
+	Args:         cobra.ExactArgs(2),
+	RunE:         runEditorDiff,
+	SilenceUsage: true,
+}
+
+func init() {
+	editorCmd.AddCommand(editorDiffCmd)
+
+	editorDiffCmd.Flags().BoolVar(&diffFixes, "fixes", false, "Fetch release notes and list merged fixes")
+}
+
+func runEditorDiff(cmd *cobra.Command, args []string) error {
+	hubClient := hub.NewClient()
+	hubClient.NoCache = viper.GetBool("no-cache")
+
+	releases, err := fetchReleasesWithCache(hubClient)
+	if err != nil {
+		return fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	diff, err := hub.DiffReleases(releases, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if len(diff.Releases) == 0 {
+		ui.Info("No releases between %s and %s", diff.From, diff.To)
+		return nil
+	}
+
+	ui.Print("%s → %s: %d release(s)", diff.From, diff.To, len(diff.Releases))
+	fmt.Println()
+
+	for _, r := range diff.Releases {
+		line := "  " + availVersionStyle.Render(r.Version)
+		if date := formatReleaseDate(r.ReleaseDate); date != "" {
+			line += "  " + availArchStyle.Render(date)
+		}
+		if r.ReleaseNotesURL != "" {
+			line += "  " + r.ReleaseNotesURL
+		}
+		fmt.Println(line)
+	}
+
+	if alerts := diff.SecurityAlerts(); len(alerts) > 0 {
+		fmt.Println()
+		ui.Warn("Security alerts (%d):", len(alerts))
+		for _, r := range alerts {
+			fmt.Printf("  %s: %s\n", r.Version, r.SecurityAlert)
+		}
+	}
+
+	if diffFixes {
+		fmt.Println()
+		printMergedFixes(diff)
+	}
+
+	return nil
+}
+
+// printMergedFixes fetches each release's notes and prints their Fixes sections
+func printMergedFixes(diff *hub.ReleaseDiff) {
+	for _, r := range diff.Releases {
+		if r.ReleaseNotesURL == "" {
+			continue
+		}
+
+		notes, err := ui.WithSpinner(fmt.Sprintf("Fetching release notes for %s...", r.Version), func() (string, error) {
+			return hub.FetchReleaseNotes(r.ReleaseNotesURL)
+		})
+		if err != nil {
+			ui.Warn("%s: %v", r.Version, err)
+			continue
+		}
+
+		fixes := hub.ExtractFixesSection(notes)
+		if len(fixes) == 0 {
+			continue
+		}
+
+		fmt.Println(headerStyle.Render(fmt.Sprintf("Fixes in %s (%d)", r.Version, len(fixes))))
+		for _, fix := range fixes {
+			fmt.Printf("  • %s\n", fix)
+		}
+		fmt.Println()
+	}
+}
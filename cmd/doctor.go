@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/adb"
+	"github.com/neptaco/uniforge/pkg/doctor"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the uniforge environment",
+	Long: `Check that the tools and locations uniforge depends on are available.
+
+Checks:
+  - Unity Hub presence and version
+  - Editor install path writability
+  - Network access to services.unity.com
+  - Cache directory health
+  - git availability
+  - Optional tools: adb (Android), xcodebuild (iOS, macOS only)
+  - Inside a Unity project: end of support of the project's Unity stream
+  - Inside a Unity project: Unity Hub recording another editor version than
+    ProjectVersion.txt
+  - Inside a Unity project: assembly definitions (duplicate names, invalid
+    root namespaces, missing references, editor-only assemblies referenced
+    from runtime ones, missing or unreferenced precompiled DLLs)
+  - Inside a Unity project: editors, platform modules and native toolchains
+    (Visual Studio C++ tools, Xcode Command Line Tools, Android NDK) for the
+    targets of .uniforge/build.yaml, or else for the targets set to IL2CPP
+
+Each failed check prints a suggested fix. Exits with a non-zero status when
+a required check fails.
+
+With --all, the project checks run for every project listed in the
+workspace's .uniforge/workspace.yaml, followed by a report of all of them.
+
+Examples:
+  uniforge doctor
+
+  # Check every project of the workspace
+  uniforge doctor --all`,
+	Args:         cobra.NoArgs,
+	RunE:         runDoctor,
+	SilenceUsage: true,
+}
+
+var doctorAll bool
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	addAllFlag(doctorCmd, &doctorAll)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	hubClient := hub.NewClient()
+
+	checks := []doctor.Check{
+		func() doctor.Result { return checkUnityHub(hubClient) },
+		func() doctor.Result { return checkEditorInstallPath(hubClient) },
+		func() doctor.Result {
+			return doctor.CheckReachable("Unity services", hub.GraphQLEndpoint, 10*time.Second,
+				"Check your network or proxy settings (HTTPS_PROXY); release listings need services.unity.com")
+		},
+		func() doctor.Result {
+			return doctor.CheckWritableDir("Cache directory", hubClient.CacheDir(),
+				"Ensure the cache directory is writable, or run 'uniforge cache clear'")
+		},
+		func() doctor.Result {
+			return doctor.CheckCommand("git", "git", true, "Install git: https://git-scm.com/downloads")
+		},
+		func() doctor.Result {
+			r := doctor.CheckFile("adb", adb.NewClient().Path(), false,
+				"Install Android platform-tools and set ANDROID_HOME or UNIFORGE_ADB_PATH (needed for 'uniforge android')")
+			r.Name = "adb (optional)"
+			return r
+		},
+	}
+
+	if runtime.GOOS == "darwin" {
+		checks = append(checks, func() doctor.Result {
+			r := doctor.CheckCommand("xcodebuild", "xcodebuild", false,
+				"Install Xcode and run 'xcode-select --install' (needed for 'uniforge ios')")
+			r.Name = "xcodebuild (optional)"
+			return r
+		})
+	}
+
+	if doctorAll {
+		return runDoctorAll(hubClient, checks)
+	}
+
+	if projectRoot, err := unity.FindProjectRoot("."); err == nil {
+		checks = append(checks, projectChecks(hubClient, projectRoot)...)
+	}
+
+	results := doctor.Run(checks)
+	for _, r := range results {
+		printDoctorResult(r)
+	}
+	refreshProjectStatus(".")
+
+	if failures := doctor.CountFailures(results); failures > 0 {
+		return fmt.Errorf("%d check(s) failed", failures)
+	}
+
+	ui.Success("All required checks passed")
+	return nil
+}
+
+// runDoctorAll runs the environment checks once and the project checks for
+// every project of the workspace
+func runDoctorAll(hubClient *hub.Client, checks []doctor.Check) error {
+	results := doctor.Run(checks)
+	for _, r := range results {
+		printDoctorResult(r)
+	}
+	envFailures := doctor.CountFailures(results)
+
+	err := runInWorkspace(nil, func(projectPath, _ string) error {
+		results := doctor.Run(projectChecks(hubClient, projectPath))
+		for _, r := range results {
+			printDoctorResult(r)
+		}
+		refreshProjectStatus(projectPath)
+		if failures := doctor.CountFailures(results); failures > 0 {
+			return fmt.Errorf("%d check(s) failed", failures)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if envFailures > 0 {
+		return fmt.Errorf("%d check(s) failed", envFailures)
+	}
+
+	ui.Success("All required checks passed")
+	return nil
+}
+
+// projectChecks returns the checks of the project at projectRoot
+func projectChecks(hubClient *hub.Client, projectRoot string) []doctor.Check {
+	return []doctor.Check{
+		func() doctor.Result { return checkProjectSupport(hubClient, projectRoot) },
+		func() doctor.Result { return checkHubProjectVersion(hubClient, projectRoot) },
+		func() doctor.Result { return checkCloudProjectLink(hubClient, projectRoot) },
+		func() doctor.Result { return checkAssemblyDefinitions(projectRoot) },
+		func() doctor.Result { return checkBuildToolchains(projectRoot) },
+	}
+}
+
+func checkUnityHub(hubClient *hub.Client) doctor.Result {
+	r := doctor.CheckFile("Unity Hub", hubClient.HubPath(), true,
+		"Install Unity Hub (https://unity.com/download) or set UNIFORGE_HUB_PATH")
+	if r.Status == doctor.StatusOK {
+		if version := hubClient.HubVersion(); version != "" {
+			r.Detail = fmt.Sprintf("%s (v%s)", r.Detail, version)
+		}
+	}
+	return r
+}
+
+func checkEditorInstallPath(hubClient *hub.Client) doctor.Result {
+	installPath, err := hubClient.GetInstallPath()
+	if err != nil {
+		return doctor.Result{
+			Name:   "Editor install path",
+			Status: doctor.StatusFail,
+			Detail: err.Error(),
+			Remedy: "Set the Installs location in Unity Hub preferences or UNIFORGE_EDITOR_BASE_PATH",
+		}
+	}
+	return doctor.CheckWritableDir("Editor install path", installPath,
+		"Grant write access to the install location or change it in Unity Hub preferences")
+}
+
+// checkProjectSupport warns when the project is pinned to a stream that no
+// longer receives updates, or soon will not
+func checkProjectSupport(hubClient *hub.Client, projectRoot string) doctor.Result {
+	r := doctor.Result{Name: "Unity support"}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		r.Status = doctor.StatusWarn
+		r.Detail = err.Error()
+		return r
+	}
+
+	stream := hub.GetMajorMinorFromVersion(project.UnityVersion)
+	support := hubClient.SupportWindows()
+	window, ok := support.For(project.UnityVersion)
+	if !ok || window.EOL.IsZero() {
+		r.Detail = fmt.Sprintf("%s (end of support not announced)", stream)
+		return r
+	}
+
+	eol := window.EOL.Format("2006-01-02")
+	switch badge := support.Badge(project.UnityVersion, time.Now()); badge {
+	case "":
+		r.Detail = fmt.Sprintf("%s (supported until %s)", stream, eol)
+	case "EOL":
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("%s reached end of support on %s", stream, eol)
+		r.Remedy = "Upgrade the project to a supported LTS stream ('uniforge editor available --lts')"
+	default:
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("%s ends support on %s (%s)", stream, eol, badge)
+		r.Remedy = "Plan an upgrade to a newer LTS stream ('uniforge editor available --lts')"
+	}
+	return r
+}
+
+// checkCloudProjectLink warns when the Unity Cloud project the project is linked
+// to differs from the one Unity Hub has recorded for it
+// checkHubProjectVersion compares the editor version Unity Hub records for the
+// project with its ProjectVersion.txt
+func checkHubProjectVersion(hubClient *hub.Client, projectRoot string) doctor.Result {
+	r := doctor.Result{Name: "Unity Hub record"}
+	projects, err := hubClient.ListProjectsWithOptions(hub.ProjectListOptions{ProjectVersions: true})
+	if err != nil {
+		r.Status = doctor.StatusWarn
+		r.Detail = err.Error()
+		return r
+	}
+	registered := hub.FindRegisteredProject(projects, projectRoot)
+	switch {
+	case registered == nil:
+		r.Detail = "not registered in Unity Hub"
+	case registered.VersionMismatch():
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("ProjectVersion.txt: %s, Unity Hub: %s", registered.ActualVersion, registered.HubVersion)
+		r.Remedy = "Open the project from Unity Hub once so it records the current version"
+	default:
+		r.Detail = registered.Version
+	}
+	return r
+}
+
+func checkCloudProjectLink(hubClient *hub.Client, projectRoot string) doctor.Result {
+	r := doctor.Result{Name: "Unity Cloud link"}
+	settings, err := unity.LoadPlayerSettings(projectRoot)
+	if err != nil {
+		r.Status = doctor.StatusWarn
+		r.Detail = err.Error()
+		return r
+	}
+
+	linked := "not linked"
+	if settings.CloudProjectID != "" {
+		linked = settings.CloudProjectID
+		if settings.OrganizationID != "" {
+			linked += " (" + settings.OrganizationID + ")"
+		}
+	}
+
+	projects, err := hubClient.ListProjects()
+	if err != nil {
+		r.Detail = linked
+		return r
+	}
+	registered := hub.FindRegisteredProject(projects, projectRoot)
+	if registered == nil || registered.CloudProjectID == "" {
+		r.Detail = linked
+		return r
+	}
+	if registered.CloudProjectID != settings.CloudProjectID {
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("ProjectSettings.asset: %s, Unity Hub: %s", linked, registered.CloudProjectID)
+		r.Remedy = "Relink the project in Project Settings > Services, then reopen it from Unity Hub so both agree"
+		return r
+	}
+	if settings.OrganizationID != "" && registered.CloudOrgID != "" && registered.CloudOrgID != settings.OrganizationID {
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("organization %s in ProjectSettings.asset, %s in Unity Hub", settings.OrganizationID, registered.CloudOrgID)
+		r.Remedy = "Relink the project in Project Settings > Services, then reopen it from Unity Hub so both agree"
+		return r
+	}
+	r.Detail = linked
+	return r
+}
+
+func checkAssemblyDefinitions(projectRoot string) doctor.Result {
+	r := doctor.Result{Name: "Assembly definitions"}
+	issues, err := unity.AnalyzeAssemblies(projectRoot)
+	if err != nil {
+		r.Status = doctor.StatusFail
+		r.Detail = err.Error()
+		return r
+	}
+
+	errors := 0
+	for _, issue := range issues {
+		if !issue.Warning {
+			errors++
+		}
+		r.Items = append(r.Items, fmt.Sprintf("%s: %s", issue.Location(), issue.Message))
+	}
+	switch {
+	case len(issues) == 0:
+		r.Detail = "no issues"
+	case errors > 0:
+		r.Status = doctor.StatusFail
+		r.Detail = fmt.Sprintf("%d error(s), %d warning(s)", errors, len(issues)-errors)
+		r.Remedy = "Fix the .asmdef files listed above"
+	default:
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("%d warning(s)", len(issues))
+	}
+	return r
+}
+
+// checkBuildToolchains checks the prerequisites of the build matrix targets, or
+// of the targets the project builds with IL2CPP when it has no build matrix
+func checkBuildToolchains(projectRoot string) doctor.Result {
+	r := doctor.Result{Name: "Build toolchains"}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		r.Status = doctor.StatusWarn
+		r.Detail = err.Error()
+		return r
+	}
+
+	var targets []unity.ToolchainTarget
+	if config, err := unity.LoadBuildConfig(project.Path); err == nil {
+		jobs, err := resolveBuildJobs(project, config.Targets)
+		if err != nil {
+			r.Status = doctor.StatusWarn
+			r.Detail = err.Error()
+			return r
+		}
+		for _, job := range jobs {
+			targets = append(targets, unity.NewToolchainTarget(project.Path, job.Target.Target, job.Version))
+		}
+	} else {
+		for _, target := range []string{"Android", hostStandaloneTarget()} {
+			if t := unity.NewToolchainTarget(project.Path, target, project.UnityVersion); t.IL2CPP {
+				targets = append(targets, t)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		r.Detail = "no IL2CPP targets"
+		return r
+	}
+
+	names := make([]string, len(targets))
+	errors := 0
+	for i, t := range targets {
+		names[i] = t.Target
+		for _, issue := range unity.CheckToolchain(t) {
+			if !issue.Warning {
+				errors++
+			}
+			r.Items = append(r.Items, fmt.Sprintf("%s → %s", issue, issue.Remedy))
+		}
+	}
+	switch {
+	case len(r.Items) == 0:
+		r.Detail = strings.Join(names, ", ")
+	case errors > 0:
+		r.Status = doctor.StatusFail
+		r.Detail = fmt.Sprintf("%d prerequisite(s) missing", errors)
+		r.Remedy = "Install what is listed above before building these targets"
+	default:
+		r.Status = doctor.StatusWarn
+		r.Detail = fmt.Sprintf("%d warning(s)", len(r.Items))
+	}
+	return r
+}
+
+// hostStandaloneTarget returns the standalone build target of this machine
+func hostStandaloneTarget() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "StandaloneWindows64"
+	case "darwin":
+		return "StandaloneOSX"
+	default:
+		return "StandaloneLinux64"
+	}
+}
+
+func printDoctorResult(r doctor.Result) {
+	line := fmt.Sprintf("%-24s %s", r.Name, r.Detail)
+	switch r.Status {
+	case doctor.StatusOK:
+		ui.Success("%s", line)
+	case doctor.StatusWarn:
+		ui.Warn("%s", line)
+	default:
+		ui.Error("%s", line)
+	}
+	for _, item := range r.Items {
+		ui.Muted("    %s", item)
+	}
+	if r.Remedy != "" && r.Status != doctor.StatusOK {
+		ui.Muted("    → %s", r.Remedy)
+	}
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	packOutput     string
+	packModules    string
+	packNoModules  bool
+	unpackDest     string
+	unpackForce    bool
+	unpackRegister bool
+)
+
+var editorPackCmd = &cobra.Command{
+	Use:   "pack [version]",
+	Short: "Package an installed editor for another machine",
+	Long: `Write an installed Unity Editor and its modules into a relocatable archive,
+for 'editor unpack' on other machines of the same platform. Copying a pack from
+a local file share is much faster than downloading the editor again on every
+build agent.
+
+Without a version, the editor of the project in the current directory is
+packed. All installed modules are included unless --modules or --no-modules
+is given. The archive ends with a manifest listing the SHA-256 of every file,
+which 'editor unpack' checks. The compression follows the output extension:
+.tar.zst (needs zstd), .tar.gz or .tar.
+
+Examples:
+  # Pack 2022.3.10f1 with all its modules to Unity-2022.3.10f1-<os>.tar.zst
+  uniforge editor pack 2022.3.10f1
+
+  # Only Android support, onto a file share
+  uniforge editor pack 2022.3.10f1 --modules android -o /mnt/share/unity-2022.3.10f1-android.tar.zst
+
+  # Install it on a build agent
+  uniforge editor unpack /mnt/share/unity-2022.3.10f1-android.tar.zst`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runEditorPack,
+	SilenceUsage: true,
+}
+
+var editorUnpackCmd = &cobra.Command{
+	Use:   "unpack <archive>",
+	Short: "Install an editor from an 'editor pack' archive",
+	Long: `Install the editor of an archive written by 'editor pack' and register it
+with Unity Hub. Every file is verified against the archive's manifest before
+the editor is moved into place, so an interrupted or corrupted unpack never
+leaves a half-installed editor.
+
+The editor is installed into <dest>/<version>; --dest defaults to the
+directory editors are installed in (UNIFORGE_EDITOR_BASE_PATH or Unity Hub's
+install path). Packs only install on the platform they were made on.
+
+Examples:
+  # Install into Unity Hub's install path
+  uniforge editor unpack Unity-2022.3.10f1-linux.tar.zst
+
+  # Install into another directory, replacing an existing copy
+  uniforge editor unpack /mnt/share/unity.tar.zst --dest /opt/unity --force`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runEditorUnpack,
+	SilenceUsage: true,
+}
+
+func init() {
+	editorCmd.AddCommand(editorPackCmd)
+	editorCmd.AddCommand(editorUnpackCmd)
+
+	editorPackCmd.Flags().StringVarP(&packOutput, "output", "o", "", "Archive file (.tar.zst, .tar.gz or .tar)")
+	editorPackCmd.Flags().StringVar(&packModules, "modules", "", "Comma-separated list of installed modules to include (default: all)")
+	editorPackCmd.Flags().BoolVar(&packNoModules, "no-modules", false, "Pack the editor without modules")
+	editorPackCmd.MarkFlagsMutuallyExclusive("modules", "no-modules")
+
+	editorUnpackCmd.Flags().StringVar(&unpackDest, "dest", "", "Directory to install the editor into (default: the editor install path)")
+	editorUnpackCmd.Flags().BoolVar(&unpackForce, "force", false, "Replace an editor of the same version in the destination")
+	editorUnpackCmd.Flags().BoolVar(&unpackRegister, "register", true, "Register the editor with Unity Hub when it is installed")
+}
+
+func runEditorPack(cmd *cobra.Command, args []string) error {
+	var version string
+	if len(args) > 0 {
+		version = args[0]
+	} else {
+		projectRoot, err := unity.FindProjectRoot(".")
+		if err != nil {
+			return errs.WithHint(
+				errs.New(errs.Usage, "no version given and no Unity project found"),
+				"Pass the editor version, e.g. 'uniforge editor pack 2022.3.10f1'",
+			)
+		}
+		project, err := unity.LoadProject(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load project: %w", err)
+		}
+		version = project.UnityVersion
+	}
+
+	packer := &unity.EditorPacker{Client: hub.NewClient(), Version: version}
+	switch {
+	case packNoModules:
+		packer.Modules = []string{}
+	case packModules != "":
+		for _, m := range strings.Split(packModules, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				packer.Modules = append(packer.Modules, m)
+			}
+		}
+	}
+
+	output := packOutput
+	if output == "" {
+		output = fmt.Sprintf("Unity-%s-%s.tar.zst", version, runtime.GOOS)
+	}
+
+	ui.Info("Packing Unity %s", version)
+	progress := ui.NewProgress("Packing editor", 0, ui.WithBytes())
+	packer.OnStart = func(_ int, size int64) { progress.SetTotal(size) }
+	packer.OnWrite = progress.Add
+	manifest, err := packer.Create(output)
+	progress.Done(err)
+	if err != nil {
+		return err
+	}
+
+	abs, _ := filepath.Abs(output)
+	ui.Success("Packed Unity %s (%d files, %s) to %s", version, len(manifest.Files), ui.FormatBytes(manifest.TotalSize()), abs)
+	ui.Muted("Modules: %s", packModulesLabel(manifest.Modules))
+	return nil
+}
+
+func runEditorUnpack(cmd *cobra.Command, args []string) error {
+	archive := args[0]
+	hubClient := hub.NewClient()
+
+	progress := ui.NewProgress("Unpacking "+filepath.Base(archive), 0, ui.WithBytes())
+	opts := unity.UnpackOptions{
+		Dest:      unpackDest,
+		Overwrite: unpackForce,
+		OnStart:   progress.SetTotal,
+		OnRead:    progress.Add,
+	}
+	manifest, editorPath, err := unity.UnpackEditor(hubClient, archive, opts)
+	progress.Done(err)
+	if err != nil {
+		return err
+	}
+
+	ui.Success("Installed Unity %s at %s (checksums verified)", manifest.Version, editorPath)
+	ui.Muted("Modules: %s", packModulesLabel(manifest.Modules))
+
+	if unpackRegister && hubClient.HubPath() != "" {
+		if err := hubClient.RegisterEditor(editorPath); err != nil {
+			ui.Warn("Failed to register the editor with Unity Hub: %v", err)
+			ui.Hint("Add it in Unity Hub under Installs > Locate")
+		}
+	} else if installed, _, _ := hubClient.IsEditorInstalled(manifest.Version); !installed {
+		ui.Hint("%s is outside the editor install path, so uniforge does not list it; unpack without --dest, or add it in Unity Hub under Installs > Locate", filepath.Dir(hub.EditorDir(editorPath)))
+	}
+	return nil
+}
+
+// packModulesLabel lists the modules of a pack for display
+func packModulesLabel(modules []string) string {
+	if len(modules) == 0 {
+		return "none"
+	}
+	return strings.Join(modules, ", ")
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/neptaco/uniforge/pkg/editorprefs"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prefsOutput string
+	prefsSafe   bool
+	prefsDryRun bool
+)
+
+var editorPrefsCmd = &cobra.Command{
+	Use:   "prefs",
+	Short: "Export and import Unity Editor preferences",
+	Long: `Copy Unity Editor preferences (EditorPrefs) between machines.
+
+Preferences are read from the plist on macOS, the registry on Windows and
+~/.local/share/unity3d/prefs on Linux, and saved as a portable JSON bundle.
+
+With --safe only an allowlist of keys is exported or imported: external tool
+paths (script editor and its arguments, diff, merge and image tools, Android
+SDK, NDK, JDK and Gradle), the editor theme, auto refresh and the UI scale.
+Other preferences include recent projects and account state that rarely make
+sense on another machine.`,
+}
+
+var editorPrefsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the editor preferences to a JSON bundle",
+	Long: `Write the current user's Unity Editor preferences to a JSON bundle.
+
+Examples:
+  # Portable settings only
+  uniforge editor prefs export --safe -o unity-prefs.json
+
+  # Everything, to stdout
+  uniforge editor prefs export`,
+	Args:         cobra.NoArgs,
+	RunE:         runEditorPrefsExport,
+	SilenceUsage: true,
+}
+
+var editorPrefsImportCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Restore editor preferences from a JSON bundle",
+	Long: `Set the preferences in a bundle written by 'editor prefs export'. Preferences
+not in the bundle are left unchanged.
+
+Unity saves its preferences when it quits, overwriting imported values, so
+close all editors first.
+
+Examples:
+  # Preview
+  uniforge editor prefs import unity-prefs.json --dry-run
+
+  # Import only the allowlisted keys of a full export
+  uniforge editor prefs import unity-prefs.json --safe`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runEditorPrefsImport,
+	SilenceUsage: true,
+}
+
+func init() {
+	editorCmd.AddCommand(editorPrefsCmd)
+	editorPrefsCmd.AddCommand(editorPrefsExportCmd)
+	editorPrefsCmd.AddCommand(editorPrefsImportCmd)
+
+	editorPrefsCmd.PersistentFlags().BoolVar(&prefsSafe, "safe", false, "Only keys on the allowlist of portable preferences")
+	editorPrefsExportCmd.Flags().StringVarP(&prefsOutput, "output", "o", "", "Write the bundle to a file instead of stdout")
+	editorPrefsImportCmd.Flags().BoolVar(&prefsDryRun, "dry-run", false, "List the preferences without writing them")
+}
+
+func runEditorPrefsExport(cmd *cobra.Command, args []string) error {
+	prefs, err := editorprefs.Read()
+	if err != nil {
+		return err
+	}
+	if prefsSafe {
+		prefs = editorprefs.FilterSafe(prefs)
+	}
+
+	data, err := json.MarshalIndent(editorprefs.NewBundle(prefs), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if prefsOutput == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := fsutil.WriteFileAtomic(prefsOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", prefsOutput, err)
+	}
+	ui.Success("Exported %d preference(s) to %s", len(prefs), prefsOutput)
+	return nil
+}
+
+func runEditorPrefsImport(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errs.New(errs.NotFound, "preferences bundle not found: %s", args[0])
+		}
+		return err
+	}
+	bundle, err := editorprefs.ParseBundle(data)
+	if err != nil {
+		return err
+	}
+
+	prefs := bundle.Prefs
+	if prefsSafe {
+		prefs = editorprefs.FilterSafe(prefs)
+	}
+	if len(prefs) == 0 {
+		ui.Info("No preferences to import")
+		return nil
+	}
+
+	if prefsDryRun {
+		for _, p := range prefs {
+			fmt.Printf("%s (%s) = %s\n", p.Key, p.Type, p.Value)
+		}
+		ui.Muted("Dry run: %d preference(s) would be imported", len(prefs))
+		return nil
+	}
+
+	ok, err := ui.Confirm("Import %d preference(s)? Close all Unity editors first", len(prefs))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ui.Muted("Skipped. No preferences were changed.")
+		return nil
+	}
+
+	if err := editorprefs.Write(prefs); err != nil {
+		return err
+	}
+	ui.Success("Imported %d preference(s)", len(prefs))
+	return nil
+}
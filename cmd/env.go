@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Inspect the environment uniforge gives Unity",
+	Long: `Commands for the per-project environment in .uniforge/env.yaml, which
+uniforge applies whenever it starts Unity or a task step for the project.`,
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envShowProfile string
+	envShowAll     bool
+	envShowFormat  string
+)
+
+var envShowCmd = &cobra.Command{
+	Use:   "show [project]",
+	Short: "Show the environment Unity runs of a project get",
+	Long: `Show the environment variables uniforge sets when it starts Unity or a task
+step for the project: those of .uniforge/env.yaml, with the profile named by
+--profile, UNIFORGE_ENV_PROFILE or env-profile in ~/.uniforge.yaml applied on
+top. Use it to debug which SDK, Gradle home or proxy a build actually sees.
+
+  env:
+    ANDROID_SDK_ROOT: /opt/android-sdk
+    GRADLE_USER_HOME: ${HOME}/.gradle-unity
+  profiles:
+    office:
+      HTTPS_PROXY: http://proxy.example.com:8080
+      HTTP_PROXY: ${HTTPS_PROXY}
+
+Values may refer to the inherited environment and to variables set before them
+as $NAME or ${NAME}; write $$ for a literal $. Profile variables are set after
+the env ones.
+
+Examples:
+  # Variables env.yaml sets for the project in the current directory
+  uniforge env show
+
+  # With the office profile
+  uniforge env show --profile office
+
+  # The complete environment, inherited variables included
+  uniforge env show --all
+
+  # JSON for scripting
+  uniforge env show ./MyGame --format json`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runEnvShow,
+	SilenceUsage: true,
+}
+
+func init() {
+	envCmd.AddCommand(envShowCmd)
+
+	envShowCmd.Flags().StringVar(&envShowProfile, "profile", "", "Profile of env.yaml to apply (default: $UNIFORGE_ENV_PROFILE)")
+	envShowCmd.Flags().BoolVar(&envShowAll, "all", false, "Include the inherited environment")
+	envShowCmd.Flags().StringVar(&envShowFormat, "format", "table", "Output format: table, json")
+}
+
+func runEnvShow(cmd *cobra.Command, args []string) error {
+	if envShowFormat != "table" && envShowFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s (use table or json)", envShowFormat)
+	}
+	projectRoot, err := projectRootArg(args, 0)
+	if err != nil {
+		return err
+	}
+
+	profile := envShowProfile
+	if !cmd.Flags().Changed("profile") {
+		profile = os.Getenv(unity.EnvProfileEnv)
+	}
+	config, err := unity.LoadEnvConfig(projectRoot)
+	if err != nil {
+		return err
+	}
+	vars, err := config.Resolve(os.Environ(), profile)
+	if err != nil {
+		return err
+	}
+	if envShowAll {
+		vars = withInherited(vars)
+	}
+
+	if envShowFormat == "json" {
+		if vars == nil {
+			vars = []unity.EnvVar{}
+		}
+		data, err := json.MarshalIndent(vars, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode environment: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(vars) == 0 {
+		ui.Info("%s sets no variables", unity.EnvConfigPath(projectRoot))
+		if names := config.ProfileNames(); len(names) > 0 && profile == "" {
+			ui.Muted("Profiles: %s", strings.Join(names, ", "))
+		}
+		return nil
+	}
+	printEnvVars(vars)
+	return nil
+}
+
+// withInherited returns the current environment with vars applied, sorted by
+// name. Inherited variables have the source "inherited".
+func withInherited(vars []unity.EnvVar) []unity.EnvVar {
+	byName := make(map[string]unity.EnvVar)
+	for _, kv := range os.Environ() {
+		name, value, ok := strings.Cut(kv, "=")
+		if ok && name != "" {
+			byName[name] = unity.EnvVar{Name: name, Value: value, Source: "inherited"}
+		}
+	}
+	for _, v := range vars {
+		byName[v.Name] = v
+	}
+
+	all := make([]unity.EnvVar, 0, len(byName))
+	for _, v := range byName {
+		all = append(all, v)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// printEnvVars prints variables as a table
+func printEnvVars(vars []unity.EnvVar) {
+	rows := make([][]string, 0, len(vars))
+	for _, v := range vars {
+		rows = append(rows, []string{v.Name, v.Value, v.Source})
+	}
+
+	t := table.New().
+		Headers("NAME", "VALUE", "SOURCE").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			switch col {
+			case 0:
+				return versionStyle
+			case 2:
+				return pathStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var gitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Configure git for Unity projects",
+	Long:  `Commands for configuring git repositories that contain Unity projects.`,
+}
+
+func init() {
+	rootCmd.AddCommand(gitCmd)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/git"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	smartMergeCheck         bool
+	smartMergeEditorVersion string
+)
+
+var gitSetupSmartMergeCmd = &cobra.Command{
+	Use:   "setup-smart-merge [project]",
+	Short: "Configure UnityYAMLMerge as git merge driver",
+	Long: `Configure git to merge scenes, prefabs and assets with UnityYAMLMerge.
+
+The UnityYAMLMerge binary of the project's editor version is registered as
+the "unityyamlmerge" merge driver in .git/config, and *.unity, *.prefab and
+*.asset are routed to it in .gitattributes at the repository root. Existing
+.gitattributes lines are preserved; uniforge only manages its own block.
+
+Running the command again is safe. Use --check in CI to verify the setup
+without changing anything (exits with an error if something is missing).
+
+Examples:
+  # Configure the repository of the current project
+  uniforge git setup-smart-merge
+
+  # Verify the configuration (for CI)
+  uniforge git setup-smart-merge --check
+
+  # Use UnityYAMLMerge from a specific installed editor
+  uniforge git setup-smart-merge --editor-version 6000.0.30f1`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runGitSetupSmartMerge,
+	SilenceUsage: true,
+}
+
+func init() {
+	gitCmd.AddCommand(gitSetupSmartMergeCmd)
+
+	gitSetupSmartMergeCmd.Flags().BoolVar(&smartMergeCheck, "check", false, "Only verify the configuration, exit with an error if it is not up to date")
+	gitSetupSmartMergeCmd.Flags().StringVar(&smartMergeEditorVersion, "editor-version", "", "Editor whose UnityYAMLMerge to use (default: project's version)")
+}
+
+func runGitSetupSmartMerge(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+
+	project, err := unity.LoadProject(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	repoRoot, err := git.RepoRoot(project.Path)
+	if err != nil {
+		return err
+	}
+
+	version := project.UnityVersion
+	if smartMergeEditorVersion != "" {
+		version = smartMergeEditorVersion
+	}
+	yamlMerge, err := unity.NewEditor(version).YAMLMergePath()
+	if err != nil {
+		return fmt.Errorf("failed to locate UnityYAMLMerge for Unity %s: %w", version, err)
+	}
+	ui.Debug("Using UnityYAMLMerge", "path", yamlMerge)
+
+	if smartMergeCheck {
+		changes, err := unity.CheckSmartMerge(repoRoot, yamlMerge)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			ui.Success("Smart merge is configured")
+			return nil
+		}
+		ui.Error("Smart merge is not configured (%d settings missing):", len(changes))
+		for _, c := range changes {
+			fmt.Printf("  %s: %s\n", c.Target, c.Detail)
+		}
+		return fmt.Errorf("run 'uniforge git setup-smart-merge' to fix")
+	}
+
+	changes, err := unity.SetupSmartMerge(repoRoot, yamlMerge)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		ui.Success("Smart merge is already configured")
+		return nil
+	}
+
+	for _, c := range changes {
+		ui.Muted("%s: %s", c.Target, c.Detail)
+	}
+	ui.Success("Configured UnityYAMLMerge from Unity %s", version)
+	ui.Muted("Commit .gitattributes so merges route scenes and prefabs to the driver")
+	return nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyOp     string
+	historySince  string
+	historyFailed bool
+	historyFormat string
+	historyLimit  int
+)
+
+var historyOps = []string{hub.JournalInstall, hub.JournalModules, hub.JournalDiscard, hub.JournalUnpack, hub.JournalCacheClear, hub.JournalRegister, hub.JournalModuleRemove}
+
+var (
+	historyFailedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	historyOKStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [version|path]",
+	Short: "Show what uniforge changed on this machine",
+	Long: `Show the journal of changes uniforge made on this machine: editor installs,
+modules added or removed, interrupted installs discarded, editors unpacked,
+caches cleared and projects registered with Unity Hub. Each entry records when, by
+which user and host, with which command, and whether it failed.
+
+The journal is kept in uniforge's state directory ($XDG_STATE_HOME/uniforge,
+~/.local/state/uniforge on Linux, ~/Library/Application Support/uniforge on
+macOS, %LOCALAPPDATA%\uniforge on Windows, $UNIFORGE_HOME/state when set),
+separate from the caches.
+Changes made through Unity Hub directly are not recorded.
+
+Examples:
+  # Everything, newest first
+  uniforge history
+
+  # What happened to 2021.3 editors
+  uniforge history 2021.3
+
+  # Failed installs of the last week
+  uniforge history --op install --failed --since 168h
+
+  # Since a date, as JSON
+  uniforge history --since 2025-06-01 --format json`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runHistory,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historyOp, "op", "", "Only this operation: "+strings.Join(historyOps, ", "))
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only entries since a date (YYYY-MM-DD) or within a duration (e.g. 24h)")
+	historyCmd.Flags().BoolVar(&historyFailed, "failed", false, "Only failed operations")
+	historyCmd.Flags().StringVar(&historyFormat, "format", "text", "Output format: text, json")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 50, "Show at most this many entries (0 for all)")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyFormat != "text" && historyFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s (use text or json)", historyFormat)
+	}
+	if historyOp != "" && !slices.Contains(historyOps, historyOp) {
+		return errs.New(errs.Usage, "unknown operation: %s (use %s)", historyOp, strings.Join(historyOps, ", "))
+	}
+
+	filter := hub.JournalFilter{Op: historyOp, Failed: historyFailed}
+	if len(args) > 0 {
+		filter.Subject = args[0]
+	}
+	if historySince != "" {
+		if d, err := time.ParseDuration(historySince); err == nil {
+			filter.Since = time.Now().Add(-d)
+		} else {
+			since, err := parseDateFlag("since", historySince)
+			if err != nil {
+				return errs.New(errs.Usage, "invalid --since %q (expected YYYY-MM-DD or a duration like 24h)", historySince)
+			}
+			filter.Since = since
+		}
+	}
+
+	hubClient := hub.NewClient()
+	entries, err := hubClient.LoadJournal()
+	if err != nil {
+		return err
+	}
+
+	// Newest first
+	var selected []hub.JournalEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if filter.Match(entries[i]) {
+			selected = append(selected, entries[i])
+		}
+		if historyLimit > 0 && len(selected) == historyLimit {
+			break
+		}
+	}
+
+	if historyFormat == "json" {
+		if selected == nil {
+			selected = []hub.JournalEntry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(selected)
+	}
+
+	if len(selected) == 0 {
+		ui.Info("No recorded changes (journal: %s)", hubClient.JournalFilePath())
+		return nil
+	}
+	printHistoryTable(selected)
+	return nil
+}
+
+func printHistoryTable(entries []hub.JournalEntry) {
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		result := "ok"
+		detail := e.Detail
+		if e.Failed() {
+			result = "failed"
+			detail = e.Error
+		}
+		who := e.User
+		if e.Host != "" {
+			who += "@" + e.Host
+		}
+		rows = append(rows, []string{e.Time.Local().Format(time.DateTime), e.Op, e.Subject, who, result, detail})
+	}
+
+	t := table.New().
+		Headers("TIME", "OPERATION", "SUBJECT", "BY", "RESULT", "DETAIL").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			switch col {
+			case 2:
+				return editorVersionStyle
+			case 3, 5:
+				return editorPathStyle
+			case 4:
+				if rows[row][col] == "failed" {
+					return historyFailedStyle
+				}
+				return historyOKStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/viper"
+)
+
+// loadHook reads hooks.on_<event>_complete from the config.
+// A plain string is treated as a command; a map may set command and/or webhook.
+func loadHook(event string) hooks.Hook {
+	key := "hooks.on_" + event + "_complete"
+
+	if command, ok := viper.Get(key).(string); ok {
+		return hooks.Hook{Command: command}
+	}
+
+	var hook hooks.Hook
+	if err := viper.UnmarshalKey(key, &hook); err != nil {
+		ui.Warn("Invalid %s config: %v", key, err)
+	}
+	return hook
+}
+
+// notifyHook runs the configured hook for a finished operation.
+// Hook failures are reported as warnings and never change the command result.
+func notifyHook(event, subject string, start time.Time, opErr error) {
+	hook := loadHook(event)
+	if hook.IsEmpty() {
+		return
+	}
+
+	if err := hooks.Run(hook, hooks.NewEvent(event, subject, start, opErr)); err != nil {
+		ui.Warn("%v", err)
+	}
+}
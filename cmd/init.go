@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/paths"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// initLTSChoices is how many LTS streams are offered for installation
+const initLTSChoices = 3
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up this machine for Unity development",
+	Long: `Walk through first-run setup of a development machine.
+
+The wizard:
+  1. Detects Unity Hub (or asks for its location)
+  2. Selects or creates the directory editors are installed into
+  3. Optionally installs a recent LTS editor
+  4. Chooses the external code editor used by the project TUI
+  5. Writes the answers to the config file ($HOME/.uniforge.yaml or --config)
+
+Existing config keys not touched by the wizard are preserved.
+
+Examples:
+  # Run the setup wizard
+  uniforge init
+
+  # Write to a specific config file
+  uniforge init --config ./uniforge.yaml`,
+	Args:         cobra.NoArgs,
+	RunE:         runInit,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	if !ui.CanPrompt() {
+		return fmt.Errorf("init is interactive and needs a terminal without --no-input")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	settings := make(map[string]any)
+
+	// Step 1: Unity Hub
+	ui.Info("Step 1/5: Unity Hub")
+	hubClient := hub.NewClient()
+	if hubClient.HubPath() != "" {
+		ui.Success("Found Unity Hub %s at %s", hubClient.HubVersion(), hubClient.HubPath())
+	} else {
+		ui.Muted("Install Unity Hub from https://unity.com/download, or enter the path to an existing installation.")
+		hubPath := promptLine(reader, "Unity Hub path (empty to skip)", "")
+		switch {
+		case hubPath == "":
+			ui.Muted("Skipped. Editor installation will not be available until Unity Hub is installed.")
+		case !fileExistsAt(hubPath):
+			ui.Warn("%s does not exist, skipping", hubPath)
+		default:
+			settings["hub-path"] = hubPath
+			_ = os.Setenv("UNIFORGE_HUB_PATH", hubPath)
+			hubClient = hub.NewClient()
+		}
+	}
+	fmt.Println()
+
+	// Step 2: Editor install location
+	ui.Info("Step 2/5: Editor install location")
+	currentPath, err := hubClient.GetInstallPath()
+	if err != nil {
+		ui.Debug("Failed to get install path", "error", err)
+	}
+	installPath := promptLine(reader, "Install editors into", currentPath)
+	if installPath != "" && installPath != currentPath {
+		if err := os.MkdirAll(installPath, 0755); err != nil {
+			return fmt.Errorf("failed to create install directory: %w", err)
+		}
+		if hubClient.HubPath() != "" {
+			if err := hubClient.SetInstallPath(installPath); err != nil {
+				ui.Warn("Could not update Unity Hub: %v", err)
+			}
+		}
+		settings["editor-base-path"] = installPath
+		_ = os.Setenv("UNIFORGE_EDITOR_BASE_PATH", installPath)
+		ui.Success("Editors will be installed into %s", installPath)
+	}
+	fmt.Println()
+
+	// Step 3: Default LTS editor
+	ui.Info("Step 3/5: Install an LTS editor")
+	if hubClient.HubPath() == "" {
+		ui.Muted("Skipped (Unity Hub not found)")
+	} else if err := initInstallLTS(hubClient); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Step 4: External editor
+	ui.Info("Step 4/5: External code editor")
+	if editor := initSelectEditor(); editor != "" {
+		settings["editor"] = editor
+		ui.Success("Using %s", editor)
+	} else {
+		ui.Muted("Auto-detecting (rider > cursor > code)")
+	}
+	fmt.Println()
+
+	// Step 5: Config file
+	ui.Info("Step 5/5: Save configuration")
+	if len(settings) == 0 {
+		ui.Muted("Nothing to save, defaults are in use")
+		return nil
+	}
+
+	configPath, err := initConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := writeConfigKeys(configPath, settings); err != nil {
+		return err
+	}
+	ui.Success("Wrote %s", configPath)
+	return nil
+}
+
+// initInstallLTS offers the newest LTS releases and installs the chosen one
+func initInstallLTS(hubClient *hub.Client) error {
+	releases, err := ui.WithSpinner("Fetching Unity releases...", func() ([]hub.UnityRelease, error) {
+		return hubClient.GetAllReleases()
+	})
+	if err != nil {
+		ui.Warn("Failed to fetch releases: %v", err)
+		return nil
+	}
+
+	var options []ui.SelectOption
+	for _, r := range hub.LatestLTSReleases(releases, initLTSChoices) {
+		desc := "LTS"
+		if r.Installed {
+			desc = "LTS, already installed"
+		}
+		options = append(options, ui.SelectOption{Label: r.Version, Description: desc, Value: r.Version})
+	}
+	options = append(options, ui.SelectOption{Label: "Skip", Description: "Do not install an editor now"})
+
+	idx := ui.Select("Install a Unity Editor", options)
+	if idx < 0 || options[idx].Value == nil {
+		ui.Muted("Skipped")
+		return nil
+	}
+
+	version := options[idx].Value.(string)
+	release := hubClient.FindRelease(version)
+	if release != nil && release.Installed {
+		ui.Muted("Unity Editor %s is already installed", version)
+		return nil
+	}
+	if err := preflightInstall(hubClient, version, nil, true, false); err != nil {
+		return err
+	}
+
+	ui.Info("Installing Unity Editor %s", version)
+	installOpts := hub.InstallOptions{Version: version}
+	if release != nil {
+		installOpts.Changeset = release.Changeset
+	}
+
+	start := time.Now()
+	err = hubClient.InstallEditorWithOptions(installOpts)
+	notifyHook(hooks.EventInstall, version, start, err)
+	if err != nil {
+		return fmt.Errorf("failed to install Unity Editor: %w", err)
+	}
+	ui.Success("Installed Unity Editor %s", version)
+	return nil
+}
+
+// initSelectEditor asks for the external editor; an empty result means auto-detect
+func initSelectEditor() string {
+	var options []ui.SelectOption
+	for _, name := range []string{"rider", "cursor", "code"} {
+		if path, err := exec.LookPath(name); err == nil {
+			options = append(options, ui.SelectOption{Label: name, Description: path, Value: name})
+		}
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		options = append(options, ui.SelectOption{Label: editor, Description: "$EDITOR", Value: editor})
+	}
+	options = append(options, ui.SelectOption{Label: "Auto-detect", Description: "Pick the first available at launch time"})
+
+	idx := ui.Select("Open scripts with", options)
+	if idx < 0 {
+		return ""
+	}
+	editor, _ := options[idx].Value.(string)
+	return editor
+}
+
+// initConfigPath returns the config file to write: --config, or the one
+// paths.ConfigFile picks
+func initConfigPath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	return paths.ConfigFile(), nil
+}
+
+// writeConfigKeys merges settings into the YAML config file at path, keeping other keys
+func writeConfigKeys(path string, settings map[string]any) error {
+	config := make(map[string]any)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if config == nil {
+			config = make(map[string]any)
+		}
+	}
+
+	for key, value := range settings {
+		config[key] = value
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(path, out, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// promptLine asks for a line of input, returning def when the answer is empty
+func promptLine(reader *bufio.Reader, label, def string) string {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def
+	}
+	return answer
+}
+
+// fileExistsAt reports whether path exists
+func fileExistsAt(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/neptaco/uniforge/pkg/hooks"
 	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/neptaco/uniforge/pkg/unity"
@@ -16,7 +19,10 @@ var (
 	installChangeset    string
 	installArchitecture string
 	installForce        bool
+	installIgnoreDisk   bool
+	installNoHub        bool
 	installProject      string
+	installLockTimeout  time.Duration
 )
 
 var editorInstallCmd = &cobra.Command{
@@ -30,6 +36,18 @@ If the editor is already installed:
   - Without --modules: skips installation (use --force to reinstall)
   - With --modules: checks if modules are installed and adds missing ones
 
+On Linux and macOS without Unity Hub (or with --no-hub), the official editor
+and module installers are downloaded for the version's changeset and extracted
+into UNIFORGE_EDITOR_BASE_PATH or the Unity Hub default location.
+  - Linux: .tar.xz archives, requires tar and xz. Modules: android, ios, webgl,
+    windows-mono, mac-mono, linux-il2cpp, documentation
+  - macOS: .pkg installers, expanded with pkgutil without administrator rights.
+    Modules: android, ios, appletv, webgl, windows-mono, linux-mono,
+    linux-il2cpp, mac-il2cpp, documentation
+
+Only one install runs at a time; others wait for it for up to --lock-timeout
+(install-lock-timeout in the config file), e.g. two CI jobs on one agent.
+
 Examples:
   # Interactive mode - select version and modules from TUI
   uniforge editor install
@@ -40,6 +58,11 @@ Examples:
   # Install specific version
   uniforge editor install 2022.3.10f1
 
+  # Install the newest patch of a stream, the newest LTS, or the newest Unity 6
+  uniforge editor install 2022.3
+  uniforge editor install lts
+  uniforge editor install 6
+
   # Install from specific project path
   uniforge editor install -p /path/to/project
 
@@ -47,7 +70,13 @@ Examples:
   uniforge editor install 2022.3.10f1 --modules ios,android
 
   # Add modules to existing editor (only installs missing modules)
-  uniforge editor install 2022.3.10f1 --modules webgl`,
+  uniforge editor install 2022.3.10f1 --modules webgl
+
+  # Install although the free disk space looks too small
+  uniforge editor install 2022.3.10f1 --ignore-disk-space
+
+  # CI machine without Unity Hub
+  uniforge editor install -p . --no-hub --modules android,webgl`,
 	Args:         cobra.MaximumNArgs(1),
 	RunE:         runInstall,
 	SilenceUsage: true,
@@ -60,19 +89,54 @@ func init() {
 	editorInstallCmd.Flags().StringVar(&installModules, "modules", "", "Comma-separated list of modules to install (e.g., ios,android)")
 	editorInstallCmd.Flags().StringVar(&installChangeset, "changeset", "", "Changeset for versions not in release list")
 	editorInstallCmd.Flags().StringVar(&installArchitecture, "architecture", "", "Architecture to install (x86_64 or arm64, auto-detect if not specified)")
+	editorInstallCmd.Flags().BoolVar(&installNoHub, "no-hub", false, "Linux, macOS: download the official installers instead of using Unity Hub (default when Hub is not installed)")
 	editorInstallCmd.Flags().BoolVar(&installForce, "force", false, "Force reinstall even if already installed")
+	editorInstallCmd.Flags().BoolVar(&installIgnoreDisk, "ignore-disk-space", false, "Install even when the estimated size exceeds the free disk space")
+	addLockTimeoutFlag(editorInstallCmd, &installLockTimeout)
+}
+
+// addLockTimeoutFlag adds --lock-timeout to a command that installs editors
+func addLockTimeoutFlag(cmd *cobra.Command, timeout *time.Duration) {
+	cmd.Flags().DurationVar(timeout, "lock-timeout", hub.DefaultInstallLockTimeout, "How long to wait for another install to finish (0 fails at once)")
 }
 
-func runInstall(cmd *cobra.Command, args []string) error {
+// applyLockTimeout sets how long installs of hubClient wait for another
+// install: --lock-timeout if given, else install-lock-timeout from the config
+// file or UNIFORGE_INSTALL_LOCK_TIMEOUT
+func applyLockTimeout(cmd *cobra.Command, hubClient *hub.Client, timeout time.Duration) {
+	if cmd.Flags().Changed("lock-timeout") || !viper.IsSet("install-lock-timeout") {
+		hubClient.InstallLockTimeout = timeout
+		return
+	}
+	hubClient.InstallLockTimeout = viper.GetDuration("install-lock-timeout")
+}
+
+func runInstall(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		if err == nil {
+			refreshProjectStatus(cmp.Or(installProject, "."))
+		}
+	}()
+
 	var version string
 	var changeset string
 
 	hubClient := hub.NewClient()
 	hubClient.NoCache = viper.GetBool("no-cache")
+	hubClient.NoHub = installNoHub
+	applyLockTimeout(cmd, hubClient, installLockTimeout)
 
 	if len(args) > 0 {
-		// Version specified as positional argument
+		// Version specified as positional argument (may be a spec like "2022.3" or "lts")
 		version = args[0]
+		if !hub.IsExactVersion(version) {
+			resolved, err := hubClient.ResolveVersionSpec(version, true)
+			if err != nil {
+				return err
+			}
+			ui.Info("Resolved %s to Unity %s", version, resolved)
+			version = resolved
+		}
 	} else if installProject != "" {
 		// Project path specified - detect from project
 		ui.Debug("Detecting Unity version from project", "path", installProject)
@@ -132,9 +196,15 @@ func runInstall(cmd *cobra.Command, args []string) error {
 				missingModules := hubClient.GetMissingModules(installedPath, modules)
 				if len(missingModules) > 0 {
 					ui.Info("Unity Editor %s is installed, but missing modules: %s", version, strings.Join(missingModules, ", "))
+					if err := preflightInstall(hubClient, version, missingModules, false, installIgnoreDisk); err != nil {
+						return err
+					}
 					ui.Info("Installing missing modules...")
 
-					if err := hubClient.InstallModules(version, missingModules); err != nil {
+					start := time.Now()
+					err := hubClient.InstallModules(version, missingModules)
+					notifyHook(hooks.EventInstall, version, start, err)
+					if err != nil {
 						return fmt.Errorf("failed to install modules: %w", err)
 					}
 
@@ -169,6 +239,10 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if err := preflightInstall(hubClient, version, modules, true, installIgnoreDisk); err != nil {
+		return err
+	}
+
 	ui.Info("Installing Unity Editor %s", version)
 
 	// Configure installation options
@@ -179,7 +253,10 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		Architecture: installArchitecture,
 	}
 
-	if err := hubClient.InstallEditorWithOptions(options); err != nil {
+	start := time.Now()
+	err = hubClient.InstallEditorWithOptions(options)
+	notifyHook(hooks.EventInstall, version, start, err)
+	if err != nil {
 		return fmt.Errorf("failed to install Unity Editor: %w", err)
 	}
 
@@ -190,3 +267,28 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// preflightInstall shows the estimated install size and checks free disk space.
+// With ignoreDiskSpace, insufficient disk space is reported as a warning instead
+// of an error.
+func preflightInstall(hubClient *hub.Client, version string, modules []string, includeEditor, ignoreDiskSpace bool) error {
+	release := hubClient.FindRelease(version)
+	if release == nil {
+		ui.Debug("No release metadata, skipping size preflight", "version", version)
+		return nil
+	}
+
+	size := hub.EstimateInstallSize(release, modules, includeEditor)
+	if size.Download > 0 || size.Installed > 0 {
+		ui.Muted("Download size: %s, installed size: %s", ui.FormatBytes(size.Download), ui.FormatBytes(size.Installed))
+	}
+
+	if err := hubClient.CheckDiskSpace(size); err != nil {
+		if ignoreDiskSpace {
+			ui.Warn("%v", err)
+			return nil
+		}
+		return fmt.Errorf("%w (use --ignore-disk-space to install anyway)", err)
+	}
+	return nil
+}
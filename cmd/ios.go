@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var iosCmd = &cobra.Command{
+	Use:   "ios",
+	Short: "Work with iOS builds",
+	Long:  `Commands to archive and export Xcode projects generated by Unity iOS builds.`,
+}
+
+func init() {
+	rootCmd.AddCommand(iosCmd)
+}
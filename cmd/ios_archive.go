@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/xcode"
+	"github.com/spf13/cobra"
+)
+
+var (
+	iosArchiveProject       string
+	iosArchiveScheme        string
+	iosArchiveConfiguration string
+	iosArchiveOutput        string
+	iosArchiveExportOptions string
+	iosArchiveOpen          bool
+	iosArchiveVerbose       bool
+)
+
+var iosArchiveCmd = &cobra.Command{
+	Use:   "archive [xcode-project-dir]",
+	Short: "Archive a Unity-generated Xcode project",
+	Long: `Archive the Xcode project produced by a Unity iOS build with xcodebuild.
+
+When no directory is given, the newest directory under the project's
+Build/ or Builds/ directory that contains Unity-iPhone.xcodeproj is used.
+Unity-iPhone.xcworkspace is preferred when present (CocoaPods).
+
+With --export-options, an .ipa is exported from the archive into the
+output directory.
+
+Examples:
+  # Archive the latest iOS build of the current project
+  uniforge ios archive
+
+  # Archive and export an .ipa
+  uniforge ios archive Builds/iOS --export-options exportOptions.plist
+
+  # Archive and open the result in Xcode Organizer
+  uniforge ios archive --open`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runIOSArchive,
+}
+
+func init() {
+	iosCmd.AddCommand(iosArchiveCmd)
+
+	iosArchiveCmd.Flags().StringVarP(&iosArchiveProject, "project", "p", ".", "Unity project path (used to locate the build)")
+	iosArchiveCmd.Flags().StringVar(&iosArchiveScheme, "scheme", xcode.DefaultScheme, "Xcode scheme")
+	iosArchiveCmd.Flags().StringVar(&iosArchiveConfiguration, "configuration", "Release", "Build configuration")
+	iosArchiveCmd.Flags().StringVarP(&iosArchiveOutput, "output", "o", "", "Output directory (default: <xcode-project-dir>/build)")
+	iosArchiveCmd.Flags().StringVar(&iosArchiveExportOptions, "export-options", "", "exportOptions.plist used to export an .ipa")
+	iosArchiveCmd.Flags().BoolVar(&iosArchiveOpen, "open", false, "Open the archive in Xcode when done")
+	iosArchiveCmd.Flags().BoolVarP(&iosArchiveVerbose, "verbose", "v", false, "Show full xcodebuild output")
+}
+
+func runIOSArchive(cmd *cobra.Command, args []string) error {
+	var dir string
+	if len(args) > 0 {
+		dir = args[0]
+	} else {
+		var err error
+		dir, err = findLatestXcodeBuild(iosArchiveProject)
+		if err != nil {
+			return err
+		}
+	}
+
+	project, err := xcode.FindProject(dir)
+	if err != nil {
+		return err
+	}
+
+	outputDir := iosArchiveOutput
+	if outputDir == "" {
+		outputDir = filepath.Join(project.Dir, "build")
+	}
+	archivePath := filepath.Join(outputDir, iosArchiveScheme+".xcarchive")
+
+	ui.Info("Archiving %s (%s)...", iosArchiveScheme, iosArchiveConfiguration)
+	archivePath, err = project.Archive(xcode.ArchiveOptions{
+		Scheme:        iosArchiveScheme,
+		Configuration: iosArchiveConfiguration,
+		ArchivePath:   archivePath,
+		Verbose:       iosArchiveVerbose,
+	})
+	if err != nil {
+		var signingErr *xcode.SigningError
+		if errors.As(err, &signingErr) {
+			ui.Error("%s", signingErr.Error())
+			ui.Muted("Open the project in Xcode and check Signing & Capabilities, or set a team in Player Settings")
+			return fmt.Errorf("archive failed due to code signing")
+		}
+		return err
+	}
+	ui.Success("Archive created: %s", archivePath)
+
+	if iosArchiveExportOptions != "" {
+		ui.Info("Exporting .ipa...")
+		if err := xcode.ExportIPA(archivePath, iosArchiveExportOptions, outputDir, iosArchiveVerbose); err != nil {
+			var signingErr *xcode.SigningError
+			if errors.As(err, &signingErr) {
+				ui.Error("%s", signingErr.Error())
+				return fmt.Errorf("export failed due to code signing")
+			}
+			return err
+		}
+		ui.Success("Exported to %s", outputDir)
+	}
+
+	if iosArchiveOpen {
+		if err := exec.Command("open", archivePath).Start(); err != nil {
+			return fmt.Errorf("failed to open archive: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findLatestXcodeBuild returns the most recently modified Xcode project directory in the project's build directories
+func findLatestXcodeBuild(projectPath string) (string, error) {
+	var latest string
+	var latestTime int64
+
+	for _, dir := range []string{"Build", "Builds"} {
+		root := filepath.Join(projectPath, dir)
+		candidates := []string{root}
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				candidates = append(candidates, filepath.Join(root, e.Name()))
+			}
+		}
+
+		for _, c := range candidates {
+			if !xcode.IsXcodeProjectDir(c) {
+				continue
+			}
+			info, err := os.Stat(filepath.Join(c, xcode.DefaultScheme+".xcodeproj"))
+			if err != nil {
+				continue
+			}
+			if info.ModTime().UnixNano() > latestTime {
+				latest = c
+				latestTime = info.ModTime().UnixNano()
+			}
+		}
+	}
+
+	if latest == "" {
+		return "", fmt.Errorf("no Xcode project found under %s (Build/ or Builds/)", projectPath)
+	}
+
+	ui.Debug("Found Xcode project", "path", latest)
+	return latest, nil
+}
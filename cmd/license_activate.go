@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/license"
 	"github.com/neptaco/uniforge/pkg/ui"
@@ -31,6 +32,8 @@ Credentials can be provided via flags or environment variables:
   UNITY_PASSWORD  - Password
   UNITY_SERIAL    - Serial key (required for Plus/Pro only)
 
+When they are absent, credentials saved with 'uniforge license login' are used.
+
 Examples:
   # Activate Personal license (no serial needed)
   export UNITY_USERNAME=user@example.com
@@ -44,7 +47,10 @@ Examples:
   uniforge license activate
 
   # Specify Unity version
-  uniforge license activate --version 2022.3.10f1`,
+  uniforge license activate --version 2022.3.10f1
+
+  # Use the newest installed 2022.3 editor
+  uniforge license activate --version 2022.3`,
 	RunE: runLicenseActivate,
 }
 
@@ -59,10 +65,22 @@ func init() {
 }
 
 func runLicenseActivate(cmd *cobra.Command, args []string) error {
-	// Get credentials from flags or environment
-	username := getCredential(licenseUsername, "UNITY_USERNAME")
-	password := getCredential(licensePassword, "UNITY_PASSWORD")
-	serial := getCredential(licenseSerial, "UNITY_SERIAL")
+	// Get credentials from flags or environment, falling back to the credential store
+	creds := license.Credentials{
+		Username: getCredential(licenseUsername, "UNITY_USERNAME"),
+		Password: getCredential(licensePassword, "UNITY_PASSWORD"),
+		Serial:   getCredential(licenseSerial, "UNITY_SERIAL"),
+	}
+	if creds.Username == "" || creds.Password == "" {
+		stored, err := license.LoadCredentials()
+		if err != nil {
+			ui.Debug("No stored credentials", "error", err)
+		} else {
+			ui.Muted("Using credentials saved by 'uniforge license login'")
+			creds.Merge(stored)
+		}
+	}
+	username, password, serial := creds.Username, creds.Password, creds.Serial
 
 	// Warn if password is provided via flag
 	if licensePassword != "" {
@@ -71,10 +89,10 @@ func runLicenseActivate(cmd *cobra.Command, args []string) error {
 
 	// Validate credentials
 	if username == "" {
-		return fmt.Errorf("username is required (use --username or UNITY_USERNAME env)")
+		return errs.New(errs.LicenseMissing, "username is required (use --username, UNITY_USERNAME env or 'uniforge license login')")
 	}
 	if password == "" {
-		return fmt.Errorf("password is required (use --password or UNITY_PASSWORD env)")
+		return errs.New(errs.LicenseMissing, "password is required (use --password, UNITY_PASSWORD env or 'uniforge license login')")
 	}
 	// Note: serial is optional for Personal license, required for Plus/Pro
 
@@ -115,13 +133,19 @@ func getEditorPath(version string) (string, error) {
 	hubClient := hub.NewClient()
 
 	if version != "" {
-		// Use specific version
+		// Use specific version (specs like "2022.3" resolve to the newest installed patch)
+		resolved, err := hubClient.ResolveVersionSpec(version, false)
+		if err != nil {
+			return "", err
+		}
+		version = resolved
+
 		installed, path, err := hubClient.IsEditorInstalled(version)
 		if err != nil {
 			return "", fmt.Errorf("failed to check editor installation: %w", err)
 		}
 		if !installed {
-			return "", fmt.Errorf("unity %s is not installed", version)
+			return "", errs.New(errs.NotInstalled, "unity %s is not installed", version)
 		}
 		return path, nil
 	}
@@ -132,7 +156,7 @@ func getEditorPath(version string) (string, error) {
 		return "", fmt.Errorf("failed to list installed editors: %w", err)
 	}
 	if len(editors) == 0 {
-		return "", fmt.Errorf("no Unity editors installed. Install one with: uniforge editor install <version>")
+		return "", errs.New(errs.NotInstalled, "no Unity editors installed")
 	}
 
 	// Use the first available editor
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/neptaco/uniforge/pkg/license"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	loginUsername      string
+	loginSerial        string
+	loginPasswordStdin bool
+)
+
+var licenseLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Save license credentials to the OS credential store",
+	Long: `Save Unity ID credentials (and optionally a serial key) to the OS credential
+store: macOS Keychain, Windows Credential Manager, or the Secret Service on
+Linux (requires secret-tool).
+
+'uniforge license activate' uses the saved credentials when they are not
+given via flags or environment variables, so the password does not have to
+live in the environment or shell history.
+
+Examples:
+  # Prompt for username and password
+  uniforge license login
+
+  # Save a Plus/Pro serial as well
+  uniforge license login --username user@example.com --serial XXXX-XXXX-XXXX-XXXX
+
+  # Read the password from stdin (for provisioning scripts)
+  echo "$PASSWORD" | uniforge license login --username user@example.com --password-stdin`,
+	RunE:         runLicenseLogin,
+	SilenceUsage: true,
+}
+
+var licenseLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove saved license credentials",
+	Long: `Remove the credentials saved by 'uniforge license login' from the OS credential store.
+
+This does not return the activated license; use 'uniforge license return' for that.
+
+Examples:
+  uniforge license logout`,
+	RunE:         runLicenseLogout,
+	SilenceUsage: true,
+}
+
+func init() {
+	licenseCmd.AddCommand(licenseLoginCmd)
+	licenseCmd.AddCommand(licenseLogoutCmd)
+
+	licenseLoginCmd.Flags().StringVarP(&loginUsername, "username", "u", "", "Unity ID email")
+	licenseLoginCmd.Flags().StringVarP(&loginSerial, "serial", "s", "", "Serial key for Plus/Pro license")
+	licenseLoginCmd.Flags().BoolVar(&loginPasswordStdin, "password-stdin", false, "Read the password from stdin")
+}
+
+func runLicenseLogin(cmd *cobra.Command, args []string) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	username := loginUsername
+	if username == "" {
+		if loginPasswordStdin || !ui.CanPrompt() {
+			return fmt.Errorf("username is required (use --username)")
+		}
+		fmt.Print("Unity ID email: ")
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read username: %w", err)
+		}
+		username = strings.TrimSpace(line)
+	}
+	if username == "" {
+		return fmt.Errorf("username is required")
+	}
+
+	password, err := readPassword(reader)
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return fmt.Errorf("password is required")
+	}
+
+	creds := license.Credentials{
+		Username: username,
+		Password: password,
+		Serial:   loginSerial,
+	}
+	if err := license.SaveCredentials(creds); err != nil {
+		return err
+	}
+
+	ui.Success("Saved credentials for %s", username)
+	return nil
+}
+
+// readPassword reads the password from stdin with --password-stdin, or prompts without echo
+func readPassword(reader *bufio.Reader) (string, error) {
+	if loginPasswordStdin {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	if !ui.CanPrompt() {
+		return "", fmt.Errorf("cannot prompt for the password (use --password-stdin)")
+	}
+	fmt.Print("Password: ")
+	password, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(password), nil
+}
+
+func runLicenseLogout(cmd *cobra.Command, args []string) error {
+	if err := license.DeleteCredentials(); err != nil {
+		return err
+	}
+	ui.Success("Removed saved credentials")
+	return nil
+}
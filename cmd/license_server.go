@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var licenseServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Configure Unity Licensing Server",
+	Long:  `Commands for configuring the Unity Licensing Server used by this machine.`,
+}
+
+func init() {
+	licenseCmd.AddCommand(licenseServerCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/license"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	licenseServerFloating   bool
+	licenseServerToolset    string
+	licenseServerScope      string
+	licenseServerNoValidate bool
+)
+
+var licenseServerSetCmd = &cobra.Command{
+	Use:   "set <url>",
+	Short: "Write the licensing server to services-config.json",
+	Long: `Write or update services-config.json so Unity uses the given Unity Licensing
+Server. Other settings in the file are preserved.
+
+The server is validated by requesting its /v1/admin/status endpoint before
+the configuration is written (skip with --no-validate).
+
+Scopes:
+  user     Per-user config (macOS and Linux)
+  machine  Shared by all users, usually requires admin rights (the only option on Windows)
+
+Examples:
+  # Use a floating (Build Server) license
+  uniforge license server set http://license-server:8080
+
+  # Named-user licensing server for all users of the machine
+  sudo uniforge license server set http://license-server:8080 --floating=false --scope machine
+
+  # Select a toolset configured on the server
+  uniforge license server set http://license-server:8080 --toolset ci-agents`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runLicenseServerSet,
+	SilenceUsage: true,
+}
+
+func init() {
+	licenseServerCmd.AddCommand(licenseServerSetCmd)
+
+	licenseServerSetCmd.Flags().BoolVar(&licenseServerFloating, "floating", true, "Enable the floating license API (enableFloatingApi)")
+	licenseServerSetCmd.Flags().StringVar(&licenseServerToolset, "toolset", "", "Toolset ID configured on the licensing server")
+	licenseServerSetCmd.Flags().StringVar(&licenseServerScope, "scope", string(license.DefaultConfigScope()), "Config location: user or machine")
+	licenseServerSetCmd.Flags().BoolVar(&licenseServerNoValidate, "no-validate", false, "Write the configuration without contacting the server")
+}
+
+func runLicenseServerSet(cmd *cobra.Command, args []string) error {
+	serverURL := args[0]
+	if err := license.ValidateServerURL(serverURL); err != nil {
+		return err
+	}
+
+	path, err := license.ServicesConfigPath(license.ConfigScope(licenseServerScope))
+	if err != nil {
+		return err
+	}
+
+	if !licenseServerNoValidate {
+		err := ui.WithSpinnerNoResult("Checking licensing server...", func() error {
+			return license.CheckServerStatus(serverURL, 10*time.Second)
+		})
+		if err != nil {
+			return fmt.Errorf("%w (use --no-validate to write the configuration anyway)", err)
+		}
+		ui.Success("Licensing server is reachable")
+	}
+
+	cfg := license.ServerConfig{
+		URL:         serverURL,
+		FloatingAPI: licenseServerFloating,
+		Toolset:     licenseServerToolset,
+	}
+	if err := license.WriteServerConfig(path, cfg); err != nil {
+		return err
+	}
+
+	ui.Success("Licensing server configured: %s", serverURL)
+	ui.Muted("Config file: %s", path)
+	return nil
+}
@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/license"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
+var licenseStatusFormat string
+
 var licenseStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check Unity license status",
@@ -18,26 +25,103 @@ Checks the following license types:
   - Unity Hub login
   - Unity Licensing Server (via UNITY_LICENSING_SERVER env or services-config.json)
 
+For every installed editor, shows which license mode it would pick up, so
+build agents can be audited. The serial license's expiration date is read
+from Unity_lic.ulf when available.
+
 Examples:
-  uniforge license status`,
-	RunE: runLicenseStatus,
+  uniforge license status
+
+  # Machine-readable output
+  uniforge license status --format json`,
+	RunE:         runLicenseStatus,
+	SilenceUsage: true,
 }
 
 func init() {
 	licenseCmd.AddCommand(licenseStatusCmd)
+
+	licenseStatusCmd.Flags().StringVar(&licenseStatusFormat, "format", "text", "output format: text, json")
+}
+
+// licenseStatusJSON is the --format json output of license status
+type licenseStatusJSON struct {
+	HasLicense    bool                `json:"hasLicense"`
+	Type          license.LicenseType `json:"type"`
+	LicensePath   string              `json:"licensePath"`
+	HubConfigPath string              `json:"hubConfigPath"`
+	HubLoggedIn   bool                `json:"hubLoggedIn"`
+	ServerURL     string              `json:"serverUrl,omitempty"`
+	Serial        *serialLicenseJSON  `json:"serial,omitempty"`
+	Warnings      []string            `json:"warnings"`
+	Editors       []editorLicenseJSON `json:"editors"`
+}
+
+type serialLicenseJSON struct {
+	SerialMasked   string `json:"serialMasked,omitempty"`
+	LicenseVersion string `json:"licenseVersion,omitempty"`
+	StartDate      string `json:"startDate,omitempty"`
+	StopDate       string `json:"stopDate,omitempty"`
+}
+
+type editorLicenseJSON struct {
+	Version  string              `json:"version"`
+	Path     string              `json:"path"`
+	Mode     license.LicenseType `json:"mode"`
+	Warnings []string            `json:"warnings"`
 }
 
 func runLicenseStatus(cmd *cobra.Command, args []string) error {
+	if licenseStatusFormat != "text" && licenseStatusFormat != "json" {
+		return fmt.Errorf("unknown format: %s", licenseStatusFormat)
+	}
+
 	status, err := license.GetStatus()
 	if err != nil {
 		return fmt.Errorf("failed to check license status: %w", err)
 	}
 
+	// Editors are optional for the audit; a machine without Hub still has a status
+	editors, err := hub.NewClient().ListInstalledEditors()
+	if err != nil {
+		ui.Debug("Failed to list installed editors", "error", err)
+	}
+
+	if licenseStatusFormat == "json" {
+		return printLicenseStatusJSON(status, editors)
+	}
+
+	printLicenseStatus(status)
+
+	if len(editors) > 0 {
+		fmt.Println()
+		fmt.Println("Installed editors:")
+		for _, e := range editors {
+			audit := status.ForEditor(e.Version)
+			fmt.Printf("  %-16s %s\n", e.Version, audit.Mode)
+			for _, w := range audit.Warnings {
+				ui.Warn("%s: %s", e.Version, w)
+			}
+		}
+	}
+
+	return nil
+}
+
+func printLicenseStatus(status *license.Status) {
 	if status.HasLicense {
 		switch status.LicenseType {
 		case license.LicenseTypeSerial:
 			ui.Success("License is active (Serial)")
 			ui.Muted("License file: %s", status.LicensePath)
+			if s := status.Serial; s != nil {
+				if s.SerialMasked != "" {
+					ui.Muted("Serial: %s", s.SerialMasked)
+				}
+				if !s.StopDate.IsZero() {
+					ui.Muted("Expires: %s", s.StopDate.Format("2006-01-02"))
+				}
+			}
 		case license.LicenseTypeHub:
 			ui.Success("License is active (Unity Hub)")
 			ui.Muted("Logged in via Unity Hub")
@@ -48,6 +132,9 @@ func runLicenseStatus(cmd *cobra.Command, args []string) error {
 			ui.Success("License is active (Build Server)")
 			ui.Muted("Server: %s", status.ServerURL)
 		}
+		for _, w := range status.Warnings(time.Now()) {
+			ui.Warn("%s", strings.ToUpper(w[:1])+w[1:])
+		}
 	} else {
 		ui.Warn("No license found")
 		fmt.Println()
@@ -61,6 +148,51 @@ func runLicenseStatus(cmd *cobra.Command, args []string) error {
 		fmt.Println("  - Use 'uniforge license activate' with serial key")
 		fmt.Println("  - Configure UNITY_LICENSING_SERVER environment variable")
 	}
+}
 
-	return nil
+func printLicenseStatusJSON(status *license.Status, editors []hub.EditorInfo) error {
+	output := licenseStatusJSON{
+		HasLicense:    status.HasLicense,
+		Type:          status.LicenseType,
+		LicensePath:   status.LicensePath,
+		HubConfigPath: status.HubConfigPath,
+		HubLoggedIn:   status.HubLoggedIn,
+		ServerURL:     status.ServerURL,
+		Warnings:      status.Warnings(time.Now()),
+		Editors:       []editorLicenseJSON{},
+	}
+	if output.Warnings == nil {
+		output.Warnings = []string{}
+	}
+
+	if s := status.Serial; s != nil {
+		output.Serial = &serialLicenseJSON{
+			SerialMasked:   s.SerialMasked,
+			LicenseVersion: s.LicenseVersion,
+		}
+		if !s.StartDate.IsZero() {
+			output.Serial.StartDate = s.StartDate.Format(time.RFC3339)
+		}
+		if !s.StopDate.IsZero() {
+			output.Serial.StopDate = s.StopDate.Format(time.RFC3339)
+		}
+	}
+
+	for _, e := range editors {
+		audit := status.ForEditor(e.Version)
+		entry := editorLicenseJSON{
+			Version:  e.Version,
+			Path:     e.Path,
+			Mode:     audit.Mode,
+			Warnings: audit.Warnings,
+		}
+		if entry.Warnings == nil {
+			entry.Warnings = []string{}
+		}
+		output.Editors = append(output.Editors, entry)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
@@ -29,8 +30,8 @@ func init() {
 func runList(cmd *cobra.Command, args []string) error {
 	ui.Debug("Listing installed Unity Editor versions")
 
+	hubClient := hub.NewClient()
 	editors, err := ui.WithSpinner("Fetching installed editors...", func() ([]hub.EditorInfo, error) {
-		hubClient := hub.NewClient()
 		return hubClient.ListInstalledEditors()
 	})
 	if err != nil {
@@ -42,13 +43,24 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	support := hubClient.SupportWindows()
+	now := time.Now()
+	// A version installed for several architectures is listed once per architecture
+	installs := make(map[string]int)
+	for _, editor := range editors {
+		installs[editor.Version]++
+	}
 	rows := make([][]string, 0, len(editors))
 	for _, editor := range editors {
-		rows = append(rows, []string{editor.Version, editor.Path})
+		version := editor.Version
+		if installs[version] > 1 && editor.Architecture != "" {
+			version += " (" + editor.Architecture + ")"
+		}
+		rows = append(rows, []string{version, support.Badge(editor.Version, now), editor.Path})
 	}
 
 	t := table.New().
-		Headers("VERSION", "PATH").
+		Headers("VERSION", "SUPPORT", "PATH").
 		Rows(rows...).
 		Border(lipgloss.HiddenBorder()).
 		StyleFunc(func(row, col int) lipgloss.Style {
@@ -59,6 +71,8 @@ func runList(cmd *cobra.Command, args []string) error {
 			case 0:
 				return editorVersionStyle
 			case 1:
+				return supportBadgeStyle(rows[row][col])
+			case 2:
 				return editorPathStyle
 			}
 			return lipgloss.NewStyle()
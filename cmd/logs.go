@@ -7,15 +7,17 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/logger"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/neptaco/uniforge/pkg/unity"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var (
@@ -26,8 +28,14 @@ var (
 	logTrace     bool
 	logFullTrace bool
 	logTimestamp bool
+	logAll       bool
+	logProject   string
+	logNoise     []string
 )
 
+// showNoiseUsage describes the --show-noise flag of the commands that show Unity's log
+const showNoiseUsage = "Show noise of these categories instead of suppressing it (e.g. unity-licensing, package-manager, or all)"
+
 var logCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Display Unity Editor log",
@@ -41,7 +49,22 @@ The log file location is platform-specific:
 Log lines are colorized:
   - Red: Errors and exceptions
   - Yellow: Warnings
-  - Gray: Stack traces and startup noise
+  - Gray: Stack traces and noise shown with --show-noise
+
+Startup noise (licensing, package manager, domain reloads, ...) is suppressed
+and counted per category; the counts are printed at the end. --show-noise
+shows the noise of a category again, or of all with --show-noise all.
+Categories: ` + noiseCategoryNames() + `
+
+With --all, -f follows every log Unity writes while it runs as one stream:
+Editor.log, the Package Manager's upm.log next to it and the AssetImportWorker
+logs in the Logs folder of the project (-p, the current directory by default).
+Each line is prefixed with its colored source, e.g. [Editor ] or [Worker0].
+
+In a terminal, script locations such as "(at Assets/Scripts/Player.cs:30)" are
+links that open the script of the project (-p) at that line in the IDE
+'uniforge project' opens: VS Code and Cursor jump to the line, other IDEs get
+the file. Terminals without link support show them as plain text.
 
 Examples:
   # Show last 100 lines (default)
@@ -56,9 +79,15 @@ Examples:
   # Follow with timestamps
   uniforge logs -f -t
 
+  # Follow Editor.log, upm.log and the project's AssetImportWorker logs together
+  uniforge logs -f --all
+
   # Show raw output without colors
   uniforge logs --raw
 
+  # Also show Package Manager messages
+  uniforge logs --show-noise package-manager
+
   # Show project stack traces (Assets/, Packages/)
   uniforge logs --trace
 
@@ -67,7 +96,8 @@ Examples:
 
   # Open in text editor
   uniforge logs --editor`,
-	RunE: runLog,
+	SilenceUsage: true,
+	RunE:         runLog,
 }
 
 func init() {
@@ -80,9 +110,17 @@ func init() {
 	logCmd.Flags().BoolVar(&logTrace, "trace", false, "Show project stack traces (Assets/, Packages/)")
 	logCmd.Flags().BoolVar(&logFullTrace, "full-trace", false, "Show full stack traces including Unity internals")
 	logCmd.Flags().BoolVarP(&logTimestamp, "timestamp", "t", false, "Show timestamp for each line")
+	logCmd.Flags().BoolVar(&logAll, "all", false, "With -f, also follow upm.log and the project's AssetImportWorker logs")
+	logCmd.Flags().StringSliceVar(&logNoise, "show-noise", nil, showNoiseUsage)
+	logCmd.Flags().StringVarP(&logProject, "project", "p", ".", "Unity project to link scripts in stack traces to and whose AssetImportWorker logs --all follows")
 }
 
 func runLog(cmd *cobra.Command, args []string) error {
+	showNoise, err := logger.ParseNoiseCategories(logNoise)
+	if err != nil {
+		return err
+	}
+
 	logPath, err := unity.GetEditorLogPath()
 	if err != nil {
 		return fmt.Errorf("failed to get log path: %w", err)
@@ -98,11 +136,30 @@ func runLog(cmd *cobra.Command, args []string) error {
 		return openInEditor(logPath)
 	}
 
+	if logAll && !logFollow {
+		return errs.New(errs.Usage, "--all only applies when following with -f")
+	}
+
+	var formatter *logger.Formatter
+	if !logRaw {
+		formatter = logger.NewFormatter(
+			logger.WithNoColor(!ui.ColorEnabled()),
+			logger.WithHideStackTrace(!logFullTrace),
+			logger.WithHideAllStackTraces(!logTrace && !logFullTrace),
+			logger.WithLinker(logLinker(logProject)),
+			logger.WithHideNoise(true),
+			logger.WithShowNoise(showNoise),
+		)
+	}
+
 	if logFollow {
-		return followLog(logPath)
+		if logAll {
+			return followAllLogs(cmd, logPath, formatter)
+		}
+		return followLog(logPath, formatter)
 	}
 
-	return showLog(logPath, logLines)
+	return showLog(logPath, logLines, formatter)
 }
 
 func openInEditor(logPath string) error {
@@ -119,18 +176,104 @@ func openInEditor(logPath string) error {
 	return cmd.Run()
 }
 
-func followLog(logPath string) error {
-	noColor := viper.GetBool("no-color") || os.Getenv("NO_COLOR") != ""
+// followedLog is a log file followed by followLogs
+type followedLog struct {
+	path   string
+	prefix string   // printed before each line; empty when following a single log
+	file   *os.File // nil until the file exists
+	offset int64
+}
 
-	fmt.Printf("Following %s (Ctrl+C to stop)\n\n", logPath)
+// logSourceColors colors the source prefix of each followed log in turn
+var logSourceColors = []string{logger.ColorCyan, logger.ColorMagenta, logger.ColorBlue, logger.ColorGreen}
 
-	var formatter *logger.Formatter
-	if !logRaw && !noColor {
-		formatter = logger.NewFormatter(
-			logger.WithNoColor(false),
-			logger.WithHideStackTrace(!logFullTrace),
-			logger.WithHideAllStackTraces(!logTrace && !logFullTrace),
-		)
+// logSourceWidth pads source names so lines of different logs line up
+const logSourceWidth = 7
+
+// logSourceName returns the short name shown for a log, e.g. Editor, upm or
+// Worker0 for AssetImportWorker0.log
+func logSourceName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".log")
+	return strings.Replace(name, "AssetImportWorker", "Worker", 1)
+}
+
+// newFollowedLog returns a followed log whose lines are prefixed with its
+// source name, colored by its position among the followed logs
+func newFollowedLog(path string, index int) *followedLog {
+	prefix := fmt.Sprintf("[%-*s] ", logSourceWidth, logSourceName(path))
+	if !logRaw && ui.ColorEnabled() {
+		prefix = logSourceColors[index%len(logSourceColors)] + prefix + logger.ColorReset
+	}
+	return &followedLog{path: path, prefix: prefix}
+}
+
+// allLogs returns the logs Unity writes while it runs: Editor.log, upm.log and
+// the asset import worker logs of the project at projectDir
+func allLogs(editorLog, projectDir string) []*followedLog {
+	paths := []string{editorLog}
+	if upmLog, err := unity.GetUpmLogPath(); err == nil {
+		paths = append(paths, upmLog)
+	}
+	if projectDir != "" {
+		workerLogs, err := unity.AssetImportWorkerLogs(projectDir)
+		if err != nil {
+			ui.Debug("Failed to list asset import worker logs", "error", err)
+		}
+		paths = append(paths, workerLogs...)
+	}
+
+	logs := make([]*followedLog, 0, len(paths))
+	for i, path := range paths {
+		logs = append(logs, newFollowedLog(path, i))
+	}
+	return logs
+}
+
+// logLinker links script locations in logs to the project at dir, if dir is
+// inside one
+func logLinker(dir string) logger.Linker {
+	projectRoot, err := unity.FindProjectRoot(dir)
+	if err != nil {
+		return nil
+	}
+	return unity.ScriptLinker(projectRoot)
+}
+
+// followAllLogs follows Editor.log together with the other logs of --all
+func followAllLogs(cmd *cobra.Command, logPath string, formatter *logger.Formatter) error {
+	projectDir, err := unity.FindProjectRoot(logProject)
+	if err != nil {
+		// Outside a project, follow the logs that do not belong to one
+		if cmd.Flags().Changed("project") {
+			return err
+		}
+		ui.Debug("No project to follow AssetImportWorker logs of", "error", err)
+		projectDir = ""
+	}
+
+	workerDir := ""
+	if projectDir != "" {
+		workerDir = unity.ProjectLogsDir(projectDir)
+	}
+	return followLogs(allLogs(logPath, projectDir), workerDir, formatter)
+}
+
+func followLog(logPath string, formatter *logger.Formatter) error {
+	return followLogs([]*followedLog{{path: logPath}}, "", formatter)
+}
+
+// followLogs prints lines appended to logs until interrupted. Logs that do not
+// exist yet are followed from their start once they appear, as are asset
+// import worker logs created in workerDir, if set.
+func followLogs(logs []*followedLog, workerDir string, formatter *logger.Formatter) error {
+	if len(logs) == 1 {
+		fmt.Printf("Following %s (Ctrl+C to stop)\n\n", logs[0].path)
+	} else {
+		names := make([]string, 0, len(logs))
+		for _, l := range logs {
+			names = append(names, logSourceName(l.path))
+		}
+		fmt.Printf("Following %s (Ctrl+C to stop)\n\n", strings.Join(names, ", "))
 	}
 
 	// Set up signal handler for graceful shutdown
@@ -145,26 +288,60 @@ func followLog(logPath string) error {
 	}
 	defer func() { _ = watcher.Close() }()
 
-	// Watch the directory (to detect file recreation)
-	dir := logPath[:len(logPath)-len("/"+logPath[len(logPath)-len("Editor.log"):])]
-	if idx := lastIndexOfPathSeparator(logPath); idx >= 0 {
-		dir = logPath[:idx]
+	// Watch the directories (to detect file recreation and new logs)
+	watchedDirs := make(map[string]bool)
+	watchDir := func(dir string) {
+		if dir == "" || watchedDirs[dir] {
+			return
+		}
+		watchedDirs[dir] = true
+		if err := watcher.Add(dir); err != nil {
+			ui.Debug("Failed to watch directory, falling back to file-only watch", "dir", dir, "error", err)
+		}
 	}
-	if err := watcher.Add(dir); err != nil {
-		ui.Debug("Failed to watch directory, falling back to file-only watch", "error", err)
+	for _, l := range logs {
+		watchDir(filepath.Dir(l.path))
 	}
+	watchDir(workerDir)
 
-	// Also watch the file itself
-	if err := watcher.Add(logPath); err != nil {
-		return fmt.Errorf("failed to watch log file: %w", err)
+	// Open existing files and seek to end
+	for i, l := range logs {
+		if i > 0 {
+			if _, err := os.Stat(l.path); os.IsNotExist(err) {
+				continue
+			}
+		}
+
+		// Also watch the file itself
+		if err := watcher.Add(l.path); err != nil {
+			return fmt.Errorf("failed to watch log file: %w", err)
+		}
+		l.file, l.offset, err = openAndSeekToEnd(l.path)
+		if err != nil {
+			return err
+		}
 	}
+	defer func() {
+		for _, l := range logs {
+			if l.file != nil {
+				_ = l.file.Close()
+			}
+		}
+	}()
 
-	// Open file and seek to end
-	file, offset, err := openAndSeekToEnd(logPath)
-	if err != nil {
-		return err
+	findLog := func(path string) *followedLog {
+		for _, l := range logs {
+			if l.path == path {
+				return l
+			}
+		}
+		if workerDir != "" && filepath.Dir(path) == workerDir && unity.IsAssetImportWorkerLog(filepath.Base(path)) {
+			l := newFollowedLog(path, len(logs))
+			logs = append(logs, l)
+			return l
+		}
+		return nil
 	}
-	defer func() { _ = file.Close() }()
 
 	// Create a ticker for polling (as backup for platforms where fsnotify may not work perfectly)
 	ticker := time.NewTicker(500 * time.Millisecond)
@@ -174,6 +351,7 @@ func followLog(logPath string) error {
 		select {
 		case <-sigChan:
 			fmt.Println("\nStopped following log.")
+			printNoiseSummary(formatter)
 			return nil
 
 		case event, ok := <-watcher.Events:
@@ -183,20 +361,21 @@ func followLog(logPath string) error {
 
 			// Handle file write or create (file recreation)
 			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) {
-				// If file was recreated, reopen it
-				if event.Has(fsnotify.Create) && event.Name == logPath {
-					_ = file.Close()
+				l := findLog(event.Name)
+				if l == nil {
+					continue
+				}
+
+				// If file was (re)created, open it from the beginning
+				if event.Has(fsnotify.Create) || l.file == nil {
 					time.Sleep(100 * time.Millisecond) // Wait for file to be ready
-					file, offset, err = openAndSeekToEnd(logPath)
-					if err != nil {
+					if err := l.reopen(); err != nil {
 						ui.Debug("Failed to reopen file", "error", err)
 						continue
 					}
-					offset = 0 // Start from beginning of new file
 				}
 
-				offset, err = readNewLines(file, offset, formatter)
-				if err != nil {
+				if err := l.readNewLines(formatter); err != nil {
 					ui.Debug("Error reading new lines", "error", err)
 				}
 			}
@@ -209,30 +388,42 @@ func followLog(logPath string) error {
 
 		case <-ticker.C:
 			// Periodic poll as backup
-			offset, err = readNewLines(file, offset, formatter)
-			if err != nil {
-				// File might have been recreated
-				if _, statErr := os.Stat(logPath); statErr == nil {
-					_ = file.Close()
-					file, _, err = openAndSeekToEnd(logPath)
-					if err != nil {
+			for _, l := range logs {
+				if l.file == nil {
+					if _, statErr := os.Stat(l.path); statErr != nil {
+						continue
+					}
+					if err := l.reopen(); err != nil {
 						ui.Debug("Failed to reopen file", "error", err)
+						continue
+					}
+				}
+				if err := l.readNewLines(formatter); err != nil {
+					// File might have been recreated
+					if _, statErr := os.Stat(l.path); statErr == nil {
+						if err := l.reopen(); err != nil {
+							ui.Debug("Failed to reopen file", "error", err)
+						}
 					}
-					offset = 0
 				}
 			}
 		}
 	}
 }
 
-// lastIndexOfPathSeparator returns the index of the last path separator in the path
-func lastIndexOfPathSeparator(path string) int {
-	for i := len(path) - 1; i >= 0; i-- {
-		if path[i] == '/' || path[i] == '\\' {
-			return i
-		}
+// reopen opens the log again to read it from the beginning, e.g. after it was
+// recreated
+func (l *followedLog) reopen() error {
+	if l.file != nil {
+		_ = l.file.Close()
+		l.file = nil
+	}
+	file, _, err := openAndSeekToEnd(l.path)
+	if err != nil {
+		return err
 	}
-	return -1
+	l.file, l.offset = file, 0
+	return nil
 }
 
 // openAndSeekToEnd opens a file and seeks to the end, returning the file and its size
@@ -252,12 +443,15 @@ func openAndSeekToEnd(path string) (*os.File, int64, error) {
 	return file, offset, nil
 }
 
-// readNewLines reads new lines from the file starting at offset
-func readNewLines(file *os.File, offset int64, formatter *logger.Formatter) (int64, error) {
+// readNewLines prints the lines appended to the log since the last read
+func (l *followedLog) readNewLines(formatter *logger.Formatter) error {
+	file, offset := l.file, l.offset
+	defer func() { l.offset = offset }()
+
 	// Get current file size
 	info, err := file.Stat()
 	if err != nil {
-		return offset, err
+		return err
 	}
 
 	// If file was truncated, start from beginning
@@ -267,13 +461,13 @@ func readNewLines(file *os.File, offset int64, formatter *logger.Formatter) (int
 
 	// If no new content, return
 	if info.Size() == offset {
-		return offset, nil
+		return nil
 	}
 
 	// Seek to last position
 	_, err = file.Seek(offset, io.SeekStart)
 	if err != nil {
-		return offset, err
+		return err
 	}
 
 	// Read new content
@@ -285,7 +479,7 @@ func readNewLines(file *os.File, offset int64, formatter *logger.Formatter) (int
 				// Partial line, put it back by adjusting offset
 				break
 			}
-			return offset, err
+			return err
 		}
 
 		// Update offset
@@ -300,18 +494,18 @@ func readNewLines(file *os.File, offset int64, formatter *logger.Formatter) (int
 				formatted := formatter.FormatLine(line)
 				if logTimestamp {
 					ts := time.Now().Format("15:04:05.000")
-					fmt.Printf("%s[%s]%s %s\n", logger.ColorGray, ts, logger.ColorReset, formatted)
+					fmt.Printf("%s %s%s\n", logDim("["+ts+"]"), l.prefix, formatted)
 				} else {
-					fmt.Println(formatted)
+					fmt.Println(l.prefix + formatted)
 				}
 			}
 		} else {
 			// Raw output
-			fmt.Println(line)
+			fmt.Println(l.prefix + line)
 		}
 	}
 
-	return offset, nil
+	return nil
 }
 
 // trimLineEnding removes \n and \r\n from the end of a line
@@ -323,7 +517,7 @@ func trimLineEnding(line string) string {
 	return line
 }
 
-func showLog(logPath string, lines int) error {
+func showLog(logPath string, lines int, formatter *logger.Formatter) error {
 	file, err := os.Open(logPath)
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %w", err)
@@ -353,9 +547,7 @@ func showLog(logPath string, lines int) error {
 		start = 0
 	}
 
-	noColor := viper.GetBool("no-color") || os.Getenv("NO_COLOR") != ""
-
-	if logRaw || noColor {
+	if formatter == nil {
 		// Print raw without formatting
 		for i := start; i < len(allLines); i++ {
 			fmt.Println(allLines[i])
@@ -364,24 +556,47 @@ func showLog(logPath string, lines int) error {
 	}
 
 	// Print with formatting
-	formatter := logger.NewFormatter(
-		logger.WithNoColor(false),
-		logger.WithHideStackTrace(!logFullTrace),
-		logger.WithHideAllStackTraces(!logTrace && !logFullTrace),
-	)
-
 	for i := start; i < len(allLines); i++ {
 		line := allLines[i]
 		if formatter.ShouldShow(line) {
 			formatted := formatter.FormatLine(line)
 			if logTimestamp {
 				// For historical logs, show line number instead of time
-				fmt.Printf("%s[%5d]%s %s\n", logger.ColorGray, i+1, logger.ColorReset, formatted)
+				fmt.Printf("%s %s\n", logDim(fmt.Sprintf("[%5d]", i+1)), formatted)
 			} else {
 				fmt.Println(formatted)
 			}
 		}
 	}
 
+	printNoiseSummary(formatter)
 	return nil
 }
+
+// printNoiseSummary prints how many noise lines the formatter suppressed
+func printNoiseSummary(formatter *logger.Formatter) {
+	if formatter == nil {
+		return
+	}
+	if summary := formatter.NoiseStats().Summary(); summary != "" {
+		fmt.Println()
+		ui.Muted("%s; %s", summary, logger.NoiseHint)
+	}
+}
+
+// noiseCategoryNames lists the categories --show-noise accepts
+func noiseCategoryNames() string {
+	names := make([]string, 0, len(logger.NoiseCategories))
+	for _, c := range logger.NoiseCategories {
+		names = append(names, c.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// logDim dims timestamps, line numbers and separators printed next to log lines
+func logDim(s string) string {
+	if !ui.ColorEnabled() {
+		return s
+	}
+	return logger.ColorGray + s + logger.ColorReset
+}
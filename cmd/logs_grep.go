@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsGrepIgnoreCase bool
+	logsGrepLevel      string
+	logsGrepIn         string
+	logsGrepContext    int
+	logsGrepPrev       bool
+)
+
+var logsGrepCmd = &cobra.Command{
+	Use:   "grep <pattern> [log-file...]",
+	Short: "Search the Unity Editor log",
+	Long: `Search the Unity Editor log for lines matching a regular expression.
+
+Matches keep the coloring of 'uniforge logs' and are printed with their line
+numbers; groups of matches with their context are separated by "--". Unlike
+'logs | grep', stack traces and noise are searched too, and the search can be
+limited to a level or a kind of line:
+
+  --level error     Errors only
+  --level warning   Warnings and errors
+  --in compiler     C# compiler errors and warnings (CSxxxx)
+  --in exception    Exception messages
+  --in stacktrace   Stack trace lines
+  --in noise        Lines 'logs' dims as noise (licensing, package manager, ...)
+
+Editor.log is searched by default, Editor-prev.log of the previous editor
+session with --prev. Other logs, e.g. the build.log a build saved, can be
+given as arguments.
+
+Examples:
+  # Lines mentioning NullReferenceException
+  uniforge logs grep NullReferenceException
+
+  # Errors about a script, with 3 lines of context
+  uniforge logs grep -C 3 --level error PlayerController
+
+  # Compiler errors with code CS0246 in the previous session
+  uniforge logs grep --prev --in compiler CS0246
+
+  # Search a saved build log, ignoring case
+  uniforge logs grep -i "out of memory" Builds/Android/build.log`,
+	Args:         cobra.MinimumNArgs(1),
+	RunE:         runLogsGrep,
+	SilenceUsage: true,
+}
+
+func init() {
+	logCmd.AddCommand(logsGrepCmd)
+
+	logsGrepCmd.Flags().BoolVarP(&logsGrepIgnoreCase, "ignore-case", "i", false, "Match the pattern case-insensitively")
+	logsGrepCmd.Flags().StringVar(&logsGrepLevel, "level", "", "Only match lines of this level: error, warning (warnings and errors)")
+	logsGrepCmd.Flags().StringVar(&logsGrepIn, "in", "", "Only match lines of this kind: compiler, exception, stacktrace, noise")
+	logsGrepCmd.Flags().IntVarP(&logsGrepContext, "context", "C", 0, "Lines of context to show around each match")
+	logsGrepCmd.Flags().BoolVar(&logsGrepPrev, "prev", false, "Search Editor-prev.log of the previous editor session")
+}
+
+func runLogsGrep(cmd *cobra.Command, args []string) error {
+	opts, err := logsGrepOptions(args[0])
+	if err != nil {
+		return err
+	}
+
+	logPaths := args[1:]
+	if len(logPaths) > 0 && logsGrepPrev {
+		return errs.New(errs.Usage, "--prev cannot be used with log files")
+	}
+	if len(logPaths) == 0 {
+		logPath, err := unity.GetEditorLogPath()
+		if logsGrepPrev {
+			logPath, err = unity.GetEditorPrevLogPath()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get log path: %w", err)
+		}
+		logPaths = []string{logPath}
+	}
+
+	formatter := logger.NewFormatter(
+		logger.WithNoColor(!ui.ColorEnabled()),
+		logger.WithLinker(logLinker(".")),
+	)
+	matches := 0
+	for _, logPath := range logPaths {
+		file, err := os.Open(logPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("log file not found: %s", logPath)
+			}
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		groups, err := formatter.Search(file, opts)
+		_ = file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", logPath, err)
+		}
+
+		if len(logPaths) > 1 && len(groups) > 0 {
+			fmt.Println(headerStyle.Render(logPath))
+		}
+		for i, group := range groups {
+			if i > 0 {
+				fmt.Println(logDim("--"))
+			}
+			for _, line := range group {
+				if line.Match {
+					matches++
+				}
+				printLogsGrepLine(formatter, line)
+			}
+		}
+	}
+
+	if matches == 0 {
+		ui.Muted("No matches")
+	}
+	return nil
+}
+
+// logsGrepOptions builds the search options of the command line
+func logsGrepOptions(pattern string) (logger.SearchOptions, error) {
+	opts := logger.SearchOptions{Context: logsGrepContext}
+	if logsGrepIgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return opts, errs.New(errs.Usage, "invalid pattern: %v", err)
+	}
+	opts.Pattern = re
+
+	switch logsGrepLevel {
+	case "":
+	case "error":
+		opts.Level = logger.LogLevelError
+	case "warning":
+		opts.Level = logger.LogLevelWarning
+	default:
+		return opts, errs.New(errs.Usage, "unknown level: %s (use error or warning)", logsGrepLevel)
+	}
+
+	if logsGrepIn != "" {
+		opts.Scope = logger.SearchScope(logsGrepIn)
+		known := make([]string, 0, len(logger.SearchScopes))
+		valid := false
+		for _, scope := range logger.SearchScopes {
+			known = append(known, string(scope))
+			valid = valid || scope == opts.Scope
+		}
+		if !valid {
+			return opts, errs.New(errs.Usage, "unknown kind of line: %s (use %s)", logsGrepIn, strings.Join(known, ", "))
+		}
+	}
+
+	if logsGrepContext < 0 {
+		return opts, errs.New(errs.Usage, "--context must not be negative")
+	}
+	return opts, nil
+}
+
+// printLogsGrepLine prints a line of a search result with its line number,
+// followed by ':' for a match and '-' for context as grep does
+func printLogsGrepLine(formatter *logger.Formatter, line logger.SearchLine) {
+	sep := "-"
+	if line.Match {
+		sep = ":"
+	}
+	fmt.Printf("%s %s\n", logDim(fmt.Sprintf("%6d%s", line.Number, sep)), formatter.FormatLine(line.Text))
+}
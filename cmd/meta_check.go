@@ -1,19 +1,25 @@
 package cmd
 
 import (
-	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
 
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/neptaco/uniforge/pkg/unity"
 	"github.com/spf13/cobra"
 )
 
 var (
-	metaCheckFix   bool
-	metaCheckForce bool
+	metaCheckFix            bool
+	metaCheckForce          bool
+	metaCheckFormat         string
+	metaCheckBaseline       string
+	metaCheckNoBaseline     bool
+	metaCheckUpdateBaseline bool
+	metaCheckAll            bool
 )
 
 var metaCheckCmd = &cobra.Command{
@@ -25,6 +31,22 @@ This command checks for:
   - Missing .meta files (Error): Assets without corresponding .meta files
   - Orphan .meta files (Warning): .meta files without corresponding assets
   - Duplicate GUIDs (Error): Multiple .meta files with the same GUID
+  - Case collisions (Error): Assets whose names differ only in case, which
+    clash on case-insensitive file systems (Windows, macOS)
+  - Invalid names (Error): Characters or device names Windows does not allow
+  - Long paths (Warning): Paths that may exceed Windows' MAX_PATH once cloned
+  - Invalid .meta files: folderAsset not matching whether the asset is a
+    folder (Error), or a fileFormatVersion other than 2 (Warning)
+  - Empty folders (Warning): Folders git will not keep, leaving their .meta
+    files orphaned in other clones (add a .gitkeep to keep them)
+
+Issues listed in <project>/.uniforge/meta-baseline.json are accepted and do
+not fail the check, so that a project with existing issues can adopt it and
+still catch new ones. Write the baseline with --update-baseline and commit it;
+a duplicate GUID only stays accepted while no further file gets that GUID.
+
+--format json prints the issues for scripts, and --format sarif prints a SARIF
+log for code scanning (e.g. GitHub's upload-sarif action).
 
 Examples:
   # Check current directory
@@ -37,40 +59,230 @@ Examples:
   uniforge meta check --fix
 
   # Fix without confirmation (for CI)
-  uniforge meta check --fix --force`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMetaCheck,
+  uniforge meta check --fix --force
+
+  # Accept the current issues, then fail only on new ones
+  uniforge meta check --update-baseline
+
+  # Report to code scanning
+  uniforge meta check --format sarif > meta.sarif
+
+  # Check every project of the workspace (.uniforge/workspace.yaml)
+  uniforge meta check --all`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runMetaCheck,
+	SilenceUsage: true,
 }
 
 func init() {
 	metaCmd.AddCommand(metaCheckCmd)
 
 	metaCheckCmd.Flags().BoolVar(&metaCheckFix, "fix", false, "Remove orphan .meta files")
-	metaCheckCmd.Flags().BoolVar(&metaCheckForce, "force", false, "Skip confirmation when using --fix (for CI)")
+	metaCheckCmd.Flags().BoolVar(&metaCheckForce, "force", false, "Skip confirmation when using --fix (same as --yes)")
+	metaCheckCmd.Flags().StringVar(&metaCheckFormat, "format", "text", "Output format: text, json, sarif")
+	metaCheckCmd.Flags().StringVar(&metaCheckBaseline, "baseline", "", "Baseline file (default: <project>/.uniforge/meta-baseline.json)")
+	metaCheckCmd.Flags().BoolVar(&metaCheckNoBaseline, "no-baseline", false, "Report every issue, including those in the baseline")
+	metaCheckCmd.Flags().BoolVar(&metaCheckUpdateBaseline, "update-baseline", false, "Write the current issues to the baseline and exit")
+	addAllFlag(metaCheckCmd, &metaCheckAll)
+}
+
+// metaCheckJSON is the --format json output of meta check
+type metaCheckJSON struct {
+	Project   string            `json:"project"`
+	Errors    int               `json:"errors"`
+	Warnings  int               `json:"warnings"`
+	Baselined int               `json:"baselined"`
+	Fixed     int               `json:"fixedBaseline"` // Baseline entries that no longer occur
+	Issues    []unity.MetaIssue `json:"issues"`
 }
 
 func runMetaCheck(cmd *cobra.Command, args []string) error {
-	projectPath := "."
-	if len(args) > 0 {
-		projectPath = args[0]
+	switch metaCheckFormat {
+	case "text", "json", "sarif":
+	default:
+		return errs.New(errs.Usage, "unknown format: %s (use text, json or sarif)", metaCheckFormat)
+	}
+	text := metaCheckFormat == "text"
+	if metaCheckFix && !text {
+		return errs.New(errs.Usage, "--fix can only be used with --format text")
+	}
+	if metaCheckNoBaseline && metaCheckUpdateBaseline {
+		return errs.New(errs.Usage, "--no-baseline and --update-baseline cannot be used together")
+	}
+	if metaCheckAll && metaCheckBaseline != "" {
+		return errs.New(errs.Usage, "--baseline cannot be used with --all; each project uses its own baseline")
+	}
+
+	if metaCheckAll && text {
+		return runInWorkspace(args, func(projectPath, _ string) error {
+			report, err := checkMetaProject(projectPath)
+			if err != nil || report == nil {
+				return err
+			}
+			if err := printMetaCheckReport(report); err != nil {
+				return err
+			}
+			if report.result.HasErrors() {
+				return fmt.Errorf(".meta check failed")
+			}
+			return nil
+		})
+	}
+
+	projectPaths := []string{"."}
+	if metaCheckAll {
+		ws, err := findWorkspace(args)
+		if err != nil {
+			return err
+		}
+		projectPaths = ws.Projects
+	} else if len(args) > 0 {
+		projectPaths = args
+	}
+
+	var reports []*metaCheckReport
+	for _, projectPath := range projectPaths {
+		report, err := checkMetaProject(projectPath)
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			reports = append(reports, report)
+		}
+	}
+	if len(reports) == 0 {
+		return nil // Only the baseline was updated
+	}
+
+	switch metaCheckFormat {
+	case "json":
+		out := make([]metaCheckJSON, 0, len(reports))
+		for _, report := range reports {
+			out = append(out, report.json())
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		var err error
+		if metaCheckAll {
+			err = encoder.Encode(out)
+		} else {
+			err = encoder.Encode(out[0])
+		}
+		if err != nil {
+			return err
+		}
+	case "sarif":
+		sarif := make([]unity.MetaReport, 0, len(reports))
+		for _, report := range reports {
+			sarif = append(sarif, unity.MetaReport{ProjectPath: report.project.Path, Issues: report.result.Issues()})
+		}
+		data, err := unity.MetaSARIF(Version, sarif...)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stdout.Write(data); err != nil {
+			return err
+		}
+	default:
+		if err := printMetaCheckReport(reports[0]); err != nil {
+			return err
+		}
 	}
 
+	for _, report := range reports {
+		if report.result.HasErrors() {
+			exit(cmd, 1)
+		}
+	}
+	return nil
+}
+
+// metaCheckReport is the meta check of one project, after applying its baseline
+type metaCheckReport struct {
+	project      *unity.Project
+	checker      *unity.MetaChecker
+	result       *unity.MetaCheckResult
+	baselinePath string
+	suppressed   int // Issues accepted by the baseline
+	fixed        int // Baseline entries that no longer occur
+}
+
+// checkMetaProject checks the project at projectPath. With --update-baseline
+// it writes the baseline instead and returns nil.
+func checkMetaProject(projectPath string) (*metaCheckReport, error) {
+	text := metaCheckFormat == "text"
 	project, err := unity.LoadProject(projectPath)
 	if err != nil {
-		return fmt.Errorf("failed to load project: %w", err)
+		return nil, fmt.Errorf("failed to load project: %w", err)
 	}
 
-	ui.Info("Checking .meta files in: %s", project.Path)
-
 	checker := unity.NewMetaChecker(project)
-
-	result, err := ui.WithSpinner("Scanning project...", func() (*unity.MetaCheckResult, error) {
-		return checker.Check()
-	})
+	var result *unity.MetaCheckResult
+	if text {
+		ui.Info("Checking .meta files in: %s", project.Path)
+		progress := ui.NewProgress("Scanning project", 0)
+		checker.OnScan = func(string) { progress.Add(1) }
+		result, err = checker.Check()
+		progress.Done(err)
+	} else {
+		result, err = checker.Check()
+	}
 	if err != nil {
-		return fmt.Errorf("check failed: %w", err)
+		return nil, fmt.Errorf("check failed: %w", err)
 	}
 
+	report := &metaCheckReport{project: project, checker: checker, result: result, baselinePath: metaCheckBaseline}
+	if report.baselinePath == "" {
+		report.baselinePath = unity.MetaBaselinePath(project.Path)
+	}
+	if metaCheckUpdateBaseline {
+		baseline, err := unity.SaveMetaBaseline(report.baselinePath, result)
+		if err != nil {
+			return nil, err
+		}
+		ui.Success("Wrote %d issues to %s", len(baseline.Issues), report.baselinePath)
+		return nil, nil
+	}
+
+	if !metaCheckNoBaseline {
+		baseline, err := unity.LoadMetaBaseline(report.baselinePath)
+		if err != nil {
+			return nil, err
+		}
+		if baseline == nil && metaCheckBaseline != "" {
+			return nil, errs.New(errs.NotFound, "baseline %s not found", metaCheckBaseline)
+		}
+		report.suppressed, report.fixed = result.ApplyBaseline(baseline)
+	}
+	return report, nil
+}
+
+// json returns the --format json output of the report
+func (report *metaCheckReport) json() metaCheckJSON {
+	issues := report.result.Issues()
+	out := metaCheckJSON{
+		Project:   report.project.Path,
+		Baselined: report.suppressed,
+		Fixed:     report.fixed,
+		Issues:    issues,
+	}
+	if out.Issues == nil {
+		out.Issues = []unity.MetaIssue{}
+	}
+	for _, issue := range issues {
+		if issue.Severity == unity.MetaSeverityError {
+			out.Errors++
+		} else {
+			out.Warnings++
+		}
+	}
+	return out
+}
+
+// printMetaCheckReport prints the issues of a project and handles --fix
+func printMetaCheckReport(report *metaCheckReport) error {
+	project, result := report.project, report.result
+
 	// Print results
 	hasOutput := false
 
@@ -94,6 +306,55 @@ func runMetaCheck(cmd *cobra.Command, args []string) error {
 				fmt.Printf("    - %s\n", file)
 			}
 		}
+		ui.Muted("Use 'uniforge asset regen-guids <folder>' to give a copied folder fresh GUIDs")
+		fmt.Println()
+	}
+
+	// Case collisions and names invalid on Windows (Error), long paths (Warning)
+	if len(result.Portability) > 0 {
+		hasOutput = true
+		report := ui.Warn
+		for _, issue := range result.Portability {
+			if issue.IsError() {
+				report = ui.Error
+				break
+			}
+		}
+		report("Unportable paths (%d):", len(result.Portability))
+		for _, issue := range result.Portability {
+			fmt.Printf("  %s: %s\n", issue.Path, issue.Detail)
+		}
+		ui.Muted("Rename them so that the project imports on Windows, macOS and Linux alike")
+		fmt.Println()
+	}
+
+	// Wrong folderAsset flags (Error), unexpected fileFormatVersion (Warning)
+	if len(result.InvalidMeta) > 0 {
+		hasOutput = true
+		report := ui.Warn
+		for _, meta := range result.InvalidMeta {
+			if meta.IsError() {
+				report = ui.Error
+				break
+			}
+		}
+		report("Invalid .meta files (%d):", len(result.InvalidMeta))
+		for _, meta := range result.InvalidMeta {
+			fmt.Printf("  %s: %s\n", meta.Path, meta.Detail)
+		}
+		ui.Muted("Delete them and let Unity regenerate them, or restore them from version control")
+		fmt.Println()
+	}
+
+	// Empty folders (Warning)
+	if len(result.EmptyFolders) > 0 {
+		hasOutput = true
+		ui.Warn("Empty folders (%d):", len(result.EmptyFolders))
+		for _, path := range result.EmptyFolders {
+			fmt.Printf("  %s\n", path)
+		}
+		ui.Muted("Git does not keep empty folders, so their .meta files become orphans in other clones.")
+		ui.Muted("Add a .gitkeep file to keep a folder, or delete it together with its .meta file.")
 		fmt.Println()
 	}
 
@@ -109,17 +370,18 @@ func runMetaCheck(cmd *cobra.Command, args []string) error {
 		// Handle --fix option
 		if metaCheckFix {
 			if !metaCheckForce {
-				fmt.Print("Remove these orphan .meta files? [y/N]: ")
-				reader := bufio.NewReader(os.Stdin)
-				response, _ := reader.ReadString('\n')
-				response = strings.TrimSpace(strings.ToLower(response))
-				if response != "y" && response != "yes" {
+				ok, err := ui.Confirm("Remove these orphan .meta files?")
+				if err != nil {
+					return err
+				}
+				if !ok {
 					ui.Muted("Skipped. No files were deleted.")
-					return exitWithCode(result)
+					return nil
 				}
 			}
 
-			deleted, err := checker.Fix(false)
+			// Only the orphans shown, not those accepted by the baseline
+			deleted, err := report.checker.RemoveMeta(result.OrphanMeta)
 			if err != nil {
 				return fmt.Errorf("failed to fix: %w", err)
 			}
@@ -131,13 +393,20 @@ func runMetaCheck(cmd *cobra.Command, args []string) error {
 	if !hasOutput {
 		ui.Success("No issues found")
 	}
+	if report.suppressed > 0 {
+		ui.Muted("%d issues accepted by the baseline (%s)", report.suppressed, relativeToProject(project.Path, report.baselinePath))
+	}
+	if report.fixed > 0 {
+		ui.Muted("%d baseline entries no longer occur; run 'uniforge meta check --update-baseline' to remove them", report.fixed)
+	}
 
-	return exitWithCode(result)
+	return nil
 }
 
-func exitWithCode(result *unity.MetaCheckResult) error {
-	if result.HasErrors() {
-		os.Exit(1)
+// relativeToProject shortens path for display when it is inside the project
+func relativeToProject(projectPath, path string) string {
+	if rel, err := filepath.Rel(projectPath, path); err == nil && filepath.IsLocal(rel) {
+		return rel
 	}
-	return nil
+	return path
 }
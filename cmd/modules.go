@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	modulesAll    bool
+	modulesFormat string
+	modulesRemove []string
+)
+
+var editorModulesCmd = &cobra.Command{
+	Use:   "modules <version>",
+	Short: "List or remove the modules of a Unity version",
+	Long: `List the modules that can be installed for a Unity version, with their
+size and whether they are installed.
+
+--remove deletes platform modules from an installed editor and reports the
+disk space freed. Unity Hub's modules.json is updated, so Hub shows them as
+not installed. Modules other installed modules depend on (such as android
+while the Android SDK & NDK tools are installed) are refused; remove those
+first. The standalone modules of the host platform (e.g. mac-il2cpp on macOS)
+share their directory with the editor's built-in player and are refused too.
+
+Module names and descriptions are shown in the language selected with --lang
+(or lang in ~/.uniforge.yaml), which defaults to the language of your locale.
+Translations are bundled for en, ja, zh and ko. Module IDs, as used by
+'editor install --modules', are never translated.
+
+Examples:
+  # Platform modules for a version
+  uniforge editor modules 2022.3.60f1
+
+  # Every module, including documentation and language packs
+  uniforge editor modules 6000.0 --all
+
+  # Japanese module names
+  uniforge editor modules lts --lang ja
+
+  # JSON for scripting
+  uniforge editor modules 2022.3.60f1 --format json
+
+  # Reclaim the space of platforms you no longer build for
+  uniforge editor modules 2022.3.60f1 --remove ios --remove webgl`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runEditorModules,
+	SilenceUsage: true,
+}
+
+func init() {
+	editorCmd.AddCommand(editorModulesCmd)
+
+	editorModulesCmd.Flags().BoolVar(&modulesAll, "all", false, "Include non-platform and hidden modules")
+	editorModulesCmd.Flags().StringVar(&modulesFormat, "format", "table", "Output format: table, json")
+	editorModulesCmd.Flags().StringSliceVar(&modulesRemove, "remove", nil, "Remove these modules from the installed editor")
+}
+
+// moduleJSON is the JSON representation of a module
+type moduleJSON struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DisplayName   string `json:"displayName"`
+	Description   string `json:"description,omitempty"`
+	Category      string `json:"category"`
+	Installed     bool   `json:"installed"`
+	DownloadSize  int64  `json:"downloadSize"`
+	InstalledSize int64  `json:"installedSize"`
+}
+
+func runEditorModules(cmd *cobra.Command, args []string) error {
+	if modulesFormat != "table" && modulesFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s", modulesFormat)
+	}
+
+	hubClient := hub.NewClient()
+	hubClient.NoCache = viper.GetBool("no-cache")
+
+	if len(modulesRemove) > 0 {
+		return removeEditorModules(hubClient, args[0], modulesRemove)
+	}
+
+	version, err := hubClient.ResolveVersionSpec(args[0], true)
+	if err != nil {
+		return err
+	}
+
+	releases, err := fetchReleasesWithCache(hubClient)
+	if err != nil {
+		return fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	var release *hub.UnityRelease
+	for i := range releases {
+		if releases[i].Version == version {
+			release = &releases[i]
+			break
+		}
+	}
+	if release == nil {
+		return errs.New(errs.NotFound, "no release metadata for Unity %s", version)
+	}
+	release = &hubClient.EnrichReleasesWithInstallStatus([]hub.UnityRelease{*release})[0]
+
+	var modules []hub.ModuleInfo
+	for _, m := range release.Modules {
+		if modulesAll || m.IsVisible() {
+			modules = append(modules, m)
+		}
+	}
+
+	if modulesFormat == "json" {
+		out := make([]moduleJSON, 0, len(modules))
+		for _, m := range modules {
+			out = append(out, moduleJSON{
+				ID:            m.ID,
+				Name:          m.Name,
+				DisplayName:   m.DisplayName(),
+				Description:   m.DisplayDescription(),
+				Category:      m.Category,
+				Installed:     m.Installed,
+				DownloadSize:  m.DownloadSize,
+				InstalledSize: m.InstalledSize,
+			})
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode modules: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printModules(modules)
+	return nil
+}
+
+// removeEditorModules removes modules from the installed editor of spec and
+// reports the space freed
+func removeEditorModules(hubClient *hub.Client, spec string, modules []string) error {
+	version, err := hubClient.ResolveVersionSpec(spec, false)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, module := range modules {
+		freed, err := hubClient.RemoveModule(version, module)
+		if err != nil {
+			return err
+		}
+		total += freed
+		ui.Success("Removed %s from Unity %s (%s freed)", hub.ModuleID(module), version, ui.FormatBytes(freed))
+	}
+	if len(modules) > 1 {
+		ui.Info("%s freed in total", ui.FormatBytes(total))
+	}
+	return nil
+}
+
+// printModules prints modules as a table
+func printModules(modules []hub.ModuleInfo) {
+	rows := make([][]string, 0, len(modules))
+	for _, m := range modules {
+		status := ""
+		if m.Installed {
+			status = "✓"
+		}
+		size := ""
+		if m.DownloadSize > 0 {
+			size = ui.FormatBytes(m.DownloadSize)
+		}
+		rows = append(rows, []string{m.ID, m.DisplayName(), size, status, m.DisplayDescription()})
+	}
+
+	t := table.New().
+		Headers("ID", "NAME", "SIZE", "INSTALLED", "DESCRIPTION").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			switch col {
+			case 0:
+				return versionStyle
+			case 3:
+				return availInstalledStyle
+			case 4:
+				return pathStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/neptaco/uniforge/pkg/unity"
@@ -50,7 +51,7 @@ func runOpen(cmd *cobra.Command, args []string) error {
 			hubProject, hubErr := findHubProject(args[0])
 			if hubErr == nil && hubProject != nil {
 				ui.Info("Found project in Unity Hub: %s", hubProject.Title)
-				return openProject(hubProject.Path, hubProject.Version, hubProject.Title)
+				return openProjectWithSavedOptions(hubProject.Path, hubProject.Version, hubProject.Title)
 			}
 			if hubErr != nil {
 				// Return Hub error if it's more specific than "not found"
@@ -63,7 +64,7 @@ func runOpen(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load project: %w", err)
 	}
 
-	return openProject(project.Path, project.UnityVersion, project.Name)
+	return openProjectWithSavedOptions(project.Path, project.UnityVersion, project.Name)
 }
 
 // findHubProject searches Unity Hub projects and handles multiple matches with selection UI
@@ -91,7 +92,7 @@ func selectProject(matches []hub.ProjectInfo, query string) (*hub.ProjectInfo, e
 		for _, p := range matches {
 			ui.Print("  - %s (%s)", p.Title, p.Version)
 		}
-		return nil, fmt.Errorf("multiple projects match '%s', please be more specific", query)
+		return nil, errs.New(errs.MultipleMatches, "multiple projects match '%s', please be more specific", query)
 	}
 
 	// Build options for selection UI
@@ -112,15 +113,28 @@ func selectProject(matches []hub.ProjectInfo, query string) (*hub.ProjectInfo, e
 	return &matches[selected], nil
 }
 
-func openProject(path, version, name string) error {
+// openProjectWithSavedOptions opens a project with the launch options saved in its .uniforge/launch.yaml
+func openProjectWithSavedOptions(path, version, name string) error {
+	opts, err := unity.LoadLaunchOptions(path)
+	if err != nil {
+		return err
+	}
+	if !opts.IsEmpty() {
+		ui.Muted("Using saved launch options from %s", unity.LaunchOptionsPath(path))
+	}
+	return openProject(path, version, name, opts)
+}
+
+func openProject(path, version, name string, opts *unity.LaunchOptions) error {
 	err := ui.WithSpinnerNoResult("Starting Unity Editor...", func() error {
 		editor := unity.NewEditor(version)
-		return editor.Open(path)
+		return editor.OpenWithOptions(path, opts)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to open editor: %w", err)
 	}
 
 	ui.Success("Unity Editor %s started for project: %s", version, name)
+	refreshProjectStatus(path)
 	return nil
 }
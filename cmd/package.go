@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var packageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "Develop UPM packages and exchange .unitypackage files",
+	Long: `Commands for developing Unity Package Manager packages outside a project:
+link a local package folder into test projects and pack it for distribution.
+Also exports and imports .unitypackage files without opening the editor.`,
+}
+
+func init() {
+	rootCmd.AddCommand(packageCmd)
+}
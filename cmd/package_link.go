@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var packageLinkCmd = &cobra.Command{
+	Use:   "link <package-dir> [project]",
+	Short: "Use a local package folder in a project",
+	Long: `Point the project's Packages/manifest.json entry for a package at a local
+package folder with a file: reference, so changes to the package show up in
+the project as soon as Unity refreshes.
+
+The reference is relative to the project's Packages folder, so it keeps working
+when the package and project are checked out side by side on another machine.
+The replaced manifest entry is recorded in .uniforge/package-links.json and
+restored by 'package unlink'.
+
+Examples:
+  # Link ../my-package into the project in the current directory
+  uniforge package link ../my-package
+
+  # Link into a test project
+  uniforge package link . ../TestProject`,
+	Args:         cobra.RangeArgs(1, 2),
+	RunE:         runPackageLink,
+	SilenceUsage: true,
+}
+
+var packageUnlinkCmd = &cobra.Command{
+	Use:   "unlink <package> [project]",
+	Short: "Revert a package linked with 'package link'",
+	Long: `Restore the Packages/manifest.json entry a 'package link' replaced. A package
+that was not listed before linking is removed from the manifest.
+
+The package is given by name or by its local folder.
+
+Examples:
+  # Go back to the registry version
+  uniforge package unlink com.company.tool
+
+  # By folder, in a test project
+  uniforge package unlink . ../TestProject`,
+	Args:         cobra.RangeArgs(1, 2),
+	RunE:         runPackageUnlink,
+	SilenceUsage: true,
+}
+
+func init() {
+	packageCmd.AddCommand(packageLinkCmd)
+	packageCmd.AddCommand(packageUnlinkCmd)
+}
+
+func runPackageLink(cmd *cobra.Command, args []string) error {
+	projectRoot, err := projectRootArg(args, 1)
+	if err != nil {
+		return err
+	}
+	info, ref, err := unity.LinkPackage(projectRoot, args[0])
+	if err != nil {
+		return err
+	}
+	ui.Success("Linked %s into %s", info.Name, projectRoot)
+	ui.Muted("  %q: %q", info.Name, ref)
+	return nil
+}
+
+func runPackageUnlink(cmd *cobra.Command, args []string) error {
+	projectRoot, err := projectRootArg(args, 1)
+	if err != nil {
+		return err
+	}
+
+	// A folder with a package.json names its package
+	name := args[0]
+	if stat, err := os.Stat(name); err == nil && stat.IsDir() {
+		info, err := unity.LoadPackageInfo(name)
+		if err != nil {
+			return err
+		}
+		name = info.Name
+	}
+
+	link, err := unity.UnlinkPackage(projectRoot, name)
+	if err != nil {
+		return err
+	}
+	if link.Previous == "" {
+		ui.Success("Unlinked %s and removed it from the manifest", name)
+	} else {
+		ui.Success("Unlinked %s; restored %q", name, link.Previous)
+	}
+	return nil
+}
+
+// projectRootArg returns the project given as the argument at index, or the
+// project containing the current directory
+func projectRootArg(args []string, index int) (string, error) {
+	dir := "."
+	if len(args) > index {
+		dir = args[index]
+	}
+	return unity.FindProjectRoot(dir)
+}
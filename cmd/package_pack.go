@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var packagePackOutput string
+
+var packagePackCmd = &cobra.Command{
+	Use:   "pack [package-dir]",
+	Short: "Validate a package and write a .tgz tarball",
+	Long: `Check package.json and write the package as <name>-<version>.tgz, the tarball
+layout the Package Manager installs from a file: reference or a registry.
+
+The name must be lowercase and may only contain letters, digits, '-', '_' and
+'.'; the version must be a semantic version, and "unity"/"unityRelease" must
+name a Unity release. Errors stop the pack. Warnings, such as a missing
+displayName or assets without a .meta file (Unity does not import those from
+an immutable package), are printed but do not.
+
+Hidden files and node_modules are left out; folders ending in "~" such as
+Samples~ are included.
+
+Examples:
+  # Pack the package in the current directory
+  uniforge package pack
+
+  # Pack into a dist folder
+  uniforge package pack ./Packages/com.company.tool -o dist`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runPackagePack,
+	SilenceUsage: true,
+}
+
+func init() {
+	packageCmd.AddCommand(packagePackCmd)
+
+	packagePackCmd.Flags().StringVarP(&packagePackOutput, "output", "o", ".", "Directory to write the tarball to")
+}
+
+func runPackagePack(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	output, problems, err := unity.PackPackage(dir, packagePackOutput)
+	for _, p := range problems {
+		if p.Warning {
+			ui.Warn("%s", p)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	ui.Success("Packed %s", output)
+	return nil
+}
@@ -44,10 +44,14 @@ func runProjectTUI(cmd *cobra.Command, args []string) error {
 
 	hubClient := hub.NewClient()
 
-	// Open function that uses the unity package
+	// Open function that uses the unity package, honoring saved launch options
 	openFn := func(path, version string) error {
+		opts, err := unity.LoadLaunchOptions(path)
+		if err != nil {
+			return err
+		}
 		editor := unity.NewEditor(version)
-		return editor.Open(path)
+		return editor.OpenWithOptions(path, opts)
 	}
 
 	return hub.RunProjectTUI(hubClient, openFn)
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	archiveOutput   string
+	archiveExcludes []string
+	restoreForce    bool
+)
+
+var projectArchiveCmd = &cobra.Command{
+	Use:   "archive [path]",
+	Short: "Back up a project without its generated folders",
+	Long: `Write a compressed archive of a Unity project, leaving out the folders Unity
+regenerates (Library, Temp, obj, Logs and Build at the project root). Useful
+before a risky editor upgrade.
+
+The archive ends with a manifest listing the SHA-256 of every file, which
+'project restore' checks. The compression follows the output extension:
+.tar.zst (needs zstd), .tar.gz or .tar.
+
+Extra exclude patterns come from --exclude and archive.exclude in
+~/.uniforge.yaml. As in .gitignore, patterns containing "/" are matched from the
+project root ("/Assets/StreamingAssets/Videos"), others match a name at any
+depth ("*.blend1").
+
+Examples:
+  # Archive the project in the current directory to <name>-<timestamp>.tar.zst
+  uniforge project archive
+
+  # Archive a project to a specific file
+  uniforge project archive ./MyGame -o ~/backups/MyGame.tar.zst
+
+  # Also leave out large generated content
+  uniforge project archive -o MyGame.tar.gz --exclude "/Assets/AddressableAssetsData/*/"`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runProjectArchive,
+	SilenceUsage: true,
+}
+
+var projectRestoreCmd = &cobra.Command{
+	Use:   "restore <archive> [directory]",
+	Short: "Restore a project archive and verify it",
+	Long: `Extract an archive written by 'project archive' and verify every file against
+its manifest. The directory defaults to the project name in the current
+directory. Existing files are not replaced unless --force is given.
+
+Examples:
+  # Restore into ./MyGame
+  uniforge project restore MyGame-20250101-120000.tar.zst
+
+  # Restore over an existing checkout
+  uniforge project restore ~/backups/MyGame.tar.zst ./MyGame --force`,
+	Args:         cobra.RangeArgs(1, 2),
+	RunE:         runProjectRestore,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectArchiveCmd)
+	projectCmd.AddCommand(projectRestoreCmd)
+
+	projectArchiveCmd.Flags().StringVarP(&archiveOutput, "output", "o", "", "Archive file (.tar.zst, .tar.gz or .tar)")
+	projectArchiveCmd.Flags().StringArrayVar(&archiveExcludes, "exclude", nil, "Additional pattern to leave out (repeatable)")
+
+	projectRestoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Overwrite files that already exist")
+}
+
+func runProjectArchive(cmd *cobra.Command, args []string) error {
+	startPath := "."
+	if len(args) > 0 {
+		startPath = args[0]
+	}
+
+	projectRoot, err := unity.FindProjectRoot(startPath)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	output := archiveOutput
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.tar.zst", project.Name, time.Now().Format("20060102-150405"))
+	}
+
+	archiver := unity.NewProjectArchiver(project)
+	archiver.Excludes = append(viper.GetStringSlice("archive.exclude"), archiveExcludes...)
+
+	ui.Info("Archiving %s", project.Path)
+	progress := ui.NewProgress("Archiving project", 0, ui.WithBytes())
+	archiver.OnStart = func(_ int, size int64) { progress.SetTotal(size) }
+	archiver.OnWrite = progress.Add
+	manifest, err := archiver.Create(output)
+	progress.Done(err)
+	if err != nil {
+		return err
+	}
+
+	abs, _ := filepath.Abs(output)
+	ui.Success("Archived %d files (%s) to %s", len(manifest.Files), ui.FormatBytes(manifest.TotalSize()), abs)
+	return nil
+}
+
+func runProjectRestore(cmd *cobra.Command, args []string) error {
+	archive := args[0]
+
+	dest := ""
+	if len(args) > 1 {
+		dest = args[1]
+	}
+
+	progress := ui.NewProgress("Restoring "+filepath.Base(archive), 0, ui.WithBytes())
+	opts := unity.RestoreOptions{
+		Overwrite: restoreForce,
+		OnStart:   progress.SetTotal,
+		OnRead:    progress.Add,
+	}
+	manifest, err := unity.RestoreArchive(archive, dest, opts)
+	progress.Done(err)
+	if err != nil {
+		return err
+	}
+	if dest == "" {
+		dest = manifest.Project
+	}
+
+	abs, _ := filepath.Abs(dest)
+	ui.Success("Restored %d files to %s (checksums verified)", len(manifest.Files), abs)
+	if manifest.UnityVersion != "" {
+		ui.Muted("Archived with Unity %s on %s", manifest.UnityVersion, manifest.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compileMethod    string
+	compileVersion   string
+	compileLogFile   string
+	compileTimeout   int
+	compileCIMode    bool
+	compileTimestamp bool
+	compileNoise     []string
+)
+
+var projectCompileCmd = &cobra.Command{
+	Use:   "compile [project]",
+	Short: "Check that the project's scripts compile, without the editor window",
+	Long: `Open the project in batch mode so Unity compiles its scripts, then quit.
+
+Compiler errors and warnings are collected from the log and listed as
+file:line:column at the end; the command exits non-zero when there are
+compiler errors. This is a quick check before pushing that needs neither
+tests nor a build. The project must not be open in another editor.
+
+Unity runs -executeMethod after compiling; by default a built-in method that
+does nothing. Use --method to run your own, e.g. one that also compiles the
+player scripts of a build target.
+
+Examples:
+  # Compile the project in the current directory
+  uniforge project compile
+
+  # With another editor version
+  uniforge project compile ./MyGame --editor-version 6000.0
+
+  # CI mode with annotations and a saved log
+  uniforge project compile --ci --log-file compile.log`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runProjectCompile,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectCompileCmd)
+
+	projectCompileCmd.Flags().StringVar(&compileMethod, "method", unity.DefaultCompileMethod, "Static method to run after compiling (-executeMethod)")
+	projectCompileCmd.Flags().StringVar(&compileVersion, "editor-version", "", "Unity version to use instead of the project's (e.g. 2022.3.10f1, 2022.3, lts)")
+	projectCompileCmd.Flags().StringVar(&compileLogFile, "log-file", "", "Path to save log file")
+	projectCompileCmd.Flags().IntVar(&compileTimeout, "timeout", 1800, "Timeout in seconds")
+	projectCompileCmd.Flags().BoolVar(&compileCIMode, "ci", false, "CI mode (optimized output format)")
+	projectCompileCmd.Flags().BoolVarP(&compileTimestamp, "timestamp", "t", false, "Show timestamp for each line")
+	projectCompileCmd.Flags().StringSliceVar(&compileNoise, "show-noise", nil, showNoiseUsage)
+}
+
+func runProjectCompile(cmd *cobra.Command, args []string) error {
+	showNoise, err := logger.ParseNoiseCategories(compileNoise)
+	if err != nil {
+		return err
+	}
+
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	projectRoot, err := unity.FindProjectRoot(projectPath)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	if compileVersion != "" {
+		version, err := hub.NewClient().ResolveVersionSpec(compileVersion, false)
+		if err != nil {
+			return err
+		}
+		project.UnityVersion = version
+	}
+	ui.Info("Compiling %s with Unity %s", project.Name, project.UnityVersion)
+
+	result, err := unity.NewRunner(project).Compile(unity.CompileConfig{
+		ProjectPath:    project.Path,
+		Method:         compileMethod,
+		LogFile:        compileLogFile,
+		TimeoutSeconds: compileTimeout,
+		CIMode:         compileCIMode,
+		ShowTimestamp:  compileTimestamp,
+		ShowNoise:      showNoise,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, msg := range result.Warnings {
+		ui.Warn("%s: %s %s", msg.Location(), msg.Code, msg.Message)
+	}
+	for _, msg := range result.Errors {
+		ui.Error("%s: %s %s", msg.Location(), msg.Code, msg.Message)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d compiler error(s)", len(result.Errors))
+	}
+
+	ui.Success("Scripts compiled in %s (%d warning(s))", result.Duration.Round(time.Second), len(result.Warnings))
+	return nil
+}
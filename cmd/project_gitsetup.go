@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/git"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var gitSetupEnableLFS bool
+
+var projectGitSetupCmd = &cobra.Command{
+	Use:   "gitsetup [path]",
+	Short: "Write .gitignore and .gitattributes for a Unity project",
+	Long: `Write a canonical Unity .gitignore and a Git LFS .gitattributes for common
+binary asset types (textures, audio, video, models, fonts) to the project root.
+
+The project root is detected by walking up from the given path (default: current
+directory). uniforge only manages the lines between its own markers, so existing
+rules are kept and running the command again updates the block in place.
+
+Examples:
+  # Set up the project containing the current directory
+  uniforge project gitsetup
+
+  # Set up a specific project and enable Git LFS in its repository
+  uniforge project gitsetup /path/to/project --enable-lfs`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runProjectGitSetup,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectGitSetupCmd)
+
+	projectGitSetupCmd.Flags().BoolVar(&gitSetupEnableLFS, "enable-lfs", false, "Run 'git lfs install' for the repository")
+}
+
+func runProjectGitSetup(cmd *cobra.Command, args []string) error {
+	startPath := "."
+	if len(args) > 0 {
+		startPath = args[0]
+	}
+
+	projectRoot, err := unity.FindProjectRoot(startPath)
+	if err != nil {
+		return err
+	}
+	ui.Info("Unity project: %s", projectRoot)
+
+	changed, err := unity.GitSetup(projectRoot)
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		ui.Success(".gitignore and .gitattributes are up to date")
+	}
+	for _, name := range changed {
+		ui.Success("Updated %s", name)
+	}
+
+	repoRoot, err := git.RepoRoot(projectRoot)
+	if err != nil {
+		ui.Warn("Project is not in a git repository yet, run 'git init' to start tracking it")
+		if gitSetupEnableLFS {
+			return fmt.Errorf("cannot enable Git LFS outside a git repository")
+		}
+		return nil
+	}
+
+	if gitSetupEnableLFS {
+		if err := git.LFSInstall(repoRoot); err != nil {
+			return err
+		}
+		ui.Success("Enabled Git LFS in %s", repoRoot)
+	}
+	return nil
+}
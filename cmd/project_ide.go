@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/editorprefs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ideName    string
+	ideNoSync  bool
+	ideTimeout int
+)
+
+var projectIDECmd = &cobra.Command{
+	Use:   "ide [project]",
+	Short: "Make Unity open scripts in the same IDE as uniforge",
+	Long: `Set Unity's "External Script Editor" preference to an IDE and regenerate the
+project's .sln and .csproj files for it, so double-clicking a script in Unity
+and opening the project from 'uniforge project' use the same IDE.
+
+The IDE defaults to the one 'uniforge project' opens: UNIFORGE_EDITOR, else
+the first of rider, cursor and code found on PATH. The preference is shared
+by all Unity versions. Project files are regenerated by running the
+project's editor in batch mode, so the project must not be open; close all
+editors anyway, since Unity saves its preferences when it quits.
+
+Supported IDEs: ` + strings.Join(unity.ScriptEditorNames(), ", ") + `
+
+Examples:
+  # Use the detected IDE
+  uniforge project ide
+
+  # Use Rider for ./MyGame
+  uniforge project ide ./MyGame --ide rider
+
+  # Only change the preference
+  uniforge project ide --ide code --no-sync`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runProjectIDE,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectIDECmd)
+
+	projectIDECmd.Flags().StringVar(&ideName, "ide", "", "IDE to use: "+strings.Join(unity.ScriptEditorNames(), ", ")+" (default: detected)")
+	projectIDECmd.Flags().BoolVar(&ideNoSync, "no-sync", false, "Do not regenerate .sln/.csproj files")
+	projectIDECmd.Flags().IntVar(&ideTimeout, "timeout", 1800, "Timeout in seconds for regenerating project files")
+}
+
+func runProjectIDE(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	projectRoot, err := unity.FindProjectRoot(projectPath)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	name := ideName
+	if name == "" {
+		name = unity.DetectedScriptEditorName()
+	}
+	editor, editorPath, err := unity.FindScriptEditor(name)
+	if err != nil {
+		return err
+	}
+
+	if err := editorprefs.Write(unity.ScriptEditorPrefs(editor, editorPath)); err != nil {
+		return err
+	}
+	ui.Success("Unity will open scripts with %s (%s)", editor.Title, editorPath)
+
+	if !unity.HasScriptEditorPackage(project.Path, editor) {
+		ui.Warn("%s is not in Packages/manifest.json; Unity needs it to generate project files for %s", editor.Package, editor.Title)
+	}
+	if ideNoSync {
+		return nil
+	}
+
+	ui.Info("Regenerating project files with Unity %s", project.UnityVersion)
+	err = unity.NewRunner(project).Run(unity.RunConfig{
+		ProjectPath:    project.Path,
+		ExtraArgs:      []string{"-executeMethod", unity.SyncSolutionMethod},
+		TimeoutSeconds: ideTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to regenerate project files: %w", err)
+	}
+	ui.Success("Project files regenerated")
+	return nil
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/doctor"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var projectInfoFormat string
+
+var projectInfoCmd = &cobra.Command{
+	Use:   "info <project>",
+	Short: "Show everything known about a project",
+	Long: `Show the details of one project: its path, Unity version and whether that
+editor is installed, changeset, package count, Git branch and status, size on
+disk split by Assets and Library, when uniforge last opened it, its tags, the
+linked Unity Cloud project, and a summary of the project checks of 'doctor'.
+
+The project can be specified by name (partial match), index (1-based) or path.
+Projects not registered in Unity Hub can be inspected by path.
+
+Examples:
+  # By name
+  uniforge project info my-project
+
+  # The project in the current directory
+  uniforge project info .
+
+  # As JSON
+  uniforge project info 1 --format json`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runProjectInfo,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectInfoCmd)
+
+	projectInfoCmd.Flags().StringVar(&projectInfoFormat, "format", "text", "output format: text, json")
+}
+
+// projectInfoJSON is the --format json output of project info
+type projectInfoJSON struct {
+	Name            string `json:"name"`
+	Path            string `json:"path"`
+	Registered      bool   `json:"registered"`
+	Version         string `json:"version"`
+	Changeset       string `json:"changeset,omitempty"`
+	EditorInstalled bool   `json:"editor_installed"`
+	EditorPath      string `json:"editor_path,omitempty"`
+	Packages        int    `json:"packages"`
+
+	GitBranch string `json:"git_branch,omitempty"`
+	GitStatus string `json:"git_status,omitempty"`
+
+	DiskUsage *projectDiskUsageJSON `json:"disk_usage,omitempty"`
+
+	LastModified     string `json:"last_modified,omitempty"`
+	LastOpened       string `json:"last_opened,omitempty"`
+	LastOpenedEditor string `json:"last_opened_editor,omitempty"`
+
+	Tags []string `json:"tags"`
+
+	CloudProjectID string `json:"cloud_project_id,omitempty"`
+	CloudOrgID     string `json:"cloud_organization_id,omitempty"`
+
+	Doctor *projectDoctorJSON `json:"doctor,omitempty"`
+
+	root string // Project directory, empty if the project could not be loaded
+}
+
+type projectDiskUsageJSON struct {
+	Assets  int64 `json:"assets"`
+	Library int64 `json:"library"`
+	Other   int64 `json:"other"`
+	Total   int64 `json:"total"`
+}
+
+type projectDoctorJSON struct {
+	OK       int                `json:"ok"`
+	Warnings int                `json:"warnings"`
+	Failures int                `json:"failures"`
+	Issues   []projectIssueJSON `json:"issues"`
+}
+
+type projectIssueJSON struct {
+	Check  string   `json:"check"`
+	Status string   `json:"status"`
+	Detail string   `json:"detail"`
+	Items  []string `json:"items,omitempty"`
+	Remedy string   `json:"remedy,omitempty"`
+}
+
+func runProjectInfo(cmd *cobra.Command, args []string) error {
+	if projectInfoFormat != "text" && projectInfoFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s (use text or json)", projectInfoFormat)
+	}
+
+	hubClient := hub.NewClient()
+	info, err := resolveProjectInfo(hubClient, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Walking Library and running the checks can take a while on large projects
+	var doctorResults []doctor.Result
+	check := func() error {
+		if info.root == "" {
+			return nil
+		}
+		if usage, err := unity.MeasureDiskUsage(info.root); err == nil {
+			info.DiskUsage = &projectDiskUsageJSON{Assets: usage.Assets, Library: usage.Library, Other: usage.Other, Total: usage.Total()}
+		}
+		doctorResults = doctor.Run(projectChecks(hubClient, info.root))
+		info.Doctor = summarizeProjectChecks(doctorResults)
+		return nil
+	}
+
+	if projectInfoFormat == "json" {
+		_ = check()
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	}
+	_ = ui.WithSpinnerNoResult("Checking project...", check)
+	printProjectInfo(info, doctorResults)
+	return nil
+}
+
+// resolveProjectInfo finds the project by path, name or index and gathers
+// everything but its size and the doctor checks
+func resolveProjectInfo(hubClient *hub.Client, query string) (*projectInfoJSON, error) {
+	var project *hub.ProjectInfo
+	registered := true
+	if stat, err := os.Stat(query); err == nil && stat.IsDir() {
+		root, err := unity.FindProjectRoot(query)
+		if err != nil {
+			return nil, errs.New(errs.NotFound, "not a Unity project: %s", query)
+		}
+		if project, registered, err = hubClient.GetProjectByPath(root); err != nil {
+			return nil, err
+		}
+	} else {
+		if project, err = findHubProject(query); err != nil {
+			return nil, fmt.Errorf("failed to find project: %w", err)
+		}
+	}
+
+	info := &projectInfoJSON{
+		Name:           project.Title,
+		Path:           project.Path,
+		Registered:     registered,
+		Version:        project.Version,
+		GitBranch:      project.GitBranch,
+		GitStatus:      project.GitStatus,
+		Tags:           project.Tags,
+		CloudProjectID: project.CloudProjectID,
+		CloudOrgID:     project.CloudOrgID,
+	}
+	if info.Tags == nil {
+		info.Tags = []string{}
+	}
+	if !project.LastModified.IsZero() {
+		info.LastModified = project.LastModified.Format(time.RFC3339)
+	}
+	if !project.LastOpened.IsZero() {
+		info.LastOpened = project.LastOpened.Format(time.RFC3339)
+		info.LastOpenedEditor = project.LastOpenedEditor
+	}
+
+	// ProjectVersion.txt is authoritative; Unity Hub's entry can be stale
+	loaded, err := unity.LoadProject(project.Path)
+	if err != nil {
+		ui.Warn("%v", err)
+	} else {
+		info.root = loaded.Path
+		info.Version = loaded.UnityVersion
+		info.Changeset = loaded.Changeset
+		if manifest, err := unity.LoadPackageManifest(loaded.Path); err == nil {
+			info.Packages = len(manifest.Dependencies)
+		}
+		if settings, err := unity.LoadPlayerSettings(loaded.Path); err == nil && settings.CloudProjectID != "" {
+			info.CloudProjectID = settings.CloudProjectID
+			info.CloudOrgID = settings.OrganizationID
+		}
+	}
+	if info.Version != "" {
+		info.EditorInstalled, info.EditorPath, _ = hubClient.IsEditorInstalled(info.Version)
+	}
+	return info, nil
+}
+
+// summarizeProjectChecks counts the doctor results and keeps the ones that need attention
+func summarizeProjectChecks(results []doctor.Result) *projectDoctorJSON {
+	summary := &projectDoctorJSON{Issues: []projectIssueJSON{}}
+	for _, r := range results {
+		switch r.Status {
+		case doctor.StatusOK:
+			summary.OK++
+			continue
+		case doctor.StatusWarn:
+			summary.Warnings++
+		default:
+			summary.Failures++
+		}
+		summary.Issues = append(summary.Issues, projectIssueJSON{
+			Check:  r.Name,
+			Status: r.Status.String(),
+			Detail: r.Detail,
+			Items:  r.Items,
+			Remedy: r.Remedy,
+		})
+	}
+	return summary
+}
+
+func printProjectInfo(info *projectInfoJSON, doctorResults []doctor.Result) {
+	field := func(label, value string) {
+		fmt.Printf("  %-13s %s\n", label, value)
+	}
+
+	fmt.Println(headerStyle.Render(info.Name))
+	field("Path", pathStyle.Render(info.Path))
+	if !info.Registered {
+		field("Unity Hub", "not registered (register with 'uniforge project scan')")
+	}
+
+	editor := "not installed"
+	if info.EditorInstalled {
+		editor = "installed"
+	}
+	field("Unity", fmt.Sprintf("%s (%s)", versionStyle.Render(info.Version), editor))
+	if info.Changeset != "" {
+		field("Changeset", info.Changeset)
+	}
+	field("Packages", fmt.Sprintf("%d", info.Packages))
+
+	git := formatGitInfo(info.GitBranch, info.GitStatus)
+	field("Git", gitColumnStyle(git).Render(git))
+
+	if u := info.DiskUsage; u != nil {
+		field("Size", fmt.Sprintf("%s (Assets %s, Library %s, other %s)",
+			ui.FormatBytes(u.Total), ui.FormatBytes(u.Assets), ui.FormatBytes(u.Library), ui.FormatBytes(u.Other)))
+	}
+
+	opened := "never"
+	if info.LastOpened != "" {
+		t, _ := time.Parse(time.RFC3339, info.LastOpened)
+		opened = hub.FormatTimeAgo(t, time.Now())
+		if info.LastOpenedEditor != "" {
+			opened += " with " + info.LastOpenedEditor
+		}
+	}
+	field("Last opened", openedStyle.Render(opened))
+
+	if len(info.Tags) > 0 {
+		field("Tags", tagsStyle.Render(strings.Join(info.Tags, ", ")))
+	}
+	if info.CloudProjectID != "" {
+		cloud := info.CloudProjectID
+		if info.CloudOrgID != "" {
+			cloud += " (" + info.CloudOrgID + ")"
+		}
+		field("Unity Cloud", cloud)
+	}
+
+	if d := info.Doctor; d != nil {
+		field("Doctor", fmt.Sprintf("%d ok, %d warning(s), %d failed", d.OK, d.Warnings, d.Failures))
+		for _, r := range doctorResults {
+			if r.Status != doctor.StatusOK {
+				printDoctorResult(r)
+			}
+		}
+	}
+}
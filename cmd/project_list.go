@@ -5,10 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
 	"github.com/mattn/go-isatty"
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/spf13/cobra"
@@ -18,6 +19,9 @@ var (
 	projectListFormat   string
 	projectListPathOnly bool
 	projectListNoGit    bool
+	projectListTags     []string
+	projectListAll      bool
+	projectListColumns  []string
 
 	// Table styles
 	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("75"))
@@ -27,13 +31,37 @@ var (
 	gitDirtyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 	pathStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	noGitStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	openedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	tagsStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("177"))
 )
 
+// projectListTableColumns are the columns of the table format
+var projectListTableColumns = []ui.Column{
+	{Name: "name", Header: "NAME", Flexible: true},
+	{Name: "version", Header: "VERSION"},
+	{Name: "git", Header: "GIT", Flexible: true},
+	{Name: "opened", Header: "OPENED"},
+	{Name: "tags", Header: "TAGS", Flexible: true},
+	{Name: "path", Header: "PATH", Flexible: true, KeepEnd: true},
+}
+
 var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List Unity Hub projects",
 	Long: `List all Unity projects registered in Unity Hub.
 
+Projects tagged with 'uniforge project tag' can be filtered with --tag.
+Favorites are listed first, and archived projects are hidden unless --all
+or --tag archived is given.
+
+The version is read from each project's ProjectVersion.txt. When Unity Hub
+records another one, for example after an upgrade outside Hub, the table
+shows Hub's next to it and JSON output sets version_mismatch.
+
+The table fits the terminal width by shortening names, tags and paths, and
+is shown in a pager when taller than the terminal. --columns picks the
+columns: name, version, git, opened, tags, path.
+
 Examples:
   # Table format (default for TTY)
   uniforge project list
@@ -48,7 +76,13 @@ Examples:
   uniforge project list --path-only
 
   # Without Git information (faster)
-  uniforge project list --no-git`,
+  uniforge project list --no-git
+
+  # Client projects only
+  uniforge project list --tag client
+
+  # Only names and paths
+  uniforge project list --columns name,path`,
 	RunE: runProjectList,
 }
 
@@ -58,29 +92,53 @@ func init() {
 	projectListCmd.Flags().StringVar(&projectListFormat, "format", "", "output format: table, json, tsv (auto-detected if not specified)")
 	projectListCmd.Flags().BoolVar(&projectListPathOnly, "path-only", false, "output only project paths")
 	projectListCmd.Flags().BoolVar(&projectListNoGit, "no-git", false, "skip Git information (faster)")
+	projectListCmd.Flags().StringSliceVar(&projectListTags, "tag", nil, "only projects with this tag (repeatable)")
+	projectListCmd.Flags().BoolVar(&projectListAll, "all", false, "include archived projects")
+	projectListCmd.Flags().StringSliceVar(&projectListColumns, "columns", nil, "table columns to show, in order (e.g. name,version,path)")
 }
 
 func runProjectList(cmd *cobra.Command, args []string) error {
+	if len(projectListColumns) > 0 {
+		if projectListFormat != "" && projectListFormat != "table" {
+			return errs.New(errs.Usage, "--columns only applies to the table format")
+		}
+		if err := (&ui.Table{Columns: projectListTableColumns}).Select(projectListColumns); err != nil {
+			return err
+		}
+	}
+
+	tags := make([]string, len(projectListTags))
+	for i, tag := range projectListTags {
+		var err error
+		if tags[i], err = hub.NormalizeTag(tag); err != nil {
+			return err
+		}
+	}
+
 	hubClient := hub.NewClient()
 
 	var projects []hub.ProjectInfo
 	var err error
 
+	opts := hub.ProjectListOptions{Git: !projectListNoGit, ProjectVersions: true}
 	if projectListNoGit {
-		projects, err = hubClient.ListProjects()
+		projects, err = hubClient.ListProjectsWithOptions(opts)
 	} else {
 		projects, err = ui.WithSpinner("Fetching projects...", func() ([]hub.ProjectInfo, error) {
-			return hubClient.ListProjectsWithGit()
+			return hubClient.ListProjectsWithOptions(opts)
 		})
 	}
 
 	if err != nil {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
+	projects = hub.FilterProjectsByTags(projects, tags, projectListAll)
 
 	if len(projects) == 0 {
 		if projectListFormat == "json" {
 			fmt.Println("[]")
+		} else if len(tags) > 0 {
+			ui.Info("No projects tagged %s", strings.Join(tags, ", "))
 		} else {
 			ui.Info("No projects registered in Unity Hub")
 		}
@@ -97,6 +155,9 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 
 	// Determine format
 	format := projectListFormat
+	if format == "" && len(projectListColumns) > 0 {
+		format = "table"
+	}
 	if format == "" {
 		if isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
 			format = "table"
@@ -119,22 +180,46 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 
 func printProjectsJSON(projects []hub.ProjectInfo) error {
 	type jsonProject struct {
-		Name      string `json:"name"`
-		Path      string `json:"path"`
-		Version   string `json:"version"`
+		Name    string `json:"name"`
+		Path    string `json:"path"`
+		Version string `json:"version"`
+
+		HubVersion      string `json:"hub_version,omitempty"`
+		VersionMismatch bool   `json:"version_mismatch,omitempty"`
+
 		GitBranch string `json:"git_branch,omitempty"`
 		GitStatus string `json:"git_status,omitempty"`
+
+		LastOpened       string `json:"last_opened,omitempty"`
+		LastOpenedEditor string `json:"last_opened_editor,omitempty"`
+
+		Tags []string `json:"tags,omitempty"`
+
+		CloudProjectID string `json:"cloud_project_id,omitempty"`
+		CloudOrgID     string `json:"cloud_organization_id,omitempty"`
 	}
 
 	var output []jsonProject
 	for _, p := range projects {
-		output = append(output, jsonProject{
+		jp := jsonProject{
 			Name:      p.Title,
 			Path:      p.Path,
 			Version:   p.Version,
 			GitBranch: p.GitBranch,
 			GitStatus: p.GitStatus,
-		})
+			Tags:      p.Tags,
+
+			HubVersion:      p.HubVersion,
+			VersionMismatch: p.VersionMismatch(),
+
+			CloudProjectID: p.CloudProjectID,
+			CloudOrgID:     p.CloudOrgID,
+		}
+		if !p.LastOpened.IsZero() {
+			jp.LastOpened = p.LastOpened.Format(time.RFC3339)
+			jp.LastOpenedEditor = p.LastOpenedEditor
+		}
+		output = append(output, jp)
 	}
 
 	encoder := json.NewEncoder(os.Stdout)
@@ -143,6 +228,7 @@ func printProjectsJSON(projects []hub.ProjectInfo) error {
 }
 
 func printProjectsTSV(projects []hub.ProjectInfo) error {
+	// New columns (last opened, tags) are appended so existing "cut -f4" pipelines keep working
 	for _, p := range projects {
 		gitInfo := ""
 		if p.GitBranch != "" {
@@ -151,41 +237,66 @@ func printProjectsTSV(projects []hub.ProjectInfo) error {
 				gitInfo += " (" + p.GitStatus + ")"
 			}
 		}
-		fmt.Printf("%s\t%s\t%s\t%s\n", p.Title, p.Version, gitInfo, p.Path)
+		lastOpened := ""
+		if !p.LastOpened.IsZero() {
+			lastOpened = p.LastOpened.Format(time.RFC3339)
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", p.Title, p.Version, gitInfo, p.Path, lastOpened, strings.Join(p.Tags, ","))
 	}
 	return nil
 }
 
 func printProjectsTable(projects []hub.ProjectInfo) error {
+	now := time.Now()
 	rows := make([][]string, 0, len(projects))
 	for _, p := range projects {
-		displayPath := truncatePath(p.Path, 50)
-		rows = append(rows, []string{p.Title, p.Version, formatGitInfo(p.GitBranch, p.GitStatus), displayPath})
+		name := p.Title
+		if p.HasTag(hub.TagFavorite) {
+			name = "★ " + name
+		}
+		rows = append(rows, []string{name, formatProjectVersion(p), formatGitInfo(p.GitBranch, p.GitStatus), formatLastOpened(p, now), formatTags(p.Tags), p.Path})
 	}
 
-	t := table.New().
-		Headers("NAME", "VERSION", "GIT", "PATH").
-		Rows(rows...).
-		Border(lipgloss.HiddenBorder()).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			if row == table.HeaderRow {
-				return headerStyle
-			}
+	t := &ui.Table{
+		Columns:     projectListTableColumns,
+		Rows:        rows,
+		HeaderStyle: headerStyle,
+		Style: func(row, col int) lipgloss.Style {
 			switch col {
 			case 0:
 				return nameStyle
 			case 1:
+				if projects[row].VersionMismatch() {
+					return versionMismatchStyle
+				}
 				return versionStyle
 			case 2:
 				return gitColumnStyle(rows[row][col])
 			case 3:
+				return openedStyle
+			case 4:
+				return tagsStyle
+			case 5:
 				return pathStyle
 			}
 			return lipgloss.NewStyle()
-		})
+		},
+	}
+	if err := t.Select(projectListColumns); err != nil {
+		return err
+	}
+	return t.Print()
+}
 
-	fmt.Println(t)
-	return nil
+// versionMismatchStyle marks the version of a project Unity Hub records another one for
+var versionMismatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+// formatProjectVersion shows the project's version, with Unity Hub's when it differs
+func formatProjectVersion(p hub.ProjectInfo) string {
+	if p.VersionMismatch() {
+		return fmt.Sprintf("%s (Hub: %s)", p.Version, p.HubVersion)
+	}
+	return p.Version
 }
 
 func gitColumnStyle(status string) lipgloss.Style {
@@ -210,21 +321,24 @@ func formatGitInfo(branch, status string) string {
 	return fmt.Sprintf("%s (%s)", branch, status)
 }
 
-func truncatePath(path string, maxLen int) string {
-	if len(path) <= maxLen {
-		return path
+// formatLastOpened shows when uniforge last launched the project, e.g. "3 days ago"
+func formatLastOpened(p hub.ProjectInfo, now time.Time) string {
+	if p.LastOpened.IsZero() {
+		return "—"
 	}
+	return hub.FormatTimeAgo(p.LastOpened, now)
+}
 
-	// Try to show meaningful parts
-	parts := strings.Split(path, string(os.PathSeparator))
-	if len(parts) <= 3 {
-		return "..." + path[len(path)-maxLen+3:]
+// formatTags lists tags other than favorite, which the name column marks with ★
+func formatTags(tags []string) string {
+	var shown []string
+	for _, tag := range tags {
+		if tag != hub.TagFavorite {
+			shown = append(shown, tag)
+		}
 	}
-
-	// Show first and last parts
-	result := parts[0] + "/.../" + strings.Join(parts[len(parts)-2:], "/")
-	if len(result) > maxLen {
-		return "..." + path[len(path)-maxLen+3:]
+	if len(shown) == 0 {
+		return "—"
 	}
-	return result
+	return strings.Join(shown, ", ")
 }
@@ -8,14 +8,30 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	openBuildTarget         string
+	openDisableDomainReload bool
+	openResetLaunch         bool
+)
+
 var projectOpenCmd = &cobra.Command{
-	Use:   "open <project>",
+	Use:   "open <project> [-- unity-args...]",
 	Short: "Open project in Unity Editor",
 	Long: `Open a Unity Hub project in Unity Editor.
 
 The project can be specified by name (partial match) or index (1-based).
 The appropriate Unity Editor version is automatically detected from the project.
 
+Launch options (--build-target and arguments after --) are saved to
+<project>/.uniforge/launch.yaml and reused on later opens, including from the
+project TUI. Use --reset to clear them.
+
+--disable-domain-reload turns off domain reload on entering Play Mode in the
+project's Editor settings (ProjectSettings/EditorSettings.asset), since Unity
+has no command line argument for it. The setting stays until
+--disable-domain-reload=false turns domain reload back on, and is shared with
+everyone using the project. Close the editor before changing it.
+
 Examples:
   # Open by project name
   uniforge project open my-project
@@ -24,31 +40,105 @@ Examples:
   uniforge project open guitar
 
   # Open by index
-  uniforge project open 1`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectOpen,
+  uniforge project open 1
+
+  # Open with Android as the active build target (remembered for next time)
+  uniforge project open my-project --build-target Android
+
+  # Enter Play Mode without reloading the scripting domain
+  uniforge project open my-project --disable-domain-reload
+
+  # Pass extra arguments to Unity
+  uniforge project open my-project -- -force-vulkan
+
+  # Forget saved launch options
+  uniforge project open my-project --reset`,
+	Args:         cobra.MinimumNArgs(1),
+	RunE:         runProjectOpen,
+	SilenceUsage: true,
 }
 
 func init() {
 	projectCmd.AddCommand(projectOpenCmd)
+
+	projectOpenCmd.Flags().StringVar(&openBuildTarget, "build-target", "", "Active build target to open with (e.g., Android, iOS, WebGL, StandaloneWindows64)")
+	projectOpenCmd.Flags().BoolVar(&openDisableDomainReload, "disable-domain-reload", false, "Turn off domain reload when entering Play Mode in the project's Editor settings")
+	projectOpenCmd.Flags().BoolVar(&openResetLaunch, "reset", false, "Clear saved launch options before applying flags")
 }
 
 func runProjectOpen(cmd *cobra.Command, args []string) error {
-	project, err := findHubProject(args[0])
+	query, passthrough, err := splitPassthroughArgs(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	project, err := findHubProject(query)
 	if err != nil {
 		return fmt.Errorf("failed to find project: %w", err)
 	}
 
+	opts, err := resolveLaunchOptions(cmd, project.Path, passthrough)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("disable-domain-reload") {
+		if err := unity.SetDomainReload(project.Path, !openDisableDomainReload); err != nil {
+			return err
+		}
+		state := "on"
+		if openDisableDomainReload {
+			state = "off"
+		}
+		ui.Muted("Domain reload on entering Play Mode turned %s in ProjectSettings/EditorSettings.asset", state)
+	}
+
 	ui.Info("Opening project: %s (%s)", project.Title, project.Version)
+	return openProject(project.Path, project.Version, project.Title, opts)
+}
 
-	err = ui.WithSpinnerNoResult("Starting Unity Editor...", func() error {
-		editor := unity.NewEditor(project.Version)
-		return editor.Open(project.Path)
-	})
-	if err != nil {
-		return fmt.Errorf("failed to open editor: %w", err)
+// splitPassthroughArgs separates the project argument from Unity arguments given after "--"
+func splitPassthroughArgs(cmd *cobra.Command, args []string) (string, []string, error) {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		dash = len(args)
+	}
+	if dash != 1 {
+		return "", nil, fmt.Errorf("expected exactly one project before \"--\", got %d", dash)
+	}
+	return args[0], args[dash:], nil
+}
+
+// resolveLaunchOptions merges the saved launch options of a project with the
+// command line flags, and saves the result when anything was changed
+func resolveLaunchOptions(cmd *cobra.Command, projectPath string, passthrough []string) (*unity.LaunchOptions, error) {
+	opts := &unity.LaunchOptions{}
+	if !openResetLaunch {
+		saved, err := unity.LoadLaunchOptions(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		opts = saved
+	}
+
+	changed := openResetLaunch
+	flags := cmd.Flags()
+	if flags.Changed("build-target") {
+		opts.BuildTarget = openBuildTarget
+		changed = true
+	}
+	if len(passthrough) > 0 {
+		opts.ExtraArgs = passthrough
+		changed = true
+	}
+
+	if changed {
+		if err := unity.SaveLaunchOptions(projectPath, opts); err != nil {
+			return nil, err
+		}
+		ui.Debug("Saved launch options", "path", unity.LaunchOptionsPath(projectPath))
+	} else if !opts.IsEmpty() {
+		ui.Muted("Using saved launch options from %s", unity.LaunchOptionsPath(projectPath))
 	}
 
-	ui.Success("Unity Editor %s started for project: %s", project.Version, project.Title)
-	return nil
+	return opts, nil
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectScanDepth    int
+	projectScanListOnly bool
+)
+
+var projectScanCmd = &cobra.Command{
+	Use:   "scan <root-dir>",
+	Short: "Find Unity projects not registered in Unity Hub",
+	Long: `Search a directory tree for Unity projects (folders with
+ProjectSettings/ProjectVersion.txt), list those Unity Hub does not know about
+and offer to register them. Useful after cloning many repositories onto a new
+machine.
+
+Hidden folders, node_modules and folders inside projects are not searched.
+Quit Unity Hub before registering: it rewrites its project list on exit.
+
+Examples:
+  # Find and register projects under ~/src
+  uniforge project scan ~/src
+
+  # Only list them
+  uniforge project scan ~/src --list-only
+
+  # Register without asking
+  uniforge project scan ~/src --yes`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runProjectScan,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectScanCmd)
+
+	projectScanCmd.Flags().IntVar(&projectScanDepth, "depth", 5, "Maximum folder depth to search below root-dir (0 for no limit)")
+	projectScanCmd.Flags().BoolVar(&projectScanListOnly, "list-only", false, "List unregistered projects without offering to register them")
+}
+
+func runProjectScan(cmd *cobra.Command, args []string) error {
+	found, err := ui.WithSpinner("Searching for Unity projects...", func() ([]*unity.Project, error) {
+		return unity.FindProjects(args[0], projectScanDepth)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", args[0], err)
+	}
+	if len(found) == 0 {
+		ui.Info("No Unity projects found under %s", args[0])
+		return nil
+	}
+
+	hubClient := hub.NewClient()
+	registered, err := hubClient.ListProjects()
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var unregistered []hub.ProjectInfo
+	for _, p := range found {
+		if !hub.IsProjectRegistered(registered, p.Path) {
+			unregistered = append(unregistered, hub.ProjectInfo{Title: p.Name, Path: p.Path, Version: p.UnityVersion})
+		}
+	}
+	if len(unregistered) == 0 {
+		ui.Success("All %d project(s) found are registered in Unity Hub", len(found))
+		return nil
+	}
+
+	ui.Info("%d of %d project(s) found are not registered in Unity Hub:", len(unregistered), len(found))
+	for _, p := range unregistered {
+		ui.Print("  %s (%s)  %s", p.Title, p.Version, p.Path)
+	}
+	if projectScanListOnly {
+		return nil
+	}
+
+	ok, err := ui.Confirm("Register %d project(s) in Unity Hub? Quit Unity Hub first", len(unregistered))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ui.Muted("Skipped. No projects were registered.")
+		return nil
+	}
+
+	added, err := hubClient.RegisterProjects(unregistered)
+	if err != nil {
+		return err
+	}
+	ui.Success("Registered %d project(s) in Unity Hub", added)
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var projectTagRemove []string
+
+var projectTagCmd = &cobra.Command{
+	Use:   "tag <project> [tag...]",
+	Short: "Tag a project",
+	Long: `Add or remove tags of a Unity Hub project, or print its tags when no tag is
+given. Tags are stored by uniforge, not Unity Hub, and filter 'project list
+--tag' and the project TUI (^T).
+
+Two tags have a meaning of their own: "favorite" projects are listed first
+and "archived" projects are hidden unless asked for.
+
+The project can be specified by name (partial match) or index (1-based).
+
+Examples:
+  # Tag a project
+  uniforge project tag my-game client prototype
+
+  # Archive it
+  uniforge project tag my-game archived --remove prototype
+
+  # Show its tags
+  uniforge project tag my-game`,
+	Args:         cobra.MinimumNArgs(1),
+	RunE:         runProjectTag,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectTagCmd)
+
+	projectTagCmd.Flags().StringSliceVar(&projectTagRemove, "remove", nil, "Remove this tag (repeatable)")
+}
+
+func runProjectTag(cmd *cobra.Command, args []string) error {
+	project, err := findHubProject(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to find project: %w", err)
+	}
+
+	add := args[1:]
+	if len(add) == 0 && len(projectTagRemove) == 0 {
+		if len(project.Tags) == 0 {
+			ui.Muted("%s has no tags", project.Title)
+			return nil
+		}
+		fmt.Println(strings.Join(project.Tags, "\n"))
+		return nil
+	}
+
+	tags, err := hub.NewClient().UpdateProjectTags(project.Path, add, projectTagRemove)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		ui.Success("%s has no tags", project.Title)
+	} else {
+		ui.Success("%s: %s", project.Title, strings.Join(tags, ", "))
+	}
+	return nil
+}
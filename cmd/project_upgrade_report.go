@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeReportOffline bool
+	upgradeReportFormat  string
+)
+
+var upgradeWarnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+var projectUpgradeReportCmd = &cobra.Command{
+	Use:   "upgrade-report <project> <target-version>",
+	Short: "Report what changes when a project moves to another Unity version",
+	Long: `Compare a project with a target Unity version before upgrading it.
+
+Packages in Packages/manifest.json are checked against the package versions the
+target editor ships with (when it is installed) and against the compatibility
+data of the Unity package registry. The project's scripts are searched for
+well-known APIs that were deprecated or removed between the two versions.
+
+The target version accepts the same specifiers as 'editor install', such as
+"6000.0" or "lts".
+
+Examples:
+  # Check the project in the current directory against Unity 6
+  uniforge project upgrade-report . 6000.0
+
+  # Without contacting the package registry
+  uniforge project upgrade-report ./MyGame 2022.3.60f1 --offline
+
+  # JSON for scripting
+  uniforge project upgrade-report . lts --format json`,
+	Args:         cobra.ExactArgs(2),
+	RunE:         runProjectUpgradeReport,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectUpgradeReportCmd)
+
+	projectUpgradeReportCmd.Flags().BoolVar(&upgradeReportOffline, "offline", false, "Skip the package registry")
+	projectUpgradeReportCmd.Flags().StringVar(&upgradeReportFormat, "format", "table", "Output format: table, json")
+}
+
+func runProjectUpgradeReport(cmd *cobra.Command, args []string) error {
+	if upgradeReportFormat != "table" && upgradeReportFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s", upgradeReportFormat)
+	}
+
+	projectRoot, err := unity.FindProjectRoot(args[0])
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	hubClient := hub.NewClient()
+	target, err := hubClient.ResolveVersionSpec(args[1], true)
+	if err != nil {
+		return err
+	}
+
+	opts := unity.UpgradeReportOptions{Offline: upgradeReportOffline}
+	if dir, err := unity.NewEditor(target).PackageManagerDir(); err == nil {
+		opts.EditorPackages = unity.EditorPackageVersions(dir)
+	} else {
+		ui.Debug("Target editor not available", "version", target, "error", err)
+	}
+
+	report, err := ui.WithSpinner("Comparing project with Unity "+target+"...", func() (*unity.UpgradeReport, error) {
+		return unity.BuildUpgradeReport(project, target, opts)
+	})
+	if err != nil {
+		return err
+	}
+
+	if upgradeReportFormat == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printUpgradeReport(report)
+	return nil
+}
+
+// printUpgradeReport prints the package table and the obsolete API usages
+func printUpgradeReport(report *unity.UpgradeReport) {
+	for _, w := range report.Warnings {
+		ui.Warn("%s", w)
+	}
+	ui.Info("Upgrade report: Unity %s -> %s", report.FromVersion, report.ToVersion)
+
+	rows := make([][]string, 0, len(report.Packages))
+	issues := 0
+	for _, p := range report.Packages {
+		if p.Status != unity.PackageUnchanged && p.Status != unity.PackageLocal {
+			issues++
+		}
+		rows = append(rows, []string{p.Name, p.Current, p.Target, p.Status, p.Note})
+	}
+	if len(rows) > 0 {
+		t := table.New().
+			Headers("PACKAGE", "CURRENT", "TARGET", "STATUS", "NOTE").
+			Rows(rows...).
+			Border(lipgloss.HiddenBorder()).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				if row == table.HeaderRow {
+					return headerStyle
+				}
+				switch col {
+				case 1, 2:
+					return versionStyle
+				case 3:
+					switch report.Packages[row].Status {
+					case unity.PackageIncompatible:
+						return buildFailStyle
+					case unity.PackageUpgraded, unity.PackageUpdateAvailable, unity.PackageUnknown:
+						return upgradeWarnStyle
+					}
+					return pathStyle
+				case 4:
+					return pathStyle
+				}
+				return lipgloss.NewStyle()
+			})
+		fmt.Println(t)
+	}
+
+	if len(report.APIs) == 0 {
+		ui.Success("No obsolete APIs found")
+	} else {
+		ui.Warn("Found %d obsolete APIs:", len(report.APIs))
+		for _, api := range report.APIs {
+			state := "deprecated"
+			if api.Removed {
+				state = "removed"
+			}
+			fmt.Printf("  %s (%s in %s, %d uses)", api.Symbol, state, api.Since, api.Count)
+			if api.Replacement != "" {
+				fmt.Printf(" -> %s", api.Replacement)
+			}
+			fmt.Println()
+			for _, loc := range api.Locations {
+				fmt.Printf("    %s\n", pathStyle.Render(loc))
+			}
+			if more := api.Count - len(api.Locations); more > 0 {
+				fmt.Printf("    %s\n", pathStyle.Render(fmt.Sprintf("... and %d more", more)))
+			}
+		}
+	}
+
+	if issues > 0 {
+		ui.Warn("%d packages change or need attention", issues)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	warmBuildTarget            string
+	warmDisableAssemblyUpdater bool
+	warmLogFile                string
+	warmTimeout                int
+)
+
+var projectWarmCmd = &cobra.Command{
+	Use:   "warm [project]",
+	Short: "Import all assets in batch mode to fill the Library folder",
+	Long: `Open the project once in batch mode with the project's editor version and
+quit when the import is done, so the Library folder is ready before the
+editor is opened. Useful right after cloning or after deleting Library.
+
+Progress is parsed from Unity's asset import log lines; the full log is only
+kept with --log-file. The build target defaults to the one saved with
+'project open --build-target', so the Library matches how the project is
+opened. The project must not be open in another editor.
+
+Examples:
+  # Warm up the project in the current directory
+  uniforge project warm
+
+  # Import for Android, skipping the assembly updater
+  uniforge project warm ./MyGame --build-target Android --disable-assembly-updater`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runProjectWarm,
+	SilenceUsage: true,
+}
+
+func init() {
+	projectCmd.AddCommand(projectWarmCmd)
+
+	projectWarmCmd.Flags().StringVar(&warmBuildTarget, "build-target", "", "Build target to import for (e.g., Android, iOS, WebGL, StandaloneWindows64)")
+	projectWarmCmd.Flags().BoolVar(&warmDisableAssemblyUpdater, "disable-assembly-updater", false, "Skip the API updater for precompiled assemblies")
+	projectWarmCmd.Flags().StringVar(&warmLogFile, "log-file", "", "Path to save log file")
+	projectWarmCmd.Flags().IntVar(&warmTimeout, "timeout", 7200, "Timeout in seconds")
+}
+
+func runProjectWarm(cmd *cobra.Command, args []string) error {
+	projectPath := "."
+	if len(args) > 0 {
+		projectPath = args[0]
+	}
+	projectRoot, err := unity.FindProjectRoot(projectPath)
+	if err != nil {
+		return err
+	}
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	buildTarget := warmBuildTarget
+	if buildTarget == "" {
+		if opts, err := unity.LoadLaunchOptions(project.Path); err == nil && opts != nil {
+			buildTarget = opts.BuildTarget
+		}
+	}
+
+	title := fmt.Sprintf("Importing %s with Unity %s", project.Name, project.UnityVersion)
+	if buildTarget != "" {
+		title += " for " + buildTarget
+	}
+	progress := ui.NewProgress(title, 0)
+	result, err := unity.NewRunner(project).Warm(unity.WarmConfig{
+		ProjectPath:            project.Path,
+		BuildTarget:            buildTarget,
+		DisableAssemblyUpdater: warmDisableAssemblyUpdater,
+		LogFile:                warmLogFile,
+		TimeoutSeconds:         warmTimeout,
+	}, func(imported int) {
+		progress.Set(int64(imported))
+	})
+	progress.Done(err)
+	if err != nil {
+		if result != nil && len(result.LogTail) > 0 {
+			ui.Muted("Last lines of the log:")
+			for _, line := range result.LogTail {
+				ui.Print("  %s", line)
+			}
+		}
+		return err
+	}
+
+	ui.Info("%d asset(s) imported in %s", result.Imported, result.Duration.Round(time.Second))
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/prompt"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var promptFormat string
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt [dir]",
+	Short: "Print the current Unity project for a shell prompt",
+	Long: `Print the Unity project containing the directory (default: current), its
+editor version and whether that editor is installed, for prompt segments.
+
+Outside a Unity project nothing is printed and the exit status is 1. The
+install status comes from a running 'uniforge daemon' or a cache refreshed
+every minute, so the command stays fast enough to run on every prompt.
+
+Text output is "<project> <version>", followed by "(not installed)" when the
+editor is missing.
+
+Commands that change what a prompt shows (open, editor install, doctor) also
+write <project>/.uniforge/status.json with the project's version, whether it
+is installed and the license mode, so prompt frameworks can read that file
+without starting a process.
+
+Examples:
+  # Text for the current directory
+  uniforge prompt
+
+  # JSON for scripts: {"name", "path", "version", "installed"}
+  uniforge prompt --format json
+
+  # starship.toml
+  [custom.unity]
+  command = "uniforge prompt"
+  when = "uniforge prompt"
+  symbol = "◆ "`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	Annotations:  map[string]string{noTelemetry: "true"},
+	RunE:         runPrompt,
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+
+	promptCmd.Flags().StringVar(&promptFormat, "format", "text", "Output format: text, json")
+}
+
+func runPrompt(cmd *cobra.Command, args []string) error {
+	if promptFormat != "text" && promptFormat != "json" {
+		return errs.New(errs.Usage, "unknown format %q (use text or json)", promptFormat)
+	}
+
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	info, err := prompt.Lookup(dir, prompt.DefaultOptions())
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		// Non-zero so `when = "uniforge prompt"` hides the segment
+		exit(cmd, 1)
+	}
+
+	if promptFormat == "json" {
+		data, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	line := info.Name
+	if info.Version != "" {
+		line += " " + info.Version
+		if !info.Installed {
+			line += " (not installed)"
+		}
+	}
+	fmt.Println(line)
+	return nil
+}
+
+// refreshProjectStatus updates .uniforge/status.json of the project containing
+// dir, if dir is inside one. Failures only matter to prompts, so they are not reported.
+func refreshProjectStatus(dir string) {
+	root, err := unity.FindProjectRoot(dir)
+	if err != nil {
+		return
+	}
+	if _, err := prompt.UpdateStatus(root); err != nil {
+		ui.Debug("Failed to update project status", "project", root, "error", err)
+	}
+}
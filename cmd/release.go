@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:    "release",
+	Short:  "Maintenance commands for publishing uniforge releases",
+	Long:   `Commands used by the uniforge maintainers to publish releases.`,
+	Hidden: true,
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/release"
+	"github.com/neptaco/uniforge/pkg/selfupdate"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseManifestVersion string
+	releaseManifestDist    string
+	releaseManifestOutput  string
+)
+
+var releaseManifestCmd = &cobra.Command{
+	Use:   "manifest <homebrew|scoop>",
+	Short: "Generate the Homebrew formula or Scoop manifest of a release",
+	Long: `Generate the Homebrew formula or Scoop manifest of a release from its
+archives and checksums.txt.
+
+The artifacts are read from a goreleaser dist folder with --dist, or from the
+GitHub release of --version (default: the latest release). GITHUB_TOKEN is used,
+when set, to raise the GitHub API rate limit.
+
+Examples:
+  # Formula of the latest release
+  uniforge release manifest homebrew
+
+  # Scoop manifest of a specific release
+  uniforge release manifest scoop --version 1.4.0 -o uniforge.json
+
+  # From a local goreleaser build
+  uniforge release manifest homebrew --dist dist -o Formula/uniforge.rb`,
+	Args:         cobra.ExactArgs(1),
+	ValidArgs:    []string{"homebrew", "scoop"},
+	RunE:         runReleaseManifest,
+	SilenceUsage: true,
+}
+
+func init() {
+	releaseCmd.AddCommand(releaseManifestCmd)
+
+	releaseManifestCmd.Flags().StringVar(&releaseManifestVersion, "version", "", "Release version (default: the latest release, or the version in --dist)")
+	releaseManifestCmd.Flags().StringVar(&releaseManifestDist, "dist", "", "goreleaser dist folder to read checksums.txt from instead of GitHub")
+	releaseManifestCmd.Flags().StringVarP(&releaseManifestOutput, "output", "o", "", "Write the manifest to this file instead of stdout")
+}
+
+func runReleaseManifest(cmd *cobra.Command, args []string) error {
+	kind := args[0]
+	if kind != "homebrew" && kind != "scoop" {
+		return errs.New(errs.Usage, "unknown manifest %q (available: homebrew, scoop)", kind)
+	}
+
+	var metadata *release.Metadata
+	var err error
+	if releaseManifestDist != "" {
+		metadata, err = releaseMetadataFromDist(releaseManifestDist, releaseManifestVersion)
+	} else {
+		metadata, err = ui.WithSpinner("Fetching release artifacts...", func() (*release.Metadata, error) {
+			return releaseMetadataFromGitHub(context.Background(), releaseManifestVersion)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if kind == "homebrew" {
+		formula, ferr := metadata.HomebrewFormula()
+		out, err = []byte(formula), ferr
+	} else {
+		out, err = metadata.ScoopManifest()
+	}
+	if err != nil {
+		return err
+	}
+
+	if releaseManifestOutput == "" {
+		fmt.Print(string(out))
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(releaseManifestOutput), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(releaseManifestOutput, out, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	ui.Success("Wrote %s for uniforge %s", releaseManifestOutput, metadata.Version)
+	return nil
+}
+
+// releaseMetadataFromDist reads the artifacts of a local goreleaser build. The
+// version defaults to the one goreleaser recorded in metadata.json.
+func releaseMetadataFromDist(dist, version string) (*release.Metadata, error) {
+	checksums, err := os.ReadFile(filepath.Join(dist, selfupdate.ChecksumsAsset))
+	if err != nil {
+		return nil, errs.WithHint(
+			fmt.Errorf("failed to read checksums: %w", err),
+			"run goreleaser first, or omit --dist to use the GitHub release",
+		)
+	}
+	if version == "" {
+		var goreleaser struct {
+			Version string `json:"version"`
+		}
+		data, err := os.ReadFile(filepath.Join(dist, "metadata.json"))
+		if err == nil {
+			err = json.Unmarshal(data, &goreleaser)
+		}
+		if err != nil || goreleaser.Version == "" {
+			return nil, errs.New(errs.Usage, "could not read the version from %s; pass --version", filepath.Join(dist, "metadata.json"))
+		}
+		version = goreleaser.Version
+	}
+	return release.FromChecksums(version, checksums, nil)
+}
+
+// releaseMetadataFromGitHub reads the artifacts of a published release, or of
+// the latest one when version is empty
+func releaseMetadataFromGitHub(ctx context.Context, version string) (*release.Metadata, error) {
+	checker := selfupdate.NewChecker()
+	var published *selfupdate.Release
+	var err error
+	if version == "" {
+		published, err = checker.Latest(ctx)
+	} else {
+		published, err = checker.Tagged(ctx, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	asset, ok := published.Asset(selfupdate.ChecksumsAsset)
+	if !ok {
+		return nil, errs.New(errs.NotFound, "release %s has no %s", published.Version, selfupdate.ChecksumsAsset)
+	}
+	checksums, err := checker.Download(ctx, asset.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", selfupdate.ChecksumsAsset, err)
+	}
+	return release.FromChecksums(published.Version, checksums, func(name string) string {
+		if a, ok := published.Asset(name); ok {
+			return a.URL
+		}
+		return release.DownloadURL(published.Version, name)
+	})
+}
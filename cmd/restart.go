@@ -40,6 +40,11 @@ func runRestart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load project: %w", err)
 	}
 
+	opts, err := unity.LoadLaunchOptions(project.Path)
+	if err != nil {
+		return err
+	}
+
 	editor := unity.NewEditor(project.UnityVersion)
 
 	// Try to close existing instance (ignore error if not running)
@@ -55,7 +60,7 @@ func runRestart(cmd *cobra.Command, args []string) error {
 
 	// Open editor
 	err = ui.WithSpinnerNoResult("Starting Unity Editor...", func() error {
-		return editor.Open(project.Path)
+		return editor.OpenWithOptions(project.Path, opts)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to open editor: %w", err)
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	resumeList        bool
+	resumeDiscard     bool
+	resumeLockTimeout time.Duration
+)
+
+var editorResumeCmd = &cobra.Command{
+	Use:   "resume [version]",
+	Short: "Continue interrupted editor installs",
+	Long: `Continue editor and module installs that were interrupted, e.g. by a reboot
+or a killed process. Every install is recorded in a queue in the uniforge
+cache directory until it finishes; without a version all queued installs are
+resumed, oldest first.
+
+Installs without Unity Hub continue partially downloaded installers where the
+server supports it and reuse installers that were downloaded completely.
+Installs through Unity Hub are started again.
+
+Examples:
+  # Show interrupted installs
+  uniforge editor resume --list
+
+  # Resume all of them
+  uniforge editor resume
+
+  # Resume one version
+  uniforge editor resume 2022.3.10f1
+
+  # Forget an interrupted install and delete its downloads
+  uniforge editor resume 2022.3.10f1 --discard`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runResume,
+	SilenceUsage: true,
+}
+
+func init() {
+	editorCmd.AddCommand(editorResumeCmd)
+
+	editorResumeCmd.Flags().BoolVar(&resumeList, "list", false, "List interrupted installs without resuming them")
+	editorResumeCmd.Flags().BoolVar(&resumeDiscard, "discard", false, "Remove interrupted installs from the queue and delete their downloads")
+	addLockTimeoutFlag(editorResumeCmd, &resumeLockTimeout)
+}
+
+func runResume(cmd *cobra.Command, args []string) error {
+	hubClient := hub.NewClient()
+	hubClient.NoCache = viper.GetBool("no-cache")
+	applyLockTimeout(cmd, hubClient, resumeLockTimeout)
+
+	jobs, err := hubClient.LoadInstallQueue()
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		var matched []hub.InstallJob
+		for _, job := range jobs {
+			if job.Version == args[0] {
+				matched = append(matched, job)
+			}
+		}
+		if len(matched) == 0 {
+			return errs.WithHint(
+				errs.New(errs.NotFound, "no interrupted install of Unity %s", args[0]),
+				"Run 'uniforge editor resume --list' to see interrupted installs",
+			)
+		}
+		jobs = matched
+	}
+	if len(jobs) == 0 {
+		ui.Info("No interrupted installs")
+		return nil
+	}
+
+	switch {
+	case resumeList:
+		for _, job := range jobs {
+			fmt.Println(describeInstallJob(job))
+		}
+		return nil
+	case resumeDiscard:
+		for _, job := range jobs {
+			if err := hubClient.DiscardInstall(job.Version); err != nil {
+				return err
+			}
+			ui.Success("Discarded install of Unity %s", job.Version)
+		}
+		return nil
+	}
+
+	for _, job := range jobs {
+		ui.Info("Resuming %s", describeInstallJob(job))
+		start := time.Now()
+		err := hubClient.ResumeInstall(job)
+		notifyHook(hooks.EventInstall, job.Version, start, err)
+		if err != nil {
+			return fmt.Errorf("failed to resume install of Unity %s: %w", job.Version, err)
+		}
+		ui.Success("Installed Unity %s", job.Version)
+	}
+	refreshProjectStatus(".")
+	return nil
+}
+
+// describeInstallJob summarizes a queued install on one line
+func describeInstallJob(job hub.InstallJob) string {
+	what := "Unity " + job.Version
+	if job.ModulesOnly {
+		what = "modules for Unity " + job.Version
+	}
+	if len(job.Modules) > 0 {
+		what += " (" + strings.Join(job.Modules, ", ") + ")"
+	}
+
+	details := []string{"via " + job.Backend, "started " + hub.FormatTimeAgo(job.Started, time.Now())}
+	if done, total := job.Downloaded(); done > 0 {
+		if total > 0 {
+			details = append(details, fmt.Sprintf("%s of %s downloaded", ui.FormatBytes(done), ui.FormatBytes(total)))
+		} else {
+			details = append(details, ui.FormatBytes(done)+" downloaded")
+		}
+	}
+	return what + ", " + strings.Join(details, ", ")
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
+	"strings"
 
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/paths"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -15,31 +18,56 @@ var (
 	Version  string
 )
 
+// configEnvKeys maps config file keys to the environment variables they stand in for
+var configEnvKeys = map[string]string{
+	"hub-path":         "UNIFORGE_HUB_PATH",
+	"editor-base-path": "UNIFORGE_EDITOR_BASE_PATH",
+	"editor":           "UNIFORGE_EDITOR",
+	"env-profile":      "UNIFORGE_ENV_PROFILE",
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "uniforge",
 	Short: "Command-line tool for Unity development",
 	Long: `UniForge is a command-line tool for Unity development.
 It provides functionality to manage Unity Editor installations,
 build Unity projects, and run Unity in batch mode.`,
+	// Errors are printed by Execute, together with a remediation hint
+	SilenceErrors: true,
 }
 
 func Execute(version string) {
 	Version = version
 	rootCmd.Version = version
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	cmd, err := rootCmd.ExecuteC()
+	finishTrace(cmd, err)
+	reportTelemetry(cmd, errs.ExitCode(err), err)
+	if err != nil {
+		ui.Error("%v", err)
+		if hint := errs.Hint(err); hint != "" {
+			ui.Hint("%s", hint)
+		}
+		os.Exit(errs.ExitCode(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.uniforge.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.uniforge.yaml, or config.yaml in $UNIFORGE_HOME or uniforge's config directory)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().Bool("no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().Bool("no-pager", false, "print long tables directly instead of through a pager ($UNIFORGE_PAGER, $PAGER or less)")
 	rootCmd.PersistentFlags().Bool("no-cache", false, "skip reading from cache (still writes to cache)")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "answer yes to all confirmations")
+	rootCmd.PersistentFlags().Bool("no-input", false, "never prompt; fail instead when input would be needed")
+	rootCmd.PersistentFlags().String("lang", "", "language for module names: en, ja, zh, ko (default from locale)")
+	rootCmd.PersistentFlags().Bool("trace-timing", false, "print how long cache loads, API calls, scans and subprocesses took")
+	rootCmd.PersistentFlags().String("trace-otlp", "", "export the timing as a trace to this OTLP/HTTP endpoint (e.g., http://localhost:4318/v1/traces)")
 
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errs.New(errs.Usage, "%w", err)
+	})
 	rootCmd.SetVersionTemplate(`{{printf "%s\n" .Version}}`)
 
 	if err := viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level")); err != nil {
@@ -50,32 +78,90 @@ func init() {
 		ui.Error("Failed to bind no-color flag: %v", err)
 		os.Exit(1)
 	}
+	if err := viper.BindPFlag("no-pager", rootCmd.PersistentFlags().Lookup("no-pager")); err != nil {
+		ui.Error("Failed to bind no-pager flag: %v", err)
+		os.Exit(1)
+	}
 	if err := viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache")); err != nil {
 		ui.Error("Failed to bind no-cache flag: %v", err)
 		os.Exit(1)
 	}
+	if err := viper.BindPFlag("yes", rootCmd.PersistentFlags().Lookup("yes")); err != nil {
+		ui.Error("Failed to bind yes flag: %v", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("no-input", rootCmd.PersistentFlags().Lookup("no-input")); err != nil {
+		ui.Error("Failed to bind no-input flag: %v", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("lang", rootCmd.PersistentFlags().Lookup("lang")); err != nil {
+		ui.Error("Failed to bind lang flag: %v", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("trace-timing", rootCmd.PersistentFlags().Lookup("trace-timing")); err != nil {
+		ui.Error("Failed to bind trace-timing flag: %v", err)
+		os.Exit(1)
+	}
+	if err := viper.BindPFlag("trace-otlp", rootCmd.PersistentFlags().Lookup("trace-otlp")); err != nil {
+		ui.Error("Failed to bind trace-otlp flag: %v", err)
+		os.Exit(1)
+	}
 }
 
 func initConfig() {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-
-		viper.AddConfigPath(home)
+		viper.SetConfigFile(paths.ConfigFile())
 		viper.SetConfigType("yaml")
-		viper.SetConfigName(".uniforge")
 	}
 
 	viper.SetEnvPrefix("UNIFORGE")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err == nil {
 		ui.Debug("Using config file", "path", viper.ConfigFileUsed())
 	}
 
+	// Packages read these settings from the environment; let the config file provide them too
+	for key, env := range configEnvKeys {
+		if os.Getenv(env) == "" && viper.IsSet(key) {
+			_ = os.Setenv(env, viper.GetString(key))
+		}
+	}
+
+	ui.SetNoColor(viper.GetBool("no-color"))
+	ui.SetNoPager(viper.GetBool("no-pager"))
+	hub.SetMouse(!viper.GetBool("no-mouse"))
+
 	// Set debug mode based on log level
 	logLevel := viper.GetString("log-level")
 	ui.SetDebugMode(logLevel == "debug")
+
+	// Earlier versions kept some files in other directories
+	moved, err := paths.Migrate()
+	for _, m := range moved {
+		ui.Debug("Moved to uniforge's directories", "from", m.From, "to", m.To)
+	}
+	if err != nil {
+		ui.Debug("Failed to move files to uniforge's directories", "error", err)
+	}
+
+	startTrace()
+
+	ui.SetAssumeYes(viper.GetBool("yes"))
+	ui.SetNoInput(viper.GetBool("no-input"))
+
+	lang := viper.GetString("lang")
+	if lang == "" {
+		lang = hub.DetectLanguage()
+	} else if !hub.IsSupportedLanguage(lang) {
+		ui.Warn("Unsupported language %q, using English (supported: %s)", lang, strings.Join(hub.SupportedLanguages, ", "))
+	}
+	hub.SetLanguage(lang)
+
+	if err := hub.SetKeyBindings(viper.GetStringMapStringSlice("keys")); err != nil {
+		ui.Warn("Ignoring key bindings in config: %v", err)
+	}
 }
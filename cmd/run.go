@@ -3,7 +3,11 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/logger"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/neptaco/uniforge/pkg/unity"
 	"github.com/spf13/cobra"
@@ -14,6 +18,9 @@ var (
 	runTimeout   int
 	runCIMode    bool
 	runTimestamp bool
+	runVersion   string
+	runIdle      int
+	runNoise     []string
 )
 
 var runCmd = &cobra.Command{
@@ -38,8 +45,14 @@ Examples:
   # Custom asset processing
   uniforge run -- -executeMethod AssetProcessor.ProcessAll
 
+  # Use the newest installed 2022.3 editor instead of the project's version
+  uniforge run --editor-version 2022.3 -- -executeMethod MyScript.DoSomething
+
   # With project path and timeout
-  uniforge run /path/to/project --timeout 3600 -- -executeMethod LongProcess.Run`,
+  uniforge run /path/to/project --timeout 3600 -- -executeMethod LongProcess.Run
+
+  # Kill Unity if it logs nothing for 10 minutes
+  uniforge run --idle-timeout 600 -- -executeMethod LongProcess.Run`,
 	RunE: runRun,
 }
 
@@ -50,9 +63,17 @@ func init() {
 	runCmd.Flags().IntVar(&runTimeout, "timeout", 3600, "Timeout in seconds")
 	runCmd.Flags().BoolVar(&runCIMode, "ci", false, "CI mode (optimized output format)")
 	runCmd.Flags().BoolVarP(&runTimestamp, "timestamp", "t", false, "Show timestamp for each line")
+	runCmd.Flags().IntVar(&runIdle, "idle-timeout", 0, "Kill Unity after this many seconds without log output, or sooner after a known hang (0 to disable)")
+	runCmd.Flags().StringSliceVar(&runNoise, "show-noise", nil, showNoiseUsage)
+	runCmd.Flags().StringVar(&runVersion, "editor-version", "", "Unity version to use instead of the project's (e.g. 2022.3.10f1, 2022.3, lts)")
 }
 
 func runRun(cmd *cobra.Command, args []string) error {
+	showNoise, err := logger.ParseNoiseCategories(runNoise)
+	if err != nil {
+		return err
+	}
+
 	projectPath := "."
 	unityArgs := args
 
@@ -69,6 +90,15 @@ func runRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load project: %w", err)
 	}
 
+	if runVersion != "" {
+		version, err := hub.NewClient().ResolveVersionSpec(runVersion, false)
+		if err != nil {
+			return err
+		}
+		ui.Info("Using Unity %s", version)
+		project.UnityVersion = version
+	}
+
 	runConfig := unity.RunConfig{
 		ProjectPath:    projectPath,
 		ExtraArgs:      unityArgs,
@@ -76,11 +106,17 @@ func runRun(cmd *cobra.Command, args []string) error {
 		TimeoutSeconds: runTimeout,
 		CIMode:         runCIMode,
 		ShowTimestamp:  runTimestamp,
+		ShowNoise:      showNoise,
+
+		IdleTimeoutSeconds: runIdle,
 	}
 
+	start := time.Now()
 	runner := unity.NewRunner(project)
-	if err := runner.Run(runConfig); err != nil {
-		return fmt.Errorf("execution failed: %w", err)
+	err = runner.Run(runConfig)
+	notifyHook(hooks.EventRun, project.Name, start, err)
+	if err != nil {
+		return stallError(fmt.Errorf("execution failed: %w", err))
 	}
 
 	ui.Success("Unity execution completed successfully")
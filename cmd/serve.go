@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/platform"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/webgl"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveHost string
+	servePort int
+	serveOpen bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve <build-dir>",
+	Short: "Serve a WebGL build locally",
+	Long: `Serve a Unity WebGL build over HTTP for local testing.
+
+Compressed payloads (.br, .gz) are served with the matching Content-Encoding
+and the Content-Type of the uncompressed file, and .wasm files are served as
+application/wasm, so Unity's loader works without decompression fallback.
+
+Examples:
+  # Serve on http://localhost:8080
+  uniforge serve Builds/WebGL
+
+  # Use another port and open the browser
+  uniforge serve Builds/WebGL --port 3000 --open`,
+	Args: cobra.ExactArgs(1),
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost", "Host to listen on")
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	serveCmd.Flags().BoolVar(&serveOpen, "open", false, "Open the build in the default browser")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	buildDir := args[0]
+	if err := webgl.ValidateBuildDir(buildDir); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(serveHost, fmt.Sprint(servePort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	server := &http.Server{
+		Handler:           webgl.Handler(buildDir),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	url := fmt.Sprintf("http://%s", listener.Addr().String())
+	ui.Success("Serving %s at %s (Ctrl+C to stop)", buildDir, url)
+
+	if serveOpen {
+		if err := platform.OpenURL(url); err != nil {
+			ui.Warn("Failed to open browser: %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server error: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
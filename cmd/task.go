@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/tasks"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var taskDryRun bool
+
+var taskCmd = &cobra.Command{
+	Use:   "task [name] [project]",
+	Short: "Run a task defined in .uniforge/tasks.yaml",
+	Long: `Run a task of the project's .uniforge/tasks.yaml, giving the team one entry
+point instead of scattered shell scripts. Without a name, the tasks are listed.
+
+A task is a list of steps, each one of:
+  uniforge: <args>   a uniforge command, e.g. "build --profile android"
+  run: <command>     a shell command (sh -c, or cmd /C on Windows)
+  task: <name>       another task
+
+Steps run in the project folder and stop at the first failure. They see
+PROJECT_PATH, UNITY_VERSION, UNITY_PATH (the project's editor, if installed;
+an existing UNITY_PATH is kept) and UNIFORGE_TASK, plus the project's
+.uniforge/env.yaml (see 'uniforge env show') and the env of tasks.yaml and of
+the task. Env values and uniforge arguments may refer to variables as
+$NAME or ${NAME}; write $$ for a literal $. Shell steps expand variables
+themselves.
+
+  env:
+    BUILD_DIR: Builds
+  tasks:
+    check:
+      description: Checks run before every push
+      steps:
+        - uniforge: meta check
+        - uniforge: project compile
+    build-android:
+      env:
+        OUTPUT: ${BUILD_DIR}/android
+      steps:
+        - task: check
+        - uniforge: build --profile android -o $OUTPUT
+        - run: ./scripts/upload.sh "$OUTPUT"
+
+Examples:
+  # List the tasks
+  uniforge task
+
+  # Run a task
+  uniforge task build-android
+
+  # Show the steps without running them
+  uniforge task build-android --dry-run`,
+	Args:         cobra.MaximumNArgs(2),
+	RunE:         runTask,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(taskCmd)
+
+	taskCmd.Flags().BoolVarP(&taskDryRun, "dry-run", "n", false, "Print the steps instead of running them")
+}
+
+func runTask(cmd *cobra.Command, args []string) error {
+	projectRoot, err := projectRootArg(args, 1)
+	if err != nil {
+		return err
+	}
+	config, err := tasks.Load(projectRoot)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		printTasks(config)
+		return nil
+	}
+	name := args[0]
+
+	if taskDryRun {
+		steps, err := config.Plan(name)
+		if err != nil {
+			return err
+		}
+		for i, step := range steps {
+			fmt.Printf("%d. %s %s\n", i+1, pathStyle.Render("["+step.Task+"]"), step.Step)
+		}
+		return nil
+	}
+
+	env, err := taskEnv(projectRoot, name)
+	if err != nil {
+		return err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the uniforge executable: %w", err)
+	}
+
+	runner := &tasks.Runner{
+		Config:      config,
+		ProjectPath: projectRoot,
+		Executable:  executable,
+		Env:         env,
+		Stdout:      os.Stdout,
+		Stderr:      os.Stderr,
+		OnStep: func(i, total int, step tasks.PlannedStep) {
+			ui.Info("[%d/%d] %s: %s", i+1, total, step.Task, step.Step)
+		},
+	}
+	start := time.Now()
+	if err := runner.Run(name); err != nil {
+		return err
+	}
+	ui.Success("Task %s completed in %s", name, time.Since(start).Round(time.Second))
+	return nil
+}
+
+// taskEnv returns the variables uniforge sets for the steps of a task
+func taskEnv(projectRoot, name string) ([]string, error) {
+	project, err := unity.LoadProject(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
+	}
+	// The project's env.yaml comes first so tasks.yaml can build on it
+	vars, err := unity.ProjectEnvVars(projectRoot, os.Getenv(unity.EnvProfileEnv))
+	if err != nil {
+		return nil, err
+	}
+	env := append(unity.EnvStrings(vars),
+		"PROJECT_PATH="+project.Path,
+		"UNITY_VERSION="+project.UnityVersion,
+		"UNIFORGE_TASK="+name,
+	)
+
+	editorPath, err := unityPath(project.UnityVersion)
+	if err != nil {
+		// Tasks with shell steps only work without the editor
+		ui.Debug("UNITY_PATH not set for task", "version", project.UnityVersion, "error", err)
+		return env, nil
+	}
+	return append(env, "UNITY_PATH="+editorPath), nil
+}
+
+func printTasks(config *tasks.Config) {
+	rows := make([][]string, 0, len(config.Tasks))
+	for _, name := range config.Names() {
+		task := config.Tasks[name]
+		rows = append(rows, []string{name, task.Description})
+	}
+
+	t := table.New().
+		Headers("TASK", "DESCRIPTION").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col == 0 {
+				return pathStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+}
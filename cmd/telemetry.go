@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/telemetry"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// commandStart is when the process started running the command, for telemetry
+var commandStart = time.Now()
+
+// telemetryWait bounds how long a report may delay the exit of a command. A
+// report still in flight is dropped when the process exits.
+const telemetryWait = 300 * time.Millisecond
+
+// noTelemetry is the annotation that exempts a command and its subcommands
+// from telemetry, for commands run so often (prompt) or so long (daemon) that
+// their outcome says nothing about failures.
+const noTelemetry = "uniforge.telemetry.off"
+
+// reportTelemetry posts the outcome of cmd to the telemetry endpoint when the
+// user opted in (see "uniforge config telemetry"). Failures never affect the command.
+func reportTelemetry(cmd *cobra.Command, exitCode int, err error) {
+	if !viper.GetBool("telemetry") || telemetryExempt(cmd) {
+		return
+	}
+	endpoint := viper.GetString("telemetry-endpoint")
+	if endpoint == "" {
+		ui.Debug("Telemetry is enabled but no telemetry-endpoint is set")
+		return
+	}
+
+	name := rootCmd.Name()
+	if cmd != nil {
+		name = cmd.CommandPath()
+	}
+	event := telemetry.NewEvent(name, Version, commandStart, exitCode, err)
+	done := make(chan error, 1)
+	go func() { done <- telemetry.Send(endpoint, event) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			ui.Debug("Failed to report telemetry", "error", err)
+			return
+		}
+		ui.Debug("Reported telemetry", "endpoint", endpoint, "command", name)
+	case <-time.After(telemetryWait):
+		ui.Debug("Gave up waiting for telemetry", "endpoint", endpoint, "wait", telemetryWait)
+	}
+}
+
+// telemetryExempt reports whether cmd or one of its parents carries the
+// noTelemetry annotation
+func telemetryExempt(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[noTelemetry]; ok {
+			return true
+		}
+	}
+	return false
+}
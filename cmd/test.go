@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/logger"
 	"github.com/neptaco/uniforge/pkg/ui"
 	"github.com/neptaco/uniforge/pkg/unity"
 	"github.com/spf13/cobra"
@@ -16,6 +19,8 @@ var (
 	testTimeout   int
 	testCIMode    bool
 	testTimestamp bool
+	testAll       bool
+	testNoise     []string
 )
 
 var testCmd = &cobra.Command{
@@ -42,7 +47,11 @@ Examples:
   uniforge test --platform editmode --ci --timeout 1800
 
   # Specify project path
-  uniforge test /path/to/project --platform editmode`,
+  uniforge test /path/to/project --platform editmode
+
+  # Test every project of the workspace (.uniforge/workspace.yaml); results
+  # go to test-results/<project>/results.xml
+  uniforge test --all --platform editmode --results test-results/results.xml`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runTest,
 }
@@ -57,6 +66,8 @@ func init() {
 	testCmd.Flags().IntVar(&testTimeout, "timeout", 600, "Test timeout in seconds")
 	testCmd.Flags().BoolVar(&testCIMode, "ci", false, "CI mode (optimized output format)")
 	testCmd.Flags().BoolVarP(&testTimestamp, "timestamp", "t", false, "Show timestamp for each line")
+	testCmd.Flags().StringSliceVar(&testNoise, "show-noise", nil, showNoiseUsage)
+	addAllFlag(testCmd, &testAll)
 
 	if err := testCmd.MarkFlagRequired("platform"); err != nil {
 		ui.Warn("Failed to mark platform flag as required: %v", err)
@@ -64,11 +75,38 @@ func init() {
 }
 
 func runTest(cmd *cobra.Command, args []string) error {
+	platform := unity.TestPlatform(testPlatform)
+	if platform != unity.TestPlatformEditMode && platform != unity.TestPlatformPlayMode {
+		return fmt.Errorf("invalid platform: %s (must be 'editmode' or 'playmode')", testPlatform)
+	}
+	showNoise, err := logger.ParseNoiseCategories(testNoise)
+	if err != nil {
+		return err
+	}
+
+	if testAll {
+		return runInWorkspace(args, func(projectPath, name string) error {
+			resultsFile, err := workspaceOutputPath(testResults, name)
+			if err != nil {
+				return err
+			}
+			logFile, err := workspaceOutputPath(testLogFile, name)
+			if err != nil {
+				return err
+			}
+			return testProject(projectPath, platform, resultsFile, logFile, showNoise)
+		})
+	}
+
 	projectPath := "."
 	if len(args) > 0 {
 		projectPath = args[0]
 	}
+	return testProject(projectPath, platform, testResults, testLogFile, showNoise)
+}
 
+// testProject runs the tests of one project
+func testProject(projectPath string, platform unity.TestPlatform, resultsFile, logFile string, showNoise []logger.NoiseCategory) error {
 	ui.Info("Running tests for project: %s", projectPath)
 
 	project, err := unity.LoadProject(projectPath)
@@ -76,24 +114,23 @@ func runTest(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load project: %w", err)
 	}
 
-	platform := unity.TestPlatform(testPlatform)
-	if platform != unity.TestPlatformEditMode && platform != unity.TestPlatformPlayMode {
-		return fmt.Errorf("invalid platform: %s (must be 'editmode' or 'playmode')", testPlatform)
-	}
-
 	testConfig := unity.TestConfig{
 		ProjectPath:    projectPath,
 		Platform:       platform,
 		Filter:         testFilter,
-		ResultsFile:    testResults,
-		LogFile:        testLogFile,
+		ResultsFile:    resultsFile,
+		LogFile:        logFile,
 		TimeoutSeconds: testTimeout,
 		CIMode:         testCIMode,
 		ShowTimestamp:  testTimestamp,
+		ShowNoise:      showNoise,
 	}
 
+	start := time.Now()
 	runner := unity.NewTestRunner(project)
-	if err := runner.RunTests(testConfig); err != nil {
+	err = runner.RunTests(testConfig)
+	notifyHook(hooks.EventTest, project.Name, start, err)
+	if err != nil {
 		return fmt.Errorf("tests failed: %w", err)
 	}
 
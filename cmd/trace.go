@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// startTrace enables phase timing when --trace-timing or --trace-otlp is set
+func startTrace() {
+	if viper.GetBool("trace-timing") || viper.GetString("trace-otlp") != "" {
+		trace.Enable()
+	}
+}
+
+// finishTrace prints the timing breakdown of cmd to stderr and exports it to
+// the OTLP collector, if any. It does nothing when tracing is off.
+func finishTrace(cmd *cobra.Command, err error) {
+	name := rootCmd.Name()
+	if cmd != nil {
+		name = cmd.CommandPath()
+	}
+	spans := trace.Finish(name, err)
+	if spans == nil {
+		return
+	}
+
+	timing := viper.GetBool("trace-timing")
+	if timing {
+		trace.PrintSummary(os.Stderr, spans)
+	}
+
+	endpoint := viper.GetString("trace-otlp")
+	if endpoint == "" && timing {
+		endpoint = trace.OTLPEndpoint()
+	}
+	if endpoint == "" {
+		return
+	}
+	if err := trace.ExportOTLP(endpoint, spans, rootCmd.Name(), Version); err != nil {
+		ui.Warn("%v", err)
+		return
+	}
+	ui.Debug("Exported trace", "endpoint", endpoint, "spans", len(spans))
+}
+
+// exit ends the process with code after finishing the trace and telemetry of
+// cmd, for commands that report their result through the exit code alone
+func exit(cmd *cobra.Command, code int) {
+	finishTrace(cmd, nil)
+	reportTelemetry(cmd, code, nil)
+	os.Exit(code)
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var upmCmd = &cobra.Command{
+	Use:   "upm",
+	Short: "Configure the Unity Package Manager",
+	Long: `Commands for the Unity Package Manager setup of a project and user: scoped
+registries in Packages/manifest.json and registry credentials in .upmconfig.toml.`,
+}
+
+func init() {
+	rootCmd.AddCommand(upmCmd)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/keychain"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	authTokenEnv        string
+	authTokenStdin      bool
+	authKeychainService string
+	authKeychainAccount string
+	authEmail           string
+	authNoVerify        bool
+)
+
+var upmAuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage registry credentials in .upmconfig.toml",
+	Long: `Manage the npm auth tokens Unity uses for private scoped registries.
+
+Examples:
+  uniforge upm auth set https://npm.example.com --token-env NPM_TOKEN`,
+}
+
+var upmAuthSetCmd = &cobra.Command{
+	Use:   "set <registry-url>",
+	Short: "Store an auth token for a registry",
+	Long: `Store an npm auth token for a scoped registry in the user's .upmconfig.toml,
+where the Package Manager looks for credentials: %USERPROFILE%\.upmconfig.toml
+on Windows and ~/.upmconfig.toml on macOS and Linux, or $UPM_USER_CONFIG_FILE
+if set. Credentials of other registries in the file are kept.
+
+The token is read from an environment variable (--token-env), stdin
+(--token-stdin) or the OS credential store (--keychain: macOS Keychain,
+Windows Credential Manager, or the Secret Service on Linux via secret-tool).
+Without any of these, it is prompted for without echo. Tokens are never taken
+on the command line, where they would end up in shell history.
+
+Before it is stored, the token is checked against the registry's /-/whoami
+endpoint (or an authenticated search for registries without one); --no-verify
+skips the check.
+
+Examples:
+  # Provision a CI agent from a secret
+  uniforge upm auth set https://npm.example.com --token-env NPM_TOKEN
+
+  # From a secret manager
+  vault kv get -field=token secret/npm | uniforge upm auth set https://npm.example.com --token-stdin
+
+  # From the OS credential store
+  uniforge upm auth set https://npm.example.com --keychain npm.example.com --keychain-account ci`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runUPMAuthSet,
+	SilenceUsage: true,
+}
+
+func init() {
+	upmCmd.AddCommand(upmAuthCmd)
+	upmAuthCmd.AddCommand(upmAuthSetCmd)
+
+	upmAuthSetCmd.Flags().StringVar(&authTokenEnv, "token-env", "", "Environment variable holding the token")
+	upmAuthSetCmd.Flags().BoolVar(&authTokenStdin, "token-stdin", false, "Read the token from stdin")
+	upmAuthSetCmd.Flags().StringVar(&authKeychainService, "keychain", "", "Read the token from the OS credential store entry of this service")
+	upmAuthSetCmd.Flags().StringVar(&authKeychainAccount, "keychain-account", "", "Account of the credential store entry (default: the registry URL)")
+	upmAuthSetCmd.Flags().StringVar(&authEmail, "email", "", "Email stored with the token")
+	upmAuthSetCmd.Flags().BoolVar(&authNoVerify, "no-verify", false, "Don't check the token against the registry")
+	upmAuthSetCmd.MarkFlagsMutuallyExclusive("token-env", "token-stdin", "keychain")
+}
+
+func runUPMAuthSet(cmd *cobra.Command, args []string) error {
+	registryURL, err := unity.NormalizeRegistryURL(args[0])
+	if err != nil {
+		return err
+	}
+	token, err := readRegistryToken(registryURL)
+	if err != nil {
+		return err
+	}
+
+	if !authNoVerify {
+		user, err := ui.WithSpinner(fmt.Sprintf("Checking the token with %s...", registryURL), func() (string, error) {
+			return unity.VerifyRegistryToken(registryURL, token)
+		})
+		if err != nil {
+			return err
+		}
+		if user != "" {
+			ui.Info("Authenticated as %s", user)
+		}
+	}
+
+	path, err := unity.UPMConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := unity.SetUPMAuth(path, registryURL, unity.UPMAuth{Token: token, Email: authEmail, AlwaysAuth: true}); err != nil {
+		return fmt.Errorf("failed to store the token: %w", err)
+	}
+	ui.Success("Stored the token for %s in %s", registryURL, path)
+	return nil
+}
+
+// readRegistryToken returns the token of the registry at registryURL from the
+// source chosen by the flags, prompting for it if none was
+func readRegistryToken(registryURL string) (string, error) {
+	var token string
+	switch {
+	case authTokenEnv != "":
+		token = os.Getenv(authTokenEnv)
+		if token == "" {
+			return "", errs.New(errs.InputRequired, "environment variable %s is not set", authTokenEnv)
+		}
+	case authTokenStdin:
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read the token from stdin: %w", err)
+		}
+		token = line
+	case authKeychainService != "":
+		account := authKeychainAccount
+		if account == "" {
+			account = registryURL
+		}
+		secret, err := keychain.Get(authKeychainService, account)
+		if errors.Is(err, keychain.ErrNotFound) {
+			return "", errs.New(errs.NotFound, "no credential stored for service %s and account %s", authKeychainService, account)
+		}
+		if err != nil {
+			return "", err
+		}
+		token = secret
+	default:
+		if !ui.CanPrompt() {
+			return "", errs.WithHint(errs.New(errs.InputRequired, "cannot prompt for the token"),
+				"Pass the token with --token-env, --token-stdin or --keychain.")
+		}
+		fmt.Print("Token: ")
+		secret, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read the token: %w", err)
+		}
+		token = string(secret)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errs.New(errs.InputRequired, "the token is empty")
+	}
+	return token, nil
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registryScopes   []string
+	registryTokenEnv string
+	registryEmail    string
+	registryNoVerify bool
+)
+
+var upmRegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage the scoped registries of a project",
+	Long: `List, add and remove the scopedRegistries entries of Packages/manifest.json.
+
+Examples:
+  uniforge upm registry list
+  uniforge upm registry add Company https://npm.example.com --scope com.company
+  uniforge upm registry remove Company`,
+}
+
+var upmRegistryListCmd = &cobra.Command{
+	Use:   "list [project]",
+	Short: "List the scoped registries of a project",
+	Long: `List the scoped registries in Packages/manifest.json, with whether the user's
+.upmconfig.toml has credentials for them.
+
+Examples:
+  uniforge upm registry list
+  uniforge upm registry list ./MyGame`,
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runUPMRegistryList,
+	SilenceUsage: true,
+}
+
+var upmRegistryAddCmd = &cobra.Command{
+	Use:   "add <name> <url> [project]",
+	Short: "Add a scoped registry to a project",
+	Long: `Add a scoped registry to Packages/manifest.json. An entry with the same name
+or URL is replaced; a scope can only belong to one registry.
+
+Before the manifest is changed, the registry must answer the npm search
+endpoint (/-/v1/search) the Package Manager uses; --no-verify skips the check.
+
+For a private registry, --token-env names an environment variable holding an
+npm auth token. The token is stored for the registry in the user's
+.upmconfig.toml ($UPM_USER_CONFIG_FILE, or .upmconfig.toml in the home folder),
+which Unity reads, and is used for the check. Without --token-env, a token
+stored earlier with 'upm auth set' is used for the check. Tokens are never
+taken on the command line, where they would end up in shell history.
+
+Examples:
+  # A public registry
+  uniforge upm registry add "package.openupm.com" https://package.openupm.com --scope com.cysharp --scope jp.hadashikick
+
+  # A private registry, with the token from CI secrets
+  uniforge upm registry add Company https://npm.example.com --scope com.company --token-env NPM_TOKEN`,
+	Args:         cobra.RangeArgs(2, 3),
+	RunE:         runUPMRegistryAdd,
+	SilenceUsage: true,
+}
+
+var upmRegistryRemoveCmd = &cobra.Command{
+	Use:   "remove <name|url> [project]",
+	Short: "Remove a scoped registry from a project",
+	Long: `Remove a scoped registry from Packages/manifest.json. Credentials in
+.upmconfig.toml are kept, as other projects may use them.
+
+Examples:
+  uniforge upm registry remove Company
+  uniforge upm registry remove https://npm.example.com ./MyGame`,
+	Args:         cobra.RangeArgs(1, 2),
+	RunE:         runUPMRegistryRemove,
+	SilenceUsage: true,
+}
+
+func init() {
+	upmCmd.AddCommand(upmRegistryCmd)
+	upmRegistryCmd.AddCommand(upmRegistryListCmd)
+	upmRegistryCmd.AddCommand(upmRegistryAddCmd)
+	upmRegistryCmd.AddCommand(upmRegistryRemoveCmd)
+
+	upmRegistryAddCmd.Flags().StringSliceVar(&registryScopes, "scope", nil, "Package name prefix served by the registry (repeatable)")
+	upmRegistryAddCmd.Flags().StringVar(&registryTokenEnv, "token-env", "", "Environment variable holding the registry's auth token")
+	upmRegistryAddCmd.Flags().StringVar(&registryEmail, "email", "", "Email stored with the token in .upmconfig.toml")
+	upmRegistryAddCmd.Flags().BoolVar(&registryNoVerify, "no-verify", false, "Don't check that the registry answers")
+	if err := upmRegistryAddCmd.MarkFlagRequired("scope"); err != nil {
+		ui.Warn("Failed to mark scope flag as required: %v", err)
+	}
+}
+
+func runUPMRegistryList(cmd *cobra.Command, args []string) error {
+	projectRoot, err := projectRootArg(args, 0)
+	if err != nil {
+		return err
+	}
+	registries, err := unity.ListScopedRegistries(projectRoot)
+	if err != nil {
+		return err
+	}
+	if len(registries) == 0 {
+		ui.Info("No scoped registries in %s", projectRoot)
+		return nil
+	}
+
+	auths := map[string]unity.UPMAuth{}
+	if path, err := unity.UPMConfigPath(); err == nil {
+		if auths, err = unity.LoadUPMAuth(path); err != nil {
+			ui.Warn("Failed to read %s: %v", path, err)
+		}
+	}
+
+	rows := make([][]string, 0, len(registries))
+	for _, r := range registries {
+		auth := "-"
+		if a, ok := auths[r.URL]; ok && a.Token != "" {
+			auth = "token"
+		}
+		rows = append(rows, []string{r.Name, r.URL, strings.Join(r.Scopes, ", "), auth})
+	}
+
+	t := table.New().
+		Headers("NAME", "URL", "SCOPES", "AUTH").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col == 1 {
+				return pathStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+	return nil
+}
+
+func runUPMRegistryAdd(cmd *cobra.Command, args []string) error {
+	registryURL, err := unity.NormalizeRegistryURL(args[1])
+	if err != nil {
+		return err
+	}
+	registry := unity.ScopedRegistry{Name: args[0], URL: registryURL, Scopes: registryScopes}
+	if err := registry.Validate(); err != nil {
+		return err
+	}
+	projectRoot, err := projectRootArg(args, 2)
+	if err != nil {
+		return err
+	}
+
+	token := ""
+	if registryTokenEnv != "" {
+		token = os.Getenv(registryTokenEnv)
+		if token == "" {
+			return errs.New(errs.InputRequired, "environment variable %s is not set", registryTokenEnv)
+		}
+	}
+
+	if !registryNoVerify {
+		// Check with a token stored earlier by 'upm auth set'
+		verifyToken := token
+		if verifyToken == "" {
+			if path, err := unity.UPMConfigPath(); err == nil {
+				if auths, err := unity.LoadUPMAuth(path); err == nil {
+					verifyToken = auths[registryURL].Token
+				}
+			}
+		}
+		err := ui.WithSpinnerNoResult(fmt.Sprintf("Checking %s...", registryURL), func() error {
+			return unity.VerifyRegistry(registryURL, registry.Scopes[0], verifyToken)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if token != "" {
+		path, err := unity.UPMConfigPath()
+		if err != nil {
+			return err
+		}
+		if err := unity.SetUPMAuth(path, registryURL, unity.UPMAuth{Token: token, Email: registryEmail, AlwaysAuth: true}); err != nil {
+			return fmt.Errorf("failed to store the token: %w", err)
+		}
+		ui.Success("Stored the token for %s in %s", registryURL, path)
+	}
+
+	replaced, err := unity.AddScopedRegistry(projectRoot, registry)
+	if err != nil {
+		return err
+	}
+	if replaced {
+		ui.Success("Updated registry %s in %s", registry.Name, projectRoot)
+	} else {
+		ui.Success("Added registry %s to %s", registry.Name, projectRoot)
+	}
+	return nil
+}
+
+func runUPMRegistryRemove(cmd *cobra.Command, args []string) error {
+	projectRoot, err := projectRootArg(args, 1)
+	if err != nil {
+		return err
+	}
+	registry, err := unity.RemoveScopedRegistry(projectRoot, args[0])
+	if err != nil {
+		return err
+	}
+	ui.Success("Removed registry %s (%s) from %s", registry.Name, registry.URL, projectRoot)
+	return nil
+}
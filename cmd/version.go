@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/selfupdate"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	versionCheckUpdate bool
+	versionApply       bool
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the uniforge version and check for updates",
+	Long: `Print the uniforge version. With --check-update, look up the latest release
+on GitHub and show how to upgrade; with --apply, download the release for this
+platform, verify its checksum and replace the running binary.
+
+Installs managed by Homebrew or Scoop are not replaced; upgrade them with the
+package manager. GITHUB_TOKEN is used, when set, to raise the GitHub API rate
+limit. Set no-update-check in the config file (or UNIFORGE_NO_UPDATE_CHECK=1) to
+never contact GitHub, e.g. in privacy-sensitive environments.
+
+Examples:
+  # Print the version
+  uniforge version
+
+  # Check for a newer release
+  uniforge version --check-update
+
+  # Update to the latest release
+  uniforge version --check-update --apply`,
+	Args:         cobra.NoArgs,
+	RunE:         runVersionCmd,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().BoolVar(&versionCheckUpdate, "check-update", false, "Check GitHub for a newer release")
+	versionCmd.Flags().BoolVar(&versionApply, "apply", false, "Replace this binary with the latest release (implies --check-update)")
+}
+
+func runVersionCmd(cmd *cobra.Command, args []string) error {
+	fmt.Println(Version)
+	if !versionCheckUpdate && !versionApply {
+		return nil
+	}
+	if viper.GetBool("no-update-check") {
+		return errs.WithHint(
+			errs.New(errs.Usage, "update checks are disabled by no-update-check"),
+			"remove no-update-check from ~/.uniforge.yaml and unset UNIFORGE_NO_UPDATE_CHECK to check for updates",
+		)
+	}
+
+	checker := selfupdate.NewChecker()
+	ctx := context.Background()
+	release, err := ui.WithSpinner("Checking for updates...", func() (*selfupdate.Release, error) {
+		return checker.Latest(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !selfupdate.IsNewer(release.Version, Version) {
+		if selfupdate.IsDevelopment(Version) {
+			ui.Muted("Development build; the latest release is %s", release.Version)
+		} else {
+			ui.Success("uniforge %s is up to date", Version)
+		}
+		return nil
+	}
+
+	ui.Info("uniforge %s is available (you have %s)", release.Version, Version)
+	if release.URL != "" {
+		ui.Muted("%s", release.URL)
+	}
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to locate the uniforge executable: %w", err)
+	}
+	packageManager := selfupdate.PackageManager(exe)
+
+	if !versionApply {
+		if packageManager != "" {
+			ui.Hint("Upgrade with: %s", packageManager)
+		} else {
+			ui.Hint("Upgrade with: uniforge version --apply")
+		}
+		return nil
+	}
+	if packageManager != "" {
+		return errs.WithHint(fmt.Errorf("%s is managed by a package manager", exe), "upgrade with: "+packageManager)
+	}
+
+	err = ui.WithSpinnerNoResult(fmt.Sprintf("Downloading uniforge %s...", release.Version), func() error {
+		return checker.Apply(ctx, release, exe)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update uniforge: %w", err)
+	}
+	ui.Success("Updated %s to uniforge %s", exe, release.Version)
+	return nil
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hooks"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	watchStreams  []string
+	watchFormat   string
+	watchWebhook  string
+	watchExitCode bool
+	watchDryRun   bool
+)
+
+var editorWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Report Unity releases published since the last check",
+	Long: `Check the streams you care about for new Unity releases, suitable for cron.
+
+Each run compares the available releases with the newest version seen on the
+previous run and reports what was published since. The first run of a stream
+only records the current version. Streams are a major.minor stream (2022.3), a
+major version (6000, every stream of Unity 6) or lts (every LTS stream), taken
+from --stream or the watch.streams config:
+
+  # ~/.uniforge.yaml
+  watch:
+    streams: [2022.3, "6000", lts]
+    webhook: https://hooks.slack.com/services/...
+
+New releases can be posted to a Slack or Discord incoming webhook. Releases
+with a security alert are marked. With --exit-code the command exits with 1
+when something new was found.
+
+Examples:
+  # Check the configured streams
+  uniforge editor watch
+
+  # Check 2022.3 and Unity 6 and post new releases to Slack
+  uniforge editor watch --stream 2022.3,6000 --webhook https://hooks.slack.com/services/...
+
+  # Cron job that only notifies when something changed
+  uniforge editor watch --exit-code || notify-send "New Unity release"
+
+  # Machine-readable output
+  uniforge editor watch --format json`,
+	Args:         cobra.NoArgs,
+	RunE:         runEditorWatch,
+	SilenceUsage: true,
+}
+
+func init() {
+	editorCmd.AddCommand(editorWatchCmd)
+
+	editorWatchCmd.Flags().StringSliceVar(&watchStreams, "stream", nil, "Streams to watch, e.g. 2022.3, 6000 or lts (default from watch.streams config)")
+	editorWatchCmd.Flags().StringVar(&watchFormat, "format", "text", "Output format: text, json")
+	editorWatchCmd.Flags().StringVar(&watchWebhook, "webhook", "", "Slack or Discord incoming webhook to post new releases to (default from watch.webhook config)")
+	editorWatchCmd.Flags().BoolVar(&watchExitCode, "exit-code", false, "Exit with 1 when new releases were found")
+	editorWatchCmd.Flags().BoolVar(&watchDryRun, "dry-run", false, "Report new releases without remembering them")
+}
+
+// watchJSON is the JSON output of editor watch
+type watchJSON struct {
+	Streams []string               `json:"streams"`
+	New     []availableJSONRelease `json:"new"`
+	Latest  map[string]string      `json:"latest"` // major.minor -> newest release
+}
+
+func runEditorWatch(cmd *cobra.Command, args []string) error {
+	if watchFormat != "text" && watchFormat != "json" {
+		return errs.New(errs.Usage, "unknown format: %s (use text or json)", watchFormat)
+	}
+
+	entries := watchStreams
+	if len(entries) == 0 {
+		entries = viper.GetStringSlice("watch.streams")
+	}
+	entries, err := hub.NormalizeWatchEntries(entries)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errs.WithHint(
+			errs.New(errs.Usage, "no streams to watch"),
+			"Pass --stream 2022.3,lts or set watch.streams in ~/.uniforge.yaml",
+		)
+	}
+
+	hubClient := hub.NewClient()
+	hubClient.NoCache = viper.GetBool("no-cache")
+
+	releases, err := fetchReleasesWithCache(hubClient)
+	if err != nil {
+		return fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	state, err := hubClient.LoadWatchState()
+	if err != nil {
+		return err
+	}
+	firstRun := make(map[string]bool)
+	for _, entry := range entries {
+		firstRun[entry] = !slices.Contains(state.Watched, entry)
+	}
+	found := state.Update(releases, entries)
+	latest := hub.WatchedLatest(releases, entries)
+
+	if !watchDryRun {
+		if err := hubClient.SaveWatchState(state); err != nil {
+			return err
+		}
+	}
+
+	if watchFormat == "json" {
+		out := watchJSON{Streams: entries, New: []availableJSONRelease{}, Latest: latest}
+		support := hubClient.SupportWindows()
+		for _, r := range found {
+			out.New = append(out.New, toAvailableJSON(r, support))
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		printWatchResult(entries, firstRun, found, latest)
+	}
+
+	if len(found) > 0 {
+		webhook := watchWebhook
+		if webhook == "" {
+			webhook = viper.GetString("watch.webhook")
+		}
+		if webhook != "" && !watchDryRun {
+			if err := hooks.PostMessage(webhook, watchMessage(found)); err != nil {
+				ui.Warn("Failed to post to webhook: %v", err)
+			}
+		}
+		if watchExitCode {
+			exit(cmd, 1)
+		}
+	}
+	return nil
+}
+
+// printWatchResult prints new releases, or the current state when there are none
+func printWatchResult(entries []string, firstRun map[string]bool, found []hub.UnityRelease, latest map[string]string) {
+	for _, entry := range entries {
+		if firstRun[entry] {
+			ui.Info("Now watching %s", entry)
+		}
+	}
+
+	if len(found) == 0 {
+		streams := make([]string, 0, len(latest))
+		for stream := range latest {
+			streams = append(streams, stream)
+		}
+		sort.Strings(streams)
+		var parts []string
+		for _, stream := range streams {
+			parts = append(parts, latest[stream])
+		}
+		if len(parts) == 0 {
+			ui.Info("No releases found for %s", strings.Join(entries, ", "))
+			return
+		}
+		ui.Info("No new releases (latest: %s)", strings.Join(parts, ", "))
+		return
+	}
+
+	ui.Success("%d new Unity release(s)", len(found))
+	for _, r := range found {
+		line := "  " + availVersionStyle.Render(r.Version)
+		if r.LTS {
+			line += " LTS"
+		}
+		if date := formatReleaseDate(r.ReleaseDate); date != "" {
+			line += "  " + availArchStyle.Render(date)
+		}
+		if r.ReleaseNotesURL != "" {
+			line += "  " + r.ReleaseNotesURL
+		}
+		fmt.Println(line)
+		if r.SecurityAlert != "" {
+			ui.Warn("  Security alert: %s", r.SecurityAlert)
+		}
+	}
+}
+
+// watchMessage is the webhook text announcing new releases
+func watchMessage(found []hub.UnityRelease) string {
+	lines := []string{fmt.Sprintf("🆕 %d new Unity release(s)", len(found))}
+	for _, r := range found {
+		line := "• " + r.Version
+		if r.LTS {
+			line += " LTS"
+		}
+		if r.ReleaseNotesURL != "" {
+			line += " " + r.ReleaseNotesURL
+		}
+		lines = append(lines, line)
+		if r.SecurityAlert != "" {
+			lines = append(lines, "  ⚠️ Security alert: "+r.SecurityAlert)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/watchdog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchdogIdleTimeout      int
+	watchdogSignatureTimeout int
+	watchdogTail             int
+	watchdogLogFile          string
+)
+
+var watchdogCmd = &cobra.Command{
+	Use:   "watchdog [flags] -- <command> [args...]",
+	Short: "Kill a batch job that stops making progress",
+	Long: `Run a command, typically Unity in batch mode, and kill it (with the processes
+it started) when it hangs, instead of letting a CI job run into its time limit
+hours later. The command counts as hung when:
+
+  - it writes no output for --idle-timeout seconds, or
+  - its last output matches a known hang signature (license prompt, stalled
+    asset import worker or shader compiler) and it then stays quiet for
+    --signature-timeout seconds.
+
+Output is passed through unchanged. When Unity writes its log to a file
+(-logFile <path>), pass the same path with --log-file so the watchdog sees it.
+After a kill the last lines of output are printed.
+
+The exit code is the command's own, or 1 when the watchdog killed it.
+
+Examples:
+  # Kill Unity after 10 minutes without log output
+  uniforge watchdog --idle-timeout 600 -- Unity -batchmode -quit -projectPath . -logFile - -executeMethod Build.Run
+
+  # Unity logging to a file
+  uniforge watchdog --log-file build.log -- Unity -batchmode -quit -logFile build.log -executeMethod Build.Run
+
+  # Guard a uniforge command
+  uniforge watchdog -- uniforge test --platform playmode`,
+	Args:         cobra.MinimumNArgs(1),
+	RunE:         runWatchdog,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(watchdogCmd)
+
+	watchdogCmd.Flags().IntVar(&watchdogIdleTimeout, "idle-timeout", int(watchdog.DefaultIdleTimeout.Seconds()), "Kill after this many seconds without output")
+	watchdogCmd.Flags().IntVar(&watchdogSignatureTimeout, "signature-timeout", int(watchdog.DefaultSignatureTimeout.Seconds()), "Kill after this many seconds without output following a hang signature")
+	watchdogCmd.Flags().IntVar(&watchdogTail, "tail", watchdog.DefaultTailLines, "Lines of output to print after a kill")
+	watchdogCmd.Flags().StringVar(&watchdogLogFile, "log-file", "", "Also watch this log file for output")
+	// Flags after the command belong to it, even without --
+	watchdogCmd.Flags().SetInterspersed(false)
+}
+
+func runWatchdog(cmd *cobra.Command, args []string) error {
+	child := exec.Command(args[0], args[1:]...)
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	err := watchdog.Run(child, watchdog.Config{
+		IdleTimeout:      time.Duration(watchdogIdleTimeout) * time.Second,
+		SignatureTimeout: time.Duration(watchdogSignatureTimeout) * time.Second,
+		TailLines:        watchdogTail,
+		LogFile:          watchdogLogFile,
+	})
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exit(cmd, exitErr.ExitCode())
+	}
+	return stallError(err)
+}
+
+// stallError prints the output leading up to a watchdog kill and attaches the
+// signature's hint; other errors are returned unchanged
+func stallError(err error) error {
+	var stall *watchdog.StallError
+	if !errors.As(err, &stall) {
+		return err
+	}
+	if len(stall.Tail) > 0 {
+		ui.Error("Last %d line(s) of output:", len(stall.Tail))
+		for _, line := range stall.Tail {
+			fmt.Fprintln(os.Stderr, "  "+line)
+		}
+	}
+	if stall.Signature != nil {
+		return errs.WithHint(err, stall.Signature.Hint)
+	}
+	return err
+}
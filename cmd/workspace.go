@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"github.com/spf13/cobra"
+)
+
+// addAllFlag registers --all, which runs a project command on every project of
+// the workspace (see unity.Workspace) instead of a single one
+func addAllFlag(cmd *cobra.Command, all *bool) {
+	cmd.Flags().BoolVar(all, "all", false, "Run on every project listed in .uniforge/workspace.yaml")
+}
+
+// findWorkspace returns the workspace containing the current directory, for --all
+func findWorkspace(args []string) (*unity.Workspace, error) {
+	if len(args) > 0 {
+		return nil, errs.New(errs.Usage, "--all runs on every workspace project; do not pass a project path")
+	}
+	return unity.FindWorkspace(".")
+}
+
+// workspaceResult is the outcome of a command on one workspace project
+type workspaceResult struct {
+	Project  string
+	Err      error
+	Duration time.Duration
+}
+
+// runInWorkspace runs fn on every project of the workspace, one after another,
+// then prints a report of all of them. It fails when any project failed. fn
+// gets the absolute project path and its path relative to the workspace.
+func runInWorkspace(args []string, fn func(projectPath, name string) error) error {
+	ws, err := findWorkspace(args)
+	if err != nil {
+		return err
+	}
+	ui.Info("Workspace %s: %d projects", ws.Root, len(ws.Projects))
+
+	results := make([]workspaceResult, 0, len(ws.Projects))
+	for _, projectPath := range ws.Projects {
+		fmt.Println()
+		name := ws.Rel(projectPath)
+		ui.Info("━━ %s", name)
+		start := time.Now()
+		err := fn(projectPath, name)
+		if err != nil {
+			ui.Error("%v", err)
+		}
+		results = append(results, workspaceResult{Project: name, Err: err, Duration: time.Since(start)})
+	}
+
+	fmt.Println()
+	printWorkspaceSummary(results)
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d projects failed", failed, len(results))
+	}
+	return nil
+}
+
+// workspaceOutputPath moves a file given on the command line into a folder per
+// project, e.g. results/tests.xml becomes results/<project>/tests.xml, so that
+// workspace projects do not overwrite each other's output
+func workspaceOutputPath(path, name string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(filepath.Join(filepath.Dir(path), filepath.FromSlash(name), filepath.Base(path)))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return abs, nil
+}
+
+// printWorkspaceSummary prints the pass/fail table of a workspace run
+func printWorkspaceSummary(results []workspaceResult) {
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status, detail := "PASS", ""
+		if r.Err != nil {
+			status, detail = "FAIL", r.Err.Error()
+		}
+		rows = append(rows, []string{r.Project, status, r.Duration.Round(time.Second).String(), detail})
+	}
+
+	t := table.New().
+		Headers("PROJECT", "STATUS", "DURATION", "DETAIL").
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			switch col {
+			case 0:
+				return pathStyle
+			case 1:
+				if rows[row][col] == "PASS" {
+					return buildPassStyle
+				}
+				return buildFailStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	fmt.Println(t)
+}
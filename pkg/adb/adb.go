@@ -0,0 +1,346 @@
+// Package adb provides a thin wrapper around the Android Debug Bridge
+package adb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Client runs adb commands against a single device
+type Client struct {
+	adbPath string
+	Serial  string // Target device serial (empty = the only connected device)
+}
+
+// Device represents a device reported by `adb devices -l`
+type Device struct {
+	Serial  string
+	State   string // "device", "offline", "unauthorized", ...
+	Model   string
+	Product string
+}
+
+// IsReady returns true if the device can accept commands
+func (d Device) IsReady() bool {
+	return d.State == "device"
+}
+
+// NewClient creates a new adb Client
+func NewClient() *Client {
+	return &Client{
+		adbPath: findADB(),
+	}
+}
+
+// Path returns the resolved adb executable path
+func (c *Client) Path() string {
+	return c.adbPath
+}
+
+// Devices returns all devices known to adb
+func (c *Client) Devices() ([]Device, error) {
+	if c.adbPath == "" {
+		return nil, fmt.Errorf("adb not found")
+	}
+
+	output, err := exec.Command(c.adbPath, "devices", "-l").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	return parseDevices(string(output)), nil
+}
+
+// ResolveDevice returns the target device, failing when it is ambiguous
+func (c *Client) ResolveDevice() (*Device, error) {
+	devices, err := c.Devices()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Serial != "" {
+		for _, d := range devices {
+			if d.Serial == c.Serial {
+				if !d.IsReady() {
+					return nil, fmt.Errorf("device %s is %s", d.Serial, d.State)
+				}
+				return &d, nil
+			}
+		}
+		return nil, fmt.Errorf("device not found: %s", c.Serial)
+	}
+
+	var ready []Device
+	for _, d := range devices {
+		if d.IsReady() {
+			ready = append(ready, d)
+		}
+	}
+
+	switch len(ready) {
+	case 0:
+		return nil, fmt.Errorf("no Android device connected")
+	case 1:
+		c.Serial = ready[0].Serial
+		return &ready[0], nil
+	default:
+		return nil, errs.New(errs.MultipleMatches, "multiple devices connected (%d), specify one with --device", len(ready))
+	}
+}
+
+// Install installs an APK or AAB onto the target device
+func (c *Client) Install(packagePath string) error {
+	switch strings.ToLower(filepath.Ext(packagePath)) {
+	case ".apk":
+		return c.run("install", "-r", packagePath)
+	case ".aab":
+		return c.installBundle(packagePath)
+	default:
+		return fmt.Errorf("unsupported package type: %s (expected .apk or .aab)", packagePath)
+	}
+}
+
+// installBundle installs an AAB through bundletool, which adb cannot do by itself
+func (c *Client) installBundle(aabPath string) error {
+	bundletool := findBundletool()
+	if len(bundletool) == 0 {
+		return fmt.Errorf("bundletool not found (set UNIFORGE_BUNDLETOOL_PATH or install bundletool)")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "uniforge-aab-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	apksPath := filepath.Join(tmpDir, "app.apks")
+	buildArgs := append(append([]string{}, bundletool[1:]...), "build-apks", "--bundle="+aabPath, "--output="+apksPath, "--connected-device", "--adb="+c.adbPath)
+	if c.Serial != "" {
+		buildArgs = append(buildArgs, "--device-id="+c.Serial)
+	}
+	if err := runCommand(bundletool[0], buildArgs...); err != nil {
+		return fmt.Errorf("failed to build APKs from bundle: %w", err)
+	}
+
+	installArgs := append(append([]string{}, bundletool[1:]...), "install-apks", "--apks="+apksPath, "--adb="+c.adbPath)
+	if c.Serial != "" {
+		installArgs = append(installArgs, "--device-id="+c.Serial)
+	}
+	if err := runCommand(bundletool[0], installArgs...); err != nil {
+		return fmt.Errorf("failed to install APKs: %w", err)
+	}
+
+	return nil
+}
+
+// Launch starts the launcher activity of the given application
+func (c *Client) Launch(packageName string) error {
+	if packageName == "" {
+		return fmt.Errorf("package name is required")
+	}
+	return c.run("shell", "monkey", "-p", packageName, "-c", "android.intent.category.LAUNCHER", "1")
+}
+
+// ClearLogcat clears the device log buffer
+func (c *Client) ClearLogcat() error {
+	return c.run("logcat", "-c")
+}
+
+// Logcat streams Unity-related logcat entries to handle until ctx is cancelled
+func (c *Client) Logcat(ctx context.Context, handle func(Entry)) error {
+	if c.adbPath == "" {
+		return fmt.Errorf("adb not found")
+	}
+
+	args := append(c.serialArgs(), "logcat", "-v", "tag")
+	args = append(args, LogcatTags...)
+	args = append(args, "*:S")
+
+	ui.Debug("Starting logcat", "command", c.adbPath, "args", strings.Join(args, " "))
+
+	cmd := exec.CommandContext(ctx, c.adbPath, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to attach to logcat: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start logcat: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	const maxCapacity = 1024 * 1024
+	scanner.Buffer(make([]byte, maxCapacity), maxCapacity)
+
+	for scanner.Scan() {
+		entry, ok := ParseLogcatLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		handle(entry)
+	}
+
+	if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("logcat exited: %w", err)
+	}
+
+	return nil
+}
+
+// LogcatTags are the logcat tag filters used for Unity applications
+var LogcatTags = []string{
+	"Unity:V",
+	"CRASH:E",
+	"AndroidRuntime:E",
+	"DEBUG:V",
+	"libc:F",
+}
+
+// Entry is a single parsed logcat line
+type Entry struct {
+	Priority string // V, D, I, W, E, F
+	Tag      string
+	Message  string
+}
+
+// ParseLogcatLine parses a line in `logcat -v tag` format ("I/Unity: message")
+func ParseLogcatLine(line string) (Entry, bool) {
+	if len(line) < 3 || line[1] != '/' {
+		return Entry{}, false
+	}
+
+	rest := line[2:]
+	idx := strings.Index(rest, ":")
+	if idx < 0 {
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Priority: line[:1],
+		Tag:      strings.TrimSpace(rest[:idx]),
+		Message:  strings.TrimPrefix(rest[idx+1:], " "),
+	}
+	return entry, true
+}
+
+func (c *Client) serialArgs() []string {
+	if c.Serial == "" {
+		return nil
+	}
+	return []string{"-s", c.Serial}
+}
+
+func (c *Client) run(args ...string) error {
+	if c.adbPath == "" {
+		return fmt.Errorf("adb not found")
+	}
+	return runCommand(c.adbPath, append(c.serialArgs(), args...)...)
+}
+
+func runCommand(name string, args ...string) error {
+	ui.Debug("Running command", "command", name, "args", strings.Join(args, " "))
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// parseDevices parses `adb devices -l` output
+func parseDevices(output string) []Device {
+	var devices []Device
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "List of devices") || strings.HasPrefix(line, "*") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		device := Device{
+			Serial: fields[0],
+			State:  fields[1],
+		}
+		for _, field := range fields[2:] {
+			key, value, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "model":
+				device.Model = value
+			case "product":
+				device.Product = value
+			}
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices
+}
+
+// findADB locates the adb executable
+func findADB() string {
+	// 1. Check environment variable first
+	if envPath := os.Getenv("UNIFORGE_ADB_PATH"); envPath != "" && fileExists(envPath) {
+		return envPath
+	}
+
+	exe := "adb"
+	if runtime.GOOS == "windows" {
+		exe = "adb.exe"
+	}
+
+	// 2. Android SDK environment variables
+	for _, env := range []string{"ANDROID_HOME", "ANDROID_SDK_ROOT"} {
+		if sdk := os.Getenv(env); sdk != "" {
+			path := filepath.Join(sdk, "platform-tools", exe)
+			if fileExists(path) {
+				ui.Debug("Found adb via "+env, "path", path)
+				return path
+			}
+		}
+	}
+
+	// 3. PATH lookup
+	if path, err := exec.LookPath("adb"); err == nil {
+		return path
+	}
+
+	return ""
+}
+
+// findBundletool returns the command used to invoke bundletool (binary or java -jar)
+func findBundletool() []string {
+	if envPath := os.Getenv("UNIFORGE_BUNDLETOOL_PATH"); envPath != "" && fileExists(envPath) {
+		if strings.HasSuffix(envPath, ".jar") {
+			return []string{"java", "-jar", envPath}
+		}
+		return []string{envPath}
+	}
+
+	if path, err := exec.LookPath("bundletool"); err == nil {
+		return []string{path}
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
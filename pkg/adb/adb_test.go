@@ -0,0 +1,56 @@
+package adb
+
+import "testing"
+
+func TestParseDevices(t *testing.T) {
+	output := `List of devices attached
+* daemon started successfully
+emulator-5554          device product:sdk_gphone64_arm64 model:sdk_gphone64_arm64 device:emu64a transport_id:1
+R58M123ABC             unauthorized usb:1-1 transport_id:2
+
+`
+	devices := parseDevices(output)
+	if len(devices) != 2 {
+		t.Fatalf("Expected 2 devices, got %d", len(devices))
+	}
+
+	if devices[0].Serial != "emulator-5554" || !devices[0].IsReady() {
+		t.Errorf("Unexpected first device: %+v", devices[0])
+	}
+	if devices[0].Model != "sdk_gphone64_arm64" {
+		t.Errorf("Expected model sdk_gphone64_arm64, got %s", devices[0].Model)
+	}
+	if devices[1].State != "unauthorized" || devices[1].IsReady() {
+		t.Errorf("Unexpected second device: %+v", devices[1])
+	}
+}
+
+func TestParseLogcatLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantOK   bool
+		priority string
+		tag      string
+		message  string
+	}{
+		{"I/Unity   : Hello world", true, "I", "Unity", "Hello world"},
+		{"E/AndroidRuntime: FATAL EXCEPTION: main", true, "E", "AndroidRuntime", "FATAL EXCEPTION: main"},
+		{"--------- beginning of main", false, "", "", ""},
+		{"", false, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			entry, ok := ParseLogcatLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseLogcatLine(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if entry.Priority != tt.priority || entry.Tag != tt.tag || entry.Message != tt.message {
+				t.Errorf("ParseLogcatLine(%q) = %+v", tt.line, entry)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package ci formats settings for consumption by CI pipelines
+package ci
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvVar is a single environment variable to export
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// Output formats for FormatEnv
+const (
+	FormatShell      = "shell"
+	FormatDotenv     = "dotenv"
+	FormatPowerShell = "powershell"
+)
+
+// FormatEnv renders vars in the given format, one assignment per line
+func FormatEnv(vars []EnvVar, format string) (string, error) {
+	var b strings.Builder
+	for _, v := range vars {
+		switch format {
+		case FormatShell:
+			fmt.Fprintf(&b, "export %s=%s\n", v.Name, shellQuote(v.Value))
+		case FormatDotenv:
+			if strings.ContainsAny(v.Value, "\r\n") {
+				return "", fmt.Errorf("%s contains a newline, which dotenv format cannot represent", v.Name)
+			}
+			fmt.Fprintf(&b, "%s=%s\n", v.Name, v.Value)
+		case FormatPowerShell:
+			fmt.Fprintf(&b, "$env:%s = '%s'\n", v.Name, strings.ReplaceAll(v.Value, "'", "''"))
+		default:
+			return "", fmt.Errorf("unknown format: %s (use %s, %s or %s)", format, FormatShell, FormatDotenv, FormatPowerShell)
+		}
+	}
+	return b.String(), nil
+}
+
+// shellQuote single-quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// AppendGitHubEnv appends vars to the GitHub Actions environment file at path
+// (normally $GITHUB_ENV), so they are set for all later steps of the job.
+// Multi-line values use the heredoc syntax with a random delimiter.
+func AppendGitHubEnv(path string, vars []EnvVar) error {
+	var b strings.Builder
+	for _, v := range vars {
+		if !strings.ContainsAny(v.Value, "\r\n") {
+			fmt.Fprintf(&b, "%s=%s\n", v.Name, v.Value)
+			continue
+		}
+		delimiter, err := randomDelimiter()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", v.Name, delimiter, v.Value, delimiter)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// randomDelimiter returns a heredoc delimiter that cannot collide with a value
+func randomDelimiter() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate delimiter: %w", err)
+	}
+	return "ghadelimiter_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,69 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatEnv(t *testing.T) {
+	vars := []EnvVar{
+		{Name: "UNITY_PATH", Value: "/opt/Unity Hub/Editor/6000.0.30f1/Editor/Unity"},
+		{Name: "QUOTED", Value: "it's"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{FormatShell, "export UNITY_PATH='/opt/Unity Hub/Editor/6000.0.30f1/Editor/Unity'\nexport QUOTED='it'\\''s'\n"},
+		{FormatDotenv, "UNITY_PATH=/opt/Unity Hub/Editor/6000.0.30f1/Editor/Unity\nQUOTED=it's\n"},
+		{FormatPowerShell, "$env:UNITY_PATH = '/opt/Unity Hub/Editor/6000.0.30f1/Editor/Unity'\n$env:QUOTED = 'it''s'\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := FormatEnv(vars, tt.format)
+			if err != nil {
+				t.Fatalf("FormatEnv() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := FormatEnv(vars, "yaml"); err == nil {
+		t.Error("FormatEnv() with unknown format should return an error")
+	}
+	if _, err := FormatEnv([]EnvVar{{Name: "A", Value: "x\ny"}}, FormatDotenv); err == nil {
+		t.Error("FormatEnv() dotenv with a newline should return an error")
+	}
+}
+
+func TestAppendGitHubEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "github_env")
+	if err := os.WriteFile(path, []byte("EXISTING=1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	vars := []EnvVar{
+		{Name: "UNITY_VERSION", Value: "2022.3.62f1"},
+		{Name: "MULTI", Value: "line1\nline2"},
+	}
+	if err := AppendGitHubEnv(path, vars); err != nil {
+		t.Fatalf("AppendGitHubEnv() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read env file: %v", err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, "EXISTING=1\nUNITY_VERSION=2022.3.62f1\nMULTI<<ghadelimiter_") {
+		t.Errorf("unexpected env file content:\n%s", content)
+	}
+	if !strings.Contains(content, "\nline1\nline2\nghadelimiter_") {
+		t.Errorf("multi-line value not written as heredoc:\n%s", content)
+	}
+}
@@ -0,0 +1,281 @@
+package ci
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// CI providers supported by Generate
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// Runner operating systems, using the default labels of GitHub self-hosted runners
+const (
+	RunnerLinux   = "Linux"
+	RunnerMacOS   = "macOS"
+	RunnerWindows = "Windows"
+)
+
+// Target is a Unity build target and what a runner needs to build it
+type Target struct {
+	BuildTarget string // Value for -buildTarget, e.g. "Android"
+	Module      string // Editor module to install, empty if built in
+	RunnerOS    string // Required runner OS, empty if any OS works
+}
+
+// moduleTargets maps editor module IDs to the build target they enable.
+// Mono standalone modules are built into the editor on their native OS,
+// which is where those targets are built, so they need no module.
+var moduleTargets = map[string]Target{
+	"android":        {BuildTarget: "Android", Module: "android"},
+	"ios":            {BuildTarget: "iOS", Module: "ios", RunnerOS: RunnerMacOS},
+	"webgl":          {BuildTarget: "WebGL", Module: "webgl"},
+	"windows-il2cpp": {BuildTarget: "StandaloneWindows64", Module: "windows-il2cpp", RunnerOS: RunnerWindows},
+	"windows-mono":   {BuildTarget: "StandaloneWindows64", RunnerOS: RunnerWindows},
+	"mac-il2cpp":     {BuildTarget: "StandaloneOSX", Module: "mac-il2cpp", RunnerOS: RunnerMacOS},
+	"mac-mono":       {BuildTarget: "StandaloneOSX", RunnerOS: RunnerMacOS},
+	"linux-il2cpp":   {BuildTarget: "StandaloneLinux64", Module: "linux-il2cpp", RunnerOS: RunnerLinux},
+	"linux-mono":     {BuildTarget: "StandaloneLinux64", RunnerOS: RunnerLinux},
+}
+
+// TargetsFromModules returns the build targets enabled by installed editor modules,
+// sorted by build target. IL2CPP modules win over Mono for the same target.
+func TargetsFromModules(modules []string) []Target {
+	byTarget := make(map[string]Target)
+	for _, m := range modules {
+		t, ok := moduleTargets[strings.ToLower(m)]
+		if !ok {
+			continue
+		}
+		if existing, ok := byTarget[t.BuildTarget]; ok && existing.Module != "" {
+			continue
+		}
+		byTarget[t.BuildTarget] = t
+	}
+
+	targets := make([]Target, 0, len(byTarget))
+	for _, t := range byTarget {
+		targets = append(targets, t)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].BuildTarget < targets[j].BuildTarget })
+	return targets
+}
+
+// LookupTarget finds a target by build target name or module ID (case-insensitive)
+func LookupTarget(name string) (Target, error) {
+	if t, ok := moduleTargets[strings.ToLower(name)]; ok {
+		return t, nil
+	}
+
+	var found *Target
+	for _, t := range moduleTargets {
+		if !strings.EqualFold(t.BuildTarget, name) {
+			continue
+		}
+		if found == nil || t.Module != "" {
+			found = &t
+		}
+	}
+	if found == nil {
+		return Target{}, fmt.Errorf("unknown build target: %s", name)
+	}
+	return *found, nil
+}
+
+// PipelineSpec describes the pipeline to generate
+type PipelineSpec struct {
+	ProjectPath   string // Unity project relative to the repository root, "." for the root
+	UnityVersion  string
+	Targets       []Target
+	BuildMethod   string // Static method passed to -executeMethod
+	DefaultRunner string // Runner OS for tests and targets that run anywhere
+}
+
+// Generate renders a CI pipeline definition for the given provider
+func Generate(provider string, spec PipelineSpec) (string, error) {
+	if spec.ProjectPath == "" {
+		spec.ProjectPath = "."
+	}
+	if spec.DefaultRunner == "" {
+		spec.DefaultRunner = RunnerLinux
+	}
+	for i := range spec.Targets {
+		if spec.Targets[i].RunnerOS == "" {
+			spec.Targets[i].RunnerOS = spec.DefaultRunner
+		}
+	}
+
+	var tmpl *template.Template
+	switch provider {
+	case ProviderGitHub:
+		tmpl = githubTemplate
+	case ProviderGitLab:
+		tmpl = gitlabTemplate
+	default:
+		return "", fmt.Errorf("unknown provider: %s (use %s or %s)", provider, ProviderGitHub, ProviderGitLab)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, spec); err != nil {
+		return "", fmt.Errorf("failed to render %s pipeline: %w", provider, err)
+	}
+	return b.String(), nil
+}
+
+// templateFuncs are shared by the pipeline templates
+var templateFuncs = template.FuncMap{
+	// projectFile joins a path inside the project, e.g. "Library" -> "Game/Library"
+	"projectFile": func(spec PipelineSpec, name string) string {
+		return path.Join(spec.ProjectPath, name)
+	},
+	"lower": strings.ToLower,
+}
+
+// Templates use [[ ]] delimiters so that GitHub's ${{ }} expressions need no escaping
+var githubTemplate = template.Must(template.New("github").Delims("[[", "]]").Funcs(templateFuncs).Parse(`# Generated by uniforge ci generate for Unity [[.UnityVersion]]
+name: Unity
+
+on:
+  push:
+    branches: [main]
+  pull_request:
+
+env:
+  UNITY_USERNAME: ${{ secrets.UNITY_USERNAME }}
+  UNITY_PASSWORD: ${{ secrets.UNITY_PASSWORD }}
+  UNITY_SERIAL: ${{ secrets.UNITY_SERIAL }}
+
+jobs:
+  test:
+    runs-on: [self-hosted, [[.DefaultRunner]]]
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          lfs: true
+
+      - uses: neptaco/setup-uniforge@v1
+
+      - uses: actions/cache@v4
+        with:
+          path: [[projectFile . "Library"]]
+          key: Library-test-${{ hashFiles('[[projectFile . "Packages/packages-lock.json"]]', '[[projectFile . "ProjectSettings/ProjectVersion.txt"]]') }}
+          restore-keys: Library-test-
+
+      - name: Install Unity
+        run: uniforge editor install -p [[.ProjectPath]]
+
+      - name: Activate license
+        run: uniforge license activate --version [[.UnityVersion]]
+
+      - name: Check .meta files
+        run: uniforge meta check [[.ProjectPath]]
+
+      - name: Run tests
+        run: uniforge test [[.ProjectPath]] --platform editmode --ci --results test-results/editmode.xml
+
+      - uses: actions/upload-artifact@v4
+        if: always()
+        with:
+          name: test-results
+          path: test-results
+
+      - name: Return license
+        if: always()
+        run: uniforge license return --version [[.UnityVersion]]
+[[- if .Targets]]
+
+  build:
+    needs: test
+    strategy:
+      fail-fast: false
+      matrix:
+        include:
+[[- range .Targets]]
+          - target: [[.BuildTarget]]
+            runner: [[.RunnerOS]]
+            modules: "[[.Module]]"
+[[- end]]
+
+    runs-on:
+      - self-hosted
+      - ${{ matrix.runner }}
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          lfs: true
+
+      - uses: neptaco/setup-uniforge@v1
+
+      - uses: actions/cache@v4
+        with:
+          path: [[projectFile . "Library"]]
+          key: Library-${{ matrix.target }}-${{ hashFiles('[[projectFile . "Packages/packages-lock.json"]]', '[[projectFile . "ProjectSettings/ProjectVersion.txt"]]') }}
+          restore-keys: Library-${{ matrix.target }}-
+
+      - name: Install Unity
+        if: matrix.modules == ''
+        run: uniforge editor install -p [[.ProjectPath]]
+
+      - name: Install Unity with modules
+        if: matrix.modules != ''
+        run: uniforge editor install -p [[.ProjectPath]] --modules ${{ matrix.modules }}
+
+      - name: Activate license
+        run: uniforge license activate --version [[.UnityVersion]]
+
+      - name: Build
+        run: uniforge run [[.ProjectPath]] --ci -- -executeMethod [[.BuildMethod]] -buildTarget ${{ matrix.target }}
+
+      - name: Return license
+        if: always()
+        run: uniforge license return --version [[.UnityVersion]]
+[[- end]]
+`))
+
+var gitlabTemplate = template.Must(template.New("gitlab").Delims("[[", "]]").Funcs(templateFuncs).Parse(`# Generated by uniforge ci generate for Unity [[.UnityVersion]]
+# Set UNITY_USERNAME, UNITY_PASSWORD and UNITY_SERIAL as masked CI/CD variables.
+stages:
+  - test
+  - build
+
+test:
+  stage: test
+  tags: [unity, [[lower .DefaultRunner]]]
+  cache:
+    key: Library-test
+    paths:
+      - [[projectFile . "Library"]]/
+  script:
+    - uniforge editor install -p [[.ProjectPath]]
+    - uniforge license activate --version [[.UnityVersion]]
+    - uniforge meta check [[.ProjectPath]]
+    - uniforge test [[.ProjectPath]] --platform editmode --ci --results test-results/editmode.xml
+  after_script:
+    - uniforge license return --version [[.UnityVersion]]
+  artifacts:
+    when: always
+    paths:
+      - test-results/
+[[- $spec := .]]
+[[- range .Targets]]
+
+build:[[lower .BuildTarget]]:
+  stage: build
+  tags: [unity, [[lower .RunnerOS]]]
+  cache:
+    key: Library-[[.BuildTarget]]
+    paths:
+      - [[projectFile $spec "Library"]]/
+  script:
+    - uniforge editor install -p [[$spec.ProjectPath]][[if .Module]] --modules [[.Module]][[end]]
+    - uniforge license activate --version [[$spec.UnityVersion]]
+    - uniforge run [[$spec.ProjectPath]] --ci -- -executeMethod [[$spec.BuildMethod]] -buildTarget [[.BuildTarget]]
+  after_script:
+    - uniforge license return --version [[$spec.UnityVersion]]
+[[- end]]
+`))
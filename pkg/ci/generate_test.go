@@ -0,0 +1,112 @@
+package ci
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTargetsFromModules(t *testing.T) {
+	targets := TargetsFromModules([]string{"android", "android-open-jdk", "windows-mono", "windows-il2cpp", "mac-mono", "documentation"})
+
+	var got []string
+	for _, target := range targets {
+		got = append(got, target.BuildTarget+":"+target.Module)
+	}
+	want := "Android:android,StandaloneOSX:,StandaloneWindows64:windows-il2cpp"
+	if strings.Join(got, ",") != want {
+		t.Errorf("TargetsFromModules() = %v, want %s", got, want)
+	}
+}
+
+func TestLookupTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantTarget string
+		wantModule string
+	}{
+		{"android", "Android", "android"},
+		{"iOS", "iOS", "ios"},
+		{"standalonewindows64", "StandaloneWindows64", "windows-il2cpp"},
+		{"mac-mono", "StandaloneOSX", ""},
+	}
+	for _, tt := range tests {
+		got, err := LookupTarget(tt.name)
+		if err != nil {
+			t.Errorf("LookupTarget(%q) error = %v", tt.name, err)
+			continue
+		}
+		if got.BuildTarget != tt.wantTarget || got.Module != tt.wantModule {
+			t.Errorf("LookupTarget(%q) = %+v, want %s/%s", tt.name, got, tt.wantTarget, tt.wantModule)
+		}
+	}
+
+	if _, err := LookupTarget("PlayStation"); err == nil {
+		t.Error("LookupTarget() with unknown target should return an error")
+	}
+}
+
+func testSpec() PipelineSpec {
+	return PipelineSpec{
+		ProjectPath:  "Game",
+		UnityVersion: "2022.3.62f1",
+		Targets: []Target{
+			{BuildTarget: "Android", Module: "android"},
+			{BuildTarget: "iOS", Module: "ios", RunnerOS: RunnerMacOS},
+		},
+		BuildMethod: "Build.Perform",
+	}
+}
+
+func TestGenerate_GitHub(t *testing.T) {
+	out, err := Generate(ProviderGitHub, testSpec())
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("generated workflow is not valid YAML: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{
+		"path: Game/Library",
+		"hashFiles('Game/Packages/packages-lock.json'",
+		"uniforge editor install -p Game --modules ${{ matrix.modules }}",
+		"- target: iOS\n            runner: macOS",
+		"- target: Android\n            runner: Linux",
+		"-executeMethod Build.Perform -buildTarget ${{ matrix.target }}",
+		"uniforge license return --version 2022.3.62f1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("workflow missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerate_GitLab(t *testing.T) {
+	out, err := Generate(ProviderGitLab, testSpec())
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("generated pipeline is not valid YAML: %v\n%s", err, out)
+	}
+	for _, job := range []string{"test", "build:android", "build:ios"} {
+		if _, ok := doc[job]; !ok {
+			t.Errorf("pipeline missing job %q:\n%s", job, out)
+		}
+	}
+	if !strings.Contains(out, "uniforge editor install -p Game --modules ios") {
+		t.Errorf("iOS job should install the ios module:\n%s", out)
+	}
+}
+
+func TestGenerate_UnknownProvider(t *testing.T) {
+	if _, err := Generate("jenkins", testSpec()); err == nil {
+		t.Error("Generate() with unknown provider should return an error")
+	}
+}
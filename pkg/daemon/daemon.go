@@ -0,0 +1,393 @@
+// Package daemon answers installed editor, version and project queries over a
+// local socket, so IDE plugins and shell prompts avoid a cold start per query.
+//
+// The protocol is JSON-RPC 2.0 with one request or response object per line.
+// Unix sockets are used on every platform (Windows 10 and later support them).
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/paths"
+	"github.com/neptaco/uniforge/pkg/sdk"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Methods served by the daemon
+const (
+	MethodListEditors    = "editors.list"    // No params; result []sdk.Editor
+	MethodResolveVersion = "version.resolve" // ResolveParams; result ResolveResult
+	MethodListProjects   = "projects.list"   // No params; result []sdk.Project
+	MethodRefresh        = "daemon.refresh"  // Drops cached answers; no result
+	MethodStatus         = "daemon.status"   // No params; result Status
+	MethodShutdown       = "daemon.shutdown" // Stops the daemon; no result
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeFailed         = -32000 // The query itself failed; data holds the exit code
+)
+
+// DefaultTTL is how long answers are reused before editors and projects are read again
+const DefaultTTL = 30 * time.Second
+
+// callTimeout bounds a client call, including a version resolve that fetches releases
+const callTimeout = 60 * time.Second
+
+// ResolveParams are the parameters of MethodResolveVersion
+type ResolveParams struct {
+	Spec             string `json:"spec"`
+	IncludeAvailable bool   `json:"includeAvailable,omitempty"`
+}
+
+// ResolveResult is the result of MethodResolveVersion
+type ResolveResult struct {
+	Version string `json:"version"`
+}
+
+// Status is the result of MethodStatus
+type Status struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"startedAt"`
+	Requests  int64     `json:"requests"`
+	CacheTTL  string    `json:"cacheTtl"`
+	Cached    int       `json:"cached"` // Answers currently cached
+}
+
+// Backend answers the queries; *sdk.Client implements it
+type Backend interface {
+	InstalledEditors() ([]sdk.Editor, error)
+	ResolveVersion(spec string, includeAvailable bool) (string, error)
+	Projects() ([]sdk.Project, error)
+	Refresh()
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int       `json:"code"`
+	Message string    `json:"message"`
+	Data    *failData `json:"data,omitempty"`
+}
+
+type failData struct {
+	ExitCode int    `json:"exitCode"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+// Server answers requests from a Backend, reusing answers for the TTL
+type Server struct {
+	backend Backend
+	ttl     time.Duration
+	started time.Time
+
+	requests atomic.Int64
+
+	// backendMu serializes backend calls, which are not safe for concurrent use
+	backendMu sync.Mutex
+
+	mu          sync.Mutex // Guards the fields below; never held during a backend call
+	cache       map[string]json.RawMessage
+	inflight    map[string]*call // Answers being computed, by cache key
+	generation  int              // Incremented by every refresh
+	refreshedAt time.Time
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewServer returns a Server for backend. Answers are reused for ttl, or
+// until MethodRefresh is called.
+func NewServer(backend Backend, ttl time.Duration) *Server {
+	now := time.Now()
+	return &Server{
+		backend:     backend,
+		ttl:         ttl,
+		started:     now,
+		cache:       make(map[string]json.RawMessage),
+		inflight:    make(map[string]*call),
+		refreshedAt: now,
+		done:        make(chan struct{}),
+	}
+}
+
+// Done is closed once a client requested a shutdown and was answered
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+// Serve accepts connections on l until it is closed
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		resp, shutdown := s.handle(scanner.Bytes())
+		err := encoder.Encode(resp)
+		// Only once the client has its answer, so it does not see the daemon exit first
+		if shutdown {
+			s.closeOnce.Do(func() { close(s.done) })
+		}
+		if err != nil {
+			ui.Debug("Failed to write daemon response", "error", err)
+			return
+		}
+	}
+}
+
+// handle answers one encoded request and reports whether it asks the daemon
+// to shut down
+func (s *Server) handle(line []byte) (response, bool) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(nil, codeParseError, "invalid JSON: "+err.Error()), false
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, `expected {"jsonrpc": "2.0", "method": ...}`), false
+	}
+	s.requests.Add(1)
+	ui.Debug("Daemon request", "method", req.Method)
+
+	result, code, err := s.dispatch(req)
+	if err != nil {
+		resp := errorResponse(req.ID, code, err.Error())
+		if code == codeFailed {
+			resp.Error.Data = &failData{ExitCode: errs.ExitCode(err), Hint: errs.Hint(err)}
+		}
+		return resp, false
+	}
+	return response{JSONRPC: "2.0", ID: req.ID, Result: result}, req.Method == MethodShutdown
+}
+
+func (s *Server) dispatch(req request) (json.RawMessage, int, error) {
+	switch req.Method {
+	case MethodListEditors:
+		return s.cached(req.Method, func() (any, error) { return s.backend.InstalledEditors() })
+
+	case MethodListProjects:
+		return s.cached(req.Method, func() (any, error) { return s.backend.Projects() })
+
+	case MethodResolveVersion:
+		var params ResolveParams
+		if len(req.Params) == 0 || json.Unmarshal(req.Params, &params) != nil || params.Spec == "" {
+			return nil, codeInvalidParams, fmt.Errorf(`expected {"spec": "<version spec>"}`)
+		}
+		key := fmt.Sprintf("%s %s %t", req.Method, params.Spec, params.IncludeAvailable)
+		return s.cached(key, func() (any, error) {
+			version, err := s.backend.ResolveVersion(params.Spec, params.IncludeAvailable)
+			return ResolveResult{Version: version}, err
+		})
+
+	case MethodRefresh:
+		s.mu.Lock()
+		s.refreshLocked()
+		s.mu.Unlock()
+		return json.RawMessage("null"), 0, nil
+
+	case MethodStatus:
+		s.mu.Lock()
+		cached := len(s.cache)
+		s.mu.Unlock()
+		return marshal(Status{
+			PID:       os.Getpid(),
+			StartedAt: s.started,
+			Requests:  s.requests.Load(),
+			CacheTTL:  s.ttl.String(),
+			Cached:    cached,
+		})
+
+	case MethodShutdown:
+		// serveConn closes done after writing this answer
+		return json.RawMessage("null"), 0, nil
+	}
+	return nil, codeMethodNotFound, fmt.Errorf("unknown method %q", req.Method)
+}
+
+// call is an answer being computed; done is closed once the rest is set
+type call struct {
+	done   chan struct{}
+	result json.RawMessage
+	code   int
+	err    error
+}
+
+// cached returns the answer stored under key, computing it with fn if it is
+// missing. Concurrent requests for the same key share one computation, and
+// s.mu is not held while fn runs, so a slow query does not hold up the
+// others. Failures are not cached.
+func (s *Server) cached(key string, fn func() (any, error)) (json.RawMessage, int, error) {
+	s.mu.Lock()
+	if time.Since(s.refreshedAt) > s.ttl {
+		s.refreshLocked()
+	}
+	if result, ok := s.cache[key]; ok {
+		s.mu.Unlock()
+		return result, 0, nil
+	}
+	if c, ok := s.inflight[key]; ok {
+		s.mu.Unlock()
+		<-c.done
+		return c.result, c.code, c.err
+	}
+	c := &call{done: make(chan struct{})}
+	s.inflight[key] = c
+	generation := s.generation
+	s.mu.Unlock()
+
+	s.backendMu.Lock()
+	value, err := fn()
+	s.backendMu.Unlock()
+	if err != nil {
+		c.code, c.err = codeFailed, err
+	} else {
+		c.result, c.code, c.err = marshal(value)
+	}
+
+	s.mu.Lock()
+	delete(s.inflight, key)
+	// An answer computed before a refresh may already be outdated
+	if c.err == nil && generation == s.generation {
+		s.cache[key] = c.result
+	}
+	s.mu.Unlock()
+	close(c.done)
+	return c.result, c.code, c.err
+}
+
+// refreshLocked drops every cached answer; s.mu must be held
+func (s *Server) refreshLocked() {
+	s.backend.Refresh()
+	clear(s.cache)
+	s.generation++
+	s.refreshedAt = time.Now()
+}
+
+func marshal(value any) (json.RawMessage, int, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, codeFailed, err
+	}
+	return data, 0, nil
+}
+
+func errorResponse(id json.RawMessage, code int, message string) response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
+
+// DefaultSocketPath returns the socket the daemon listens on unless told otherwise
+func DefaultSocketPath() string {
+	return filepath.Join(paths.CacheDir(), "daemon.sock")
+}
+
+// Listen listens on the unix socket at path. A socket left behind by a daemon
+// that is no longer running is replaced; a running daemon is an error.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			_ = conn.Close()
+			return nil, errs.WithHint(fmt.Errorf("a daemon is already listening on %s", path),
+				"Stop it with 'uniforge daemon stop' or pass --socket")
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// Only the current user may query the daemon
+	if err := os.Chmod(path, 0600); err != nil {
+		ui.Debug("Failed to restrict socket permissions", "error", err)
+	}
+	return l, nil
+}
+
+// Call sends one request to the daemon at socketPath and decodes its result
+// into result, which may be nil
+func Call(socketPath, method string, params, result any) error {
+	conn, err := net.DialTimeout("unix", socketPath, time.Second)
+	if err != nil {
+		return errs.WithHint(
+			errs.New(errs.NotFound, "no daemon is listening on %s", socketPath),
+			"Start one with 'uniforge daemon'")
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(callTimeout))
+
+	req := request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method}
+	if params != nil {
+		if req.Params, err = json.Marshal(params); err != nil {
+			return err
+		}
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.Error != nil {
+		if resp.Error.Data != nil && resp.Error.Data.Hint != "" {
+			return errs.WithHint(errors.New(resp.Error.Message), resp.Error.Data.Hint)
+		}
+		return errors.New(resp.Error.Message)
+	}
+	if result != nil && len(resp.Result) > 0 {
+		return json.Unmarshal(resp.Result, result)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/sdk"
+)
+
+type fakeBackend struct {
+	editorCalls int
+	refreshes   int
+}
+
+func (b *fakeBackend) InstalledEditors() ([]sdk.Editor, error) {
+	b.editorCalls++
+	return []sdk.Editor{{Version: "2022.3.10f1", Path: "/editors/2022.3.10f1"}}, nil
+}
+
+func (b *fakeBackend) ResolveVersion(spec string, includeAvailable bool) (string, error) {
+	if spec == "2019" {
+		return "", errs.New(errs.NotInstalled, "no installed editor matches %s", spec)
+	}
+	return "2022.3.10f1", nil
+}
+
+func (b *fakeBackend) Projects() ([]sdk.Project, error) {
+	return []sdk.Project{{Name: "Game", Path: "/work/game"}}, nil
+}
+
+func (b *fakeBackend) Refresh() { b.refreshes++ }
+
+func startServer(t *testing.T, backend Backend, ttl time.Duration) (*Server, string) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "uf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "daemon.sock")
+
+	l, err := Listen(socket)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	server := NewServer(backend, ttl)
+	go func() { _ = server.Serve(l) }()
+	return server, socket
+}
+
+func TestServer(t *testing.T) {
+	backend := &fakeBackend{}
+	server, socket := startServer(t, backend, time.Hour)
+
+	var editors []sdk.Editor
+	for i := 0; i < 3; i++ {
+		if err := Call(socket, MethodListEditors, nil, &editors); err != nil {
+			t.Fatalf("Call(editors.list) error = %v", err)
+		}
+	}
+	if len(editors) != 1 || editors[0].Version != "2022.3.10f1" {
+		t.Errorf("editors = %+v", editors)
+	}
+	if backend.editorCalls != 1 {
+		t.Errorf("backend asked %d times, want answers reused", backend.editorCalls)
+	}
+
+	var resolved ResolveResult
+	if err := Call(socket, MethodResolveVersion, ResolveParams{Spec: "2022"}, &resolved); err != nil || resolved.Version != "2022.3.10f1" {
+		t.Errorf("Call(version.resolve) = %+v, %v", resolved, err)
+	}
+	err := Call(socket, MethodResolveVersion, ResolveParams{Spec: "2019"}, &resolved)
+	if err == nil || !strings.Contains(err.Error(), "no installed editor matches 2019") || errs.Hint(err) == "" {
+		t.Errorf("Call(version.resolve 2019) error = %v, want the failure with its hint", err)
+	}
+	if err := Call(socket, MethodResolveVersion, nil, nil); err == nil || !strings.Contains(err.Error(), "spec") {
+		t.Errorf("Call(version.resolve) without params error = %v", err)
+	}
+	if err := Call(socket, "editors.remove", nil, nil); err == nil || !strings.Contains(err.Error(), "unknown method") {
+		t.Errorf("unknown method error = %v", err)
+	}
+
+	// Refreshing drops the cached answers
+	if err := Call(socket, MethodRefresh, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := Call(socket, MethodListEditors, nil, &editors); err != nil {
+		t.Fatal(err)
+	}
+	if backend.editorCalls != 2 || backend.refreshes != 1 {
+		t.Errorf("after refresh: %d editor calls, %d refreshes", backend.editorCalls, backend.refreshes)
+	}
+
+	var status Status
+	if err := Call(socket, MethodStatus, nil, &status); err != nil || status.PID != os.Getpid() || status.Requests < 8 {
+		t.Errorf("Call(daemon.status) = %+v, %v", status, err)
+	}
+
+	// A second daemon on the same socket is refused
+	if _, err := Listen(socket); err == nil || !strings.Contains(err.Error(), "already listening") {
+		t.Errorf("second Listen() error = %v", err)
+	}
+
+	if err := Call(socket, MethodShutdown, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-server.Done():
+	case <-time.After(time.Second):
+		t.Error("shutdown did not close Done()")
+	}
+}
+
+func TestServerTTL(t *testing.T) {
+	backend := &fakeBackend{}
+	_, socket := startServer(t, backend, time.Nanosecond)
+
+	for i := 0; i < 2; i++ {
+		if err := Call(socket, MethodListEditors, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if backend.editorCalls != 2 || backend.refreshes != 2 {
+		t.Errorf("%d editor calls, %d refreshes; want both answers read again", backend.editorCalls, backend.refreshes)
+	}
+}
+
+func TestServerRawProtocol(t *testing.T) {
+	_, socket := startServer(t, &fakeBackend{}, time.Hour)
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Several requests on one connection, answered in order
+	lines := "not json\n" +
+		`{"jsonrpc":"1.0","id":1,"method":"projects.list"}` + "\n" +
+		`{"jsonrpc":"2.0","id":"p","method":"projects.list"}` + "\n"
+	if _, err := conn.Write([]byte(lines)); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	var responses []response
+	for range 3 {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp response
+		if err := json.Unmarshal(line, &resp); err != nil {
+			t.Fatalf("invalid response %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if responses[0].Error == nil || responses[0].Error.Code != codeParseError || string(responses[0].ID) != "null" {
+		t.Errorf("parse error response = %+v", responses[0])
+	}
+	if responses[1].Error == nil || responses[1].Error.Code != codeInvalidRequest {
+		t.Errorf("invalid request response = %+v", responses[1])
+	}
+	if responses[2].Error != nil || string(responses[2].ID) != `"p"` || !strings.Contains(string(responses[2].Result), `"path":"/work/game"`) {
+		t.Errorf("projects.list response = %+v", responses[2])
+	}
+}
+
+// slowBackend resolves versions only once release is closed
+type slowBackend struct {
+	fakeBackend
+	release  chan struct{}
+	resolves atomic.Int32
+}
+
+func (b *slowBackend) ResolveVersion(spec string, includeAvailable bool) (string, error) {
+	b.resolves.Add(1)
+	<-b.release
+	return b.fakeBackend.ResolveVersion(spec, includeAvailable)
+}
+
+func TestServerSlowQuery(t *testing.T) {
+	backend := &slowBackend{release: make(chan struct{})}
+	_, socket := startServer(t, backend, time.Hour)
+
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			var resolved ResolveResult
+			results <- Call(socket, MethodResolveVersion, ResolveParams{Spec: "lts", IncludeAvailable: true}, &resolved)
+		}()
+	}
+
+	// Status answers while the resolve is running
+	deadline := time.Now().Add(time.Second)
+	for backend.resolves.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	var status Status
+	if err := Call(socket, MethodStatus, nil, &status); err != nil {
+		t.Fatalf("Call(daemon.status) during a resolve error = %v", err)
+	}
+
+	close(backend.release)
+	for range 2 {
+		if err := <-results; err != nil {
+			t.Errorf("Call(version.resolve) error = %v", err)
+		}
+	}
+	if n := backend.resolves.Load(); n != 1 {
+		t.Errorf("backend resolved %d times, want the concurrent requests to share one", n)
+	}
+}
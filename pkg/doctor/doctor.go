@@ -0,0 +1,159 @@
+// Package doctor implements environment diagnostics for uniforge
+package doctor
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// Status is the outcome of a single check
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusWarn
+	StatusFail
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusWarn:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
+
+// Result is the outcome of a check with an optional remediation hint
+type Result struct {
+	Name   string
+	Status Status
+	Detail string
+	Remedy string   // Actionable step shown when the check does not pass
+	Items  []string // Individual findings, e.g. "file:line: message"
+}
+
+// Check runs one diagnostic
+type Check func() Result
+
+// Run executes all checks in order
+func Run(checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, check())
+	}
+	return results
+}
+
+// CountFailures returns the number of failed results
+func CountFailures(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Status == StatusFail {
+			n++
+		}
+	}
+	return n
+}
+
+// CheckWritableDir verifies that dir exists (or can be created) and accepts new files
+func CheckWritableDir(name, dir, remedy string) Result {
+	r := Result{Name: name, Detail: dir}
+
+	if dir == "" {
+		r.Status = StatusFail
+		r.Detail = "path could not be determined"
+		r.Remedy = remedy
+		return r
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		r.Status = StatusFail
+		r.Detail = fmt.Sprintf("%s (%v)", dir, err)
+		r.Remedy = remedy
+		return r
+	}
+
+	f, err := os.CreateTemp(dir, ".uniforge-doctor-*")
+	if err != nil {
+		r.Status = StatusFail
+		r.Detail = fmt.Sprintf("%s is not writable (%v)", dir, err)
+		r.Remedy = remedy
+		return r
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+
+	return r
+}
+
+// CheckReachable verifies that url answers an HTTP request within timeout
+func CheckReachable(name, url string, timeout time.Duration, remedy string) Result {
+	r := Result{Name: name, Detail: url}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Head(url)
+	if err != nil {
+		r.Status = StatusFail
+		r.Detail = fmt.Sprintf("%s (%v)", url, err)
+		r.Remedy = remedy
+		return r
+	}
+	_ = resp.Body.Close()
+
+	// Any HTTP response proves connectivity; API endpoints often reject HEAD
+	if resp.StatusCode >= 500 {
+		r.Status = StatusWarn
+		r.Detail = fmt.Sprintf("%s (%s)", url, resp.Status)
+		r.Remedy = remedy
+	}
+	return r
+}
+
+// CheckCommand verifies that an executable is available on PATH.
+// Missing optional commands are reported as warnings.
+func CheckCommand(name, command string, required bool, remedy string) Result {
+	r := Result{Name: name}
+
+	path, err := exec.LookPath(command)
+	if err != nil {
+		r.Status = StatusWarn
+		if required {
+			r.Status = StatusFail
+		}
+		r.Detail = command + " not found in PATH"
+		r.Remedy = remedy
+		return r
+	}
+
+	r.Detail = path
+	return r
+}
+
+// CheckFile verifies that path exists
+func CheckFile(name, path string, required bool, remedy string) Result {
+	r := Result{Name: name, Detail: path}
+
+	if path == "" {
+		r.Status = StatusWarn
+		if required {
+			r.Status = StatusFail
+		}
+		r.Detail = "not found"
+		r.Remedy = remedy
+		return r
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		r.Status = StatusFail
+		r.Detail = fmt.Sprintf("%s (%v)", filepath.Clean(path), err)
+		r.Remedy = remedy
+	}
+	return r
+}
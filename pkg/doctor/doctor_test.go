@@ -0,0 +1,75 @@
+package doctor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckWritableDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	r := CheckWritableDir("Cache", dir, "fix it")
+	if r.Status != StatusOK {
+		t.Errorf("Expected ok for creatable directory, got %s: %s", r.Status, r.Detail)
+	}
+
+	r = CheckWritableDir("Cache", "", "fix it")
+	if r.Status != StatusFail || r.Remedy == "" {
+		t.Errorf("Expected failure with remedy for empty path, got %+v", r)
+	}
+
+	// A regular file cannot be used as a directory
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	r = CheckWritableDir("Cache", file, "fix it")
+	if r.Status != StatusFail {
+		t.Errorf("Expected failure for file path, got %s", r.Status)
+	}
+}
+
+func TestCheckReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer server.Close()
+
+	r := CheckReachable("API", server.URL, time.Second, "check network")
+	if r.Status != StatusOK {
+		t.Errorf("Expected ok for reachable server, got %s: %s", r.Status, r.Detail)
+	}
+
+	server.Close()
+	r = CheckReachable("API", server.URL, time.Second, "check network")
+	if r.Status != StatusFail {
+		t.Errorf("Expected failure for closed server, got %s", r.Status)
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	r := CheckCommand("Missing", "uniforge-nonexistent-command", false, "install it")
+	if r.Status != StatusWarn {
+		t.Errorf("Expected warning for missing optional command, got %s", r.Status)
+	}
+
+	r = CheckCommand("Missing", "uniforge-nonexistent-command", true, "install it")
+	if r.Status != StatusFail {
+		t.Errorf("Expected failure for missing required command, got %s", r.Status)
+	}
+}
+
+func TestCountFailures(t *testing.T) {
+	results := Run([]Check{
+		func() Result { return Result{Status: StatusOK} },
+		func() Result { return Result{Status: StatusWarn} },
+		func() Result { return Result{Status: StatusFail} },
+	})
+	if got := CountFailures(results); got != 1 {
+		t.Errorf("CountFailures() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,155 @@
+// Package editorprefs reads and writes Unity Editor preferences (EditorPrefs):
+// the plist on macOS, the registry on Windows and the prefs file on Linux
+package editorprefs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned on systems Unity Editor does not run on
+var ErrUnsupported = errors.New("editor preferences are not supported on this system")
+
+// BundleVersion is the format version of exported bundles
+const BundleVersion = 1
+
+// Value types of a Pref
+const (
+	TypeString = "string"
+	TypeInt    = "int"
+	TypeFloat  = "float"
+)
+
+// Pref is one editor preference. Values are kept as text so bundles read the
+// same on every platform; ints and floats use Go's decimal formatting.
+type Pref struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Bundle is the portable JSON file written by export and read by import
+type Bundle struct {
+	Version    int       `json:"version"`
+	Platform   string    `json:"platform"` // GOOS of the exporting machine
+	ExportedAt time.Time `json:"exportedAt"`
+	Prefs      []Pref    `json:"prefs"`
+}
+
+// safeKeys are preferences that are meaningful on another machine and hold no
+// machine identity or session state: external tools, theme and the script editor
+var safeKeys = []string{
+	"kScriptsDefaultApp",
+	"kScriptEditorArgs", // Also per-editor variants, kScriptEditorArgs<path>
+	"kImagesDefaultApp",
+	"kDiffsDefaultApp",
+	"kMergeDefaultApp",
+	"UserSkin",
+	"AndroidSdkRoot",
+	"AndroidNdkRoot",
+	"JdkPath",
+	"GradlePath",
+	"SdkUseEmbedded",
+	"NdkUseEmbedded",
+	"JdkUseEmbedded",
+	"GradleUseEmbedded",
+	"kAutoRefresh",
+	"CustomEditorUIScale",
+}
+
+// IsSafe reports whether key is on the allowlist of portable preferences
+func IsSafe(key string) bool {
+	return slices.ContainsFunc(safeKeys, func(safe string) bool {
+		return key == safe || (safe == "kScriptEditorArgs" && strings.HasPrefix(key, safe))
+	})
+}
+
+// FilterSafe returns the prefs whose keys are on the allowlist
+func FilterSafe(prefs []Pref) []Pref {
+	return slices.DeleteFunc(slices.Clone(prefs), func(p Pref) bool { return !IsSafe(p.Key) })
+}
+
+// Read returns the editor preferences of the current user, sorted by key
+func Read() ([]Pref, error) {
+	prefs, err := read()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(prefs, func(a, b Pref) int { return strings.Compare(a.Key, b.Key) })
+	return prefs, nil
+}
+
+// Write sets the given preferences, leaving others unchanged. Unity keeps its
+// preferences in memory and saves them on quit, so editors should be closed.
+func Write(prefs []Pref) error {
+	for _, p := range prefs {
+		if err := p.validate(); err != nil {
+			return err
+		}
+	}
+	return write(prefs)
+}
+
+// NewBundle wraps prefs for export
+func NewBundle(prefs []Pref) *Bundle {
+	if prefs == nil {
+		prefs = []Pref{}
+	}
+	return &Bundle{
+		Version:    BundleVersion,
+		Platform:   runtime.GOOS,
+		ExportedAt: time.Now().UTC(),
+		Prefs:      prefs,
+	}
+}
+
+// ParseBundle reads an exported bundle and checks its preferences
+func ParseBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid preferences bundle: %w", err)
+	}
+	if bundle.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported preferences bundle version %d (expected %d)", bundle.Version, BundleVersion)
+	}
+	for _, p := range bundle.Prefs {
+		if err := p.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &bundle, nil
+}
+
+func (p Pref) validate() error {
+	if p.Key == "" {
+		return fmt.Errorf("preference without a key")
+	}
+	switch p.Type {
+	case TypeString:
+	case TypeInt:
+		if _, err := strconv.ParseInt(p.Value, 10, 32); err != nil {
+			return fmt.Errorf("preference %s: invalid int %q", p.Key, p.Value)
+		}
+	case TypeFloat:
+		if _, err := strconv.ParseFloat(p.Value, 64); err != nil {
+			return fmt.Errorf("preference %s: invalid float %q", p.Key, p.Value)
+		}
+	default:
+		return fmt.Errorf("preference %s: unknown type %q", p.Key, p.Type)
+	}
+	return nil
+}
+
+func intPref(key string, v int64) Pref {
+	return Pref{Key: key, Type: TypeInt, Value: strconv.FormatInt(v, 10)}
+}
+
+func floatPref(key string, v float64) Pref {
+	return Pref{Key: key, Type: TypeFloat, Value: strconv.FormatFloat(v, 'g', -1, 64)}
+}
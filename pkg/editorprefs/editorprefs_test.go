@@ -0,0 +1,140 @@
+package editorprefs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePlist(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>kScriptsDefaultApp</key>
+	<string>/Applications/Rider.app</string>
+	<key>UserSkin</key>
+	<integer>1</integer>
+	<key>Nested</key>
+	<dict>
+		<key>Inner</key>
+		<string>skipped</string>
+	</dict>
+	<key>List</key>
+	<array><string>skipped</string></array>
+	<key>CustomEditorUIScale</key>
+	<real>1.25</real>
+	<key>kAutoRefresh</key>
+	<true/>
+</dict>
+</plist>`
+
+	prefs, err := parsePlist([]byte(data))
+	if err != nil {
+		t.Fatalf("parsePlist() error = %v", err)
+	}
+	want := []Pref{
+		{Key: "kScriptsDefaultApp", Type: TypeString, Value: "/Applications/Rider.app"},
+		{Key: "UserSkin", Type: TypeInt, Value: "1"},
+		{Key: "CustomEditorUIScale", Type: TypeFloat, Value: "1.25"},
+		{Key: "kAutoRefresh", Type: TypeInt, Value: "1"},
+	}
+	if !slices.Equal(prefs, want) {
+		t.Errorf("parsePlist() = %+v, want %+v", prefs, want)
+	}
+}
+
+func TestPrefsFile(t *testing.T) {
+	data := `<unity_prefs version_major="1" version_minor="1">
+	<pref name="kScriptsDefaultApp" type="string">L3Vzci9iaW4vY29kZQ==</pref>
+	<pref name="UserSkin" type="int">0</pref>
+	<pref name="Scale" type="float">1.5</pref>
+</unity_prefs>`
+
+	file, err := parsePrefsFile([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Pref{
+		{Key: "kScriptsDefaultApp", Type: TypeString, Value: "/usr/bin/code"},
+		{Key: "UserSkin", Type: TypeInt, Value: "0"},
+		{Key: "Scale", Type: TypeFloat, Value: "1.5"},
+	}
+	if got := file.prefs(); !slices.Equal(got, want) {
+		t.Errorf("prefs() = %+v, want %+v", got, want)
+	}
+
+	file.set([]Pref{
+		{Key: "UserSkin", Type: TypeInt, Value: "1"},
+		{Key: "JdkPath", Type: TypeString, Value: "/opt/jdk"},
+	})
+	out, err := file.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(out), `<unity_prefs version_major="1" version_minor="1">`) {
+		t.Errorf("marshal() lost the root element:\n%s", out)
+	}
+
+	reread, err := parsePrefsFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Pref{
+		{Key: "kScriptsDefaultApp", Type: TypeString, Value: "/usr/bin/code"},
+		{Key: "UserSkin", Type: TypeInt, Value: "1"},
+		{Key: "Scale", Type: TypeFloat, Value: "1.5"},
+		{Key: "JdkPath", Type: TypeString, Value: "/opt/jdk"},
+	}
+	if got := reread.prefs(); !slices.Equal(got, want) {
+		t.Errorf("after set = %+v, want %+v", got, want)
+	}
+}
+
+func TestRegistryValueName(t *testing.T) {
+	name := registryValueName("UserSkin")
+	if !strings.HasPrefix(name, "UserSkin_h") {
+		t.Fatalf("registryValueName() = %q", name)
+	}
+	if key, ok := registryKeyName(name); !ok || key != "UserSkin" {
+		t.Errorf("registryKeyName(%q) = %q, %v", name, key, ok)
+	}
+	for _, name := range []string{"UserSkin", "UserSkin_h1", "_h123"} {
+		if _, ok := registryKeyName(name); ok {
+			t.Errorf("registryKeyName(%q) should not be a preference", name)
+		}
+	}
+}
+
+func TestFilterSafe(t *testing.T) {
+	prefs := []Pref{
+		{Key: "kScriptsDefaultApp", Type: TypeString},
+		{Key: "kScriptEditorArgs/Applications/Rider.app", Type: TypeString},
+		{Key: "UnityConnectUserId", Type: TypeString},
+		{Key: "RecentlyUsedProjectPaths-0", Type: TypeString},
+	}
+	got := FilterSafe(prefs)
+	if len(got) != 2 || got[0].Key != "kScriptsDefaultApp" || got[1].Key != "kScriptEditorArgs/Applications/Rider.app" {
+		t.Errorf("FilterSafe() = %+v", got)
+	}
+	if len(prefs) != 4 {
+		t.Error("FilterSafe() modified its input")
+	}
+}
+
+func TestParseBundle(t *testing.T) {
+	if _, err := ParseBundle([]byte(`{"version": 1, "prefs": [{"key": "UserSkin", "type": "int", "value": "1"}]}`)); err != nil {
+		t.Errorf("ParseBundle() error = %v", err)
+	}
+	for _, data := range []string{
+		`not json`,
+		`{"version": 2, "prefs": []}`,
+		`{"version": 1, "prefs": [{"key": "UserSkin", "type": "int", "value": "dark"}]}`,
+		`{"version": 1, "prefs": [{"key": "UserSkin", "type": "bool", "value": "1"}]}`,
+		`{"version": 1, "prefs": [{"type": "string", "value": ""}]}`,
+	} {
+		if _, err := ParseBundle([]byte(data)); err == nil {
+			t.Errorf("ParseBundle(%s) should fail", data)
+		}
+	}
+}
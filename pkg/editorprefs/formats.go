@@ -0,0 +1,193 @@
+package editorprefs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parsePlist reads the top-level dictionary of an XML property list, as
+// printed by `defaults export`. Nested arrays, dictionaries and data are
+// skipped; Unity stores its preferences as strings, integers and reals.
+func parsePlist(data []byte) ([]Pref, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	var (
+		prefs []Pref
+		key   string
+		depth int // Nesting of dict and array elements
+	)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid plist: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			if end, ok := tok.(xml.EndElement); ok && (end.Name.Local == "dict" || end.Name.Local == "array") {
+				depth--
+			}
+			continue
+		}
+
+		switch start.Name.Local {
+		case "dict", "array":
+			depth++
+			if depth > 1 {
+				// Skip the whole nested value
+				if err := decoder.Skip(); err != nil {
+					return nil, fmt.Errorf("invalid plist: %w", err)
+				}
+				depth--
+				key = ""
+			}
+			continue
+		case "plist":
+			continue
+		}
+
+		var text string
+		if err := decoder.DecodeElement(&text, &start); err != nil {
+			return nil, fmt.Errorf("invalid plist: %w", err)
+		}
+		if depth != 1 {
+			continue
+		}
+		if start.Name.Local == "key" {
+			key = text
+			continue
+		}
+		if key == "" {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "string":
+			prefs = append(prefs, Pref{Key: key, Type: TypeString, Value: text})
+		case "integer":
+			if v, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64); err == nil {
+				prefs = append(prefs, intPref(key, v))
+			}
+		case "real":
+			if v, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+				prefs = append(prefs, floatPref(key, v))
+			}
+		case "true":
+			prefs = append(prefs, intPref(key, 1))
+		case "false":
+			prefs = append(prefs, intPref(key, 0))
+		}
+		key = ""
+	}
+	return prefs, nil
+}
+
+// prefsFile is the Linux prefs file, ~/.local/share/unity3d/prefs.
+// String values are base64 encoded.
+type prefsFile struct {
+	XMLName      xml.Name         `xml:"unity_prefs"`
+	VersionMajor string           `xml:"version_major,attr"`
+	VersionMinor string           `xml:"version_minor,attr"`
+	Prefs        []prefsFileEntry `xml:"pref"`
+}
+
+type prefsFileEntry struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func parsePrefsFile(data []byte) (*prefsFile, error) {
+	var file prefsFile
+	if err := xml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid prefs file: %w", err)
+	}
+	return &file, nil
+}
+
+// prefs returns the preferences in the file
+func (f *prefsFile) prefs() []Pref {
+	prefs := make([]Pref, 0, len(f.Prefs))
+	for _, entry := range f.Prefs {
+		value := strings.TrimSpace(entry.Value)
+		switch entry.Type {
+		case TypeString:
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				continue
+			}
+			prefs = append(prefs, Pref{Key: entry.Name, Type: TypeString, Value: string(decoded)})
+		case TypeInt, TypeFloat:
+			prefs = append(prefs, Pref{Key: entry.Name, Type: entry.Type, Value: value})
+		}
+	}
+	return prefs
+}
+
+// set replaces or adds prefs, keeping the order of existing entries
+func (f *prefsFile) set(prefs []Pref) {
+	index := make(map[string]int, len(f.Prefs))
+	for i, entry := range f.Prefs {
+		index[entry.Name] = i
+	}
+	for _, p := range prefs {
+		entry := prefsFileEntry{Name: p.Key, Type: p.Type, Value: p.Value}
+		if p.Type == TypeString {
+			entry.Value = base64.StdEncoding.EncodeToString([]byte(p.Value))
+		}
+		if i, ok := index[p.Key]; ok {
+			f.Prefs[i] = entry
+		} else {
+			index[p.Key] = len(f.Prefs)
+			f.Prefs = append(f.Prefs, entry)
+		}
+	}
+}
+
+func (f *prefsFile) marshal() ([]byte, error) {
+	if f.VersionMajor == "" {
+		f.VersionMajor, f.VersionMinor = "1", "1"
+	}
+	data, err := xml.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// registryValueName returns the registry value Unity uses for key on Windows:
+// the key followed by "_h" and a hash of it
+func registryValueName(key string) string {
+	return key + "_h" + strconv.FormatUint(uint64(registryHash(key)), 10)
+}
+
+// registryKeyName is the inverse of registryValueName. Values without a
+// matching hash suffix are not preferences and are reported as not ok.
+func registryKeyName(valueName string) (string, bool) {
+	i := strings.LastIndex(valueName, "_h")
+	if i <= 0 {
+		return "", false
+	}
+	key := valueName[:i]
+	if registryValueName(key) != valueName {
+		return "", false
+	}
+	return key, true
+}
+
+// registryHash is the djb2 variant with xor Unity applies to preference keys
+func registryHash(key string) uint32 {
+	hash := uint32(5381)
+	for i := 0; i < len(key); i++ {
+		hash = hash*33 ^ uint32(key[i])
+	}
+	return hash
+}
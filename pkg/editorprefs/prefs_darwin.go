@@ -0,0 +1,32 @@
+//go:build darwin
+
+package editorprefs
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// plistDomain is the defaults domain of ~/Library/Preferences/com.unity3d.UnityEditor5.x.plist
+const plistDomain = "com.unity3d.UnityEditor5.x"
+
+func read() ([]Pref, error) {
+	// defaults converts the binary plist and sees values cfprefsd has not saved yet
+	out, err := exec.Command("defaults", "export", plistDomain, "-").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", plistDomain, err)
+	}
+	return parsePlist(out)
+}
+
+func write(prefs []Pref) error {
+	for _, p := range prefs {
+		// `defaults import` would replace the whole domain, so keys are written one by one
+		out, err := exec.Command("defaults", "write", plistDomain, p.Key, "-"+p.Type, p.Value).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w: %s", p.Key, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build linux
+
+package editorprefs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+func prefsFilePath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "unity3d", "prefs")
+}
+
+func read() ([]Pref, error) {
+	data, err := os.ReadFile(prefsFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	file, err := parsePrefsFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", prefsFilePath(), err)
+	}
+	return file.prefs(), nil
+}
+
+func write(prefs []Pref) error {
+	path := prefsFilePath()
+	file := &prefsFile{}
+	if data, err := os.ReadFile(path); err == nil {
+		if file, err = parsePrefsFile(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	file.set(prefs)
+	data, err := file.marshal()
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, data, 0644)
+}
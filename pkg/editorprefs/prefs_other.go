@@ -0,0 +1,11 @@
+//go:build !darwin && !linux && !windows
+
+package editorprefs
+
+func read() ([]Pref, error) {
+	return nil, ErrUnsupported
+}
+
+func write(prefs []Pref) error {
+	return ErrUnsupported
+}
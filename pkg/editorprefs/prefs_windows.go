@@ -0,0 +1,91 @@
+//go:build windows
+
+package editorprefs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// registryPrefsKey holds EditorPrefs under HKEY_CURRENT_USER
+const registryPrefsKey = `Software\Unity Technologies\Unity Editor 5.x`
+
+func read() ([]Pref, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, registryPrefsKey, registry.QUERY_VALUE)
+	if err == registry.ErrNotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open HKCU\\%s: %w", registryPrefsKey, err)
+	}
+	defer func() { _ = key.Close() }()
+
+	names, err := key.ReadValueNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HKCU\\%s: %w", registryPrefsKey, err)
+	}
+
+	var prefs []Pref
+	for _, name := range names {
+		prefKey, ok := registryKeyName(name)
+		if !ok {
+			continue
+		}
+		buf := make([]byte, 1024)
+		n, valueType, err := key.GetValue(name, buf)
+		if err == registry.ErrShortBuffer || n > len(buf) {
+			buf = make([]byte, n)
+			n, valueType, err = key.GetValue(name, buf)
+		}
+		if err != nil {
+			continue
+		}
+		buf = buf[:n]
+
+		// Strings are null-terminated UTF-8 in binary values, ints are DWORDs
+		// and floats are doubles stored as QWORDs
+		switch valueType {
+		case registry.BINARY:
+			prefs = append(prefs, Pref{Key: prefKey, Type: TypeString, Value: string(bytes.TrimRight(buf, "\x00"))})
+		case registry.DWORD:
+			if len(buf) >= 4 {
+				prefs = append(prefs, intPref(prefKey, int64(int32(binary.LittleEndian.Uint32(buf)))))
+			}
+		case registry.QWORD:
+			if len(buf) >= 8 {
+				prefs = append(prefs, floatPref(prefKey, math.Float64frombits(binary.LittleEndian.Uint64(buf))))
+			}
+		}
+	}
+	return prefs, nil
+}
+
+func write(prefs []Pref) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, registryPrefsKey, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to open HKCU\\%s: %w", registryPrefsKey, err)
+	}
+	defer func() { _ = key.Close() }()
+
+	for _, p := range prefs {
+		name := registryValueName(p.Key)
+		switch p.Type {
+		case TypeString:
+			err = key.SetBinaryValue(name, append([]byte(p.Value), 0))
+		case TypeInt:
+			v, _ := strconv.ParseInt(p.Value, 10, 32)
+			err = key.SetDWordValue(name, uint32(int32(v)))
+		case TypeFloat:
+			v, _ := strconv.ParseFloat(p.Value, 64)
+			err = key.SetQWordValue(name, math.Float64bits(v))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", p.Key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package errs defines the error categories shared by uniforge commands.
+//
+// Each category is a sentinel error with a documented process exit code and a
+// remediation hint. Packages attach a category with New, callers test for it
+// with errors.Is, and the CLI maps it to an exit code and hint when a command fails.
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes returned by the uniforge process
+const (
+	ExitOK                 = 0 // Success
+	ExitGeneral            = 1 // Any failure without a more specific category
+	ExitUsage              = 2 // Invalid flags, arguments or version specs
+	ExitNotFound           = 3 // Project, release or file not found
+	ExitNotInstalled       = 4 // Unity editor, module or tool not installed
+	ExitMultipleMatches    = 5 // A query matched more than one item
+	ExitHubMissing         = 6 // Unity Hub not found
+	ExitLicenseMissing     = 7 // No license or license credentials
+	ExitNetworkUnavailable = 8 // A network request failed
+	ExitInputRequired      = 9 // Input needed but running non-interactively
+)
+
+// Category classifies an error. Categories are sentinel errors: test with errors.Is.
+type Category struct {
+	name     string
+	exitCode int
+	hint     string
+}
+
+func (c *Category) Error() string {
+	return c.name
+}
+
+// ExitCode returns the process exit code for the category
+func (c *Category) ExitCode() int {
+	return c.exitCode
+}
+
+// Hint returns the default remediation hint for the category
+func (c *Category) Hint() string {
+	return c.hint
+}
+
+// Error categories
+var (
+	Usage = &Category{
+		name:     "usage error",
+		exitCode: ExitUsage,
+		hint:     "Run 'uniforge <command> --help' for usage.",
+	}
+	NotFound = &Category{
+		name:     "not found",
+		exitCode: ExitNotFound,
+		hint:     "Check the name or path. 'uniforge project list' shows known projects.",
+	}
+	NotInstalled = &Category{
+		name:     "not installed",
+		exitCode: ExitNotInstalled,
+		hint:     "List installed editors with 'uniforge editor list', or install one with 'uniforge editor install <version>'.",
+	}
+	MultipleMatches = &Category{
+		name:     "multiple matches",
+		exitCode: ExitMultipleMatches,
+		hint:     "Use a more specific name, or run in a terminal to choose interactively.",
+	}
+	HubMissing = &Category{
+		name:     "unity hub not found",
+		exitCode: ExitHubMissing,
+		hint:     "Install Unity Hub from https://unity.com/download, or set UNIFORGE_HUB_PATH (hub-path in ~/.uniforge.yaml).",
+	}
+	LicenseMissing = &Category{
+		name:     "license missing",
+		exitCode: ExitLicenseMissing,
+		hint:     "Store credentials with 'uniforge license login', then run 'uniforge license activate'.",
+	}
+	NetworkUnavailable = &Category{
+		name:     "network unavailable",
+		exitCode: ExitNetworkUnavailable,
+		hint:     "Check your network connection and proxy settings (HTTPS_PROXY), then retry.",
+	}
+	InputRequired = &Category{
+		name:     "input required",
+		exitCode: ExitInputRequired,
+		hint:     "Run in a terminal, or pass --yes to accept confirmations.",
+	}
+)
+
+// categorized is an error with a category attached
+type categorized struct {
+	category *Category
+	err      error
+}
+
+func (e *categorized) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap lists the wrapped error before the category, so that a category
+// found deeper in the chain (the root cause) takes precedence in CategoryOf
+func (e *categorized) Unwrap() []error {
+	return []error{e.err, e.category}
+}
+
+// New formats an error like fmt.Errorf (including %w) and attaches category c
+func New(c *Category, format string, args ...any) error {
+	return &categorized{category: c, err: fmt.Errorf(format, args...)}
+}
+
+// hinted is an error with a specific remediation hint
+type hinted struct {
+	err  error
+	hint string
+}
+
+func (e *hinted) Error() string {
+	return e.err.Error()
+}
+
+func (e *hinted) Unwrap() error {
+	return e.err
+}
+
+// WithHint attaches a remediation hint that replaces the category's default
+func WithHint(err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &hinted{err: err, hint: hint}
+}
+
+// CategoryOf returns the category of err, or nil if it has none.
+// When several are wrapped, the innermost one wins.
+func CategoryOf(err error) *Category {
+	var c *Category
+	if errors.As(err, &c) {
+		return c
+	}
+	return nil
+}
+
+// ExitCode returns the process exit code for err
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if c := CategoryOf(err); c != nil {
+		return c.exitCode
+	}
+	return ExitGeneral
+}
+
+// Hint returns the remediation hint for err, or "" if there is none
+func Hint(err error) string {
+	var h *hinted
+	if errors.As(err, &h) {
+		return h.hint
+	}
+	if c := CategoryOf(err); c != nil {
+		return c.hint
+	}
+	return ""
+}
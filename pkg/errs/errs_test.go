@@ -0,0 +1,86 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := New(NetworkUnavailable, "failed to fetch releases: %w", cause)
+
+	if got := err.Error(); got != "failed to fetch releases: connection refused" {
+		t.Errorf("Error() = %q", got)
+	}
+	if !errors.Is(err, NetworkUnavailable) {
+		t.Error("errors.Is(err, NetworkUnavailable) = false")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is(err, cause) = false")
+	}
+	if errors.Is(err, NotInstalled) {
+		t.Error("errors.Is(err, NotInstalled) = true")
+	}
+}
+
+func TestExitCodeAndHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantHint string
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			wantCode: ExitOK,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("boom"),
+			wantCode: ExitGeneral,
+		},
+		{
+			name:     "category",
+			err:      New(HubMissing, "unity hub not found"),
+			wantCode: ExitHubMissing,
+			wantHint: HubMissing.Hint(),
+		},
+		{
+			name:     "wrapped with fmt.Errorf",
+			err:      fmt.Errorf("failed to open: %w", New(NotFound, "project not found: %s", "Game")),
+			wantCode: ExitNotFound,
+			wantHint: NotFound.Hint(),
+		},
+		{
+			name:     "innermost category wins",
+			err:      New(NotInstalled, "editor not found: %w", New(HubMissing, "unity hub not found")),
+			wantCode: ExitHubMissing,
+			wantHint: HubMissing.Hint(),
+		},
+		{
+			name:     "specific hint",
+			err:      WithHint(New(NotInstalled, "unity 2022.3.10f1 is not installed"), "Run 'uniforge editor install 2022.3.10f1'."),
+			wantCode: ExitNotInstalled,
+			wantHint: "Run 'uniforge editor install 2022.3.10f1'.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.wantCode {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.wantCode)
+			}
+			if got := Hint(tt.err); got != tt.wantHint {
+				t.Errorf("Hint() = %q, want %q", got, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestWithHintNil(t *testing.T) {
+	if err := WithHint(nil, "hint"); err != nil {
+		t.Errorf("WithHint(nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,84 @@
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "cache.json")
+
+	if err := WriteFileAtomic(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("second"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic overwrite failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Expected content 'second', got %q", string(data))
+	}
+
+	// No temp files should be left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected only the target file, got %d entries", len(entries))
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{"a.bundle": "12345", filepath.Join("nested", "b.bundle"): "123"}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize failed: %v", err)
+	}
+	if size != 8 {
+		t.Errorf("DirSize = %d, want 8", size)
+	}
+}
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	lock, err := Lock(path, time.Second)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+
+	// A second lock on the same path must wait and time out
+	if _, err := Lock(path, 100*time.Millisecond); !errors.Is(err, ErrLockTimeout) {
+		t.Errorf("Expected ErrLockTimeout while locked, got %v", err)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	lock2, err := Lock(path, time.Second)
+	if err != nil {
+		t.Fatalf("Lock after unlock failed: %v", err)
+	}
+	_ = lock2.Unlock()
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// Managed blocks are delimited by these markers so uniforge can update its own
+// lines in files like .gitignore without touching user customizations
+const (
+	blockBeginFormat = "# >>> uniforge: %s >>>"
+	blockEndFormat   = "# <<< uniforge: %s <<<"
+)
+
+// ReadBlock returns the content of the named managed block in path
+func ReadBlock(path, name string) (string, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	start, end, ok := findBlock(string(data), name)
+	if !ok {
+		return "", false, nil
+	}
+	text := string(data)
+	content := text[start:end]
+	content = strings.TrimPrefix(content, fmt.Sprintf(blockBeginFormat, name)+"\n")
+	content = strings.TrimSuffix(content, fmt.Sprintf(blockEndFormat, name))
+	return content, true, nil
+}
+
+// WriteBlock replaces the named managed block in path with content, or appends it
+// if the block does not exist yet. Lines outside the block are preserved.
+// Returns false if the file already had exactly this content.
+func WriteBlock(path, name, content string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	text := string(data)
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	block := fmt.Sprintf(blockBeginFormat, name) + "\n" + content + fmt.Sprintf(blockEndFormat, name)
+
+	var updated string
+	if start, end, ok := findBlock(text, name); ok {
+		updated = text[:start] + block + text[end:]
+	} else {
+		switch {
+		case text == "":
+		case strings.HasSuffix(text, "\n\n"):
+		case strings.HasSuffix(text, "\n"):
+			text += "\n"
+		default:
+			text += "\n\n"
+		}
+		updated = text + block + "\n"
+	}
+
+	if updated == string(data) {
+		return false, nil
+	}
+
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := fsutil.WriteFileAtomic(path, []byte(updated), perm); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findBlock returns the byte range of the named block from its begin marker to the end of its end marker
+func findBlock(text, name string) (int, int, bool) {
+	begin := fmt.Sprintf(blockBeginFormat, name)
+	end := fmt.Sprintf(blockEndFormat, name)
+
+	start := strings.Index(text, begin)
+	if start < 0 {
+		return 0, 0, false
+	}
+	offset := strings.Index(text[start:], end)
+	if offset < 0 {
+		return 0, 0, false
+	}
+	return start, start + offset + len(end), true
+}
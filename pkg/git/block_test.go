@@ -0,0 +1,80 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitattributes")
+	user := "# my rules\n*.psd filter=lfs\n"
+	if err := os.WriteFile(path, []byte(user), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	changed, err := WriteBlock(path, "smart-merge", "*.unity merge=unityyamlmerge")
+	if err != nil {
+		t.Fatalf("WriteBlock() error = %v", err)
+	}
+	if !changed {
+		t.Error("WriteBlock() should report a change when appending")
+	}
+
+	want := user + "\n# >>> uniforge: smart-merge >>>\n*.unity merge=unityyamlmerge\n# <<< uniforge: smart-merge <<<\n"
+	assertFileContent(t, path, want)
+
+	// Writing the same content again is a no-op
+	changed, err = WriteBlock(path, "smart-merge", "*.unity merge=unityyamlmerge\n")
+	if err != nil {
+		t.Fatalf("WriteBlock() error = %v", err)
+	}
+	if changed {
+		t.Error("WriteBlock() should not report a change for identical content")
+	}
+
+	// Updating replaces only the block and keeps lines added after it
+	if err := os.WriteFile(path, []byte(want+"*.wav filter=lfs\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := WriteBlock(path, "smart-merge", "*.prefab merge=unityyamlmerge"); err != nil {
+		t.Fatalf("WriteBlock() error = %v", err)
+	}
+	want = user + "\n# >>> uniforge: smart-merge >>>\n*.prefab merge=unityyamlmerge\n# <<< uniforge: smart-merge <<<\n*.wav filter=lfs\n"
+	assertFileContent(t, path, want)
+
+	content, found, err := ReadBlock(path, "smart-merge")
+	if err != nil || !found {
+		t.Fatalf("ReadBlock() = %q, %v, %v", content, found, err)
+	}
+	if content != "*.prefab merge=unityyamlmerge\n" {
+		t.Errorf("ReadBlock() = %q, want %q", content, "*.prefab merge=unityyamlmerge\n")
+	}
+}
+
+func TestReadBlock_Missing(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, found, err := ReadBlock(filepath.Join(dir, "missing"), "x"); err != nil || found {
+		t.Errorf("ReadBlock() on missing file = %v, %v, want not found", found, err)
+	}
+
+	path := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(path, []byte("Library/\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, found, err := ReadBlock(path, "x"); err != nil || found {
+		t.Errorf("ReadBlock() without block = %v, %v, want not found", found, err)
+	}
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != want {
+		t.Errorf("file content = %q, want %q", string(data), want)
+	}
+}
@@ -0,0 +1,50 @@
+// Package git wraps the git command line and uniforge-managed blocks in git files
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RepoRoot returns the top-level directory of the git work tree containing dir
+func RepoRoot(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %s", dir)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ConfigGet returns a value from the repository's local git config, or "" if unset
+func ConfigGet(repoDir, key string) (string, error) {
+	out, err := exec.Command("git", "-C", repoDir, "config", "--local", "--get", key).Output()
+	if err != nil {
+		// Exit code 1 means the key is not set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read git config %s: %w", key, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ConfigSet writes a value to the repository's local git config (.git/config)
+func ConfigSet(repoDir, key, value string) error {
+	out, err := exec.Command("git", "-C", repoDir, "config", "--local", key, value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to set git config %s: %w: %s", key, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// LFSInstall enables Git LFS hooks for the repository
+func LFSInstall(repoDir string) error {
+	out, err := exec.Command("git", "-C", repoDir, "lfs", "install", "--local").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to enable Git LFS: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package hooks runs user-configured notifications when long operations finish
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Event names (configured as hooks.on_<name>_complete)
+const (
+	EventInstall = "install"
+	EventBuild   = "build"
+	EventRun     = "run"
+	EventTest    = "test"
+)
+
+// Status values reported to hooks
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// Hook is a single hook configuration
+type Hook struct {
+	Command string `mapstructure:"command"` // Shell command to execute
+	Webhook string `mapstructure:"webhook"` // Slack or Discord incoming webhook URL
+}
+
+// IsEmpty returns true if nothing is configured
+func (h Hook) IsEmpty() bool {
+	return h.Command == "" && h.Webhook == ""
+}
+
+// Event describes a finished operation
+type Event struct {
+	Name     string        // install, build, run, test
+	Subject  string        // e.g. Unity version or project name
+	Status   string        // success or failure
+	Duration time.Duration // Wall-clock duration of the operation
+	Error    string        // Error message on failure
+}
+
+// NewEvent creates an Event from the operation result
+func NewEvent(name, subject string, start time.Time, err error) Event {
+	e := Event{
+		Name:     name,
+		Subject:  subject,
+		Status:   StatusSuccess,
+		Duration: time.Since(start).Round(time.Second),
+	}
+	if err != nil {
+		e.Status = StatusFailure
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// Message returns a human-readable summary of the event
+func (e Event) Message() string {
+	icon := "✅"
+	if e.Status == StatusFailure {
+		icon = "❌"
+	}
+
+	msg := fmt.Sprintf("%s uniforge %s %s", icon, e.Name, e.Status)
+	if e.Subject != "" {
+		msg += ": " + e.Subject
+	}
+	msg += fmt.Sprintf(" (%s)", e.Duration)
+	if e.Error != "" {
+		msg += "\n" + e.Error
+	}
+	return msg
+}
+
+// Run executes the hook for the event. Both command and webhook run when configured.
+func Run(hook Hook, e Event) error {
+	var errs []string
+
+	if hook.Command != "" {
+		if err := runCommand(hook.Command, e); err != nil {
+			errs = append(errs, fmt.Sprintf("command: %v", err))
+		}
+	}
+
+	if hook.Webhook != "" {
+		if err := postWebhook(hook.Webhook, e); err != nil {
+			errs = append(errs, fmt.Sprintf("webhook: %v", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("hook on_%s_complete failed: %s", e.Name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Env returns the environment variables passed to hook commands
+func (e Event) Env() []string {
+	return []string{
+		"UNIFORGE_HOOK_EVENT=" + e.Name,
+		"UNIFORGE_HOOK_SUBJECT=" + e.Subject,
+		"UNIFORGE_HOOK_STATUS=" + e.Status,
+		fmt.Sprintf("UNIFORGE_HOOK_DURATION=%d", int(e.Duration.Seconds())),
+		"UNIFORGE_HOOK_ERROR=" + e.Error,
+	}
+}
+
+func runCommand(command string, e Event) error {
+	ui.Debug("Running hook command", "event", e.Name, "command", command)
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), e.Env()...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// WebhookPayload builds the JSON body for the webhook URL (Discord uses "content", Slack "text")
+func WebhookPayload(webhookURL string, e Event) ([]byte, error) {
+	return messagePayload(webhookURL, e.Message())
+}
+
+func messagePayload(webhookURL, message string) ([]byte, error) {
+	key := "text"
+	if u, err := url.Parse(webhookURL); err == nil {
+		host := strings.ToLower(u.Hostname())
+		if strings.HasSuffix(host, "discord.com") || strings.HasSuffix(host, "discordapp.com") {
+			key = "content"
+		}
+	}
+	return json.Marshal(map[string]string{key: message})
+}
+
+func postWebhook(webhookURL string, e Event) error {
+	ui.Debug("Posting hook webhook", "event", e.Name)
+	return PostMessage(webhookURL, e.Message())
+}
+
+// PostMessage posts a plain message to a Slack or Discord incoming webhook
+func PostMessage(webhookURL, message string) error {
+	body, err := messagePayload(webhookURL, message)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewEvent(t *testing.T) {
+	start := time.Now().Add(-90 * time.Second)
+
+	e := NewEvent(EventInstall, "2022.3.60f1", start, nil)
+	if e.Status != StatusSuccess || e.Error != "" {
+		t.Errorf("Expected success event, got %+v", e)
+	}
+	if e.Duration < 89*time.Second {
+		t.Errorf("Expected duration of about 90s, got %s", e.Duration)
+	}
+
+	e = NewEvent(EventTest, "MyGame", start, errors.New("2 tests failed"))
+	if e.Status != StatusFailure || e.Error != "2 tests failed" {
+		t.Errorf("Expected failure event, got %+v", e)
+	}
+	if !strings.Contains(e.Message(), "uniforge test failure: MyGame") {
+		t.Errorf("Unexpected message: %s", e.Message())
+	}
+}
+
+func TestWebhookPayload(t *testing.T) {
+	e := Event{Name: EventBuild, Subject: "MyGame", Status: StatusSuccess, Duration: time.Minute}
+
+	tests := []struct {
+		url string
+		key string
+	}{
+		{"https://hooks.slack.com/services/T000/B000/XXX", "text"},
+		{"https://discord.com/api/webhooks/123/abc", "content"},
+		{"https://example.com/hook", "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			body, err := WebhookPayload(tt.url, e)
+			if err != nil {
+				t.Fatalf("WebhookPayload failed: %v", err)
+			}
+			var payload map[string]string
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if payload[tt.key] == "" {
+				t.Errorf("Expected %q key in payload, got %v", tt.key, payload)
+			}
+		})
+	}
+}
+
+func TestRun_Webhook(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	e := Event{Name: EventInstall, Subject: "2022.3.60f1", Status: StatusSuccess, Duration: time.Second}
+	if err := Run(Hook{Webhook: server.URL}, e); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !strings.Contains(received, "2022.3.60f1") {
+		t.Errorf("Expected payload to mention subject, got %s", received)
+	}
+}
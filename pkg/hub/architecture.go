@@ -0,0 +1,81 @@
+package hub
+
+import (
+	"runtime"
+	"strings"
+)
+
+// architectureNames maps the names editors' architectures appear under (Hub's
+// files, folder suffixes, GOARCH) to the names Unity Hub uses
+var architectureNames = map[string]string{
+	"x86_64":        "x86_64",
+	"x64":           "x86_64",
+	"amd64":         "x86_64",
+	"intel":         "x86_64",
+	"arm64":         "arm64",
+	"aarch64":       "arm64",
+	"apple-silicon": "arm64",
+	"apple silicon": "arm64",
+}
+
+// hostArchitecture returns the architecture of this machine as Unity Hub names it
+func hostArchitecture() string {
+	return normalizeArchitecture(runtime.GOARCH)
+}
+
+// normalizeArchitecture returns Unity Hub's name of arch, the host
+// architecture when arch is empty and arch itself when it is unknown
+func normalizeArchitecture(arch string) string {
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	if arch == "" {
+		return hostArchitecture()
+	}
+	if name, ok := architectureNames[arch]; ok {
+		return name
+	}
+	return arch
+}
+
+// splitEditorFolder splits the name of an editor's version directory into the
+// version and the architecture suffix Unity Hub adds when it installs the same
+// version for a second architecture, e.g. "2022.3.60f1-x86_64". The
+// architecture is "" for a plain version.
+func splitEditorFolder(name string) (version, arch string, ok bool) {
+	if isValidUnityVersion(name) {
+		return name, "", true
+	}
+	version, suffix, found := strings.Cut(name, "-")
+	if !found || !isValidUnityVersion(version) {
+		return "", "", false
+	}
+	arch, ok = architectureNames[strings.ToLower(suffix)]
+	if !ok {
+		return "", "", false
+	}
+	return version, arch, true
+}
+
+// editorKey identifies an installed editor: the same version can be installed
+// once per architecture
+func editorKey(e EditorInfo) string {
+	return e.Version + "/" + normalizeArchitecture(e.Architecture)
+}
+
+// findEditor returns the editor of version, preferring the one matching the
+// host architecture when several are installed
+func findEditor(editors []EditorInfo, version string) (EditorInfo, bool) {
+	var found EditorInfo
+	ok := false
+	for _, e := range editors {
+		if e.Version != version {
+			continue
+		}
+		if normalizeArchitecture(e.Architecture) == hostArchitecture() {
+			return e, true
+		}
+		if !ok {
+			found, ok = e, true
+		}
+	}
+	return found, ok
+}
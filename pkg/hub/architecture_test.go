@@ -0,0 +1,89 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSplitEditorFolder(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		arch    string
+		ok      bool
+	}{
+		{name: "2022.3.60f1", version: "2022.3.60f1", ok: true},
+		{name: "2022.3.60f1-x86_64", version: "2022.3.60f1", arch: "x86_64", ok: true},
+		{name: "2022.3.60f1-arm64", version: "2022.3.60f1", arch: "arm64", ok: true},
+		{name: "6000.0.23f1-Intel", version: "6000.0.23f1", arch: "x86_64", ok: true},
+		{name: "2022.3.60f1-backup"},
+		{name: "notaversion-x86_64"},
+	}
+	for _, tt := range tests {
+		version, arch, ok := splitEditorFolder(tt.name)
+		if version != tt.version || arch != tt.arch || ok != tt.ok {
+			t.Errorf("splitEditorFolder(%q) = %q, %q, %v; want %q, %q, %v", tt.name, version, arch, ok, tt.version, tt.arch, tt.ok)
+		}
+	}
+}
+
+func TestListInstalledEditorsArchitectures(t *testing.T) {
+	installPath := t.TempDir()
+	for _, folder := range []string{"2022.3.60f1", "2022.3.60f1-x86_64", "2022.3.60f1-arm64", "6000.0.23f1"} {
+		editor := EditorPathIn(filepath.Join(installPath, folder))
+		if err := os.MkdirAll(filepath.Dir(editor), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(editor, []byte("fake"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Hub lists 6000.0.23f1 with one folder per architecture
+	hubDir := t.TempDir()
+	editorsJSON := `{"schema_version": "v2", "data": [
+		{"version": "6000.0.23f1", "architecture": "arm64", "location": [
+			"/Editors/6000.0.23f1/Unity.app",
+			"/Editors/6000.0.23f1-x86_64/Unity.app"
+		]}
+	]}`
+	if err := os.WriteFile(filepath.Join(hubDir, "editors-v2.json"), []byte(editorsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClientWithOptions(ClientOptions{
+		HubDataDir:  hubDir,
+		EditorPaths: []string{installPath},
+		CacheDir:    t.TempDir(),
+	})
+	editors, err := client.ListInstalledEditors()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range editors {
+		got = append(got, e.Version+" "+e.Architecture)
+	}
+	slices.Sort(got)
+	host := hostArchitecture()
+	other := "arm64"
+	if host == "arm64" {
+		other = "x86_64"
+	}
+	want := []string{"2022.3.60f1 arm64", "2022.3.60f1 x86_64", "6000.0.23f1 arm64", "6000.0.23f1 x86_64"}
+	if !slices.Equal(got, want) {
+		t.Errorf("editors = %v, want %v", got, want)
+	}
+
+	// The unsuffixed folder is the host's; findEditor prefers it
+	e, ok := findEditor(editors, "2022.3.60f1")
+	if !ok || e.Architecture != host {
+		t.Errorf("findEditor() = %+v, want the %s editor", e, host)
+	}
+	if e, ok := findEditor([]EditorInfo{{Version: "2022.3.60f1", Architecture: other}}, "2022.3.60f1"); !ok || e.Architecture != other {
+		t.Errorf("findEditor() = %+v, %v; want the only editor", e, ok)
+	}
+}
@@ -0,0 +1,192 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// downloadArchiveURL is Unity's download archive page; replaced in tests
+var downloadArchiveURL = "https://unity.com/releases/editor/archive"
+
+// archiveCacheTTL is how long the scraped archive is reused. Historical
+// releases do not change, so the page is rarely worth fetching again.
+const archiveCacheTTL = 7 * 24 * time.Hour
+
+var (
+	// unityhub://2018.4.36f1/6cd387d23174 links, possibly with JSON-escaped slashes
+	archiveHubLinkPattern = regexp.MustCompile(`unityhub:(?:\\?/){2}(\d+\.\d+\.\d+[abfp]\d+(?:[cx]\d+)?)(?:\\?/)([0-9a-f]{12})\b`)
+	// "version":"2018.4.36f1", ... "shortRevision":"6cd387d23174" in embedded page data
+	archiveJSONPattern = regexp.MustCompile(`"version"\s*:\s*"(\d+\.\d+\.\d+[abfp]\d+(?:[cx]\d+)?)"[^{}]*?"(?:changeset|shortRevision)"\s*:\s*"([0-9a-f]{12})"`)
+)
+
+type archiveCacheData struct {
+	UpdatedAt time.Time           `json:"updatedAt"`
+	Releases  []archiveCacheEntry `json:"releases"`
+}
+
+type archiveCacheEntry struct {
+	Version   string `json:"version"`
+	Changeset string `json:"changeset"`
+}
+
+// FetchArchiveReleases returns the versions and changesets listed in Unity's
+// download archive. The GraphQL API omits some old (pre-2019) versions that
+// the archive still lists. Results are cached for a week.
+func (c *Client) FetchArchiveReleases() ([]UnityRelease, error) {
+	if !c.NoCache {
+		if cache, err := c.loadArchiveCache(); err == nil && time.Since(cache.UpdatedAt) < archiveCacheTTL {
+			ui.Debug("Using cached download archive", "releases", len(cache.Releases))
+			return archiveEntriesToReleases(cache.Releases), nil
+		}
+	}
+
+	span := trace.Start(trace.KindHTTP, "download archive")
+	defer span.End()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(downloadArchiveURL)
+	if err != nil {
+		return nil, errs.New(errs.NetworkUnavailable, "failed to fetch the Unity download archive: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download archive returned HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the download archive: %w", err)
+	}
+
+	entries := parseDownloadArchive(body)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no releases found in the download archive; the page format may have changed")
+	}
+	if err := c.saveArchiveCache(entries); err != nil {
+		ui.Debug("Failed to save download archive cache", "error", err)
+	}
+	return archiveEntriesToReleases(entries), nil
+}
+
+// MergeArchiveReleases adds the download archive's versions missing from
+// releases. Releases already listed only get a changeset if they lack one.
+// A failure to read the archive is logged and releases are returned as-is.
+func (c *Client) MergeArchiveReleases(releases []UnityRelease) []UnityRelease {
+	archived, err := c.FetchArchiveReleases()
+	if err != nil {
+		ui.Warn("Could not read the Unity download archive: %v", err)
+		return releases
+	}
+
+	index := make(map[string]int, len(releases))
+	for i, r := range releases {
+		index[r.Version] = i
+	}
+
+	added := 0
+	for _, r := range archived {
+		if i, ok := index[r.Version]; ok {
+			if releases[i].Changeset == "" {
+				releases[i].Changeset = r.Changeset
+			}
+			continue
+		}
+		index[r.Version] = len(releases)
+		releases = append(releases, r)
+		added++
+	}
+	ui.Debug("Merged download archive releases", "added", added)
+	return releases
+}
+
+// parseDownloadArchive extracts version/changeset pairs from the archive page,
+// from either its unityhub:// links or the JSON data embedded in it
+func parseDownloadArchive(body []byte) []archiveCacheEntry {
+	seen := make(map[string]bool)
+	var entries []archiveCacheEntry
+	for _, pattern := range []*regexp.Regexp{archiveHubLinkPattern, archiveJSONPattern} {
+		for _, m := range pattern.FindAllSubmatch(body, -1) {
+			version := string(m[1])
+			if seen[version] {
+				continue
+			}
+			seen[version] = true
+			entries = append(entries, archiveCacheEntry{Version: version, Changeset: string(m[2])})
+		}
+	}
+	return entries
+}
+
+func archiveEntriesToReleases(entries []archiveCacheEntry) []UnityRelease {
+	releases := make([]UnityRelease, 0, len(entries))
+	for _, e := range entries {
+		stream := archiveStream(e.Version)
+		releases = append(releases, UnityRelease{
+			Version:   e.Version,
+			Changeset: e.Changeset,
+			Stream:    stream,
+			LTS:       stream == "LTS",
+			Archive:   true,
+		})
+	}
+	return releases
+}
+
+// archiveStream guesses the stream of an archived version, which the archive
+// page does not state: x.4 was LTS until 2019, x.3 from 2020 on.
+func archiveStream(version string) string {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return "TECH"
+	}
+	switch {
+	case v.Type == ReleaseAlpha:
+		return "ALPHA"
+	case v.Type == ReleaseBeta:
+		return "BETA"
+	case v.Major >= 2017 && v.Major <= 2019 && v.Minor == 4,
+		v.Major >= 2020 && v.Major < 6000 && v.Minor == 3,
+		v.Major >= 6000 && v.Minor == 0:
+		return "LTS"
+	}
+	return "TECH"
+}
+
+func (c *Client) archiveCacheFilePath() string {
+	return filepath.Join(c.CacheDir(), "archive-releases.json")
+}
+
+func (c *Client) loadArchiveCache() (*archiveCacheData, error) {
+	span := trace.Start(trace.KindCache, "archive cache")
+	defer span.End()
+
+	data, err := os.ReadFile(c.archiveCacheFilePath())
+	if err != nil {
+		return nil, err
+	}
+	var cache archiveCacheData
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+func (c *Client) saveArchiveCache(entries []archiveCacheEntry) error {
+	data, err := json.MarshalIndent(archiveCacheData{UpdatedAt: time.Now(), Releases: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(c.archiveCacheFilePath(), data, 0644)
+}
@@ -0,0 +1,113 @@
+package hub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testArchivePage = `<html><body>
+<a href="unityhub://2018.4.36f1/6cd387d23174">Unity Hub</a>
+<a href="unityhub://2017.1.0f3/472613c02cf7">Unity Hub</a>
+<script id="__NEXT_DATA__">{"releases":[
+  {"version":"2019.4.40f1","shortRevision":"ffc62b691db5","stream":"LTS"},
+  {"version":"5.6.7f1","changeset":"e80cc3114ac1"},
+  {"version":"2018.4.36f1","shortRevision":"6cd387d23174"},
+  {"hubUrl":"unityhub:\/\/2018.3.0b1\/aaaaaaaaaaaa"}
+]}</script>
+</body></html>`
+
+func TestParseDownloadArchive(t *testing.T) {
+	entries := parseDownloadArchive([]byte(testArchivePage))
+	want := map[string]string{
+		"2018.4.36f1": "6cd387d23174",
+		"2017.1.0f3":  "472613c02cf7",
+		"2018.3.0b1":  "aaaaaaaaaaaa",
+		"2019.4.40f1": "ffc62b691db5",
+		"5.6.7f1":     "e80cc3114ac1",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("parseDownloadArchive() = %+v, want %d entries", entries, len(want))
+	}
+	for _, e := range entries {
+		if want[e.Version] != e.Changeset {
+			t.Errorf("%s changeset = %q, want %q", e.Version, e.Changeset, want[e.Version])
+		}
+	}
+}
+
+func TestArchiveStream(t *testing.T) {
+	tests := map[string]string{
+		"2018.4.36f1": "LTS",
+		"2018.3.14f1": "TECH",
+		"2020.3.48f1": "LTS",
+		"2019.3.15f1": "TECH",
+		"6000.0.23f1": "LTS",
+		"2019.1.0b3":  "BETA",
+		"2020.1.0a12": "ALPHA",
+		"5.6.7f1":     "TECH",
+	}
+	for version, want := range tests {
+		if got := archiveStream(version); got != want {
+			t.Errorf("archiveStream(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestMergeArchiveReleases(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(testArchivePage))
+	}))
+	defer server.Close()
+	orig := downloadArchiveURL
+	downloadArchiveURL = server.URL
+	defer func() { downloadArchiveURL = orig }()
+
+	c := &Client{}
+	releases := c.MergeArchiveReleases([]UnityRelease{
+		{Version: "2019.4.40f1", Stream: "LTS", LTS: true},
+		{Version: "2018.4.36f1", Changeset: "fromtheapi00"},
+	})
+	if len(releases) != 5 {
+		t.Fatalf("merged %d releases, want 5: %+v", len(releases), releases)
+	}
+	if releases[0].Changeset != "ffc62b691db5" || releases[0].Archive {
+		t.Errorf("API release = %+v, want changeset filled in", releases[0])
+	}
+	if releases[1].Changeset != "fromtheapi00" {
+		t.Errorf("API changeset replaced: %+v", releases[1])
+	}
+	if r := releases[2]; r.Version != "2017.1.0f3" || !r.Archive || r.Stream != "TECH" {
+		t.Errorf("archive release = %+v", r)
+	}
+
+	// Archive releases stay out of the release cache
+	if err := c.SaveCache(nil, releases); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := c.LoadCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.Releases) != 2 {
+		t.Errorf("release cache has %d releases, want 2", len(cache.Releases))
+	}
+
+	// The second fetch uses the archive cache
+	if _, err := c.FetchArchiveReleases(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("archive fetched %d times, want 1", requests)
+	}
+	if _, err := os.Stat(filepath.Join(c.CacheDir(), "archive-releases.json")); err != nil {
+		t.Errorf("archive cache not written: %v", err)
+	}
+}
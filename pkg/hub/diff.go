@@ -0,0 +1,118 @@
+package hub
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// ReleaseDiff describes the releases between two versions of the same stream
+type ReleaseDiff struct {
+	From     string
+	To       string
+	Releases []UnityRelease // Releases after From up to and including To, oldest first
+}
+
+// SecurityAlerts returns the releases in the diff that carry a security alert
+func (d *ReleaseDiff) SecurityAlerts() []UnityRelease {
+	var alerts []UnityRelease
+	for _, r := range d.Releases {
+		if r.SecurityAlert != "" {
+			alerts = append(alerts, r)
+		}
+	}
+	return alerts
+}
+
+// DiffReleases returns the releases between from (exclusive) and to (inclusive).
+// Both versions must belong to the same major.minor stream.
+func DiffReleases(releases []UnityRelease, from, to string) (*ReleaseDiff, error) {
+	if GetMajorMinorFromVersion(from) != GetMajorMinorFromVersion(to) {
+		return nil, fmt.Errorf("versions must be in the same stream: %s and %s", from, to)
+	}
+	if compareVersions(from, to) > 0 {
+		from, to = to, from
+	}
+
+	stream := GetMajorMinorFromVersion(from)
+	diff := &ReleaseDiff{From: from, To: to}
+	for _, r := range releases {
+		if GetMajorMinorFromVersion(r.Version) != stream {
+			continue
+		}
+		if compareVersions(r.Version, from) > 0 && compareVersions(r.Version, to) <= 0 {
+			diff.Releases = append(diff.Releases, r)
+		}
+	}
+
+	sort.Slice(diff.Releases, func(i, j int) bool {
+		return compareVersions(diff.Releases[i].Version, diff.Releases[j].Version) < 0
+	})
+
+	return diff, nil
+}
+
+// FetchReleaseNotes downloads the release notes document at url
+func FetchReleaseNotes(url string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", errs.New(errs.NetworkUnavailable, "failed to fetch release notes: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch release notes: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release notes: %w", err)
+	}
+	return string(body), nil
+}
+
+// ExtractFixesSection returns the bullet lines under the "Fixes" heading of
+// Markdown release notes, stopping at the next heading of the same or higher level
+func ExtractFixesSection(notes string) []string {
+	var fixes []string
+	level := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(notes))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+
+		if strings.HasPrefix(line, "#") {
+			hashes := len(line) - len(strings.TrimLeft(line, "#"))
+			title := strings.TrimSpace(line[hashes:])
+			if level == 0 {
+				if strings.EqualFold(title, "Fixes") {
+					level = hashes
+				}
+				continue
+			}
+			if hashes <= level {
+				break
+			}
+			continue
+		}
+
+		if level == 0 {
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "- ") {
+			fixes = append(fixes, strings.TrimSpace(trimmed[2:]))
+		}
+	}
+
+	return fixes
+}
@@ -0,0 +1,86 @@
+package hub
+
+import "testing"
+
+func TestDiffReleases(t *testing.T) {
+	releases := []UnityRelease{
+		{Version: "2022.3.62f1"},
+		{Version: "2022.3.61f1", SecurityAlert: "Security fix"},
+		{Version: "2022.3.60f1"},
+		{Version: "2022.3.50f1"},
+		{Version: "6000.0.30f1"},
+	}
+
+	diff, err := DiffReleases(releases, "2022.3.50f1", "2022.3.62f1")
+	if err != nil {
+		t.Fatalf("DiffReleases failed: %v", err)
+	}
+
+	want := []string{"2022.3.60f1", "2022.3.61f1", "2022.3.62f1"}
+	if len(diff.Releases) != len(want) {
+		t.Fatalf("Expected %d releases, got %d", len(want), len(diff.Releases))
+	}
+	for i, v := range want {
+		if diff.Releases[i].Version != v {
+			t.Errorf("Releases[%d] = %s, want %s", i, diff.Releases[i].Version, v)
+		}
+	}
+
+	if alerts := diff.SecurityAlerts(); len(alerts) != 1 || alerts[0].Version != "2022.3.61f1" {
+		t.Errorf("Unexpected security alerts: %+v", alerts)
+	}
+
+	// Reversed arguments produce the same range
+	reversed, err := DiffReleases(releases, "2022.3.62f1", "2022.3.50f1")
+	if err != nil {
+		t.Fatalf("DiffReleases reversed failed: %v", err)
+	}
+	if reversed.From != "2022.3.50f1" || len(reversed.Releases) != 3 {
+		t.Errorf("Unexpected reversed diff: %+v", reversed)
+	}
+
+	if _, err := DiffReleases(releases, "2022.3.50f1", "6000.0.30f1"); err == nil {
+		t.Error("Expected error for versions in different streams")
+	}
+}
+
+func TestExtractFixesSection(t *testing.T) {
+	notes := `# 2022.3.62f1
+
+## Known Issues
+
+* Something is broken
+
+## Fixes
+
+* Android: Fixed a crash on startup. (UUM-1234)
+* Editor: Fixed layout glitch.
+
+### Sub heading
+
+- Graphics: Fixed shader compile error.
+
+## API Changes
+
+* Added a new API.
+`
+
+	fixes := ExtractFixesSection(notes)
+	want := []string{
+		"Android: Fixed a crash on startup. (UUM-1234)",
+		"Editor: Fixed layout glitch.",
+		"Graphics: Fixed shader compile error.",
+	}
+	if len(fixes) != len(want) {
+		t.Fatalf("Expected %d fixes, got %d: %v", len(want), len(fixes), fixes)
+	}
+	for i := range want {
+		if fixes[i] != want[i] {
+			t.Errorf("fixes[%d] = %q, want %q", i, fixes[i], want[i])
+		}
+	}
+
+	if fixes := ExtractFixesSection("no headings here"); len(fixes) != 0 {
+		t.Errorf("Expected no fixes, got %v", fixes)
+	}
+}
@@ -0,0 +1,566 @@
+package hub
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// directDownloadBase hosts the official editor and module installers; replaced in tests
+var directDownloadBase = "https://download.unity3d.com/download_unity"
+
+// docsDownloadURL is the offline documentation for a major.minor stream; replaced in tests
+var docsDownloadURL = "https://storage.googleapis.com/docscloudstorage/%s/UnityDocumentation.zip"
+
+// directModule is a module installer published next to the editor
+type directModule struct {
+	file string // Path below <base>/<changeset>/ with %s for the version; empty for the documentation
+	dir  string // Directory below PlaybackEngines; empty when the payload is relative to the version directory
+}
+
+// directPlatform describes the official installers of one OS
+type directPlatform struct {
+	editorFiles  map[string]string // Architecture -> editor installer below <base>/<changeset>/
+	editorBinary string            // Editor executable relative to the version directory
+	engines      string            // PlaybackEngines relative to the version directory
+	modules      map[string]directModule
+	aliases      map[string]string // User-facing names mapped to module IDs with a payload
+	tools        []string          // Tools needed to extract the installers
+}
+
+// directPlatforms lists the platforms that can install without Unity Hub, by GOOS
+var directPlatforms = map[string]directPlatform{
+	"linux": {
+		editorFiles:  map[string]string{"x86_64": "LinuxEditorInstaller/Unity-%s.tar.xz"},
+		editorBinary: filepath.Join("Editor", "Unity"),
+		engines:      filepath.Join("Editor", "Data", "PlaybackEngines"),
+		// Linux Mono standalone support is part of the editor itself
+		modules: map[string]directModule{
+			"android":       {file: "LinuxEditorTargetInstaller/UnitySetup-Android-Support-for-Editor-%s.tar.xz", dir: "AndroidPlayer"},
+			"ios":           {file: "LinuxEditorTargetInstaller/UnitySetup-iOS-Support-for-Editor-%s.tar.xz", dir: "iOSSupport"},
+			"webgl":         {file: "LinuxEditorTargetInstaller/UnitySetup-WebGL-Support-for-Editor-%s.tar.xz", dir: "WebGLSupport"},
+			"windows-mono":  {file: "LinuxEditorTargetInstaller/UnitySetup-Windows-Mono-Support-for-Editor-%s.tar.xz", dir: "WindowsStandaloneSupport"},
+			"mac-mono":      {file: "LinuxEditorTargetInstaller/UnitySetup-Mac-Mono-Support-for-Editor-%s.tar.xz", dir: "MacStandaloneSupport"},
+			"linux-il2cpp":  {file: "LinuxEditorTargetInstaller/UnitySetup-Linux-IL2CPP-Support-for-Editor-%s.tar.xz", dir: "LinuxStandaloneSupport"},
+			"documentation": {}, // Offline documentation zip, shared by all platforms
+		},
+		// The IL2CPP payloads for other desktop platforms do not exist on Linux
+		aliases: map[string]string{"windows": "windows-mono", "mac": "mac-mono"},
+		tools:   []string{"tar", "xz"},
+	},
+	"darwin": macPlatform,
+}
+
+// editorURL returns the download URL of the editor for version, changeset and architecture
+func (p directPlatform) editorURL(version, changeset, arch string) (string, error) {
+	file, ok := p.editorFiles[arch]
+	if !ok {
+		archs := make([]string, 0, len(p.editorFiles))
+		for a := range p.editorFiles {
+			archs = append(archs, a)
+		}
+		sort.Strings(archs)
+		return "", errs.New(errs.Usage, "the editor is only available for %s, not %s", strings.Join(archs, ", "), arch)
+	}
+	return fmt.Sprintf("%s/%s/%s", directDownloadBase, changeset, fmt.Sprintf(file, version)), nil
+}
+
+// moduleURL returns the download URL of a module installer
+func (p directPlatform) moduleURL(version, changeset, moduleID string) (string, error) {
+	m, ok := p.modules[moduleID]
+	if !ok {
+		return "", errs.New(errs.NotFound, "module %s has no installer without Unity Hub (available: %s)", moduleID, strings.Join(p.moduleIDs(), ", "))
+	}
+	if m.file == "" {
+		return fmt.Sprintf(docsDownloadURL, GetMajorMinorFromVersion(version)), nil
+	}
+	return fmt.Sprintf("%s/%s/%s", directDownloadBase, changeset, fmt.Sprintf(m.file, version)), nil
+}
+
+// moduleIDs returns the IDs of the modules that can be installed without Unity Hub
+func (p directPlatform) moduleIDs() []string {
+	ids := make([]string, 0, len(p.modules))
+	for id := range p.modules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// resolveModules maps user-facing module names to module IDs with an installer
+func (p directPlatform) resolveModules(modules []string) ([]string, error) {
+	var ids []string
+	for _, name := range modules {
+		id := strings.ToLower(strings.TrimSpace(name))
+		if alias, ok := p.aliases[id]; ok {
+			id = alias
+		} else if mapped, ok := moduleMap[id]; ok {
+			id = mapped
+		}
+		if _, ok := p.modules[id]; !ok {
+			return nil, errs.New(errs.NotFound, "module %s has no installer without Unity Hub (available: %s)", name, strings.Join(p.moduleIDs(), ", "))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// requireTools checks for the tools used to extract the installers
+func (p directPlatform) requireTools() error {
+	for _, tool := range p.tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			return errs.New(errs.NotInstalled, "%s is required to install without Unity Hub", tool)
+		}
+	}
+	return nil
+}
+
+// usesDirectInstall reports whether installs bypass Unity Hub: on Linux and
+// macOS when NoHub is set or Unity Hub is not installed
+func (c *Client) usesDirectInstall() bool {
+	_, supported := directPlatforms[runtime.GOOS]
+	return supported && (c.NoHub || c.hubPath == "")
+}
+
+// EditorInstallRoot returns the directory editors are installed into without
+// Unity Hub (direct installs, 'editor unpack'): UNIFORGE_EDITOR_BASE_PATH, the
+// Hub install path if known, or the Hub default
+func (c *Client) EditorInstallRoot() (string, error) {
+	if path := os.Getenv("UNIFORGE_EDITOR_BASE_PATH"); path != "" {
+		return path, nil
+	}
+	if path, err := c.GetInstallPath(); err == nil && path != "" {
+		return path, nil
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join("/Applications", "Unity", "Hub", "Editor"), nil
+	case "windows":
+		return filepath.Join(os.Getenv("ProgramFiles"), "Unity", "Hub", "Editor"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, "Unity", "Hub", "Editor"), nil
+}
+
+// installEditorDirect downloads the editor and modules from the official installers
+// and extracts them into <install root>/<version>, the layout Unity Hub uses
+func (c *Client) installEditorDirect(options InstallOptions) error {
+	return c.installEditorWith(directPlatforms[runtime.GOOS], options)
+}
+
+func (c *Client) installEditorWith(p directPlatform, options InstallOptions) error {
+	if options.Changeset == "" {
+		return errs.New(errs.Usage, "installing without Unity Hub needs the changeset of %s (use --changeset)", options.Version)
+	}
+	arch := options.Architecture
+	if arch == "" {
+		arch = defaultDirectArchitecture()
+	}
+	url, err := p.editorURL(options.Version, options.Changeset, arch)
+	if err != nil {
+		return err
+	}
+	moduleIDs, err := p.resolveModules(options.Modules)
+	if err != nil {
+		return err
+	}
+	if err := p.requireTools(); err != nil {
+		return err
+	}
+
+	root, err := c.EditorInstallRoot()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return fmt.Errorf("failed to create install directory: %w", err)
+	}
+
+	// Extract into a staging directory so an interrupted install never looks installed
+	editorDir := filepath.Join(root, options.Version)
+	staging := filepath.Join(root, "."+options.Version+".partial")
+	if err := os.RemoveAll(staging); err != nil {
+		return fmt.Errorf("failed to clean up previous install: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	ui.Debug("Installing editor without Unity Hub", "version", options.Version, "changeset", options.Changeset, "path", editorDir)
+	if err := c.downloadAndExtract(options.Version, url, staging, fmt.Sprintf("Unity %s", options.Version)); err != nil {
+		return err
+	}
+	if !fileExists(filepath.Join(staging, p.editorBinary)) {
+		return fmt.Errorf("unexpected editor installer layout: %s not found", p.editorBinary)
+	}
+
+	if err := c.installDirectModules(p, staging, options.Version, options.Changeset, moduleIDs); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(editorDir); err != nil {
+		return fmt.Errorf("failed to replace existing editor: %w", err)
+	}
+	if err := os.Rename(staging, editorDir); err != nil {
+		return fmt.Errorf("failed to move editor into place: %w", err)
+	}
+
+	c.installPath = root
+	c.installPathInit = true
+	ui.Muted("Installed at %s", editorDir)
+	return nil
+}
+
+// defaultDirectArchitecture returns the editor architecture matching this machine
+func defaultDirectArchitecture() string {
+	if runtime.GOARCH == "arm64" {
+		return "arm64"
+	}
+	return "x86_64"
+}
+
+// installModulesDirect adds modules to an editor installed at editorPath
+func (c *Client) installModulesDirect(version, editorPath string, modules []string) error {
+	p := directPlatforms[runtime.GOOS]
+	moduleIDs, err := p.resolveModules(modules)
+	if err != nil {
+		return err
+	}
+	changeset := c.GetEditorChangeset(editorPath)
+	if changeset == "" {
+		return fmt.Errorf("could not determine the changeset of Unity %s", version)
+	}
+	if err := p.requireTools(); err != nil {
+		return err
+	}
+	return c.installDirectModules(p, EditorDir(editorPath), version, changeset, moduleIDs)
+}
+
+// EditorDir returns the version directory containing the editor at editorPath
+// (Unity.app, Editor/Unity.exe or Editor/Unity)
+func EditorDir(editorPath string) string {
+	switch {
+	case strings.HasSuffix(editorPath, ".app"):
+		return filepath.Dir(editorPath)
+	case strings.EqualFold(filepath.Base(editorPath), "Unity.exe"):
+		return filepath.Dir(filepath.Dir(editorPath))
+	}
+	return editorRootDir(editorPath)
+}
+
+// EditorPathIn returns the editor executable (Unity.app on macOS) of the
+// version directory dir, as Unity Hub lays it out
+func EditorPathIn(dir string) string {
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(dir, "Unity.app")
+	case "windows":
+		return filepath.Join(dir, "Editor", "Unity.exe")
+	}
+	return filepath.Join(dir, "Editor", "Unity")
+}
+
+// installDirectModules downloads module installers into the editor at editorDir
+// and records them in modules.json
+func (c *Client) installDirectModules(p directPlatform, editorDir, version, changeset string, moduleIDs []string) error {
+	for _, id := range moduleIDs {
+		url, err := p.moduleURL(version, changeset, id)
+		if err != nil {
+			return err
+		}
+		staging := filepath.Join(editorDir, ".module-"+id)
+		if err := os.RemoveAll(staging); err != nil {
+			return err
+		}
+		if err := c.downloadAndExtract(version, url, staging, fmt.Sprintf("Module %s", id)); err != nil {
+			_ = os.RemoveAll(staging)
+			return err
+		}
+		err = placeModulePayload(staging, editorDir, p.engines, p.modules[id].dir)
+		_ = os.RemoveAll(staging)
+		if err != nil {
+			return fmt.Errorf("failed to install module %s: %w", id, err)
+		}
+	}
+	if len(moduleIDs) == 0 {
+		return nil
+	}
+	return setModulesInstalled(filepath.Join(editorDir, "modules.json"), moduleIDs, true, c.cachedModuleInfo(version))
+}
+
+// placeModulePayload moves an extracted module into the editor. Payloads either
+// contain the path relative to the version directory (e.g. Editor/Data/PlaybackEngines/...),
+// the module directory itself, or only its contents. An empty moduleDir means the
+// payload always belongs in the version directory.
+func placeModulePayload(staging, editorDir, engines, moduleDir string) error {
+	if moduleDir == "" {
+		return moveTree(staging, editorDir)
+	}
+
+	top := strings.SplitN(filepath.ToSlash(engines), "/", 2)[0]
+	if fileExists(filepath.Join(staging, top)) {
+		return moveTree(staging, editorDir)
+	}
+	entries, err := os.ReadDir(staging)
+	if err != nil {
+		return err
+	}
+	playbackEngines := filepath.Join(editorDir, engines)
+	if len(entries) == 1 && entries[0].IsDir() && entries[0].Name() == moduleDir {
+		return moveTree(filepath.Join(staging, moduleDir), filepath.Join(playbackEngines, moduleDir))
+	}
+	return moveTree(staging, filepath.Join(playbackEngines, moduleDir))
+}
+
+// moveTree merges the directory src into dst, replacing files that exist in both
+func moveTree(src, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		if info, err := os.Lstat(to); err == nil {
+			if entry.IsDir() && info.IsDir() {
+				if err := moveTree(from, to); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := os.RemoveAll(to); err != nil {
+				return err
+			}
+		}
+		if err := os.Rename(from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadAndExtract downloads an installer with progress and extracts it into dir.
+// The installer is kept until the install of version finishes, so a resumed
+// install does not download it again.
+func (c *Client) downloadAndExtract(version, url, dir, title string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	archive := c.downloadPath(url)
+	if err := c.downloadFile(version, url, archive, title); err != nil {
+		return err
+	}
+
+	ui.Debug("Extracting installer", "archive", archive, "dir", dir)
+	if err := extractArchive(archive, dir); err != nil {
+		// Likely a corrupted download, fetch it again next time
+		_ = os.Remove(archive)
+		c.recordDownload(version, DownloadState{URL: url, File: archive})
+		return fmt.Errorf("failed to extract %s: %w", filepath.Base(url), err)
+	}
+	return nil
+}
+
+// downloadPath returns the file an installer is downloaded to. The URL hash
+// keeps installers with the same name apart, e.g. the documentation of
+// different streams.
+func (c *Client) downloadPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.downloadDir(), hex.EncodeToString(sum[:4])+"-"+path.Base(url))
+}
+
+// archiveExt returns the installer extension of url, e.g. ".tar.xz" or ".pkg"
+func archiveExt(url string) string {
+	if strings.HasSuffix(url, ".tar.xz") {
+		return ".tar.xz"
+	}
+	return filepath.Ext(url)
+}
+
+// extractArchive extracts a .tar.xz, .zip, .pkg or .dmg installer into dir
+func extractArchive(archive, dir string) error {
+	span := trace.Start(trace.KindExec, "extract installer", "archive", filepath.Base(archive))
+	defer span.End()
+
+	switch archiveExt(archive) {
+	case ".tar.xz":
+		cmd := exec.Command("tar", "-xJf", archive, "-C", dir)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	case ".zip":
+		return extractZip(archive, dir)
+	case ".pkg":
+		return expandPkg(archive, dir)
+	case ".dmg":
+		return extractDmg(archive, dir)
+	}
+	return fmt.Errorf("unsupported installer format: %s", filepath.Base(archive))
+}
+
+// extractZip extracts a zip archive into dir, keeping file modes
+func extractZip(archive, dir string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	for _, f := range r.File {
+		path := filepath.Join(dir, f.Name)
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", f.Name)
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := extractZipFile(f, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, path string) error {
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	mode := f.Mode().Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// downloadFile downloads url to file, reporting progress under title and
+// recording it in the install queue entry of version. A partial file left by
+// an interrupted install is continued with a range request when the server
+// supports it.
+func (c *Client) downloadFile(version, url, file, title string) error {
+	span := trace.Start(trace.KindHTTP, "download installer", "url", url)
+	defer span.End()
+
+	state := c.downloadState(version, url)
+	var offset int64
+	if info, err := os.Stat(file); err == nil && state.Size > 0 && state.File == file {
+		switch {
+		case info.Size() == state.Size:
+			ui.Muted("Using %s downloaded earlier", path.Base(url))
+			return nil
+		case info.Size() < state.Size:
+			offset = info.Size()
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create download directory: %w", err)
+	}
+
+	ui.Debug("Downloading", "url", url, "offset", offset)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errs.New(errs.NetworkUnavailable, "failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden:
+		return errs.New(errs.NotFound, "%s not found (check the version and changeset)", url)
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		ui.Muted("Resuming download of %s at %s", path.Base(url), ui.FormatBytes(offset))
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		// No range support or a fresh download
+		offset = 0
+		flags |= os.O_TRUNC
+	default:
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	f, err := os.OpenFile(file, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create download file: %w", err)
+	}
+
+	state = DownloadState{URL: url, File: file, Done: offset}
+	if resp.ContentLength >= 0 {
+		state.Size = offset + resp.ContentLength
+	}
+	c.recordDownload(version, state)
+
+	progress := ui.NewProgress("Downloading "+title, state.Size, ui.WithBytes())
+	progress.Set(offset)
+	recorder := &downloadRecorder{client: c, version: version, state: state, saved: time.Now()}
+	_, err = io.Copy(io.MultiWriter(f, progress, recorder), resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	c.recordDownload(version, recorder.state)
+	progress.Done(err)
+	if err != nil {
+		return errs.New(errs.NetworkUnavailable, "failed to download %s: %w", url, err)
+	}
+	return nil
+}
+
+// downloadRecorder counts downloaded bytes and saves them to the install
+// queue every downloadSaveInterval
+type downloadRecorder struct {
+	client  *Client
+	version string
+	state   DownloadState
+	saved   time.Time
+}
+
+func (r *downloadRecorder) Write(b []byte) (int, error) {
+	r.state.Done += int64(len(b))
+	if time.Since(r.saved) >= downloadSaveInterval {
+		r.client.recordDownload(r.version, r.state)
+		r.saved = time.Now()
+	}
+	return len(b), nil
+}
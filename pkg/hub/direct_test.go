@@ -0,0 +1,284 @@
+package hub
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestDirectURLs(t *testing.T) {
+	linux := directPlatforms["linux"]
+	editor, err := linux.editorURL("2022.3.10f1", "ff3792e53c62", "x86_64")
+	if err != nil {
+		t.Fatalf("editorURL() error = %v", err)
+	}
+	if want := "https://download.unity3d.com/download_unity/ff3792e53c62/LinuxEditorInstaller/Unity-2022.3.10f1.tar.xz"; editor != want {
+		t.Errorf("editorURL() = %q, want %q", editor, want)
+	}
+	if _, err := linux.editorURL("2022.3.10f1", "ff3792e53c62", "arm64"); !errors.Is(err, errs.Usage) {
+		t.Errorf("editorURL(arm64) error = %v, want Usage", err)
+	}
+
+	mac, err := macPlatform.editorURL("2022.3.10f1", "ff3792e53c62", "arm64")
+	if err != nil {
+		t.Fatalf("editorURL() error = %v", err)
+	}
+	if want := "https://download.unity3d.com/download_unity/ff3792e53c62/MacEditorInstallerArm64/Unity-2022.3.10f1.pkg"; mac != want {
+		t.Errorf("editorURL() = %q, want %q", mac, want)
+	}
+
+	module, err := linux.moduleURL("2022.3.10f1", "ff3792e53c62", "android")
+	if err != nil {
+		t.Fatalf("moduleURL() error = %v", err)
+	}
+	if want := "https://download.unity3d.com/download_unity/ff3792e53c62/LinuxEditorTargetInstaller/UnitySetup-Android-Support-for-Editor-2022.3.10f1.tar.xz"; module != want {
+		t.Errorf("moduleURL() = %q, want %q", module, want)
+	}
+
+	appletv, err := macPlatform.moduleURL("2022.3.10f1", "ff3792e53c62", "appletv")
+	if err != nil {
+		t.Fatalf("moduleURL() error = %v", err)
+	}
+	if want := "https://download.unity3d.com/download_unity/ff3792e53c62/MacEditorTargetInstaller/UnitySetup-AppleTV-Support-for-Editor-2022.3.10f1.pkg"; appletv != want {
+		t.Errorf("moduleURL() = %q, want %q", appletv, want)
+	}
+
+	docs, err := macPlatform.moduleURL("2022.3.10f1", "ff3792e53c62", "documentation")
+	if err != nil {
+		t.Fatalf("moduleURL() error = %v", err)
+	}
+	if want := "https://storage.googleapis.com/docscloudstorage/2022.3/UnityDocumentation.zip"; docs != want {
+		t.Errorf("moduleURL() = %q, want %q", docs, want)
+	}
+
+	if _, err := linux.moduleURL("2022.3.10f1", "ff3792e53c62", "visionos"); !errors.Is(err, errs.NotFound) {
+		t.Errorf("moduleURL(visionos) error = %v, want NotFound", err)
+	}
+}
+
+func TestResolveDirectModules(t *testing.T) {
+	tests := []struct {
+		goos    string
+		modules []string
+		want    []string
+	}{
+		{"linux", []string{"Android", " webgl", "windows", "mac-mono"}, []string{"android", "webgl", "windows-mono", "mac-mono"}},
+		{"darwin", []string{"ios", "appletv", "windows", "mac", "linux"}, []string{"ios", "appletv", "windows-mono", "mac-il2cpp", "linux-il2cpp"}},
+	}
+	for _, tt := range tests {
+		got, err := directPlatforms[tt.goos].resolveModules(tt.modules)
+		if err != nil {
+			t.Fatalf("%s: resolveModules() error = %v", tt.goos, err)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: resolveModules() = %v, want %v", tt.goos, got, tt.want)
+		}
+	}
+
+	if _, err := directPlatforms["linux"].resolveModules([]string{"android", "appletv"}); !errors.Is(err, errs.NotFound) {
+		t.Errorf("resolveModules(appletv) error = %v, want NotFound", err)
+	}
+}
+
+func TestEditorRootDir(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/opt/unity/2022.3.10f1/Editor/Unity", "/opt/unity/2022.3.10f1"},
+		{"/opt/unity/2022.3.10f1", "/opt/unity/2022.3.10f1"},
+		{"/Applications/Unity/Hub/Editor/2022.3.10f1/Unity.app", "/Applications/Unity/Hub/Editor/2022.3.10f1/Unity.app"},
+	}
+	for _, tt := range tests {
+		if got := editorRootDir(tt.path); got != tt.want {
+			t.Errorf("editorRootDir(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlaceModulePayload(t *testing.T) {
+	linuxEngines := filepath.Join("Editor", "Data", "PlaybackEngines")
+	tests := []struct {
+		name    string
+		engines string
+		payload string // file written below the staging directory
+	}{
+		{"editor relative", linuxEngines, "Editor/Data/PlaybackEngines/AndroidPlayer/module.asset"},
+		{"module directory", linuxEngines, "AndroidPlayer/module.asset"},
+		{"module contents", linuxEngines, "module.asset"},
+		{"mac version relative", "PlaybackEngines", "PlaybackEngines/AndroidPlayer/module.asset"},
+		{"mac module directory", "PlaybackEngines", "AndroidPlayer/module.asset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editorDir := t.TempDir()
+			staging := t.TempDir()
+			writeTestFile(t, filepath.Join(staging, tt.payload), "payload")
+
+			if err := placeModulePayload(staging, editorDir, tt.engines, "AndroidPlayer"); err != nil {
+				t.Fatalf("placeModulePayload() error = %v", err)
+			}
+			want := filepath.Join(editorDir, tt.engines, "AndroidPlayer", "module.asset")
+			if !fileExists(want) {
+				t.Errorf("%s not installed", want)
+			}
+		})
+	}
+}
+
+func TestMoveTreeMerges(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "a", "new.txt"), "new")
+	writeTestFile(t, filepath.Join(src, "a", "same.txt"), "replaced")
+	writeTestFile(t, filepath.Join(dst, "a", "same.txt"), "old")
+	writeTestFile(t, filepath.Join(dst, "a", "keep.txt"), "keep")
+
+	if err := moveTree(src, dst); err != nil {
+		t.Fatalf("moveTree() error = %v", err)
+	}
+
+	for name, want := range map[string]string{"new.txt": "new", "same.txt": "replaced", "keep.txt": "keep"} {
+		data, err := os.ReadFile(filepath.Join(dst, "a", name))
+		if err != nil {
+			t.Errorf("read %s: %v", name, err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", name, data, want)
+		}
+	}
+}
+
+// buildTarXz archives files (path -> content) as .tar.xz and returns the archive
+func buildTarXz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(dir, name), content)
+	}
+	archive := filepath.Join(t.TempDir(), "payload.tar.xz")
+	if output, err := exec.Command("tar", "-cJf", archive, "-C", dir, ".").CombinedOutput(); err != nil {
+		t.Fatalf("tar: %v: %s", err, output)
+	}
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// buildZip archives files (path -> content) as .zip and returns the archive
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestInstallEditorFromTarball(t *testing.T) {
+	linux := directPlatforms["linux"]
+	if err := linux.requireTools(); err != nil {
+		t.Skip(err)
+	}
+
+	editor := buildTarXz(t, map[string]string{
+		"Editor/Unity":                "#!/bin/sh\n",
+		"Editor/Data/Resources/x.txt": "resource",
+	})
+	android := buildTarXz(t, map[string]string{"AndroidPlayer/module.asset": "android"})
+	docs := buildZip(t, map[string]string{"Documentation/en/index.html": "docs"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/abc123/LinuxEditorInstaller/Unity-2022.3.10f1.tar.xz":
+			_, _ = w.Write(editor)
+		case "/abc123/LinuxEditorTargetInstaller/UnitySetup-Android-Support-for-Editor-2022.3.10f1.tar.xz":
+			_, _ = w.Write(android)
+		case "/docs/2022.3/UnityDocumentation.zip":
+			_, _ = w.Write(docs)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	orig := directDownloadBase
+	directDownloadBase = server.URL
+	origDocs := docsDownloadURL
+	docsDownloadURL = server.URL + "/docs/%s/UnityDocumentation.zip"
+	t.Cleanup(func() {
+		directDownloadBase = orig
+		docsDownloadURL = origDocs
+	})
+
+	root := t.TempDir()
+	t.Setenv("UNIFORGE_EDITOR_BASE_PATH", root)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	c := &Client{}
+
+	err := c.installEditorWith(linux, InstallOptions{Version: "2022.3.10f1", Changeset: "abc123", Architecture: "x86_64", Modules: []string{"android", "documentation"}})
+	if err != nil {
+		t.Fatalf("installEditorWith() error = %v", err)
+	}
+
+	editorDir := filepath.Join(root, "2022.3.10f1")
+	for _, path := range []string{
+		filepath.Join(editorDir, "Editor", "Unity"),
+		filepath.Join(editorDir, "Editor", "Data", "PlaybackEngines", "AndroidPlayer", "module.asset"),
+		filepath.Join(editorDir, "Documentation", "en", "index.html"),
+		filepath.Join(editorDir, "modules.json"),
+	} {
+		if !fileExists(path) {
+			t.Errorf("%s not installed", path)
+		}
+	}
+	if fileExists(filepath.Join(root, ".2022.3.10f1.partial")) {
+		t.Error("staging directory was not removed")
+	}
+
+	err = c.installDirectModules(linux, editorDir, "2022.3.10f1", "abc123", []string{"webgl"})
+	if !errors.Is(err, errs.NotFound) {
+		t.Errorf("installDirectModules(webgl) error = %v, want NotFound", err)
+	}
+}
+
+func TestInstallEditorDirectNeedsChangeset(t *testing.T) {
+	c := &Client{}
+	err := c.installEditorWith(directPlatforms["linux"], InstallOptions{Version: "2022.3.10f1"})
+	if !errors.Is(err, errs.Usage) {
+		t.Errorf("installEditorWith() error = %v, want Usage", err)
+	}
+}
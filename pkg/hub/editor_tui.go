@@ -2,8 +2,6 @@ package hub
 
 import (
 	"fmt"
-	"os/exec"
-	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -12,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/neptaco/uniforge/pkg/platform"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
 
@@ -22,6 +21,7 @@ const (
 	stateStreamSelect editorTUIState = iota
 	stateVersionSelect
 	stateInstalledSelect // インストール済みバージョン一覧
+	stateReleaseSelect   // Releases handed over by editor available
 	stateModuleSelect
 	stateInstalling
 	stateComplete
@@ -37,6 +37,7 @@ type editorKeyMap struct {
 	Tab             key.Binding
 	OpenNotes       key.Binding
 	FilterInstalled key.Binding
+	Help            key.Binding
 }
 
 var editorKeys = editorKeyMap{
@@ -70,7 +71,11 @@ var editorKeys = editorKeyMap{
 	),
 	FilterInstalled: key.NewBinding(
 		key.WithKeys("ctrl+l"),
-		key.WithHelp("C-l", "installed"),
+		key.WithHelp("C-l", "show installed versions"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "show this help"),
 	),
 }
 
@@ -123,6 +128,9 @@ var (
 	editorSecurityAlertStyle = lipgloss.NewStyle().
 					Foreground(lipgloss.Color("196")).
 					Bold(true)
+
+	editorErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("196"))
 )
 
 // editorInstallModel is the bubbletea model for editor install TUI
@@ -155,6 +163,7 @@ type editorInstallModel struct {
 	moduleCursor    int
 	selectedModules map[string]bool
 	selectedVersion *UnityRelease
+	freeSpace       int64 // Free bytes on the install volume (-1 if unknown)
 
 	// Install
 	architecture   string
@@ -165,6 +174,19 @@ type editorInstallModel struct {
 
 	// Project counts per version
 	projectCounts map[string]int
+
+	// Installed versions per stream, known once the releases are loaded
+	streamSummaries map[string]streamSummary
+
+	// Browsing a fixed release list (editor available --interactive)
+	browsing bool
+
+	// The key binding overlay is shown
+	showHelp bool
+
+	// Terminal size, 0 until the first tea.WindowSizeMsg
+	width  int
+	height int
 }
 
 // Message types
@@ -211,7 +233,23 @@ func initialEditorInstallModel(client *Client) editorInstallModel {
 	}
 }
 
+// newEditorBrowseModel returns a model listing the given releases, which are
+// already loaded and filtered, instead of the stream selection
+func newEditorBrowseModel(client *Client, releases []UnityRelease) editorInstallModel {
+	m := initialEditorInstallModel(client)
+	m.state = stateReleaseSelect
+	m.browsing = true
+	m.loadingStreams = false
+	m.loadingReleases = false
+	m.allReleases = releases
+	m.filteredReleases = releases
+	return m
+}
+
 func (m editorInstallModel) Init() tea.Cmd {
+	if m.browsing {
+		return nil
+	}
 	return tea.Batch(
 		m.loadStreams(),
 		m.loadAllReleases(),
@@ -280,9 +318,12 @@ func (m editorInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.allReleases = msg.releases
+		m.streamSummaries = summarizeStreams(msg.releases)
 		// Update filtered releases if we're already in version select state
 		if m.state == stateVersionSelect && m.selectedStream != nil {
 			m.updateFilteredReleases()
+		} else if m.state == stateStreamSelect {
+			m.applyFilter()
 		}
 		return m, nil
 
@@ -295,7 +336,29 @@ func (m editorInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		// Leave room for the "> " prompt
+		m.filterInput.Width = max(10, msg.Width-3)
+		return m, nil
+
+	case tea.MouseMsg:
+		if m.showHelp {
+			return m, nil
+		}
+		m.updateMouse(msg)
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+		if key.Matches(msg, editorKeys.Help) && m.state != stateInstalling && m.state != stateComplete {
+			m.showHelp = true
+			return m, nil
+		}
+
 		switch m.state {
 		case stateStreamSelect:
 			return m.updateStreamSelect(msg)
@@ -303,6 +366,8 @@ func (m editorInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateVersionSelect(msg)
 		case stateInstalledSelect:
 			return m.updateInstalledSelect(msg)
+		case stateReleaseSelect:
+			return m.updateReleaseSelect(msg)
 		case stateModuleSelect:
 			return m.updateModuleSelect(msg)
 		case stateComplete:
@@ -313,10 +378,43 @@ func (m editorInstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// isVersionSearchMode returns true if filter looks like a version (2+ dots)
+// updateMouse scrolls the list shown in the current state with the wheel and
+// selects clicked rows
+func (m *editorInstallModel) updateMouse(msg tea.MouseMsg) {
+	// Lists start below the header and a blank line
+	top := 2
+	var cursor *int
+	var total int
+	switch m.state {
+	case stateStreamSelect:
+		if m.loadingStreams {
+			return
+		}
+		if m.isVersionSearchMode() {
+			cursor, total = &m.versionCursor, len(m.filteredReleases)
+		} else {
+			cursor, total = &m.streamCursor, len(m.filteredStreams)
+		}
+	case stateVersionSelect, stateInstalledSelect, stateReleaseSelect:
+		cursor, total = &m.versionCursor, len(m.filteredReleases)
+	case stateModuleSelect:
+		// Below the "Platforms:" label
+		cursor, total, top = &m.moduleCursor, len(m.modules), 3
+	default:
+		return
+	}
+	start, end := listWindow(*cursor, total, m.listRows())
+	*cursor = scrollList(msg, *cursor, total, start, end, top)
+}
+
+// isVersionSearchMode returns true if filter looks like a version (2+ dots),
+// or matches no stream but some versions, e.g. "2260" for "2022.3.60f1"
 func (m editorInstallModel) isVersionSearchMode() bool {
 	filter := m.filterInput.Value()
-	return strings.Count(filter, ".") >= 2
+	if strings.Count(filter, ".") >= 2 {
+		return true
+	}
+	return filter != "" && len(m.filteredStreams) == 0 && !m.loadingReleases && len(m.allReleases) > 0
 }
 
 func (m editorInstallModel) updateStreamSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -384,7 +482,7 @@ func (m editorInstallModel) updateStreamSelect(msg tea.KeyMsg) (tea.Model, tea.C
 		if m.isVersionSearchMode() && len(m.filteredReleases) > 0 {
 			selected := m.filteredReleases[m.versionCursor]
 			if selected.ReleaseNotesURL != "" {
-				_ = openURL(selected.ReleaseNotesURL)
+				_ = platform.OpenURL(selected.ReleaseNotesURL)
 			}
 		}
 		return m, nil
@@ -417,31 +515,21 @@ func (m editorInstallModel) updateStreamSelect(msg tea.KeyMsg) (tea.Model, tea.C
 func (m *editorInstallModel) applyFilter() {
 	filter := m.filterInput.Value()
 
-	if m.isVersionSearchMode() {
-		// Version search mode
-		if !m.loadingReleases {
-			m.filteredReleases = FilterReleasesByVersion(m.allReleases, filter)
-			if m.versionCursor >= len(m.filteredReleases) {
-				m.versionCursor = max(0, len(m.filteredReleases)-1)
-			}
-		}
+	// Stream filter mode
+	if strings.Count(filter, ".") >= 2 {
+		m.filteredStreams = nil
 	} else {
-		// Stream filter mode
-		if filter == "" {
-			m.filteredStreams = m.streams
-		} else {
-			filter = strings.ToLower(filter)
-			var result []VersionStream
-			for _, s := range m.streams {
-				if strings.Contains(strings.ToLower(s.DisplayName), filter) ||
-					strings.Contains(strings.ToLower(s.MajorMinor), filter) {
-					result = append(result, s)
-				}
-			}
-			m.filteredStreams = result
-		}
-		if m.streamCursor >= len(m.filteredStreams) {
-			m.streamCursor = max(0, len(m.filteredStreams)-1)
+		m.filteredStreams = fuzzyFilter(m.streams, filter, func(s VersionStream) string { return s.DisplayName })
+	}
+	if m.streamCursor >= len(m.filteredStreams) {
+		m.streamCursor = max(0, len(m.filteredStreams)-1)
+	}
+
+	// Version search mode
+	if m.isVersionSearchMode() {
+		m.filteredReleases = FilterReleasesByVersion(m.allReleases, filter)
+		if m.versionCursor >= len(m.filteredReleases) {
+			m.versionCursor = max(0, len(m.filteredReleases)-1)
 		}
 	}
 }
@@ -506,7 +594,7 @@ func (m editorInstallModel) updateVersionSelect(msg tea.KeyMsg) (tea.Model, tea.
 		if len(m.filteredReleases) > 0 {
 			selected := m.filteredReleases[m.versionCursor]
 			if selected.ReleaseNotesURL != "" {
-				_ = openURL(selected.ReleaseNotesURL)
+				_ = platform.OpenURL(selected.ReleaseNotesURL)
 			}
 		}
 		return m, nil
@@ -530,12 +618,8 @@ func (m editorInstallModel) updateVersionSelect(msg tea.KeyMsg) (tea.Model, tea.
 	m.filterInput, cmd = m.filterInput.Update(msg)
 
 	// Filter versions within stream
-	filter := m.filterInput.Value()
-	if filter == "" {
-		m.updateFilteredReleases()
-	} else {
-		m.filteredReleases = FilterReleasesByVersion(m.filteredReleases, filter)
-	}
+	m.updateFilteredReleases()
+	m.filteredReleases = FilterReleasesByVersion(m.filteredReleases, m.filterInput.Value())
 	if m.versionCursor >= len(m.filteredReleases) {
 		m.versionCursor = max(0, len(m.filteredReleases)-1)
 	}
@@ -567,14 +651,20 @@ func (m editorInstallModel) selectVersion(selected *UnityRelease) (tea.Model, te
 
 	// Mark installed modules (always check if version is installed)
 	if selected.Installed && selected.InstalledPath != "" {
+		installed := m.client.GetInstalledModules(selected.InstalledPath)
 		for i := range m.modules {
-			m.modules[i].Installed = m.client.IsModuleInstalled(selected.InstalledPath, m.modules[i].ID)
+			m.modules[i].Installed = installed[m.modules[i].ID]
 		}
 	}
 
 	m.moduleCursor = 0
 	m.selectedModules = make(map[string]bool)
 
+	m.freeSpace = -1
+	if _, free, ok := m.client.installVolumeFreeSpace(); ok {
+		m.freeSpace = free
+	}
+
 	return m, nil
 }
 
@@ -604,7 +694,7 @@ func (m editorInstallModel) updateInstalledSelect(msg tea.KeyMsg) (tea.Model, te
 		if len(m.filteredReleases) > 0 {
 			selected := m.filteredReleases[m.versionCursor]
 			if selected.ReleaseNotesURL != "" {
-				_ = openURL(selected.ReleaseNotesURL)
+				_ = platform.OpenURL(selected.ReleaseNotesURL)
 			}
 		}
 		return m, nil
@@ -617,6 +707,57 @@ func (m editorInstallModel) updateInstalledSelect(msg tea.KeyMsg) (tea.Model, te
 	return m, nil
 }
 
+// updateReleaseSelect handles key input for the handed-over release list
+func (m editorInstallModel) updateReleaseSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, editorKeys.Up):
+		if m.versionCursor > 0 {
+			m.versionCursor--
+		}
+		return m, nil
+
+	case key.Matches(msg, editorKeys.Down):
+		if m.versionCursor < len(m.filteredReleases)-1 {
+			m.versionCursor++
+		}
+		return m, nil
+
+	case key.Matches(msg, editorKeys.Enter):
+		if len(m.filteredReleases) > 0 {
+			selected := m.filteredReleases[m.versionCursor]
+			return m.selectVersion(&selected)
+		}
+		return m, nil
+
+	case key.Matches(msg, editorKeys.OpenNotes):
+		if len(m.filteredReleases) > 0 {
+			selected := m.filteredReleases[m.versionCursor]
+			if selected.ReleaseNotesURL != "" {
+				_ = platform.OpenURL(selected.ReleaseNotesURL)
+			}
+		}
+		return m, nil
+
+	case key.Matches(msg, editorKeys.Escape):
+		if m.filterInput.Value() != "" {
+			m.filterInput.SetValue("")
+			m.filteredReleases = m.allReleases
+			m.versionCursor = 0
+			return m, nil
+		}
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	m.filteredReleases = FilterReleasesByVersion(m.allReleases, m.filterInput.Value())
+	if m.versionCursor >= len(m.filteredReleases) {
+		m.versionCursor = max(0, len(m.filteredReleases)-1)
+	}
+	return m, cmd
+}
+
 func (m editorInstallModel) updateModuleSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, editorKeys.Up):
@@ -690,6 +831,8 @@ func (m editorInstallModel) updateModuleSelect(msg tea.KeyMsg) (tea.Model, tea.C
 		if m.selectedStream != nil {
 			m.state = stateVersionSelect
 			m.updateFilteredReleases()
+		} else if m.browsing {
+			m.state = stateReleaseSelect
 		} else {
 			// Came from installed versions list
 			m.state = stateInstalledSelect
@@ -708,6 +851,13 @@ func (m editorInstallModel) View() string {
 		return ""
 	}
 
+	if m.showHelp {
+		return renderKeyHelp("Editor Install Keys", []key.Binding{
+			editorKeys.Up, editorKeys.Down, editorKeys.Enter, editorKeys.Space, editorKeys.Tab,
+			editorKeys.OpenNotes, editorKeys.FilterInstalled, editorKeys.Help, editorKeys.Escape,
+		}, editorCheckboxStyle, editorMutedStyle)
+	}
+
 	switch m.state {
 	case stateStreamSelect:
 		return m.viewStreamSelect()
@@ -715,6 +865,8 @@ func (m editorInstallModel) View() string {
 		return m.viewVersionSelect()
 	case stateInstalledSelect:
 		return m.viewInstalledSelect()
+	case stateReleaseSelect:
+		return m.viewReleaseSelect()
 	case stateModuleSelect:
 		return m.viewModuleSelect()
 	case stateInstalling:
@@ -752,19 +904,14 @@ func (m editorInstallModel) viewStreamSelect() string {
 	}
 
 	// Stream list
-	maxDisplay := 15
-	start := 0
-	if m.streamCursor >= maxDisplay {
-		start = m.streamCursor - maxDisplay + 1
-	}
-	end := min(start+maxDisplay, len(m.filteredStreams))
+	start, end := listWindow(m.streamCursor, len(m.filteredStreams), m.listRows())
 
 	for i := start; i < end; i++ {
 		s := m.filteredStreams[i]
 		line := m.formatStreamLine(s)
 
 		if i == m.streamCursor {
-			b.WriteString(editorSelectedStyle.Render(line))
+			b.WriteString(editorSelectedStyle.Render(ui.MarkSelected(line)))
 		} else {
 			b.WriteString(editorNormalStyle.Render(line))
 		}
@@ -780,7 +927,7 @@ func (m editorInstallModel) viewStreamSelect() string {
 	b.WriteString("\n")
 	counter := fmt.Sprintf("  %d/%d", len(m.filteredStreams), len(m.streams))
 	b.WriteString(editorMutedStyle.Render(counter))
-	help := "  C-l:Installed  Enter:Select  Esc:Quit"
+	help := editorFooter(keyHint(editorKeys.FilterInstalled, "Installed"), keyHint(editorKeys.Enter, "Select"), keyHint(editorKeys.Help, "Help"), keyHint(editorKeys.Escape, "Quit"))
 	b.WriteString(editorMutedStyle.Render(help))
 	b.WriteString("\n")
 
@@ -806,19 +953,14 @@ func (m editorInstallModel) viewVersionSearch(b *strings.Builder) string {
 		b.WriteString(editorMutedStyle.Render("  No matching versions"))
 		b.WriteString("\n")
 	} else {
-		maxDisplay := 15
-		start := 0
-		if m.versionCursor >= maxDisplay {
-			start = m.versionCursor - maxDisplay + 1
-		}
-		end := min(start+maxDisplay, len(m.filteredReleases))
+		start, end := listWindow(m.versionCursor, len(m.filteredReleases), m.listRows())
 
 		for i := start; i < end; i++ {
 			r := m.filteredReleases[i]
 			line := m.formatVersionLine(r)
 
 			if i == m.versionCursor {
-				b.WriteString(editorSelectedStyle.Render(line))
+				b.WriteString(editorSelectedStyle.Render(ui.MarkSelected(line)))
 			} else {
 				b.WriteString(editorNormalStyle.Render(line))
 			}
@@ -832,7 +974,7 @@ func (m editorInstallModel) viewVersionSearch(b *strings.Builder) string {
 		counter := fmt.Sprintf("  %d/%d", len(m.filteredReleases), len(m.allReleases))
 		b.WriteString(editorMutedStyle.Render(counter))
 	}
-	help := "  Enter:Select  o:Notes  Esc:Clear"
+	help := editorFooter(keyHint(editorKeys.Enter, "Select"), keyHint(editorKeys.OpenNotes, "Notes"), keyHint(editorKeys.Help, "Help"), keyHint(editorKeys.Escape, "Clear"))
 	b.WriteString(editorMutedStyle.Render(help))
 	b.WriteString("\n")
 
@@ -847,16 +989,60 @@ func (m editorInstallModel) formatStreamLine(s VersionStream) string {
 	var parts []string
 
 	// Display name with padding
-	name := fmt.Sprintf(" %-20s", s.DisplayName)
+	name := " " + highlightMatches(s.DisplayName, m.filterInput.Value(), 20)
 	parts = append(parts, name)
 
 	// Version count
-	count := editorCountStyle.Render(fmt.Sprintf("(%d versions)", s.TotalCount))
+	count := editorCountStyle.Render(fmt.Sprintf("%-14s", fmt.Sprintf("(%d versions)", s.TotalCount)))
 	parts = append(parts, count)
 
+	// Local installs, so that streams needing attention stand out
+	if summary := m.streamSummaries[s.MajorMinor]; summary.Installed > 0 {
+		badges := editorInstalledStyle.Render(fmt.Sprintf("%d installed", summary.Installed))
+		switch {
+		case summary.RecommendedInstalled:
+			badges += editorRecommendedStyle.Render(" [★]")
+		case summary.HasRecommended:
+			badges += editorRecommendedStyle.Render(" [★ not installed]")
+		}
+		if summary.SecurityAlerts > 0 {
+			badges += editorSecurityAlertStyle.Render(fmt.Sprintf(" [!security: %d]", summary.SecurityAlerts))
+		}
+		parts = append(parts, badges)
+	}
+
 	return strings.Join(parts, " ")
 }
 
+// streamSummary is what the stream list shows about the installed versions of a stream
+type streamSummary struct {
+	Installed            int
+	HasRecommended       bool // The stream has a recommended version
+	RecommendedInstalled bool
+	SecurityAlerts       int // Installed versions with a security alert
+}
+
+// summarizeStreams summarizes the installed releases by major.minor stream
+func summarizeStreams(releases []UnityRelease) map[string]streamSummary {
+	summaries := make(map[string]streamSummary)
+	for _, r := range releases {
+		stream := GetMajorMinorFromVersion(r.Version)
+		summary := summaries[stream]
+		if r.Recommended {
+			summary.HasRecommended = true
+			summary.RecommendedInstalled = summary.RecommendedInstalled || r.Installed
+		}
+		if r.Installed {
+			summary.Installed++
+			if r.SecurityAlert != "" {
+				summary.SecurityAlerts++
+			}
+		}
+		summaries[stream] = summary
+	}
+	return summaries
+}
+
 func (m editorInstallModel) viewVersionSelect() string {
 	if m.selectedStream == nil {
 		return "No stream selected\n"
@@ -875,19 +1061,14 @@ func (m editorInstallModel) viewVersionSelect() string {
 	}
 
 	// Version list
-	maxDisplay := 15
-	start := 0
-	if m.versionCursor >= maxDisplay {
-		start = m.versionCursor - maxDisplay + 1
-	}
-	end := min(start+maxDisplay, len(m.filteredReleases))
+	start, end := listWindow(m.versionCursor, len(m.filteredReleases), m.listRows())
 
 	for i := start; i < end; i++ {
 		r := m.filteredReleases[i]
 		line := m.formatVersionLine(r)
 
 		if i == m.versionCursor {
-			b.WriteString(editorSelectedStyle.Render(line))
+			b.WriteString(editorSelectedStyle.Render(ui.MarkSelected(line)))
 		} else {
 			b.WriteString(editorNormalStyle.Render(line))
 		}
@@ -903,7 +1084,7 @@ func (m editorInstallModel) viewVersionSelect() string {
 	b.WriteString("\n")
 	counter := fmt.Sprintf("  %d/%d", len(m.filteredReleases), m.selectedStream.TotalCount)
 	b.WriteString(editorMutedStyle.Render(counter))
-	help := "  Enter:Select  o:Notes  Esc:Back"
+	help := editorFooter(keyHint(editorKeys.Enter, "Select"), keyHint(editorKeys.OpenNotes, "Notes"), keyHint(editorKeys.Help, "Help"), keyHint(editorKeys.Escape, "Back"))
 	b.WriteString(editorMutedStyle.Render(help))
 	b.WriteString("\n")
 
@@ -930,19 +1111,14 @@ func (m editorInstallModel) viewInstalledSelect() string {
 		b.WriteString(editorMutedStyle.Render("  No installed versions"))
 		b.WriteString("\n")
 	} else {
-		maxDisplay := 15
-		start := 0
-		if m.versionCursor >= maxDisplay {
-			start = m.versionCursor - maxDisplay + 1
-		}
-		end := min(start+maxDisplay, len(m.filteredReleases))
+		start, end := listWindow(m.versionCursor, len(m.filteredReleases), m.listRows())
 
 		for i := start; i < end; i++ {
 			r := m.filteredReleases[i]
 			line := m.formatInstalledVersionLine(r)
 
 			if i == m.versionCursor {
-				b.WriteString(editorSelectedStyle.Render(line))
+				b.WriteString(editorSelectedStyle.Render(ui.MarkSelected(line)))
 			} else {
 				b.WriteString(editorNormalStyle.Render(line))
 			}
@@ -954,13 +1130,52 @@ func (m editorInstallModel) viewInstalledSelect() string {
 	b.WriteString("\n")
 	counter := fmt.Sprintf("  %d installed", len(m.filteredReleases))
 	b.WriteString(editorMutedStyle.Render(counter))
-	help := "  Enter:Select  o:Notes  Esc:Back"
+	help := editorFooter(keyHint(editorKeys.Enter, "Select"), keyHint(editorKeys.OpenNotes, "Notes"), keyHint(editorKeys.Help, "Help"), keyHint(editorKeys.Escape, "Back"))
 	b.WriteString(editorMutedStyle.Render(help))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// viewReleaseSelect displays the handed-over release list
+func (m editorInstallModel) viewReleaseSelect() string {
+	var b strings.Builder
+
+	b.WriteString(editorHeaderStyle.Render("Available Unity Versions"))
+	b.WriteString("\n\n")
+
+	if len(m.filteredReleases) == 0 {
+		b.WriteString(editorMutedStyle.Render("  No matching versions"))
+		b.WriteString("\n")
+	} else {
+		start, end := listWindow(m.versionCursor, len(m.filteredReleases), m.listRows())
+
+		for i := start; i < end; i++ {
+			line := m.formatVersionLine(m.filteredReleases[i])
+			if i == m.versionCursor {
+				b.WriteString(editorSelectedStyle.Render(ui.MarkSelected(line)))
+			} else {
+				b.WriteString(editorNormalStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Help
+	b.WriteString("\n")
+	counter := fmt.Sprintf("  %d/%d", len(m.filteredReleases), len(m.allReleases))
+	b.WriteString(editorMutedStyle.Render(counter))
+	help := editorFooter(keyHint(editorKeys.Enter, "Select"), keyHint(editorKeys.OpenNotes, "Notes"), keyHint(editorKeys.Help, "Help"), keyHint(editorKeys.Escape, "Quit"))
+	b.WriteString(editorMutedStyle.Render(help))
+	b.WriteString("\n")
+
+	// Prompt
+	b.WriteString(promptStyle.Render("> "))
+	b.WriteString(m.filterInput.View())
+
+	return b.String()
+}
+
 // formatInstalledVersionLine formats a version line with project count
 func (m editorInstallModel) formatInstalledVersionLine(r UnityRelease) string {
 	var parts []string
@@ -990,7 +1205,7 @@ func (m editorInstallModel) formatInstalledVersionLine(r UnityRelease) string {
 func (m editorInstallModel) formatVersionLine(r UnityRelease) string {
 	var parts []string
 
-	parts = append(parts, fmt.Sprintf(" %-16s", r.Version))
+	parts = append(parts, " "+highlightMatches(r.Version, m.filterInput.Value(), 16))
 
 	// LTS badge
 	if r.LTS {
@@ -1027,36 +1242,48 @@ func (m editorInstallModel) formatVersionLine(r UnityRelease) string {
 
 	// Download size
 	if r.DownloadSize > 0 {
-		sizeStr := formatBytes(r.DownloadSize)
+		sizeStr := ui.FormatBytes(r.DownloadSize)
 		parts = append(parts, editorSizeStyle.Render(" ("+sizeStr+")"))
 	}
 
 	return strings.Join(parts, "")
 }
 
-// isNewRelease returns true if the release is within 14 days
-func isNewRelease(releaseDate time.Time) bool {
-	return time.Since(releaseDate) < 14*24*time.Hour
-}
+// defaultListRows is the number of rows the lists show before the terminal size is known
+const defaultListRows = 15
 
-// formatBytes formats bytes to human readable format
-func formatBytes(bytes int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-	)
+// listRows returns how many list rows fit on the screen in the current state
+func (m editorInstallModel) listRows() int {
+	if m.height <= 0 {
+		return defaultListRows
+	}
+	// The header and a blank line above the list; a blank line, the help
+	// and the prompt below it
+	chrome := 5
+	if m.state == stateModuleSelect {
+		// The "Platforms:" label, a blank line, the size footer and the help
+		chrome = 6
+	}
+	return max(1, m.height-chrome)
+}
 
-	switch {
-	case bytes >= GB:
-		return fmt.Sprintf("%.1f GB", float64(bytes)/float64(GB))
-	case bytes >= MB:
-		return fmt.Sprintf("%.1f MB", float64(bytes)/float64(MB))
-	case bytes >= KB:
-		return fmt.Sprintf("%.0f KB", float64(bytes)/float64(KB))
-	default:
-		return fmt.Sprintf("%d B", bytes)
+// listWindow returns the rows of a list of total rows to show, rows at a
+// time, scrolled so that the cursor is visible
+func listWindow(cursor, total, rows int) (start, end int) {
+	if cursor >= rows {
+		start = cursor - rows + 1
 	}
+	return start, min(start+rows, total)
+}
+
+// editorFooter joins the key hints shown below the lists
+func editorFooter(hints ...string) string {
+	return "  " + strings.Join(hints, "  ")
+}
+
+// isNewRelease returns true if the release is within 14 days
+func isNewRelease(releaseDate time.Time) bool {
+	return time.Since(releaseDate) < 14*24*time.Hour
 }
 
 func (m editorInstallModel) viewModuleSelect() string {
@@ -1073,11 +1300,12 @@ func (m editorInstallModel) viewModuleSelect() string {
 	b.WriteString(editorMutedStyle.Render("  Platforms:"))
 	b.WriteString("\n")
 
-	for i, mod := range m.modules {
-		line := m.formatModuleLine(mod)
+	start, end := listWindow(m.moduleCursor, len(m.modules), m.listRows())
+	for i := start; i < end; i++ {
+		line := m.formatModuleLine(m.modules[i])
 
 		if i == m.moduleCursor {
-			b.WriteString(editorSelectedStyle.Render(line))
+			b.WriteString(editorSelectedStyle.Render(ui.MarkSelected(line)))
 		} else {
 			b.WriteString(editorNormalStyle.Render(line))
 		}
@@ -1085,13 +1313,42 @@ func (m editorInstallModel) viewModuleSelect() string {
 	}
 
 	b.WriteString("\n")
-	help := "  Space:Toggle  Tab:Toggle All  Enter:Install  Esc:Back"
+	b.WriteString(m.viewModuleSizeFooter())
+	b.WriteString("\n")
+	help := editorFooter(keyHint(editorKeys.Space, "Toggle"), keyHint(editorKeys.Tab, "Toggle All"), keyHint(editorKeys.Enter, "Install"), keyHint(editorKeys.Help, "Help"), keyHint(editorKeys.Escape, "Back"))
 	b.WriteString(editorMutedStyle.Render(help))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// selectedInstallSize returns the total size of the pending install
+func (m editorInstallModel) selectedInstallSize() InstallSize {
+	var modules []string
+	for modID, selected := range m.selectedModules {
+		if selected {
+			modules = append(modules, modID)
+		}
+	}
+	return EstimateInstallSize(m.selectedVersion, modules, !m.selectedVersion.Installed)
+}
+
+// viewModuleSizeFooter renders download/installed totals and free space
+func (m editorInstallModel) viewModuleSizeFooter() string {
+	size := m.selectedInstallSize()
+	footer := fmt.Sprintf("  Download: %s  Installed: %s", ui.FormatBytes(size.Download), ui.FormatBytes(size.Installed))
+
+	if m.freeSpace < 0 {
+		return editorMutedStyle.Render(footer)
+	}
+
+	footer += fmt.Sprintf("  Free: %s", ui.FormatBytes(m.freeSpace))
+	if size.Required() > m.freeSpace {
+		return editorErrorStyle.Render(footer + "  (not enough disk space)")
+	}
+	return editorMutedStyle.Render(footer)
+}
+
 func (m editorInstallModel) formatModuleLine(mod ModuleInfo) string {
 	var checkbox string
 	if mod.Installed {
@@ -1102,17 +1359,14 @@ func (m editorInstallModel) formatModuleLine(mod ModuleInfo) string {
 		checkbox = "[ ]"
 	}
 
-	name := mod.Name
-	if mod.Name == "" {
-		name = mod.ID
-	}
+	name := mod.DisplayName()
 
 	var extras []string
 	if mod.Installed {
 		extras = append(extras, editorDisabledStyle.Render("installed"))
 	}
 	if mod.DownloadSize > 0 {
-		extras = append(extras, editorSizeStyle.Render(formatBytes(mod.DownloadSize)))
+		extras = append(extras, editorSizeStyle.Render(ui.FormatBytes(mod.DownloadSize)))
 	}
 
 	var suffix string
@@ -1156,8 +1410,19 @@ func (m editorInstallModel) viewComplete() string {
 // RunEditorInstallTUI launches the interactive editor install TUI
 func RunEditorInstallTUI(client *Client) error {
 	ui.Debug("Starting editor install TUI")
+	return runEditorTUI(client, initialEditorInstallModel(client))
+}
 
-	p := tea.NewProgram(initialEditorInstallModel(client))
+// RunEditorBrowseTUI lists releases in the editor install TUI, so one can be
+// picked and installed with its modules
+func RunEditorBrowseTUI(client *Client, releases []UnityRelease) error {
+	ui.Debug("Starting editor browse TUI", "releases", len(releases))
+	return runEditorTUI(client, newEditorBrowseModel(client, releases))
+}
+
+// runEditorTUI runs the model and then the install it was left with
+func runEditorTUI(client *Client, initial editorInstallModel) error {
+	p := tea.NewProgram(initial, tuiProgramOptions()...)
 	m, err := p.Run()
 	if err != nil {
 		return err
@@ -1177,6 +1442,10 @@ func RunEditorInstallTUI(client *Client) error {
 
 	// Execute pending install after TUI has exited
 	if model.pendingInstall != nil {
+		if err := client.CheckDiskSpace(model.selectedInstallSize()); err != nil {
+			return err
+		}
+
 		ui.Info("Installing Unity %s...", model.pendingInstall.Version)
 		if len(model.pendingInstall.Modules) > 0 {
 			ui.Muted("Modules: %s", strings.Join(model.pendingInstall.Modules, ", "))
@@ -1203,19 +1472,3 @@ func RunEditorInstallTUI(client *Client) error {
 
 	return nil
 }
-
-// openURL opens a URL in the default browser
-func openURL(url string) error {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-	return cmd.Start()
-}
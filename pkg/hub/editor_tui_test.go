@@ -0,0 +1,197 @@
+package hub
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressKeys(t *testing.T, m editorInstallModel, keys ...tea.KeyMsg) editorInstallModel {
+	t.Helper()
+	for _, k := range keys {
+		next, _ := m.Update(k)
+		m = next.(editorInstallModel)
+	}
+	return m
+}
+
+func TestEditorBrowseModel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	releases := []UnityRelease{
+		{Version: "6000.0.23f1", Changeset: "aaa", LTS: true},
+		{Version: "2022.3.40f1", Changeset: "bbb", LTS: true},
+		{Version: "2022.3.39f1", Changeset: "ccc", LTS: true},
+	}
+	m := newEditorBrowseModel(&Client{}, releases)
+	if m.Init() != nil {
+		t.Error("browse model should not load streams")
+	}
+
+	// Typing narrows the list
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2022")})
+	if len(m.filteredReleases) != 2 {
+		t.Fatalf("filtered = %d releases, want 2", len(m.filteredReleases))
+	}
+
+	// Enter picks the second match and shows its modules; Esc returns to the list
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != stateModuleSelect || m.selectedVersion.Version != "2022.3.39f1" {
+		t.Fatalf("state = %v, selected = %+v", m.state, m.selectedVersion)
+	}
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != stateReleaseSelect {
+		t.Fatalf("state after Esc = %v, want release list", m.state)
+	}
+
+	// Installing leaves the options for after the TUI exits
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.pendingInstall == nil || m.pendingInstall.Version != "2022.3.39f1" || m.pendingInstall.Changeset != "ccc" {
+		t.Errorf("pendingInstall = %+v", m.pendingInstall)
+	}
+
+	// Esc clears the filter first, then quits
+	m = newEditorBrowseModel(&Client{}, releases)
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("6000")}, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.filteredReleases) != 3 || m.quitting {
+		t.Errorf("after clearing: %d releases, quitting = %v", len(m.filteredReleases), m.quitting)
+	}
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.quitting {
+		t.Error("second Esc should quit")
+	}
+}
+
+func TestEditorStreamSelectFuzzy(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := initialEditorInstallModel(&Client{})
+	m.loadingStreams = false
+	m.loadingReleases = false
+	m.streams = []VersionStream{
+		{MajorMinor: "6000.0", DisplayName: "Unity 6 (6000.0) LTS"},
+		{MajorMinor: "2022.3", DisplayName: "2022.3 LTS"},
+	}
+	m.filteredStreams = m.streams
+	m.allReleases = []UnityRelease{
+		{Version: "6000.0.23f1"},
+		{Version: "2022.3.60f1"},
+		{Version: "2022.3.6f1"},
+	}
+
+	// "223" narrows the streams
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("223")})
+	if m.isVersionSearchMode() || len(m.filteredStreams) != 1 || m.filteredStreams[0].MajorMinor != "2022.3" {
+		t.Fatalf("filtered streams = %+v", m.filteredStreams)
+	}
+
+	// "2260" matches no stream, so it searches the versions
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("60")})
+	if !m.isVersionSearchMode() || len(m.filteredReleases) != 1 || m.filteredReleases[0].Version != "2022.3.60f1" {
+		t.Fatalf("filtered releases = %+v", m.filteredReleases)
+	}
+}
+
+func TestEditorMouse(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	releases := []UnityRelease{
+		{Version: "6000.0.23f1"},
+		{Version: "2022.3.40f1"},
+		{Version: "2022.3.39f1"},
+	}
+	m := newEditorBrowseModel(&Client{}, releases)
+	update := func(msg tea.MouseMsg) {
+		next, _ := m.Update(msg)
+		m = next.(editorInstallModel)
+	}
+
+	update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	update(tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+	if m.versionCursor != 2 {
+		t.Errorf("cursor after scrolling down = %d, want 2", m.versionCursor)
+	}
+
+	// The rows start below the header and a blank line
+	update(tea.MouseMsg{Y: 3, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if m.versionCursor != 1 {
+		t.Errorf("cursor after clicking the second row = %d, want 1", m.versionCursor)
+	}
+	if line := strings.Split(m.View(), "\n")[3]; !strings.Contains(line, "2022.3.40f1") {
+		t.Errorf("screen row 3 = %q, want the clicked release", line)
+	}
+
+	// Clicks outside the list and releases are ignored
+	update(tea.MouseMsg{Y: 0, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	update(tea.MouseMsg{Y: 10, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	update(tea.MouseMsg{Y: 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	if m.versionCursor != 1 {
+		t.Errorf("cursor = %d, want 1", m.versionCursor)
+	}
+}
+
+func TestEditorWindowSize(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var releases []UnityRelease
+	for _, v := range []string{"6000.0.30f1", "6000.0.29f1", "6000.0.28f1", "6000.0.27f1", "6000.0.26f1", "6000.0.25f1"} {
+		releases = append(releases, UnityRelease{Version: v})
+	}
+	m := newEditorBrowseModel(&Client{}, releases)
+	if rows := m.listRows(); rows != defaultListRows {
+		t.Errorf("rows before the size is known = %d, want %d", rows, defaultListRows)
+	}
+
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 8})
+	m = next.(editorInstallModel)
+	if m.filterInput.Width != 57 {
+		t.Errorf("filter width = %d, want 57", m.filterInput.Width)
+	}
+
+	// Three rows fit; moving past them scrolls and keeps the footer on screen
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown})
+	view := m.View()
+	if lines := strings.Count(view, "\n") + 1; lines > 8 {
+		t.Errorf("view is %d lines, want <= 8:\n%s", lines, view)
+	}
+	if !strings.Contains(view, "6000.0.26f1") || strings.Contains(view, "6000.0.29f1") || !strings.Contains(view, "Select") {
+		t.Errorf("view should show the cursor row, not the top rows, and the help:\n%s", view)
+	}
+}
+
+func TestStreamSummaries(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := initialEditorInstallModel(&Client{})
+	next, _ := m.Update(streamsLoadedMsg{streams: []VersionStream{
+		{MajorMinor: "6000.0", DisplayName: "Unity 6 (6000.0) LTS", TotalCount: 40},
+		{MajorMinor: "2022.3", DisplayName: "2022.3 LTS", TotalCount: 60},
+		{MajorMinor: "2021.3", DisplayName: "2021.3 LTS", TotalCount: 50},
+	}})
+	next, _ = next.Update(releasesLoadedMsg{releases: []UnityRelease{
+		{Version: "6000.0.40f1", Recommended: true, Installed: true},
+		{Version: "2022.3.62f1", Recommended: true},
+		{Version: "2022.3.60f1", Installed: true, SecurityAlert: "CVE-2025-59489"},
+		{Version: "2022.3.50f1", Installed: true},
+		{Version: "2021.3.45f1"},
+	}})
+	m = next.(editorInstallModel)
+
+	want := map[string]streamSummary{
+		"6000.0": {Installed: 1, HasRecommended: true, RecommendedInstalled: true},
+		"2022.3": {Installed: 2, HasRecommended: true, SecurityAlerts: 1},
+		"2021.3": {},
+	}
+	for stream, summary := range want {
+		if got := m.streamSummaries[stream]; got != summary {
+			t.Errorf("summary of %s = %+v, want %+v", stream, got, summary)
+		}
+	}
+
+	line := m.formatStreamLine(m.streams[1])
+	for _, badge := range []string{"2 installed", "[★ not installed]", "[!security: 1]"} {
+		if !strings.Contains(line, badge) {
+			t.Errorf("stream line %q lacks %q", line, badge)
+		}
+	}
+	if line := m.formatStreamLine(m.streams[2]); strings.Contains(line, "installed") {
+		t.Errorf("stream without installs shows badges: %q", line)
+	}
+}
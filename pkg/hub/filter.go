@@ -0,0 +1,395 @@
+package hub
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// ReleaseFilter is a parsed --filter expression such as
+//
+//	stream==LTS && installed==false && version>=2022.3.40
+//
+// Comparisons (==, !=, <, <=, >, >=, =~ for regular expressions) can be
+// combined with &&, ||, ! and parentheses. A boolean field on its own means
+// field==true.
+type ReleaseFilter struct {
+	expr string
+	eval func(UnityRelease) bool
+}
+
+// filterField describes a UnityRelease field usable in filter expressions
+type filterField struct {
+	kind  string // "version", "string", "bool", "int" or "date"
+	value func(UnityRelease) any
+}
+
+var filterFields = map[string]filterField{
+	"version":      {"version", func(r UnityRelease) any { return r.Version }},
+	"stream":       {"string", func(r UnityRelease) any { return r.Stream }},
+	"changeset":    {"string", func(r UnityRelease) any { return r.Changeset }},
+	"architecture": {"string", func(r UnityRelease) any { return r.Architecture }},
+	"lts":          {"bool", func(r UnityRelease) any { return r.LTS }},
+	"installed":    {"bool", func(r UnityRelease) any { return r.Installed }},
+	"recommended":  {"bool", func(r UnityRelease) any { return r.Recommended }},
+	"security":     {"bool", func(r UnityRelease) any { return r.SecurityAlert != "" }},
+	"major":        {"int", func(r UnityRelease) any { return versionComponent(r.Version, 0) }},
+	"minor":        {"int", func(r UnityRelease) any { return versionComponent(r.Version, 1) }},
+	"date":         {"date", func(r UnityRelease) any { return r.ReleaseDate }},
+}
+
+// filterFieldAliases are alternative spellings of field names
+var filterFieldAliases = map[string]string{
+	"arch": "architecture",
+}
+
+// FilterFields returns the field names usable in filter expressions
+func FilterFields() []string {
+	names := make([]string, 0, len(filterFields))
+	for name := range filterFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseReleaseFilter parses a filter expression
+func ParseReleaseFilter(expr string) (*ReleaseFilter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{expr: expr, tokens: tokens}
+	eval, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok, "unexpected %q", tok.text)
+	}
+	return &ReleaseFilter{expr: expr, eval: eval}, nil
+}
+
+// Match reports whether the release satisfies the filter
+func (f *ReleaseFilter) Match(r UnityRelease) bool {
+	return f.eval(r)
+}
+
+// String returns the expression the filter was parsed from
+func (f *ReleaseFilter) String() string {
+	return f.expr
+}
+
+// versionComponent returns the numeric dot-separated component i of a version, or -1
+func versionComponent(version string, i int) int {
+	parts := strings.Split(version, ".")
+	if i >= len(parts) {
+		return -1
+	}
+	n, err := strconv.Atoi(parts[i])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+type filterTokenKind int
+
+const (
+	tokEOF filterTokenKind = iota
+	tokWord
+	tokOp
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+// tokenizeFilter splits an expression into words, operators and parentheses.
+// Words are bare (2022.3.40f1, LTS) or quoted with " or '.
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, filterToken{tokAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, filterToken{tokOr, "||", i})
+			i += 2
+		case c == '(':
+			tokens = append(tokens, filterToken{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{tokRParen, ")", i})
+			i++
+		case strings.ContainsRune("=!<>", rune(c)):
+			op := string(c)
+			if i+1 < len(expr) && (expr[i+1] == '=' || (c == '=' && expr[i+1] == '~')) {
+				op = expr[i : i+2]
+			}
+			switch op {
+			case "!":
+				tokens = append(tokens, filterToken{tokNot, op, i})
+			case "=":
+				return nil, filterError(expr, i, "use == to compare")
+			default:
+				tokens = append(tokens, filterToken{tokOp, op, i})
+			}
+			i += len(op)
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, filterError(expr, i, "unterminated string")
+			}
+			tokens = append(tokens, filterToken{tokWord, expr[i+1 : i+1+end], i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(expr) && isFilterWordByte(expr[i]) {
+				i++
+			}
+			if i == start {
+				return nil, filterError(expr, i, "unexpected %q", string(c))
+			}
+			tokens = append(tokens, filterToken{tokWord, expr[start:i], start})
+		}
+	}
+	return append(tokens, filterToken{tokEOF, "end of expression", len(expr)}), nil
+}
+
+func isFilterWordByte(c byte) bool {
+	return c < unicode.MaxASCII && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) || strings.IndexByte("._-*:/+", c) >= 0)
+}
+
+func filterError(expr string, pos int, format string, args ...any) error {
+	return errs.WithHint(
+		errs.New(errs.Usage, "invalid filter at column %d: %s", pos+1, fmt.Sprintf(format, args...)),
+		fmt.Sprintf("Fields: %s. Example: --filter \"stream==LTS && version>=2022.3\"", strings.Join(FilterFields(), ", ")))
+}
+
+type filterParser struct {
+	expr   string
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) errorAt(tok filterToken, format string, args ...any) error {
+	return filterError(p.expr, tok.pos, format, args...)
+}
+
+// parseOr parses: and ('||' and)*
+func (p *filterParser) parseOr() (func(UnityRelease) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r UnityRelease) bool { return l(r) || right(r) }
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary ('&&' unary)*
+func (p *filterParser) parseAnd() (func(UnityRelease) bool, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r UnityRelease) bool { return l(r) && right(r) }
+	}
+	return left, nil
+}
+
+// parseUnary parses: '!' unary | '(' or ')' | comparison
+func (p *filterParser) parseUnary() (func(UnityRelease) bool, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNot:
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(r UnityRelease) bool { return !inner(r) }, nil
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorAt(closing, "expected ) but found %q", closing.text)
+		}
+		return inner, nil
+	case tokWord:
+		return p.parseComparison(tok)
+	}
+	return nil, p.errorAt(tok, "expected a field but found %q", tok.text)
+}
+
+// parseComparison parses: field [op value]
+func (p *filterParser) parseComparison(fieldTok filterToken) (func(UnityRelease) bool, error) {
+	name := strings.ToLower(fieldTok.text)
+	if alias, ok := filterFieldAliases[name]; ok {
+		name = alias
+	}
+	field, ok := filterFields[name]
+	if !ok {
+		return nil, p.errorAt(fieldTok, "unknown field %q", fieldTok.text)
+	}
+
+	if p.peek().kind != tokOp {
+		if field.kind != "bool" {
+			return nil, p.errorAt(p.peek(), "%s needs a comparison", name)
+		}
+		return func(r UnityRelease) bool { return field.value(r).(bool) }, nil
+	}
+	opTok := p.next()
+	valueTok := p.next()
+	if valueTok.kind != tokWord {
+		return nil, p.errorAt(valueTok, "expected a value but found %q", valueTok.text)
+	}
+	op, value := opTok.text, valueTok.text
+
+	if op == "=~" {
+		if field.kind == "bool" {
+			return nil, p.errorAt(opTok, "=~ needs a text field")
+		}
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, p.errorAt(valueTok, "invalid regular expression: %v", err)
+		}
+		return func(r UnityRelease) bool { return re.MatchString(fmt.Sprint(field.value(r))) }, nil
+	}
+
+	var compare func(UnityRelease) int
+	switch field.kind {
+	case "version":
+		compare = func(r UnityRelease) int { return compareVersionPrefix(r.Version, value) }
+	case "string":
+		if op != "==" && op != "!=" {
+			return nil, p.errorAt(opTok, "%s can only be compared with == or !=", name)
+		}
+		compare = func(r UnityRelease) int {
+			if strings.EqualFold(field.value(r).(string), value) {
+				return 0
+			}
+			return 1
+		}
+	case "bool":
+		want, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, p.errorAt(valueTok, "%s is true or false, not %q", name, value)
+		}
+		if op != "==" && op != "!=" {
+			return nil, p.errorAt(opTok, "%s can only be compared with == or !=", name)
+		}
+		compare = func(r UnityRelease) int {
+			if field.value(r).(bool) == want {
+				return 0
+			}
+			return 1
+		}
+	case "int":
+		want, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, p.errorAt(valueTok, "%s is a number, not %q", name, value)
+		}
+		compare = func(r UnityRelease) int { return field.value(r).(int) - want }
+	case "date":
+		want, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, p.errorAt(valueTok, "dates are YYYY-MM-DD, not %q", value)
+		}
+		compare = func(r UnityRelease) int {
+			got := field.value(r).(time.Time)
+			day := time.Date(got.Year(), got.Month(), got.Day(), 0, 0, 0, 0, time.UTC)
+			return day.Compare(want)
+		}
+	}
+
+	test := comparisonOp(op)
+	if field.kind == "date" {
+		// Releases without a date match no date comparison
+		return func(r UnityRelease) bool {
+			return !field.value(r).(time.Time).IsZero() && test(compare(r))
+		}, nil
+	}
+	return func(r UnityRelease) bool { return test(compare(r)) }, nil
+}
+
+// comparisonOp turns an operator into a test of a comparison result
+func comparisonOp(op string) func(int) bool {
+	switch op {
+	case "==":
+		return func(c int) bool { return c == 0 }
+	case "!=":
+		return func(c int) bool { return c != 0 }
+	case "<":
+		return func(c int) bool { return c < 0 }
+	case "<=":
+		return func(c int) bool { return c <= 0 }
+	case ">":
+		return func(c int) bool { return c > 0 }
+	default: // ">="
+		return func(c int) bool { return c >= 0 }
+	}
+}
+
+// compareVersionPrefix compares version with a possibly partial version. Only
+// the components given are compared, so "2022.3" equals every 2022.3 release
+// and "2022.3.40" equals 2022.3.40f1.
+func compareVersionPrefix(version, partial string) int {
+	want := strings.Split(partial, ".")
+	got := strings.Split(version, ".")
+	if len(got) > len(want) {
+		got = got[:len(want)]
+	}
+	if last := want[len(want)-1]; len(got) == len(want) && strings.IndexFunc(last, unicode.IsLetter) < 0 {
+		// "40" compares with the patch number of "40f1"
+		if i := strings.IndexFunc(got[len(got)-1], unicode.IsLetter); i >= 0 {
+			got[len(got)-1] = got[len(got)-1][:i]
+		}
+	}
+	return compareVersions(strings.Join(got, "."), partial)
+}
@@ -0,0 +1,108 @@
+package hub
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestReleaseFilter(t *testing.T) {
+	releases := []UnityRelease{
+		{Version: "6000.0.23f1", Stream: "LTS", LTS: true, ReleaseDate: time.Date(2024, 10, 15, 9, 0, 0, 0, time.UTC)},
+		{Version: "2022.3.40f1", Stream: "LTS", LTS: true, Installed: true, ReleaseDate: time.Date(2024, 7, 30, 0, 0, 0, 0, time.UTC)},
+		{Version: "2022.3.39f1", Stream: "LTS", LTS: true, SecurityAlert: "CVE"},
+		{Version: "6000.1.0b2", Stream: "BETA", Recommended: true},
+	}
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"stream==LTS && installed==false && version>=2022.3.40", "6000.0.23f1"},
+		{"stream==lts", "6000.0.23f1,2022.3.40f1,2022.3.39f1"},
+		{"!lts", "6000.1.0b2"},
+		{"version==2022.3", "2022.3.40f1,2022.3.39f1"},
+		{"version<2022.3.40", "2022.3.39f1"},
+		{"version<=2022.3.40", "2022.3.40f1,2022.3.39f1"},
+		{"version>6000.0", "6000.1.0b2"},
+		{"(major==2022 || recommended) && !security", "2022.3.40f1,6000.1.0b2"},
+		{"installed || security", "2022.3.40f1,2022.3.39f1"},
+		{"date>=2024-10-15", "6000.0.23f1"},
+		{"date<2024-10-15", "2022.3.40f1"},
+		{"version=~'b[0-9]+$'", "6000.1.0b2"},
+		{"stream != \"LTS\" && minor==1", "6000.1.0b2"},
+		{"lts==true && security==false && installed", "2022.3.40f1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseReleaseFilter(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseReleaseFilter() error = %v", err)
+			}
+			var got []string
+			for _, r := range releases {
+				if f.Match(r) {
+					got = append(got, r.Version)
+				}
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("matched %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReleaseFilterErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "expected a field"},
+		{"colour==red", `unknown field "colour"`},
+		{"stream=LTS", "use =="},
+		{"stream>LTS", "only be compared with == or !="},
+		{"installed==maybe", "true or false"},
+		{"major==six", "is a number"},
+		{"date>=yesterday", "YYYY-MM-DD"},
+		{"(lts", "expected )"},
+		{"lts &&", "expected a field"},
+		{"version", "needs a comparison"},
+		{"lts lts", `unexpected "lts"`},
+		{"stream=='LTS", "unterminated string"},
+		{"version=~'['", "invalid regular expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseReleaseFilter(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ParseReleaseFilter() error = %v, want %q", err, tt.want)
+			}
+			if !errors.Is(err, errs.Usage) {
+				t.Errorf("error category = %v, want Usage", err)
+			}
+		})
+	}
+}
+
+func TestCompareVersionPrefix(t *testing.T) {
+	tests := []struct {
+		version, partial string
+		want             int
+	}{
+		{"2022.3.40f1", "2022.3.40", 0},
+		{"2022.3.40f1", "2022.3", 0},
+		{"2022.3.40f1", "2022.3.40f1", 0},
+		{"2022.3.40f1", "2022.3.40f2", -1},
+		{"2022.3.41f1", "2022.3.40", 1},
+		{"6000.0.1f1", "2023", 1},
+	}
+	for _, tt := range tests {
+		got := compareVersionPrefix(tt.version, tt.partial)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("compareVersionPrefix(%q, %q) = %d, want sign of %d", tt.version, tt.partial, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package hub
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Scores of FuzzyMatch, after fzf: every matched character scores, gaps cost,
+// and characters at word boundaries or continuing a run score extra
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyGapStart          = -3
+	fuzzyGapExtension      = -1
+	fuzzyBonusBoundary     = 8
+	fuzzyBonusCamel        = 7
+	fuzzyBonusConsecutive  = 4
+	fuzzyFirstCharMultiple = 2
+)
+
+// fuzzyMatchStyle highlights the matched characters in the TUI lists
+var fuzzyMatchStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("76")).
+	Bold(true).
+	Underline(true)
+
+// FuzzyMatch reports whether the characters of pattern appear in text in
+// order (case-insensitive), e.g. "2260" in "2022.3.60f1" or "mygm" in
+// "my-game". It returns the score of the best alignment, higher for matches
+// at word boundaries and in consecutive runs, and the rune indexes of text
+// that matched. An empty pattern matches everything with a score of 0.
+func FuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	t := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(t) {
+		// Lowercasing changed the rune count; compare the lowered text only
+		t = lower
+	}
+	n, m := len(t), len(p)
+	if m > n {
+		return 0, nil, false
+	}
+
+	bonus := make([]int, n)
+	for j := range t {
+		bonus[j] = fuzzyBonusAt(t, j)
+	}
+
+	// best[i][j] is the best score with p[i] matched at t[j], or noMatch;
+	// from[i][j] is where p[i-1] was matched for that score
+	const noMatch = -1 << 30
+	best := make([][]int, m)
+	from := make([][]int, m)
+	for i := range best {
+		best[i] = make([]int, n)
+		from[i] = make([]int, n)
+		for j := range best[i] {
+			best[i][j] = noMatch
+		}
+	}
+
+	for i := 0; i < m; i++ {
+		for j := i; j < n; j++ {
+			if lower[j] != p[i] {
+				continue
+			}
+			if i == 0 {
+				best[i][j] = fuzzyScoreMatch + bonus[j]*fuzzyFirstCharMultiple
+				continue
+			}
+			for k := i - 1; k < j; k++ {
+				if best[i-1][k] == noMatch {
+					continue
+				}
+				s := best[i-1][k] + fuzzyScoreMatch + bonus[j]
+				if k == j-1 {
+					s += fuzzyBonusConsecutive
+				} else {
+					s += fuzzyGapStart + fuzzyGapExtension*(j-k-2)
+				}
+				if s > best[i][j] {
+					best[i][j] = s
+					from[i][j] = k
+				}
+			}
+		}
+	}
+
+	end := -1
+	for j := m - 1; j < n; j++ {
+		if best[m-1][j] != noMatch && (end < 0 || best[m-1][j] > best[m-1][end]) {
+			end = j
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	score = best[m-1][end]
+	positions = make([]int, m)
+	for i, j := m-1, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return score, positions, true
+}
+
+// fuzzyBonusAt is the bonus for matching t[j]: the start of a word (after a
+// separator such as ".", "-" or a space), a camelCase hump or the first digit
+// after letters
+func fuzzyBonusAt(t []rune, j int) int {
+	if j == 0 {
+		return fuzzyBonusBoundary
+	}
+	prev, cur := t[j-1], t[j]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return fuzzyBonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return fuzzyBonusCamel
+	case unicode.IsLetter(prev) && unicode.IsDigit(cur):
+		return fuzzyBonusCamel
+	}
+	return 0
+}
+
+// fuzzyFilter returns the items whose text fuzzy-matches query, best match
+// first. Items that score the same keep their order.
+func fuzzyFilter[T any](items []T, query string, text func(T) string) []T {
+	if query == "" {
+		return items
+	}
+	type scored struct {
+		item  T
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		if score, _, ok := FuzzyMatch(query, text(item)); ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	result := make([]T, len(matches))
+	for i, s := range matches {
+		result[i] = s.item
+	}
+	return result
+}
+
+// highlightMatches renders text with the characters matching query
+// highlighted, padded with spaces to width cells
+func highlightMatches(text, query string, width int) string {
+	pad := strings.Repeat(" ", max(0, width-lipgloss.Width(text)))
+	_, positions, ok := FuzzyMatch(query, text)
+	if !ok || len(positions) == 0 || len([]rune(text)) != len([]rune(strings.ToLower(text))) {
+		return text + pad
+	}
+
+	var b strings.Builder
+	runes := []rune(text)
+	next := 0
+	for i, r := range runes {
+		if next < len(positions) && positions[next] == i {
+			b.WriteString(fuzzyMatchStyle.Render(string(r)))
+			next++
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String() + pad
+}
@@ -0,0 +1,69 @@
+package hub
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		ok        bool
+		positions []int
+	}{
+		{"2260", "2022.3.60f1", true, []int{0, 2, 7, 8}},
+		{"mygm", "my-game", true, []int{0, 1, 3, 5}},
+		{"MyGm", "my-game", true, []int{0, 1, 3, 5}},
+		{"sg", "SpaceGame", true, []int{0, 5}},
+		{"", "anything", true, nil},
+		{"gm", "mg", false, nil},
+		{"2024", "2022.3.60f1", false, nil},
+	}
+	for _, tt := range tests {
+		_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+		if ok != tt.ok || !slices.Equal(positions, tt.positions) {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, %v; want %v, %v", tt.pattern, tt.text, positions, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	score := func(pattern, text string) int {
+		s, _, ok := FuzzyMatch(pattern, text)
+		if !ok {
+			t.Fatalf("FuzzyMatch(%q, %q) did not match", pattern, text)
+		}
+		return s
+	}
+
+	// Consecutive runs beat scattered characters
+	if score("game", "my-game") <= score("game", "green-arm-machine") {
+		t.Error("a consecutive match should outscore a scattered one")
+	}
+	// Word starts beat matches in the middle of words
+	if score("sg", "space-game") <= score("sg", "posting") {
+		t.Error("a match at word boundaries should outscore one inside a word")
+	}
+
+	projects := []ProjectInfo{{Title: "program-manager"}, {Title: "my-game"}, {Title: "mygame-old"}}
+	got := fuzzyFilter(projects, "mygm", func(p ProjectInfo) string { return p.Title })
+	var titles []string
+	for _, p := range got {
+		titles = append(titles, p.Title)
+	}
+	if !slices.Equal(titles, []string{"my-game", "mygame-old"}) {
+		t.Errorf("fuzzyFilter() = %v", titles)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	got := highlightMatches("my-game", "mygm", 10)
+	if !strings.HasSuffix(got, "   ") {
+		t.Errorf("highlightMatches() not padded: %q", got)
+	}
+	if plain := highlightMatches("my-game", "", 10); plain != "my-game   " {
+		t.Errorf("highlightMatches() without query = %q", plain)
+	}
+}
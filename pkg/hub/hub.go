@@ -4,33 +4,53 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/trace"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
 
 type Client struct {
 	hubPath              string
-	installPath          string // Cache for install path
-	installPathInit      bool   // Whether install path has been initialized
-	projectsFileOverride string // For testing: override projects file path
-	NoCache              bool   // Skip reading from cache (still writes to cache)
+	installPath          string         // Cache for install path
+	installPathInit      bool           // Whether install path has been initialized
+	projectsFileOverride string         // For testing: override projects file path
+	openedFileOverride   string         // For testing: override last-opened state file path
+	tagsFileOverride     string         // For testing: override project tags file path
+	queueFileOverride    string         // For testing: override install queue file path
+	watchFileOverride    string         // For testing: override editor watch state file path
+	journalFileOverride  string         // For testing: override operation journal file path
+	NoCache              bool           // Skip reading from cache (still writes to cache)
+	NoHub                bool           // Linux, macOS: install from the official installers instead of Unity Hub
+	options              *ClientOptions // Set by NewClientWithOptions; nil uses the platform defaults
+	IncludeArchive       bool           // GetAllReleases: add old versions from the download archive
+	InstallLockTimeout   time.Duration  // How long installs wait for another uniforge install; 0 fails at once
+
+	snapshotMu sync.Mutex
+	snapshot   *editorSnapshot // Installed editors read by this client; see InvalidateEditors
+
+	projectVersionsMu sync.Mutex
+	projectVersions   map[string]projectVersionFile // ProjectVersion.txt read by this client, by path
 }
 
 type EditorInfo struct {
-	Version      string
-	Path         string
-	Modules      []string
-	Changeset    string // Changeset from Unity executable
-	Architecture string // arm64, x86_64, etc.
-	Manual       bool   // Whether it was manually added
+	Version          string
+	Path             string
+	InstalledModules []string // Hub IDs of the installed modules, sorted
+	Changeset        string   // From version.txt; "" if not found
+	Architecture     string   // arm64, x86_64, etc.
+	Manual           bool     // Whether it was manually added
 }
 
 type ReleaseInfo struct {
@@ -46,27 +66,26 @@ type InstallOptions struct {
 	Architecture string
 }
 
-// moduleFileEntry represents an entry in modules.json
-type moduleFileEntry struct {
-	ID          string `json:"id"`
-	IsInstalled *bool  `json:"isInstalled"` // pointer to detect null vs false
-}
-
 func NewClient() *Client {
 	return &Client{
-		hubPath: findUnityHub(),
+		hubPath:            findUnityHub(),
+		InstallLockTimeout: DefaultInstallLockTimeout,
 	}
 }
 
-func (c *Client) ListInstalledEditors() ([]EditorInfo, error) {
-	// Collect editors from multiple sources
+// listInstalledEditors reads the installed editors from Unity Hub's files, the
+// install paths and the registry, falling back to the Hub CLI
+func (c *Client) listInstalledEditors() ([]EditorInfo, error) {
+	// Collect editors from multiple sources, by version and architecture
 	editorMap := make(map[string]EditorInfo)
 
 	// 1. Read from editors-v2.json (Unity Hub 3.16+)
 	editors, err := c.listEditorsFromFile()
 	if err == nil {
 		for _, e := range editors {
-			editorMap[e.Version] = e
+			if _, exists := editorMap[editorKey(e)]; !exists {
+				editorMap[editorKey(e)] = e
+			}
 		}
 		ui.Debug("Loaded editors from editors-v2.json", "count", len(editors))
 	}
@@ -76,14 +95,21 @@ func (c *Client) ListInstalledEditors() ([]EditorInfo, error) {
 		scannedEditors, err := c.scanInstallPath(path)
 		if err == nil {
 			for _, e := range scannedEditors {
-				if _, exists := editorMap[e.Version]; !exists {
-					editorMap[e.Version] = e
+				if _, exists := editorMap[editorKey(e)]; !exists {
+					editorMap[editorKey(e)] = e
 				}
 			}
 			ui.Debug("Scanned install path", "path", path, "count", len(scannedEditors))
 		}
 	}
 
+	// 3. Editors registered by the Unity installer outside the default paths (Windows)
+	for _, e := range registryEditors() {
+		if _, exists := editorMap[editorKey(e)]; !exists {
+			editorMap[editorKey(e)] = e
+		}
+	}
+
 	// Convert map to slice
 	var result []EditorInfo
 	for _, e := range editorMap {
@@ -96,12 +122,14 @@ func (c *Client) ListInstalledEditors() ([]EditorInfo, error) {
 
 	// Fallback to Unity Hub CLI
 	if c.hubPath == "" {
-		return nil, fmt.Errorf("unity hub not found")
+		return nil, errs.New(errs.HubMissing, "unity hub not found")
 	}
 
 	ui.Debug("Falling back to Unity Hub CLI for editor list")
+	span := trace.Start(trace.KindExec, "Unity Hub editors -i")
 	cmd := exec.Command(c.hubPath, "--", "--headless", "editors", "-i")
 	output, err := cmd.Output()
+	span.End()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list editors: %w", err)
 	}
@@ -109,22 +137,20 @@ func (c *Client) ListInstalledEditors() ([]EditorInfo, error) {
 	return c.parseEditorsList(string(output))
 }
 
-// editorsFileData represents the structure of editors-v2.json
-type editorsFileData struct {
-	SchemaVersion string            `json:"schema_version"`
-	Data          []editorFileEntry `json:"data"`
-}
-
+// editorFileEntry is an editor entry of editors-v2.json
 type editorFileEntry struct {
-	Version      string   `json:"version"`
-	Location     []string `json:"location"`
-	Manual       bool     `json:"manual"`
-	Architecture string   `json:"architecture"`
-	ProductName  string   `json:"productName"`
+	Version      string     `json:"version"`
+	Location     stringList `json:"location"`
+	Manual       bool       `json:"manual"`
+	Architecture string     `json:"architecture"`
+	ProductName  string     `json:"productName"`
 }
 
 // listEditorsFromFile reads installed editors from Unity Hub's editors-v2.json
 func (c *Client) listEditorsFromFile() ([]EditorInfo, error) {
+	span := trace.Start(trace.KindCache, "Unity Hub editors-v2.json")
+	defer span.End()
+
 	editorsFilePath := c.getEditorsFilePath()
 	if editorsFilePath == "" {
 		return nil, fmt.Errorf("could not determine editors file path")
@@ -138,24 +164,44 @@ func (c *Client) listEditorsFromFile() ([]EditorInfo, error) {
 		return nil, fmt.Errorf("failed to read editors file: %w", err)
 	}
 
-	var editorsData editorsFileData
-	if err := json.Unmarshal(data, &editorsData); err != nil {
-		return nil, fmt.Errorf("failed to parse editors file: %w", err)
+	entries, err := decodeHubFile[editorFileEntry]("editors-v2.json", data, editorsSchemaVersion)
+	if err != nil {
+		return nil, err
 	}
 
 	var result []EditorInfo
-	for _, entry := range editorsData.Data {
-		path := ""
-		if len(entry.Location) > 0 {
-			path = entry.Location[0]
+	for _, e := range entries {
+		entry := e.Value
+		// Older Hub versions keyed the entries by version
+		if entry.Version == "" {
+			entry.Version = e.Key
+		}
+		if entry.Version == "" {
+			continue
+		}
+		if len(entry.Location) == 0 {
+			result = append(result, EditorInfo{
+				Version:      entry.Version,
+				Architecture: normalizeArchitecture(entry.Architecture),
+				Manual:       entry.Manual,
+			})
+			continue
 		}
 
-		result = append(result, EditorInfo{
-			Version:      entry.Version,
-			Path:         path,
-			Architecture: entry.Architecture,
-			Manual:       entry.Manual,
-		})
+		// Hub lists the folders of each architecture a version is installed
+		// for; a folder's suffix tells its architecture
+		for _, path := range entry.Location {
+			arch := entry.Architecture
+			if _, suffix, ok := splitEditorFolder(filepath.Base(EditorDir(path))); ok && suffix != "" {
+				arch = suffix
+			}
+			result = append(result, EditorInfo{
+				Version:      entry.Version,
+				Path:         path,
+				Architecture: normalizeArchitecture(arch),
+				Manual:       entry.Manual,
+			})
+		}
 	}
 
 	return result, nil
@@ -168,6 +214,9 @@ func (c *Client) getEditorsFilePath() string {
 
 // getUnityHubBasePath returns the base path for Unity Hub configuration files
 func (c *Client) getUnityHubBasePath() string {
+	if c.options != nil {
+		return c.options.HubDataDir
+	}
 	switch runtime.GOOS {
 	case "darwin":
 		return filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "UnityHub")
@@ -210,6 +259,10 @@ func (c *Client) getEditorInstallPaths() []string {
 		paths = append(paths, secondaryPath)
 	}
 
+	if c.options != nil {
+		return append(paths, c.options.EditorPaths...)
+	}
+
 	// Default install paths per platform
 	switch runtime.GOOS {
 	case "darwin":
@@ -230,6 +283,9 @@ func (c *Client) getEditorInstallPaths() []string {
 
 // scanInstallPath scans a directory for Unity editors
 func (c *Client) scanInstallPath(installPath string) ([]EditorInfo, error) {
+	span := trace.Start(trace.KindScan, "editor install path", "path", installPath)
+	defer span.End()
+
 	if installPath == "" {
 		return nil, fmt.Errorf("empty install path")
 	}
@@ -239,68 +295,42 @@ func (c *Client) scanInstallPath(installPath string) ([]EditorInfo, error) {
 		return nil, fmt.Errorf("failed to read install path: %w", err)
 	}
 
-	var result []EditorInfo
+	var candidates []EditorInfo
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
 		}
 
-		// Check if this looks like a Unity version directory (e.g., 2022.3.60f1)
-		version := entry.Name()
-		if !isValidUnityVersion(version) {
+		// Check if this looks like a Unity version directory (e.g., 2022.3.60f1,
+		// or 2022.3.60f1-x86_64 next to the native editor of that version)
+		version, arch, ok := splitEditorFolder(entry.Name())
+		if !ok {
 			continue
 		}
 
 		// Check if Unity.app exists (macOS) or Unity.exe (Windows)
-		var editorPath string
-		switch runtime.GOOS {
-		case "darwin":
-			editorPath = filepath.Join(installPath, version, "Unity.app")
-		case "windows":
-			editorPath = filepath.Join(installPath, version, "Editor", "Unity.exe")
-		case "linux":
-			editorPath = filepath.Join(installPath, version, "Editor", "Unity")
-		}
-
-		if _, err := os.Stat(editorPath); err != nil {
-			continue
-		}
-
-		result = append(result, EditorInfo{
+		candidates = append(candidates, EditorInfo{
 			Version:      version,
-			Path:         editorPath,
-			Architecture: runtime.GOARCH,
+			Path:         EditorPathIn(filepath.Join(installPath, entry.Name())),
+			Architecture: normalizeArchitecture(arch),
 		})
 	}
 
-	return result, nil
-}
-
-// isValidUnityVersion checks if a string looks like a Unity version
-func isValidUnityVersion(s string) bool {
-	// Unity versions look like: 2022.3.60f1, 6000.3.3f1, etc.
-	// Format: YEAR.MINOR.PATCH[a|b|f|p|x]REVISION
-	if len(s) < 8 {
-		return false
-	}
+	// Stat the executables in parallel; install dirs are often on slow or network drives
+	exists := make([]bool, len(candidates))
+	forEachConcurrent(len(candidates), snapshotConcurrency, func(i int) {
+		_, err := os.Stat(candidates[i].Path)
+		exists[i] = err == nil
+	})
 
-	// Check for at least 2 dots
-	dotCount := 0
-	for _, c := range s {
-		if c == '.' {
-			dotCount++
+	var result []EditorInfo
+	for i, e := range candidates {
+		if exists[i] {
+			result = append(result, e)
 		}
 	}
-	if dotCount < 2 {
-		return false
-	}
-
-	// Check if first character is a digit
-	if s[0] < '0' || s[0] > '9' {
-		return false
-	}
 
-	return true
+	return result, nil
 }
 
 func (c *Client) InstallEditor(version string, modules []string) error {
@@ -310,9 +340,33 @@ func (c *Client) InstallEditor(version string, modules []string) error {
 	})
 }
 
-func (c *Client) InstallEditorWithOptions(options InstallOptions) error {
-	if c.hubPath == "" {
-		return fmt.Errorf("unity hub not found")
+func (c *Client) InstallEditorWithOptions(options InstallOptions) (err error) {
+	defer c.InvalidateEditors()
+	if !c.usesDirectInstall() && c.hubPath == "" {
+		return errs.New(errs.HubMissing, "unity hub not found")
+	}
+
+	unlock, err := c.lockInstall(options.Version)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Record the install so it can be resumed if it is interrupted
+	c.queueInstall(InstallJob{
+		Version:      options.Version,
+		Changeset:    options.Changeset,
+		Architecture: options.Architecture,
+		Modules:      options.Modules,
+		Backend:      c.installBackend(),
+	})
+	defer func() {
+		c.settleInstall(options.Version, err)
+		c.RecordJournal(JournalInstall, options.Version, installDetail(options.Architecture, options.Modules), err)
+	}()
+
+	if c.usesDirectInstall() {
+		return c.installEditorDirect(options)
 	}
 
 	args := []string{"--", "--headless", "install", "--version", options.Version}
@@ -406,10 +460,8 @@ func (c *Client) IsEditorInstalled(version string) (bool, string, error) {
 		return false, "", err
 	}
 
-	for _, editor := range editors {
-		if editor.Version == version {
-			return true, editor.Path, nil
-		}
+	if editor, ok := findEditor(editors, version); ok {
+		return true, editor.Path, nil
 	}
 
 	return false, "", nil
@@ -419,33 +471,9 @@ func (c *Client) IsEditorInstalled(version string) (bool, string, error) {
 // First tries to read from version.txt file, then falls back to running Unity -version
 func (c *Client) GetEditorChangeset(editorPath string) string {
 	// First, try to read from version.txt file (fastest method)
-	var versionFilePath string
-	switch runtime.GOOS {
-	case "darwin":
-		if strings.HasSuffix(editorPath, ".app") {
-			versionFilePath = filepath.Join(editorPath, "Contents", "Resources", "version.txt")
-		} else {
-			versionFilePath = filepath.Join(editorPath, "Unity.app", "Contents", "Resources", "version.txt")
-		}
-	case "windows":
-		// Windows: C:\Program Files\Unity\Hub\Editor\2022.3.20f1\Editor\Data\Resources\version.txt
-		if strings.HasSuffix(editorPath, ".exe") {
-			// If it's already pointing to Unity.exe, go up to find Data folder
-			versionFilePath = filepath.Join(filepath.Dir(editorPath), "Data", "Resources", "version.txt")
-		} else {
-			versionFilePath = filepath.Join(editorPath, "Editor", "Data", "Resources", "version.txt")
-		}
-	case "linux":
-		versionFilePath = filepath.Join(editorPath, "Editor", "Data", "Resources", "version.txt")
-	}
-
-	// Try to read version.txt file
-	if fileExists(versionFilePath) {
-		changeset := c.readChangesetFromVersionFile(versionFilePath)
-		if changeset != "" {
-			ui.Debug("Found changeset from version.txt", "changeset", changeset)
-			return changeset
-		}
+	if changeset := c.editorState(editorPath).changeset; changeset != "" {
+		ui.Debug("Found changeset from version.txt", "changeset", changeset)
+		return changeset
 	}
 
 	// Fallback to running Unity -version
@@ -464,7 +492,7 @@ func (c *Client) GetEditorChangeset(editorPath string) string {
 			unityExec = filepath.Join(editorPath, "Editor", "Unity.exe")
 		}
 	case "linux":
-		unityExec = filepath.Join(editorPath, "Editor", "Unity")
+		unityExec = filepath.Join(editorRootDir(editorPath), "Editor", "Unity")
 	}
 
 	if !fileExists(unityExec) {
@@ -472,8 +500,10 @@ func (c *Client) GetEditorChangeset(editorPath string) string {
 		return ""
 	}
 
+	span := trace.Start(trace.KindExec, "Unity -version")
 	cmd := exec.Command(unityExec, "-version")
 	output, err := cmd.Output()
+	span.End()
 	if err != nil {
 		ui.Debug("Failed to get Unity version", "error", err)
 		return ""
@@ -553,10 +583,12 @@ func (c *Client) GetInstallPath() (string, error) {
 
 	// If defaults don't work, query Unity Hub
 	if c.hubPath == "" {
-		return "", fmt.Errorf("unity hub not found")
+		return "", errs.New(errs.HubMissing, "unity hub not found")
 	}
 
 	ui.Debug("Querying Unity Hub for install path")
+	span := trace.Start(trace.KindExec, "Unity Hub install-path --get")
+	defer span.End()
 	cmd := exec.Command(c.hubPath, "--", "--headless", "install-path", "--get")
 	output, err := cmd.Output()
 	if err != nil {
@@ -570,6 +602,24 @@ func (c *Client) GetInstallPath() (string, error) {
 	return c.installPath, nil
 }
 
+// SetInstallPath changes the directory Unity Hub installs editors into
+func (c *Client) SetInstallPath(path string) error {
+	if c.hubPath == "" {
+		return errs.New(errs.HubMissing, "unity hub not found")
+	}
+
+	ui.Debug("Setting Unity Hub install path", "path", path)
+	cmd := exec.Command(c.hubPath, "--", "--headless", "install-path", "--set", path)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set install path: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	c.installPath = path
+	c.installPathInit = true
+	c.saveInstallPathCache(path)
+	return nil
+}
+
 // Cache file structure
 type installPathCacheData struct {
 	Path      string    `json:"path"`
@@ -578,8 +628,7 @@ type installPathCacheData struct {
 
 // Get cache file path
 func (c *Client) getCacheFilePath() string {
-	tmpDir := os.TempDir()
-	return filepath.Join(tmpDir, "uniforge-install-path.json")
+	return filepath.Join(c.CacheDir(), "install-path.json")
 }
 
 // Load install path from cache file
@@ -596,7 +645,8 @@ func (c *Client) loadInstallPathCache() string {
 
 	var cache installPathCacheData
 	if err := json.Unmarshal(data, &cache); err != nil {
-		ui.Debug("Failed to parse cache file", "error", err)
+		ui.Debug("Discarding corrupted cache file", "error", err)
+		_ = os.Remove(cacheFile)
 		return ""
 	}
 
@@ -624,7 +674,14 @@ func (c *Client) saveInstallPathCache(path string) {
 		return
 	}
 
-	if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+	lock, err := fsutil.Lock(cacheFile, cacheLockTimeout)
+	if err != nil {
+		ui.Debug("Failed to lock cache file", "error", err)
+		return
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	if err := fsutil.WriteFileAtomic(cacheFile, data, 0644); err != nil {
 		ui.Debug("Failed to write cache file", "error", err)
 		return
 	}
@@ -633,6 +690,10 @@ func (c *Client) saveInstallPathCache(path string) {
 }
 
 func (c *Client) getDefaultInstallPaths() []string {
+	if c.options != nil {
+		return c.options.EditorPaths
+	}
+
 	var paths []string
 
 	// Check for custom install path from environment variable
@@ -665,9 +726,11 @@ func (c *Client) getDefaultInstallPaths() []string {
 
 func (c *Client) ListAvailableReleases() ([]ReleaseInfo, error) {
 	if c.hubPath == "" {
-		return nil, fmt.Errorf("unity hub not found")
+		return nil, errs.New(errs.HubMissing, "unity hub not found")
 	}
 
+	span := trace.Start(trace.KindExec, "Unity Hub editors -r")
+	defer span.End()
 	cmd := exec.Command(c.hubPath, "--", "--headless", "editors", "-r")
 	output, err := cmd.Output()
 	if err != nil {
@@ -761,6 +824,10 @@ var moduleMap = map[string]string{
 	"windows":        "windows-il2cpp",
 	"linux":          "linux-il2cpp",
 	"mac":            "mac-il2cpp",
+	"windows-mono":   "windows-mono",
+	"linux-mono":     "linux-mono",
+	"mac-mono":       "mac-mono",
+	"appletv":        "appletv",
 	"documentation":  "documentation",
 	"standardassets": "standardassets",
 	"example":        "example",
@@ -774,6 +841,10 @@ var modulePathMap = map[string]string{
 	"windows-il2cpp": "WindowsStandaloneSupport",
 	"linux-il2cpp":   "LinuxStandaloneSupport",
 	"mac-il2cpp":     "MacStandaloneSupport",
+	"windows-mono":   "WindowsStandaloneSupport",
+	"mac-mono":       "MacStandaloneSupport",
+	"linux-mono":     "LinuxStandaloneSupport",
+	"appletv":        "AppleTVSupport",
 }
 
 func (c *Client) mapModules(modules []string) []string {
@@ -809,12 +880,21 @@ func (c *Client) GetPlaybackEnginesPath(editorPath string) string {
 		// editorPath is the version directory
 		return filepath.Join(editorPath, "Editor", "Data", "PlaybackEngines")
 	case "linux":
-		// Linux: similar to Windows
-		return filepath.Join(editorPath, "Editor", "Data", "PlaybackEngines")
+		// Linux: ~/Unity/Hub/Editor/2022.3.60f1/Editor/Data/PlaybackEngines
+		return filepath.Join(editorRootDir(editorPath), "Editor", "Data", "PlaybackEngines")
 	}
 	return ""
 }
 
+// editorRootDir returns the version directory of a Linux editor, given either
+// that directory or the executable (<version>/Editor/Unity)
+func editorRootDir(editorPath string) string {
+	if filepath.Base(editorPath) == "Unity" && filepath.Base(filepath.Dir(editorPath)) == "Editor" {
+		return filepath.Dir(filepath.Dir(editorPath))
+	}
+	return editorPath
+}
+
 // getModulesFilePath returns the path to modules.json for a given editor
 func (c *Client) getModulesFilePath(editorPath string) string {
 	switch runtime.GOOS {
@@ -833,77 +913,83 @@ func (c *Client) getModulesFilePath(editorPath string) string {
 		}
 		return filepath.Join(editorPath, "modules.json")
 	case "linux":
-		// Linux: similar structure
-		return filepath.Join(editorPath, "modules.json")
+		// Linux: ~/Unity/Hub/Editor/2022.3.60f1/modules.json
+		return filepath.Join(editorRootDir(editorPath), "modules.json")
 	}
 	return ""
 }
 
-// readModulesFile reads and parses modules.json
-func (c *Client) readModulesFile(editorPath string) ([]moduleFileEntry, error) {
-	modulesFilePath := c.getModulesFilePath(editorPath)
-	if modulesFilePath == "" {
-		return nil, fmt.Errorf("could not determine modules file path")
-	}
+// GetInstalledModules returns the Hub module IDs installed for an editor, read
+// in one pass. modules.json decides where it sets isInstalled; otherwise a
+// known module counts as installed when its PlaybackEngines directory exists.
+func (c *Client) GetInstalledModules(editorPath string) map[string]bool {
+	installed := c.editorState(editorPath).installedModules()
+	ui.Debug("Installed modules", "editor", editorPath, "count", len(installed))
+	return installed
+}
 
-	data, err := os.ReadFile(modulesFilePath)
-	if err != nil {
-		return nil, err
+// installedModules returns the module IDs the state reports as installed
+func (state *editorState) installedModules() map[string]bool {
+	installed := make(map[string]bool)
+
+	// Modules whose isInstalled is null fall through to the directory check
+	explicit := make(map[string]bool)
+	for _, m := range state.modules {
+		if m.IsInstalled != nil {
+			explicit[m.ID] = true
+			if *m.IsInstalled {
+				installed[m.ID] = true
+			}
+		}
 	}
 
-	var modules []moduleFileEntry
-	if err := json.Unmarshal(data, &modules); err != nil {
-		return nil, err
+	for id, dirName := range modulePathMap {
+		if !explicit[id] && state.engines[dirName] {
+			installed[id] = true
+		}
 	}
-
-	return modules, nil
+	return installed
 }
 
 // IsModuleInstalled checks if a specific module is installed for an editor
 func (c *Client) IsModuleInstalled(editorPath string, module string) bool {
-	// Map user-friendly name to Hub CLI module ID first
-	moduleID := module
-	if mapped, ok := moduleMap[strings.ToLower(module)]; ok {
-		moduleID = mapped
-	}
-
-	// Try to read from modules.json first
-	modules, err := c.readModulesFile(editorPath)
-	if err == nil {
-		for _, m := range modules {
-			if m.ID == moduleID {
-				// If isInstalled is explicitly set, use that value
-				if m.IsInstalled != nil {
-					ui.Debug("Module check from modules.json", "module", module, "id", moduleID, "installed", *m.IsInstalled)
-					return *m.IsInstalled
-				}
-				// isInstalled is null, fall through to directory check
-				ui.Debug("Module isInstalled is null, checking directory", "module", module, "id", moduleID)
-				break
-			}
-		}
-	}
+	return c.GetInstalledModules(editorPath)[ModuleID(module)]
+}
 
-	// Fallback to directory check
-	dirName, ok := modulePathMap[moduleID]
+// ModuleDir returns the directory module is installed in for the editor at
+// editorPath, or "" for modules that do not live in PlaybackEngines
+func (c *Client) ModuleDir(editorPath, module string) string {
+	dir, ok := modulePathMap[ModuleID(module)]
 	if !ok {
-		ui.Debug("Unknown module for path check", "module", module)
-		return false
+		return ""
 	}
+	return filepath.Join(c.GetPlaybackEnginesPath(editorPath), dir)
+}
 
-	playbackEnginesPath := c.GetPlaybackEnginesPath(editorPath)
-	modulePath := filepath.Join(playbackEnginesPath, dirName)
+// RegisterEditor adds the editor at editorPath to Unity Hub's list of
+// installed editors. Editors inside Hub's install path do not need it.
+func (c *Client) RegisterEditor(editorPath string) error {
+	if c.hubPath == "" {
+		return errs.New(errs.HubMissing, "unity hub not found")
+	}
+	defer c.InvalidateEditors()
+	return c.executeHubCommand("Registering editor with Unity Hub", "register editor", []string{"--", "--headless", "editors", "--add", editorPath})
+}
 
-	exists := fileExists(modulePath)
-	ui.Debug("Module check by directory", "module", module, "path", modulePath, "exists", exists)
-	return exists
+// ModuleID maps a user-friendly module name to its Hub CLI module ID
+func ModuleID(module string) string {
+	if mapped, ok := moduleMap[strings.ToLower(module)]; ok {
+		return mapped
+	}
+	return module
 }
 
 // GetMissingModules returns a list of modules that are not installed
 func (c *Client) GetMissingModules(editorPath string, modules []string) []string {
+	installed := c.GetInstalledModules(editorPath)
 	var missing []string
 	for _, module := range modules {
-		if !c.IsModuleInstalled(editorPath, module) {
+		if !installed[ModuleID(module)] {
 			missing = append(missing, module)
 		}
 	}
@@ -911,14 +997,46 @@ func (c *Client) GetMissingModules(editorPath string, modules []string) []string
 }
 
 // InstallModules installs additional modules to an existing editor
-func (c *Client) InstallModules(version string, modules []string) error {
-	if c.hubPath == "" {
-		return fmt.Errorf("unity hub not found")
-	}
-
+func (c *Client) InstallModules(version string, modules []string) (err error) {
 	if len(modules) == 0 {
 		return nil
 	}
+	defer c.InvalidateEditors()
+
+	var editorPath string
+	if c.usesDirectInstall() {
+		installed, path, err := c.IsEditorInstalled(version)
+		if err != nil {
+			return err
+		}
+		if !installed {
+			return errs.New(errs.NotInstalled, "unity %s is not installed", version)
+		}
+		editorPath = path
+	} else if c.hubPath == "" {
+		return errs.New(errs.HubMissing, "unity hub not found")
+	}
+
+	unlock, err := c.lockInstall(version)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	c.queueInstall(InstallJob{
+		Version:     version,
+		Modules:     modules,
+		ModulesOnly: true,
+		Backend:     c.installBackend(),
+	})
+	defer func() {
+		c.settleInstall(version, err)
+		c.RecordJournal(JournalModules, version, installDetail("", modules), err)
+	}()
+
+	if editorPath != "" {
+		return c.installModulesDirect(version, editorPath, modules)
+	}
 
 	args := []string{"--", "--headless", "install-modules", "--version", version}
 
@@ -933,9 +1051,23 @@ func (c *Client) InstallModules(version string, modules []string) error {
 	return c.executeHubCommand("Installing modules", "install modules", args)
 }
 
+// installDetail describes an install for the journal
+func installDetail(architecture string, modules []string) string {
+	var parts []string
+	if architecture != "" {
+		parts = append(parts, architecture)
+	}
+	if len(modules) > 0 {
+		parts = append(parts, "modules: "+strings.Join(modules, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
 // executeHubCommand runs a Unity Hub CLI command with the given arguments
 func (c *Client) executeHubCommand(debugMsg, operation string, args []string) error {
 	ui.Debug(debugMsg, "command", c.hubPath, "args", strings.Join(args, " "))
+	span := trace.Start(trace.KindExec, "Unity Hub "+operation)
+	defer span.End()
 
 	// Create context that cancels on SIGINT/SIGTERM
 	ctx, cancel := context.WithCancel(context.Background())
@@ -946,32 +1078,47 @@ func (c *Client) executeHubCommand(debugMsg, operation string, args []string) er
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	defer signal.Stop(sigChan)
 
+	// Hub output is parsed for download/install percentages and shown as progress
+	pr, pw := io.Pipe()
 	cmd := exec.CommandContext(ctx, c.hubPath, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stdout = pw
+	cmd.Stderr = pw
 
 	// Start the command
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start %s: %w", operation, err)
 	}
 
+	progress := ui.NewProgress(debugMsg, 0)
+	tracked := make(chan struct{})
+	go func() {
+		trackHubProgress(pr, progress)
+		close(tracked)
+	}()
+
 	// Wait for either command completion or signal
 	done := make(chan error, 1)
 	go func() {
-		done <- cmd.Wait()
+		err := cmd.Wait()
+		_ = pw.Close()
+		<-tracked
+		done <- err
 	}()
 
 	select {
 	case err := <-done:
 		if err != nil {
-			return fmt.Errorf("failed to %s: %w", operation, err)
+			err = fmt.Errorf("failed to %s: %w", operation, err)
 		}
-		return nil
+		progress.Done(err)
+		return err
 	case sig := <-sigChan:
-		ui.Muted("\nReceived %s, stopping Unity Hub...", sig)
+		progress.Println("Received %s, stopping Unity Hub...", sig)
 		cancel() // This will send SIGKILL to the process
 		<-done   // Wait for process to exit
-		return fmt.Errorf("interrupted by %s", sig)
+		err := fmt.Errorf("interrupted by %s", sig)
+		progress.Done(err)
+		return err
 	}
 }
 
@@ -982,66 +1129,60 @@ type hubInfoData struct {
 }
 
 func findUnityHub() string {
-	// 1. Check environment variable first
-	envPath := os.Getenv("UNIFORGE_HUB_PATH")
-	if envPath != "" && fileExists(envPath) {
-		return envPath
-	}
-
-	// 2. Try to read from hubInfo.json
-	if path := getHubPathFromHubInfo(); path != "" {
+	if path := detectUnityHub(); path != "" {
+		ui.Debug("Found Unity Hub", "path", path)
 		return path
 	}
 
-	// 3. Try default paths
-	paths := getUnityHubPaths()
-	for _, path := range paths {
-		if fileExists(path) {
-			ui.Debug("Found Unity Hub", "path", path)
-			return path
-		}
-	}
-
-	// 4. Try PATH lookup
-	pathCmd, err := exec.LookPath("Unity Hub")
-	if err == nil {
-		return pathCmd
-	}
-
 	ui.Warn("Unity Hub not found. Please install Unity Hub or set UNIFORGE_HUB_PATH environment variable")
 	return ""
 }
 
 // getHubPathFromHubInfo reads the Unity Hub executable path from hubInfo.json
 func getHubPathFromHubInfo() string {
-	var basePath string
-	switch runtime.GOOS {
-	case "darwin":
-		basePath = filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "UnityHub")
-	case "windows":
-		basePath = filepath.Join(os.Getenv("APPDATA"), "UnityHub")
-	case "linux":
-		basePath = filepath.Join(os.Getenv("HOME"), ".config", "UnityHub")
-	default:
+	hubInfo := readHubInfo((&Client{}).getUnityHubBasePath())
+	if hubInfo == nil {
 		return ""
 	}
 
+	if hubInfo.ExecutablePath != "" && fileExists(hubInfo.ExecutablePath) {
+		ui.Debug("Found Unity Hub from hubInfo.json", "path", hubInfo.ExecutablePath)
+		return hubInfo.ExecutablePath
+	}
+
+	return ""
+}
+
+// readHubInfo reads hubInfo.json written by Unity Hub into basePath, or returns nil
+func readHubInfo(basePath string) *hubInfoData {
+	if basePath == "" {
+		return nil
+	}
+
 	hubInfoPath := filepath.Join(basePath, "hubInfo.json")
 	data, err := os.ReadFile(hubInfoPath)
 	if err != nil {
-		return ""
+		return nil
 	}
 
 	var hubInfo hubInfoData
 	if err := json.Unmarshal(data, &hubInfo); err != nil {
-		return ""
+		return nil
 	}
 
-	if hubInfo.ExecutablePath != "" && fileExists(hubInfo.ExecutablePath) {
-		ui.Debug("Found Unity Hub from hubInfo.json", "path", hubInfo.ExecutablePath)
-		return hubInfo.ExecutablePath
-	}
+	return &hubInfo
+}
 
+// HubPath returns the detected Unity Hub executable path (empty if not found)
+func (c *Client) HubPath() string {
+	return c.hubPath
+}
+
+// HubVersion returns the Unity Hub version recorded in hubInfo.json (empty if unknown)
+func (c *Client) HubVersion() string {
+	if hubInfo := readHubInfo(c.getUnityHubBasePath()); hubInfo != nil {
+		return hubInfo.Version
+	}
 	return ""
 }
 
@@ -290,6 +290,12 @@ func TestIsValidUnityVersion(t *testing.T) {
 		{"Valid alpha version", "2023.1.0a1", true},
 		{"Valid beta version", "2023.1.0b1", true},
 		{"Valid patch version", "2022.3.10p1", true},
+		{"Valid China version", "2021.3.14f1c1", true},
+		{"Valid China x version", "2022.3.2f1x3", true},
+		{"Missing revision", "2022.3.60f", false},
+		{"Unknown release type", "2022.3.60t1", false},
+		{"Trailing text", "2022.3.60f1-arm64", false},
+		{"Partial version", "2022.3.60", false},
 		{"Too short", "2022.3", false},
 		{"No dots", "20223601f", false},
 		{"One dot only", "2022.360f1", false},
@@ -0,0 +1,172 @@
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Newest schema versions of Unity Hub's files that uniforge knows
+const (
+	editorsSchemaVersion  = 2 // editors-v2.json
+	projectsSchemaVersion = 1 // projects-v1.json
+)
+
+// hubFile is the envelope Unity Hub writes its JSON files in. Data is decoded
+// entry by entry, so one entry Hub changed does not hide the others.
+type hubFile struct {
+	SchemaVersion schemaVersion   `json:"schema_version"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// schemaVersion is a schema_version as Hub writes it: "v1", "2" or 2
+type schemaVersion string
+
+// UnmarshalJSON accepts the version as a string or a number
+func (v *schemaVersion) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = schemaVersion(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("schema_version is neither a string nor a number: %s", data)
+	}
+	*v = schemaVersion(n.String())
+	return nil
+}
+
+// number returns the version number, false when there is none or it is not a
+// whole number
+func (v schemaVersion) number() (int, bool) {
+	s := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(string(v))), "v")
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// newerThan reports whether the version is newer than known. A version that
+// is not a number is taken as newer; no version at all is not.
+func (v schemaVersion) newerThan(known int) bool {
+	if v == "" {
+		return false
+	}
+	n, ok := v.number()
+	return !ok || n > known
+}
+
+// hubFileEntry is an entry of a Hub file with its key when data is an object
+type hubFileEntry[T any] struct {
+	Key   string
+	Value T
+}
+
+// warnedSchemas holds the files a newer schema warning was shown for
+var warnedSchemas sync.Map
+
+// decodeHubFile decodes a Unity Hub file, whose data is a list or an object of
+// entries. Unknown fields are ignored and entries that do not decode are
+// skipped. A file with a newer schema than known is read as far as it is
+// recognisable: a warning is shown once, and data that lost its expected
+// shape yields no entries instead of an error, so callers fall back to their
+// other sources. Files without a schema_version are read as the known one.
+func decodeHubFile[T any](name string, content []byte, known int) ([]hubFileEntry[T], error) {
+	var file hubFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	newer := file.SchemaVersion.newerThan(known)
+	if newer {
+		if _, warned := warnedSchemas.LoadOrStore(name, true); !warned {
+			ui.Warn("Unity Hub's %s has schema version %s, newer than uniforge supports (v%d); some entries may be missing. Update uniforge if they are.", name, file.SchemaVersion, known)
+		}
+	}
+
+	entries, err := decodeHubFileData[T](name, file.Data)
+	if err != nil {
+		if newer {
+			ui.Debug("Ignoring data of newer schema", "file", name, "error", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entries, nil
+}
+
+// decodeHubFileData decodes the entries of a list or an object in order
+func decodeHubFileData[T any](name string, data json.RawMessage) ([]hubFileEntry[T], error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var raws []hubFileEntry[json.RawMessage]
+	switch data[0] {
+	case '[':
+		var list []json.RawMessage
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		for _, raw := range list {
+			raws = append(raws, hubFileEntry[json.RawMessage]{Value: raw})
+		}
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		_, _ = dec.Token() // {
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			raws = append(raws, hubFileEntry[json.RawMessage]{Key: tok.(string), Value: raw})
+		}
+	default:
+		return nil, fmt.Errorf("failed to parse %s: data is neither a list nor an object", name)
+	}
+
+	entries := make([]hubFileEntry[T], 0, len(raws))
+	for _, raw := range raws {
+		var value T
+		if err := json.Unmarshal(raw.Value, &value); err != nil {
+			ui.Debug("Skipping unreadable entry", "file", name, "key", raw.Key, "error", err)
+			continue
+		}
+		entries = append(entries, hubFileEntry[T]{Key: raw.Key, Value: value})
+	}
+	return entries, nil
+}
+
+// stringList is a list of strings Hub may also write as a single string
+type stringList []string
+
+// UnmarshalJSON accepts a list of strings, a string or null
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*l = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
@@ -0,0 +1,192 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// fixtureClient returns a client reading Hub's files from testdata/<hub>
+func fixtureClient(t *testing.T, hub string) *Client {
+	t.Helper()
+	return NewClientWithOptions(ClientOptions{
+		HubDataDir: filepath.Join("testdata", hub),
+		CacheDir:   t.TempDir(),
+	})
+}
+
+func TestListProjectsFixtures(t *testing.T) {
+	tests := []struct {
+		hub    string
+		titles []string
+	}{
+		{hub: "hub-2.4", titles: []string{"Legacy"}},
+		{hub: "hub-3.4", titles: []string{"Puzzle", "Racer"}},
+		{hub: "hub-3.12", titles: []string{"Prototype", "Shooter"}},
+		// Data is a list now; entries are still read by their fields
+		{hub: "hub-future", titles: []string{"Next"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hub, func(t *testing.T) {
+			projects, err := fixtureClient(t, tt.hub).ListProjects()
+			if err != nil {
+				t.Fatalf("ListProjects() error = %v", err)
+			}
+			var titles []string
+			for _, p := range projects {
+				titles = append(titles, p.Title)
+				if p.Path == "" || p.Version == "" {
+					t.Errorf("project %q misses path or version: %+v", p.Title, p)
+				}
+			}
+			slices.Sort(titles)
+			if !slices.Equal(titles, tt.titles) {
+				t.Errorf("titles = %v, want %v", titles, tt.titles)
+			}
+		})
+	}
+}
+
+func TestListProjectsFixtureFields(t *testing.T) {
+	projects, err := fixtureClient(t, "hub-3.12").ListProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := FindRegisteredProject(projects, "C:/Users/dev/Projects/Shooter")
+	if p == nil {
+		t.Fatal("Shooter not found")
+	}
+	if p.Version != "6000.0.41f1" || p.CloudProjectID != "1f2e3d4c-5b6a-4789-8a9b-0c1d2e3f4a5b" || p.CloudOrgID != "dev-org" {
+		t.Errorf("unexpected project: %+v", p)
+	}
+	if p.LastModified.UnixMilli() != 1741250400000 {
+		t.Errorf("LastModified = %v", p.LastModified)
+	}
+}
+
+func TestListEditorsFromFileFixtures(t *testing.T) {
+	tests := []struct {
+		hub      string
+		versions []string
+	}{
+		{hub: "hub-2.4"}, // Hub 2 had no editors-v2.json
+		{hub: "hub-3.4", versions: []string{"2021.3.16f1", "2022.2.1f1"}},
+		// A location written as a string is read, an entry without version skipped
+		{hub: "hub-3.12", versions: []string{"2022.3.58f1", "6000.0.41f1"}},
+		// Data no longer holds editor entries: nothing is read, no error
+		{hub: "hub-future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hub, func(t *testing.T) {
+			editors, err := fixtureClient(t, tt.hub).listEditorsFromFile()
+			if err != nil {
+				t.Fatalf("listEditorsFromFile() error = %v", err)
+			}
+			var versions []string
+			for _, e := range editors {
+				versions = append(versions, e.Version)
+				if e.Path == "" {
+					t.Errorf("editor %s has no path", e.Version)
+				}
+			}
+			slices.Sort(versions)
+			if !slices.Equal(versions, tt.versions) {
+				t.Errorf("versions = %v, want %v", versions, tt.versions)
+			}
+		})
+	}
+}
+
+func TestDecodeHubFile(t *testing.T) {
+	type entry struct {
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		name    string
+		json    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "list", json: `{"schema_version":"v1","data":[{"name":"a"},{"name":"b"}]}`, want: []string{"a", "b"}},
+		{name: "object keeps order and keys", json: `{"schema_version":"1","data":{"x":{"name":"b"},"y":{"name":"a"}}}`, want: []string{"x:b", "y:a"}},
+		{name: "numeric version", json: `{"schema_version":1,"data":[{"name":"a"}]}`, want: []string{"a"}},
+		{name: "no version", json: `{"data":[{"name":"a"}]}`, want: []string{"a"}},
+		{name: "missing data", json: `{"schema_version":"v1"}`},
+		{name: "null data", json: `{"schema_version":"v1","data":null}`},
+		{name: "unreadable entry skipped", json: `{"schema_version":"v1","data":[{"name":1},{"name":"b"}]}`, want: []string{"b"}},
+		{name: "unknown fields ignored", json: `{"schema_version":"v1","extra":true,"data":[{"name":"a","added":{}}]}`, want: []string{"a"}},
+		{name: "data of unknown shape", json: `{"schema_version":"v1","data":"a"}`, wantErr: true},
+		{name: "data of unknown shape in newer schema", json: `{"schema_version":"v2","data":"a"}`},
+		{name: "invalid JSON", json: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := decodeHubFile[entry]("test.json", []byte(tt.json), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, e := range entries {
+				if e.Key != "" {
+					got = append(got, e.Key+":"+e.Value.Name)
+				} else {
+					got = append(got, e.Value.Name)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("entries = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaVersionNewerThan(t *testing.T) {
+	tests := []struct {
+		version schemaVersion
+		known   int
+		want    bool
+	}{
+		{"", 1, false},
+		{"v1", 1, false},
+		{"V1", 2, false},
+		{"2", 2, false},
+		{"v2", 1, true},
+		{"3", 2, true},
+		{"2.1", 2, true},
+		{"beta", 1, true},
+	}
+	for _, tt := range tests {
+		if got := tt.version.newerThan(tt.known); got != tt.want {
+			t.Errorf("%q.newerThan(%d) = %v, want %v", tt.version, tt.known, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterProjectsNewerSchema(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "projects-v1.json")
+	original := `{"schema_version":"v2","data":[]}`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client := &Client{
+		projectsFileOverride: path,
+		openedFileOverride:   filepath.Join(dir, "last-opened.json"),
+		tagsFileOverride:     filepath.Join(dir, "project-tags.json"),
+	}
+
+	if _, err := client.RegisterProjects([]ProjectInfo{{Path: filepath.Join(dir, "Game"), Version: "6000.0.1f1"}}); err == nil {
+		t.Fatal("expected an error for a newer schema")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != original {
+		t.Errorf("projects file was changed: %s", data)
+	}
+}
@@ -0,0 +1,96 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// DefaultInstallLockTimeout is how long an install waits for another one to finish
+const DefaultInstallLockTimeout = 30 * time.Minute
+
+// installLockHolder is written next to the install lock by the process holding
+// it, so that waiting processes can say what they are waiting for
+type installLockHolder struct {
+	PID     int       `json:"pid"`
+	Version string    `json:"version"`
+	Started time.Time `json:"started"`
+}
+
+// installLockPath returns the path guarded by the install lock; the lock file
+// is this path with ".lock" appended
+func (c *Client) installLockPath() string {
+	return filepath.Join(filepath.Dir(c.getQueueFilePath()), "install")
+}
+
+// lockInstall waits up to InstallLockTimeout for other uniforge processes to
+// finish installing, since concurrent installs corrupt Unity Hub's state. The
+// returned function releases the lock.
+func (c *Client) lockInstall(version string) (func(), error) {
+	path := c.installLockPath()
+	holderFile := path + ".json"
+
+	lock, err := fsutil.Lock(path, 0)
+	if errors.Is(err, fsutil.ErrLockTimeout) {
+		holder := readInstallLockHolder(holderFile)
+		if c.InstallLockTimeout <= 0 {
+			return nil, installBusyError(holder)
+		}
+		ui.Info("Waiting for another install to finish (%s)...", holder)
+		lock, err = fsutil.Lock(path, c.InstallLockTimeout)
+		if errors.Is(err, fsutil.ErrLockTimeout) {
+			// The holder may have changed while waiting
+			return nil, installBusyError(readInstallLockHolder(holderFile))
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock installs: %w", err)
+	}
+
+	holder := installLockHolder{PID: os.Getpid(), Version: version, Started: time.Now()}
+	if data, err := json.Marshal(holder); err == nil {
+		if err := fsutil.WriteFileAtomic(holderFile, data, 0644); err != nil {
+			ui.Debug("Failed to record install lock holder", "error", err)
+		}
+	}
+	return func() {
+		_ = os.Remove(holderFile)
+		if err := lock.Unlock(); err != nil {
+			ui.Debug("Failed to release install lock", "error", err)
+		}
+	}, nil
+}
+
+// readInstallLockHolder returns the process holding the install lock, as far as it is known
+func readInstallLockHolder(path string) installLockHolder {
+	var holder installLockHolder
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &holder)
+	}
+	return holder
+}
+
+func (h installLockHolder) String() string {
+	if h.PID == 0 {
+		return "unknown process"
+	}
+	s := fmt.Sprintf("pid %d, version %s", h.PID, h.Version)
+	if !h.Started.IsZero() {
+		s += ", started " + FormatTimeAgo(h.Started, time.Now())
+	}
+	return s
+}
+
+func installBusyError(holder installLockHolder) error {
+	return errs.WithHint(
+		fmt.Errorf("another install is in progress (%s)", holder),
+		"wait for it to finish, or raise --lock-timeout (install-lock-timeout in ~/.uniforge.yaml)",
+	)
+}
@@ -0,0 +1,185 @@
+package hub
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/paths"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Operations recorded in the journal
+const (
+	JournalInstall      = "install"       // Editor install
+	JournalModules      = "modules"       // Modules added to an installed editor
+	JournalDiscard      = "discard"       // Interrupted install discarded with `editor resume --discard`
+	JournalUnpack       = "unpack"        // Editor installed from an `editor pack` archive
+	JournalCacheClear   = "cache-clear"   // Release caches removed
+	JournalRegister     = "register"      // Project added to Unity Hub's project list
+	JournalModuleRemove = "module-remove" // Module removed with `editor modules --remove`
+)
+
+// journalMaxSize is the size at which the journal is rotated; one older
+// generation is kept
+var journalMaxSize int64 = 4 << 20
+
+// JournalEntry is an operation uniforge performed on this machine
+type JournalEntry struct {
+	Time    time.Time `json:"time"`
+	Op      string    `json:"op"`
+	Subject string    `json:"subject,omitempty"` // Unity version or project path
+	Detail  string    `json:"detail,omitempty"`  // e.g. modules, architecture
+	Error   string    `json:"error,omitempty"`   // Set when the operation failed
+	User    string    `json:"user,omitempty"`
+	Host    string    `json:"host,omitempty"`
+	Command string    `json:"command,omitempty"` // uniforge command line that caused it
+}
+
+// Failed reports whether the operation failed
+func (e JournalEntry) Failed() bool {
+	return e.Error != ""
+}
+
+// JournalFilter selects journal entries; zero fields match everything
+type JournalFilter struct {
+	Op      string    // Operation, e.g. JournalInstall
+	Subject string    // Version prefix ("2021.3") or part of a project path
+	Since   time.Time // Entries at or after
+	Failed  bool      // Only failed operations
+}
+
+// Match reports whether e is selected by the filter
+func (f JournalFilter) Match(e JournalEntry) bool {
+	if f.Op != "" && e.Op != f.Op {
+		return false
+	}
+	if f.Subject != "" && !strings.Contains(e.Subject, f.Subject) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	return !f.Failed || e.Failed()
+}
+
+// StateDir returns the directory of uniforge's state that has to outlive the
+// caches (see paths.StateDir)
+func (c *Client) StateDir() string {
+	if c.options != nil {
+		if c.options.StateDir != "" {
+			return c.options.StateDir
+		}
+		return c.options.CacheDir
+	}
+	return paths.StateDir()
+}
+
+// JournalFilePath returns the path of the operation journal
+func (c *Client) JournalFilePath() string {
+	if c.journalFileOverride != "" {
+		return c.journalFileOverride
+	}
+	return filepath.Join(c.StateDir(), "journal.jsonl")
+}
+
+// RecordJournal appends an operation to the journal. The journal is a record
+// for people, so failing to write it only logs a debug message.
+func (c *Client) RecordJournal(op, subject, detail string, opErr error) {
+	entry := JournalEntry{
+		Time:    time.Now().UTC(),
+		Op:      op,
+		Subject: subject,
+		Detail:  detail,
+		User:    currentUser(),
+		Command: strings.Join(append([]string{filepath.Base(os.Args[0])}, os.Args[1:]...), " "),
+	}
+	entry.Host, _ = os.Hostname()
+	if opErr != nil {
+		entry.Error = opErr.Error()
+	}
+	if err := c.appendJournal(entry); err != nil {
+		ui.Debug("Failed to write journal", "error", err)
+	}
+}
+
+func (c *Client) appendJournal(entry JournalEntry) error {
+	journalFile := c.JournalFilePath()
+	if err := os.MkdirAll(filepath.Dir(journalFile), 0755); err != nil {
+		return err
+	}
+	lock, err := fsutil.Lock(journalFile, cacheLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	if info, err := os.Stat(journalFile); err == nil && info.Size() >= journalMaxSize {
+		if err := os.Rename(journalFile, journalFile+".1"); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(journalFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadJournal returns the recorded operations, oldest first. Lines that
+// cannot be parsed are skipped.
+func (c *Client) LoadJournal() ([]JournalEntry, error) {
+	journalFile := c.JournalFilePath()
+	var entries []JournalEntry
+	for _, path := range []string{journalFile + ".1", journalFile} {
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read journal: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var entry JournalEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				ui.Debug("Skipping corrupted journal line", "file", path, "error", err)
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		err = scanner.Err()
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+// currentUser returns the name of the user running uniforge
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
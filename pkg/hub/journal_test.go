@@ -0,0 +1,102 @@
+package hub
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestJournal(t *testing.T) {
+	client := createTestClient(t, "")
+
+	client.RecordJournal(JournalInstall, "2021.3.45f1", installDetail("x86_64", []string{"android"}), nil)
+	client.RecordJournal(JournalModules, "2022.3.61f1", installDetail("", []string{"ios"}), errors.New("exit status 1"))
+	client.RecordJournal(JournalDiscard, "2021.3.45f1", "", nil)
+
+	entries, err := client.LoadJournal()
+	if err != nil {
+		t.Fatalf("LoadJournal() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	first := entries[0]
+	if first.Op != JournalInstall || first.Detail != "x86_64; modules: android" || first.Failed() {
+		t.Errorf("entries[0] = %+v", first)
+	}
+	if first.Host == "" || first.Command == "" || first.Time.IsZero() {
+		t.Errorf("entries[0] misses who and when: %+v", first)
+	}
+	if !entries[1].Failed() || entries[1].Error != "exit status 1" {
+		t.Errorf("entries[1] = %+v, want failed", entries[1])
+	}
+
+	match := func(f JournalFilter) int {
+		n := 0
+		for _, e := range entries {
+			if f.Match(e) {
+				n++
+			}
+		}
+		return n
+	}
+	tests := []struct {
+		name   string
+		filter JournalFilter
+		want   int
+	}{
+		{"all", JournalFilter{}, 3},
+		{"stream", JournalFilter{Subject: "2021.3"}, 2},
+		{"op", JournalFilter{Op: JournalDiscard, Subject: "2021.3"}, 1},
+		{"failed", JournalFilter{Failed: true}, 1},
+		{"since", JournalFilter{Since: time.Now().Add(time.Hour)}, 0},
+	}
+	for _, tt := range tests {
+		if got := match(tt.filter); got != tt.want {
+			t.Errorf("%s: %d entries match, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestJournalRotation(t *testing.T) {
+	client := createTestClient(t, "")
+	defer func(size int64) { journalMaxSize = size }(journalMaxSize)
+	journalMaxSize = 1
+
+	client.RecordJournal(JournalCacheClear, "", "", nil)
+	client.RecordJournal(JournalInstall, "6000.0.40f1", "", nil)
+	if _, err := os.Stat(client.JournalFilePath() + ".1"); err != nil {
+		t.Fatalf("journal was not rotated: %v", err)
+	}
+
+	// Skips corrupted lines and reads the rotated generation first
+	f, err := os.OpenFile(client.JournalFilePath(), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{not json\n")
+	_ = f.Close()
+
+	entries, err := client.LoadJournal()
+	if err != nil {
+		t.Fatalf("LoadJournal() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Op != JournalCacheClear || entries[1].Op != JournalInstall {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestRegisterProjectsRecordsJournal(t *testing.T) {
+	client := createTestClient(t, `{"schema_version": "v1", "data": {}}`)
+	if _, err := client.RegisterProjects([]ProjectInfo{{Path: "/path/to/game", Version: "2022.3.60f1"}}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := client.LoadJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Op != JournalRegister || entries[0].Subject != "/path/to/game" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
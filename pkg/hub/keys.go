@@ -0,0 +1,125 @@
+package hub
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// keyActions maps the action names of the keys section in ~/.uniforge.yaml
+// to the bindings of the project and editor TUIs they rebind
+func keyActions() map[string][]*key.Binding {
+	return map[string][]*key.Binding{
+		"up":            {&keys.Up, &editorKeys.Up},
+		"down":          {&keys.Down, &editorKeys.Down},
+		"enter":         {&keys.Enter, &editorKeys.Enter},
+		"quit":          {&keys.Quit, &editorKeys.Escape},
+		"help":          {&keys.Help, &editorKeys.Help},
+		"open-editor":   {&keys.Editor},
+		"copy-path":     {&keys.CopyPath},
+		"tag":           {&keys.Tag},
+		"toggle":        {&editorKeys.Space},
+		"toggle-all":    {&editorKeys.Tab},
+		"release-notes": {&editorKeys.OpenNotes},
+		"installed":     {&editorKeys.FilterInstalled},
+	}
+}
+
+// KeyActions returns the names of the TUI actions whose keys can be configured
+func KeyActions() []string {
+	var names []string
+	for name := range keyActions() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetKeyBindings replaces the keys of TUI actions, e.g. {"down": {"j", "down"}}.
+// Keys are named as in bubbletea ("ctrl+j", "pgdown", "space" or "?").
+func SetKeyBindings(bindings map[string][]string) error {
+	actions := keyActions()
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		targets, ok := actions[strings.ToLower(name)]
+		if !ok {
+			return errs.New(errs.Usage, "unknown key binding %q (available: %s)", name, strings.Join(KeyActions(), ", "))
+		}
+		var bound []string
+		for _, k := range bindings[name] {
+			k = strings.ToLower(strings.TrimSpace(k))
+			if k == "space" {
+				k = " "
+			}
+			if k != "" {
+				bound = append(bound, k)
+			}
+		}
+		if len(bound) == 0 {
+			return errs.New(errs.Usage, "no keys given for key binding %q", name)
+		}
+		for _, b := range targets {
+			b.SetKeys(bound...)
+			b.SetHelp(keyLabel(bound[0]), b.Help().Desc)
+		}
+	}
+	return nil
+}
+
+// keyLabel is how a key is shown in the TUI help, e.g. "^E" for ctrl+e
+func keyLabel(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case " ":
+		return "Space"
+	case "enter", "esc", "tab", "home", "end", "pgup", "pgdown":
+		return strings.ToUpper(k[:1]) + k[1:]
+	}
+	if rest, ok := strings.CutPrefix(k, "ctrl+"); ok {
+		return "^" + strings.ToUpper(rest)
+	}
+	return k
+}
+
+// keyHint is a help entry of a TUI footer, e.g. "Enter:Select"
+func keyHint(b key.Binding, label string) string {
+	return b.Help().Key + ":" + label
+}
+
+// renderKeyHelp renders the help overlay: every key of each binding and what it does
+func renderKeyHelp(title string, bindings []key.Binding, keyStyle, descStyle lipgloss.Style) string {
+	var b strings.Builder
+	b.WriteString(editorHeaderStyle.Render(title))
+	b.WriteString("\n\n")
+
+	labels := make([]string, len(bindings))
+	width := 0
+	for i, binding := range bindings {
+		var names []string
+		for _, k := range binding.Keys() {
+			names = append(names, keyLabel(k))
+		}
+		labels[i] = strings.Join(names, " / ")
+		width = max(width, lipgloss.Width(labels[i]))
+	}
+	for i, binding := range bindings {
+		pad := strings.Repeat(" ", width-lipgloss.Width(labels[i]))
+		b.WriteString(fmt.Sprintf("  %s%s  %s\n", keyStyle.Render(labels[i]), pad, descStyle.Render(binding.Help().Desc)))
+	}
+	b.WriteString("\n")
+	b.WriteString(descStyle.Render("  Press any key to close this help"))
+	b.WriteString("\n")
+	return b.String()
+}
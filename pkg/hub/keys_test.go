@@ -0,0 +1,82 @@
+package hub
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// restoreKeyBindings puts the default key bindings back after the test
+func restoreKeyBindings(t *testing.T) {
+	t.Helper()
+	savedKeys, savedEditorKeys := keys, editorKeys
+	t.Cleanup(func() {
+		keys, editorKeys = savedKeys, savedEditorKeys
+	})
+}
+
+func TestSetKeyBindings(t *testing.T) {
+	restoreKeyBindings(t)
+
+	err := SetKeyBindings(map[string][]string{
+		"down": {"j", "Down"},
+		"quit": {"q"},
+		"help": {"space"},
+	})
+	if err != nil {
+		t.Fatalf("SetKeyBindings() error = %v", err)
+	}
+	if !slices.Equal(keys.Down.Keys(), []string{"j", "down"}) || !slices.Equal(editorKeys.Down.Keys(), []string{"j", "down"}) {
+		t.Errorf("down keys = %v, %v", keys.Down.Keys(), editorKeys.Down.Keys())
+	}
+	if got := keyHint(editorKeys.Escape, "Quit"); got != "q:Quit" {
+		t.Errorf("quit hint = %q", got)
+	}
+	if got := keyHint(keys.Help, "Help"); got != "Space:Help" {
+		t.Errorf("help hint = %q", got)
+	}
+	if !slices.Equal(keys.Up.Keys(), []string{"up", "ctrl+k"}) {
+		t.Errorf("up keys changed: %v", keys.Up.Keys())
+	}
+
+	err = SetKeyBindings(map[string][]string{"jump": {"g"}})
+	if !errors.Is(err, errs.Usage) || !strings.Contains(err.Error(), "copy-path, down") {
+		t.Errorf("unknown action error = %v", err)
+	}
+	if err := SetKeyBindings(map[string][]string{"up": {" "}}); err == nil {
+		t.Error("empty key list should fail")
+	}
+}
+
+func TestEditorHelpOverlay(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	restoreKeyBindings(t)
+	if err := SetKeyBindings(map[string][]string{"down": {"j", "down"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	releases := []UnityRelease{{Version: "6000.0.23f1"}, {Version: "2022.3.40f1"}}
+	m := newEditorBrowseModel(&Client{}, releases)
+
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	if !m.showHelp || !strings.Contains(m.View(), "j / ↓") || !strings.Contains(m.View(), "open release notes") {
+		t.Fatalf("help overlay not shown:\n%s", m.View())
+	}
+	if m.filterInput.Value() != "" {
+		t.Errorf("? typed into the filter: %q", m.filterInput.Value())
+	}
+
+	// Any key closes the overlay without acting; then j moves down instead of typing
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showHelp || m.state != stateReleaseSelect {
+		t.Fatalf("showHelp = %v, state = %v", m.showHelp, m.state)
+	}
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.versionCursor != 1 || m.filterInput.Value() != "" {
+		t.Errorf("cursor = %d, filter = %q", m.versionCursor, m.filterInput.Value())
+	}
+}
@@ -0,0 +1,199 @@
+package hub
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// macPlatform describes the official macOS installers. The .pkg payloads are
+// expanded with pkgutil instead of being run by installer, so no administrator
+// rights are needed and the editor can live anywhere.
+var macPlatform = directPlatform{
+	editorFiles: map[string]string{
+		"x86_64": "MacEditorInstaller/Unity-%s.pkg",
+		"arm64":  "MacEditorInstallerArm64/Unity-%s.pkg",
+	},
+	editorBinary: filepath.Join("Unity.app", "Contents", "MacOS", "Unity"),
+	engines:      "PlaybackEngines",
+	// Mac Mono standalone support is part of the editor itself
+	modules: map[string]directModule{
+		"android":       {file: "MacEditorTargetInstaller/UnitySetup-Android-Support-for-Editor-%s.pkg", dir: "AndroidPlayer"},
+		"ios":           {file: "MacEditorTargetInstaller/UnitySetup-iOS-Support-for-Editor-%s.pkg", dir: "iOSSupport"},
+		"appletv":       {file: "MacEditorTargetInstaller/UnitySetup-AppleTV-Support-for-Editor-%s.pkg", dir: "AppleTVSupport"},
+		"webgl":         {file: "MacEditorTargetInstaller/UnitySetup-WebGL-Support-for-Editor-%s.pkg", dir: "WebGLSupport"},
+		"windows-mono":  {file: "MacEditorTargetInstaller/UnitySetup-Windows-Mono-Support-for-Editor-%s.pkg", dir: "WindowsStandaloneSupport"},
+		"linux-mono":    {file: "MacEditorTargetInstaller/UnitySetup-Linux-Mono-Support-for-Editor-%s.pkg", dir: "LinuxStandaloneSupport"},
+		"linux-il2cpp":  {file: "MacEditorTargetInstaller/UnitySetup-Linux-IL2CPP-Support-for-Editor-%s.pkg", dir: "LinuxStandaloneSupport"},
+		"mac-il2cpp":    {file: "MacEditorTargetInstaller/UnitySetup-Mac-IL2CPP-Support-for-Editor-%s.pkg", dir: "MacStandaloneSupport"},
+		"documentation": {}, // Offline documentation zip, shared by all platforms
+	},
+	// The Windows IL2CPP payload does not exist on macOS
+	aliases: map[string]string{"windows": "windows-mono"},
+	tools:   []string{"pkgutil"},
+}
+
+// macPkgInstallRoot is where Unity installers place their payload; Unity Hub
+// moves it to the version directory, and so do we
+const macPkgInstallRoot = "/Applications/Unity"
+
+// expandPkg extracts the payloads of a .pkg installer into dir, placing each
+// component relative to the editor version directory
+func expandPkg(pkg, dir string) error {
+	// pkgutil refuses to expand into an existing directory
+	expanded := dir + ".expanded"
+	if err := os.RemoveAll(expanded); err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(expanded) }()
+
+	ui.Debug("Expanding installer package", "pkg", pkg)
+	if output, err := exec.Command("pkgutil", "--expand-full", pkg, expanded).CombinedOutput(); err != nil {
+		return fmt.Errorf("pkgutil: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	components, err := pkgComponents(expanded)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		return fmt.Errorf("no payload found in %s", filepath.Base(pkg))
+	}
+	for _, component := range components {
+		location := pkgInstallLocation(filepath.Join(component, "PackageInfo"))
+		target := filepath.Join(dir, relativeInstallLocation(location))
+		ui.Debug("Placing package payload", "component", filepath.Base(component), "installLocation", location, "target", target)
+		if err := moveTree(filepath.Join(component, "Payload"), target); err != nil {
+			return err
+		}
+	}
+	return ensureWritable(dir)
+}
+
+// pkgComponents returns the expanded component packages below root. A component
+// package expands to a directory with a Payload; a product archive to one
+// <name>.pkg directory per component.
+func pkgComponents(root string) ([]string, error) {
+	if isDir(filepath.Join(root, "Payload")) {
+		return []string{root}, nil
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	var components []string
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+		if entry.IsDir() && strings.HasSuffix(entry.Name(), ".pkg") && isDir(filepath.Join(dir, "Payload")) {
+			components = append(components, dir)
+		}
+	}
+	sort.Strings(components)
+	return components, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// pkgInstallLocation reads the install-location of a component's PackageInfo,
+// or "" if it cannot be read
+func pkgInstallLocation(packageInfo string) string {
+	data, err := os.ReadFile(packageInfo)
+	if err != nil {
+		return ""
+	}
+	var info struct {
+		InstallLocation string `xml:"install-location,attr"`
+	}
+	if err := xml.Unmarshal(data, &info); err != nil {
+		ui.Debug("Failed to parse PackageInfo", "path", packageInfo, "error", err)
+		return ""
+	}
+	return info.InstallLocation
+}
+
+// relativeInstallLocation maps an install-location below /Applications/Unity to
+// a path relative to the version directory. Other locations map to the version
+// directory itself and are sorted out by placeModulePayload.
+func relativeInstallLocation(location string) string {
+	location = path.Clean("/" + location)
+	if rel, ok := strings.CutPrefix(location, macPkgInstallRoot+"/"); ok {
+		return filepath.FromSlash(rel)
+	}
+	return ""
+}
+
+// extractDmg mounts a disk image read-only and extracts the installer packages
+// or app bundles it contains into dir
+func extractDmg(dmg, dir string) error {
+	mountPoint, err := os.MkdirTemp("", "uniforge-dmg-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(mountPoint) }()
+
+	ui.Debug("Mounting disk image", "dmg", dmg, "mountPoint", mountPoint)
+	attach := exec.Command("hdiutil", "attach", "-nobrowse", "-readonly", "-noautoopen", "-mountpoint", mountPoint, dmg)
+	if output, err := attach.CombinedOutput(); err != nil {
+		return fmt.Errorf("hdiutil attach: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	defer func() { _ = exec.Command("hdiutil", "detach", mountPoint, "-force").Run() }()
+
+	entries, err := os.ReadDir(mountPoint)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, entry := range entries {
+		src := filepath.Join(mountPoint, entry.Name())
+		switch filepath.Ext(entry.Name()) {
+		case ".pkg":
+			if err := expandPkg(src, dir); err != nil {
+				return err
+			}
+			found = true
+		case ".app":
+			// ditto keeps permissions, symlinks and extended attributes of the bundle
+			if output, err := exec.Command("ditto", src, filepath.Join(dir, entry.Name())).CombinedOutput(); err != nil {
+				return fmt.Errorf("ditto: %w: %s", err, strings.TrimSpace(string(output)))
+			}
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no installer package or app found in %s", filepath.Base(dmg))
+	}
+	return ensureWritable(dir)
+}
+
+// ensureWritable gives the owner full access to every directory below root.
+// Payloads built for root-owned installs contain read-only directories, which
+// would block adding modules to the editor or removing it later.
+func ensureWritable(root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if mode := info.Mode().Perm(); mode&0700 != 0700 {
+			return os.Chmod(p, mode|0700)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestRelativeInstallLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{"/Applications/Unity", ""},
+		{"/Applications/Unity/", ""},
+		{"/Applications/Unity/PlaybackEngines/iOSSupport", filepath.Join("PlaybackEngines", "iOSSupport")},
+		{"Applications/Unity/Documentation", "Documentation"},
+		{"/Applications/UnityHub", ""},
+		{"/", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := relativeInstallLocation(tt.location); got != tt.want {
+			t.Errorf("relativeInstallLocation(%q) = %q, want %q", tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestPkgInstallLocation(t *testing.T) {
+	dir := t.TempDir()
+	info := filepath.Join(dir, "PackageInfo")
+	writeTestFile(t, info, `<?xml version="1.0" encoding="utf-8"?>
+<pkg-info format-version="2" identifier="com.unity3d.iOSSupport" version="2022.3.10f1" install-location="/Applications/Unity/PlaybackEngines/iOSSupport" auth="root">
+  <payload numberOfFiles="100" installKBytes="1024"/>
+</pkg-info>`)
+
+	if got := pkgInstallLocation(info); got != "/Applications/Unity/PlaybackEngines/iOSSupport" {
+		t.Errorf("pkgInstallLocation() = %q", got)
+	}
+	if got := pkgInstallLocation(filepath.Join(dir, "missing")); got != "" {
+		t.Errorf("pkgInstallLocation(missing) = %q, want empty", got)
+	}
+}
+
+func TestPkgComponents(t *testing.T) {
+	product := t.TempDir()
+	writeTestFile(t, filepath.Join(product, "Distribution"), "<installer-gui-script/>")
+	writeTestFile(t, filepath.Join(product, "Unity.pkg", "Payload", "Unity.app", "Info.plist"), "")
+	writeTestFile(t, filepath.Join(product, "Docs.pkg", "Payload", "index.html"), "")
+	writeTestFile(t, filepath.Join(product, "Resources.pkg", "Scripts", "postinstall"), "")
+
+	got, err := pkgComponents(product)
+	if err != nil {
+		t.Fatalf("pkgComponents() error = %v", err)
+	}
+	want := []string{filepath.Join(product, "Docs.pkg"), filepath.Join(product, "Unity.pkg")}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("pkgComponents() = %v, want %v", got, want)
+	}
+
+	component := t.TempDir()
+	writeTestFile(t, filepath.Join(component, "Payload", "file"), "")
+	got, err = pkgComponents(component)
+	if err != nil {
+		t.Fatalf("pkgComponents() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != component {
+		t.Errorf("pkgComponents() = %v, want [%s]", got, component)
+	}
+}
+
+func TestEnsureWritable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directory permissions are not enforced on Windows")
+	}
+	root := t.TempDir()
+	readOnly := filepath.Join(root, "PlaybackEngines", "iOSSupport")
+	writeTestFile(t, filepath.Join(readOnly, "module.asset"), "")
+	if err := os.Chmod(readOnly, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(readOnly, 0755) })
+
+	if err := ensureWritable(root); err != nil {
+		t.Fatalf("ensureWritable() error = %v", err)
+	}
+	info, err := os.Stat(readOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("mode = %v, want 0755", info.Mode().Perm())
+	}
+}
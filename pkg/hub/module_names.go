@@ -0,0 +1,171 @@
+package hub
+
+import (
+	"os"
+	"strings"
+)
+
+// Languages with bundled module name translations
+const (
+	LangEnglish  = "en"
+	LangJapanese = "ja"
+	LangChinese  = "zh" // Simplified Chinese
+	LangKorean   = "ko"
+)
+
+// SupportedLanguages lists the values accepted by SetLanguage
+var SupportedLanguages = []string{LangEnglish, LangJapanese, LangChinese, LangKorean}
+
+// language is used by ModuleInfo.DisplayName and DisplayDescription
+var language = LangEnglish
+
+// SetLanguage selects the language of module names and descriptions shown to the user.
+// Unsupported languages fall back to English.
+func SetLanguage(lang string) {
+	language = NormalizeLanguage(lang)
+}
+
+// NormalizeLanguage maps a language tag or POSIX locale such as "ja", "ja-JP"
+// or "ko_KR.UTF-8" to a supported language, or English if there is none
+func NormalizeLanguage(locale string) string {
+	if lang, ok := parseLanguage(locale); ok {
+		return lang
+	}
+	return LangEnglish
+}
+
+// IsSupportedLanguage reports whether locale names a supported language
+func IsSupportedLanguage(locale string) bool {
+	_, ok := parseLanguage(locale)
+	return ok
+}
+
+func parseLanguage(locale string) (string, bool) {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	for _, l := range SupportedLanguages {
+		if lang == l {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// DetectLanguage returns the language of the user's locale (LC_ALL, LC_MESSAGES, LANG)
+func DetectLanguage() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return NormalizeLanguage(v)
+		}
+	}
+	return LangEnglish
+}
+
+// DisplayName returns the module name in the selected language, falling back to
+// the English name from release metadata and then to the module ID
+func (m ModuleInfo) DisplayName() string {
+	if t, ok := moduleTranslations[language][strings.ToLower(m.ID)]; ok && t.name != "" {
+		return t.name
+	}
+	if m.Name != "" {
+		return m.Name
+	}
+	return m.ID
+}
+
+// DisplayDescription returns the module description in the selected language,
+// falling back to the English description from release metadata
+func (m ModuleInfo) DisplayDescription() string {
+	if t, ok := moduleTranslations[language][strings.ToLower(m.ID)]; ok && t.description != "" {
+		return t.description
+	}
+	return m.Description
+}
+
+// moduleText is a translated module name and description
+type moduleText struct {
+	name        string
+	description string
+}
+
+// moduleTranslations maps language -> module ID -> text, following the wording of
+// the localized Unity Hub. Modules missing here are shown with their English metadata.
+var moduleTranslations = map[string]map[string]moduleText{
+	LangJapanese: {
+		"android":                    {"Android ビルドサポート", "Android 向けにビルドするためのサポート"},
+		"android-sdk-ndk-tools":      {"Android SDK & NDK Tools", "Android SDK と NDK のツール"},
+		"android-open-jdk":           {"OpenJDK", "Android ビルドに必要な OpenJDK"},
+		"ios":                        {"iOS ビルドサポート", "iOS 向けにビルドするためのサポート"},
+		"appletv":                    {"tvOS ビルドサポート", "tvOS 向けにビルドするためのサポート"},
+		"visionos":                   {"visionOS ビルドサポート", "visionOS 向けにビルドするためのサポート"},
+		"webgl":                      {"WebGL ビルドサポート", "WebGL 向けにビルドするためのサポート"},
+		"windows-il2cpp":             {"Windows ビルドサポート (IL2CPP)", "IL2CPP を使用した Windows 向けビルドのサポート"},
+		"windows-mono":               {"Windows ビルドサポート (Mono)", "Mono を使用した Windows 向けビルドのサポート"},
+		"windows-server":             {"Windows Dedicated Server ビルドサポート", "Windows 向け専用サーバーのビルドサポート"},
+		"mac-il2cpp":                 {"Mac ビルドサポート (IL2CPP)", "IL2CPP を使用した Mac 向けビルドのサポート"},
+		"mac-mono":                   {"Mac ビルドサポート (Mono)", "Mono を使用した Mac 向けビルドのサポート"},
+		"mac-server":                 {"Mac Dedicated Server ビルドサポート", "Mac 向け専用サーバーのビルドサポート"},
+		"linux-il2cpp":               {"Linux ビルドサポート (IL2CPP)", "IL2CPP を使用した Linux 向けビルドのサポート"},
+		"linux-mono":                 {"Linux ビルドサポート (Mono)", "Mono を使用した Linux 向けビルドのサポート"},
+		"linux-server":               {"Linux Dedicated Server ビルドサポート", "Linux 向け専用サーバーのビルドサポート"},
+		"universal-windows-platform": {"Universal Windows Platform ビルドサポート", "UWP 向けにビルドするためのサポート"},
+		"documentation":              {"ドキュメント", "オフラインで閲覧できる Unity ドキュメント"},
+		"language-ja":                {"日本語", "エディターの日本語言語パック"},
+		"language-ko":                {"한국어", "エディターの韓国語言語パック"},
+		"language-zh-hans":           {"简体中文", "エディターの簡体字中国語言語パック"},
+		"language-zh-hant":           {"繁體中文", "エディターの繁体字中国語言語パック"},
+		"visualstudio":               {"Microsoft Visual Studio Community", "C# スクリプトを編集するための統合開発環境"},
+	},
+	LangChinese: {
+		"android":                    {"Android 构建支持", "支持构建 Android 平台"},
+		"android-sdk-ndk-tools":      {"Android SDK & NDK Tools", "Android SDK 和 NDK 工具"},
+		"android-open-jdk":           {"OpenJDK", "构建 Android 所需的 OpenJDK"},
+		"ios":                        {"iOS 构建支持", "支持构建 iOS 平台"},
+		"appletv":                    {"tvOS 构建支持", "支持构建 tvOS 平台"},
+		"visionos":                   {"visionOS 构建支持", "支持构建 visionOS 平台"},
+		"webgl":                      {"WebGL 构建支持", "支持构建 WebGL 平台"},
+		"windows-il2cpp":             {"Windows 构建支持 (IL2CPP)", "使用 IL2CPP 构建 Windows 平台"},
+		"windows-mono":               {"Windows 构建支持 (Mono)", "使用 Mono 构建 Windows 平台"},
+		"windows-server":             {"Windows Dedicated Server 构建支持", "构建 Windows 专用服务器"},
+		"mac-il2cpp":                 {"Mac 构建支持 (IL2CPP)", "使用 IL2CPP 构建 Mac 平台"},
+		"mac-mono":                   {"Mac 构建支持 (Mono)", "使用 Mono 构建 Mac 平台"},
+		"mac-server":                 {"Mac Dedicated Server 构建支持", "构建 Mac 专用服务器"},
+		"linux-il2cpp":               {"Linux 构建支持 (IL2CPP)", "使用 IL2CPP 构建 Linux 平台"},
+		"linux-mono":                 {"Linux 构建支持 (Mono)", "使用 Mono 构建 Linux 平台"},
+		"linux-server":               {"Linux Dedicated Server 构建支持", "构建 Linux 专用服务器"},
+		"universal-windows-platform": {"Universal Windows Platform 构建支持", "支持构建 UWP 平台"},
+		"documentation":              {"文档", "可离线查看的 Unity 文档"},
+		"language-ja":                {"日本語", "编辑器日语语言包"},
+		"language-ko":                {"한국어", "编辑器韩语语言包"},
+		"language-zh-hans":           {"简体中文", "编辑器简体中文语言包"},
+		"language-zh-hant":           {"繁體中文", "编辑器繁体中文语言包"},
+		"visualstudio":               {"Microsoft Visual Studio Community", "用于编辑 C# 脚本的集成开发环境"},
+	},
+	LangKorean: {
+		"android":                    {"Android 빌드 지원", "Android 플랫폼 빌드 지원"},
+		"android-sdk-ndk-tools":      {"Android SDK & NDK Tools", "Android SDK 및 NDK 도구"},
+		"android-open-jdk":           {"OpenJDK", "Android 빌드에 필요한 OpenJDK"},
+		"ios":                        {"iOS 빌드 지원", "iOS 플랫폼 빌드 지원"},
+		"appletv":                    {"tvOS 빌드 지원", "tvOS 플랫폼 빌드 지원"},
+		"visionos":                   {"visionOS 빌드 지원", "visionOS 플랫폼 빌드 지원"},
+		"webgl":                      {"WebGL 빌드 지원", "WebGL 플랫폼 빌드 지원"},
+		"windows-il2cpp":             {"Windows 빌드 지원 (IL2CPP)", "IL2CPP를 사용한 Windows 빌드 지원"},
+		"windows-mono":               {"Windows 빌드 지원 (Mono)", "Mono를 사용한 Windows 빌드 지원"},
+		"windows-server":             {"Windows Dedicated Server 빌드 지원", "Windows 전용 서버 빌드 지원"},
+		"mac-il2cpp":                 {"Mac 빌드 지원 (IL2CPP)", "IL2CPP를 사용한 Mac 빌드 지원"},
+		"mac-mono":                   {"Mac 빌드 지원 (Mono)", "Mono를 사용한 Mac 빌드 지원"},
+		"mac-server":                 {"Mac Dedicated Server 빌드 지원", "Mac 전용 서버 빌드 지원"},
+		"linux-il2cpp":               {"Linux 빌드 지원 (IL2CPP)", "IL2CPP를 사용한 Linux 빌드 지원"},
+		"linux-mono":                 {"Linux 빌드 지원 (Mono)", "Mono를 사용한 Linux 빌드 지원"},
+		"linux-server":               {"Linux Dedicated Server 빌드 지원", "Linux 전용 서버 빌드 지원"},
+		"universal-windows-platform": {"Universal Windows Platform 빌드 지원", "UWP 플랫폼 빌드 지원"},
+		"documentation":              {"문서", "오프라인으로 볼 수 있는 Unity 문서"},
+		"language-ja":                {"日本語", "에디터 일본어 언어 팩"},
+		"language-ko":                {"한국어", "에디터 한국어 언어 팩"},
+		"language-zh-hans":           {"简体中文", "에디터 중국어 간체 언어 팩"},
+		"language-zh-hant":           {"繁體中文", "에디터 중국어 번체 언어 팩"},
+		"visualstudio":               {"Microsoft Visual Studio Community", "C# 스크립트 편집용 통합 개발 환경"},
+	},
+}
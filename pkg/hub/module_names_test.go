@@ -0,0 +1,89 @@
+package hub
+
+import "testing"
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		locale    string
+		expected  string
+		supported bool
+	}{
+		{"ja", LangJapanese, true},
+		{"ja_JP.UTF-8", LangJapanese, true},
+		{"zh-Hans", LangChinese, true},
+		{"zh_TW", LangChinese, true},
+		{"KO", LangKorean, true},
+		{"en_US.UTF-8", LangEnglish, true},
+		{"fr_FR", LangEnglish, false},
+		{"C", LangEnglish, false},
+		{"", LangEnglish, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := NormalizeLanguage(tt.locale); got != tt.expected {
+				t.Errorf("NormalizeLanguage(%q) = %q, want %q", tt.locale, got, tt.expected)
+			}
+			if got := IsSupportedLanguage(tt.locale); got != tt.supported {
+				t.Errorf("IsSupportedLanguage(%q) = %v, want %v", tt.locale, got, tt.supported)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "ko_KR.UTF-8")
+	t.Setenv("LANG", "ja_JP.UTF-8")
+
+	if got := DetectLanguage(); got != LangKorean {
+		t.Errorf("DetectLanguage() = %q, want %q", got, LangKorean)
+	}
+}
+
+func TestModuleDisplayName(t *testing.T) {
+	orig := language
+	t.Cleanup(func() { language = orig })
+
+	android := ModuleInfo{ID: "android", Name: "Android Build Support", Description: "Build for Android"}
+	unknown := ModuleInfo{ID: "custom-module", Name: "Custom Module", Description: "Something new"}
+	unnamed := ModuleInfo{ID: "custom-module"}
+
+	tests := []struct {
+		lang        string
+		module      ModuleInfo
+		name        string
+		description string
+	}{
+		{LangEnglish, android, "Android Build Support", "Build for Android"},
+		{LangJapanese, android, "Android ビルドサポート", "Android 向けにビルドするためのサポート"},
+		{LangChinese, android, "Android 构建支持", "支持构建 Android 平台"},
+		{LangKorean, android, "Android 빌드 지원", "Android 플랫폼 빌드 지원"},
+		{LangJapanese, unknown, "Custom Module", "Something new"},
+		{LangJapanese, unnamed, "custom-module", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+"/"+tt.module.ID, func(t *testing.T) {
+			SetLanguage(tt.lang)
+			if got := tt.module.DisplayName(); got != tt.name {
+				t.Errorf("DisplayName() = %q, want %q", got, tt.name)
+			}
+			if got := tt.module.DisplayDescription(); got != tt.description {
+				t.Errorf("DisplayDescription() = %q, want %q", got, tt.description)
+			}
+		})
+	}
+}
+
+func TestModuleTranslationsCoverSameModules(t *testing.T) {
+	for lang, modules := range moduleTranslations {
+		for other, otherModules := range moduleTranslations {
+			for id := range modules {
+				if _, ok := otherModules[id]; !ok {
+					t.Errorf("module %s is translated for %s but not for %s", id, lang, other)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package hub
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// RemoveModule deletes a platform module from the installed editor of version:
+// its PlaybackEngines directory is removed and modules.json marks it as not
+// installed. Modules that other installed modules depend on (their parent in
+// modules.json) are refused, as are the standalone modules of the host
+// platform, whose directory holds the editor's own player. Returns the bytes
+// freed.
+func (c *Client) RemoveModule(version, module string) (freed int64, err error) {
+	id := ModuleID(module)
+	installed, editorPath, err := c.IsEditorInstalled(version)
+	if err != nil {
+		return 0, err
+	}
+	if !installed {
+		return 0, errs.New(errs.NotInstalled, "unity %s is not installed", version)
+	}
+
+	dir := c.ModuleDir(editorPath, id)
+	if dir == "" {
+		return 0, errs.WithHint(
+			errs.New(errs.Usage, "module %s is not a platform module and cannot be removed by uniforge", id),
+			"Remove it in Unity Hub.")
+	}
+	if isBuiltinPlayer(runtime.GOOS, id) {
+		return 0, errs.WithHint(
+			errs.New(errs.Usage, "module %s shares %s with the player built into the editor", id, modulePathMap[id]),
+			"Remove it in Unity Hub, which keeps the built-in player.")
+	}
+	modules := c.GetInstalledModules(editorPath)
+	if !modules[id] {
+		return 0, errs.New(errs.NotInstalled, "module %s is not installed for Unity %s", id, version)
+	}
+
+	entries, err := ReadModulesFile(c.getModulesFilePath(editorPath))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if dependents := moduleDependents(entries, modules, id); len(dependents) > 0 {
+		return 0, errs.WithHint(
+			errs.New(errs.Usage, "module %s is required by %s", id, strings.Join(dependents, ", ")),
+			fmt.Sprintf("Remove %s first.", strings.Join(dependents, ", ")))
+	}
+
+	unlock, err := c.lockInstall(version)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	defer func() { c.RecordJournal(JournalModuleRemove, version, id, err) }()
+
+	freed, _ = fsutil.DirSize(dir)
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, fmt.Errorf("failed to remove %s: %w", dir, err)
+	}
+
+	// Modules sharing the directory (Mono and IL2CPP of a platform) are gone too
+	removed := []string{id}
+	for other, otherDir := range modulePathMap {
+		if other != id && modules[other] && otherDir == modulePathMap[id] {
+			removed = append(removed, other)
+		}
+	}
+	if err := c.SetModulesInstalled(editorPath, version, removed, false); err != nil {
+		return freed, fmt.Errorf("removed %s, but failed to update modules.json: %w", dir, err)
+	}
+	return freed, nil
+}
+
+// builtinPlayers is the PlaybackEngines directory of the player every editor
+// ships with, by GOOS
+var builtinPlayers = map[string]string{
+	"darwin":  "MacStandaloneSupport",
+	"windows": "WindowsStandaloneSupport",
+	"linux":   "LinuxStandaloneSupport",
+}
+
+// isBuiltinPlayer reports whether module id lives in the directory of the
+// player built into editors on goos
+func isBuiltinPlayer(goos, id string) bool {
+	dir, ok := modulePathMap[id]
+	return ok && dir == builtinPlayers[goos]
+}
+
+// moduleDependents returns the installed modules whose parent is id, sorted
+func moduleDependents(entries []ModuleEntry, installed map[string]bool, id string) []string {
+	var dependents []string
+	for _, e := range entries {
+		if strings.EqualFold(e.Parent, id) && (e.Installed() || installed[e.ID]) {
+			dependents = append(dependents, e.ID)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
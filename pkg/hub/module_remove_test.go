@@ -0,0 +1,102 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestRemoveModule(t *testing.T) {
+	root := newLinuxEditorTree(t, "2022.3.10f1")
+	versionDir := filepath.Join(root, "2022.3.10f1")
+	engines := filepath.Join(versionDir, "Editor", "Data", "PlaybackEngines")
+	files := map[string]string{
+		filepath.Join(engines, "WebGLSupport", "BuildTools", "emcc"):       "12345",
+		filepath.Join(engines, "WebGLSupport", "Variations", "a.data"):     "678",
+		filepath.Join(engines, "AndroidPlayer", "SDK", "platform-tools"):   "1",
+		filepath.Join(engines, "AndroidPlayer", "Variations", "il2cpp.so"): "2",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modules := `[
+  {"id": "webgl", "isInstalled": true},
+  {"id": "android", "isInstalled": true},
+  {"id": "android-sdk-ndk-tools", "parent": "android", "isInstalled": true}
+]`
+	if err := os.WriteFile(filepath.Join(versionDir, "modules.json"), []byte(modules), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{}
+
+	freed, err := c.RemoveModule("2022.3.10f1", "webgl")
+	if err != nil {
+		t.Fatalf("RemoveModule() error = %v", err)
+	}
+	if freed != 8 {
+		t.Errorf("freed = %d, want 8", freed)
+	}
+	if _, err := os.Stat(filepath.Join(engines, "WebGLSupport")); !os.IsNotExist(err) {
+		t.Error("WebGLSupport was not removed")
+	}
+	entries, err := ReadModulesFile(filepath.Join(versionDir, "modules.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i := indexOfModuleEntry(entries, "webgl"); i < 0 || entries[i].Installed() {
+		t.Errorf("webgl still installed in modules.json: %+v", entries)
+	}
+	editorPath := filepath.Join(versionDir, "Editor", "Unity")
+	if c.IsModuleInstalled(editorPath, "webgl") {
+		t.Error("webgl reported installed after removal")
+	}
+
+	// The Android SDK & NDK tools depend on android
+	if _, err := c.RemoveModule("2022.3.10f1", "android"); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("RemoveModule(android) error = %v, want a usage error", err)
+	}
+	if _, err := os.Stat(filepath.Join(engines, "AndroidPlayer")); err != nil {
+		t.Error("AndroidPlayer was removed although a module depends on it")
+	}
+
+	if _, err := c.RemoveModule("2022.3.10f1", "webgl"); errs.CategoryOf(err) != errs.NotInstalled {
+		t.Errorf("second RemoveModule(webgl) error = %v, want not installed", err)
+	}
+	if _, err := c.RemoveModule("2022.3.10f1", "documentation"); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("RemoveModule(documentation) error = %v, want a usage error", err)
+	}
+
+	// LinuxStandaloneSupport is the editor's own player
+	if _, err := c.RemoveModule("2022.3.10f1", "linux-il2cpp"); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("RemoveModule(linux-il2cpp) error = %v, want a usage error", err)
+	}
+}
+
+func TestIsBuiltinPlayer(t *testing.T) {
+	tests := []struct {
+		goos, id string
+		want     bool
+	}{
+		{"darwin", "mac-il2cpp", true},
+		{"darwin", "mac-mono", true},
+		{"darwin", "windows-il2cpp", false},
+		{"windows", "windows-il2cpp", true},
+		{"windows", "windows-mono", true},
+		{"windows", "linux-il2cpp", false},
+		{"linux", "linux-il2cpp", true},
+		{"linux", "mac-mono", false},
+		{"linux", "webgl", false},
+	}
+	for _, tt := range tests {
+		if got := isBuiltinPlayer(tt.goos, tt.id); got != tt.want {
+			t.Errorf("isBuiltinPlayer(%s, %s) = %v, want %v", tt.goos, tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package hub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// ModuleEntry is an entry of an editor's modules.json, which Unity Hub uses to
+// track the modules of an installed editor. Fields not listed here are kept
+// when the file is written back, so entries survive newer Hub versions.
+type ModuleEntry struct {
+	ID            string `json:"id"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Category      string `json:"category,omitempty"`
+	DownloadURL   string `json:"downloadUrl,omitempty"`
+	Destination   string `json:"destination,omitempty"`
+	Checksum      string `json:"checksum,omitempty"`
+	InstalledSize int64  `json:"installedSize"`
+	DownloadSize  int64  `json:"downloadSize"`
+	Visible       *bool  `json:"visible,omitempty"`
+	Selected      *bool  `json:"selected,omitempty"`
+	Sync          string `json:"sync,omitempty"`
+	Parent        string `json:"parent,omitempty"`
+	IsInstalled   *bool  `json:"isInstalled"` // pointer to detect null vs false
+
+	extra map[string]json.RawMessage // Unknown fields, written back unchanged
+}
+
+// moduleEntryFields are the JSON names of the ModuleEntry fields
+var moduleEntryFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(ModuleEntry{})
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// UnmarshalJSON decodes an entry, keeping unknown fields
+func (e *ModuleEntry) UnmarshalJSON(data []byte) error {
+	type plain ModuleEntry
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name := range fields {
+		if moduleEntryFields[name] {
+			delete(fields, name)
+		}
+	}
+	*e = ModuleEntry(p)
+	if len(fields) > 0 {
+		e.extra = fields
+	}
+	return nil
+}
+
+// MarshalJSON encodes an entry with the unknown fields it was read with
+func (e ModuleEntry) MarshalJSON() ([]byte, error) {
+	type plain ModuleEntry
+	data, err := json.Marshal(plain(e))
+	if err != nil || len(e.extra) == 0 {
+		return data, err
+	}
+
+	names := make([]string, 0, len(e.extra))
+	for name := range e.extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for _, name := range names {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteByte(',')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(e.extra[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Installed reports whether the entry is marked as installed
+func (e ModuleEntry) Installed() bool {
+	return e.IsInstalled != nil && *e.IsInstalled
+}
+
+// ReadModulesFile reads the modules.json at path
+func ReadModulesFile(path string) ([]ModuleEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var modules []ModuleEntry
+	if err := json.Unmarshal(data, &modules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return modules, nil
+}
+
+// WriteModulesFile atomically replaces the modules.json at path
+func WriteModulesFile(path string, modules []ModuleEntry) error {
+	if modules == nil {
+		modules = []ModuleEntry{}
+	}
+	data, err := json.MarshalIndent(modules, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, data, 0644)
+}
+
+// SetModulesInstalled updates modules.json of the editor at editorPath after
+// modules were installed or removed without Unity Hub. Modules it does not list
+// yet are added, with metadata from the release cache when available.
+func (c *Client) SetModulesInstalled(editorPath, version string, moduleIDs []string, installed bool) error {
+	path := c.getModulesFilePath(editorPath)
+	if path == "" {
+		return fmt.Errorf("could not determine modules file path")
+	}
+	defer c.InvalidateEditors()
+	return setModulesInstalled(path, moduleIDs, installed, c.cachedModuleInfo(version))
+}
+
+// setModulesInstalled sets isInstalled of moduleIDs in the modules.json at path.
+// A missing file is created.
+func setModulesInstalled(path string, moduleIDs []string, installed bool, known []ModuleInfo) error {
+	modules, err := ReadModulesFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, id := range moduleIDs {
+		i := indexOfModuleEntry(modules, id)
+		if i < 0 {
+			if !installed {
+				continue
+			}
+			modules = append(modules, newModuleEntry(id, known))
+			i = len(modules) - 1
+		}
+		modules[i].IsInstalled = &installed
+	}
+
+	return WriteModulesFile(path, modules)
+}
+
+func indexOfModuleEntry(modules []ModuleEntry, id string) int {
+	for i, m := range modules {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// newModuleEntry returns an entry for id, filled from the matching release module
+func newModuleEntry(id string, known []ModuleInfo) ModuleEntry {
+	entry := ModuleEntry{ID: id}
+	for _, m := range known {
+		if m.ID != id {
+			continue
+		}
+		visible := m.IsVisible()
+		entry.Name = m.Name
+		entry.Description = m.Description
+		entry.Category = m.Category
+		entry.DownloadSize = m.DownloadSize
+		entry.InstalledSize = m.InstalledSize
+		entry.Visible = &visible
+		break
+	}
+	return entry
+}
+
+// cachedModuleInfo returns the modules of version from the release cache, without
+// touching the network
+func (c *Client) cachedModuleInfo(version string) []ModuleInfo {
+	cache, err := c.LoadCache()
+	if err != nil || cache == nil {
+		ui.Debug("No release cache for module metadata", "error", err)
+		return nil
+	}
+	for _, r := range c.ConvertCacheToReleases(cache) {
+		if r.Version == version {
+			return r.Modules
+		}
+	}
+	return nil
+}
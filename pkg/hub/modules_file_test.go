@@ -0,0 +1,127 @@
+package hub
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModulesFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "modules.json")
+	writeTestFile(t, path, `[
+  {
+    "id": "android",
+    "name": "Android Build Support",
+    "downloadUrl": "https://download.unity3d.com/android.pkg",
+    "category": "Platforms",
+    "installedSize": 2048,
+    "downloadSize": 1024,
+    "visible": true,
+    "selected": false,
+    "isInstalled": false,
+    "eulaUrl1": "https://unity.com/eula",
+    "renameTo": "{UNITY_PATH}/PlaybackEngines/AndroidPlayer"
+  },
+  {"id": "ios", "isInstalled": null}
+]`)
+
+	modules, err := ReadModulesFile(path)
+	if err != nil {
+		t.Fatalf("ReadModulesFile() error = %v", err)
+	}
+	if len(modules) != 2 {
+		t.Fatalf("got %d modules, want 2", len(modules))
+	}
+	android := modules[0]
+	if android.Name != "Android Build Support" || android.DownloadSize != 1024 || android.Visible == nil || !*android.Visible {
+		t.Errorf("known fields not decoded: %+v", android)
+	}
+	if android.Installed() || modules[1].Installed() || modules[1].IsInstalled != nil {
+		t.Errorf("isInstalled not decoded: %v, %v", android.IsInstalled, modules[1].IsInstalled)
+	}
+
+	installed := true
+	modules[0].IsInstalled = &installed
+	if err := WriteModulesFile(path, modules); err != nil {
+		t.Fatalf("WriteModulesFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw []map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("written file is not valid JSON: %v\n%s", err, data)
+	}
+	want := map[string]any{
+		"isInstalled": true,
+		"selected":    false,
+		"eulaUrl1":    "https://unity.com/eula",
+		"renameTo":    "{UNITY_PATH}/PlaybackEngines/AndroidPlayer",
+		"downloadUrl": "https://download.unity3d.com/android.pkg",
+	}
+	for key, value := range want {
+		if raw[0][key] != value {
+			t.Errorf("%s = %v, want %v", key, raw[0][key], value)
+		}
+	}
+	if v, ok := raw[1]["isInstalled"]; !ok || v != nil {
+		t.Errorf("null isInstalled not kept: %v", raw[1])
+	}
+}
+
+func TestSetModulesInstalled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "modules.json")
+	writeTestFile(t, path, `[{"id":"android","name":"Android Build Support","isInstalled":false,"sync":"android-sdk"},{"id":"ios","isInstalled":true}]`)
+	known := []ModuleInfo{{ID: "webgl", Name: "WebGL Build Support", Category: "PLATFORM", DownloadSize: 10, InstalledSize: 20}}
+
+	if err := setModulesInstalled(path, []string{"android", "webgl"}, true, known); err != nil {
+		t.Fatalf("setModulesInstalled() error = %v", err)
+	}
+	modules, err := ReadModulesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 3 {
+		t.Fatalf("got %d entries, want 3", len(modules))
+	}
+	for _, m := range modules {
+		if !m.Installed() {
+			t.Errorf("module %s not marked installed", m.ID)
+		}
+	}
+	if modules[0].Name != "Android Build Support" || modules[0].Sync != "android-sdk" {
+		t.Errorf("existing fields were not kept: %+v", modules[0])
+	}
+	if webgl := modules[2]; webgl.Name != "WebGL Build Support" || webgl.InstalledSize != 20 || webgl.Visible == nil || !*webgl.Visible {
+		t.Errorf("new entry not filled from release metadata: %+v", webgl)
+	}
+
+	// Removing a module keeps its entry; unknown modules are not added
+	if err := setModulesInstalled(path, []string{"ios", "appletv"}, false, nil); err != nil {
+		t.Fatalf("setModulesInstalled() error = %v", err)
+	}
+	modules, err = ReadModulesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 3 || modules[1].ID != "ios" || modules[1].Installed() {
+		t.Errorf("ios not marked removed: %+v", modules)
+	}
+}
+
+func TestSetModulesInstalledCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "modules.json")
+	if err := setModulesInstalled(path, []string{"android"}, true, nil); err != nil {
+		t.Fatalf("setModulesInstalled() error = %v", err)
+	}
+	modules, err := ReadModulesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 1 || modules[0].ID != "android" || !modules[0].Installed() {
+		t.Errorf("modules = %+v", modules)
+	}
+}
@@ -0,0 +1,45 @@
+package hub
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseEnabled turns on mouse reporting in the TUIs
+var mouseEnabled = true
+
+// SetMouse enables or disables the mouse in the TUIs. Without it the TUIs are
+// drawn inline instead of on the alternate screen.
+func SetMouse(enabled bool) {
+	mouseEnabled = enabled
+}
+
+// tuiProgramOptions returns the bubbletea options of the TUIs. Clicks need the
+// alternate screen, where the rows of the view are at known screen rows.
+// Terminals without mouse reporting never send mouse events, so the keys keep
+// working there.
+func tuiProgramOptions() []tea.ProgramOption {
+	if !mouseEnabled {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+}
+
+// scrollList moves cursor through a list of total rows for a mouse event. The
+// wheel moves it by one row; a click selects the clicked row, where rows start
+// to end are visible from screen row top on.
+func scrollList(msg tea.MouseMsg, cursor, total, start, end, top int) int {
+	if msg.Action != tea.MouseActionPress || total == 0 {
+		return cursor
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return max(0, cursor-1)
+	case tea.MouseButtonWheelDown:
+		return min(total-1, cursor+1)
+	case tea.MouseButtonLeft:
+		if row := start + msg.Y - top; msg.Y >= top && row < end {
+			return row
+		}
+	}
+	return cursor
+}
@@ -0,0 +1,139 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// ProjectOpen records when uniforge last launched a project and with which editor
+type ProjectOpen struct {
+	Time          time.Time `json:"time"`
+	EditorVersion string    `json:"editorVersion"`
+}
+
+// openedFileData is the structure of last-opened.json, keyed by absolute project path
+type openedFileData struct {
+	Projects map[string]ProjectOpen `json:"projects"`
+}
+
+// getOpenedFilePath returns the path to uniforge's last-opened state file
+func (c *Client) getOpenedFilePath() string {
+	if c.openedFileOverride != "" {
+		return c.openedFileOverride
+	}
+	return filepath.Join(c.StateDir(), "last-opened.json")
+}
+
+// LoadProjectOpens returns the last uniforge launch of every project, keyed by absolute path
+func (c *Client) LoadProjectOpens() (map[string]ProjectOpen, error) {
+	data, err := os.ReadFile(c.getOpenedFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]ProjectOpen{}, nil
+		}
+		return nil, fmt.Errorf("failed to read last-opened state: %w", err)
+	}
+
+	var state openedFileData
+	if err := json.Unmarshal(data, &state); err != nil {
+		ui.Debug("Discarding corrupted last-opened state", "error", err)
+		return map[string]ProjectOpen{}, nil
+	}
+	if state.Projects == nil {
+		state.Projects = map[string]ProjectOpen{}
+	}
+	return state.Projects, nil
+}
+
+// RecordProjectOpen stores that the project at projectPath was launched now with
+// editorVersion. The project's entry in Unity Hub's projects list is touched as
+// Hub does when it opens a project, so Hub's recent ordering matches.
+func (c *Client) RecordProjectOpen(projectPath, editorVersion string) error {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	stateFile := c.getOpenedFilePath()
+	lock, err := fsutil.Lock(stateFile, cacheLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock last-opened state: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	opens, err := c.LoadProjectOpens()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	opens[filepath.Clean(absPath)] = ProjectOpen{
+		Time:          now,
+		EditorVersion: editorVersion,
+	}
+
+	// Hub's list is a convenience; a Hub file uniforge cannot write is no failure
+	if _, err := c.TouchHubProject(absPath, now); err != nil {
+		ui.Debug("Failed to update Unity Hub's recent projects", "error", err)
+	}
+
+	data, err := json.MarshalIndent(openedFileData{Projects: opens}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode last-opened state: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(stateFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write last-opened state: %w", err)
+	}
+	return nil
+}
+
+// fillLastOpened sets LastOpened and LastOpenedEditor from uniforge's own launch history.
+// Failures are ignored since the history is informational only.
+func (c *Client) fillLastOpened(projects []ProjectInfo) {
+	opens, err := c.LoadProjectOpens()
+	if err != nil {
+		ui.Debug("Failed to load last-opened state", "error", err)
+		return
+	}
+	for i := range projects {
+		if open, ok := opens[filepath.Clean(projects[i].Path)]; ok {
+			projects[i].LastOpened = open.Time
+			projects[i].LastOpenedEditor = open.EditorVersion
+		}
+	}
+}
+
+// FormatTimeAgo formats t relative to now, e.g. "3 days ago". The zero time yields "never".
+func FormatTimeAgo(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	d := now.Sub(t)
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s ago", unit)
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour")
+	case d < 30*24*time.Hour:
+		return plural(int(d.Hours()/24), "day")
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/(24*30)), "month")
+	default:
+		return plural(int(d.Hours()/(24*365)), "year")
+	}
+}
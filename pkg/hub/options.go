@@ -0,0 +1,78 @@
+package hub
+
+import (
+	"os"
+	"os/exec"
+	"slices"
+)
+
+// ClientOptions sets every location a Client reads, so it can be used without
+// consulting the environment (see NewClientWithOptions)
+type ClientOptions struct {
+	HubPath     string   // Unity Hub executable; "" disables Hub CLI fallbacks
+	HubDataDir  string   // Unity Hub's configuration directory (editors-v2.json, projects-v1.json, ...)
+	EditorPaths []string // Directories editors are installed in, besides Hub's secondary install path
+	CacheDir    string   // Directory of uniforge's caches
+	StateDir    string   // Directory of state that outlives the caches (operation journal); "" uses CacheDir
+}
+
+// DefaultClientOptions returns the options NewClient uses, detected from the
+// environment (HOME, APPDATA, UNIFORGE_HUB_PATH, UNIFORGE_EDITOR_BASE_PATH, ...)
+func DefaultClientOptions() ClientOptions {
+	c := &Client{}
+	opts := ClientOptions{
+		HubPath:    detectUnityHub(),
+		HubDataDir: c.getUnityHubBasePath(),
+		CacheDir:   c.CacheDir(),
+		StateDir:   c.StateDir(),
+	}
+	for _, path := range append(c.getEditorInstallPaths(), c.getDefaultInstallPaths()...) {
+		if !slices.Contains(opts.EditorPaths, path) && path != c.getSecondaryInstallPath() {
+			opts.EditorPaths = append(opts.EditorPaths, path)
+		}
+	}
+	return opts
+}
+
+// NewClientWithOptions returns a Client that reads only the given locations.
+// Unlike NewClient it does not look up Unity Hub or read environment variables.
+func NewClientWithOptions(opts ClientOptions) *Client {
+	opts.EditorPaths = slices.Clone(opts.EditorPaths)
+	return &Client{
+		hubPath:            opts.HubPath,
+		options:            &opts,
+		InstallLockTimeout: DefaultInstallLockTimeout,
+	}
+}
+
+// detectUnityHub returns the Unity Hub executable, or "" if it is not found
+func detectUnityHub() string {
+	// 1. Check environment variable first
+	envPath := os.Getenv("UNIFORGE_HUB_PATH")
+	if envPath != "" && fileExists(envPath) {
+		return envPath
+	}
+
+	// 2. Try to read from hubInfo.json
+	if path := getHubPathFromHubInfo(); path != "" {
+		return path
+	}
+
+	// 3. Try default paths
+	for _, path := range getUnityHubPaths() {
+		if fileExists(path) {
+			return path
+		}
+	}
+
+	// 4. Try the uninstall registry entry (Windows)
+	if path := registryHubPath(); path != "" {
+		return path
+	}
+
+	// 5. Try PATH lookup
+	if path, err := exec.LookPath("Unity Hub"); err == nil {
+		return path
+	}
+	return ""
+}
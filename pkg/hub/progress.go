@@ -0,0 +1,96 @@
+package hub
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// hubProgressPattern matches Unity Hub CLI progress lines such as
+// "[Unity 2022.3.10f1] downloading 45.31%" or "[Android Build Support] installing (80%)"
+var hubProgressPattern = regexp.MustCompile(`^\[([^\]]+)\]\s*([A-Za-z][A-Za-z ]*?)[\s:(]*(\d+(?:\.\d+)?)\s*%`)
+
+// hubFinishedPattern matches lines reporting that an item is finished
+var hubFinishedPattern = regexp.MustCompile(`(?i)^\[([^\]]+)\].*\b(installed successfully|finished|completed|done)\b`)
+
+// hubProgress is a parsed progress line
+type hubProgress struct {
+	Item    string
+	Phase   string
+	Percent float64
+}
+
+// parseHubProgressLine extracts item, phase and percentage from a Hub CLI output line
+func parseHubProgressLine(line string) (hubProgress, bool) {
+	m := hubProgressPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return hubProgress{}, false
+	}
+	percent, err := strconv.ParseFloat(m[3], 64)
+	if err != nil || percent > 100 {
+		return hubProgress{}, false
+	}
+	return hubProgress{Item: strings.TrimSpace(m[1]), Phase: strings.ToLower(strings.TrimSpace(m[2])), Percent: percent}, true
+}
+
+// trackHubProgress reads Unity Hub CLI output and shows one progress step per
+// downloaded or installed item. Other lines are passed through.
+func trackHubProgress(r io.Reader, progress *ui.Progress) {
+	steps := make(map[string]*ui.Progress)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanLinesOrCR)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if p, ok := parseHubProgressLine(line); ok {
+			key := p.Item + "\x00" + p.Phase
+			step, exists := steps[key]
+			if !exists {
+				step = progress.Step(p.Item+" ("+p.Phase+")", 100)
+				steps[key] = step
+			}
+			step.Set(int64(p.Percent))
+			if p.Percent >= 100 {
+				step.Done(nil)
+			}
+			continue
+		}
+
+		if m := hubFinishedPattern.FindStringSubmatch(line); m != nil {
+			for key, step := range steps {
+				if strings.HasPrefix(key, strings.TrimSpace(m[1])+"\x00") {
+					step.Done(nil)
+				}
+			}
+		}
+		progress.Println("%s", line)
+	}
+}
+
+// scanLinesOrCR splits on \n, \r\n and bare \r, since progress output often
+// rewrites the current line with \r
+func scanLinesOrCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		advance = i + 1
+		if data[i] == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+			advance++
+		}
+		return advance, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,40 @@
+package hub
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestParseHubProgressLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want hubProgress
+		ok   bool
+	}{
+		{"[Unity 2022.3.10f1] downloading 45.31%", hubProgress{"Unity 2022.3.10f1", "downloading", 45.31}, true},
+		{"[Android Build Support] installing (80%)", hubProgress{"Android Build Support", "installing", 80}, true},
+		{"[WebGL Build Support] Downloading: 100 %", hubProgress{"WebGL Build Support", "downloading", 100}, true},
+		{"[Unity 2022.3.10f1] installed successfully.", hubProgress{}, false},
+		{"Some other output 50%", hubProgress{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseHubProgressLine(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseHubProgressLine(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestScanLinesOrCR(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("a 10%\ra 20%\r\nb\nc"))
+	scanner.Split(scanLinesOrCR)
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if got := strings.Join(lines, "|"); got != "a 10%|a 20%|b|c" {
+		t.Errorf("lines = %q, want %q", got, "a 10%|a 20%|b|c")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -20,6 +21,8 @@ type keyMap struct {
 	Enter    key.Binding
 	Editor   key.Binding
 	CopyPath key.Binding
+	Tag      key.Binding
+	Help     key.Binding
 	Quit     key.Binding
 }
 
@@ -44,9 +47,17 @@ var keys = keyMap{
 		key.WithKeys("ctrl+p"),
 		key.WithHelp("^P", "copy path"),
 	),
+	Tag: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("^T", "cycle tag filter"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "show this help"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("esc", "ctrl+c"),
-		key.WithHelp("Esc", "quit"),
+		key.WithHelp("Esc", "clear filter/quit"),
 	),
 }
 
@@ -79,6 +90,9 @@ var (
 
 	statusStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("42"))
+
+	tagStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("177"))
 )
 
 // OpenProjectFunc is a function type for opening a project in Unity
@@ -98,6 +112,9 @@ type projectModel struct {
 	openProjectFn OpenProjectFunc
 	editorName    string // detected editor name for help display
 	filterInput   textinput.Model
+	tagFilter     string // only projects with this tag; "" shows all but archived
+	showHelp      bool   // the key binding overlay is shown
+	height        int    // terminal height, 0 until the first tea.WindowSizeMsg
 }
 
 type projectsLoadedMsg struct {
@@ -120,7 +137,7 @@ func initialProjectModel(openFn OpenProjectFunc) projectModel {
 	return projectModel{
 		loading:       true,
 		openProjectFn: openFn,
-		editorName:    getExternalEditor(),
+		editorName:    ExternalEditor(),
 		filterInput:   ti,
 	}
 }
@@ -131,7 +148,7 @@ func (m projectModel) Init() tea.Cmd {
 
 func loadProjects() tea.Msg {
 	client := NewClient()
-	projects, err := client.ListProjectsWithGit()
+	projects, err := client.ListProjectsWithOptions(ProjectListOptions{Git: true, ProjectVersions: true})
 	return projectsLoadedMsg{projects: projects, err: err}
 }
 
@@ -140,7 +157,7 @@ func (m projectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case projectsLoadedMsg:
 		m.loading = false
 		m.projects = msg.projects
-		m.filtered = msg.projects
+		m.filtered = m.filterProjects("")
 		m.err = msg.err
 		return m, nil
 
@@ -155,12 +172,34 @@ func (m projectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.quitting = true
 		return m, tea.Quit
 
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		// Leave room for the "> " prompt
+		m.filterInput.Width = max(10, msg.Width-3)
+		return m, nil
+
+	case tea.MouseMsg:
+		if m.loading || m.launching || m.showHelp {
+			return m, nil
+		}
+		// The project list is the first thing on screen
+		start, end := listWindow(m.cursor, len(m.filtered), m.listRows())
+		m.cursor = scrollList(msg, m.cursor, len(m.filtered), start, end, 0)
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.loading {
 			return m, nil
 		}
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
 
 		switch {
+		case key.Matches(msg, keys.Help):
+			m.showHelp = true
+			return m, nil
 		case key.Matches(msg, keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
@@ -190,11 +229,16 @@ func (m projectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, copyPath(m.filtered[m.cursor])
 			}
 			return m, nil
+		case key.Matches(msg, keys.Tag):
+			m.tagFilter = nextTag(ProjectTagNames(m.projects), m.tagFilter)
+			m.filtered = m.filterProjects(m.filterInput.Value())
+			m.cursor = 0
+			return m, nil
 		case key.Matches(msg, keys.Quit):
 			// If filter has text, clear it first
 			if m.filterInput.Value() != "" {
 				m.filterInput.SetValue("")
-				m.filtered = m.projects
+				m.filtered = m.filterProjects("")
 				m.cursor = 0
 				return m, nil
 			}
@@ -218,19 +262,39 @@ func (m projectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// filterProjects filters projects by name (case-insensitive)
+// filterProjects filters projects by the tag filter and fuzzy-matches their names, best match first
 func (m projectModel) filterProjects(query string) []ProjectInfo {
-	if query == "" {
-		return m.projects
+	var tags []string
+	if m.tagFilter != "" {
+		tags = []string{m.tagFilter}
+	}
+	projects := FilterProjectsByTags(m.projects, tags, false)
+	return fuzzyFilter(projects, query, func(p ProjectInfo) string { return p.Title })
+}
+
+// listRows returns how many projects fit on the screen above the help and
+// the prompt; all of them until the terminal size is known
+func (m projectModel) listRows() int {
+	if m.height <= 0 {
+		return max(1, len(m.filtered))
 	}
-	query = strings.ToLower(query)
-	var result []ProjectInfo
-	for _, p := range m.projects {
-		if strings.Contains(strings.ToLower(p.Title), query) {
-			result = append(result, p)
+	return max(1, m.height-2)
+}
+
+// nextTag returns the tag after current in tags, or "" (no filter) after the last one
+func nextTag(tags []string, current string) string {
+	if current == "" {
+		if len(tags) == 0 {
+			return ""
+		}
+		return tags[0]
+	}
+	for i, tag := range tags {
+		if tag == current && i+1 < len(tags) {
+			return tags[i+1]
 		}
 	}
-	return result
+	return ""
 }
 
 func (m projectModel) View() string {
@@ -257,15 +321,22 @@ func (m projectModel) View() string {
 		return "No projects registered in Unity Hub.\n"
 	}
 
+	if m.showHelp {
+		return renderKeyHelp("Project Keys", []key.Binding{
+			keys.Up, keys.Down, keys.Enter, keys.Editor, keys.CopyPath, keys.Tag, keys.Help, keys.Quit,
+		}, promptStyle, versionStyle)
+	}
+
 	var b strings.Builder
 
 	// Calculate max widths for alignment
 	maxTitleLen := 0
 	maxVersionLen := 0
 	maxBranchLen := 0
+	now := time.Now()
 	for _, p := range m.filtered {
-		if len(p.Title) > maxTitleLen {
-			maxTitleLen = len(p.Title)
+		if w := lipgloss.Width(projectLabel(p)); w > maxTitleLen {
+			maxTitleLen = w
 		}
 		if len(p.Version) > maxVersionLen {
 			maxVersionLen = len(p.Version)
@@ -276,9 +347,15 @@ func (m projectModel) View() string {
 	}
 
 	// Project list
-	for i, p := range m.filtered {
+	query := m.filterInput.Value()
+	start, end := listWindow(m.cursor, len(m.filtered), m.listRows())
+	for i := start; i < end; i++ {
+		p := m.filtered[i]
 		// Build line content
-		title := p.Title + strings.Repeat(" ", maxTitleLen-len(p.Title))
+		title := highlightMatches(p.Title, query, maxTitleLen-lipgloss.Width(projectLabel(p))+lipgloss.Width(p.Title))
+		if p.HasTag(TagFavorite) {
+			title = "★ " + title
+		}
 		version := p.Version + strings.Repeat(" ", maxVersionLen-len(p.Version))
 
 		var gitInfo string
@@ -294,9 +371,17 @@ func (m projectModel) View() string {
 		}
 
 		line := " " + title + "  " + versionStyle.Render(version) + "  " + gitInfo
+		if !p.LastOpened.IsZero() {
+			line += "  " + counterStyle.Render("opened "+FormatTimeAgo(p.LastOpened, now))
+		}
+		for _, tag := range p.Tags {
+			if tag != TagFavorite {
+				line += " " + tagStyle.Render("#"+tag)
+			}
+		}
 
 		if i == m.cursor {
-			b.WriteString(selectedStyle.Render(line))
+			b.WriteString(selectedStyle.Render(ui.MarkSelected(line)))
 		} else {
 			b.WriteString(normalStyle.Render(line))
 		}
@@ -312,8 +397,14 @@ func (m projectModel) View() string {
 	// Counter and help
 	editorLabel := strings.ToUpper(m.editorName[:1]) + m.editorName[1:]
 	counter := fmt.Sprintf("  %d/%d", len(m.filtered), len(m.projects))
-	help := fmt.Sprintf("  Enter:Unity ^E:%s ^P:Copy Esc:Quit", editorLabel)
+	help := "  " + strings.Join([]string{
+		keyHint(keys.Enter, "Unity"), keyHint(keys.Editor, editorLabel), keyHint(keys.CopyPath, "Copy"),
+		keyHint(keys.Tag, "Tag"), keyHint(keys.Help, "Help"), keyHint(keys.Quit, "Quit"),
+	}, " ")
 	b.WriteString(counterStyle.Render(counter + help))
+	if m.tagFilter != "" {
+		b.WriteString(tagStyle.Render("  #" + m.tagFilter))
+	}
 	b.WriteString("\n")
 
 	// Prompt
@@ -323,6 +414,14 @@ func (m projectModel) View() string {
 	return b.String()
 }
 
+// projectLabel is the project title, marked with a star for favorites
+func projectLabel(p ProjectInfo) string {
+	if p.HasTag(TagFavorite) {
+		return "★ " + p.Title
+	}
+	return p.Title
+}
+
 func openInUnity(p ProjectInfo, openFn OpenProjectFunc) tea.Cmd {
 	return func() tea.Msg {
 		if openFn == nil {
@@ -338,7 +437,7 @@ func openInUnity(p ProjectInfo, openFn OpenProjectFunc) tea.Cmd {
 
 func openInEditor(p ProjectInfo) tea.Cmd {
 	return func() tea.Msg {
-		editorCmd := getExternalEditor()
+		editorCmd := ExternalEditor()
 		cmd := exec.Command(editorCmd, p.Path)
 		err := cmd.Start()
 		if err != nil {
@@ -358,7 +457,9 @@ func copyPath(p ProjectInfo) tea.Cmd {
 	}
 }
 
-func getExternalEditor() string {
+// ExternalEditor returns the command of the IDE to open projects with:
+// UNIFORGE_EDITOR, else the first of rider, cursor and code on PATH, else EDITOR
+func ExternalEditor() string {
 	// Explicit override
 	if editor := os.Getenv("UNIFORGE_EDITOR"); editor != "" {
 		return editor
@@ -406,7 +507,14 @@ func isCommandAvailable(name string) bool {
 func RunProjectTUI(client *Client, openFn OpenProjectFunc) error {
 	ui.Debug("Starting project TUI")
 
-	p := tea.NewProgram(initialProjectModel(openFn))
-	_, err := p.Run()
-	return err
+	p := tea.NewProgram(initialProjectModel(openFn), tuiProgramOptions()...)
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+	// The alternate screen is gone after exiting; show the last message again
+	if m, ok := final.(projectModel); ok && mouseEnabled && m.status != "" {
+		fmt.Println(statusStyle.Render(m.status))
+	}
+	return nil
 }
@@ -0,0 +1,85 @@
+package hub
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// projectVersionFile is a ProjectVersion.txt as it was read, kept until the
+// file changes
+type projectVersionFile struct {
+	modTime time.Time
+	version string
+}
+
+// VersionMismatch reports whether Unity Hub records another editor version
+// than the project's ProjectVersion.txt, for example after the project was
+// upgraded by opening it in a newer editor without Hub
+func (p ProjectInfo) VersionMismatch() bool {
+	return p.ActualVersion != "" && p.HubVersion != "" && p.ActualVersion != p.HubVersion
+}
+
+// fillProjectVersions reads the ProjectVersion.txt of the projects in
+// parallel and makes its version theirs. Projects without one keep Hub's.
+func (c *Client) fillProjectVersions(projects []ProjectInfo) {
+	forEachConcurrent(len(projects), snapshotConcurrency, func(i int) {
+		p := &projects[i]
+		if p.ActualVersion = c.projectVersion(p.Path); p.ActualVersion != "" {
+			p.Version = p.ActualVersion
+		}
+	})
+}
+
+// projectVersion returns the editor version in the ProjectVersion.txt of the
+// project at projectPath, or "" if it cannot be read. Files are read again
+// only when they changed since this client last read them.
+func (c *Client) projectVersion(projectPath string) string {
+	path := filepath.Join(projectPath, "ProjectSettings", "ProjectVersion.txt")
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+
+	c.projectVersionsMu.Lock()
+	cached, ok := c.projectVersions[path]
+	c.projectVersionsMu.Unlock()
+	if ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.version
+	}
+
+	version, err := readProjectVersionFile(path)
+	if err != nil {
+		ui.Debug("Failed to read ProjectVersion.txt", "path", path, "error", err)
+		return ""
+	}
+
+	c.projectVersionsMu.Lock()
+	if c.projectVersions == nil {
+		c.projectVersions = make(map[string]projectVersionFile)
+	}
+	c.projectVersions[path] = projectVersionFile{modTime: info.ModTime(), version: version}
+	c.projectVersionsMu.Unlock()
+	return version
+}
+
+// readProjectVersionFile returns the m_EditorVersion of a ProjectVersion.txt
+func readProjectVersionFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "m_EditorVersion:"); ok {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	return "", scanner.Err()
+}
@@ -1,47 +1,53 @@
 package hub
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/trace"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
 
 // ProjectInfo represents a Unity project registered in Unity Hub
 type ProjectInfo struct {
-	Title        string
-	Path         string
-	Version      string
-	LastModified time.Time
-	GitBranch    string // Current git branch
-	GitStatus    string // "clean", "dirty", or "N uncommitted"
-}
-
-// projectsFileData represents the structure of projects-v1.json
-type projectsFileData struct {
-	SchemaVersion string                  `json:"schema_version"`
-	Data          map[string]projectEntry `json:"data"`
+	Title            string
+	Path             string
+	Version          string // ActualVersion when it was read, otherwise HubVersion
+	HubVersion       string // As recorded by Unity Hub, which can be stale after upgrades outside Hub
+	ActualVersion    string // From ProjectSettings/ProjectVersion.txt; "" unless read (see ProjectListOptions)
+	LastModified     time.Time
+	LastOpened       time.Time // When uniforge last launched the project (zero if never)
+	LastOpenedEditor string    // Editor version used for that launch
+	GitBranch        string    // Current git branch
+	GitStatus        string    // "clean", "dirty", or "N uncommitted"
+	Tags             []string  // uniforge's own tags, sorted
+	CloudProjectID   string    // Linked Unity Cloud project, as recorded by Unity Hub
+	CloudOrgID       string    // Organization of the linked Unity Cloud project
 }
 
+// projectEntry is a project entry of projects-v1.json, keyed by its path
 type projectEntry struct {
 	Title        string `json:"title,omitempty"`
 	Path         string `json:"path,omitempty"`
 	Version      string `json:"version,omitempty"`
 	LastModified int64  `json:"lastModified,omitempty"`
 	CloudProject string `json:"cloudProjectId,omitempty"`
+	CloudOrg     string `json:"organizationId,omitempty"`
 	ProjectName  string `json:"projectName,omitempty"`
 }
 
 // ListProjects returns all projects registered in Unity Hub
 func (c *Client) ListProjects() ([]ProjectInfo, error) {
+	span := trace.Start(trace.KindCache, "Unity Hub projects-v1.json")
+	defer span.End()
+
 	projectsFilePath := c.getProjectsFilePath()
 	if projectsFilePath == "" {
 		return nil, fmt.Errorf("could not determine Unity Hub projects file path")
@@ -55,17 +61,27 @@ func (c *Client) ListProjects() ([]ProjectInfo, error) {
 		return nil, fmt.Errorf("failed to read projects file: %w", err)
 	}
 
-	var projectsData projectsFileData
-	if err := json.Unmarshal(data, &projectsData); err != nil {
-		return nil, fmt.Errorf("failed to parse projects file: %w", err)
+	entries, err := decodeHubFile[projectEntry]("projects-v1.json", data, projectsSchemaVersion)
+	if err != nil {
+		return nil, err
 	}
 
 	var result []ProjectInfo
-	for _, entry := range projectsData.Data {
+	for _, e := range entries {
+		entry := e.Value
+		if entry.Path == "" {
+			entry.Path = e.Key
+		}
+		if entry.Path == "" {
+			continue
+		}
 		info := ProjectInfo{
-			Path:    entry.Path,
-			Title:   entry.Title,
-			Version: entry.Version,
+			Path:           entry.Path,
+			Title:          entry.Title,
+			Version:        entry.Version,
+			HubVersion:     entry.Version,
+			CloudProjectID: entry.CloudProject,
+			CloudOrgID:     entry.CloudOrg,
 		}
 
 		// Use directory name as title if not specified
@@ -81,26 +97,48 @@ func (c *Client) ListProjects() ([]ProjectInfo, error) {
 		result = append(result, info)
 	}
 
+	c.fillLastOpened(result)
+	c.fillTags(result)
+
 	return result, nil
 }
 
+// ProjectListOptions selects what ListProjectsWithOptions reads besides
+// Unity Hub's projects file
+type ProjectListOptions struct {
+	Git             bool // Branch and status of each project
+	ProjectVersions bool // Editor version from each project's ProjectVersion.txt
+}
+
 // ListProjectsWithGit returns all projects with Git information
 func (c *Client) ListProjectsWithGit() ([]ProjectInfo, error) {
+	return c.ListProjectsWithOptions(ProjectListOptions{Git: true})
+}
+
+// ListProjectsWithOptions returns all projects with the information opts
+// asks for, read for all projects in parallel
+func (c *Client) ListProjectsWithOptions(opts ProjectListOptions) ([]ProjectInfo, error) {
 	projects, err := c.ListProjects()
 	if err != nil {
 		return nil, err
 	}
 
-	// Fetch git info in parallel
-	var wg sync.WaitGroup
-	for i := range projects {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-			c.fillGitInfo(&projects[idx])
-		}(i)
+	if opts.ProjectVersions {
+		c.fillProjectVersions(projects)
+	}
+
+	if opts.Git {
+		// Fetch git info in parallel
+		var wg sync.WaitGroup
+		for i := range projects {
+			wg.Add(1)
+			go func(idx int) {
+				defer wg.Done()
+				c.fillGitInfo(&projects[idx])
+			}(i)
+		}
+		wg.Wait()
 	}
-	wg.Wait()
 
 	return projects, nil
 }
@@ -115,6 +153,11 @@ func (e *MultipleMatchError) Error() string {
 	return fmt.Sprintf("multiple projects match '%s': found %d", e.Query, len(e.Matches))
 }
 
+// Unwrap categorizes the error as errs.MultipleMatches
+func (e *MultipleMatchError) Unwrap() error {
+	return errs.MultipleMatches
+}
+
 // FindProjectsByName finds all projects matching the name (case-insensitive)
 // Returns matches in priority order: exact match, prefix match, contains match
 func (c *Client) FindProjectsByName(name string) ([]ProjectInfo, error) {
@@ -166,7 +209,7 @@ func (c *Client) GetProjectByName(name string) (*ProjectInfo, error) {
 	}
 
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("project not found: %s", name)
+		return nil, errs.New(errs.NotFound, "project not found: %s", name)
 	}
 
 	if len(matches) > 1 {
@@ -185,7 +228,7 @@ func (c *Client) GetProjectByIndex(index int) (*ProjectInfo, error) {
 	}
 
 	if index < 1 || index > len(projects) {
-		return nil, fmt.Errorf("project index out of range: %d (1-%d)", index, len(projects))
+		return nil, errs.New(errs.NotFound, "project index out of range: %d (1-%d)", index, len(projects))
 	}
 
 	p := projects[index-1]
@@ -204,6 +247,26 @@ func (c *Client) GetProject(nameOrIndex string) (*ProjectInfo, error) {
 	return c.GetProjectByName(nameOrIndex)
 }
 
+// GetProjectByPath returns the project at path with Git information, and whether
+// it is registered in Unity Hub. Unregistered projects get an entry built from
+// the path, without a version.
+func (c *Client) GetProjectByPath(path string) (*ProjectInfo, bool, error) {
+	projects, err := c.ListProjects()
+	if err != nil {
+		return nil, false, err
+	}
+	if p := FindRegisteredProject(projects, path); p != nil {
+		c.fillGitInfo(p)
+		return p, true, nil
+	}
+
+	p := []ProjectInfo{{Title: filepath.Base(path), Path: path}}
+	c.fillLastOpened(p)
+	c.fillTags(p)
+	c.fillGitInfo(&p[0])
+	return &p[0], false, nil
+}
+
 // getProjectsFilePath returns the path to Unity Hub's projects-v1.json
 func (c *Client) getProjectsFilePath() string {
 	// Allow override for testing
@@ -211,24 +274,18 @@ func (c *Client) getProjectsFilePath() string {
 		return c.projectsFileOverride
 	}
 
-	var basePath string
-
-	switch runtime.GOOS {
-	case "darwin":
-		basePath = filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "UnityHub")
-	case "windows":
-		basePath = filepath.Join(os.Getenv("APPDATA"), "UnityHub")
-	case "linux":
-		basePath = filepath.Join(os.Getenv("HOME"), ".config", "UnityHub")
-	default:
+	basePath := c.getUnityHubBasePath()
+	if basePath == "" {
 		return ""
 	}
-
 	return filepath.Join(basePath, "projects-v1.json")
 }
 
 // fillGitInfo populates Git branch and status information for a project
 func (c *Client) fillGitInfo(project *ProjectInfo) {
+	span := trace.Start(trace.KindExec, "git status", "project", project.Path)
+	defer span.End()
+
 	// Check if inside a git repository (works for subdirectories too)
 	cmd := exec.Command("git", "-C", project.Path, "rev-parse", "--is-inside-work-tree")
 	if output, err := cmd.Output(); err != nil || strings.TrimSpace(string(output)) != "true" {
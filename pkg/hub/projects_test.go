@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestParseProjectsFile(t *testing.T) {
@@ -56,8 +57,7 @@ func TestParseProjectsFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var data projectsFileData
-			err := json.Unmarshal([]byte(tt.json), &data)
+			entries, err := decodeHubFile[projectEntry]("projects-v1.json", []byte(tt.json), projectsSchemaVersion)
 
 			if tt.wantErr {
 				if err == nil {
@@ -71,8 +71,8 @@ func TestParseProjectsFile(t *testing.T) {
 				return
 			}
 
-			if len(data.Data) != tt.expected {
-				t.Errorf("Expected %d projects, got %d", tt.expected, len(data.Data))
+			if len(entries) != tt.expected {
+				t.Errorf("Expected %d projects, got %d", tt.expected, len(entries))
 			}
 		})
 	}
@@ -87,7 +87,14 @@ func createTestClient(t *testing.T, projectsJSON string) *Client {
 		t.Fatalf("Failed to write test file: %v", err)
 	}
 
-	return &Client{projectsFileOverride: projectsFile}
+	return &Client{
+		projectsFileOverride: projectsFile,
+		openedFileOverride:   filepath.Join(tempDir, "last-opened.json"),
+		tagsFileOverride:     filepath.Join(tempDir, "project-tags.json"),
+		queueFileOverride:    filepath.Join(tempDir, "install-queue.json"),
+		watchFileOverride:    filepath.Join(tempDir, "watch-state.json"),
+		journalFileOverride:  filepath.Join(tempDir, "journal.jsonl"),
+	}
 }
 
 func TestFindProjectsByName(t *testing.T) {
@@ -326,7 +333,9 @@ func TestProjectInfoFields(t *testing.T) {
 				"title": "My Project",
 				"path": "/path/to/project",
 				"version": "2022.3.60f1",
-				"lastModified": 1700000000000
+				"lastModified": 1700000000000,
+				"cloudProjectId": "5f0c2c0e-1b2a-4d3e-9f40-123456789abc",
+				"organizationId": "space-studio"
 			}
 		}
 	}`
@@ -355,6 +364,12 @@ func TestProjectInfoFields(t *testing.T) {
 	if p.LastModified.IsZero() {
 		t.Error("Expected non-zero LastModified")
 	}
+	if p.CloudProjectID != "5f0c2c0e-1b2a-4d3e-9f40-123456789abc" || p.CloudOrgID != "space-studio" {
+		t.Errorf("Expected the cloud link, got %q / %q", p.CloudProjectID, p.CloudOrgID)
+	}
+	if found := FindRegisteredProject(projects, "/path/to/project/"); found == nil || found.Title != "My Project" {
+		t.Errorf("FindRegisteredProject() = %+v", found)
+	}
 }
 
 func TestListProjectsEmptyFile(t *testing.T) {
@@ -412,3 +427,164 @@ func TestTitleFallbackToDirectoryName(t *testing.T) {
 		t.Errorf("Expected title 'my-project-dir', got '%s'", projects[0].Title)
 	}
 }
+
+func TestRecordProjectOpen(t *testing.T) {
+	projectsJSON := `{
+		"schema_version": "v1",
+		"data": {
+			"/path/to/opened": {"title": "Opened", "path": "/path/to/opened", "version": "2022.3.60f1"},
+			"/path/to/other": {"title": "Other", "path": "/path/to/other", "version": "2022.3.60f1"}
+		}
+	}`
+	client := createTestClient(t, projectsJSON)
+
+	if err := client.RecordProjectOpen("/path/to/opened", "6000.0.30f1"); err != nil {
+		t.Fatalf("RecordProjectOpen() error = %v", err)
+	}
+
+	projects, err := client.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+
+	for _, p := range projects {
+		switch p.Title {
+		case "Opened":
+			if p.LastOpened.IsZero() || time.Since(p.LastOpened) > time.Minute {
+				t.Errorf("LastOpened = %v, want about now", p.LastOpened)
+			}
+			if p.LastOpenedEditor != "6000.0.30f1" {
+				t.Errorf("LastOpenedEditor = %q, want %q", p.LastOpenedEditor, "6000.0.30f1")
+			}
+			// Unity Hub's recent ordering follows
+			if time.Since(p.LastModified) > time.Minute {
+				t.Errorf("LastModified = %v, want about now", p.LastModified)
+			}
+		case "Other":
+			if !p.LastOpened.IsZero() {
+				t.Errorf("LastOpened = %v, want zero for a project never opened", p.LastOpened)
+			}
+			if !p.LastModified.IsZero() {
+				t.Errorf("LastModified = %v, want it untouched", p.LastModified)
+			}
+		}
+	}
+}
+
+func TestFormatTimeAgo(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Time{}, "never"},
+		{now.Add(-10 * time.Second), "just now"},
+		{now.Add(-1 * time.Minute), "1 minute ago"},
+		{now.Add(-5 * time.Hour), "5 hours ago"},
+		{now.Add(-3 * 24 * time.Hour), "3 days ago"},
+		{now.Add(-65 * 24 * time.Hour), "2 months ago"},
+		{now.Add(-800 * 24 * time.Hour), "2 years ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatTimeAgo(tt.t, now); got != tt.want {
+			t.Errorf("FormatTimeAgo(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestGetProjectByPath(t *testing.T) {
+	client := createTestClient(t, `{
+		"schema_version": "v1",
+		"data": {
+			"/path/to/project": {"title": "My Project", "path": "/path/to/project", "version": "2022.3.60f1"}
+		}
+	}`)
+
+	p, registered, err := client.GetProjectByPath("/path/to/project")
+	if err != nil || !registered || p.Title != "My Project" || p.Version != "2022.3.60f1" {
+		t.Errorf("GetProjectByPath(registered) = %+v, %v, %v", p, registered, err)
+	}
+
+	other := filepath.Join(t.TempDir(), "Other Game")
+	p, registered, err = client.GetProjectByPath(other)
+	if err != nil || registered || p.Title != "Other Game" || p.Path != other {
+		t.Errorf("GetProjectByPath(unregistered) = %+v, %v, %v", p, registered, err)
+	}
+}
+
+func TestListProjectsWithProjectVersions(t *testing.T) {
+	root := t.TempDir()
+	upgraded := filepath.Join(root, "Upgraded")
+	current := filepath.Join(root, "Current")
+	missing := filepath.Join(root, "Missing")
+	for path, version := range map[string]string{upgraded: "6000.0.23f1", current: "2022.3.10f1"} {
+		if err := os.MkdirAll(filepath.Join(path, "ProjectSettings"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "m_EditorVersion: " + version + "\nm_EditorVersionWithRevision: " + version + " (abc123def456)\n"
+		if err := os.WriteFile(filepath.Join(path, "ProjectSettings", "ProjectVersion.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := map[string]any{}
+	for path, version := range map[string]string{upgraded: "2022.3.10f1", current: "2022.3.10f1", missing: "2021.3.1f1"} {
+		entries[path] = map[string]any{"path": path, "version": version}
+	}
+	data, err := json.Marshal(map[string]any{"schema_version": "v1", "data": entries})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := createTestClient(t, string(data))
+
+	projects, err := client.ListProjectsWithOptions(ProjectListOptions{ProjectVersions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path     string
+		version  string
+		actual   string
+		mismatch bool
+	}{
+		{upgraded, "6000.0.23f1", "6000.0.23f1", true},
+		{current, "2022.3.10f1", "2022.3.10f1", false},
+		{missing, "2021.3.1f1", "", false},
+	}
+	for _, tt := range tests {
+		p := FindRegisteredProject(projects, tt.path)
+		if p == nil {
+			t.Fatalf("%s not listed", tt.path)
+		}
+		if p.Version != tt.version || p.ActualVersion != tt.actual || p.VersionMismatch() != tt.mismatch {
+			t.Errorf("%s = %+v, mismatch %v", filepath.Base(tt.path), p, p.VersionMismatch())
+		}
+	}
+
+	// Without the option Hub's version is used
+	projects, err = client.ListProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := FindRegisteredProject(projects, upgraded); p.Version != "2022.3.10f1" || p.ActualVersion != "" {
+		t.Errorf("ListProjects() = %+v", p)
+	}
+
+	// A changed file is read again, an unchanged one comes from the cache
+	versionFile := filepath.Join(upgraded, "ProjectSettings", "ProjectVersion.txt")
+	if err := os.WriteFile(versionFile, []byte("m_EditorVersion: 6000.1.0f1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(versionFile, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.projectVersion(upgraded); got != "6000.1.0f1" {
+		t.Errorf("projectVersion() after change = %q", got)
+	}
+	client.projectVersions[versionFile] = projectVersionFile{modTime: later, version: "cached"}
+	if got := client.projectVersion(upgraded); got != "cached" {
+		t.Errorf("projectVersion() = %q, want the cached version", got)
+	}
+}
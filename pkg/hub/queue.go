@@ -0,0 +1,279 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Install backends recorded in the install queue
+const (
+	InstallBackendHub    = "hub"    // Unity Hub CLI
+	InstallBackendDirect = "direct" // Official installers, see direct.go
+)
+
+// InstallJob is an editor or module install that has not finished yet. Jobs are
+// persisted in install-queue.json so an install interrupted by a reboot or a
+// killed process can be resumed with ResumeInstall.
+type InstallJob struct {
+	Version      string          `json:"version"`
+	Changeset    string          `json:"changeset,omitempty"`
+	Architecture string          `json:"architecture,omitempty"`
+	Modules      []string        `json:"modules,omitempty"`
+	ModulesOnly  bool            `json:"modulesOnly,omitempty"` // Adds modules to an installed editor
+	Backend      string          `json:"backend"`
+	Started      time.Time       `json:"started"`
+	Updated      time.Time       `json:"updated"`
+	Downloads    []DownloadState `json:"downloads,omitempty"` // Direct installs only
+}
+
+// DownloadState is the progress of one installer download of a direct install
+type DownloadState struct {
+	URL  string `json:"url"`
+	File string `json:"file"`
+	Size int64  `json:"size"` // 0 when the server did not report it
+	Done int64  `json:"done"`
+}
+
+// Downloaded returns the bytes downloaded so far and the total size of the
+// job's known downloads
+func (j InstallJob) Downloaded() (done, total int64) {
+	for _, d := range j.Downloads {
+		done += d.Done
+		total += d.Size
+	}
+	return done, total
+}
+
+// queueFileData is the structure of install-queue.json
+type queueFileData struct {
+	Jobs []InstallJob `json:"jobs"`
+}
+
+// downloadSaveInterval bounds how often download progress is written to the queue
+var downloadSaveInterval = 5 * time.Second
+
+// getQueueFilePath returns the path to the install queue
+func (c *Client) getQueueFilePath() string {
+	if c.queueFileOverride != "" {
+		return c.queueFileOverride
+	}
+	return filepath.Join(c.StateDir(), "install-queue.json")
+}
+
+// downloadDir returns the directory direct installs download installers into.
+// Unlike the system temp directory it survives a reboot.
+func (c *Client) downloadDir() string {
+	return filepath.Join(filepath.Dir(c.getQueueFilePath()), "downloads")
+}
+
+// LoadInstallQueue returns the unfinished installs, oldest first
+func (c *Client) LoadInstallQueue() ([]InstallJob, error) {
+	data, err := os.ReadFile(c.getQueueFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read install queue: %w", err)
+	}
+
+	var queue queueFileData
+	if err := json.Unmarshal(data, &queue); err != nil {
+		ui.Debug("Discarding corrupted install queue", "error", err)
+		return nil, nil
+	}
+	sort.SliceStable(queue.Jobs, func(i, j int) bool {
+		return queue.Jobs[i].Started.Before(queue.Jobs[j].Started)
+	})
+	return queue.Jobs, nil
+}
+
+// updateInstallQueue applies update to the queue while holding its lock
+func (c *Client) updateInstallQueue(update func([]InstallJob) []InstallJob) error {
+	queueFile := c.getQueueFilePath()
+	if err := os.MkdirAll(filepath.Dir(queueFile), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	lock, err := fsutil.Lock(queueFile, cacheLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock install queue: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	jobs, err := c.LoadInstallQueue()
+	if err != nil {
+		return err
+	}
+	jobs = update(jobs)
+
+	data, err := json.MarshalIndent(queueFileData{Jobs: jobs}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode install queue: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(queueFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write install queue: %w", err)
+	}
+	return nil
+}
+
+// queueInstall records that an install of job.Version is starting. A job left
+// by an interrupted install of the same version keeps its start time and
+// downloads, so partial downloads are continued.
+func (c *Client) queueInstall(job InstallJob) {
+	now := time.Now()
+	job.Started = now
+	job.Updated = now
+	err := c.updateInstallQueue(func(jobs []InstallJob) []InstallJob {
+		for i, existing := range jobs {
+			if existing.Version != job.Version {
+				continue
+			}
+			job.Started = existing.Started
+			job.Downloads = existing.Downloads
+			if existing.Backend != job.Backend {
+				job.Downloads = nil
+			}
+			if job.ModulesOnly && !existing.ModulesOnly {
+				// The editor itself is still missing
+				job.ModulesOnly = false
+				job.Changeset = existing.Changeset
+				job.Architecture = existing.Architecture
+			}
+			for _, m := range existing.Modules {
+				if !slices.Contains(job.Modules, m) {
+					job.Modules = append(job.Modules, m)
+				}
+			}
+			jobs[i] = job
+			return jobs
+		}
+		return append(jobs, job)
+	})
+	if err != nil {
+		// The queue only helps resuming; never fail an install because of it
+		ui.Debug("Failed to record install in queue", "version", job.Version, "error", err)
+	}
+}
+
+// settleInstall removes the job of version from the queue once the install
+// succeeded or failed in a way a retry cannot fix. Other failures keep the job
+// and its partial downloads for ResumeInstall.
+func (c *Client) settleInstall(version string, installErr error) {
+	if installErr != nil && !errors.Is(installErr, errs.Usage) && !errors.Is(installErr, errs.NotFound) {
+		ui.Debug("Keeping interrupted install in queue", "version", version)
+		return
+	}
+	if err := c.RemoveInstallJob(version); err != nil {
+		ui.Debug("Failed to remove install from queue", "version", version, "error", err)
+	}
+}
+
+// DiscardInstall gives up an interrupted install: its job and partial
+// downloads are removed and the decision is recorded in the journal
+func (c *Client) DiscardInstall(version string) (err error) {
+	defer func() { c.RecordJournal(JournalDiscard, version, "", err) }()
+	return c.RemoveInstallJob(version)
+}
+
+// RemoveInstallJob removes the job of version from the queue and deletes its
+// partial downloads
+func (c *Client) RemoveInstallJob(version string) error {
+	var removed []InstallJob
+	err := c.updateInstallQueue(func(jobs []InstallJob) []InstallJob {
+		return slices.DeleteFunc(jobs, func(job InstallJob) bool {
+			if job.Version == version {
+				removed = append(removed, job)
+				return true
+			}
+			return false
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, job := range removed {
+		for _, d := range job.Downloads {
+			if err := os.Remove(d.File); err != nil && !os.IsNotExist(err) {
+				ui.Debug("Failed to remove download", "file", d.File, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// downloadState returns the recorded progress of url in the job of version
+func (c *Client) downloadState(version, url string) DownloadState {
+	jobs, err := c.LoadInstallQueue()
+	if err != nil {
+		return DownloadState{}
+	}
+	for _, job := range jobs {
+		if job.Version != version {
+			continue
+		}
+		for _, d := range job.Downloads {
+			if d.URL == url {
+				return d
+			}
+		}
+	}
+	return DownloadState{}
+}
+
+// recordDownload stores the progress of a download in the job of version
+func (c *Client) recordDownload(version string, state DownloadState) {
+	err := c.updateInstallQueue(func(jobs []InstallJob) []InstallJob {
+		for i := range jobs {
+			if jobs[i].Version != version {
+				continue
+			}
+			jobs[i].Updated = time.Now()
+			for j := range jobs[i].Downloads {
+				if jobs[i].Downloads[j].URL == state.URL {
+					jobs[i].Downloads[j] = state
+					return jobs
+				}
+			}
+			jobs[i].Downloads = append(jobs[i].Downloads, state)
+		}
+		return jobs
+	})
+	if err != nil {
+		ui.Debug("Failed to record download progress", "url", state.URL, "error", err)
+	}
+}
+
+// ResumeInstall continues an interrupted install with the backend it was
+// started with. Direct installs continue partial downloads; Unity Hub starts
+// its install over.
+func (c *Client) ResumeInstall(job InstallJob) error {
+	if job.Backend == InstallBackendDirect {
+		c.NoHub = true
+	}
+	if job.ModulesOnly {
+		return c.InstallModules(job.Version, job.Modules)
+	}
+	return c.InstallEditorWithOptions(InstallOptions{
+		Version:      job.Version,
+		Changeset:    job.Changeset,
+		Modules:      job.Modules,
+		Architecture: job.Architecture,
+	})
+}
+
+// installBackend returns the backend installs of this client use
+func (c *Client) installBackend() string {
+	if c.usesDirectInstall() {
+		return InstallBackendDirect
+	}
+	return InstallBackendHub
+}
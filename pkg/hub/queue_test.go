@@ -0,0 +1,170 @@
+package hub
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestInstallQueue(t *testing.T) {
+	client := createTestClient(t, "")
+
+	client.queueInstall(InstallJob{Version: "2022.3.10f1", Changeset: "abc123", Modules: []string{"android"}, Backend: InstallBackendDirect})
+	file := client.downloadPath("https://example.com/Unity.tar.xz")
+	if err := os.MkdirAll(client.downloadDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("partial"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client.recordDownload("2022.3.10f1", DownloadState{URL: "https://example.com/Unity.tar.xz", File: file, Size: 100, Done: 7})
+
+	// Adding modules to the interrupted install keeps the editor and the download
+	client.queueInstall(InstallJob{Version: "2022.3.10f1", Modules: []string{"webgl"}, ModulesOnly: true, Backend: InstallBackendDirect})
+	client.queueInstall(InstallJob{Version: "6000.0.30f1", Backend: InstallBackendHub})
+
+	jobs, err := client.LoadInstallQueue()
+	if err != nil {
+		t.Fatalf("LoadInstallQueue() error = %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+	job := jobs[0]
+	if job.Version != "2022.3.10f1" || job.ModulesOnly || job.Changeset != "abc123" {
+		t.Errorf("merged job = %+v", job)
+	}
+	if want := []string{"webgl", "android"}; !slices.Equal(job.Modules, want) {
+		t.Errorf("Modules = %v, want %v", job.Modules, want)
+	}
+	if done, total := job.Downloaded(); done != 7 || total != 100 {
+		t.Errorf("Downloaded() = %d/%d, want 7/100", done, total)
+	}
+
+	// Network failures keep the job, failures a retry cannot fix drop it
+	client.settleInstall("2022.3.10f1", errs.New(errs.NetworkUnavailable, "offline"))
+	if jobs, _ := client.LoadInstallQueue(); len(jobs) != 2 {
+		t.Errorf("job removed after a network failure")
+	}
+	client.settleInstall("2022.3.10f1", errs.New(errs.NotFound, "no such version"))
+	jobs, _ = client.LoadInstallQueue()
+	if len(jobs) != 1 || jobs[0].Version != "6000.0.30f1" {
+		t.Errorf("jobs = %+v, want only 6000.0.30f1", jobs)
+	}
+	if fileExists(file) {
+		t.Error("partial download was not removed with its job")
+	}
+
+	client.settleInstall("6000.0.30f1", nil)
+	if jobs, _ := client.LoadInstallQueue(); len(jobs) != 0 {
+		t.Errorf("jobs = %+v, want none after success", jobs)
+	}
+}
+
+func TestDownloadFileResumes(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "Unity.tar.xz", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	client := createTestClient(t, "")
+	url := server.URL + "/Unity.tar.xz"
+	file := client.downloadPath(url)
+	client.queueInstall(InstallJob{Version: "2022.3.10f1", Backend: InstallBackendDirect})
+
+	// Simulate a download interrupted after 4000 bytes
+	if err := os.MkdirAll(client.downloadDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, content[:4000], 0644); err != nil {
+		t.Fatal(err)
+	}
+	client.recordDownload("2022.3.10f1", DownloadState{URL: url, File: file, Size: int64(len(content)), Done: 4000})
+
+	if err := client.downloadFile("2022.3.10f1", url, file, "Unity"); err != nil {
+		t.Fatalf("downloadFile() error = %v", err)
+	}
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("downloaded %d bytes, want the original %d", len(got), len(content))
+	}
+	if want := []string{"bytes=4000-"}; !slices.Equal(ranges, want) {
+		t.Errorf("requests = %q, want %q", ranges, want)
+	}
+	if state := client.downloadState("2022.3.10f1", url); state.Done != int64(len(content)) {
+		t.Errorf("recorded Done = %d, want %d", state.Done, len(content))
+	}
+
+	// A complete download is not fetched again
+	if err := client.downloadFile("2022.3.10f1", url, file, "Unity"); err != nil {
+		t.Fatalf("downloadFile() error = %v", err)
+	}
+	if len(ranges) != 1 {
+		t.Errorf("complete download was requested again: %q", ranges)
+	}
+
+	// Without a recorded size the partial file cannot be trusted
+	if err := os.WriteFile(file, []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client.recordDownload("2022.3.10f1", DownloadState{URL: url, File: file})
+	if err := client.downloadFile("2022.3.10f1", url, file, "Unity"); err != nil {
+		t.Fatalf("downloadFile() error = %v", err)
+	}
+	if got, _ := os.ReadFile(file); !bytes.Equal(got, content) {
+		t.Error("partial file without a recorded size was not replaced")
+	}
+	if last := ranges[len(ranges)-1]; last != "" {
+		t.Errorf("last request Range = %q, want a full download", last)
+	}
+}
+
+func TestInstallLock(t *testing.T) {
+	client := createTestClient(t, "")
+	client.InstallLockTimeout = 0
+
+	unlock, err := client.lockInstall("2022.3.10f1")
+	if err != nil {
+		t.Fatalf("lockInstall() error = %v", err)
+	}
+	holder := readInstallLockHolder(client.installLockPath() + ".json")
+	if holder.PID != os.Getpid() || holder.Version != "2022.3.10f1" {
+		t.Errorf("holder = %+v", holder)
+	}
+
+	// A second install fails at once, or after waiting, naming the running one
+	other := createTestClient(t, "")
+	other.queueFileOverride = client.queueFileOverride
+	_, err = other.lockInstall("6000.0.30f1")
+	if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("another install is in progress (pid %d, version 2022.3.10f1", os.Getpid())) {
+		t.Errorf("lockInstall() while locked error = %v", err)
+	}
+	other.InstallLockTimeout = 100 * time.Millisecond
+	if _, err := other.lockInstall("6000.0.30f1"); err == nil {
+		t.Error("lockInstall() should time out while locked")
+	}
+
+	unlock()
+	unlock, err = other.lockInstall("6000.0.30f1")
+	if err != nil {
+		t.Fatalf("lockInstall() after unlock error = %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(client.installLockPath() + ".json"); !os.IsNotExist(err) {
+		t.Errorf("holder file left behind: %v", err)
+	}
+}
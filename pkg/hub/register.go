@@ -0,0 +1,190 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// hubProjectEntry is a project entry as Unity Hub writes it to projects-v1.json
+type hubProjectEntry struct {
+	Title                string `json:"title"`
+	LastModified         int64  `json:"lastModified"`
+	IsCustomEditor       bool   `json:"isCustomEditor"`
+	Path                 string `json:"path"`
+	ContainingFolderPath string `json:"containingFolderPath"`
+	Version              string `json:"version"`
+	IsFavorite           bool   `json:"isFavorite"`
+}
+
+// IsProjectRegistered reports whether a project at path is in the projects list.
+// Paths are compared case-insensitively on Windows and macOS.
+func IsProjectRegistered(projects []ProjectInfo, path string) bool {
+	return FindRegisteredProject(projects, path) != nil
+}
+
+// FindRegisteredProject returns the entry of the project at path, or nil
+func FindRegisteredProject(projects []ProjectInfo, path string) *ProjectInfo {
+	key := projectPathKey(path)
+	for i := range projects {
+		if projectPathKey(projects[i].Path) == key {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+func projectPathKey(path string) string {
+	key := filepath.Clean(path)
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// RegisterProjects adds projects to Unity Hub's projects-v1.json, keeping the
+// entries and fields already there. Projects already registered are skipped.
+// Unity Hub reads the file on startup and rewrites it on exit, so it should not
+// be running. Returns the number of projects added.
+func (c *Client) RegisterProjects(projects []ProjectInfo) (int, error) {
+	registered, err := c.ListProjects()
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	err = c.updateProjectsFile(func(entries map[string]json.RawMessage) (bool, error) {
+		now := time.Now().UnixMilli()
+		for _, p := range projects {
+			if IsProjectRegistered(registered, p.Path) {
+				continue
+			}
+			// Unity Hub writes forward slashes on Windows too
+			path := filepath.ToSlash(filepath.Clean(p.Path))
+			title := p.Title
+			if title == "" {
+				title = filepath.Base(p.Path)
+			}
+			raw, err := json.Marshal(hubProjectEntry{
+				Title:                title,
+				LastModified:         now,
+				Path:                 path,
+				ContainingFolderPath: filepath.ToSlash(filepath.Dir(filepath.Clean(p.Path))),
+				Version:              p.Version,
+			})
+			if err != nil {
+				return false, err
+			}
+			entries[path] = raw
+			registered = append(registered, p)
+			added++
+		}
+		return added > 0, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range registered[len(registered)-added:] {
+		c.RecordJournal(JournalRegister, p.Path, p.Version, nil)
+	}
+	return added, nil
+}
+
+// TouchHubProject sets lastModified of the project at projectPath in Unity
+// Hub's projects-v1.json to at, which is what Hub sorts its recent projects
+// by and sets itself when it opens a project. Projects not registered with
+// Hub are left alone. Reports whether an entry was updated.
+func (c *Client) TouchHubProject(projectPath string, at time.Time) (bool, error) {
+	key := projectPathKey(projectPath)
+	touched := false
+	err := c.updateProjectsFile(func(entries map[string]json.RawMessage) (bool, error) {
+		for name, raw := range entries {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				continue
+			}
+			path := name
+			if rawPath, ok := fields["path"]; ok {
+				_ = json.Unmarshal(rawPath, &path)
+			}
+			if path == "" || projectPathKey(path) != key {
+				continue
+			}
+			fields["lastModified"] = json.RawMessage(strconv.FormatInt(at.UnixMilli(), 10))
+			updated, err := json.Marshal(fields)
+			if err != nil {
+				return false, err
+			}
+			entries[name] = updated
+			touched = true
+		}
+		return touched, nil
+	})
+	return touched, err
+}
+
+// updateProjectsFile lets update change the entries of projects-v1.json by
+// their key and writes the file back when update reports a change. The file
+// is decoded loosely, so fields uniforge does not know survive the rewrite,
+// and files of a newer schema are refused. A missing file is created.
+func (c *Client) updateProjectsFile(update func(entries map[string]json.RawMessage) (bool, error)) error {
+	projectsFilePath := c.getProjectsFilePath()
+	if projectsFilePath == "" {
+		return fmt.Errorf("could not determine Unity Hub projects file path")
+	}
+
+	file := map[string]json.RawMessage{}
+	entries := map[string]json.RawMessage{}
+	data, err := os.ReadFile(projectsFilePath)
+	if err == nil {
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("failed to parse projects file: %w", err)
+		}
+		// Entries written in the known layout could break a newer Hub
+		var version schemaVersion
+		if raw, ok := file["schema_version"]; ok {
+			if err := json.Unmarshal(raw, &version); err != nil || version.newerThan(projectsSchemaVersion) {
+				return errs.WithHint(
+					fmt.Errorf("unity hub's projects file has schema version %s, which uniforge cannot write", raw),
+					"Update uniforge, or add the projects in Unity Hub.")
+			}
+		}
+		if raw, ok := file["data"]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &entries); err != nil {
+				return fmt.Errorf("failed to parse projects file: %w", err)
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read projects file: %w", err)
+	}
+	if _, ok := file["schema_version"]; !ok {
+		file["schema_version"] = json.RawMessage(`"v1"`)
+	}
+
+	changed, err := update(entries)
+	if err != nil || !changed {
+		return err
+	}
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	file["data"] = raw
+	out, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := fsutil.WriteFileAtomic(projectsFilePath, out, 0644); err != nil {
+		return fmt.Errorf("failed to write projects file: %w", err)
+	}
+	return nil
+}
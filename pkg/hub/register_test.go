@@ -0,0 +1,124 @@
+package hub
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRegisterProjects(t *testing.T) {
+	projectsJSON := `{
+		"schema_version": "v1",
+		"data": {
+			"/path/to/existing": {"title": "existing", "path": "/path/to/existing", "version": "2022.3.60f1", "isFavorite": true, "architecture": "arm64"}
+		}
+	}`
+	client := createTestClient(t, projectsJSON)
+
+	added, err := client.RegisterProjects([]ProjectInfo{
+		{Title: "existing", Path: "/path/to/existing/", Version: "2022.3.60f1"},
+		{Title: "new", Path: "/path/to/new", Version: "6000.0.30f1"},
+	})
+	if err != nil {
+		t.Fatalf("RegisterProjects() error = %v", err)
+	}
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+
+	projects, err := client.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if len(projects) != 2 || !IsProjectRegistered(projects, "/path/to/new") {
+		t.Errorf("ListProjects() = %+v, want existing and new", projects)
+	}
+
+	// Fields uniforge does not model are kept
+	data, err := os.ReadFile(client.projectsFileOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Data map[string]map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file.Data["/path/to/existing"]["architecture"] != "arm64" {
+		t.Errorf("existing entry lost its fields: %v", file.Data["/path/to/existing"])
+	}
+	if file.Data["/path/to/new"]["containingFolderPath"] != "/path/to" {
+		t.Errorf("new entry = %v", file.Data["/path/to/new"])
+	}
+}
+
+func TestRegisterProjectsCreatesFile(t *testing.T) {
+	client := createTestClient(t, "")
+	if err := os.Remove(client.projectsFileOverride); err != nil {
+		t.Fatal(err)
+	}
+	added, err := client.RegisterProjects([]ProjectInfo{{Path: "/path/to/game", Version: "2022.3.60f1"}})
+	if err != nil {
+		t.Fatalf("RegisterProjects() error = %v", err)
+	}
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+	projects, err := client.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if len(projects) != 1 || projects[0].Title != "game" {
+		t.Errorf("ListProjects() = %+v", projects)
+	}
+}
+
+func TestTouchHubProject(t *testing.T) {
+	projectsJSON := `{
+		"schema_version": "v1",
+		"data": {
+			"/path/to/game": {"title": "game", "path": "/path/to/game", "version": "2022.3.60f1", "lastModified": 1700000000000, "isFavorite": true},
+			"/path/to/other": {"title": "other", "path": "/path/to/other", "version": "2022.3.60f1", "lastModified": 1700000000000}
+		}
+	}`
+	client := createTestClient(t, projectsJSON)
+	at := time.UnixMilli(1750000000000)
+
+	touched, err := client.TouchHubProject("/path/to/game/", at)
+	if err != nil || !touched {
+		t.Fatalf("TouchHubProject() = %v, %v; want true", touched, err)
+	}
+	if touched, err := client.TouchHubProject("/path/to/unregistered", at); err != nil || touched {
+		t.Errorf("TouchHubProject(unregistered) = %v, %v; want false", touched, err)
+	}
+
+	projects, err := client.ListProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range projects {
+		want := int64(1700000000000)
+		if p.Title == "game" {
+			want = at.UnixMilli()
+		}
+		if p.LastModified.UnixMilli() != want {
+			t.Errorf("%s: LastModified = %d, want %d", p.Title, p.LastModified.UnixMilli(), want)
+		}
+	}
+
+	data, err := os.ReadFile(client.projectsFileOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Data map[string]map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file.Data["/path/to/game"]["isFavorite"] != true {
+		t.Errorf("touched entry lost its fields: %v", file.Data["/path/to/game"])
+	}
+}
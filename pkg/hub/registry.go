@@ -0,0 +1,30 @@
+package hub
+
+import "strings"
+
+// Registry keys written by Unity installers and Unity Hub on Windows
+const (
+	// registryInstallerKey has one subkey per editor, e.g. "Unity 2022.3.10f1",
+	// whose "Location x64" value is the editor root directory
+	registryInstallerKey = `Software\Unity Technologies\Installer`
+	// registryUninstallKey lists installed applications, including Unity Hub
+	registryUninstallKey = `Software\Microsoft\Windows\CurrentVersion\Uninstall`
+)
+
+// registryLocationValues are the installer values holding the editor root, by preference
+var registryLocationValues = []string{"Location x64", "Location"}
+
+// versionFromInstallerKey extracts the editor version from an installer subkey
+// name such as "Unity 2022.3.10f1", or returns "" if it is not an editor key
+func versionFromInstallerKey(name string) string {
+	version, ok := strings.CutPrefix(name, "Unity ")
+	if !ok || !isValidUnityVersion(version) {
+		return ""
+	}
+	return version
+}
+
+// isUnityHubDisplayName reports whether an uninstall entry belongs to Unity Hub
+func isUnityHubDisplayName(name string) bool {
+	return strings.HasPrefix(name, "Unity Hub")
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package hub
+
+// registryEditors returns editors registered by the Unity installer (Windows only)
+func registryEditors() []EditorInfo {
+	return nil
+}
+
+// registryHubPath returns the Unity Hub executable registered for uninstall (Windows only)
+func registryHubPath() string {
+	return ""
+}
@@ -0,0 +1,46 @@
+package hub
+
+import "testing"
+
+func TestVersionFromInstallerKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Unity 2022.3.10f1", "2022.3.10f1"},
+		{"Unity 6000.0.32f1", "6000.0.32f1"},
+		{"Unity 2023.1.0b5", "2023.1.0b5"},
+		{"Unity", ""},
+		{"Unity Hub", ""},
+		{"UnityHub 3.7.0", ""},
+		{"2022.3.10f1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionFromInstallerKey(tt.name); got != tt.expected {
+				t.Errorf("versionFromInstallerKey(%q) = %q, want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsUnityHubDisplayName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"Unity Hub 3.7.0", true},
+		{"Unity Hub", true},
+		{"Unity 2022.3.10f1", false},
+		{"Visual Studio Code", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnityHubDisplayName(tt.name); got != tt.expected {
+				t.Errorf("isUnityHubDisplayName(%q) = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
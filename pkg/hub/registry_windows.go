@@ -0,0 +1,127 @@
+//go:build windows
+
+package hub
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+	"golang.org/x/sys/windows/registry"
+)
+
+// registryEditors returns editors registered by the Unity installer under
+// HKCU\Software\Unity Technologies\Installer. This finds editors installed
+// with the standalone installer outside the Hub's install paths.
+func registryEditors() []EditorInfo {
+	key, err := registry.OpenKey(registry.CURRENT_USER, registryInstallerKey, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		ui.Debug("No Unity installer registry key", "error", err)
+		return nil
+	}
+	defer func() { _ = key.Close() }()
+
+	names, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		ui.Debug("Failed to read Unity installer registry key", "error", err)
+		return nil
+	}
+
+	var editors []EditorInfo
+	for _, name := range names {
+		version := versionFromInstallerKey(name)
+		if version == "" {
+			continue
+		}
+		location := readInstallerLocation(key, name)
+		if location == "" {
+			continue
+		}
+		editorPath := filepath.Join(location, "Editor", "Unity.exe")
+		if !fileExists(editorPath) {
+			ui.Debug("Registered editor not found on disk", "version", version, "path", editorPath)
+			continue
+		}
+		editors = append(editors, EditorInfo{
+			Version:      version,
+			Path:         editorPath,
+			Architecture: runtime.GOARCH,
+		})
+	}
+	return editors
+}
+
+// readInstallerLocation reads the editor root of an installer subkey
+func readInstallerLocation(parent registry.Key, name string) string {
+	key, err := registry.OpenKey(parent, name, registry.QUERY_VALUE)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = key.Close() }()
+
+	for _, value := range registryLocationValues {
+		if location, _, err := key.GetStringValue(value); err == nil && location != "" {
+			return location
+		}
+	}
+	return ""
+}
+
+// registryHubPath finds Unity Hub through its uninstall entry, which records
+// the install location for both per-user and machine-wide installs
+func registryHubPath() string {
+	roots := []struct {
+		root   registry.Key
+		access uint32
+	}{
+		{registry.LOCAL_MACHINE, registry.WOW64_64KEY},
+		{registry.LOCAL_MACHINE, registry.WOW64_32KEY},
+		{registry.CURRENT_USER, 0},
+	}
+
+	for _, r := range roots {
+		key, err := registry.OpenKey(r.root, registryUninstallKey, registry.ENUMERATE_SUB_KEYS|r.access)
+		if err != nil {
+			continue
+		}
+		names, err := key.ReadSubKeyNames(-1)
+		if err != nil {
+			_ = key.Close()
+			continue
+		}
+		for _, name := range names {
+			if path := readHubUninstallEntry(key, name, r.access); path != "" {
+				_ = key.Close()
+				return path
+			}
+		}
+		_ = key.Close()
+	}
+	return ""
+}
+
+// readHubUninstallEntry returns the Unity Hub executable of an uninstall entry,
+// or "" if the entry is not Unity Hub
+func readHubUninstallEntry(parent registry.Key, name string, access uint32) string {
+	key, err := registry.OpenKey(parent, name, registry.QUERY_VALUE|access)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = key.Close() }()
+
+	displayName, _, err := key.GetStringValue("DisplayName")
+	if err != nil || !isUnityHubDisplayName(displayName) {
+		return ""
+	}
+	location, _, err := key.GetStringValue("InstallLocation")
+	if err != nil || location == "" {
+		return ""
+	}
+
+	path := filepath.Join(location, "Unity Hub.exe")
+	if !fileExists(path) {
+		return ""
+	}
+	ui.Debug("Found Unity Hub in registry", "path", path)
+	return path
+}
@@ -3,17 +3,23 @@ package hub
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/paths"
+	"github.com/neptaco/uniforge/pkg/trace"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
 
@@ -33,6 +39,7 @@ type UnityRelease struct {
 	DownloadSize    int64  // bytes
 	InstalledSize   int64  // bytes
 	SecurityAlert   string // Security alert message if any
+	Archive         bool   // Only listed in the download archive; see FetchArchiveReleases
 }
 
 // ModuleInfo represents a module available for a Unity version
@@ -92,9 +99,73 @@ type graphQLReleasesRequest struct {
 	Query         string         `json:"query"`
 }
 
+// GraphQLError is an entry of the "errors" array of a GraphQL response
+type GraphQLError struct {
+	Message    string `json:"message"`
+	Path       []any  `json:"path,omitempty"` // Starts with the alias of the failed query
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+// APIError is returned when the Unity GraphQL API answers with an HTTP status
+// other than 200 or with GraphQL errors, such as an expired entitlement or a
+// query the schema no longer accepts. With Partial, the other streams returned
+// their releases, which are returned alongside the error.
+type APIError struct {
+	Status  int // HTTP status code
+	Errors  []GraphQLError
+	Partial bool     // Some streams returned releases
+	Streams []string // Streams whose query failed, e.g. 2022.3; empty when unknown
+}
+
+func (e *APIError) Error() string {
+	var messages []string
+	for _, ge := range e.Errors {
+		if ge.Message != "" && !slices.Contains(messages, ge.Message) {
+			messages = append(messages, ge.Message)
+		}
+	}
+	detail := strings.Join(messages, "; ")
+	switch {
+	case e.Status != http.StatusOK && detail == "":
+		return fmt.Sprintf("unity API returned HTTP %d", e.Status)
+	case e.Status != http.StatusOK:
+		return fmt.Sprintf("unity API returned HTTP %d: %s", e.Status, detail)
+	case len(e.Streams) > 0:
+		return fmt.Sprintf("unity API failed for %s: %s", strings.Join(e.Streams, ", "), detail)
+	default:
+		return "unity API returned errors: " + detail
+	}
+}
+
+// graphQLStatusError returns the error for a response with a status other
+// than 200, with the messages of the GraphQL errors in its body if there are
+// any. Server errors and rate limits are categorized as network problems.
+func graphQLStatusError(status int, body []byte) error {
+	if status == http.StatusOK {
+		return nil
+	}
+	var resp struct {
+		Errors []GraphQLError `json:"errors"`
+	}
+	_ = json.Unmarshal(body, &resp)
+	err := &APIError{Status: status, Errors: resp.Errors}
+	if status >= 500 || status == http.StatusTooManyRequests {
+		return errs.New(errs.NetworkUnavailable, "%w", err)
+	}
+	return err
+}
+
+// streamFromAlias returns the stream of a batch query alias: v2022_3 -> 2022.3
+func streamFromAlias(alias string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(alias, "v"), "_", ".")
+}
+
 // graphQLReleasesResponse is the response from the Unity GraphQL API
 type graphQLReleasesResponse struct {
-	Data struct {
+	Errors []GraphQLError `json:"errors"`
+	Data   struct {
 		GetUnityReleases struct {
 			TotalCount int `json:"totalCount"`
 			Edges      []struct {
@@ -142,6 +213,12 @@ type graphQLModule struct {
 	InstalledSize graphQLDigitalValue `json:"installedSize"`
 }
 
+// GraphQLEndpoint is the Unity services API used for release metadata
+const GraphQLEndpoint = "https://services.unity.com/graphql"
+
+// cacheLockTimeout bounds how long a cache write waits for another process
+const cacheLockTimeout = 10 * time.Second
+
 // releasesCacheData represents the cached release data
 type releasesCacheData struct {
 	Streams   map[string]streamCacheEntry `json:"streams"`
@@ -165,6 +242,7 @@ type releaseCacheEntry struct {
 	ReleaseNotesURL string             `json:"releaseNotesUrl,omitempty"`
 	DownloadSize    int64              `json:"downloadSize,omitempty"`
 	InstalledSize   int64              `json:"installedSize,omitempty"`
+	SecurityAlert   string             `json:"securityAlert,omitempty"`
 	Modules         []moduleCacheEntry `json:"modules,omitempty"`
 }
 
@@ -252,6 +330,9 @@ func (c *Client) DiscoverMajorVersions() []string {
 
 // fetchMajorVersionsFromAPI fetches all major versions from GraphQL API
 func (c *Client) fetchMajorVersionsFromAPI() ([]string, error) {
+	span := trace.Start(trace.KindHTTP, "GraphQL GetMajorVersions")
+	defer span.End()
+
 	// Query all streams to get complete version list
 	query := `query GetMajorVersions {
   lts: getUnityReleaseMajorVersions(stream: LTS) { version }
@@ -271,7 +352,7 @@ func (c *Client) fetchMajorVersionsFromAPI() ([]string, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", "https://services.unity.com/graphql", bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequest("POST", GraphQLEndpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -334,33 +415,31 @@ func (c *Client) fetchMajorVersionsFromAPI() ([]string, error) {
 
 // GetReleasesFilePath returns the path to Unity Hub's releases.json
 func (c *Client) GetReleasesFilePath() string {
-	var basePath string
-
-	switch runtime.GOOS {
-	case "darwin":
-		basePath = filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "UnityHub")
-	case "windows":
-		basePath = filepath.Join(os.Getenv("APPDATA"), "UnityHub")
-	case "linux":
-		basePath = filepath.Join(os.Getenv("HOME"), ".config", "UnityHub")
-	default:
+	basePath := c.getUnityHubBasePath()
+	if basePath == "" {
 		return ""
 	}
-
 	return filepath.Join(basePath, "releases.json")
 }
 
-// getCacheFilePath returns the path to uniforge's release cache
-func (c *Client) getReleaseCacheFilePath() string {
-	cacheDir, err := os.UserCacheDir()
-	if err != nil {
-		cacheDir = os.TempDir()
+// CacheDir returns the directory of uniforge's caches
+func (c *Client) CacheDir() string {
+	if c.options != nil {
+		return c.options.CacheDir
 	}
-	return filepath.Join(cacheDir, "uniforge", "releases-cache.json")
+	return paths.CacheDir()
+}
+
+// getReleaseCacheFilePath returns the path to uniforge's release cache
+func (c *Client) getReleaseCacheFilePath() string {
+	return filepath.Join(c.CacheDir(), "releases-cache.json")
 }
 
 // LoadReleasesFromFile loads releases from Unity Hub's releases.json
 func (c *Client) LoadReleasesFromFile() ([]UnityRelease, error) {
+	span := trace.Start(trace.KindCache, "Unity Hub releases.json")
+	defer span.End()
+
 	releasesFilePath := c.GetReleasesFilePath()
 	if releasesFilePath == "" {
 		return nil, fmt.Errorf("could not determine releases file path")
@@ -477,6 +556,9 @@ func (c *Client) FetchStreams() ([]VersionStream, error) {
 
 // fetchStreamMetadata fetches metadata for a single stream
 func (c *Client) fetchStreamMetadata(majorMinor string) (VersionStream, error) {
+	span := trace.Start(trace.KindHTTP, "GraphQL GetRelease", "stream", majorMinor)
+	defer span.End()
+
 	query := `query GetRelease($limit: Int, $version: String!) {
   getUnityReleases(
     limit: $limit
@@ -507,7 +589,7 @@ func (c *Client) fetchStreamMetadata(majorMinor string) (VersionStream, error) {
 		return VersionStream{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https://services.unity.com/graphql", bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequest("POST", GraphQLEndpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return VersionStream{}, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -516,7 +598,7 @@ func (c *Client) fetchStreamMetadata(majorMinor string) (VersionStream, error) {
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return VersionStream{}, fmt.Errorf("failed to fetch from Unity API: %w", err)
+		return VersionStream{}, errs.New(errs.NetworkUnavailable, "failed to fetch from Unity API: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -524,11 +606,17 @@ func (c *Client) fetchStreamMetadata(majorMinor string) (VersionStream, error) {
 	if err != nil {
 		return VersionStream{}, fmt.Errorf("failed to read response: %w", err)
 	}
+	if err := graphQLStatusError(resp.StatusCode, body); err != nil {
+		return VersionStream{}, err
+	}
 
 	var graphQLResp graphQLReleasesResponse
 	if err := json.Unmarshal(body, &graphQLResp); err != nil {
 		return VersionStream{}, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if len(graphQLResp.Errors) > 0 {
+		return VersionStream{}, &APIError{Status: resp.StatusCode, Errors: graphQLResp.Errors, Streams: []string{majorMinor}}
+	}
 
 	stream := VersionStream{
 		MajorMinor: majorMinor,
@@ -561,6 +649,9 @@ func (c *Client) FetchReleasesForStream(majorMinor string) ([]UnityRelease, erro
 
 // FetchReleasesFromGraphQL fetches releases from Unity's GraphQL API
 func (c *Client) FetchReleasesFromGraphQL(majorMinorVersions []string) ([]UnityRelease, error) {
+	span := trace.Start(trace.KindHTTP, "GraphQL GetAllReleases", "streams", strings.Join(majorMinorVersions, ","))
+	defer span.End()
+
 	if len(majorMinorVersions) == 0 {
 		return nil, nil
 	}
@@ -579,7 +670,7 @@ func (c *Client) FetchReleasesFromGraphQL(majorMinorVersions []string) ([]UnityR
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https://services.unity.com/graphql", bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequest("POST", GraphQLEndpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -588,7 +679,7 @@ func (c *Client) FetchReleasesFromGraphQL(majorMinorVersions []string) ([]UnityR
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from Unity API: %w", err)
+		return nil, errs.New(errs.NetworkUnavailable, "failed to fetch from Unity API: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -596,8 +687,14 @@ func (c *Client) FetchReleasesFromGraphQL(majorMinorVersions []string) ([]UnityR
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
+	if err := graphQLStatusError(resp.StatusCode, body); err != nil {
+		span.Fail(err)
+		return nil, err
+	}
 
-	return c.parseBatchReleasesResponse(body)
+	releases, err := c.parseBatchReleasesResponse(body)
+	span.Fail(err)
+	return releases, err
 }
 
 // buildBatchReleasesQuery builds a GraphQL query with aliases for multiple versions
@@ -645,11 +742,14 @@ func (c *Client) buildBatchReleasesQuery(versions []string) string {
 	return sb.String()
 }
 
-// parseBatchReleasesResponse parses the batch response with dynamic aliases
+// parseBatchReleasesResponse parses the batch response with dynamic aliases.
+// GraphQL errors are returned as an *APIError; when only some aliases failed,
+// the releases of the others are returned with it and the error is Partial.
 func (c *Client) parseBatchReleasesResponse(body []byte) ([]UnityRelease, error) {
-	// Parse as generic map since aliases are dynamic
+	// Parse as generic map since aliases are dynamic; a failed alias is null
 	var resp struct {
-		Data map[string]struct {
+		Errors []GraphQLError `json:"errors"`
+		Data   map[string]*struct {
 			Edges []struct {
 				Node graphQLReleaseNode `json:"node"`
 			} `json:"edges"`
@@ -662,15 +762,34 @@ func (c *Client) parseBatchReleasesResponse(body []byte) ([]UnityRelease, error)
 
 	currentPlatform, currentArch := c.detectPlatformArch()
 	var allReleases []UnityRelease
+	succeeded := 0
 
 	for _, versionData := range resp.Data {
+		if versionData == nil {
+			continue
+		}
+		succeeded++
 		for _, edge := range versionData.Edges {
 			release := c.convertNodeToRelease(edge.Node, currentPlatform, currentArch)
 			allReleases = append(allReleases, release)
 		}
 	}
 
-	return allReleases, nil
+	if len(resp.Errors) == 0 {
+		return allReleases, nil
+	}
+
+	apiErr := &APIError{Status: http.StatusOK, Errors: resp.Errors, Partial: succeeded > 0}
+	for _, ge := range resp.Errors {
+		if len(ge.Path) == 0 {
+			continue
+		}
+		if alias, ok := ge.Path[0].(string); ok && !slices.Contains(apiErr.Streams, streamFromAlias(alias)) {
+			apiErr.Streams = append(apiErr.Streams, streamFromAlias(alias))
+		}
+	}
+	sort.Strings(apiErr.Streams)
+	return allReleases, apiErr
 }
 
 // convertNodeToRelease converts a GraphQL node to UnityRelease
@@ -735,22 +854,22 @@ func (c *Client) detectPlatformArch() (platform, arch string) {
 	return platform, arch
 }
 
-// ClearCache removes the cache file
-func (c *Client) ClearCache() error {
-	cachePath := c.getReleaseCacheFilePath()
-
-	if err := os.Remove(cachePath); err != nil {
-		if os.IsNotExist(err) {
-			// Cache doesn't exist, nothing to clear
-			return nil
+// ClearCache removes the cache files
+func (c *Client) ClearCache() (err error) {
+	defer func() { c.RecordJournal(JournalCacheClear, "", "", err) }()
+	for _, cachePath := range []string{c.getReleaseCacheFilePath(), c.archiveCacheFilePath(), c.supportCacheFilePath()} {
+		if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
+			return err
 		}
-		return err
 	}
 	return nil
 }
 
 // LoadCache loads cached releases
 func (c *Client) LoadCache() (*releasesCacheData, error) {
+	span := trace.Start(trace.KindCache, "release cache")
+	defer span.End()
+
 	cachePath := c.getReleaseCacheFilePath()
 
 	data, err := os.ReadFile(cachePath)
@@ -760,7 +879,10 @@ func (c *Client) LoadCache() (*releasesCacheData, error) {
 
 	var cache releasesCacheData
 	if err := json.Unmarshal(data, &cache); err != nil {
-		return nil, err
+		// A corrupted cache is treated as a cache miss and rebuilt on next save
+		ui.Debug("Discarding corrupted release cache", "path", cachePath, "error", err)
+		_ = os.Remove(cachePath)
+		return nil, nil
 	}
 
 	return &cache, nil
@@ -770,11 +892,6 @@ func (c *Client) LoadCache() (*releasesCacheData, error) {
 func (c *Client) SaveCache(streams []VersionStream, releases []UnityRelease) error {
 	cachePath := c.getReleaseCacheFilePath()
 
-	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
-		return err
-	}
-
 	cache := releasesCacheData{
 		Streams:   make(map[string]streamCacheEntry),
 		UpdatedAt: time.Now(),
@@ -789,6 +906,10 @@ func (c *Client) SaveCache(streams []VersionStream, releases []UnityRelease) err
 	}
 
 	for _, r := range releases {
+		// Archive releases have their own cache
+		if r.Archive {
+			continue
+		}
 		entry := releaseCacheEntry{
 			Version:         r.Version,
 			Changeset:       r.Changeset,
@@ -799,6 +920,7 @@ func (c *Client) SaveCache(streams []VersionStream, releases []UnityRelease) err
 			ReleaseNotesURL: r.ReleaseNotesURL,
 			DownloadSize:    r.DownloadSize,
 			InstalledSize:   r.InstalledSize,
+			SecurityAlert:   r.SecurityAlert,
 		}
 
 		// Convert modules
@@ -821,7 +943,14 @@ func (c *Client) SaveCache(streams []VersionStream, releases []UnityRelease) err
 		return err
 	}
 
-	return os.WriteFile(cachePath, data, 0644)
+	// Serialize writers across concurrent uniforge processes (e.g., parallel CI steps)
+	lock, err := fsutil.Lock(cachePath, cacheLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	return fsutil.WriteFileAtomic(cachePath, data, 0644)
 }
 
 // CheckCacheValidity checks if cache is valid by comparing totalCount
@@ -859,6 +988,7 @@ func (c *Client) ConvertCacheToReleases(cache *releasesCacheData) []UnityRelease
 			ReleaseNotesURL: entry.ReleaseNotesURL,
 			DownloadSize:    entry.DownloadSize,
 			InstalledSize:   entry.InstalledSize,
+			SecurityAlert:   entry.SecurityAlert,
 		}
 
 		// Convert modules
@@ -891,7 +1021,18 @@ func (c *Client) GetAllReleases() ([]UnityRelease, error) {
 	majorVersions := c.DiscoverMajorVersions()
 	apiReleases, err := c.FetchReleasesFromGraphQL(majorVersions)
 	if err != nil {
-		ui.Debug("Failed to fetch releases from GraphQL", "error", err)
+		var apiErr *APIError
+		switch {
+		case len(apiReleases) == 0 && len(localReleases) == 0:
+			return nil, fmt.Errorf("failed to fetch releases: %w", err)
+		case !errors.As(err, &apiErr):
+			// Offline: the releases known locally are enough
+			ui.Debug("Failed to fetch releases from GraphQL", "error", err)
+		case apiErr.Partial:
+			ui.Warn("The release list is incomplete: %v", err)
+		default:
+			ui.Warn("Only locally known releases are listed: %v", err)
+		}
 	}
 
 	// Merge: API releases + local releases (local has module info)
@@ -900,6 +1041,11 @@ func (c *Client) GetAllReleases() ([]UnityRelease, error) {
 	// Deduplicate releases by version
 	releases = deduplicateReleases(releases)
 
+	// Add old versions the API no longer lists
+	if c.IncludeArchive {
+		releases = c.MergeArchiveReleases(releases)
+	}
+
 	// Enrich with install status
 	releases = c.EnrichReleasesWithInstallStatus(releases)
 
@@ -994,8 +1140,9 @@ func (c *Client) EnrichReleasesWithInstallStatus(releases []UnityRelease) []Unit
 			releases[i].InstalledPath = editor.Path
 
 			// Enrich modules with install status
+			installedModules := c.GetInstalledModules(editor.Path)
 			for j := range releases[i].Modules {
-				releases[i].Modules[j].Installed = c.IsModuleInstalled(editor.Path, releases[i].Modules[j].ID)
+				releases[i].Modules[j].Installed = installedModules[releases[i].Modules[j].ID]
 			}
 		}
 	}
@@ -1015,102 +1162,10 @@ func GetCommonModules() []ModuleInfo {
 	}
 }
 
-// compareVersions compares two Unity version strings
-// Returns: >0 if v1 > v2, <0 if v1 < v2, 0 if equal
-func compareVersions(v1, v2 string) int {
-	// Parse version like "2022.3.60f1", "6000.4.0b3", "6000.4.0a5"
-	p1 := parseVersionParts(v1)
-	p2 := parseVersionParts(v2)
-
-	// Compare each part
-	for i := 0; i < len(p1) && i < len(p2); i++ {
-		if p1[i] > p2[i] {
-			return 1
-		}
-		if p1[i] < p2[i] {
-			return -1
-		}
-	}
-
-	// If all parts are equal, longer version is greater
-	return len(p1) - len(p2)
-}
-
-// parseVersionParts parses a version string into comparable integer parts
-// Format: major.minor.patch[a|b|f]N -> [major, minor, patch, releaseType, releaseNum]
-// releaseType: alpha=1, beta=2, final=3
-func parseVersionParts(version string) []int {
-	var parts []int
-
-	// Split by dot
-	dotParts := strings.Split(version, ".")
-	for i, part := range dotParts {
-		if i == len(dotParts)-1 {
-			// Last part may contain suffix like "60f1", "0b3", "0a5"
-			num, releaseType, releaseNum := parseVersionSuffix(part)
-			parts = append(parts, num, releaseType, releaseNum)
-		} else {
-			var num int
-			_, _ = fmt.Sscanf(part, "%d", &num)
-			parts = append(parts, num)
-		}
-	}
-
-	return parts
-}
-
-// parseVersionSuffix parses "60f1" -> (60, 3, 1), "0b3" -> (0, 2, 3), "0a5" -> (0, 1, 5)
-func parseVersionSuffix(part string) (num, releaseType, releaseNum int) {
-	// Find where the letter starts
-	letterIdx := -1
-	for i, c := range part {
-		if c == 'a' || c == 'b' || c == 'f' {
-			letterIdx = i
-			break
-		}
-	}
-
-	if letterIdx == -1 {
-		// No suffix, treat as final release
-		_, _ = fmt.Sscanf(part, "%d", &num)
-		return num, 3, 0
-	}
-
-	// Parse number before letter
-	_, _ = fmt.Sscanf(part[:letterIdx], "%d", &num)
-
-	// Parse release type
-	switch part[letterIdx] {
-	case 'a':
-		releaseType = 1 // alpha
-	case 'b':
-		releaseType = 2 // beta
-	case 'f':
-		releaseType = 3 // final
-	}
-
-	// Parse release number after letter
-	if letterIdx+1 < len(part) {
-		_, _ = fmt.Sscanf(part[letterIdx+1:], "%d", &releaseNum)
-	}
-
-	return
-}
-
-// FilterReleasesByVersion filters releases that match a version prefix
-func FilterReleasesByVersion(releases []UnityRelease, prefix string) []UnityRelease {
-	if prefix == "" {
-		return releases
-	}
-
-	prefix = strings.ToLower(prefix)
-	var result []UnityRelease
-	for _, r := range releases {
-		if strings.Contains(strings.ToLower(r.Version), prefix) {
-			result = append(result, r)
-		}
-	}
-	return result
+// FilterReleasesByVersion returns the releases whose version fuzzy-matches
+// query (see FuzzyMatch), best match first
+func FilterReleasesByVersion(releases []UnityRelease, query string) []UnityRelease {
+	return fuzzyFilter(releases, query, func(r UnityRelease) string { return r.Version })
 }
 
 // GetMajorMinorFromVersion extracts major.minor from a version string
@@ -3,10 +3,16 @@ package hub
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
 )
 
 func TestGetMajorMinorFromVersion(t *testing.T) {
@@ -52,6 +58,17 @@ func TestCompareVersions(t *testing.T) {
 		{"6000.4.0a5", "6000.4.0a4", 1}, // a5 > a4
 		{"6000.4.0a4", "6000.4.0a2", 1}, // a4 > a2
 		{"6000.4.0b1", "6000.4.0a5", 1}, // beta > alpha even if number is lower
+		// Patch releases follow the final release of the same version
+		{"2019.4.1p1", "2019.4.1f1", 1},
+		{"2019.4.1p2", "2019.4.1p1", 1},
+		{"2019.4.2f1", "2019.4.1p3", 1},
+		// China builds follow the regular release they are based on
+		{"2021.3.14f1c1", "2021.3.14f1", 1},
+		{"2021.3.14f1c2", "2021.3.14f1c1", 1},
+		{"2021.3.14f2", "2021.3.14f1c3", 1},
+		// Partial versions
+		{"2022.3.60f1", "2022.3.60", 1},
+		{"2022.3", "2021.3", 1},
 	}
 
 	for _, tt := range tests {
@@ -320,6 +337,67 @@ func TestParseBatchReleasesResponse_EmptyData(t *testing.T) {
 	}
 }
 
+func TestParseBatchReleasesResponse_PartialErrors(t *testing.T) {
+	client := &Client{}
+
+	responseJSON := `{
+		"errors": [
+			{"message": "Entitlement XLTS expired", "path": ["v2021_3"], "extensions": {"code": "FORBIDDEN"}}
+		],
+		"data": {
+			"v2021_3": null,
+			"v2022_3": {"edges": [{"node": {"version": "2022.3.60f1", "stream": "LTS", "downloads": []}}]}
+		}
+	}`
+	releases, err := client.parseBatchReleasesResponse([]byte(responseJSON))
+	if len(releases) != 1 || releases[0].Version != "2022.3.60f1" {
+		t.Errorf("releases = %+v, want the 2022.3 release", releases)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if !apiErr.Partial || len(apiErr.Streams) != 1 || apiErr.Streams[0] != "2021.3" {
+		t.Errorf("APIError = %+v, want partial failure of 2021.3", apiErr)
+	}
+	if want := "unity API failed for 2021.3: Entitlement XLTS expired"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestParseBatchReleasesResponse_Errors(t *testing.T) {
+	client := &Client{}
+
+	responseJSON := `{"errors": [{"message": "Cannot query field \"shortRevision\" on type \"UnityRelease\"."}], "data": null}`
+	releases, err := client.parseBatchReleasesResponse([]byte(responseJSON))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Partial || len(releases) != 0 {
+		t.Fatalf("releases = %d, err = %v; want a complete failure", len(releases), err)
+	}
+	if !strings.Contains(err.Error(), `Cannot query field "shortRevision"`) {
+		t.Errorf("Error() = %q, want the GraphQL message", err.Error())
+	}
+}
+
+func TestGraphQLStatusError(t *testing.T) {
+	if err := graphQLStatusError(http.StatusOK, nil); err != nil {
+		t.Errorf("200: err = %v", err)
+	}
+
+	err := graphQLStatusError(http.StatusUnauthorized, []byte(`{"errors": [{"message": "Unauthorized"}]}`))
+	if err == nil || err.Error() != "unity API returned HTTP 401: Unauthorized" {
+		t.Errorf("401: err = %v", err)
+	}
+	if errs.CategoryOf(err) != nil {
+		t.Errorf("401 categorized as %v", errs.CategoryOf(err))
+	}
+
+	err = graphQLStatusError(http.StatusBadGateway, []byte("<html>Bad Gateway</html>"))
+	if !errors.Is(err, errs.NetworkUnavailable) || err.Error() != "unity API returned HTTP 502" {
+		t.Errorf("502: err = %v", err)
+	}
+}
+
 func TestModuleInfo_IsVisible(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -602,3 +680,82 @@ func TestReleaseCacheRoundTrip(t *testing.T) {
 		t.Errorf("Modules length = %d, want %d", len(parsed.Modules), len(original.Modules))
 	}
 }
+
+func TestEstimateInstallSize(t *testing.T) {
+	release := &UnityRelease{
+		Version:       "2022.3.60f1",
+		DownloadSize:  1000,
+		InstalledSize: 3000,
+		Modules: []ModuleInfo{
+			{ID: "android", DownloadSize: 100, InstalledSize: 300},
+			{ID: "windows-il2cpp", DownloadSize: 50, InstalledSize: 150},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		modules       []string
+		includeEditor bool
+		expected      InstallSize
+	}{
+		{"editor only", nil, true, InstallSize{Download: 1000, Installed: 3000}},
+		{"editor with modules", []string{"android", "windows"}, true, InstallSize{Download: 1150, Installed: 3450}},
+		{"modules only", []string{"android"}, false, InstallSize{Download: 100, Installed: 300}},
+		{"unknown module", []string{"switch"}, false, InstallSize{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateInstallSize(release, tt.modules, tt.includeEditor)
+			if got != tt.expected {
+				t.Errorf("EstimateInstallSize() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := EstimateInstallSize(nil, []string{"android"}, true); got != (InstallSize{}) {
+		t.Errorf("EstimateInstallSize(nil) = %+v, want zero", got)
+	}
+}
+
+func TestLoadCache_DiscardsCorruptedFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", home)
+	t.Setenv("LocalAppData", home)
+
+	client := &Client{}
+	cachePath := client.getReleaseCacheFilePath()
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachePath, []byte(`{"streams": {"2022.3": {`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := client.LoadCache()
+	if err != nil {
+		t.Fatalf("Expected corrupted cache to be discarded without error, got %v", err)
+	}
+	if cache != nil {
+		t.Error("Expected nil cache for corrupted file")
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Error("Expected corrupted cache file to be removed")
+	}
+
+	// Saving afterwards produces a readable cache
+	streams := []VersionStream{{MajorMinor: "2022.3", TotalCount: 1, LatestVersion: "2022.3.60f1", LTS: true}}
+	releases := []UnityRelease{{Version: "2022.3.60f1", LTS: true}}
+	if err := client.SaveCache(streams, releases); err != nil {
+		t.Fatalf("SaveCache failed: %v", err)
+	}
+
+	cache, err = client.LoadCache()
+	if err != nil || cache == nil {
+		t.Fatalf("LoadCache after save failed: %v", err)
+	}
+	if len(cache.Releases) != 1 || cache.Releases[0].Version != "2022.3.60f1" {
+		t.Errorf("Unexpected cache contents: %+v", cache.Releases)
+	}
+}
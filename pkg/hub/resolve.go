@@ -0,0 +1,175 @@
+package hub
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Version spec keywords accepted by ResolveVersionSpec
+const (
+	VersionSpecLatest = "latest"
+	VersionSpecLTS    = "lts"
+)
+
+// IsExactVersion returns true if spec is a full Unity version such as "2022.3.60f1"
+func IsExactVersion(spec string) bool {
+	return isValidUnityVersion(spec)
+}
+
+// ResolveVersionSpec resolves a fuzzy version specifier to a full Unity version.
+//
+// Supported specifiers:
+//   - "2022.3.60f1": returned as is
+//   - "2022.3", "2022", "6", "6.3": newest patch of that major/minor ("6" means 6000)
+//   - "lts": newest LTS release
+//   - "latest": newest final release
+//
+// Installed editors are searched by default. When includeAvailable is true,
+// releases available for download are considered as well (for install).
+func (c *Client) ResolveVersionSpec(spec string, includeAvailable bool) (string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || IsExactVersion(spec) {
+		return spec, nil
+	}
+
+	var installed []string
+	editors, err := c.ListInstalledEditors()
+	if err != nil && !includeAvailable {
+		return "", fmt.Errorf("failed to list installed editors: %w", err)
+	}
+	for _, e := range editors {
+		installed = append(installed, e.Version)
+	}
+
+	// Release metadata is needed to know which versions are LTS
+	var releases []UnityRelease
+	if includeAvailable || strings.EqualFold(spec, VersionSpecLTS) {
+		releases, err = c.GetAllReleases()
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch releases: %w", err)
+		}
+	}
+
+	version, err := resolveVersionSpec(spec, installed, releases, includeAvailable)
+	if err != nil {
+		return "", err
+	}
+
+	ui.Debug("Resolved version spec", "spec", spec, "version", version)
+	return version, nil
+}
+
+// resolveVersionSpec picks the newest candidate matching spec
+func resolveVersionSpec(spec string, installed []string, releases []UnityRelease, includeAvailable bool) (string, error) {
+	lts := make(map[string]bool)
+	for _, r := range releases {
+		if r.LTS {
+			lts[r.Version] = true
+		}
+	}
+
+	candidates := installed
+	if includeAvailable {
+		candidates = append([]string{}, installed...)
+		for _, r := range releases {
+			candidates = append(candidates, r.Version)
+		}
+	}
+
+	var match func(string) bool
+	switch strings.ToLower(spec) {
+	case VersionSpecLatest:
+		match = func(string) bool { return true }
+	case VersionSpecLTS:
+		match = func(v string) bool { return lts[v] }
+	default:
+		prefix, err := normalizeVersionPrefix(spec)
+		if err != nil {
+			return "", err
+		}
+		match = func(v string) bool { return strings.HasPrefix(v, prefix+".") }
+	}
+
+	// Prefer final releases; fall back to pre-releases only when nothing else matches
+	best, bestPre := "", ""
+	for _, v := range candidates {
+		if !match(v) {
+			continue
+		}
+		if isPreRelease(v) {
+			if bestPre == "" || compareVersions(v, bestPre) > 0 {
+				bestPre = v
+			}
+			continue
+		}
+		if best == "" || compareVersions(v, best) > 0 {
+			best = v
+		}
+	}
+
+	if best == "" {
+		best = bestPre
+	}
+	if best == "" {
+		if includeAvailable {
+			return "", errs.New(errs.NotFound, "no Unity release matches %q", spec)
+		}
+		return "", errs.New(errs.NotInstalled, "no installed Unity editor matches %q", spec)
+	}
+
+	return best, nil
+}
+
+// normalizeVersionPrefix validates a partial version and expands Unity 6 shorthand ("6.3" -> "6000.3")
+func normalizeVersionPrefix(spec string) (string, error) {
+	parts := strings.Split(spec, ".")
+	if len(parts) > 2 {
+		return "", errs.New(errs.Usage, "invalid version spec: %s", spec)
+	}
+	for _, p := range parts {
+		if p == "" || strings.Trim(p, "0123456789") != "" {
+			return "", errs.New(errs.Usage, "invalid version spec: %s (expected e.g. 2022.3, 6, lts or latest)", spec)
+		}
+	}
+
+	if parts[0] == "6" {
+		parts[0] = "6000"
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// isPreRelease returns true for alpha and beta versions
+func isPreRelease(version string) bool {
+	_, releaseType, _, _ := parseVersionSuffix(version[strings.LastIndex(version, ".")+1:])
+	return releaseType < ReleaseFinal
+}
+
+// LatestLTSReleases returns the newest LTS release of each stream, newest stream first,
+// limited to n entries (n <= 0 means no limit)
+func LatestLTSReleases(releases []UnityRelease, n int) []UnityRelease {
+	newest := make(map[string]UnityRelease)
+	for _, r := range releases {
+		if !r.LTS || isPreRelease(r.Version) {
+			continue
+		}
+		stream := GetMajorMinorFromVersion(r.Version)
+		if cur, ok := newest[stream]; !ok || compareVersions(r.Version, cur.Version) > 0 {
+			newest[stream] = r
+		}
+	}
+
+	result := make([]UnityRelease, 0, len(newest))
+	for _, r := range newest {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool { return compareVersions(result[i].Version, result[j].Version) > 0 })
+
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
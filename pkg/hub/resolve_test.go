@@ -0,0 +1,103 @@
+package hub
+
+import "testing"
+
+func TestIsExactVersion(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected bool
+	}{
+		{"2022.3.60f1", true},
+		{"6000.0.32f1", true},
+		{"6000.4.0b3", true},
+		{"2022.3", false},
+		{"6", false},
+		{"lts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := IsExactVersion(tt.spec); got != tt.expected {
+				t.Errorf("IsExactVersion(%q) = %v, want %v", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveVersionSpec(t *testing.T) {
+	installed := []string{"2022.3.10f1", "2022.3.45f1", "2021.3.30f1", "6000.0.20f1", "6000.4.0b3"}
+	releases := []UnityRelease{
+		{Version: "2022.3.60f1", LTS: true},
+		{Version: "2022.3.45f1", LTS: true},
+		{Version: "2021.3.45f1", LTS: true},
+		{Version: "6000.0.32f1", LTS: true},
+		{Version: "6000.0.20f1", LTS: true},
+		{Version: "6000.2.5f1"},
+		{Version: "6000.4.0b5"},
+	}
+
+	tests := []struct {
+		name             string
+		spec             string
+		includeAvailable bool
+		expected         string
+		wantErr          bool
+	}{
+		{"major.minor installed", "2022.3", false, "2022.3.45f1", false},
+		{"major.minor available", "2022.3", true, "2022.3.60f1", false},
+		{"major only", "2021", false, "2021.3.30f1", false},
+		{"unity 6 shorthand", "6", false, "6000.0.20f1", false},
+		{"unity 6 shorthand available", "6", true, "6000.2.5f1", false},
+		{"unity 6 with minor", "6.0", true, "6000.0.32f1", false},
+		{"pre-release only", "6000.4", false, "6000.4.0b3", false},
+		{"lts installed", "lts", false, "6000.0.20f1", false},
+		{"lts available", "LTS", true, "6000.0.32f1", false},
+		{"latest installed", "latest", false, "6000.0.20f1", false},
+		{"latest available", "latest", true, "6000.2.5f1", false},
+		{"no match", "2019.4", false, "", true},
+		{"invalid", "2022.x", false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveVersionSpec(tt.spec, installed, releases, tt.includeAvailable)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveVersionSpec(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("resolveVersionSpec(%q) = %q, want %q", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveVersionSpec_Exact(t *testing.T) {
+	client := &Client{}
+	got, err := client.ResolveVersionSpec("2022.3.60f1", false)
+	if err != nil {
+		t.Fatalf("ResolveVersionSpec failed: %v", err)
+	}
+	if got != "2022.3.60f1" {
+		t.Errorf("Expected exact version to be returned as is, got %q", got)
+	}
+}
+
+func TestLatestLTSReleases(t *testing.T) {
+	releases := []UnityRelease{
+		{Version: "2021.3.40f1", LTS: true},
+		{Version: "2022.3.50f1", LTS: true},
+		{Version: "2022.3.62f1", LTS: true},
+		{Version: "6000.0.30f1", LTS: true},
+		{Version: "6000.1.5f1"},
+		{Version: "2022.3.9f1", LTS: true},
+	}
+
+	got := LatestLTSReleases(releases, 2)
+	if len(got) != 2 || got[0].Version != "6000.0.30f1" || got[1].Version != "2022.3.62f1" {
+		t.Errorf("LatestLTSReleases() = %v, want [6000.0.30f1 2022.3.62f1]", got)
+	}
+
+	if all := LatestLTSReleases(releases, 0); len(all) != 3 {
+		t.Errorf("LatestLTSReleases(n=0) returned %d releases, want 3", len(all))
+	}
+}
@@ -0,0 +1,121 @@
+package hub
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/platform"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// InstallSize is the estimated size of an editor and/or module installation
+type InstallSize struct {
+	Download  int64 // bytes
+	Installed int64 // bytes
+}
+
+// Add returns the sum of two sizes
+func (s InstallSize) Add(other InstallSize) InstallSize {
+	return InstallSize{
+		Download:  s.Download + other.Download,
+		Installed: s.Installed + other.Installed,
+	}
+}
+
+// Required returns the disk space needed on the install volume
+func (s InstallSize) Required() int64 {
+	if s.Installed > 0 {
+		return s.Installed
+	}
+	return s.Download
+}
+
+// InsufficientDiskSpaceError is returned when the install volume lacks free space
+type InsufficientDiskSpaceError struct {
+	Path      string
+	Required  int64
+	Available int64
+}
+
+func (e *InsufficientDiskSpaceError) Error() string {
+	return fmt.Sprintf("not enough disk space on %s: %s required, %s available",
+		e.Path, ui.FormatBytes(e.Required), ui.FormatBytes(e.Available))
+}
+
+// EstimateInstallSize sums the sizes of the editor (when includeEditor is set)
+// and the requested modules. Module names may use the short aliases accepted by --modules.
+func EstimateInstallSize(release *UnityRelease, modules []string, includeEditor bool) InstallSize {
+	var size InstallSize
+	if release == nil {
+		return size
+	}
+
+	if includeEditor {
+		size.Download = release.DownloadSize
+		size.Installed = release.InstalledSize
+	}
+
+	for _, name := range modules {
+		id := strings.ToLower(name)
+		if mapped, ok := moduleMap[id]; ok {
+			id = mapped
+		}
+		for _, mod := range release.Modules {
+			if mod.ID == id {
+				size = size.Add(InstallSize{Download: mod.DownloadSize, Installed: mod.InstalledSize})
+				break
+			}
+		}
+	}
+
+	return size
+}
+
+// FindRelease returns release metadata for version, or nil if it is unknown
+func (c *Client) FindRelease(version string) *UnityRelease {
+	releases, err := c.GetAllReleases()
+	if err != nil {
+		ui.Debug("Failed to fetch releases for size estimate", "error", err)
+		return nil
+	}
+	for i := range releases {
+		if releases[i].Version == version {
+			return &releases[i]
+		}
+	}
+	return nil
+}
+
+// CheckDiskSpace verifies that the install volume has room for size
+func (c *Client) CheckDiskSpace(size InstallSize) error {
+	required := size.Required()
+	if required <= 0 {
+		return nil
+	}
+
+	installPath, free, ok := c.installVolumeFreeSpace()
+	if !ok {
+		return nil
+	}
+
+	if free < required {
+		return &InsufficientDiskSpaceError{Path: installPath, Required: required, Available: free}
+	}
+	return nil
+}
+
+// installVolumeFreeSpace returns the editor install path and the free bytes on its volume
+func (c *Client) installVolumeFreeSpace() (string, int64, bool) {
+	installPath, err := c.GetInstallPath()
+	if err != nil || installPath == "" {
+		ui.Debug("Skipping disk space check, install path unknown", "error", err)
+		return "", 0, false
+	}
+
+	free, err := platform.FreeDiskSpace(installPath)
+	if err != nil {
+		ui.Debug("Skipping disk space check", "path", installPath, "error", err)
+		return "", 0, false
+	}
+	return installPath, int64(free), true
+}
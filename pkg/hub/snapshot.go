@@ -0,0 +1,205 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// snapshotConcurrency limits the editors read at the same time
+const snapshotConcurrency = 8
+
+// editorSnapshot is the installed editors as seen once per command. Reading
+// every version directory for each query made commands slow on machines with
+// many editors, so the Client keeps what it read until it is invalidated.
+type editorSnapshot struct {
+	mu      sync.Mutex
+	editors []EditorInfo
+	err     error
+	listed  bool
+	states  map[string]*editorState // By editor path
+}
+
+// editorState is what an installed editor reports about itself
+type editorState struct {
+	modules   []ModuleEntry   // From modules.json; nil if missing or unreadable
+	engines   map[string]bool // Directories below PlaybackEngines
+	changeset string          // From version.txt; "" if not found
+}
+
+// InvalidateEditors drops the installed editor snapshot, so the next query reads
+// the install directories again. Installing editors or modules calls it.
+func (c *Client) InvalidateEditors() {
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+	c.snapshot = nil
+}
+
+// currentSnapshot returns the snapshot, creating an empty one if needed
+func (c *Client) currentSnapshot() *editorSnapshot {
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+	if c.snapshot == nil {
+		c.snapshot = &editorSnapshot{states: make(map[string]*editorState)}
+	}
+	return c.snapshot
+}
+
+// ListInstalledEditors returns the installed editors with their changeset and
+// installed modules. The editors and their modules are read concurrently on
+// the first call and reused afterwards.
+func (c *Client) ListInstalledEditors() ([]EditorInfo, error) {
+	s := c.currentSnapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.listed {
+		s.editors, s.err = c.listInstalledEditors()
+		s.listed = true
+		if s.err == nil {
+			c.loadEditorStates(s, s.editors)
+			fillEditorStates(s)
+		}
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	editors := make([]EditorInfo, len(s.editors))
+	for i, e := range s.editors {
+		e.InstalledModules = slices.Clone(e.InstalledModules)
+		editors[i] = e
+	}
+	return editors, nil
+}
+
+// fillEditorStates sets the changeset and installed modules of the listed
+// editors from their states
+func fillEditorStates(s *editorSnapshot) {
+	for i := range s.editors {
+		e := &s.editors[i]
+		state, ok := s.states[e.Path]
+		if !ok {
+			continue
+		}
+		if e.Changeset == "" {
+			e.Changeset = state.changeset
+		}
+		e.InstalledModules = e.InstalledModules[:0]
+		for id := range state.installedModules() {
+			e.InstalledModules = append(e.InstalledModules, id)
+		}
+		slices.Sort(e.InstalledModules)
+	}
+}
+
+// loadEditorStates reads the state of each editor not in the snapshot yet, in parallel
+func (c *Client) loadEditorStates(s *editorSnapshot, editors []EditorInfo) {
+	var paths []string
+	for _, e := range editors {
+		if _, ok := s.states[e.Path]; !ok && e.Path != "" {
+			paths = append(paths, e.Path)
+		}
+	}
+
+	states := make([]*editorState, len(paths))
+	forEachConcurrent(len(paths), snapshotConcurrency, func(i int) {
+		states[i] = c.readEditorState(paths[i])
+	})
+	for i, path := range paths {
+		s.states[path] = states[i]
+	}
+	ui.Debug("Read installed editor states", "count", len(paths))
+}
+
+// editorState returns the state of the editor at editorPath, reading it if it
+// is not part of the snapshot yet
+func (c *Client) editorState(editorPath string) *editorState {
+	s := c.currentSnapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[editorPath]
+	if !ok {
+		state = c.readEditorState(editorPath)
+		s.states[editorPath] = state
+	}
+	return state
+}
+
+// readEditorState reads modules.json, the PlaybackEngines directory and
+// version.txt of the editor at editorPath
+func (c *Client) readEditorState(editorPath string) *editorState {
+	state := &editorState{engines: make(map[string]bool)}
+
+	if path := c.getModulesFilePath(editorPath); path != "" {
+		modules, err := ReadModulesFile(path)
+		if err == nil {
+			state.modules = modules
+		} else if !os.IsNotExist(err) {
+			ui.Debug("Failed to read modules.json", "path", path, "error", err)
+		}
+	}
+
+	if entries, err := os.ReadDir(c.GetPlaybackEnginesPath(editorPath)); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				state.engines[entry.Name()] = true
+			}
+		}
+	}
+
+	if path := versionFilePath(editorPath); path != "" && fileExists(path) {
+		state.changeset = c.readChangesetFromVersionFile(path)
+	}
+	return state
+}
+
+// versionFilePath returns the location of an editor's version.txt
+func versionFilePath(editorPath string) string {
+	switch runtime.GOOS {
+	case "darwin":
+		if filepath.Ext(editorPath) == ".app" {
+			return filepath.Join(editorPath, "Contents", "Resources", "version.txt")
+		}
+		return filepath.Join(editorPath, "Unity.app", "Contents", "Resources", "version.txt")
+	case "windows":
+		// C:\Program Files\Unity\Hub\Editor\2022.3.20f1\Editor\Data\Resources\version.txt
+		if filepath.Ext(editorPath) == ".exe" {
+			return filepath.Join(filepath.Dir(editorPath), "Data", "Resources", "version.txt")
+		}
+		return filepath.Join(editorPath, "Editor", "Data", "Resources", "version.txt")
+	case "linux":
+		return filepath.Join(editorRootDir(editorPath), "Editor", "Data", "Resources", "version.txt")
+	}
+	return ""
+}
+
+// forEachConcurrent calls fn for 0..n-1 with at most limit calls running at once
+func forEachConcurrent(n, limit int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+	if limit > n {
+		limit = n
+	}
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
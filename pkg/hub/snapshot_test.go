@@ -0,0 +1,196 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// newLinuxEditorTree creates editors below $HOME/Unity/Hub/Editor, the default
+// Linux install path, and returns that directory
+func newLinuxEditorTree(t *testing.T, versions ...string) string {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("uses the Linux install layout")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := filepath.Join(home, "Unity", "Hub", "Editor")
+	for _, version := range versions {
+		editor := filepath.Join(root, version, "Editor")
+		if err := os.MkdirAll(filepath.Join(editor, "Data", "Resources"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(editor, "Unity"), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		versionTxt := version + " (cs" + version[:4] + ")\nLinux x64 Unity Editor\n"
+		if err := os.WriteFile(filepath.Join(editor, "Data", "Resources", "version.txt"), []byte(versionTxt), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A directory without an editor is ignored
+	if err := os.MkdirAll(filepath.Join(root, "2019.4.1f1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestEditorSnapshot(t *testing.T) {
+	root := newLinuxEditorTree(t, "2022.3.10f1", "6000.0.23f1")
+	c := &Client{}
+
+	editors, err := c.ListInstalledEditors()
+	if err != nil {
+		t.Fatalf("ListInstalledEditors() error = %v", err)
+	}
+	if len(editors) != 2 {
+		t.Fatalf("ListInstalledEditors() = %+v, want 2 editors", editors)
+	}
+
+	editorPath := filepath.Join(root, "2022.3.10f1", "Editor", "Unity")
+	if got := c.GetEditorChangeset(editorPath); got != "cs2022" {
+		t.Errorf("GetEditorChangeset() = %q, want cs2022", got)
+	}
+	if c.IsModuleInstalled(editorPath, "android") {
+		t.Error("android reported installed before it exists")
+	}
+
+	// Changes on disk are not seen until the snapshot is invalidated
+	engines := filepath.Join(root, "2022.3.10f1", "Editor", "Data", "PlaybackEngines", "AndroidPlayer")
+	if err := os.MkdirAll(engines, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if c.IsModuleInstalled(editorPath, "android") {
+		t.Error("snapshot was read again without invalidation")
+	}
+	c.InvalidateEditors()
+	if !c.IsModuleInstalled(editorPath, "android") {
+		t.Error("android not installed after InvalidateEditors()")
+	}
+
+	// Writing modules.json invalidates the snapshot
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	if c.IsModuleInstalled(editorPath, "webgl") {
+		t.Error("webgl reported installed before it exists")
+	}
+	if err := c.SetModulesInstalled(editorPath, "2022.3.10f1", []string{"webgl"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsModuleInstalled(editorPath, "webgl") {
+		t.Error("webgl not installed after SetModulesInstalled()")
+	}
+}
+
+func TestListInstalledEditorsStates(t *testing.T) {
+	root := newLinuxEditorTree(t, "2022.3.10f1", "6000.0.23f1")
+	engines := filepath.Join(root, "6000.0.23f1", "Editor", "Data", "PlaybackEngines")
+	for _, dir := range []string{"WebGLSupport", "AndroidPlayer"} {
+		if err := os.MkdirAll(filepath.Join(engines, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &Client{}
+
+	editors, err := c.ListInstalledEditors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byVersion := make(map[string]EditorInfo)
+	for _, e := range editors {
+		byVersion[e.Version] = e
+	}
+	if e := byVersion["2022.3.10f1"]; e.Changeset != "cs2022" || len(e.InstalledModules) != 0 {
+		t.Errorf("2022.3.10f1 = %+v", e)
+	}
+	if e := byVersion["6000.0.23f1"]; e.Changeset != "cs6000" || !slices.Equal(e.InstalledModules, []string{"android", "webgl"}) {
+		t.Errorf("6000.0.23f1 = %+v", e)
+	}
+
+	// Callers get their own copy of the modules
+	editors[0].InstalledModules = append(editors[0].InstalledModules[:0], "changed")
+	again, _ := c.ListInstalledEditors()
+	if slices.Contains(again[0].InstalledModules, "changed") {
+		t.Error("modules of the snapshot were changed through a returned editor")
+	}
+}
+
+func TestEnrichReleasesWithInstallStatus(t *testing.T) {
+	root := newLinuxEditorTree(t, "2022.3.10f1")
+	if err := os.MkdirAll(filepath.Join(root, "2022.3.10f1", "Editor", "Data", "PlaybackEngines", "WebGLSupport"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{}
+
+	releases := c.EnrichReleasesWithInstallStatus([]UnityRelease{
+		{Version: "2022.3.10f1", Modules: []ModuleInfo{{ID: "webgl"}, {ID: "android"}}},
+		{Version: "6000.0.23f1", Modules: []ModuleInfo{{ID: "webgl"}}},
+	})
+	if !releases[0].Installed || !releases[0].Modules[0].Installed || releases[0].Modules[1].Installed {
+		t.Errorf("2022.3.10f1 = %+v", releases[0])
+	}
+	if releases[1].Installed || releases[1].Modules[0].Installed {
+		t.Errorf("6000.0.23f1 = %+v", releases[1])
+	}
+}
+
+func TestForEachConcurrent(t *testing.T) {
+	var sum, running, peak atomic.Int64
+	forEachConcurrent(100, 4, func(i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		sum.Add(int64(i))
+		running.Add(-1)
+	})
+	if sum.Load() != 4950 {
+		t.Errorf("sum = %d, want 4950", sum.Load())
+	}
+	if peak.Load() > 4 {
+		t.Errorf("peak concurrency = %d, want <= 4", peak.Load())
+	}
+}
+
+func TestGetInstalledModules(t *testing.T) {
+	root := newLinuxEditorTree(t, "2022.3.10f1")
+	versionDir := filepath.Join(root, "2022.3.10f1")
+	for _, dir := range []string{"AndroidPlayer", "WebGLSupport", "iOSSupport"} {
+		if err := os.MkdirAll(filepath.Join(versionDir, "Editor", "Data", "PlaybackEngines", dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// webgl is marked as not installed, android's isInstalled is null, docs have no directory
+	modules := `[
+		{"id": "webgl", "isInstalled": false},
+		{"id": "android", "isInstalled": null},
+		{"id": "documentation", "isInstalled": true},
+		{"id": "linux-il2cpp", "isInstalled": null}
+	]`
+	if err := os.WriteFile(filepath.Join(versionDir, "modules.json"), []byte(modules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{}
+	editorPath := filepath.Join(versionDir, "Editor", "Unity")
+	got := c.GetInstalledModules(editorPath)
+	want := map[string]bool{"android": true, "ios": true, "documentation": true}
+	if len(got) != len(want) {
+		t.Errorf("GetInstalledModules() = %v, want %v", got, want)
+	}
+	for id := range want {
+		if !got[id] {
+			t.Errorf("%s not installed", id)
+		}
+	}
+
+	if missing := c.GetMissingModules(editorPath, []string{"android", "webgl", "linux"}); len(missing) != 2 || missing[0] != "webgl" || missing[1] != "linux" {
+		t.Errorf("GetMissingModules() = %v, want [webgl linux]", missing)
+	}
+}
@@ -0,0 +1,234 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// supportURL lists the support windows of Unity streams; replaced in tests
+var supportURL = "https://endoflife.date/api/unity.json"
+
+const (
+	// supportCacheTTL is how long fetched support windows are reused
+	supportCacheTTL = 7 * 24 * time.Hour
+	// supportRetryAfter delays the next fetch after a failed one, so offline
+	// machines do not wait for the network on every listing
+	supportRetryAfter = 24 * time.Hour
+	// SupportWarnBefore is how early a stream's end of support is announced
+	SupportWarnBefore = 180 * 24 * time.Hour
+)
+
+// SupportWindow is the period a major.minor stream receives updates
+type SupportWindow struct {
+	Stream   string    `json:"stream"` // e.g. "2022.3"
+	LTS      bool      `json:"lts"`
+	Released time.Time `json:"released"`
+	EOL      time.Time `json:"eol"` // End of support; zero when not announced
+}
+
+// builtinSupportWindows are the end of support dates of the public plans,
+// used until the published list has been fetched. Tech streams end when the
+// next stream is released.
+var builtinSupportWindows = []SupportWindow{
+	{Stream: "2018.4", LTS: true, Released: date(2019, 5, 13), EOL: date(2021, 5, 31)},
+	{Stream: "2019.4", LTS: true, Released: date(2020, 6, 8), EOL: date(2022, 6, 1)},
+	{Stream: "2020.3", LTS: true, Released: date(2021, 3, 9), EOL: date(2023, 5, 1)},
+	{Stream: "2021.3", LTS: true, Released: date(2022, 4, 13), EOL: date(2024, 4, 30)},
+	{Stream: "2022.3", LTS: true, Released: date(2023, 5, 30), EOL: date(2025, 5, 31)},
+	{Stream: "2023.1", Released: date(2023, 4, 13), EOL: date(2023, 11, 14)},
+	{Stream: "2023.2", Released: date(2023, 11, 14), EOL: date(2024, 4, 29)},
+	{Stream: "6000.0", LTS: true, Released: date(2024, 10, 16), EOL: date(2026, 10, 31)},
+	{Stream: "6000.1", Released: date(2025, 4, 23), EOL: date(2025, 8, 12)},
+	{Stream: "6000.2", Released: date(2025, 8, 12), EOL: date(2025, 12, 4)},
+	{Stream: "6000.3", LTS: true, Released: date(2025, 12, 4), EOL: date(2027, 12, 31)},
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// SupportWindows maps streams to their support windows
+type SupportWindows map[string]SupportWindow
+
+// For returns the support window of the stream version belongs to
+func (w SupportWindows) For(version string) (SupportWindow, bool) {
+	window, ok := w[GetMajorMinorFromVersion(version)]
+	return window, ok
+}
+
+// Badge returns "EOL" once support of the stream of version has ended,
+// "EOL in 3 months" within SupportWarnBefore of the end, and "" otherwise
+func (w SupportWindows) Badge(version string, now time.Time) string {
+	window, ok := w.For(version)
+	if !ok || window.EOL.IsZero() {
+		return ""
+	}
+	left := window.EOL.Sub(now)
+	switch {
+	case left <= 0:
+		return "EOL"
+	case left > SupportWarnBefore:
+		return ""
+	}
+	days := int(math.Ceil(left.Hours() / 24))
+	switch {
+	case days < 45:
+		return fmt.Sprintf("EOL in %d day%s", days, plural(days))
+	default:
+		months := (days + 15) / 30
+		return fmt.Sprintf("EOL in %d month%s", months, plural(months))
+	}
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+type supportCacheData struct {
+	UpdatedAt time.Time       `json:"updatedAt"`
+	Windows   []SupportWindow `json:"windows"`
+}
+
+func (c *Client) supportCacheFilePath() string {
+	return filepath.Join(c.CacheDir(), "support-windows.json")
+}
+
+// SupportWindows returns the support windows of Unity streams: the built-in
+// list, updated with the published list fetched at most once a week. Network
+// failures fall back to the last fetched or the built-in list.
+func (c *Client) SupportWindows() SupportWindows {
+	windows := make(SupportWindows)
+	for _, w := range builtinSupportWindows {
+		windows[w.Stream] = w
+	}
+
+	cache, err := c.loadSupportCache()
+	if err != nil && !os.IsNotExist(err) {
+		ui.Debug("Failed to read support windows cache", "error", err)
+	}
+	if cache == nil || c.NoCache || time.Since(cache.UpdatedAt) >= supportCacheTTL {
+		fetched, err := fetchSupportWindows()
+		if err != nil {
+			ui.Debug("Failed to fetch support windows", "error", err)
+			if cache == nil {
+				cache = &supportCacheData{}
+			}
+			// Try again tomorrow rather than on every listing
+			cache.UpdatedAt = time.Now().Add(supportRetryAfter - supportCacheTTL)
+		} else {
+			cache = &supportCacheData{UpdatedAt: time.Now(), Windows: fetched}
+		}
+		if err := c.saveSupportCache(cache); err != nil {
+			ui.Debug("Failed to save support windows cache", "error", err)
+		}
+	}
+
+	for _, w := range cache.Windows {
+		windows[w.Stream] = w
+	}
+	return windows
+}
+
+func (c *Client) loadSupportCache() (*supportCacheData, error) {
+	data, err := os.ReadFile(c.supportCacheFilePath())
+	if err != nil {
+		return nil, err
+	}
+	var cache supportCacheData
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+func (c *Client) saveSupportCache(cache *supportCacheData) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.CacheDir(), 0755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(c.supportCacheFilePath(), data, 0644)
+}
+
+// fetchSupportWindows downloads the published support windows
+func fetchSupportWindows() ([]SupportWindow, error) {
+	span := trace.Start(trace.KindHTTP, "support windows")
+	defer span.End()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(supportURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("support windows returned HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseSupportWindows(body)
+}
+
+// eolCycle is an entry of the endoflife.date API. eol and lts are either a
+// date or a boolean.
+type eolCycle struct {
+	Cycle       string          `json:"cycle"`
+	ReleaseDate string          `json:"releaseDate"`
+	EOL         json.RawMessage `json:"eol"`
+	LTS         json.RawMessage `json:"lts"`
+}
+
+// parseSupportWindows converts the endoflife.date list of Unity cycles
+func parseSupportWindows(body []byte) ([]SupportWindow, error) {
+	var cycles []eolCycle
+	if err := json.Unmarshal(body, &cycles); err != nil {
+		return nil, fmt.Errorf("failed to parse support windows: %w", err)
+	}
+
+	var windows []SupportWindow
+	for _, cycle := range cycles {
+		if strings.Count(cycle.Cycle, ".") != 1 {
+			continue
+		}
+		w := SupportWindow{Stream: cycle.Cycle}
+		w.Released, _ = time.Parse(time.DateOnly, cycle.ReleaseDate)
+
+		var eolDate string
+		var eolFlag bool
+		switch {
+		case json.Unmarshal(cycle.EOL, &eolDate) == nil:
+			w.EOL, _ = time.Parse(time.DateOnly, eolDate)
+		case json.Unmarshal(cycle.EOL, &eolFlag) == nil && eolFlag:
+			// Ended on an unpublished date; treat it as ended at release
+			w.EOL = w.Released
+		}
+
+		var ltsFlag bool
+		var ltsDate string
+		w.LTS = json.Unmarshal(cycle.LTS, &ltsFlag) == nil && ltsFlag ||
+			json.Unmarshal(cycle.LTS, &ltsDate) == nil && ltsDate != ""
+		windows = append(windows, w)
+	}
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("no Unity streams in support windows")
+	}
+	return windows, nil
+}
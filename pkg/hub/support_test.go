@@ -0,0 +1,112 @@
+package hub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSupportBadge(t *testing.T) {
+	windows := SupportWindows{
+		"2021.3": {Stream: "2021.3", LTS: true, EOL: date(2024, 4, 30)},
+		"2022.3": {Stream: "2022.3", LTS: true, EOL: date(2025, 5, 31)},
+		"6000.0": {Stream: "6000.0", LTS: true},
+	}
+	now := date(2025, 3, 1)
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"2021.3.45f1", "EOL"},
+		{"2022.3.61f1", "EOL in 3 months"},
+		{"6000.0.40f1", ""}, // Not announced
+		{"2019.4.40f1", ""}, // Unknown stream
+	}
+	for _, tt := range tests {
+		if got := windows.Badge(tt.version, now); got != tt.want {
+			t.Errorf("Badge(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+
+	if got := windows.Badge("2022.3.61f1", date(2025, 5, 20)); got != "EOL in 11 days" {
+		t.Errorf("Badge() 11 days before = %q", got)
+	}
+	if got := windows.Badge("2022.3.61f1", date(2024, 5, 1)); got != "" {
+		t.Errorf("Badge() a year before = %q, want none", got)
+	}
+}
+
+func TestParseSupportWindows(t *testing.T) {
+	body := `[
+		{"cycle": "6000.0", "releaseDate": "2024-04-29", "eol": "2026-10-31", "lts": "2024-10-16"},
+		{"cycle": "6000.1", "releaseDate": "2025-04-23", "eol": true, "lts": false},
+		{"cycle": "6000.3", "releaseDate": "2025-12-04", "eol": false, "lts": true},
+		{"cycle": "5", "releaseDate": "2015-03-03", "eol": true}
+	]`
+	windows, err := parseSupportWindows([]byte(body))
+	if err != nil {
+		t.Fatalf("parseSupportWindows() error = %v", err)
+	}
+	if len(windows) != 3 {
+		t.Fatalf("got %d windows, want 3: %+v", len(windows), windows)
+	}
+	if w := windows[0]; !w.LTS || !w.EOL.Equal(date(2026, 10, 31)) {
+		t.Errorf("6000.0 = %+v", w)
+	}
+	if w := windows[1]; w.LTS || w.EOL.IsZero() {
+		t.Errorf("6000.1 = %+v, want ended tech stream", w)
+	}
+	if w := windows[2]; !w.LTS || !w.EOL.IsZero() {
+		t.Errorf("6000.3 = %+v, want LTS without end", w)
+	}
+
+	if _, err := parseSupportWindows([]byte(`[]`)); err == nil {
+		t.Error("parseSupportWindows() of an empty list succeeded")
+	}
+}
+
+func TestClientSupportWindows(t *testing.T) {
+	requests := 0
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`[{"cycle": "2022.3", "releaseDate": "2023-05-30", "eol": "2026-05-31", "lts": true}]`))
+	}))
+	defer server.Close()
+	defer func(url string) { supportURL = url }(supportURL)
+	supportURL = server.URL
+
+	client := NewClientWithOptions(ClientOptions{CacheDir: t.TempDir()})
+	windows := client.SupportWindows()
+	if got := windows["2022.3"].EOL; !got.Equal(date(2026, 5, 31)) {
+		t.Errorf("fetched 2022.3 EOL = %v", got)
+	}
+	if _, ok := windows["2021.3"]; !ok {
+		t.Error("built-in 2021.3 missing")
+	}
+
+	// The fetched list is cached
+	client.SupportWindows()
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 with a fresh cache", requests)
+	}
+
+	// A failed refresh keeps the cached list and is not retried right away
+	cache, _ := client.loadSupportCache()
+	cache.UpdatedAt = time.Now().Add(-supportCacheTTL)
+	if err := client.saveSupportCache(cache); err != nil {
+		t.Fatal(err)
+	}
+	status = http.StatusInternalServerError
+	windows = client.SupportWindows()
+	if got := windows["2022.3"].EOL; !got.Equal(date(2026, 5, 31)) {
+		t.Errorf("2022.3 EOL after failed refresh = %v", got)
+	}
+	client.SupportWindows()
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 after a failed refresh", requests)
+	}
+}
@@ -0,0 +1,178 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Tags with a meaning of their own
+const (
+	TagFavorite = "favorite" // Listed first
+	TagArchived = "archived" // Hidden unless asked for
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// tagsFileData is the structure of project-tags.json, keyed by absolute project path
+type tagsFileData struct {
+	Projects map[string][]string `json:"projects"`
+}
+
+// getTagsFilePath returns the path to uniforge's project tags file
+func (c *Client) getTagsFilePath() string {
+	if c.tagsFileOverride != "" {
+		return c.tagsFileOverride
+	}
+	return filepath.Join(c.StateDir(), "project-tags.json")
+}
+
+// NormalizeTag lower-cases tag and checks that it is a single word of
+// letters, digits, '.', '_' and '-'
+func NormalizeTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	if !tagPattern.MatchString(normalized) {
+		return "", errs.New(errs.Usage, "invalid tag %q: use letters, digits, '.', '_' and '-'", tag)
+	}
+	return normalized, nil
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, len(tags))
+	for i, tag := range tags {
+		var err error
+		if normalized[i], err = NormalizeTag(tag); err != nil {
+			return nil, err
+		}
+	}
+	return normalized, nil
+}
+
+// LoadProjectTags returns the tags of every tagged project, keyed by absolute path
+func (c *Client) LoadProjectTags() (map[string][]string, error) {
+	data, err := os.ReadFile(c.getTagsFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read project tags: %w", err)
+	}
+
+	var state tagsFileData
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", c.getTagsFilePath(), err)
+	}
+	if state.Projects == nil {
+		state.Projects = map[string][]string{}
+	}
+	return state.Projects, nil
+}
+
+// UpdateProjectTags adds and removes tags of the project at projectPath and
+// returns its tags afterwards, sorted
+func (c *Client) UpdateProjectTags(projectPath string, add, remove []string) ([]string, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	absPath = filepath.Clean(absPath)
+
+	if add, err = normalizeTags(add); err != nil {
+		return nil, err
+	}
+	if remove, err = normalizeTags(remove); err != nil {
+		return nil, err
+	}
+
+	stateFile := c.getTagsFilePath()
+	lock, err := fsutil.Lock(stateFile, cacheLockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock project tags: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	all, err := c.LoadProjectTags()
+	if err != nil {
+		return nil, err
+	}
+	tags := slices.DeleteFunc(append(all[absPath], add...), func(tag string) bool { return slices.Contains(remove, tag) })
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	if len(tags) == 0 {
+		delete(all, absPath)
+	} else {
+		all[absPath] = tags
+	}
+
+	data, err := json.MarshalIndent(tagsFileData{Projects: all}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode project tags: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(stateFile, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write project tags: %w", err)
+	}
+	return tags, nil
+}
+
+// fillTags sets Tags from uniforge's tags file. Failures are only logged so a
+// broken file does not hide the project list.
+func (c *Client) fillTags(projects []ProjectInfo) {
+	all, err := c.LoadProjectTags()
+	if err != nil {
+		ui.Debug("Failed to load project tags", "error", err)
+		return
+	}
+	for i := range projects {
+		projects[i].Tags = all[filepath.Clean(projects[i].Path)]
+	}
+}
+
+// HasTag reports whether the project is tagged with tag
+func (p ProjectInfo) HasTag(tag string) bool {
+	return slices.Contains(p.Tags, tag)
+}
+
+// FilterProjectsByTags returns the projects having all of tags, favorites
+// first. Archived projects are left out unless includeArchived is set or
+// "archived" is one of the tags.
+func FilterProjectsByTags(projects []ProjectInfo, tags []string, includeArchived bool) []ProjectInfo {
+	includeArchived = includeArchived || slices.Contains(tags, TagArchived)
+	var result []ProjectInfo
+	for _, p := range projects {
+		if !includeArchived && p.HasTag(TagArchived) {
+			continue
+		}
+		if !slices.ContainsFunc(tags, func(tag string) bool { return !p.HasTag(tag) }) {
+			result = append(result, p)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b ProjectInfo) int {
+		switch {
+		case a.HasTag(TagFavorite) == b.HasTag(TagFavorite):
+			return 0
+		case a.HasTag(TagFavorite):
+			return -1
+		default:
+			return 1
+		}
+	})
+	return result
+}
+
+// ProjectTagNames returns every tag used by the projects, sorted
+func ProjectTagNames(projects []ProjectInfo) []string {
+	var tags []string
+	for _, p := range projects {
+		tags = append(tags, p.Tags...)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
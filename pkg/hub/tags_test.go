@@ -0,0 +1,107 @@
+package hub
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUpdateProjectTags(t *testing.T) {
+	projectsJSON := `{
+		"schema_version": "v1",
+		"data": {
+			"/path/to/client": {"title": "Client", "path": "/path/to/client", "version": "2022.3.60f1"},
+			"/path/to/other": {"title": "Other", "path": "/path/to/other", "version": "2022.3.60f1"}
+		}
+	}`
+	client := createTestClient(t, projectsJSON)
+
+	tags, err := client.UpdateProjectTags("/path/to/client", []string{"Client", "prototype", "client"}, nil)
+	if err != nil {
+		t.Fatalf("UpdateProjectTags() error = %v", err)
+	}
+	if want := []string{"client", "prototype"}; !slices.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	tags, err = client.UpdateProjectTags("/path/to/client", []string{"archived"}, []string{"prototype"})
+	if err != nil {
+		t.Fatalf("UpdateProjectTags() error = %v", err)
+	}
+	if want := []string{"archived", "client"}; !slices.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	projects, err := client.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	for _, p := range projects {
+		switch p.Title {
+		case "Client":
+			if !slices.Equal(p.Tags, tags) {
+				t.Errorf("Tags = %v, want %v", p.Tags, tags)
+			}
+		case "Other":
+			if len(p.Tags) != 0 {
+				t.Errorf("Tags = %v, want none", p.Tags)
+			}
+		}
+	}
+
+	if _, err := client.UpdateProjectTags("/path/to/client", []string{"two words"}, nil); err == nil {
+		t.Error("UpdateProjectTags() with an invalid tag: expected error")
+	}
+}
+
+func TestFilterProjectsByTags(t *testing.T) {
+	projects := []ProjectInfo{
+		{Title: "a", Tags: []string{"client"}},
+		{Title: "b", Tags: []string{"archived", "client"}},
+		{Title: "c"},
+		{Title: "d", Tags: []string{"client", "favorite"}},
+	}
+
+	titles := func(projects []ProjectInfo) []string {
+		var result []string
+		for _, p := range projects {
+			result = append(result, p.Title)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name            string
+		tags            []string
+		includeArchived bool
+		want            []string
+	}{
+		{"default hides archived, favorites first", nil, false, []string{"d", "a", "c"}},
+		{"include archived", nil, true, []string{"d", "a", "b", "c"}},
+		{"by tag", []string{"client"}, false, []string{"d", "a"}},
+		{"archived tag shows archived", []string{"archived"}, false, []string{"b"}},
+		{"all tags must match", []string{"client", "favorite"}, false, []string{"d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titles(FilterProjectsByTags(projects, tt.tags, tt.includeArchived))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FilterProjectsByTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextTag(t *testing.T) {
+	tags := []string{"archived", "client"}
+	want := []string{"archived", "client", "", "archived"}
+	current := ""
+	for i, w := range want {
+		current = nextTag(tags, current)
+		if current != w {
+			t.Errorf("step %d: nextTag() = %q, want %q", i, current, w)
+		}
+	}
+	if got := nextTag(nil, ""); got != "" {
+		t.Errorf("nextTag(nil) = %q, want empty", got)
+	}
+}
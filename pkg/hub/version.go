@@ -0,0 +1,185 @@
+package hub
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReleaseType is the kind of build a Unity version names, in release order
+type ReleaseType int
+
+const (
+	ReleaseAlpha ReleaseType = iota + 1 // "a", e.g. 6000.4.0a5
+	ReleaseBeta                         // "b", e.g. 6000.4.0b3
+	ReleaseFinal                        // "f", e.g. 2022.3.60f1
+	ReleasePatch                        // "p", e.g. 2019.4.1p1, released after 2019.4.1f1
+)
+
+var releaseTypeLetters = map[byte]ReleaseType{
+	'a': ReleaseAlpha,
+	'b': ReleaseBeta,
+	'f': ReleaseFinal,
+	'p': ReleasePatch,
+}
+
+// Letter returns the version letter of t, or "" if t is unknown
+func (t ReleaseType) Letter() string {
+	for letter, rt := range releaseTypeLetters {
+		if rt == t {
+			return string(letter)
+		}
+	}
+	return ""
+}
+
+// unityVersionPattern matches major.minor.patch, the release type and revision,
+// and the optional China build suffix
+var unityVersionPattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)([abfp])(\d+)(?:([cx])(\d+))?$`)
+
+// Version is a parsed Unity version such as "2022.3.60f1". China builds append
+// a "c" or "x" revision to a regular version ("2021.3.14f1c1") and sort after
+// it but before the next regular revision.
+type Version struct {
+	Major    int
+	Minor    int
+	Patch    int
+	Type     ReleaseType
+	Revision int
+
+	China         string // "c" or "x" for China builds, "" otherwise
+	ChinaRevision int
+}
+
+// ParseVersion parses a full Unity version. Partial versions such as "2022.3"
+// are rejected; use CompareVersions to compare those.
+func ParseVersion(s string) (Version, error) {
+	m := unityVersionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid Unity version %q (expected e.g. 2022.3.60f1)", s)
+	}
+	num := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	return Version{
+		Major:         num(m[1]),
+		Minor:         num(m[2]),
+		Patch:         num(m[3]),
+		Type:          releaseTypeLetters[m[4][0]],
+		Revision:      num(m[5]),
+		China:         m[6],
+		ChinaRevision: num(m[7]),
+	}, nil
+}
+
+// String returns the version as Unity writes it
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d%s%d", v.Major, v.Minor, v.Patch, v.Type.Letter(), v.Revision)
+	if v.China != "" {
+		s += fmt.Sprintf("%s%d", v.China, v.ChinaRevision)
+	}
+	return s
+}
+
+// MajorMinor returns the stream of the version, e.g. "2022.3"
+func (v Version) MajorMinor() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// IsPreRelease returns true for alpha and beta versions
+func (v Version) IsPreRelease() bool {
+	return v.Type < ReleaseFinal
+}
+
+// Compare returns >0 if v is newer than o, <0 if it is older and 0 if they are equal
+func (v Version) Compare(o Version) int {
+	return compareParts(v.parts(), o.parts())
+}
+
+func (v Version) parts() []int {
+	return []int{v.Major, v.Minor, v.Patch, int(v.Type), v.Revision, v.ChinaRevision}
+}
+
+// isValidUnityVersion checks if a string is a full Unity version
+func isValidUnityVersion(s string) bool {
+	_, err := ParseVersion(s)
+	return err == nil
+}
+
+// CompareVersions compares two Unity version strings such as "2022.3.60f1" or "2021.3"
+// Returns: >0 if v1 > v2, <0 if v1 < v2, 0 if equal
+func CompareVersions(v1, v2 string) int {
+	return compareVersions(v1, v2)
+}
+
+// compareVersions compares two Unity version strings
+// Returns: >0 if v1 > v2, <0 if v1 < v2, 0 if equal
+func compareVersions(v1, v2 string) int {
+	return compareParts(parseVersionParts(v1), parseVersionParts(v2))
+}
+
+func compareParts(p1, p2 []int) int {
+	for i := 0; i < len(p1) && i < len(p2); i++ {
+		if p1[i] > p2[i] {
+			return 1
+		}
+		if p1[i] < p2[i] {
+			return -1
+		}
+	}
+
+	// If all parts are equal, longer version is greater
+	return len(p1) - len(p2)
+}
+
+// parseVersionParts parses a possibly partial version string into comparable integer parts
+// Format: major.minor.patch[a|b|f|p]N[c|x]M -> [major, minor, patch, releaseType, releaseNum, chinaNum]
+// A full version gives the same parts as Version.parts
+func parseVersionParts(version string) []int {
+	var parts []int
+
+	// Split by dot
+	dotParts := strings.Split(version, ".")
+	for i, part := range dotParts {
+		if i == len(dotParts)-1 {
+			// Last part may contain suffix like "60f1", "0b3", "0a5", "14f1c1"
+			num, releaseType, releaseNum, chinaNum := parseVersionSuffix(part)
+			parts = append(parts, num, int(releaseType), releaseNum, chinaNum)
+		} else {
+			var num int
+			_, _ = fmt.Sscanf(part, "%d", &num)
+			parts = append(parts, num)
+		}
+	}
+
+	return parts
+}
+
+// parseVersionSuffix parses "60f1" -> (60, final, 1, 0), "0b3" -> (0, beta, 3, 0),
+// "14f1c2" -> (14, final, 1, 2). A number without suffix is treated as a final release.
+func parseVersionSuffix(part string) (num int, releaseType ReleaseType, releaseNum, chinaNum int) {
+	digits := func(s string) (int, string) {
+		end := 0
+		for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+			end++
+		}
+		n, _ := strconv.Atoi(s[:end])
+		return n, s[end:]
+	}
+
+	num, rest := digits(part)
+	releaseType = ReleaseFinal
+	if rest == "" {
+		return num, releaseType, 0, 0
+	}
+	if rt, ok := releaseTypeLetters[rest[0]]; ok {
+		releaseType = rt
+		releaseNum, rest = digits(rest[1:])
+	}
+	if rest != "" && (rest[0] == 'c' || rest[0] == 'x') {
+		chinaNum, _ = digits(rest[1:])
+	}
+	return num, releaseType, releaseNum, chinaNum
+}
@@ -0,0 +1,93 @@
+package hub
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Version
+	}{
+		{"2022.3.60f1", Version{Major: 2022, Minor: 3, Patch: 60, Type: ReleaseFinal, Revision: 1}},
+		{"6000.4.0a5", Version{Major: 6000, Minor: 4, Type: ReleaseAlpha, Revision: 5}},
+		{"6000.4.0b12", Version{Major: 6000, Minor: 4, Type: ReleaseBeta, Revision: 12}},
+		{"2019.4.1p3", Version{Major: 2019, Minor: 4, Patch: 1, Type: ReleasePatch, Revision: 3}},
+		{"2021.3.14f1c1", Version{Major: 2021, Minor: 3, Patch: 14, Type: ReleaseFinal, Revision: 1, China: "c", ChinaRevision: 1}},
+		{"2022.3.2f1x3", Version{Major: 2022, Minor: 3, Patch: 2, Type: ReleaseFinal, Revision: 1, China: "x", ChinaRevision: 3}},
+		{"5.6.7f1", Version{Major: 5, Minor: 6, Patch: 7, Type: ReleaseFinal, Revision: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseVersion(tt.input)
+			if err != nil {
+				t.Fatalf("ParseVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseVersion() = %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.input {
+				t.Errorf("String() = %q, want %q", got.String(), tt.input)
+			}
+			if got.IsPreRelease() != (tt.want.Type < ReleaseFinal) {
+				t.Errorf("IsPreRelease() = %v", got.IsPreRelease())
+			}
+			// Parsed and string comparison agree
+			if got.Compare(tt.want) != 0 || compareVersions(tt.input, tt.want.String()) != 0 {
+				t.Errorf("%s does not compare equal to itself", tt.input)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "2022.3", "2022.3.60", "2022.3.60f", "2022.3.60t1", "2021.3.14f1c", "v2022.3.60f1", "2022.3.60f1 "} {
+		if _, err := ParseVersion(input); err == nil {
+			t.Errorf("ParseVersion(%q) succeeded, want error", input)
+		}
+	}
+}
+
+func TestVersionOrder(t *testing.T) {
+	// Every version here is newer than the one before it
+	ordered := []string{
+		"2018.4.36f1",
+		"2019.4.1f1",
+		"2019.4.1p1",
+		"2019.4.1p2",
+		"2019.4.2f1",
+		"2021.3.14f1",
+		"2021.3.14f1c1",
+		"2021.3.14f1c2",
+		"2021.3.14f2",
+		"2021.3.15f1",
+		"2022.3.9f1",
+		"2022.3.10f1",
+		"6000.0.0a1",
+		"6000.0.0a12",
+		"6000.0.0b1",
+		"6000.0.0f1",
+		"6000.0.1f1",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		older, newer := ordered[i-1], ordered[i]
+		if compareVersions(newer, older) <= 0 || compareVersions(older, newer) >= 0 {
+			t.Errorf("compareVersions does not order %s before %s", older, newer)
+		}
+		a, _ := ParseVersion(older)
+		b, _ := ParseVersion(newer)
+		if b.Compare(a) <= 0 || a.Compare(b) >= 0 {
+			t.Errorf("Version.Compare does not order %s before %s", older, newer)
+		}
+	}
+
+	reversed := make([]string, len(ordered))
+	for i, v := range ordered {
+		reversed[len(ordered)-1-i] = v
+	}
+	sort.Slice(reversed, func(i, j int) bool { return compareVersions(reversed[i], reversed[j]) < 0 })
+	if strings.Join(reversed, ",") != strings.Join(ordered, ",") {
+		t.Errorf("sorted = %v", reversed)
+	}
+}
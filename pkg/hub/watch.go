@@ -0,0 +1,177 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// WatchLTS watches every LTS stream
+const WatchLTS = "lts"
+
+// watchEntryPattern matches a major version or a major.minor stream
+var watchEntryPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// WatchState is what `editor watch` saw on its last run
+type WatchState struct {
+	Checked time.Time         `json:"checked"`
+	Watched []string          `json:"watched"` // Entries that were checked
+	Latest  map[string]string `json:"latest"`  // major.minor stream -> newest version seen
+}
+
+// NormalizeWatchEntries validates watched entries: a major version ("6000"),
+// a major.minor stream ("2022.3") or "lts" for every LTS stream
+func NormalizeWatchEntries(entries []string) ([]string, error) {
+	var result []string
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry != WatchLTS && !watchEntryPattern.MatchString(entry) {
+			return nil, errs.New(errs.Usage, "invalid stream to watch %q (use e.g. 2022.3, 6000 or lts)", entry)
+		}
+		if !slices.Contains(result, entry) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// watchMatches reports whether release r belongs to the watched entry
+func watchMatches(entry string, r UnityRelease) bool {
+	if entry == WatchLTS {
+		return r.LTS
+	}
+	if strings.Contains(entry, ".") {
+		return GetMajorMinorFromVersion(r.Version) == entry
+	}
+	return strings.SplitN(r.Version, ".", 2)[0] == entry
+}
+
+// Update compares releases against the state and records the newest version of
+// every watched stream. It returns the releases published since the last run,
+// oldest first. Entries watched for the first time only set the baseline; a
+// stream that appears under an entry watched before is reported in full.
+func (s *WatchState) Update(releases []UnityRelease, entries []string) []UnityRelease {
+	if s.Latest == nil {
+		s.Latest = make(map[string]string)
+	}
+	previous := maps.Clone(s.Latest)
+
+	var found []UnityRelease
+	matched := make(map[string]bool)
+	for _, r := range releases {
+		stream := GetMajorMinorFromVersion(r.Version)
+		var watched, baseline bool
+		for _, entry := range entries {
+			if watchMatches(entry, r) {
+				watched = true
+				matched[entry] = true
+				baseline = baseline || !slices.Contains(s.Watched, entry)
+			}
+		}
+		if !watched {
+			continue
+		}
+
+		if last, known := previous[stream]; known && compareVersions(r.Version, last) > 0 || !known && !baseline {
+			found = append(found, r)
+		}
+		if latest, ok := s.Latest[stream]; !ok || compareVersions(r.Version, latest) > 0 {
+			s.Latest[stream] = r.Version
+		}
+	}
+
+	// An entry without releases (e.g. offline) still needs its baseline
+	for _, entry := range entries {
+		if matched[entry] && !slices.Contains(s.Watched, entry) {
+			s.Watched = append(s.Watched, entry)
+		}
+	}
+	sort.Strings(s.Watched)
+	s.Checked = time.Now()
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return compareVersions(found[i].Version, found[j].Version) < 0
+	})
+	return found
+}
+
+// WatchedLatest returns the newest release of every stream matching entries,
+// keyed by major.minor
+func WatchedLatest(releases []UnityRelease, entries []string) map[string]string {
+	latest := make(map[string]string)
+	for _, r := range releases {
+		for _, entry := range entries {
+			if !watchMatches(entry, r) {
+				continue
+			}
+			stream := GetMajorMinorFromVersion(r.Version)
+			if last, ok := latest[stream]; !ok || compareVersions(r.Version, last) > 0 {
+				latest[stream] = r.Version
+			}
+			break
+		}
+	}
+	return latest
+}
+
+// getWatchFilePath returns the path to the state file of `editor watch`
+func (c *Client) getWatchFilePath() string {
+	if c.watchFileOverride != "" {
+		return c.watchFileOverride
+	}
+	return filepath.Join(c.StateDir(), "watch-state.json")
+}
+
+// LoadWatchState returns the state of the last `editor watch` run
+func (c *Client) LoadWatchState() (*WatchState, error) {
+	data, err := os.ReadFile(c.getWatchFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &WatchState{}, nil
+		}
+		return nil, fmt.Errorf("failed to read watch state: %w", err)
+	}
+
+	var state WatchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		ui.Debug("Discarding corrupted watch state", "error", err)
+		return &WatchState{}, nil
+	}
+	return &state, nil
+}
+
+// SaveWatchState stores the state for the next `editor watch` run
+func (c *Client) SaveWatchState(state *WatchState) error {
+	stateFile := c.getWatchFilePath()
+	if err := os.MkdirAll(filepath.Dir(stateFile), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	lock, err := fsutil.Lock(stateFile, cacheLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock watch state: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode watch state: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(stateFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write watch state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package hub
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestNormalizeWatchEntries(t *testing.T) {
+	got, err := NormalizeWatchEntries([]string{" 2022.3", "LTS", "6000", "2022.3", ""})
+	if err != nil {
+		t.Fatalf("NormalizeWatchEntries() error = %v", err)
+	}
+	if want := []string{"2022.3", "lts", "6000"}; !slices.Equal(got, want) {
+		t.Errorf("NormalizeWatchEntries() = %v, want %v", got, want)
+	}
+
+	for _, invalid := range []string{"2022.3.1f1", "latest", "6000."} {
+		if _, err := NormalizeWatchEntries([]string{invalid}); !errors.Is(err, errs.Usage) {
+			t.Errorf("NormalizeWatchEntries(%q) error = %v, want Usage", invalid, err)
+		}
+	}
+}
+
+func versionsOf(releases []UnityRelease) []string {
+	var versions []string
+	for _, r := range releases {
+		versions = append(versions, r.Version)
+	}
+	return versions
+}
+
+func TestWatchStateUpdate(t *testing.T) {
+	releases := []UnityRelease{
+		{Version: "2022.3.60f1", LTS: true},
+		{Version: "2022.3.61f1", LTS: true},
+		{Version: "6000.0.40f1", LTS: true},
+		{Version: "6000.1.5f1"},
+		{Version: "2021.3.45f1", LTS: true},
+	}
+	entries := []string{"2022.3", "6000"}
+
+	// The first run only records the baseline
+	state := &WatchState{}
+	if found := state.Update(releases, entries); len(found) != 0 {
+		t.Errorf("first run found %v, want nothing", versionsOf(found))
+	}
+	if state.Latest["2022.3"] != "2022.3.61f1" || state.Latest["6000.1"] != "6000.1.5f1" {
+		t.Errorf("Latest = %v", state.Latest)
+	}
+	if _, ok := state.Latest["2021.3"]; ok {
+		t.Error("unwatched stream 2021.3 recorded")
+	}
+
+	// New patches, out of order, and a new Unity 6 stream
+	releases = append(releases,
+		UnityRelease{Version: "2022.3.63f1", LTS: true},
+		UnityRelease{Version: "2022.3.62f1", LTS: true},
+		UnityRelease{Version: "6000.2.0f1"},
+		UnityRelease{Version: "2021.3.46f1", LTS: true},
+	)
+	found := state.Update(releases, entries)
+	if want := []string{"2022.3.62f1", "2022.3.63f1", "6000.2.0f1"}; !slices.Equal(versionsOf(found), want) {
+		t.Errorf("found %v, want %v", versionsOf(found), want)
+	}
+
+	// Nothing changed since
+	if found := state.Update(releases, entries); len(found) != 0 {
+		t.Errorf("repeated run found %v, want nothing", versionsOf(found))
+	}
+
+	// Adding lts sets a baseline for 2021.3 instead of reporting all of it
+	entries = append(entries, WatchLTS)
+	if found := state.Update(releases, entries); len(found) != 0 {
+		t.Errorf("newly watched lts found %v, want nothing", versionsOf(found))
+	}
+	if state.Latest["2021.3"] != "2021.3.46f1" {
+		t.Errorf("Latest[2021.3] = %q", state.Latest["2021.3"])
+	}
+}
+
+func TestWatchStateWithoutReleases(t *testing.T) {
+	// An offline first run must not turn the next run into a flood
+	state := &WatchState{}
+	state.Update(nil, []string{"2022.3"})
+	if len(state.Watched) != 0 {
+		t.Errorf("Watched = %v, want none without releases", state.Watched)
+	}
+	found := state.Update([]UnityRelease{{Version: "2022.3.61f1"}}, []string{"2022.3"})
+	if len(found) != 0 {
+		t.Errorf("found %v, want the baseline only", versionsOf(found))
+	}
+}
+
+func TestWatchStateRoundTrip(t *testing.T) {
+	client := createTestClient(t, "")
+
+	state, err := client.LoadWatchState()
+	if err != nil {
+		t.Fatalf("LoadWatchState() error = %v", err)
+	}
+	state.Update([]UnityRelease{{Version: "2022.3.61f1"}}, []string{"2022.3"})
+	if err := client.SaveWatchState(state); err != nil {
+		t.Fatalf("SaveWatchState() error = %v", err)
+	}
+
+	loaded, err := client.LoadWatchState()
+	if err != nil {
+		t.Fatalf("LoadWatchState() error = %v", err)
+	}
+	if loaded.Latest["2022.3"] != "2022.3.61f1" || !slices.Equal(loaded.Watched, []string{"2022.3"}) {
+		t.Errorf("loaded state = %+v", loaded)
+	}
+}
+
+func TestWatchedLatest(t *testing.T) {
+	releases := []UnityRelease{
+		{Version: "2022.3.60f1", LTS: true},
+		{Version: "2022.3.61f1", LTS: true},
+		{Version: "6000.1.5f1"},
+	}
+	got := WatchedLatest(releases, []string{WatchLTS})
+	if len(got) != 1 || got["2022.3"] != "2022.3.61f1" {
+		t.Errorf("WatchedLatest() = %v", got)
+	}
+}
@@ -0,0 +1,32 @@
+// Package keychain stores secrets in the OS credential store: macOS Keychain,
+// Windows Credential Manager, or the Secret Service on Linux (via secret-tool)
+package keychain
+
+import (
+	"errors"
+)
+
+// ErrNotFound is returned when no secret is stored for the service and account
+var ErrNotFound = errors.New("credential not found")
+
+// ErrUnsupported is returned when no credential store is available on this system
+var ErrUnsupported = errors.New("no supported credential store on this system")
+
+// Set stores secret for service and account, replacing any existing value
+func Set(service, account, secret string) error {
+	return set(service, account, secret)
+}
+
+// Get returns the secret stored for service and account, or ErrNotFound
+func Get(service, account string) (string, error) {
+	return get(service, account)
+}
+
+// Delete removes the secret stored for service and account. Deleting a missing secret is not an error.
+func Delete(service, account string) error {
+	err := del(service, account)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+//go:build darwin
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit code of the security tool for a missing item
+const securityNotFound = 44
+
+func set(service, account, secret string) error {
+	if strings.ContainsAny(secret, "\r\n") {
+		return errors.New("failed to store credential in Keychain: the secret contains a line break")
+	}
+	// The secret must not appear in argv, where other processes can read it,
+	// so the command goes to security's interactive mode on stdin. -U updates
+	// an existing item instead of failing.
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		securityQuote(service), securityQuote(account), securityQuote(secret)))
+	out, err := cmd.CombinedOutput()
+	if err == nil && strings.TrimSpace(string(out)) != "" {
+		// Interactive mode exits 0 even when a command fails; on success
+		// add-generic-password prints nothing
+		err = errors.New("add-generic-password failed")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store credential in Keychain: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// securityQuote quotes s as an argument of a command in security's
+// interactive mode
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func get(service, account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		if isNotFound(err) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to read credential from Keychain: %w", err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func del(service, account string) error {
+	if err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).Run(); err != nil {
+		if isNotFound(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete credential from Keychain: %w", err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secret-tool talks to the Secret Service (GNOME Keyring, KWallet)
+
+func set(service, account, secret string) error {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return fmt.Errorf("%w: install secret-tool (libsecret-tools)", ErrUnsupported)
+	}
+
+	cmd := exec.Command("secret-tool", "store", "--label", service+" ("+account+")", "service", service, "account", account)
+	// The secret is read from stdin so it never appears in the process list
+	cmd.Stdin = strings.NewReader(secret)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to store credential in Secret Service: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func get(service, account string) (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", ErrUnsupported
+	}
+
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		// secret-tool exits with 1 and no output when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to read credential from Secret Service: %w", err)
+	}
+	if len(out) == 0 {
+		return "", ErrNotFound
+	}
+	return string(out), nil
+}
+
+func del(service, account string) error {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return ErrUnsupported
+	}
+
+	if err := exec.Command("secret-tool", "clear", "service", service, "account", account).Run(); err != nil {
+		return fmt.Errorf("failed to delete credential from Secret Service: %w", err)
+	}
+	return nil
+}
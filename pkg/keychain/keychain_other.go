@@ -0,0 +1,15 @@
+//go:build !darwin && !linux && !windows
+
+package keychain
+
+func set(service, account, secret string) error {
+	return ErrUnsupported
+}
+
+func get(service, account string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func del(service, account string) error {
+	return ErrUnsupported
+}
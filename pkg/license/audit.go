@@ -0,0 +1,148 @@
+package license
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// expiryWarningPeriod is how long before expiration a serial license is reported
+const expiryWarningPeriod = 30 * 24 * time.Hour
+
+// Unity Hub login and licensing servers are handled by the Unity Licensing Client,
+// which ships with Unity 2019.4 and later
+const (
+	licensingClientMajor = 2019
+	licensingClientMinor = 4
+)
+
+// ulfDateLayout is the date format used in Unity_lic.ulf
+const ulfDateLayout = "2006-01-02T15:04:05"
+
+// ulfValuePattern matches elements like <StopDate Value="2025-01-01T00:00:00" />
+var ulfValuePattern = regexp.MustCompile(`<(\w+)\s+Value="([^"]*)"`)
+
+// SerialLicense holds the details of a Unity_lic.ulf file
+type SerialLicense struct {
+	Path           string
+	SerialMasked   string    // e.g. "SC-XXXX-XXXX-XXXX-XXXX-XXXX"
+	LicenseVersion string    // e.g. "6.x"
+	StartDate      time.Time // Zero if unknown
+	StopDate       time.Time // Zero if the license does not expire
+}
+
+// ParseSerialLicense reads the parts of Unity_lic.ulf that are useful for auditing
+func ParseSerialLicense(path string) (*SerialLicense, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read license file: %w", err)
+	}
+	return parseSerialLicense(path, string(data)), nil
+}
+
+func parseSerialLicense(path, content string) *SerialLicense {
+	serial := &SerialLicense{Path: path}
+
+	// Only the first occurrence of each element is relevant
+	values := make(map[string]string)
+	for _, m := range ulfValuePattern.FindAllStringSubmatch(content, -1) {
+		if _, ok := values[m[1]]; !ok {
+			values[m[1]] = m[2]
+		}
+	}
+
+	serial.SerialMasked = values["SerialMasked"]
+	serial.LicenseVersion = values["LicenseVersion"]
+	if t, err := time.Parse(ulfDateLayout, values["StartDate"]); err == nil {
+		serial.StartDate = t
+	}
+	if t, err := time.Parse(ulfDateLayout, values["StopDate"]); err == nil {
+		serial.StopDate = t
+	}
+	return serial
+}
+
+// Warnings returns problems with the detected licenses, such as an expired serial
+func (s *Status) Warnings(now time.Time) []string {
+	var warnings []string
+
+	if s.Serial != nil && !s.Serial.StopDate.IsZero() {
+		stop := s.Serial.StopDate
+		switch {
+		case now.After(stop):
+			warnings = append(warnings, fmt.Sprintf("serial license expired on %s", stop.Format("2006-01-02")))
+		case stop.Sub(now) < expiryWarningPeriod:
+			warnings = append(warnings, fmt.Sprintf("serial license expires on %s", stop.Format("2006-01-02")))
+		}
+	}
+
+	if !s.HasLicense {
+		warnings = append(warnings, "no license found")
+	}
+	return warnings
+}
+
+// EditorLicense describes which license an installed editor would use
+type EditorLicense struct {
+	Version  string
+	Mode     LicenseType
+	Warnings []string
+}
+
+// ForEditor returns the license mode the given editor version would pick up.
+// Editors with the Unity Licensing Client prefer a configured licensing server,
+// then a serial license, then the Unity Hub login. Older editors only read the serial file.
+func (s *Status) ForEditor(version string) EditorLicense {
+	result := EditorLicense{Version: version, Mode: LicenseTypeNone}
+	hasClient := supportsLicensingClient(version)
+
+	switch {
+	case s.ServerURL != "" && hasClient:
+		result.Mode = LicenseTypeServer
+		if s.IsBuildServer {
+			result.Mode = LicenseTypeBuildServer
+		}
+	case s.Serial != nil:
+		result.Mode = LicenseTypeSerial
+	case s.HubLoggedIn && hasClient:
+		result.Mode = LicenseTypeHub
+	}
+
+	if !hasClient {
+		if s.ServerURL != "" {
+			result.Warnings = append(result.Warnings, "licensing server requires Unity 2019.4 or later")
+		}
+		if s.HubLoggedIn && s.Serial == nil {
+			result.Warnings = append(result.Warnings, "Unity Hub login requires Unity 2019.4 or later, activate a serial license instead")
+		}
+	}
+	if result.Mode == LicenseTypeSerial && !s.Serial.StopDate.IsZero() && s.Serial.StopDate.Before(time.Now()) {
+		result.Warnings = append(result.Warnings, "serial license has expired")
+	}
+	if result.Mode == LicenseTypeNone {
+		result.Warnings = append(result.Warnings, "no usable license")
+	}
+
+	return result
+}
+
+// supportsLicensingClient returns true for Unity 2019.4 and later.
+// Unparseable versions are assumed to be recent.
+func supportsLicensingClient(version string) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return true
+	}
+	major, err1 := strconv.Atoi(parts[0])
+	minor, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	if major != licensingClientMajor {
+		return major > licensingClientMajor
+	}
+	return minor >= licensingClientMinor
+}
@@ -0,0 +1,83 @@
+package license
+
+import (
+	"testing"
+	"time"
+)
+
+const testULF = `<?xml version="1.0" encoding="UTF-8"?><root>
+<License id="Terms">
+<LicenseVersion Value="6.x" />
+<SerialMasked Value="SC-ABCD-XXXX-XXXX-XXXX-XXXX" />
+<StartDate Value="2024-01-15T00:00:00" />
+<StopDate Value="2025-01-15T00:00:00" />
+<UpdateDate Value="2024-06-01T10:00:00" />
+</License></root>`
+
+func TestParseSerialLicense(t *testing.T) {
+	serial := parseSerialLicense("Unity_lic.ulf", testULF)
+
+	if serial.SerialMasked != "SC-ABCD-XXXX-XXXX-XXXX-XXXX" {
+		t.Errorf("SerialMasked = %q", serial.SerialMasked)
+	}
+	if serial.LicenseVersion != "6.x" {
+		t.Errorf("LicenseVersion = %q", serial.LicenseVersion)
+	}
+	if want := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC); !serial.StopDate.Equal(want) {
+		t.Errorf("StopDate = %v, want %v", serial.StopDate, want)
+	}
+
+	perpetual := parseSerialLicense("Unity_lic.ulf", `<StopDate Value="" />`)
+	if !perpetual.StopDate.IsZero() {
+		t.Errorf("StopDate = %v, want zero for a license without expiration", perpetual.StopDate)
+	}
+}
+
+func TestStatusWarnings(t *testing.T) {
+	stop := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	status := &Status{HasLicense: true, LicenseType: LicenseTypeSerial, Serial: &SerialLicense{StopDate: stop}}
+
+	if w := status.Warnings(stop.AddDate(0, -3, 0)); len(w) != 0 {
+		t.Errorf("Warnings() far from expiration = %v, want none", w)
+	}
+	if w := status.Warnings(stop.AddDate(0, 0, -7)); len(w) != 1 || w[0] != "serial license expires on 2025-01-15" {
+		t.Errorf("Warnings() near expiration = %v", w)
+	}
+	if w := status.Warnings(stop.AddDate(0, 0, 1)); len(w) != 1 || w[0] != "serial license expired on 2025-01-15" {
+		t.Errorf("Warnings() after expiration = %v", w)
+	}
+
+	none := &Status{LicenseType: LicenseTypeNone}
+	if w := none.Warnings(stop); len(w) != 1 {
+		t.Errorf("Warnings() without license = %v, want one warning", w)
+	}
+}
+
+func TestStatusForEditor(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   Status
+		version  string
+		wantMode LicenseType
+		warnings int
+	}{
+		{"server preferred", Status{ServerURL: "http://ls", Serial: &SerialLicense{}}, "2022.3.60f1", LicenseTypeServer, 0},
+		{"build server", Status{ServerURL: "http://ls", IsBuildServer: true}, "6000.0.30f1", LicenseTypeBuildServer, 0},
+		{"old editor ignores server", Status{ServerURL: "http://ls", Serial: &SerialLicense{}}, "2018.4.36f1", LicenseTypeSerial, 1},
+		{"hub login", Status{HubLoggedIn: true}, "2021.3.45f1", LicenseTypeHub, 0},
+		{"old editor without serial", Status{HubLoggedIn: true}, "2019.2.0f1", LicenseTypeNone, 2},
+		{"nothing", Status{}, "2022.3.60f1", LicenseTypeNone, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.status.ForEditor(tt.version)
+			if got.Mode != tt.wantMode {
+				t.Errorf("Mode = %s, want %s", got.Mode, tt.wantMode)
+			}
+			if len(got.Warnings) != tt.warnings {
+				t.Errorf("Warnings = %v, want %d", got.Warnings, tt.warnings)
+			}
+		})
+	}
+}
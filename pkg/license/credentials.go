@@ -0,0 +1,67 @@
+package license
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/neptaco/uniforge/pkg/keychain"
+)
+
+// Keychain entry holding the Unity license credentials
+const (
+	credentialService = "uniforge"
+	credentialAccount = "unity-license"
+)
+
+// Credentials are the Unity ID login and optional serial used for activation
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Serial   string `json:"serial,omitempty"`
+}
+
+// SaveCredentials stores credentials in the OS credential store
+func SaveCredentials(creds Credentials) error {
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	return keychain.Set(credentialService, credentialAccount, string(data))
+}
+
+// LoadCredentials reads credentials from the OS credential store.
+// Returns keychain.ErrNotFound if none were saved.
+func LoadCredentials() (*Credentials, error) {
+	data, err := keychain.Get(credentialService, credentialAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal([]byte(data), &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse stored credentials: %w", err)
+	}
+	return &creds, nil
+}
+
+// DeleteCredentials removes saved credentials from the OS credential store
+func DeleteCredentials() error {
+	return keychain.Delete(credentialService, credentialAccount)
+}
+
+// Merge fills empty fields of c from stored credentials. The stored password is
+// only used when the username is unset or matches the stored one.
+func (c *Credentials) Merge(stored *Credentials) {
+	if stored == nil {
+		return
+	}
+	if c.Username == "" {
+		c.Username = stored.Username
+	}
+	if c.Password == "" && c.Username == stored.Username {
+		c.Password = stored.Password
+	}
+	if c.Serial == "" && c.Username == stored.Username {
+		c.Serial = stored.Serial
+	}
+}
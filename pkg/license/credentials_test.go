@@ -0,0 +1,25 @@
+package license
+
+import "testing"
+
+func TestCredentialsMerge(t *testing.T) {
+	stored := &Credentials{Username: "dev@example.com", Password: "secret", Serial: "SC-1234"}
+
+	empty := Credentials{}
+	empty.Merge(stored)
+	if empty != *stored {
+		t.Errorf("Merge() into empty = %+v, want %+v", empty, *stored)
+	}
+
+	sameUser := Credentials{Username: "dev@example.com", Serial: "SC-9999"}
+	sameUser.Merge(stored)
+	if sameUser.Password != "secret" || sameUser.Serial != "SC-9999" {
+		t.Errorf("Merge() for same user = %+v, want stored password and explicit serial", sameUser)
+	}
+
+	otherUser := Credentials{Username: "ci@example.com"}
+	otherUser.Merge(stored)
+	if otherUser.Password != "" || otherUser.Serial != "" {
+		t.Errorf("Merge() for another user = %+v, stored secrets must not be used", otherUser)
+	}
+}
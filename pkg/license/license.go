@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
 )
 
 // Manager handles Unity license operations
@@ -40,10 +42,10 @@ type ActivateOptions struct {
 // For Plus/Pro license, serial is required.
 func (m *Manager) Activate(opts ActivateOptions) error {
 	if opts.Username == "" {
-		return fmt.Errorf("username is required")
+		return errs.New(errs.LicenseMissing, "username is required")
 	}
 	if opts.Password == "" {
-		return fmt.Errorf("password is required")
+		return errs.New(errs.LicenseMissing, "password is required")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
@@ -118,6 +120,11 @@ type Status struct {
 	LicensePath   string // For serial license
 	HubConfigPath string // For Unity Hub
 	ServerURL     string // For Licensing Server
+
+	// All detected sources, regardless of which one LicenseType picked
+	Serial        *SerialLicense // Parsed Unity_lic.ulf, nil if missing
+	HubLoggedIn   bool
+	IsBuildServer bool
 }
 
 // GetStatus checks the current license status across all license types
@@ -127,36 +134,37 @@ func GetStatus() (*Status, error) {
 		LicenseType: LicenseTypeNone,
 	}
 
-	// Check 1: Traditional serial license (Unity_lic.ulf)
-	licensePath := getSerialLicenseFilePath()
-	status.LicensePath = licensePath
-	if fileExists(licensePath) {
-		status.HasLicense = true
-		status.LicenseType = LicenseTypeSerial
-		return status, nil
+	// Source 1: Traditional serial license (Unity_lic.ulf)
+	status.LicensePath = getSerialLicenseFilePath()
+	if fileExists(status.LicensePath) {
+		serial, err := ParseSerialLicense(status.LicensePath)
+		if err != nil {
+			// An unreadable file still counts as present; details are just unknown
+			serial = &SerialLicense{Path: status.LicensePath}
+		}
+		status.Serial = serial
 	}
 
-	// Check 2: Unity Hub login
-	hubConfigPath := getUnityHubConfigPath()
-	status.HubConfigPath = hubConfigPath
-	if fileExists(hubConfigPath) {
-		status.HasLicense = true
-		status.LicenseType = LicenseTypeHub
-		return status, nil
-	}
+	// Source 2: Unity Hub login
+	status.HubConfigPath = getUnityHubConfigPath()
+	status.HubLoggedIn = fileExists(status.HubConfigPath)
 
-	// Check 3: Licensing Server / Build Server
+	// Source 3: Licensing Server / Build Server
 	serverConfig := getLicensingServerConfig()
 	status.ServerURL = serverConfig.URL
-	if serverConfig.URL != "" {
-		status.HasLicense = true
-		if serverConfig.IsBuildServer {
-			status.LicenseType = LicenseTypeBuildServer
-		} else {
-			status.LicenseType = LicenseTypeServer
-		}
-		return status, nil
+	status.IsBuildServer = serverConfig.IsBuildServer
+
+	switch {
+	case status.Serial != nil:
+		status.LicenseType = LicenseTypeSerial
+	case status.HubLoggedIn:
+		status.LicenseType = LicenseTypeHub
+	case status.ServerURL != "" && status.IsBuildServer:
+		status.LicenseType = LicenseTypeBuildServer
+	case status.ServerURL != "":
+		status.LicenseType = LicenseTypeServer
 	}
+	status.HasLicense = status.LicenseType != LicenseTypeNone
 
 	return status, nil
 }
@@ -239,26 +247,11 @@ func getLicensingServerConfig() serverConfigResult {
 // getServicesConfigPaths returns possible paths for services-config.json
 func getServicesConfigPaths() []string {
 	var paths []string
-
-	switch runtime.GOOS {
-	case "darwin":
-		home, _ := os.UserHomeDir()
-		paths = append(paths,
-			filepath.Join(home, "Library", "Application Support", "Unity", "config", "services-config.json"),
-			"/Library/Application Support/Unity/config/services-config.json",
-		)
-	case "windows":
-		paths = append(paths,
-			filepath.Join("C:", "ProgramData", "Unity", "config", "services-config.json"),
-		)
-	case "linux":
-		home, _ := os.UserHomeDir()
-		paths = append(paths,
-			filepath.Join(home, ".config", "unity3d", "Unity", "services-config.json"),
-			"/usr/share/unity3d/config/services-config.json",
-		)
+	for _, scope := range []ConfigScope{ScopeUser, ScopeMachine} {
+		if path, err := ServicesConfigPath(scope); err == nil {
+			paths = append(paths, path)
+		}
 	}
-
 	return paths
 }
 
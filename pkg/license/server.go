@@ -0,0 +1,138 @@
+package license
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// ConfigScope selects which services-config.json location is used
+type ConfigScope string
+
+const (
+	ScopeUser    ConfigScope = "user"    // Per-user config directory
+	ScopeMachine ConfigScope = "machine" // Shared by all users (usually needs admin rights)
+)
+
+// DefaultConfigScope returns the scope used when none is specified.
+// Windows only supports the machine-wide location.
+func DefaultConfigScope() ConfigScope {
+	if runtime.GOOS == "windows" {
+		return ScopeMachine
+	}
+	return ScopeUser
+}
+
+// ServicesConfigPath returns the services-config.json path for the given scope
+func ServicesConfigPath(scope ConfigScope) (string, error) {
+	home, _ := os.UserHomeDir()
+
+	switch runtime.GOOS {
+	case "darwin":
+		switch scope {
+		case ScopeUser:
+			return filepath.Join(home, "Library", "Application Support", "Unity", "config", "services-config.json"), nil
+		case ScopeMachine:
+			return "/Library/Application Support/Unity/config/services-config.json", nil
+		}
+	case "windows":
+		if scope == ScopeMachine {
+			return filepath.Join("C:", "ProgramData", "Unity", "config", "services-config.json"), nil
+		}
+		if scope == ScopeUser {
+			return "", fmt.Errorf("user scope is not supported on Windows, use --scope machine")
+		}
+	case "linux":
+		switch scope {
+		case ScopeUser:
+			return filepath.Join(home, ".config", "unity3d", "Unity", "services-config.json"), nil
+		case ScopeMachine:
+			return "/usr/share/unity3d/config/services-config.json", nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+	return "", fmt.Errorf("invalid scope %q (expected user or machine)", scope)
+}
+
+// ServerConfig holds the licensing server settings written to services-config.json
+type ServerConfig struct {
+	URL         string
+	FloatingAPI bool   // enableFloatingApi: true for Build Server (floating) licenses
+	Toolset     string // Optional toolset ID configured on the server
+}
+
+// WriteServerConfig writes the licensing server settings to services-config.json.
+// Other keys already present in the file are preserved.
+func WriteServerConfig(path string, cfg ServerConfig) error {
+	if err := ValidateServerURL(cfg.URL); err != nil {
+		return err
+	}
+
+	config := make(map[string]any)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	config["licensingServiceBaseUrl"] = strings.TrimSuffix(cfg.URL, "/")
+	config["enableEntitlementLicensing"] = true
+	config["enableFloatingApi"] = cfg.FloatingAPI
+	if cfg.Toolset != "" {
+		config["toolset"] = cfg.Toolset
+	} else {
+		delete(config, "toolset")
+	}
+
+	out, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode services config: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(path, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ValidateServerURL checks that serverURL is an absolute http(s) URL
+func ValidateServerURL(serverURL string) error {
+	u, err := url.Parse(serverURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid licensing server URL %q (expected e.g. http://license-server:8080)", serverURL)
+	}
+	return nil
+}
+
+// CheckServerStatus queries the licensing server's /v1/admin/status endpoint
+func CheckServerStatus(serverURL string, timeout time.Duration) error {
+	endpoint := strings.TrimSuffix(serverURL, "/") + "/v1/admin/status"
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return errs.New(errs.NetworkUnavailable, "licensing server is not reachable: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("licensing server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
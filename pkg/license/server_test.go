@@ -0,0 +1,76 @@
+package license
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteServerConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "services-config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"clientConnectTimeoutSec": 5, "toolset": "old"}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err := WriteServerConfig(path, ServerConfig{URL: "http://license.example.com:8080/", FloatingAPI: true})
+	if err != nil {
+		t.Fatalf("WriteServerConfig() error = %v", err)
+	}
+
+	cfg := readServerConfigFromFile(path)
+	if cfg.URL != "http://license.example.com:8080" {
+		t.Errorf("URL = %q, want trailing slash removed", cfg.URL)
+	}
+	if !cfg.IsBuildServer {
+		t.Error("IsBuildServer should be true with FloatingAPI")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if !strings.Contains(string(data), `"clientConnectTimeoutSec": 5`) {
+		t.Errorf("existing keys should be preserved, got:\n%s", data)
+	}
+	if strings.Contains(string(data), `"toolset"`) {
+		t.Errorf("toolset should be removed when not specified, got:\n%s", data)
+	}
+}
+
+func TestValidateServerURL(t *testing.T) {
+	for _, valid := range []string{"http://ls:8080", "https://license.example.com"} {
+		if err := ValidateServerURL(valid); err != nil {
+			t.Errorf("ValidateServerURL(%q) error = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "license-server:8080", "ftp://ls", "http://"} {
+		if err := ValidateServerURL(invalid); err == nil {
+			t.Errorf("ValidateServerURL(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestCheckServerStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/admin/status" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"serverStatus":"Healthy"}`))
+	}))
+	defer server.Close()
+
+	if err := CheckServerStatus(server.URL+"/", 5*time.Second); err != nil {
+		t.Errorf("CheckServerStatus() error = %v", err)
+	}
+	if err := CheckServerStatus(server.URL+"/other", 5*time.Second); err == nil {
+		t.Error("CheckServerStatus() should fail for a non-OK response")
+	}
+}
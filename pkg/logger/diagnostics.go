@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Diagnostic is a C# compiler error or warning, e.g.
+// "Assets/Scripts/Player.cs(12,5): error CS0103: The name 'speed' does not exist"
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Count    int    `json:"count"` // How often the log repeated it
+}
+
+// DiagnosticsReport is the JSON artifact of the diagnostics of a run
+type DiagnosticsReport struct {
+	Errors      int          `json:"errors"`
+	Warnings    int          `json:"warnings"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// diagnosticPattern matches a compiler diagnostic with its location
+var diagnosticPattern = regexp.MustCompile(`^\s*(.+?\.cs)\((\d+),(\d+)\):\s*(error|warning)\s+(CS\d+):\s*(.*?)\s*$`)
+
+// ParseDiagnostic returns the compiler diagnostic line reports, if any
+func ParseDiagnostic(line string) (Diagnostic, bool) {
+	m := diagnosticPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Diagnostic{}, false
+	}
+	lineNumber, _ := strconv.Atoi(m[2])
+	column, _ := strconv.Atoi(m[3])
+	return Diagnostic{
+		File:     m[1],
+		Line:     lineNumber,
+		Column:   column,
+		Severity: m[4],
+		Code:     m[5],
+		Message:  m[6],
+		Count:    1,
+	}, true
+}
+
+// diagnosticKey identifies repeats of a diagnostic
+type diagnosticKey struct {
+	file         string
+	line, column int
+	code         string
+	message      string
+}
+
+// diagnostics collects the compiler diagnostics of a log, once each. Unity
+// prints them again with every script compilation and domain reload.
+type diagnostics struct {
+	list  []Diagnostic
+	index map[diagnosticKey]int
+}
+
+func (d *diagnostics) add(diag Diagnostic) {
+	key := diagnosticKey{diag.File, diag.Line, diag.Column, diag.Code, diag.Message}
+	if i, ok := d.index[key]; ok {
+		d.list[i].Count++
+		return
+	}
+	if d.index == nil {
+		d.index = make(map[diagnosticKey]int)
+	}
+	d.index[key] = len(d.list)
+	d.list = append(d.list, diag)
+}
+
+// sorted returns the diagnostics by file and position
+func (d *diagnostics) sorted() []Diagnostic {
+	list := append([]Diagnostic(nil), d.list...)
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].File != list[j].File {
+			return list[i].File < list[j].File
+		}
+		if list[i].Line != list[j].Line {
+			return list[i].Line < list[j].Line
+		}
+		return list[i].Column < list[j].Column
+	})
+	return list
+}
+
+// NewDiagnosticsReport counts the errors and warnings of list
+func NewDiagnosticsReport(list []Diagnostic) DiagnosticsReport {
+	report := DiagnosticsReport{Diagnostics: list}
+	if report.Diagnostics == nil {
+		report.Diagnostics = []Diagnostic{}
+	}
+	for _, d := range list {
+		if d.Severity == "error" {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	return report
+}
+
+// WriteDiagnosticsFile writes the report of list to path as JSON
+func WriteDiagnosticsFile(path string, list []Diagnostic) error {
+	data, err := json.MarshalIndent(NewDiagnosticsReport(list), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write diagnostics: %w", err)
+	}
+	return nil
+}
+
+// PrintDiagnostics writes list, sorted by file and position, grouped per file
+func PrintDiagnostics(w io.Writer, list []Diagnostic, noColor bool) {
+	if len(list) == 0 {
+		return
+	}
+	report := NewDiagnosticsReport(list)
+	color := func(code, s string) string {
+		if noColor {
+			return s
+		}
+		return code + s + ColorReset
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%s\n", color(ColorBold, fmt.Sprintf("=== Compiler messages: %d errors, %d warnings ===", report.Errors, report.Warnings)))
+	file := ""
+	for _, d := range list {
+		if d.File != file {
+			file = d.File
+			_, _ = fmt.Fprintf(w, "%s\n", color(ColorBold, file))
+		}
+		severity := color(ColorYellow, "warning "+d.Code)
+		if d.Severity == "error" {
+			severity = color(ColorRed, "error "+d.Code)
+		}
+		repeats := ""
+		if d.Count > 1 {
+			repeats = color(ColorGray, fmt.Sprintf(" (x%d)", d.Count))
+		}
+		_, _ = fmt.Fprintf(w, "  %s %s: %s%s\n", color(ColorGray, fmt.Sprintf("%5d:%-3d", d.Line, d.Column)), severity, d.Message, repeats)
+	}
+}
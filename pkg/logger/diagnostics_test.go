@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDiagnostic(t *testing.T) {
+	d, ok := ParseDiagnostic("Assets/My Scripts/Player.cs(12,5): error CS0103: The name 'speed' does not exist in the current context")
+	want := Diagnostic{File: "Assets/My Scripts/Player.cs", Line: 12, Column: 5, Severity: "error", Code: "CS0103", Message: "The name 'speed' does not exist in the current context", Count: 1}
+	if !ok || d != want {
+		t.Errorf("ParseDiagnostic() = %+v, %v; want %+v", d, ok, want)
+	}
+
+	for _, line := range []string{
+		"error CS0006: Metadata file 'Foo.dll' could not be found",
+		"Game.Player:Update () (at Assets/Scripts/Player.cs:30)",
+		"Compilation failed: 1 error(s), 0 warnings",
+	} {
+		if d, ok := ParseDiagnostic(line); ok {
+			t.Errorf("ParseDiagnostic(%q) = %+v, want none", line, d)
+		}
+	}
+}
+
+func TestLoggerDiagnostics(t *testing.T) {
+	jsonPath := filepath.Join(t.TempDir(), "diagnostics.json")
+	logger := NewWithOptions("-", WithFormatter(NewFormatter(WithNoColor(true))), WithDiagnosticsFile(jsonPath))
+
+	log := `Assets/Scripts/Player.cs(20,1): warning CS0168: The variable 'e' is declared but never used
+Assets/Scripts/Player.cs(12,5): error CS0103: The name 'speed' does not exist
+Assets/Editor/Build.cs(3,7): error CS0246: The type or namespace name 'Foo' could not be found
+Scripts have compiler errors.
+Assets/Scripts/Player.cs(12,5): error CS0103: The name 'speed' does not exist
+`
+	if _, err := logger.Write([]byte(log)); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := logger.Diagnostics()
+	want := []Diagnostic{
+		{File: "Assets/Editor/Build.cs", Line: 3, Column: 7, Severity: "error", Code: "CS0246", Message: "The type or namespace name 'Foo' could not be found", Count: 1},
+		{File: "Assets/Scripts/Player.cs", Line: 12, Column: 5, Severity: "error", Code: "CS0103", Message: "The name 'speed' does not exist", Count: 2},
+		{File: "Assets/Scripts/Player.cs", Line: 20, Column: 1, Severity: "warning", Code: "CS0168", Message: "The variable 'e' is declared but never used", Count: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagnostics() = %+v\nwant %+v", got, want)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report DiagnosticsReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Errors != 2 || report.Warnings != 1 || !reflect.DeepEqual(report.Diagnostics, want) {
+		t.Errorf("diagnostics.json = %+v", report)
+	}
+}
+
+func TestPrintDiagnostics(t *testing.T) {
+	var buf bytes.Buffer
+	PrintDiagnostics(&buf, []Diagnostic{
+		{File: "Assets/A.cs", Line: 1, Column: 2, Severity: "error", Code: "CS0103", Message: "first", Count: 3},
+		{File: "Assets/A.cs", Line: 9, Column: 1, Severity: "warning", Code: "CS0168", Message: "second", Count: 1},
+		{File: "Assets/B.cs", Line: 4, Column: 4, Severity: "error", Code: "CS0246", Message: "third", Count: 1},
+	}, true)
+
+	out := buf.String()
+	if !strings.Contains(out, "2 errors, 1 warnings") || strings.Count(out, "Assets/A.cs\n") != 1 || !strings.Contains(out, "error CS0103: first (x3)") {
+		t.Errorf("PrintDiagnostics() =\n%s", out)
+	}
+
+	buf.Reset()
+	PrintDiagnostics(&buf, nil, true)
+	if buf.Len() != 0 {
+		t.Errorf("PrintDiagnostics(nil) = %q, want nothing", buf.String())
+	}
+}
@@ -8,12 +8,15 @@ import (
 
 // ANSI color codes
 const (
-	ColorReset  = "\033[0m"
-	ColorRed    = "\033[31m"
-	ColorYellow = "\033[33m"
-	ColorGreen  = "\033[32m"
-	ColorGray   = "\033[90m"
-	ColorBold   = "\033[1m"
+	ColorReset   = "\033[0m"
+	ColorRed     = "\033[31m"
+	ColorYellow  = "\033[33m"
+	ColorGreen   = "\033[32m"
+	ColorGray    = "\033[90m"
+	ColorBlue    = "\033[34m"
+	ColorCyan    = "\033[36m"
+	ColorMagenta = "\033[35m"
+	ColorBold    = "\033[1m"
 )
 
 // LogLevel represents the type of log line
@@ -34,10 +37,14 @@ const DefaultMaxLineLength = 500
 // Formatter handles Unity log formatting with colors and filtering
 type Formatter struct {
 	noColor            bool
-	hideStackTrace     bool     // Hide non-project stack traces
-	hideAllStackTraces bool     // Hide all stack traces completely
-	maxLineLength      int      // Max line length before truncation (0 = no limit)
-	projectPaths       []string // Paths to keep in stack traces (e.g., "Assets/")
+	hideStackTrace     bool                   // Hide non-project stack traces
+	hideAllStackTraces bool                   // Hide all stack traces completely
+	maxLineLength      int                    // Max line length before truncation (0 = no limit)
+	projectPaths       []string               // Paths to keep in stack traces (e.g., "Assets/")
+	linker             Linker                 // Links script locations to their source, if set
+	hideNoise          bool                   // Hide noise lines instead of dimming them
+	shownNoise         map[NoiseCategory]bool // Noise categories shown although noise is hidden
+	noiseStats         NoiseStats             // Hidden noise lines per category
 }
 
 // FormatterOption configures a Formatter
@@ -83,6 +90,8 @@ func NewFormatter(opts ...FormatterOption) *Formatter {
 	f := &Formatter{
 		projectPaths:  []string{"Assets/", "Packages/"},
 		maxLineLength: DefaultMaxLineLength,
+		shownNoise:    make(map[NoiseCategory]bool),
+		noiseStats:    make(NoiseStats),
 	}
 	for _, opt := range opts {
 		opt(f)
@@ -383,6 +392,10 @@ func (f *Formatter) FormatLine(line string) string {
 		return line
 	}
 
+	if f.linker != nil {
+		line = f.linkLocations(line)
+	}
+
 	switch level {
 	case LogLevelError:
 		return fmt.Sprintf("%s%s%s%s", ColorBold, ColorRed, line, ColorReset)
@@ -404,6 +417,13 @@ func (f *Formatter) ShouldShow(line string) bool {
 		return false
 	}
 
+	if f.hideNoise {
+		if category := f.GetNoiseCategory(line); category != NoiseCategoryNone && !f.shownNoise[category] {
+			f.noiseStats[category]++
+			return false
+		}
+	}
+
 	level := f.ClassifyLine(line)
 	if level == LogLevelStackTrace {
 		if f.hideAllStackTraces {
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Linker returns the URL a script location in a log links to, or "" to leave
+// it as is. path is relative to the project, e.g. Assets/Scripts/Player.cs.
+type Linker func(path string, line int) string
+
+// WithLinker turns script locations in formatted lines into terminal
+// hyperlinks (OSC 8) to the URLs linker returns
+func WithLinker(linker Linker) FormatterOption {
+	return func(f *Formatter) {
+		f.linker = linker
+	}
+}
+
+// scriptLocationPatterns match the locations of scripts in Unity logs, with
+// the whole location, the path and the line as groups:
+//
+//	Game.Player:Update () (at Assets/Scripts/Player.cs:30)
+//	Assets/Scripts/Player.cs(12,5): error CS0103: ...
+//	(Filename: Assets/Scripts/Player.cs Line: 30)
+var scriptLocationPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\(at (((?:Assets|Packages)/[^:()]+?\.cs):(\d+))\)`),
+	regexp.MustCompile(`^\s*(((?:Assets|Packages)/[^:()]+?\.cs)\((\d+),\d+\))`),
+	regexp.MustCompile(`\(Filename: (((?:Assets|Packages)/[^:()]+?\.cs) Line: (\d+))\)`),
+}
+
+// hyperlink wraps text in an OSC 8 terminal hyperlink to url
+func hyperlink(url, text string) string {
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
+
+// linkLocations turns the script location in line into a hyperlink
+func (f *Formatter) linkLocations(line string) string {
+	for _, pattern := range scriptLocationPatterns {
+		m := pattern.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		path := line[m[4]:m[5]]
+		lineNumber, err := strconv.Atoi(line[m[6]:m[7]])
+		if err != nil {
+			return line
+		}
+		url := f.linker(path, lineNumber)
+		if url == "" {
+			return line
+		}
+		return line[:m[2]] + hyperlink(url, line[m[2]:m[3]]) + line[m[3]:]
+	}
+	return line
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatLineLinks(t *testing.T) {
+	f := NewFormatter(WithLinker(func(path string, line int) string {
+		if path == "Assets/Missing.cs" {
+			return ""
+		}
+		return fmt.Sprintf("vscode://file/p/%s:%d", path, line)
+	}))
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{
+			line: "Game.Player:Update () (at Assets/Scripts/Player.cs:30)",
+			want: ColorGray + "Game.Player:Update () (at " + hyperlink("vscode://file/p/Assets/Scripts/Player.cs:30", "Assets/Scripts/Player.cs:30") + ")" + ColorReset,
+		},
+		{
+			line: "Assets/My Scripts/Enemy.cs(12,5): warning CS0168: unused",
+			want: ColorYellow + hyperlink("vscode://file/p/Assets/My Scripts/Enemy.cs:12", "Assets/My Scripts/Enemy.cs(12,5)") + ": warning CS0168: unused" + ColorReset,
+		},
+		{
+			line: "(Filename: Assets/Scripts/Player.cs Line: 30)",
+			want: ColorGray + "(Filename: " + hyperlink("vscode://file/p/Assets/Scripts/Player.cs:30", "Assets/Scripts/Player.cs Line: 30") + ")" + ColorReset,
+		},
+		{
+			line: "Game.Player:Start () (at Assets/Missing.cs:1)",
+			want: ColorGray + "Game.Player:Start () (at Assets/Missing.cs:1)" + ColorReset,
+		},
+		{
+			line: "Loaded Assets/Scripts/Player.cs",
+			want: "Loaded Assets/Scripts/Player.cs",
+		},
+	}
+	for _, tt := range tests {
+		if got := f.FormatLine(tt.line); got != tt.want {
+			t.Errorf("FormatLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+
+	// Without colors, output is left as is
+	plain := NewFormatter(WithNoColor(true), WithLinker(func(string, int) string { return "file:///x" }))
+	if got := plain.FormatLine("Game.Player:Update () (at Assets/Scripts/Player.cs:30)"); got != "Game.Player:Update () (at Assets/Scripts/Player.cs:30)" {
+		t.Errorf("FormatLine() without colors = %q", got)
+	}
+}
@@ -23,6 +23,9 @@ type Logger struct {
 	showTime         bool
 	currentGroup     NoiseCategory // Current active group in CI mode
 	groupIndentLevel int           // Indentation level when group started
+	diagnostics      diagnostics   // Compiler errors and warnings seen so far
+	diagnosticsFile  string        // Where Close writes the diagnostics as JSON, if set
+	hideDiagnostics  bool          // Do not print the diagnostics report on Close
 }
 
 type LoggerOption func(*Logger)
@@ -45,6 +48,22 @@ func WithShowTime(show bool) LoggerOption {
 	}
 }
 
+// WithDiagnosticsFile makes Close write the compiler errors and warnings of
+// the log to path as JSON
+func WithDiagnosticsFile(path string) LoggerOption {
+	return func(l *Logger) {
+		l.diagnosticsFile = path
+	}
+}
+
+// WithHideDiagnostics leaves out the report of compiler errors and warnings
+// Close prints, for callers that present them themselves
+func WithHideDiagnostics(hide bool) LoggerOption {
+	return func(l *Logger) {
+		l.hideDiagnostics = hide
+	}
+}
+
 func New(logFile string, ciMode bool) *Logger {
 	return NewWithOptions(logFile, WithCIMode(ciMode))
 }
@@ -121,6 +140,10 @@ func (l *Logger) processLine(line string) {
 		}
 	}
 
+	if diag, ok := ParseDiagnostic(line); ok {
+		l.diagnostics.add(diag)
+	}
+
 	// Always write raw to file
 	if l.rawWriter != nil {
 		_, _ = fmt.Fprintln(l.rawWriter, line)
@@ -184,8 +207,11 @@ func (l *Logger) processLineNormalMode(line string, level LogLevel) {
 	formatted := l.formatter.FormatLine(line)
 
 	if l.showTime {
-		timestamp := time.Now().Format("15:04:05.000")
-		_, _ = fmt.Fprintf(os.Stdout, "%s[%s]%s %s\n", ColorGray, timestamp, ColorReset, formatted)
+		timestamp := "[" + time.Now().Format("15:04:05.000") + "]"
+		if !l.formatter.noColor {
+			timestamp = ColorGray + timestamp + ColorReset
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "%s %s\n", timestamp, formatted)
 	} else {
 		_, _ = fmt.Fprintln(os.Stdout, formatted)
 	}
@@ -239,6 +265,14 @@ func (l *Logger) GetStats() (warnings, errors int) {
 	return l.warnings, l.errors
 }
 
+// Diagnostics returns the compiler errors and warnings of the log, once each,
+// sorted by file and position
+func (l *Logger) Diagnostics() []Diagnostic {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.diagnostics.sorted()
+}
+
 func (l *Logger) Close() error {
 	if l.pipeWriter != nil {
 		_ = l.pipeWriter.Close()
@@ -254,6 +288,27 @@ func (l *Logger) Close() error {
 	}
 	l.mutex.Unlock()
 
+	l.mutex.Lock()
+	noise := l.formatter.NoiseStats().Summary()
+	l.mutex.Unlock()
+	if noise != "" {
+		noise += "; " + NoiseHint
+		if !l.formatter.noColor {
+			noise = ColorGray + noise + ColorReset
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "\n%s\n", noise)
+	}
+
+	diagnostics := l.Diagnostics()
+	if !l.hideDiagnostics {
+		PrintDiagnostics(os.Stdout, diagnostics, l.formatter.noColor)
+	}
+	if l.diagnosticsFile != "" {
+		if err := WriteDiagnosticsFile(l.diagnosticsFile, diagnostics); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
 	warnings, errors := l.GetStats()
 	if warnings > 0 || errors > 0 {
 		var summaryColor string
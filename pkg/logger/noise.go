@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// NoiseCategories lists the categories of noise in the order summaries list
+// them when their counts are equal
+var NoiseCategories = []NoiseCategory{
+	NoiseCategoryLicensing,
+	NoiseCategoryPackage,
+	NoiseCategoryMemory,
+	NoiseCategoryAssembly,
+	NoiseCategoryGRPC,
+	NoiseCategorySubsystems,
+	NoiseCategoryAssetImport,
+	NoiseCategoryShader,
+	NoiseCategoryOther,
+}
+
+// Name returns the name of the category for --show-noise, e.g. package-manager
+func (c NoiseCategory) Name() string {
+	return strings.ReplaceAll(strings.ToLower(string(c)), " ", "-")
+}
+
+// ParseNoiseCategories returns the categories names refer to, by Name; "all"
+// is every category
+func ParseNoiseCategories(names []string) ([]NoiseCategory, error) {
+	var categories []NoiseCategory
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "all" {
+			return NoiseCategories, nil
+		}
+		found := false
+		for _, c := range NoiseCategories {
+			if c.Name() == name {
+				categories = append(categories, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			known := make([]string, 0, len(NoiseCategories))
+			for _, c := range NoiseCategories {
+				known = append(known, c.Name())
+			}
+			return nil, errs.WithHint(
+				errs.New(errs.Usage, "unknown noise category: %s", name),
+				"Categories: "+strings.Join(known, ", ")+", or all")
+		}
+	}
+	return categories, nil
+}
+
+// WithHideNoise hides noise lines instead of dimming them, counting them per
+// category in NoiseStats
+func WithHideNoise(hide bool) FormatterOption {
+	return func(f *Formatter) {
+		f.hideNoise = hide
+	}
+}
+
+// WithShowNoise keeps showing the noise of categories when noise is hidden
+func WithShowNoise(categories []NoiseCategory) FormatterOption {
+	return func(f *Formatter) {
+		for _, c := range categories {
+			f.shownNoise[c] = true
+		}
+	}
+}
+
+// NoiseStats counts the hidden noise lines per category
+type NoiseStats map[NoiseCategory]int
+
+// NoiseStats returns how many noise lines ShouldShow has hidden so far
+func (f *Formatter) NoiseStats() NoiseStats {
+	stats := make(NoiseStats, len(f.noiseStats))
+	for c, n := range f.noiseStats {
+		stats[c] = n
+	}
+	return stats
+}
+
+// Total returns the number of hidden lines
+func (s NoiseStats) Total() int {
+	total := 0
+	for _, n := range s {
+		total += n
+	}
+	return total
+}
+
+// Summary describes the hidden lines, largest category first, e.g.
+// "Suppressed 1,243 noise lines: Unity Licensing 800, Package Manager 300".
+// Returns "" if nothing was hidden.
+func (s NoiseStats) Summary() string {
+	if s.Total() == 0 {
+		return ""
+	}
+	categories := make([]NoiseCategory, 0, len(s))
+	for _, c := range NoiseCategories {
+		if s[c] > 0 {
+			categories = append(categories, c)
+		}
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return s[categories[i]] > s[categories[j]]
+	})
+
+	parts := make([]string, 0, len(categories))
+	for _, c := range categories {
+		parts = append(parts, fmt.Sprintf("%s %s", c, groupDigits(s[c])))
+	}
+	return fmt.Sprintf("Suppressed %s noise lines: %s", groupDigits(s.Total()), strings.Join(parts, ", "))
+}
+
+// NoiseHint tells how to show suppressed noise again
+const NoiseHint = "show them with --show-noise <category>|all"
+
+// groupDigits formats n with thousands separators, e.g. 1,243
+func groupDigits(n int) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
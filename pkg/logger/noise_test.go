@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestParseNoiseCategories(t *testing.T) {
+	got, err := ParseNoiseCategories([]string{"package-manager", " Unity-Licensing"})
+	if err != nil || !reflect.DeepEqual(got, []NoiseCategory{NoiseCategoryPackage, NoiseCategoryLicensing}) {
+		t.Errorf("ParseNoiseCategories() = %v, %v", got, err)
+	}
+	if got, err := ParseNoiseCategories([]string{"all"}); err != nil || len(got) != len(NoiseCategories) {
+		t.Errorf("ParseNoiseCategories(all) = %v, %v", got, err)
+	}
+	if _, err := ParseNoiseCategories([]string{"licensing-stuff"}); errs.CategoryOf(err) != errs.Usage || errs.Hint(err) == "" {
+		t.Errorf("ParseNoiseCategories(unknown) error = %v, want a usage error with a hint", err)
+	}
+}
+
+func TestFormatterHideNoise(t *testing.T) {
+	f := NewFormatter(WithHideNoise(true), WithShowNoise([]NoiseCategory{NoiseCategoryShader}))
+	lines := []string{
+		"[Licensing::Module] Successfully connected",
+		"[Licensing::Client] Handshaking",
+		"[Package Manager] Registered 52 packages",
+		"Compiling shader \"Hidden/Blit\"",
+		"GfxDevice: creating device client",
+		"Hello from the project",
+	}
+	var shown []string
+	for _, line := range lines {
+		if f.ShouldShow(line) {
+			shown = append(shown, line)
+		}
+	}
+	if want := []string{lines[3], lines[5]}; !reflect.DeepEqual(shown, want) {
+		t.Errorf("shown = %q, want %q", shown, want)
+	}
+
+	want := NoiseStats{NoiseCategoryLicensing: 2, NoiseCategoryPackage: 1, NoiseCategoryOther: 1}
+	if stats := f.NoiseStats(); !reflect.DeepEqual(stats, want) {
+		t.Errorf("NoiseStats() = %v, want %v", stats, want)
+	}
+
+	// Noise is only dimmed by default
+	if !NewFormatter().ShouldShow(lines[0]) {
+		t.Error("ShouldShow() hid noise without WithHideNoise")
+	}
+}
+
+func TestNoiseStatsSummary(t *testing.T) {
+	stats := NoiseStats{NoiseCategoryPackage: 300, NoiseCategoryLicensing: 800, NoiseCategoryShader: 143, NoiseCategoryMemory: 0}
+	want := "Suppressed 1,243 noise lines: Unity Licensing 800, Package Manager 300, Shader Compilation 143"
+	if got := stats.Summary(); got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	if got := (NoiseStats{}).Summary(); got != "" {
+		t.Errorf("Summary() without noise = %q", got)
+	}
+	if got := groupDigits(1234567); got != "1,234,567" {
+		t.Errorf("groupDigits() = %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// SearchScope limits a search to lines of one kind
+type SearchScope string
+
+const (
+	SearchScopeAll        SearchScope = ""
+	SearchScopeCompiler   SearchScope = "compiler"   // C# compiler errors and warnings (CSxxxx)
+	SearchScopeException  SearchScope = "exception"  // Exception messages
+	SearchScopeStackTrace SearchScope = "stacktrace" // Stack trace lines
+	SearchScopeNoise      SearchScope = "noise"      // Lines the formatter dims as noise
+)
+
+// SearchScopes lists the scopes a search can be limited to
+var SearchScopes = []SearchScope{SearchScopeCompiler, SearchScopeException, SearchScopeStackTrace, SearchScopeNoise}
+
+// compilerMessagePattern matches C# compiler diagnostics, e.g.
+// "Assets/Player.cs(12,5): error CS0103: ..."
+var compilerMessagePattern = regexp.MustCompile(`(?i)\b(error|warning) CS\d{4}\b`)
+
+// SearchOptions selects the lines Search matches
+type SearchOptions struct {
+	Pattern *regexp.Regexp
+	Level   LogLevel    // LogLevelWarning matches warnings and errors, LogLevelError errors only; anything else every level
+	Scope   SearchScope // Only lines of this kind
+	Context int         // Lines to include before and after each match
+}
+
+// SearchLine is a line of a search result
+type SearchLine struct {
+	Number int // 1-based
+	Text   string
+	Match  bool // false for context lines
+}
+
+// Search returns the lines of a Unity log matching opts, grouped into runs of
+// adjacent lines with their context, as grep -C prints them
+func (f *Formatter) Search(r io.Reader, opts SearchOptions) ([][]SearchLine, error) {
+	scanner := bufio.NewScanner(r)
+	// Increase buffer size for long lines
+	const maxCapacity = 1024 * 1024
+	scanner.Buffer(make([]byte, maxCapacity), maxCapacity)
+
+	var groups [][]SearchLine
+	var before []SearchLine
+	lastNumber := 0 // Number of the last line added to a group
+	after := 0
+	number := 0
+	for scanner.Scan() {
+		number++
+		line := SearchLine{Number: number, Text: scanner.Text()}
+
+		if f.searchMatches(line.Text, opts) {
+			line.Match = true
+			lines := append(before, line)
+			if len(groups) == 0 || lines[0].Number > lastNumber+1 {
+				groups = append(groups, nil)
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], lines...)
+			before = nil
+			lastNumber = number
+			after = opts.Context
+			continue
+		}
+
+		if after > 0 {
+			groups[len(groups)-1] = append(groups[len(groups)-1], line)
+			lastNumber = number
+			after--
+		} else if opts.Context > 0 {
+			before = append(before, line)
+			if len(before) > opts.Context {
+				before = before[1:]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	return groups, nil
+}
+
+// searchMatches reports whether line is one a search with opts matches
+func (f *Formatter) searchMatches(line string, opts SearchOptions) bool {
+	if opts.Pattern != nil && !opts.Pattern.MatchString(line) {
+		return false
+	}
+	if opts.Level != LogLevelWarning && opts.Level != LogLevelError && opts.Scope == SearchScopeAll {
+		return true
+	}
+
+	level := f.ClassifyLine(line)
+	switch opts.Level {
+	case LogLevelWarning:
+		if level != LogLevelWarning && level != LogLevelError {
+			return false
+		}
+	case LogLevelError:
+		if level != LogLevelError {
+			return false
+		}
+	}
+
+	switch opts.Scope {
+	case SearchScopeCompiler:
+		return compilerMessagePattern.MatchString(line)
+	case SearchScopeException:
+		return level == LogLevelError && strings.Contains(line, "Exception")
+	case SearchScopeStackTrace:
+		return level == LogLevelStackTrace
+	case SearchScopeNoise:
+		return level == LogLevelNoise
+	}
+	return true
+}
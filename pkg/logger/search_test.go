@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const searchTestLog = `Refreshing native plugins
+[Licensing::Module] error fetching entitlements
+Assets/Scripts/Player.cs(12,5): error CS0103: The name 'speed' does not exist
+Assets/Scripts/Player.cs(20,1): warning CS0168: The variable 'e' is declared but never used
+Compilation finished
+NullReferenceException: Object reference not set to an instance of an object
+Game.Player:Update () (at Assets/Scripts/Player.cs:30)
+Player loop done
+Player connection [1] established`
+
+func searchNumbers(groups [][]SearchLine) [][]int {
+	var numbers [][]int
+	for _, group := range groups {
+		var n []int
+		for _, line := range group {
+			if line.Match {
+				n = append(n, line.Number)
+			} else {
+				n = append(n, -line.Number)
+			}
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want [][]int // negative numbers are context lines
+	}{
+		{name: "pattern", opts: SearchOptions{Pattern: regexp.MustCompile(`Player`)}, want: [][]int{{3, 4}, {7, 8, 9}}},
+		{name: "errors", opts: SearchOptions{Pattern: regexp.MustCompile(`Player|Null`), Level: LogLevelError}, want: [][]int{{3}, {6}}},
+		{name: "warnings and errors", opts: SearchOptions{Level: LogLevelWarning}, want: [][]int{{3, 4}, {6}}},
+		{name: "compiler", opts: SearchOptions{Pattern: regexp.MustCompile(`speed|never`), Scope: SearchScopeCompiler}, want: [][]int{{3, 4}}},
+		{name: "exception", opts: SearchOptions{Scope: SearchScopeException}, want: [][]int{{6}}},
+		{name: "stack trace", opts: SearchOptions{Pattern: regexp.MustCompile(`Player`), Scope: SearchScopeStackTrace}, want: [][]int{{7}}},
+		{name: "noise", opts: SearchOptions{Pattern: regexp.MustCompile(`error`), Scope: SearchScopeNoise}, want: [][]int{{2}}},
+		{name: "context", opts: SearchOptions{Pattern: regexp.MustCompile(`CS0103|loop`), Context: 1}, want: [][]int{{-2, 3, -4}, {-7, 8, -9}}},
+		{name: "overlapping context", opts: SearchOptions{Pattern: regexp.MustCompile(`CS0103|Compilation`), Context: 1}, want: [][]int{{-2, 3, -4, 5, -6}}},
+		{name: "no match", opts: SearchOptions{Pattern: regexp.MustCompile(`Android`)}},
+	}
+
+	f := NewFormatter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := f.Search(strings.NewReader(searchTestLog), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := searchNumbers(groups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
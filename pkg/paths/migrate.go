@@ -0,0 +1,105 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Move is a file or directory Migrate moved to its current location
+type Move struct {
+	From string
+	To   string
+}
+
+// Files of earlier uniforge versions by the directory they belong in now
+var (
+	legacyCacheFiles = []string{"releases-cache.json", "archive-releases.json", "support-windows.json", "prompt-cache.json", "install-path.json"}
+	legacyStateFiles = []string{"last-opened.json", "project-tags.json", "install-queue.json", "watch-state.json"}
+)
+
+// Migrate moves the files earlier uniforge versions kept elsewhere, such as
+// the project tags in the cache directory or the install path cache in the
+// temp directory, to the current directories. Files already present at the
+// new location are left alone. Nothing is moved while UNIFORGE_HOME is set,
+// since the usual directories are then current rather than legacy and
+// unsetting it again must find the files where they were. Returns what was
+// moved.
+func Migrate() ([]Move, error) {
+	home, _ := os.UserHomeDir()
+	return migrate(migrations(runtime.GOOS, os.Getenv, home))
+}
+
+// migrations returns the moves from the layout before this package to dirs
+func migrations(goos string, getenv func(string) string, home string) []Move {
+	if getenv(HomeEnv) != "" {
+		return nil
+	}
+	dirs := resolve(goos, getenv, home)
+	legacyCache := legacyCacheDir(goos, getenv, home)
+
+	var moves []Move
+	for _, name := range legacyCacheFiles {
+		moves = append(moves, Move{filepath.Join(legacyCache, name), filepath.Join(dirs.Cache, name)})
+	}
+	for _, name := range legacyStateFiles {
+		moves = append(moves, Move{filepath.Join(legacyCache, name), filepath.Join(dirs.State, name)})
+	}
+	moves = append(moves,
+		Move{filepath.Join(legacyCache, "crashes"), filepath.Join(dirs.Log, "crashes")},
+		Move{filepath.Join(os.TempDir(), "uniforge-install-path.json"), filepath.Join(dirs.Cache, "install-path.json")},
+	)
+	return moves
+}
+
+// legacyCacheDir returns the cache directory of earlier versions, in
+// os.UserCacheDir
+func legacyCacheDir(goos string, getenv func(string) string, home string) string {
+	var cache string
+	switch goos {
+	case "windows":
+		cache = getenv("LOCALAPPDATA")
+	case "darwin":
+		if home != "" {
+			cache = filepath.Join(home, "Library", "Caches")
+		}
+	default:
+		cache = getenv("XDG_CACHE_HOME")
+		if cache == "" && home != "" {
+			cache = filepath.Join(home, ".cache")
+		}
+	}
+	if cache == "" {
+		cache = os.TempDir()
+	}
+	return filepath.Join(cache, "uniforge")
+}
+
+// migrate performs the moves whose source exists and target does not
+func migrate(moves []Move) ([]Move, error) {
+	var moved []Move
+	var errs []error
+	for _, m := range moves {
+		if filepath.Clean(m.From) == filepath.Clean(m.To) {
+			continue
+		}
+		if _, err := os.Lstat(m.From); err != nil {
+			continue
+		}
+		if _, err := os.Lstat(m.To); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(m.To), 0755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.Rename(m.From, m.To); err != nil {
+			errs = append(errs, fmt.Errorf("failed to move %s: %w", m.From, err))
+			continue
+		}
+		moved = append(moved, m)
+	}
+	return moved, errors.Join(errs...)
+}
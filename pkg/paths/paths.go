@@ -0,0 +1,124 @@
+// Package paths locates uniforge's own files: configuration, caches, state
+// that has to outlive the caches, and logs. The XDG base directories are used
+// where set and on Linux; macOS and Windows get their usual locations.
+// UNIFORGE_HOME puts everything below one directory instead.
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// HomeEnv is the environment variable that overrides all directories
+const HomeEnv = "UNIFORGE_HOME"
+
+// Dirs are uniforge's directories
+type Dirs struct {
+	Config string // config.yaml
+	Cache  string // Data that can be fetched again (release lists, ...)
+	State  string // Data that must survive clearing the caches (journal, tags, ...)
+	Log    string // Logs and crash bundles
+}
+
+// Current returns the directories for this user and platform
+func Current() Dirs {
+	home, _ := os.UserHomeDir()
+	return resolve(runtime.GOOS, os.Getenv, home)
+}
+
+// ConfigDir returns the directory of uniforge's configuration
+func ConfigDir() string { return Current().Config }
+
+// CacheDir returns the directory of uniforge's caches
+func CacheDir() string { return Current().Cache }
+
+// StateDir returns the directory of uniforge's state
+func StateDir() string { return Current().State }
+
+// LogDir returns the directory of uniforge's logs
+func LogDir() string { return Current().Log }
+
+// ConfigFile returns the config file to read and write: config.yaml in the
+// config directory when it exists or UNIFORGE_HOME is set, otherwise
+// ~/.uniforge.yaml
+func ConfigFile() string {
+	home, _ := os.UserHomeDir()
+	return configFile(Current(), os.Getenv, home)
+}
+
+func configFile(dirs Dirs, getenv func(string) string, home string) string {
+	file := filepath.Join(dirs.Config, "config.yaml")
+	if getenv(HomeEnv) != "" || home == "" {
+		return file
+	}
+	if _, err := os.Stat(file); err == nil {
+		return file
+	}
+	return filepath.Join(home, ".uniforge.yaml")
+}
+
+// resolve returns the directories for goos. XDG variables are honored on
+// every platform when set.
+func resolve(goos string, getenv func(string) string, home string) Dirs {
+	if dir := getenv(HomeEnv); dir != "" {
+		return Dirs{
+			Config: dir,
+			Cache:  filepath.Join(dir, "cache"),
+			State:  filepath.Join(dir, "state"),
+			Log:    filepath.Join(dir, "logs"),
+		}
+	}
+	if home == "" {
+		home = os.TempDir()
+	}
+
+	var dirs Dirs
+	switch goos {
+	case "darwin":
+		support := filepath.Join(home, "Library", "Application Support", "uniforge")
+		dirs = Dirs{
+			Config: support,
+			Cache:  filepath.Join(home, "Library", "Caches", "uniforge"),
+			State:  support,
+			Log:    filepath.Join(home, "Library", "Logs", "uniforge"),
+		}
+	case "windows":
+		roaming := getenv("APPDATA")
+		if roaming == "" {
+			roaming = filepath.Join(home, "AppData", "Roaming")
+		}
+		local := getenv("LOCALAPPDATA")
+		if local == "" {
+			local = filepath.Join(home, "AppData", "Local")
+		}
+		dirs = Dirs{
+			Config: filepath.Join(roaming, "uniforge"),
+			Cache:  filepath.Join(local, "uniforge", "cache"),
+			State:  filepath.Join(local, "uniforge"),
+			Log:    filepath.Join(local, "uniforge", "logs"),
+		}
+	default:
+		state := filepath.Join(home, ".local", "state", "uniforge")
+		dirs = Dirs{
+			Config: filepath.Join(home, ".config", "uniforge"),
+			Cache:  filepath.Join(home, ".cache", "uniforge"),
+			State:  state,
+			Log:    filepath.Join(state, "logs"),
+		}
+	}
+
+	if dir := getenv("XDG_CONFIG_HOME"); dir != "" {
+		dirs.Config = filepath.Join(dir, "uniforge")
+	}
+	if dir := getenv("XDG_CACHE_HOME"); dir != "" {
+		dirs.Cache = filepath.Join(dir, "uniforge")
+	}
+	if dir := getenv("XDG_STATE_HOME"); dir != "" {
+		dirs.State = filepath.Join(dir, "uniforge")
+		if goos != "darwin" && goos != "windows" {
+			dirs.Log = filepath.Join(dirs.State, "logs")
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,181 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestResolve(t *testing.T) {
+	home := filepath.FromSlash("/home/dev")
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want Dirs
+	}{
+		{
+			name: "linux defaults",
+			goos: "linux",
+			want: Dirs{
+				Config: filepath.Join(home, ".config", "uniforge"),
+				Cache:  filepath.Join(home, ".cache", "uniforge"),
+				State:  filepath.Join(home, ".local", "state", "uniforge"),
+				Log:    filepath.Join(home, ".local", "state", "uniforge", "logs"),
+			},
+		},
+		{
+			name: "linux XDG",
+			goos: "linux",
+			env:  map[string]string{"XDG_CONFIG_HOME": "/x/config", "XDG_CACHE_HOME": "/x/cache", "XDG_STATE_HOME": "/x/state"},
+			want: Dirs{
+				Config: filepath.Join("/x/config", "uniforge"),
+				Cache:  filepath.Join("/x/cache", "uniforge"),
+				State:  filepath.Join("/x/state", "uniforge"),
+				Log:    filepath.Join("/x/state", "uniforge", "logs"),
+			},
+		},
+		{
+			name: "macOS",
+			goos: "darwin",
+			want: Dirs{
+				Config: filepath.Join(home, "Library", "Application Support", "uniforge"),
+				Cache:  filepath.Join(home, "Library", "Caches", "uniforge"),
+				State:  filepath.Join(home, "Library", "Application Support", "uniforge"),
+				Log:    filepath.Join(home, "Library", "Logs", "uniforge"),
+			},
+		},
+		{
+			name: "windows",
+			goos: "windows",
+			env:  map[string]string{"APPDATA": "/w/Roaming", "LOCALAPPDATA": "/w/Local"},
+			want: Dirs{
+				Config: filepath.Join("/w/Roaming", "uniforge"),
+				Cache:  filepath.Join("/w/Local", "uniforge", "cache"),
+				State:  filepath.Join("/w/Local", "uniforge"),
+				Log:    filepath.Join("/w/Local", "uniforge", "logs"),
+			},
+		},
+		{
+			name: "UNIFORGE_HOME wins over XDG",
+			goos: "linux",
+			env:  map[string]string{HomeEnv: "/opt/uniforge", "XDG_CACHE_HOME": "/x/cache"},
+			want: Dirs{
+				Config: filepath.FromSlash("/opt/uniforge"),
+				Cache:  filepath.Join("/opt/uniforge", "cache"),
+				State:  filepath.Join("/opt/uniforge", "state"),
+				Log:    filepath.Join("/opt/uniforge", "logs"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolve(tt.goos, env(tt.env), home); got != tt.want {
+				t.Errorf("resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	home := t.TempDir()
+	dirs := resolve("linux", env(nil), home)
+
+	if got, want := configFile(dirs, env(nil), home), filepath.Join(home, ".uniforge.yaml"); got != want {
+		t.Errorf("configFile() = %s, want %s", got, want)
+	}
+
+	file := filepath.Join(dirs.Config, "config.yaml")
+	if err := os.MkdirAll(dirs.Config, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("log-level: debug\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := configFile(dirs, env(nil), home); got != file {
+		t.Errorf("configFile() = %s, want %s", got, file)
+	}
+
+	homeEnv := env(map[string]string{HomeEnv: "/opt/uniforge"})
+	if got, want := configFile(resolve("linux", homeEnv, home), homeEnv, home), filepath.Join("/opt/uniforge", "config.yaml"); got != want {
+		t.Errorf("configFile() with %s = %s, want %s", HomeEnv, got, want)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	home := t.TempDir()
+	local := filepath.Join(home, "AppData", "Local")
+	vars := env(map[string]string{"LOCALAPPDATA": local})
+	legacyCache := filepath.Join(local, "uniforge")
+	target := filepath.Join(local, "uniforge")
+
+	files := map[string]string{
+		filepath.Join(legacyCache, "releases-cache.json"):         "releases",
+		filepath.Join(legacyCache, "crashes", "2025-01-01", "a"):  "crash",
+		filepath.Join(legacyCache, "not-uniforge-knows-this.txt"): "stays",
+		filepath.Join(os.TempDir(), "uniforge-install-path.json"): "install path",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moved, err := migrate(migrations("windows", vars, home))
+	if err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	if len(moved) != 3 {
+		t.Errorf("moved %d, want 3: %+v", len(moved), moved)
+	}
+
+	want := map[string]string{
+		filepath.Join(target, "cache", "releases-cache.json"):       "releases",
+		filepath.Join(target, "logs", "crashes", "2025-01-01", "a"): "crash",
+		filepath.Join(target, "cache", "install-path.json"):         "install path",
+		filepath.Join(legacyCache, "not-uniforge-knows-this.txt"):   "stays",
+	}
+	for path, content := range want {
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v; want %q", path, data, err, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(legacyCache, "releases-cache.json")); !os.IsNotExist(err) {
+		t.Error("releases-cache.json was not moved away")
+	}
+
+	// Nothing is left to move on the next run
+	if moved, err := migrate(migrations("windows", vars, home)); err != nil || len(moved) != 0 {
+		t.Errorf("second migrate() = %+v, %v", moved, err)
+	}
+}
+
+func TestMigrationsWithHome(t *testing.T) {
+	// The usual directories are not legacy while UNIFORGE_HOME is set
+	if moves := migrations("linux", env(map[string]string{HomeEnv: "/opt/uniforge"}), "/home/dev"); len(moves) != 0 {
+		t.Errorf("migrations() with %s = %+v, want none", HomeEnv, moves)
+	}
+}
+
+func TestMigrationsSameDirectory(t *testing.T) {
+	// Without UNIFORGE_HOME the caches on Linux stay where they were
+	home := filepath.FromSlash("/home/dev")
+	for _, m := range migrations("linux", env(nil), home) {
+		if filepath.Base(m.From) == "releases-cache.json" && m.From != m.To {
+			t.Errorf("releases-cache.json moves from %s to %s", m.From, m.To)
+		}
+		if filepath.Base(m.From) == "project-tags.json" && m.To != filepath.Join(home, ".local", "state", "uniforge", "project-tags.json") {
+			t.Errorf("project-tags.json moves to %s", m.To)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package platform
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 )
@@ -69,3 +73,36 @@ func GetPlatformSpecificPath(paths map[string]string) string {
 
 	return ""
 }
+
+// OpenURL opens a URL in the default browser
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+	return cmd.Start()
+}
+
+// FreeDiskSpace returns the bytes available on the volume that contains path.
+// If path does not exist yet, its nearest existing parent is used.
+func FreeDiskSpace(path string) (uint64, error) {
+	dir := path
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return 0, fmt.Errorf("no existing directory for %s", path)
+		}
+		dir = parent
+	}
+	return freeDiskSpace(dir)
+}
@@ -167,3 +167,24 @@ func TestGetPlatformSpecificPath(t *testing.T) {
 		})
 	}
 }
+
+func TestFreeDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+
+	free, err := FreeDiskSpace(dir)
+	if err != nil {
+		t.Fatalf("FreeDiskSpace failed: %v", err)
+	}
+	if free == 0 {
+		t.Error("Expected non-zero free space for temp directory")
+	}
+
+	// A path that does not exist yet resolves to its nearest existing parent
+	missing, err := FreeDiskSpace(dir + "/not/yet/created")
+	if err != nil {
+		t.Fatalf("FreeDiskSpace on missing path failed: %v", err)
+	}
+	if missing == 0 {
+		t.Error("Expected non-zero free space for missing path")
+	}
+}
@@ -0,0 +1,129 @@
+// Package prompt describes the Unity project around a directory for shell
+// prompt segments, answering from the daemon or a small cache to stay fast
+package prompt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/daemon"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/paths"
+	"github.com/neptaco/uniforge/pkg/sdk"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/unity"
+)
+
+// CacheTTL is how long a project's install status is reused without a daemon
+const CacheTTL = time.Minute
+
+// Info is what a prompt shows about a project
+type Info struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Version   string `json:"version"` // Editor version in ProjectVersion.txt
+	Installed bool   `json:"installed"`
+}
+
+// Options tells Lookup where to find the daemon and the cache
+type Options struct {
+	Socket    string // Daemon socket; "" skips the daemon
+	CacheFile string // Install status cache; "" disables it
+	Now       time.Time
+}
+
+// DefaultOptions returns the daemon's default socket and a cache in the uniforge cache directory
+func DefaultOptions() Options {
+	return Options{
+		Socket:    daemon.DefaultSocketPath(),
+		CacheFile: filepath.Join(paths.CacheDir(), "prompt-cache.json"),
+		Now:       time.Now(),
+	}
+}
+
+type cacheEntry struct {
+	Version   string    `json:"version"`
+	Installed bool      `json:"installed"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Lookup describes the project containing dir, or returns nil if dir is not
+// inside a Unity project. Whether the editor is installed is taken from, in
+// order: a cache entry younger than CacheTTL, the daemon, and the install
+// directories.
+func Lookup(dir string, opts Options) (*Info, error) {
+	root, err := unity.FindProjectRoot(dir)
+	if err != nil {
+		return nil, nil
+	}
+	project, err := sdk.LoadProject(root)
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{Name: project.Name, Path: project.Path, Version: project.UnityVersion}
+	if info.Version == "" {
+		return info, nil
+	}
+
+	cache := readCache(opts.CacheFile)
+	if entry, ok := cache[info.Path]; ok && entry.Version == info.Version && opts.Now.Sub(entry.CheckedAt) < CacheTTL {
+		info.Installed = entry.Installed
+		return info, nil
+	}
+
+	info.Installed = isInstalled(info.Version, opts.Socket)
+	if opts.CacheFile != "" {
+		cache[info.Path] = cacheEntry{Version: info.Version, Installed: info.Installed, CheckedAt: opts.Now}
+		if err := writeCache(opts.CacheFile, cache, opts.Now); err != nil {
+			ui.Debug("Failed to write prompt cache", "error", err)
+		}
+	}
+	return info, nil
+}
+
+// isInstalled asks the daemon for the installed editors, or reads them itself
+// if no daemon is running
+func isInstalled(version, socket string) bool {
+	if socket != "" {
+		var editors []sdk.Editor
+		if err := daemon.Call(socket, daemon.MethodListEditors, nil, &editors); err == nil {
+			return slices.ContainsFunc(editors, func(e sdk.Editor) bool { return e.Version == version })
+		}
+	}
+
+	editors, err := (&hub.Client{}).ListInstalledEditors()
+	if err != nil {
+		ui.Debug("Failed to list installed editors", "error", err)
+		return false
+	}
+	return slices.ContainsFunc(editors, func(e hub.EditorInfo) bool { return e.Version == version })
+}
+
+func readCache(path string) map[string]cacheEntry {
+	cache := make(map[string]cacheEntry)
+	if path == "" {
+		return cache
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func writeCache(path string, cache map[string]cacheEntry, now time.Time) error {
+	// Forget projects not looked at for a day so the file stays small
+	for projectPath, entry := range cache {
+		if now.Sub(entry.CheckedAt) > 24*time.Hour {
+			delete(cache, projectPath)
+		}
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, data, 0644)
+}
@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses the Linux editor layout")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	project := filepath.Join(t.TempDir(), "Game")
+	versionFile := filepath.Join(project, "ProjectSettings", "ProjectVersion.txt")
+	if err := os.MkdirAll(filepath.Dir(versionFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(versionFile, []byte("m_EditorVersion: 2022.3.10f1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	opts := Options{CacheFile: filepath.Join(t.TempDir(), "prompt-cache.json"), Now: now}
+
+	info, err := Lookup(filepath.Join(project, "Assets"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info == nil || info.Name != "Game" || info.Version != "2022.3.10f1" || info.Installed {
+		t.Fatalf("Lookup() = %+v", info)
+	}
+
+	// Installing the editor is only noticed once the cache entry expires
+	editor := filepath.Join(home, "Unity", "Hub", "Editor", "2022.3.10f1", "Editor")
+	if err := os.MkdirAll(editor, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(editor, "Unity"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := Lookup(project, opts); info.Installed {
+		t.Error("cached status was not used")
+	}
+	opts.Now = now.Add(CacheTTL)
+	if info, _ := Lookup(project, opts); !info.Installed {
+		t.Error("editor not installed after the cache expired")
+	}
+
+	// A version change is not answered from the cache
+	if err := os.WriteFile(versionFile, []byte("m_EditorVersion: 6000.0.23f1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := Lookup(project, opts); info.Version != "6000.0.23f1" || info.Installed {
+		t.Errorf("after version change: %+v", info)
+	}
+
+	if info, err := Lookup(t.TempDir(), opts); info != nil || err != nil {
+		t.Errorf("Lookup() outside a project = %+v, %v", info, err)
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses the Linux editor layout")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("UNITY_LICENSING_SERVER", "")
+
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, "ProjectSettings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "ProjectSettings", "ProjectVersion.txt"), []byte("m_EditorVersion: 2022.3.10f1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	editor := filepath.Join(home, "Unity", "Hub", "Editor", "2022.3.10f1", "Editor")
+	if err := os.MkdirAll(editor, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(editor, "Unity"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := UpdateStatus(project)
+	if err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+	if !status.Installed || status.EditorPath == "" || status.License != "none" {
+		t.Errorf("UpdateStatus() = %+v", status)
+	}
+
+	read, err := ReadStatus(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Version != "2022.3.10f1" || !read.Installed || read.Name != status.Name {
+		t.Errorf("ReadStatus() = %+v", read)
+	}
+	gitignore, err := os.ReadFile(filepath.Join(project, ".uniforge", ".gitignore"))
+	if err != nil || string(gitignore) != "status.json\n" {
+		t.Errorf(".gitignore = %q, %v", gitignore, err)
+	}
+}
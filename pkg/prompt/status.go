@@ -0,0 +1,95 @@
+package prompt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/license"
+	"github.com/neptaco/uniforge/pkg/unity"
+)
+
+// StatusFileName is the status snapshot in a project's .uniforge directory
+const StatusFileName = "status.json"
+
+// Status is what <project>/.uniforge/status.json records, so prompt
+// frameworks can show it by reading a file instead of running uniforge
+type Status struct {
+	Name            string    `json:"name"`
+	Version         string    `json:"version"`
+	Installed       bool      `json:"installed"`
+	EditorPath      string    `json:"editorPath,omitempty"`
+	License         string    `json:"license"` // License mode the editor would use, "none" if none
+	LicenseWarnings []string  `json:"licenseWarnings,omitempty"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// StatusPath returns the location of a project's status.json
+func StatusPath(projectPath string) string {
+	return filepath.Join(projectPath, unity.ProjectConfigDir, StatusFileName)
+}
+
+// UpdateStatus records the current state of the project at projectPath in its
+// status.json. The file is machine-specific, so a .gitignore for it is added
+// to .uniforge unless one exists.
+func UpdateStatus(projectPath string) (*Status, error) {
+	project, err := unity.LoadProject(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Name:      project.Name,
+		Version:   project.UnityVersion,
+		License:   string(license.LicenseTypeNone),
+		UpdatedAt: time.Now(),
+	}
+
+	if editors, err := (&hub.Client{}).ListInstalledEditors(); err == nil {
+		if i := slices.IndexFunc(editors, func(e hub.EditorInfo) bool { return e.Version == project.UnityVersion }); i >= 0 {
+			status.Installed = true
+			status.EditorPath = editors[i].Path
+		}
+	}
+
+	if licenseStatus, err := license.GetStatus(); err == nil {
+		editorLicense := licenseStatus.ForEditor(project.UnityVersion)
+		status.License = string(editorLicense.Mode)
+		status.LicenseWarnings = editorLicense.Warnings
+	}
+
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := fsutil.WriteFileAtomic(StatusPath(project.Path), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", StatusPath(project.Path), err)
+	}
+
+	gitignore := filepath.Join(project.Path, unity.ProjectConfigDir, ".gitignore")
+	if _, err := os.Stat(gitignore); errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(gitignore, []byte(StatusFileName+"\n"), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", gitignore, err)
+		}
+	}
+	return status, nil
+}
+
+// ReadStatus reads a project's status.json
+func ReadStatus(projectPath string) (*Status, error) {
+	data, err := os.ReadFile(StatusPath(projectPath))
+	if err != nil {
+		return nil, err
+	}
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StatusPath(projectPath), err)
+	}
+	return &status, nil
+}
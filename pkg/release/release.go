@@ -0,0 +1,224 @@
+// Package release describes the artifacts of a uniforge release and renders
+// the Homebrew formula and Scoop manifest that install them.
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// Package details shared by the formula and the manifest (see .goreleaser.yml)
+const (
+	Homepage    = "https://github.com/neptaco/uniforge"
+	Description = "CLI tool for Unity development"
+	License     = "MIT"
+)
+
+// archivePattern matches the goreleaser archive names, e.g. "uniforge_darwin_arm64.tar.gz"
+var archivePattern = regexp.MustCompile(`^uniforge_([a-z0-9]+)_([a-z0-9]+)\.(tar\.gz|zip)$`)
+
+// Artifact is a release archive for one platform
+type Artifact struct {
+	Name   string // e.g. "uniforge_linux_amd64.tar.gz"
+	OS     string // GOOS
+	Arch   string // GOARCH
+	URL    string
+	SHA256 string
+}
+
+// Metadata is a release and its archives
+type Metadata struct {
+	Version   string // Without the "v" of the tag
+	Artifacts []Artifact
+}
+
+// DownloadURL returns where GitHub serves an asset of a release
+func DownloadURL(version, name string) string {
+	return fmt.Sprintf("%s/releases/download/v%s/%s", Homepage, strings.TrimPrefix(version, "v"), name)
+}
+
+// FromChecksums builds the metadata of version from a goreleaser checksums.txt
+// ("<sha256>  <file>" lines). urlFor returns the download URL of an archive;
+// nil uses DownloadURL. Files other than archives are ignored.
+func FromChecksums(version string, checksums []byte, urlFor func(name string) string) (*Metadata, error) {
+	version = strings.TrimPrefix(version, "v")
+	if version == "" {
+		return nil, errs.New(errs.Usage, "release version is required")
+	}
+	if urlFor == nil {
+		urlFor = func(name string) string { return DownloadURL(version, name) }
+	}
+
+	m := &Metadata{Version: version}
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sum, name := fields[0], strings.TrimPrefix(fields[1], "*")
+		match := archivePattern.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		if len(sum) != 64 {
+			return nil, fmt.Errorf("invalid SHA-256 for %s: %q", name, sum)
+		}
+		m.Artifacts = append(m.Artifacts, Artifact{
+			Name:   name,
+			OS:     match[1],
+			Arch:   match[2],
+			URL:    urlFor(name),
+			SHA256: strings.ToLower(sum),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(m.Artifacts) == 0 {
+		return nil, errs.New(errs.NotFound, "no uniforge archives listed in the checksums")
+	}
+	sort.Slice(m.Artifacts, func(i, j int) bool {
+		return m.Artifacts[i].Name < m.Artifacts[j].Name
+	})
+	return m, nil
+}
+
+// Artifact returns the archive for a platform
+func (m *Metadata) Artifact(goos, goarch string) (Artifact, bool) {
+	for _, a := range m.Artifacts {
+		if a.OS == goos && a.Arch == goarch {
+			return a, true
+		}
+	}
+	return Artifact{}, false
+}
+
+var formulaTemplate = template.Must(template.New("formula").Parse(`# typed: false
+# frozen_string_literal: true
+
+# Generated by "uniforge release manifest homebrew". DO NOT EDIT.
+class Uniforge < Formula
+  desc "{{.Description}}"
+  homepage "{{.Homepage}}"
+  version "{{.Version}}"
+  license "{{.License}}"
+{{range .Platforms}}
+  on_{{.Name}} do
+{{- range .Artifacts}}
+    if Hardware::CPU.{{.CPU}}?
+      url "{{.URL}}"
+      sha256 "{{.SHA256}}"
+    end
+{{- end}}
+  end
+{{end}}
+  def install
+    bin.install "uniforge"
+  end
+
+  test do
+    system "#{bin}/uniforge", "--version"
+  end
+end
+`))
+
+type formulaArtifact struct {
+	CPU    string // Hardware::CPU predicate: intel or arm
+	URL    string
+	SHA256 string
+}
+
+type formulaPlatform struct {
+	Name      string // macos or linux
+	Artifacts []formulaArtifact
+}
+
+// HomebrewFormula renders the Homebrew formula of the macOS and Linux archives
+func (m *Metadata) HomebrewFormula() (string, error) {
+	var platforms []formulaPlatform
+	for _, p := range []struct{ goos, name string }{{"darwin", "macos"}, {"linux", "linux"}} {
+		platform := formulaPlatform{Name: p.name}
+		for _, arch := range []struct{ goarch, cpu string }{{"amd64", "intel"}, {"arm64", "arm"}} {
+			if a, ok := m.Artifact(p.goos, arch.goarch); ok {
+				platform.Artifacts = append(platform.Artifacts, formulaArtifact{CPU: arch.cpu, URL: a.URL, SHA256: a.SHA256})
+			}
+		}
+		if len(platform.Artifacts) > 0 {
+			platforms = append(platforms, platform)
+		}
+	}
+	if len(platforms) == 0 {
+		return "", errs.New(errs.NotFound, "release %s has no macOS or Linux archives", m.Version)
+	}
+
+	var b strings.Builder
+	err := formulaTemplate.Execute(&b, map[string]any{
+		"Description": Description,
+		"Homepage":    Homepage,
+		"Version":     m.Version,
+		"License":     License,
+		"Platforms":   platforms,
+	})
+	return b.String(), err
+}
+
+// scoopManifest is a Scoop app manifest
+type scoopManifest struct {
+	Version      string                       `json:"version"`
+	Description  string                       `json:"description"`
+	Homepage     string                       `json:"homepage"`
+	License      string                       `json:"license"`
+	Architecture map[string]scoopArchitecture `json:"architecture"`
+	Bin          string                       `json:"bin"`
+	Checkver     string                       `json:"checkver"`
+	Autoupdate   map[string]any               `json:"autoupdate"`
+}
+
+type scoopArchitecture struct {
+	URL  string `json:"url"`
+	Hash string `json:"hash"`
+}
+
+// scoopArchitectures maps GOARCH to Scoop's architecture names
+var scoopArchitectures = map[string]string{"amd64": "64bit", "386": "32bit", "arm64": "arm64"}
+
+// ScoopManifest renders the Scoop manifest of the Windows archives
+func (m *Metadata) ScoopManifest() ([]byte, error) {
+	manifest := scoopManifest{
+		Version:      m.Version,
+		Description:  Description,
+		Homepage:     Homepage,
+		License:      License,
+		Architecture: make(map[string]scoopArchitecture),
+		Bin:          "uniforge.exe",
+		Checkver:     "github",
+	}
+	autoupdate := make(map[string]any)
+	for goarch, name := range scoopArchitectures {
+		a, ok := m.Artifact("windows", goarch)
+		if !ok {
+			continue
+		}
+		manifest.Architecture[name] = scoopArchitecture{URL: a.URL, Hash: a.SHA256}
+		autoupdate[name] = map[string]string{"url": DownloadURL("$version", a.Name)}
+	}
+	if len(manifest.Architecture) == 0 {
+		return nil, errs.New(errs.NotFound, "release %s has no Windows archives", m.Version)
+	}
+	manifest.Autoupdate = map[string]any{"architecture": autoupdate}
+
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
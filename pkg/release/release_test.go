@@ -0,0 +1,143 @@
+package release
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testChecksums = `1111111111111111111111111111111111111111111111111111111111111111  uniforge_darwin_amd64.tar.gz
+2222222222222222222222222222222222222222222222222222222222222222  uniforge_darwin_arm64.tar.gz
+3333333333333333333333333333333333333333333333333333333333333333  uniforge_linux_amd64.tar.gz
+4444444444444444444444444444444444444444444444444444444444444444  uniforge_linux_arm64.tar.gz
+5555555555555555555555555555555555555555555555555555555555555555  uniforge_windows_amd64.zip
+6666666666666666666666666666666666666666666666666666666666666666  uniforge_1.2.0_sbom.json
+`
+
+func TestFromChecksums(t *testing.T) {
+	m, err := FromChecksums("v1.2.0", []byte(testChecksums), nil)
+	if err != nil {
+		t.Fatalf("FromChecksums() error = %v", err)
+	}
+	if m.Version != "1.2.0" {
+		t.Errorf("Version = %q, want 1.2.0", m.Version)
+	}
+	if len(m.Artifacts) != 5 {
+		t.Fatalf("got %d artifacts, want 5", len(m.Artifacts))
+	}
+
+	a, ok := m.Artifact("windows", "amd64")
+	if !ok {
+		t.Fatal("windows/amd64 artifact not found")
+	}
+	if want := "https://github.com/neptaco/uniforge/releases/download/v1.2.0/uniforge_windows_amd64.zip"; a.URL != want {
+		t.Errorf("URL = %q, want %q", a.URL, want)
+	}
+	if a.SHA256 != strings.Repeat("5", 64) {
+		t.Errorf("SHA256 = %q", a.SHA256)
+	}
+	if _, ok := m.Artifact("windows", "arm64"); ok {
+		t.Error("windows/arm64 artifact should not exist")
+	}
+}
+
+func TestFromChecksumsErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		checksums string
+	}{
+		{"missing version", "", testChecksums},
+		{"no archives", "1.2.0", "abc  notes.txt\n"},
+		{"bad checksum", "1.2.0", "abc  uniforge_linux_amd64.tar.gz\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromChecksums(tt.version, []byte(tt.checksums), nil); err == nil {
+				t.Error("FromChecksums() should fail")
+			}
+		})
+	}
+}
+
+func TestHomebrewFormula(t *testing.T) {
+	m, err := FromChecksums("1.2.0", []byte(testChecksums), func(name string) string {
+		return "https://example.com/" + name
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	formula, err := m.HomebrewFormula()
+	if err != nil {
+		t.Fatalf("HomebrewFormula() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`class Uniforge < Formula`,
+		`version "1.2.0"`,
+		`license "MIT"`,
+		"on_macos do\n    if Hardware::CPU.intel?\n      url \"https://example.com/uniforge_darwin_amd64.tar.gz\"\n      sha256 \"" + strings.Repeat("1", 64) + "\"",
+		"on_linux do",
+		"if Hardware::CPU.arm?\n      url \"https://example.com/uniforge_linux_arm64.tar.gz\"",
+		`bin.install "uniforge"`,
+	} {
+		if !strings.Contains(formula, want) {
+			t.Errorf("formula missing %q:\n%s", want, formula)
+		}
+	}
+	if strings.Contains(formula, "windows") {
+		t.Errorf("formula should not reference Windows archives:\n%s", formula)
+	}
+
+	windowsOnly := &Metadata{Version: "1.2.0", Artifacts: []Artifact{{Name: "uniforge_windows_amd64.zip", OS: "windows", Arch: "amd64"}}}
+	if _, err := windowsOnly.HomebrewFormula(); err == nil {
+		t.Error("HomebrewFormula() should fail without macOS or Linux archives")
+	}
+}
+
+func TestScoopManifest(t *testing.T) {
+	m, err := FromChecksums("1.2.0", []byte(testChecksums), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.ScoopManifest()
+	if err != nil {
+		t.Fatalf("ScoopManifest() error = %v", err)
+	}
+
+	var manifest struct {
+		Version      string `json:"version"`
+		License      string `json:"license"`
+		Bin          string `json:"bin"`
+		Architecture map[string]struct {
+			URL  string `json:"url"`
+			Hash string `json:"hash"`
+		} `json:"architecture"`
+		Autoupdate struct {
+			Architecture map[string]struct {
+				URL string `json:"url"`
+			} `json:"architecture"`
+		} `json:"autoupdate"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v\n%s", err, data)
+	}
+	if manifest.Version != "1.2.0" || manifest.License != "MIT" || manifest.Bin != "uniforge.exe" {
+		t.Errorf("unexpected manifest: %s", data)
+	}
+	if len(manifest.Architecture) != 1 {
+		t.Fatalf("got %d architectures, want 1: %s", len(manifest.Architecture), data)
+	}
+	x64 := manifest.Architecture["64bit"]
+	if x64.Hash != strings.Repeat("5", 64) || !strings.HasSuffix(x64.URL, "/v1.2.0/uniforge_windows_amd64.zip") {
+		t.Errorf("64bit = %+v", x64)
+	}
+	if got := manifest.Autoupdate.Architecture["64bit"].URL; !strings.HasSuffix(got, "/v$version/uniforge_windows_amd64.zip") {
+		t.Errorf("autoupdate url = %q", got)
+	}
+
+	unix := &Metadata{Version: "1.2.0", Artifacts: []Artifact{{Name: "uniforge_linux_amd64.tar.gz", OS: "linux", Arch: "amd64"}}}
+	if _, err := unix.ScoopManifest(); err == nil {
+		t.Error("ScoopManifest() should fail without Windows archives")
+	}
+}
@@ -0,0 +1,250 @@
+// Package sdk is the stable Go API of uniforge for tools that embed it as a
+// library instead of running the CLI.
+//
+// A Client reads only the locations given in its Options; DefaultOptions
+// detects them from the environment the same way the CLI does. The types here
+// are copies of uniforge's internal ones and keep their fields across releases.
+package sdk
+
+import (
+	"sort"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/unity"
+)
+
+// Options configures a Client
+type Options struct {
+	HubPath        string   // Unity Hub executable; "" disables Hub CLI fallbacks
+	HubDataDir     string   // Unity Hub's configuration directory (required)
+	EditorPaths    []string // Directories editors are installed in
+	CacheDir       string   // Directory for uniforge's release caches (required)
+	NoCache        bool     // Always fetch release metadata instead of using the cache
+	IncludeArchive bool     // Add old versions from the Unity download archive to Releases
+}
+
+// DefaultOptions returns the options the uniforge CLI uses on this machine
+func DefaultOptions() Options {
+	opts := hub.DefaultClientOptions()
+	return Options{
+		HubPath:     opts.HubPath,
+		HubDataDir:  opts.HubDataDir,
+		EditorPaths: opts.EditorPaths,
+		CacheDir:    opts.CacheDir,
+	}
+}
+
+// Client queries Unity releases, installed editors and projects. Installed
+// editors are read once and reused; call Refresh after installing editors.
+type Client struct {
+	hub *hub.Client
+}
+
+// New returns a Client using opts
+func New(opts Options) (*Client, error) {
+	if opts.HubDataDir == "" || opts.CacheDir == "" {
+		return nil, errs.WithHint(
+			errs.New(errs.Usage, "sdk: Options.HubDataDir and Options.CacheDir are required"),
+			"Start from sdk.DefaultOptions() and override what you need")
+	}
+
+	client := hub.NewClientWithOptions(hub.ClientOptions{
+		HubPath:     opts.HubPath,
+		HubDataDir:  opts.HubDataDir,
+		EditorPaths: opts.EditorPaths,
+		CacheDir:    opts.CacheDir,
+	})
+	client.NoCache = opts.NoCache
+	client.IncludeArchive = opts.IncludeArchive
+	return &Client{hub: client}, nil
+}
+
+// Refresh drops what the Client has read about installed editors
+func (c *Client) Refresh() {
+	c.hub.InvalidateEditors()
+}
+
+// Release is a Unity editor release
+type Release struct {
+	Version         string    `json:"version"`
+	Changeset       string    `json:"changeset,omitempty"`
+	Stream          string    `json:"stream,omitempty"` // "LTS", "TECH", "BETA", "ALPHA" or "SUPPORTED"
+	LTS             bool      `json:"lts"`
+	Recommended     bool      `json:"recommended,omitempty"`
+	ReleaseDate     time.Time `json:"releaseDate,omitzero"` // Zero if unknown
+	ReleaseNotesURL string    `json:"releaseNotesUrl,omitempty"`
+	SecurityAlert   string    `json:"securityAlert,omitempty"` // Empty unless the release has a security alert
+	Modules         []string  `json:"modules,omitempty"`       // IDs of the modules that can be installed with it
+	Installed       bool      `json:"installed"`
+	InstalledPath   string    `json:"installedPath,omitempty"`
+}
+
+// Releases returns the known Unity releases, newest first, with their install
+// status. Release metadata is cached in CacheDir and refetched once Unity
+// publishes new releases.
+func (c *Client) Releases() ([]Release, error) {
+	releases, err := c.fetchReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Release, 0, len(releases))
+	for _, r := range releases {
+		release := Release{
+			Version:         r.Version,
+			Changeset:       r.Changeset,
+			Stream:          r.Stream,
+			LTS:             r.LTS,
+			Recommended:     r.Recommended,
+			ReleaseDate:     r.ReleaseDate,
+			ReleaseNotesURL: r.ReleaseNotesURL,
+			SecurityAlert:   r.SecurityAlert,
+			Installed:       r.Installed,
+			InstalledPath:   r.InstalledPath,
+		}
+		for _, m := range r.Modules {
+			release.Modules = append(release.Modules, m.ID)
+		}
+		result = append(result, release)
+	}
+	return result, nil
+}
+
+func (c *Client) fetchReleases() ([]hub.UnityRelease, error) {
+	if !c.hub.NoCache {
+		if cache, err := c.hub.LoadCache(); err == nil && cache != nil {
+			if streams, err := c.hub.FetchStreams(); err == nil && c.hub.CheckCacheValidity(cache, streams) {
+				releases := c.hub.ConvertCacheToReleases(cache)
+				if c.hub.IncludeArchive {
+					releases = c.hub.MergeArchiveReleases(releases)
+				}
+				return c.hub.EnrichReleasesWithInstallStatus(releases), nil
+			}
+		}
+	}
+
+	releases, err := c.hub.GetAllReleases()
+	if err != nil {
+		return nil, err
+	}
+	if streams, _ := c.hub.FetchStreams(); len(streams) > 0 {
+		_ = c.hub.SaveCache(streams, releases)
+	}
+	return releases, nil
+}
+
+// ResolveVersion resolves a version spec such as "2022.3", "6000", "lts" or
+// "latest" to a full version. Installed editors are searched unless
+// includeAvailable is true, in which case every release is a candidate.
+func (c *Client) ResolveVersion(spec string, includeAvailable bool) (string, error) {
+	return c.hub.ResolveVersionSpec(spec, includeAvailable)
+}
+
+// Editor is an installed Unity editor
+type Editor struct {
+	Version      string   `json:"version"`
+	Path         string   `json:"path"`
+	Changeset    string   `json:"changeset,omitempty"`
+	Architecture string   `json:"architecture,omitempty"`
+	Modules      []string `json:"modules,omitempty"` // IDs of the installed modules
+}
+
+// InstalledEditors returns the installed editors, newest first
+func (c *Client) InstalledEditors() ([]Editor, error) {
+	editors, err := c.hub.ListInstalledEditors()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Editor, 0, len(editors))
+	for _, e := range editors {
+		editor := Editor{
+			Version:      e.Version,
+			Path:         e.Path,
+			Changeset:    e.Changeset,
+			Architecture: e.Architecture,
+			Modules:      e.InstalledModules,
+		}
+		if editor.Changeset == "" {
+			// Editors without version.txt report it when run
+			editor.Changeset = c.hub.GetEditorChangeset(e.Path)
+		}
+		result = append(result, editor)
+	}
+	sort.Slice(result, func(i, j int) bool { return hub.CompareVersions(result[i].Version, result[j].Version) > 0 })
+	return result, nil
+}
+
+// Project is a Unity project
+type Project struct {
+	Name         string    `json:"name"`
+	Path         string    `json:"path"`                   // Absolute path
+	UnityVersion string    `json:"unityVersion,omitempty"` // From ProjectSettings/ProjectVersion.txt, or Unity Hub's record
+	Changeset    string    `json:"changeset,omitempty"`    // Empty if ProjectVersion.txt does not record it
+	LastModified time.Time `json:"lastModified,omitzero"`
+}
+
+// Projects returns the projects registered in Unity Hub, most recently modified first
+func (c *Client) Projects() ([]Project, error) {
+	projects, err := c.hub.ListProjectsWithOptions(hub.ProjectListOptions{ProjectVersions: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Project, 0, len(projects))
+	for _, p := range projects {
+		result = append(result, Project{
+			Name:         p.Title,
+			Path:         p.Path,
+			UnityVersion: p.Version,
+			LastModified: p.LastModified,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].LastModified.Equal(result[j].LastModified) {
+			return result[i].LastModified.After(result[j].LastModified)
+		}
+		return result[i].Path < result[j].Path
+	})
+	return result, nil
+}
+
+// FindProject returns the Unity project containing dir, which may be the
+// project root or any directory below it
+func FindProject(dir string) (*Project, error) {
+	root, err := unity.FindProjectRoot(dir)
+	if err != nil {
+		return nil, errs.New(errs.NotFound, "%w", err)
+	}
+	return LoadProject(root)
+}
+
+// LoadProject reads the Unity project at path
+func LoadProject(path string) (*Project, error) {
+	project, err := unity.LoadProject(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Project{
+		Name:         project.Name,
+		Path:         project.Path,
+		UnityVersion: project.UnityVersion,
+		Changeset:    project.Changeset,
+	}, nil
+}
+
+// Version is a parsed Unity version
+type Version = unity.Version
+
+// ParseVersion parses a full Unity version such as "2022.3.60f1"
+func ParseVersion(s string) (Version, error) {
+	return unity.ParseVersion(s)
+}
+
+// CompareVersions compares two possibly partial Unity versions, returning >0
+// if a is newer than b, <0 if it is older and 0 if they are equal
+func CompareVersions(a, b string) int {
+	return hub.CompareVersions(a, b)
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewRequiresLocations(t *testing.T) {
+	_, err := New(Options{CacheDir: t.TempDir()})
+	if !errors.Is(err, errs.Usage) {
+		t.Errorf("New() error = %v, want Usage", err)
+	}
+}
+
+func TestClient(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses the Linux editor layout")
+	}
+	// HOME points nowhere useful, so anything read from it would be noticed
+	t.Setenv("HOME", t.TempDir())
+
+	editors := t.TempDir()
+	for _, version := range []string{"2022.3.10f1", "6000.0.23f1"} {
+		writeFile(t, filepath.Join(editors, version, "Editor", "Unity"), "#!/bin/sh\n")
+		writeFile(t, filepath.Join(editors, version, "Editor", "Data", "Resources", "version.txt"), version+" (abc123def456)\n")
+	}
+	if err := os.MkdirAll(filepath.Join(editors, "2022.3.10f1", "Editor", "Data", "PlaybackEngines", "WebGLSupport"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	projectDir := filepath.Join(t.TempDir(), "Game")
+	writeFile(t, filepath.Join(projectDir, "ProjectSettings", "ProjectVersion.txt"),
+		"m_EditorVersion: 2022.3.10f1\nm_EditorVersionWithRevision: 2022.3.10f1 (abc123def456)\n")
+	hubData := t.TempDir()
+	writeFile(t, filepath.Join(hubData, "projects-v1.json"), `{"schema_version": "v1", "data": {
+		"a": {"title": "Old", "path": "/work/old", "version": "2021.3.1f1", "lastModified": 1600000000000},
+		"b": {"title": "Game", "path": "`+projectDir+`", "version": "2022.3.10f1", "lastModified": 1700000000000}
+	}}`)
+
+	client, err := New(Options{HubDataDir: hubData, EditorPaths: []string{editors}, CacheDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	installed, err := client.InstalledEditors()
+	if err != nil {
+		t.Fatalf("InstalledEditors() error = %v", err)
+	}
+	if len(installed) != 2 || installed[0].Version != "6000.0.23f1" {
+		t.Fatalf("InstalledEditors() = %+v", installed)
+	}
+	if e := installed[1]; e.Changeset != "abc123def456" || len(e.Modules) != 1 || e.Modules[0] != "webgl" {
+		t.Errorf("2022.3.10f1 = %+v", e)
+	}
+
+	if version, err := client.ResolveVersion("2022", false); err != nil || version != "2022.3.10f1" {
+		t.Errorf("ResolveVersion(2022) = %q, %v", version, err)
+	}
+
+	projects, err := client.Projects()
+	if err != nil {
+		t.Fatalf("Projects() error = %v", err)
+	}
+	if len(projects) != 2 || projects[0].Name != "Game" || projects[1].Name != "Old" {
+		t.Errorf("Projects() = %+v", projects)
+	}
+
+	project, err := FindProject(filepath.Join(projectDir, "Assets", "Scripts"))
+	if err != nil {
+		t.Fatalf("FindProject() error = %v", err)
+	}
+	if project.Path != projectDir || project.UnityVersion != "2022.3.10f1" || project.Changeset != "abc123def456" {
+		t.Errorf("FindProject() = %+v", project)
+	}
+	if _, err := FindProject(t.TempDir()); !errors.Is(err, errs.NotFound) {
+		t.Errorf("FindProject() outside a project error = %v, want NotFound", err)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	v, err := ParseVersion("2021.3.14f1c1")
+	if err != nil || v.Major != 2021 || v.China != "c" {
+		t.Errorf("ParseVersion() = %+v, %v", v, err)
+	}
+	if CompareVersions("2019.4.1p1", "2019.4.1f1") <= 0 {
+		t.Error("patch release should be newer than the final release")
+	}
+}
@@ -0,0 +1,357 @@
+// Package selfupdate checks GitHub for newer uniforge releases and replaces
+// the running binary with the release built for this platform.
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/semver"
+	"github.com/neptaco/uniforge/pkg/trace"
+)
+
+// Repository is the GitHub repository uniforge is released from
+const Repository = "neptaco/uniforge"
+
+// defaultAPIBase is the GitHub REST API
+const defaultAPIBase = "https://api.github.com"
+
+// ChecksumsAsset is the goreleaser checksum file published with every release
+const ChecksumsAsset = "checksums.txt"
+
+// Release is a published uniforge release
+type Release struct {
+	Version string  // Without the "v" of the tag, e.g. "1.4.0"
+	URL     string  // Release page
+	Assets  []Asset // Downloadable files
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string
+	URL  string // Download URL
+	Size int64
+}
+
+// Checker looks up uniforge releases
+type Checker struct {
+	APIBase    string // GitHub API base URL; "" uses api.github.com
+	Repository string // "owner/name"; "" uses Repository
+	Token      string // GitHub token to raise the rate limit; optional
+	HTTPClient *http.Client
+}
+
+// NewChecker returns a Checker for the uniforge releases on GitHub, using
+// GITHUB_TOKEN when it is set
+func NewChecker() *Checker {
+	return &Checker{
+		Token:      os.Getenv("GITHUB_TOKEN"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// githubRelease is the part of the GitHub release API response uniforge reads
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+		Size               int64  `json:"size"`
+	} `json:"assets"`
+}
+
+// Latest returns the newest release that is not a draft or pre-release
+func (c *Checker) Latest(ctx context.Context) (*Release, error) {
+	return c.fetchRelease(ctx, "latest", "the latest release")
+}
+
+// Tagged returns the release of a version, e.g. "1.4.0"
+func (c *Checker) Tagged(ctx context.Context, version string) (*Release, error) {
+	tag := "v" + strings.TrimPrefix(version, "v")
+	return c.fetchRelease(ctx, "tags/"+tag, "release "+tag)
+}
+
+// fetchRelease reads /repos/<repository>/releases/<path> from the GitHub API
+func (c *Checker) fetchRelease(ctx context.Context, path, what string) (*Release, error) {
+	span := trace.Start(trace.KindHTTP, "github release", "release", path)
+	defer span.End()
+
+	url := fmt.Sprintf("%s/repos/%s/releases/%s", cmp.Or(c.APIBase, defaultAPIBase), cmp.Or(c.Repository, Repository), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		span.Fail(err)
+		return nil, errs.New(errs.NetworkUnavailable, "failed to fetch %s: %w", what, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		span.Fail(errs.NotFound)
+		return nil, errs.New(errs.NotFound, "%s not found in %s", what, cmp.Or(c.Repository, Repository))
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("failed to fetch %s: GitHub returned %s", what, resp.Status)
+		span.Fail(err)
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, errs.WithHint(err, "the GitHub API rate limit may be exhausted; set GITHUB_TOKEN to raise it")
+		}
+		return nil, err
+	}
+
+	var gh githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&gh); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", what, err)
+	}
+	release := &Release{Version: strings.TrimPrefix(gh.TagName, "v"), URL: gh.HTMLURL}
+	for _, a := range gh.Assets {
+		release.Assets = append(release.Assets, Asset{Name: a.Name, URL: a.BrowserDownloadURL, Size: a.Size})
+	}
+	return release, nil
+}
+
+// IsDevelopment reports whether version is a development build ("dev", or
+// anything else that is not a version)
+func IsDevelopment(version string) bool {
+	return !semver.Valid(version)
+}
+
+// IsNewer reports whether latest is a newer version than current. Development
+// builds are never outdated.
+func IsNewer(latest, current string) bool {
+	return !IsDevelopment(current) && semver.Compare(latest, current) > 0
+}
+
+// AssetName returns the name of the release archive for a platform, as
+// published by goreleaser (see .goreleaser.yml)
+func AssetName(goos, goarch string) string {
+	return fmt.Sprintf("uniforge_%s_%s.tar.gz", goos, goarch)
+}
+
+// Asset returns the release asset with the given name
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Apply downloads the release archive for this platform, verifies it against
+// the release checksums, and replaces the executable at exe with its binary.
+// The new binary is written next to exe and renamed over it, so exe is never
+// left half-written.
+func (c *Checker) Apply(ctx context.Context, release *Release, exe string) error {
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	asset, ok := release.Asset(name)
+	if !ok {
+		// Archives may also be published as zip
+		zipName := strings.TrimSuffix(name, ".tar.gz") + ".zip"
+		if asset, ok = release.Asset(zipName); !ok {
+			return errs.New(errs.NotFound, "release %s has no build for %s/%s (%s)", release.Version, runtime.GOOS, runtime.GOARCH, name)
+		}
+	}
+
+	archive, err := c.Download(ctx, asset.URL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	// Never install a binary that cannot be verified
+	sums, ok := release.Asset(ChecksumsAsset)
+	if !ok {
+		return errs.WithHint(
+			errs.New(errs.NotFound, "release %s has no %s to verify %s with", release.Version, ChecksumsAsset, asset.Name),
+			"Download the release manually or update with your package manager.")
+	}
+	data, err := c.Download(ctx, sums.URL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", ChecksumsAsset, err)
+	}
+	if err := verifyChecksum(data, asset.Name, archive); err != nil {
+		return err
+	}
+
+	binary, err := extractBinary(asset.Name, archive)
+	if err != nil {
+		return err
+	}
+	return replaceExecutable(exe, binary)
+}
+
+// Download returns the content of a release asset
+func (c *Checker) Download(ctx context.Context, url string) ([]byte, error) {
+	span := trace.Start(trace.KindHTTP, "download", "url", url)
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		span.Fail(err)
+		return nil, errs.New(errs.NetworkUnavailable, "%w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("server returned %s", resp.Status)
+		span.Fail(err)
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// verifyChecksum checks data against the SHA-256 listed for name in a
+// "<sha256>  <name>" checksum file
+func verifyChecksum(sums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[1] != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, fields[0])
+		}
+		return nil
+	}
+	return fmt.Errorf("%s is not listed in %s", name, ChecksumsAsset)
+}
+
+// binaryName is the name of the uniforge executable in release archives
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "uniforge.exe"
+	}
+	return "uniforge"
+}
+
+// extractBinary returns the uniforge executable in a .tar.gz or .zip release archive
+func extractBinary(archiveName string, archive []byte) ([]byte, error) {
+	want := binaryName()
+	if strings.HasSuffix(archiveName, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archiveName, err)
+		}
+		for _, f := range zr.File {
+			if filepath.Base(f.Name) != want || f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer func() { _ = rc.Close() }()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("%s does not contain %s", archiveName, want)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", archiveName, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s does not contain %s", archiveName, want)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archiveName, err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == want {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceExecutable swaps the executable at exe for binary. The running
+// executable cannot be overwritten on Windows, so it is moved aside to
+// "<exe>.old" first (and removed by the next update).
+func replaceExecutable(exe string, binary []byte) error {
+	dir := filepath.Dir(exe)
+	tmp, err := os.CreateTemp(dir, ".uniforge-update-*")
+	if err != nil {
+		return errs.WithHint(fmt.Errorf("cannot write to %s: %w", dir, err),
+			"install the update with the tool that installed uniforge, or run with permission to write there")
+	}
+	tmpPath := tmp.Name()
+	success := false
+	defer func() {
+		if !success {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		old := exe + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return fmt.Errorf("failed to move %s aside: %w", exe, err)
+		}
+		if err := os.Rename(tmpPath, exe); err != nil {
+			_ = os.Rename(old, exe)
+			return fmt.Errorf("failed to replace %s: %w", exe, err)
+		}
+		success = true
+		return nil
+	}
+
+	if err := os.Rename(tmpPath, exe); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", exe, err)
+	}
+	success = true
+	return nil
+}
+
+// PackageManager returns the command that upgrades uniforge when exe was
+// installed by Homebrew or Scoop, which should then do the update, or ""
+func PackageManager(exe string) string {
+	path := filepath.ToSlash(exe)
+	switch {
+	case strings.Contains(path, "/Cellar/") || strings.Contains(path, "/homebrew/") || strings.Contains(path, "/linuxbrew/"):
+		return "brew upgrade uniforge"
+	case strings.Contains(path, "/scoop/apps/"):
+		return "scoop update uniforge"
+	}
+	return ""
+}
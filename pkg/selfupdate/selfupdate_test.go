@@ -0,0 +1,155 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	if IsNewer("9.9.9", "dev") {
+		t.Error("development builds should never be outdated")
+	}
+	if !IsNewer("1.4.0", "1.3.2") || IsNewer("1.3.2", "1.3.2") {
+		t.Error("IsNewer() compared wrongly")
+	}
+}
+
+// fakeGitHub serves a latest release with an archive of binary for this platform
+func fakeGitHub(t *testing.T, binary string, badChecksum bool) *httptest.Server {
+	t.Helper()
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"README.md": "readme", binaryName(): binary} {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+
+	assetName := AssetName(runtime.GOOS, runtime.GOARCH)
+	sum := sha256.Sum256(archive.Bytes())
+	checksum := hex.EncodeToString(sum[:])
+	if badChecksum {
+		checksum = strings.Repeat("0", 64)
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/neptaco/uniforge/releases/latest":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"tag_name": "v1.4.0",
+				"html_url": "https://github.com/neptaco/uniforge/releases/tag/v1.4.0",
+				"assets": []map[string]any{
+					{"name": assetName, "browser_download_url": server.URL + "/download/" + assetName},
+					{"name": "checksums.txt", "browser_download_url": server.URL + "/download/checksums.txt"},
+				},
+			})
+		case "/download/" + assetName:
+			_, _ = w.Write(archive.Bytes())
+		case "/download/checksums.txt":
+			fmt.Fprintf(w, "%s  uniforge_plan9_386.tar.gz\n%s  %s\n", strings.Repeat("1", 64), checksum, assetName)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestApply(t *testing.T) {
+	server := fakeGitHub(t, "new binary", false)
+	checker := &Checker{APIBase: server.URL, HTTPClient: server.Client()}
+
+	release, err := checker.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if release.Version != "1.4.0" || len(release.Assets) != 2 {
+		t.Fatalf("release = %+v", release)
+	}
+
+	exe := filepath.Join(t.TempDir(), binaryName())
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Apply(context.Background(), release, exe); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new binary" {
+		t.Errorf("executable = %q, want the new binary", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".uniforge-update-") {
+			t.Errorf("temporary file left behind: %s", e.Name())
+		}
+	}
+}
+
+func TestApplyChecksumMismatch(t *testing.T) {
+	server := fakeGitHub(t, "tampered binary", true)
+	checker := &Checker{APIBase: server.URL, HTTPClient: server.Client()}
+	release, err := checker.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exe := filepath.Join(t.TempDir(), binaryName())
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Apply(context.Background(), release, exe); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Apply() error = %v, want a checksum mismatch", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old binary" {
+		t.Errorf("executable was replaced: %q", data)
+	}
+}
+
+func TestApplyWithoutChecksums(t *testing.T) {
+	server := fakeGitHub(t, "unverified binary", false)
+	checker := &Checker{APIBase: server.URL, HTTPClient: server.Client()}
+	release, err := checker.Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release.Assets = release.Assets[:1] // Only the archive
+
+	exe := filepath.Join(t.TempDir(), binaryName())
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Apply(context.Background(), release, exe); err == nil || !strings.Contains(err.Error(), ChecksumsAsset) {
+		t.Errorf("Apply() error = %v, want an error about the missing checksums", err)
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old binary" {
+		t.Errorf("executable was replaced: %q", data)
+	}
+}
+
+func TestPackageManager(t *testing.T) {
+	if got := PackageManager("/opt/homebrew/Cellar/uniforge/1.3.0/bin/uniforge"); got != "brew upgrade uniforge" {
+		t.Errorf("Homebrew: %q", got)
+	}
+	if got := PackageManager(`C:\Users\dev\scoop\apps\uniforge\current\uniforge.exe`); runtime.GOOS == "windows" && got != "scoop update uniforge" {
+		t.Errorf("Scoop: %q", got)
+	}
+	if got := PackageManager("/usr/local/bin/uniforge"); got != "" {
+		t.Errorf("plain install: %q", got)
+	}
+}
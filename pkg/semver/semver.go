@@ -0,0 +1,85 @@
+// Package semver compares semantic versions, as used by uniforge releases and
+// Unity packages.
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compare compares two semantic versions such as "1.2.3", "v1.3.0" or
+// "1.3.0-rc.1". Pre-release identifiers are compared one by one, numerically
+// when both are numbers, and build metadata ("+build.5") is ignored.
+// Returns >0 if a > b, <0 if a < b, 0 if equal.
+func Compare(a, b string) int {
+	va, _ := parse(a)
+	vb, _ := parse(b)
+	for i := range va.core {
+		if va.core[i] != vb.core[i] {
+			return va.core[i] - vb.core[i]
+		}
+	}
+	// A release sorts after its pre-releases
+	switch {
+	case va.pre == "" && vb.pre == "":
+		return 0
+	case va.pre == "":
+		return 1
+	case vb.pre == "":
+		return -1
+	}
+	idsA, idsB := strings.Split(va.pre, "."), strings.Split(vb.pre, ".")
+	for i := 0; i < len(idsA) && i < len(idsB); i++ {
+		x, errX := strconv.Atoi(idsA[i])
+		y, errY := strconv.Atoi(idsB[i])
+		switch {
+		case errX == nil && errY == nil:
+			if x != y {
+				return x - y
+			}
+		case errX == nil:
+			// Numeric identifiers sort before alphanumeric ones
+			return -1
+		case errY == nil:
+			return 1
+		default:
+			if c := strings.Compare(idsA[i], idsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return len(idsA) - len(idsB)
+}
+
+// Valid reports whether s is a version Compare understands, with at most
+// three numeric core parts
+func Valid(s string) bool {
+	_, ok := parse(s)
+	return ok
+}
+
+type version struct {
+	core [3]int
+	pre  string
+}
+
+// parse parses "v1.2.3-pre+build"; missing minor and patch are 0
+func parse(s string) (version, bool) {
+	var v version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, _ := strings.Cut(s, "-")
+	v.pre = pre
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.core[i] = n
+	}
+	return v, true
+}
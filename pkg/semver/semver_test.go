@@ -0,0 +1,42 @@
+package semver
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // sign
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.3.0", "1.2.9", 1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0", "1.0.1", -1},
+		{"2", "1.9.9", 1},
+		{"1.3.0-rc.1", "1.3.0", -1},
+		{"1.3.0-rc.2", "1.3.0-rc.10", -1},
+		{"1.3.0-beta", "1.3.0-alpha", 1},
+		{"2.0.0-pre.1", "2.0.0-pre.1.1", -1},
+		{"2.0.0-1", "2.0.0-pre", -1},
+		{"1.3.0+build.5", "1.3.0", 0},
+		{"1.3.0-exp.2+build.9", "1.3.0-exp.10", -1},
+	}
+	for _, tt := range tests {
+		got := Compare(tt.a, tt.b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("Compare(%q, %q) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, s := range []string{"1.2.3", "v1.3.0-rc.1", "2", "1.3.0+build.5"} {
+		if !Valid(s) {
+			t.Errorf("Valid(%q) = false", s)
+		}
+	}
+	for _, s := range []string{"dev", "1.2.3.4", "", "1.x"} {
+		if Valid(s) {
+			t.Errorf("Valid(%q) = true", s)
+		}
+	}
+}
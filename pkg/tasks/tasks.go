@@ -0,0 +1,291 @@
+// Package tasks runs the project tasks defined in .uniforge/tasks.yaml: named
+// lists of uniforge commands, shell commands and other tasks
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/unity"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile defines the tasks of a project, inside unity.ProjectConfigDir
+const ConfigFile = "tasks.yaml"
+
+// Config is the contents of .uniforge/tasks.yaml
+type Config struct {
+	Env   map[string]string `yaml:"env"` // Environment of every task
+	Tasks map[string]*Task  `yaml:"tasks"`
+}
+
+// Task is a named list of steps
+type Task struct {
+	Description string            `yaml:"description"`
+	Env         map[string]string `yaml:"env"` // Added to the environment of the task's steps
+	Steps       []Step            `yaml:"steps"`
+}
+
+// Step is one command of a task. Exactly one field is set.
+type Step struct {
+	Uniforge Args   `yaml:"uniforge"` // uniforge arguments, e.g. "build --profile android"
+	Run      string `yaml:"run"`      // Shell command (sh -c, or cmd /C on Windows)
+	Task     string `yaml:"task"`     // Another task to run at this point
+}
+
+// Args is a command line given as a string, split like a shell does, or as a list
+type Args []string
+
+// UnmarshalYAML accepts a string or a list of strings
+func (a *Args) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		args, err := splitArgs(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*a = args
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// String returns the step as it is shown while running
+func (s Step) String() string {
+	switch {
+	case s.Uniforge != nil:
+		return "uniforge " + strings.Join(s.Uniforge, " ")
+	case s.Run != "":
+		return s.Run
+	}
+	return "task " + s.Task
+}
+
+// ConfigPath returns the path of the tasks.yaml of a project
+func ConfigPath(projectPath string) string {
+	return filepath.Join(projectPath, unity.ProjectConfigDir, ConfigFile)
+}
+
+// Load reads the tasks.yaml of the project at projectPath
+func Load(projectPath string) (*Config, error) {
+	path := ConfigPath(projectPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errs.WithHint(
+				errs.New(errs.NotFound, "no tasks defined: %s not found", path),
+				"Define tasks in .uniforge/tasks.yaml, e.g.\n  tasks:\n    check:\n      steps:\n        - uniforge: meta check\n        - run: ./scripts/lint.sh",
+			)
+		}
+		return nil, err
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// validate checks that every step sets exactly one command and names existing tasks
+func (c *Config) validate() error {
+	for _, name := range c.Names() {
+		task := c.Tasks[name]
+		if task == nil || len(task.Steps) == 0 {
+			return fmt.Errorf("task %s has no steps", name)
+		}
+		for i, step := range task.Steps {
+			set := 0
+			for _, ok := range []bool{step.Uniforge != nil, step.Run != "", step.Task != ""} {
+				if ok {
+					set++
+				}
+			}
+			if set != 1 {
+				return fmt.Errorf("step %d of task %s must set exactly one of uniforge, run or task", i+1, name)
+			}
+			if step.Task != "" && c.Tasks[step.Task] == nil {
+				return fmt.Errorf("step %d of task %s runs unknown task %s", i+1, name, step.Task)
+			}
+		}
+	}
+	return nil
+}
+
+// Names returns the task names, sorted
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Tasks))
+	for name := range c.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlannedStep is a uniforge or shell step of a task, with nested tasks expanded
+type PlannedStep struct {
+	Task string // Task the step belongs to
+	Step Step
+	Env  []map[string]string // Environment from tasks.yaml: the global env, then the task's
+}
+
+// Plan returns the steps running task name executes, in order
+func (c *Config) Plan(name string) ([]PlannedStep, error) {
+	if c.Tasks[name] == nil {
+		return nil, errs.WithHint(
+			errs.New(errs.NotFound, "unknown task: %s", name),
+			"Defined tasks: "+strings.Join(c.Names(), ", "),
+		)
+	}
+	var steps []PlannedStep
+	if err := c.plan(name, nil, &steps); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+func (c *Config) plan(name string, stack []string, steps *[]PlannedStep) error {
+	for _, parent := range stack {
+		if parent == name {
+			return fmt.Errorf("task cycle: %s -> %s", strings.Join(stack, " -> "), name)
+		}
+	}
+	stack = append(stack, name)
+
+	task := c.Tasks[name]
+	env := []map[string]string{c.Env, task.Env}
+	for _, step := range task.Steps {
+		if step.Task != "" {
+			if err := c.plan(step.Task, stack, steps); err != nil {
+				return err
+			}
+			continue
+		}
+		*steps = append(*steps, PlannedStep{Task: name, Step: step, Env: env})
+	}
+	return nil
+}
+
+// Runner executes tasks in a project
+type Runner struct {
+	Config      *Config
+	ProjectPath string   // Steps run in this folder
+	Executable  string   // uniforge binary for uniforge steps
+	Env         []string // KEY=VALUE added to the process environment, below the tasks.yaml env
+	Stdout      io.Writer
+	Stderr      io.Writer
+
+	OnStep func(i, total int, step PlannedStep) // Called before each step
+}
+
+// Run executes task name, stopping at the first failing step
+func (r *Runner) Run(name string) error {
+	steps, err := r.Config.Plan(name)
+	if err != nil {
+		return err
+	}
+	for i, step := range steps {
+		if r.OnStep != nil {
+			r.OnStep(i, len(steps), step)
+		}
+		if err := r.runStep(step); err != nil {
+			return fmt.Errorf("task %s: step %d/%d (%s) failed: %w", name, i+1, len(steps), step.Step, err)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) runStep(step PlannedStep) error {
+	env := Environ(append(os.Environ(), r.Env...), step.Env...)
+
+	var cmd *exec.Cmd
+	if step.Step.Uniforge != nil {
+		lookup := unity.EnvLookup(env)
+		args := make([]string, len(step.Step.Uniforge))
+		for i, arg := range step.Step.Uniforge {
+			args[i] = os.Expand(arg, lookup)
+		}
+		cmd = exec.Command(r.Executable, args...)
+	} else if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", step.Step.Run)
+	} else {
+		cmd = exec.Command("sh", "-c", step.Step.Run)
+	}
+	cmd.Dir = r.ProjectPath
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+	return cmd.Run()
+}
+
+// Environ returns base (KEY=VALUE) with the variables of each layer set in
+// turn. Values may refer to variables of base and earlier layers as $NAME or
+// ${NAME}; $$ is a literal $.
+func Environ(base []string, layers ...map[string]string) []string {
+	result := append([]string(nil), base...)
+	for _, layer := range layers {
+		names := make([]string, 0, len(layer))
+		for name := range layer {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		lookup := unity.EnvLookup(result)
+		for _, name := range names {
+			result = append(result, name+"="+os.Expand(layer[name], lookup))
+		}
+	}
+	return result
+}
+
+// splitArgs splits a command line at spaces outside single or double quotes
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
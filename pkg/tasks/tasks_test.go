@@ -0,0 +1,186 @@
+package tasks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/unity"
+)
+
+func writeTasks(t *testing.T, content string) string {
+	t.Helper()
+	projectPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectPath, unity.ProjectConfigDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(projectPath), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectPath
+}
+
+const testTasks = `
+env:
+  BUILD_DIR: Builds
+tasks:
+  check:
+    description: Checks
+    steps:
+      - uniforge: meta check
+      - uniforge: [project, compile, --timeout, "600"]
+  build:
+    env:
+      OUTPUT: ${BUILD_DIR}/android
+    steps:
+      - task: check
+      - uniforge: build --profile "android dev" -o $OUTPUT
+      - run: echo done
+`
+
+func TestLoadAndPlan(t *testing.T) {
+	config, err := Load(writeTasks(t, testTasks))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := strings.Join(config.Names(), ","); got != "build,check" {
+		t.Errorf("Names() = %s", got)
+	}
+
+	steps, err := config.Plan("build")
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	var got []string
+	for _, s := range steps {
+		got = append(got, s.Task+": "+s.Step.String())
+	}
+	want := []string{
+		"check: uniforge meta check",
+		"check: uniforge project compile --timeout 600",
+		"build: uniforge build --profile android dev -o $OUTPUT",
+		"build: echo done",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Plan() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if args := steps[2].Step.Uniforge; len(args) != 5 || args[2] != "android dev" {
+		t.Errorf("quoted argument not kept together: %q", args)
+	}
+
+	if _, err := config.Plan("deploy"); errs.CategoryOf(err) != errs.NotFound {
+		t.Errorf("Plan() of an unknown task error = %v", err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no steps":      "tasks:\n  a:\n    description: nothing\n",
+		"two commands":  "tasks:\n  a:\n    steps:\n      - run: ls\n        task: a\n",
+		"unknown task":  "tasks:\n  a:\n    steps:\n      - task: b\n",
+		"open quote":    "tasks:\n  a:\n    steps:\n      - uniforge: build --profile \"x\n",
+		"empty command": "tasks:\n  a:\n    steps:\n      - run: \"\"\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(writeTasks(t, content)); err == nil {
+				t.Error("Load() should fail")
+			}
+		})
+	}
+
+	if _, err := Load(t.TempDir()); errs.CategoryOf(err) != errs.NotFound {
+		t.Errorf("Load() without tasks.yaml error = %v", err)
+	}
+}
+
+func TestPlanCycle(t *testing.T) {
+	config, err := Load(writeTasks(t, "tasks:\n  a:\n    steps:\n      - task: b\n  b:\n    steps:\n      - task: a\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = config.Plan("a")
+	if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("Plan() error = %v, want a cycle", err)
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	env := Environ([]string{"HOME=/home/me", "BUILD_DIR=old"},
+		map[string]string{"BUILD_DIR": "$HOME/Builds"},
+		map[string]string{"OUTPUT": "${BUILD_DIR}/android", "EMPTY": "$UNSET", "PRICE": "$$5"},
+	)
+	lookup := unity.EnvLookup(env)
+	if got := lookup("OUTPUT"); got != "/home/me/Builds/android" {
+		t.Errorf("OUTPUT = %q", got)
+	}
+	if got := lookup("PRICE"); got != "$5" {
+		t.Errorf("PRICE = %q", got)
+	}
+	if got := lookup("EMPTY"); got != "" {
+		t.Errorf("EMPTY = %q", got)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("steps use sh")
+	}
+	projectPath := writeTasks(t, `
+env:
+  GREETING: hello
+tasks:
+  greet:
+    steps:
+      - uniforge: -c 'echo "$$0 $$1"' $GREETING $PROJECT_PATH
+      - run: echo "$GREETING from $(basename "$PWD")" > out.txt
+  fail:
+    steps:
+      - run: exit 3
+      - run: echo unreachable > out.txt
+`)
+	config, err := Load(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	var started []string
+	runner := &Runner{
+		Config:      config,
+		ProjectPath: projectPath,
+		Executable:  "sh", // Stands in for uniforge
+		Env:         []string{"PROJECT_PATH=" + projectPath},
+		Stdout:      &stdout,
+		Stderr:      &stdout,
+		OnStep: func(i, total int, step PlannedStep) {
+			started = append(started, step.Step.String())
+		},
+	}
+
+	if err := runner.Run("greet"); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "hello "+projectPath {
+		t.Errorf("uniforge step output = %q", got)
+	}
+	data, _ := os.ReadFile(filepath.Join(projectPath, "out.txt"))
+	if got := strings.TrimSpace(string(data)); got != "hello from "+filepath.Base(projectPath) {
+		t.Errorf("run step output = %q", got)
+	}
+	if len(started) != 2 {
+		t.Errorf("OnStep calls = %v", started)
+	}
+
+	err = runner.Run("fail")
+	if err == nil || !strings.Contains(err.Error(), "step 1/2 (exit 3)") {
+		t.Errorf("Run() error = %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(projectPath, "out.txt"))
+	if strings.Contains(string(data), "unreachable") {
+		t.Error("steps after a failure should not run")
+	}
+}
@@ -0,0 +1,82 @@
+// Package telemetry reports the outcome of uniforge commands to an endpoint
+// run by the user's organization. It is opt-in: nothing is sent unless the
+// telemetry setting is enabled and an endpoint is configured.
+//
+// Events are anonymous. They carry the command path, outcome, duration, error
+// category and platform, but never arguments, paths, error messages, host or
+// user names.
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// Timeout bounds how long sending one event may take
+const Timeout = 3 * time.Second
+
+// Event is the outcome of one command
+type Event struct {
+	Command       string    `json:"command"` // e.g. "uniforge editor install"
+	Success       bool      `json:"success"`
+	ExitCode      int       `json:"exitCode"`
+	ErrorCategory string    `json:"errorCategory,omitempty"` // errs category, or "error" when uncategorized
+	DurationMS    int64     `json:"durationMs"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	Version       string    `json:"version"` // uniforge version
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// NewEvent describes a command that started at start and ended with exitCode.
+// Only the category of err is kept.
+func NewEvent(command, version string, start time.Time, exitCode int, err error) Event {
+	now := time.Now()
+	event := Event{
+		Command:    command,
+		Success:    exitCode == errs.ExitOK && err == nil,
+		ExitCode:   exitCode,
+		DurationMS: now.Sub(start).Milliseconds(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Version:    version,
+		Timestamp:  now.UTC(),
+	}
+	if !event.Success {
+		event.ErrorCategory = "error"
+		if c := errs.CategoryOf(err); c != nil {
+			event.ErrorCategory = c.Error()
+		}
+	}
+	return event
+}
+
+// Send posts event as JSON to endpoint
+func Send(endpoint string, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry event: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid telemetry endpoint: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send telemetry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to send telemetry: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestNewEvent(t *testing.T) {
+	start := time.Now().Add(-1500 * time.Millisecond)
+
+	ok := NewEvent("uniforge editor list", "1.2.0", start, errs.ExitOK, nil)
+	if !ok.Success || ok.ErrorCategory != "" {
+		t.Errorf("success event = %+v", ok)
+	}
+	if ok.DurationMS < 1500 {
+		t.Errorf("DurationMS = %d, want >= 1500", ok.DurationMS)
+	}
+	if ok.OS != runtime.GOOS || ok.Arch != runtime.GOARCH || ok.Version != "1.2.0" {
+		t.Errorf("platform = %s/%s %s", ok.OS, ok.Arch, ok.Version)
+	}
+
+	err := fmt.Errorf("failed to install Unity Editor: %w", errs.New(errs.NetworkUnavailable, "GET /home/alice/secret: timeout"))
+	failed := NewEvent("uniforge editor install", "1.2.0", start, errs.ExitCode(err), err)
+	if failed.Success || failed.ExitCode != errs.ExitNetworkUnavailable {
+		t.Errorf("failure event = %+v", failed)
+	}
+	if failed.ErrorCategory != "network unavailable" {
+		t.Errorf("ErrorCategory = %q, want network unavailable", failed.ErrorCategory)
+	}
+
+	plain := NewEvent("uniforge build", "1.2.0", start, errs.ExitGeneral, errors.New("boom"))
+	if plain.ErrorCategory != "error" {
+		t.Errorf("ErrorCategory = %q, want error", plain.ErrorCategory)
+	}
+
+	// Commands that exit with a code but no error still fail
+	exited := NewEvent("uniforge test", "1.2.0", start, 2, nil)
+	if exited.Success || exited.ErrorCategory != "error" {
+		t.Errorf("exit-code event = %+v", exited)
+	}
+}
+
+func TestSend(t *testing.T) {
+	var received Event
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		if err := json.Unmarshal(data, &received); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := errs.New(errs.NotFound, "project /home/alice/Game not found")
+	event := NewEvent("uniforge open", "1.2.0", time.Now(), errs.ExitCode(err), err)
+	if err := Send(server.URL, event); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if received.Command != "uniforge open" || received.ErrorCategory != "not found" {
+		t.Errorf("received %+v", received)
+	}
+	if strings.Contains(body, "alice") {
+		t.Errorf("event leaks the error message: %s", body)
+	}
+}
+
+func TestSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := Send(server.URL, NewEvent("uniforge", "dev", time.Now(), 0, nil)); err == nil {
+		t.Error("Send() should fail on a 500 response")
+	}
+}
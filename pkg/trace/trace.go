@@ -0,0 +1,199 @@
+// Package trace records how long the major phases of a command take: cache
+// loads, Unity API calls, file system scans and subprocesses. Recording is off
+// until Enable is called; spans started while disabled are nil and cost nothing.
+package trace
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Span kinds, used to group the breakdown
+const (
+	KindCommand = "command" // The whole command; the parent of all other spans
+	KindCache   = "cache"   // Reading cache and state files
+	KindHTTP    = "http"    // Unity API calls and downloads
+	KindScan    = "scan"    // File system scans
+	KindExec    = "exec"    // Subprocesses
+)
+
+// Span is one timed phase
+type Span struct {
+	Kind  string
+	Name  string
+	Start time.Time
+	End   time.Time
+	Attrs map[string]string
+	Err   string // Error message when the phase failed
+}
+
+// Duration returns how long the span took
+func (s Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Active is a span that has not ended yet. A nil *Active is valid and does nothing.
+type Active struct {
+	span Span
+}
+
+var (
+	enabled atomic.Bool
+	mu      sync.Mutex
+	spans   []Span
+	started time.Time
+)
+
+// Enable starts recording spans
+func Enable() {
+	mu.Lock()
+	defer mu.Unlock()
+	spans = nil
+	started = time.Now()
+	enabled.Store(true)
+}
+
+// Enabled reports whether spans are recorded
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Start begins a span of kind. attrs are key, value pairs describing it.
+func Start(kind, name string, attrs ...string) *Active {
+	if !enabled.Load() {
+		return nil
+	}
+	s := &Active{span: Span{Kind: kind, Name: name, Start: time.Now()}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		s.SetAttr(attrs[i], attrs[i+1])
+	}
+	return s
+}
+
+// SetAttr adds an attribute to the span
+func (a *Active) SetAttr(key, value string) {
+	if a == nil {
+		return
+	}
+	if a.span.Attrs == nil {
+		a.span.Attrs = make(map[string]string)
+	}
+	a.span.Attrs[key] = value
+}
+
+// Fail marks the span as failed with err; a nil err is ignored
+func (a *Active) Fail(err error) {
+	if a == nil || err == nil {
+		return
+	}
+	a.span.Err = err.Error()
+}
+
+// End records the span
+func (a *Active) End() {
+	if a == nil || !enabled.Load() {
+		return
+	}
+	a.span.End = time.Now()
+	mu.Lock()
+	spans = append(spans, a.span)
+	mu.Unlock()
+}
+
+// Finish stops recording and returns the recorded spans, preceded by a
+// command span named name covering everything since Enable
+func Finish(name string, err error) []Span {
+	if !enabled.Swap(false) {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	root := Span{Kind: KindCommand, Name: name, Start: started, End: time.Now()}
+	if err != nil {
+		root.Err = err.Error()
+	}
+	result := append([]Span{root}, spans...)
+	spans = nil
+	return result
+}
+
+// Phase is the total time of the spans sharing a kind and name
+type Phase struct {
+	Kind  string
+	Name  string
+	Calls int
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Summarize groups spans by kind and name, slowest total first. Command spans
+// are left out.
+func Summarize(spans []Span) []Phase {
+	index := make(map[[2]string]int)
+	var phases []Phase
+	for _, s := range spans {
+		if s.Kind == KindCommand {
+			continue
+		}
+		key := [2]string{s.Kind, s.Name}
+		i, ok := index[key]
+		if !ok {
+			i = len(phases)
+			index[key] = i
+			phases = append(phases, Phase{Kind: s.Kind, Name: s.Name})
+		}
+		d := s.Duration()
+		phases[i].Calls++
+		phases[i].Total += d
+		phases[i].Max = max(phases[i].Max, d)
+	}
+	slices.SortStableFunc(phases, func(a, b Phase) int {
+		return cmp.Compare(b.Total, a.Total)
+	})
+	return phases
+}
+
+// PrintSummary writes the timing breakdown of spans to w. Phases run
+// concurrently, so their totals can add up to more than the command took.
+func PrintSummary(w io.Writer, spans []Span) {
+	var total time.Duration
+	for _, s := range spans {
+		if s.Kind == KindCommand {
+			total = s.Duration()
+		}
+	}
+	phases := Summarize(spans)
+
+	_, _ = fmt.Fprintf(w, "\nTiming: %s total\n", formatDuration(total))
+	if len(phases) == 0 {
+		_, _ = fmt.Fprintln(w, "  no phases recorded")
+		return
+	}
+	nameWidth := len("PHASE")
+	for _, p := range phases {
+		nameWidth = max(nameWidth, len(p.Name))
+	}
+	_, _ = fmt.Fprintf(w, "  %-6s %-*s %6s %9s %9s\n", "KIND", nameWidth, "PHASE", "CALLS", "TOTAL", "MAX")
+	for _, p := range phases {
+		_, _ = fmt.Fprintf(w, "  %-6s %-*s %6d %9s %9s\n", p.Kind, nameWidth, p.Name, p.Calls, formatDuration(p.Total), formatDuration(p.Max))
+	}
+}
+
+// formatDuration rounds d for the breakdown, e.g. "1.24s" or "35ms"
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
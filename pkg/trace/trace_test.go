@@ -0,0 +1,139 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDisabledSpansAreNil(t *testing.T) {
+	span := Start(KindCache, "cache")
+	if span != nil {
+		t.Fatal("Start() while disabled returned a span")
+	}
+	// A nil span must be safe to use
+	span.SetAttr("k", "v")
+	span.Fail(errors.New("boom"))
+	span.End()
+	if spans := Finish("uniforge", nil); spans != nil {
+		t.Errorf("Finish() while disabled = %v, want nil", spans)
+	}
+}
+
+func TestRecordSpans(t *testing.T) {
+	Enable()
+	for range 2 {
+		span := Start(KindHTTP, "GraphQL GetAllReleases", "streams", "2022.3")
+		span.End()
+	}
+	failed := Start(KindExec, "Unity compile")
+	failed.Fail(errors.New("exit status 1"))
+	failed.End()
+
+	spans := Finish("uniforge editor list", nil)
+	if Enabled() {
+		t.Error("Enabled() after Finish() = true")
+	}
+	if len(spans) != 4 {
+		t.Fatalf("got %d spans, want 4", len(spans))
+	}
+	if spans[0].Kind != KindCommand || spans[0].Name != "uniforge editor list" {
+		t.Errorf("first span = %+v, want the command", spans[0])
+	}
+	if spans[1].Attrs["streams"] != "2022.3" {
+		t.Errorf("Attrs = %v", spans[1].Attrs)
+	}
+	if spans[3].Err != "exit status 1" {
+		t.Errorf("Err = %q", spans[3].Err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	spans := []Span{
+		{Kind: KindCommand, Name: "uniforge", Start: start, End: at(5 * time.Second)},
+		{Kind: KindCache, Name: "release cache", Start: start, End: at(10 * time.Millisecond)},
+		{Kind: KindHTTP, Name: "GraphQL", Start: start, End: at(time.Second)},
+		{Kind: KindHTTP, Name: "GraphQL", Start: start, End: at(3 * time.Second)},
+	}
+
+	phases := Summarize(spans)
+	if len(phases) != 2 {
+		t.Fatalf("got %d phases, want 2", len(phases))
+	}
+	want := Phase{Kind: KindHTTP, Name: "GraphQL", Calls: 2, Total: 4 * time.Second, Max: 3 * time.Second}
+	if phases[0] != want {
+		t.Errorf("phases[0] = %+v, want %+v", phases[0], want)
+	}
+
+	var out strings.Builder
+	PrintSummary(&out, spans)
+	for _, s := range []string{"Timing: 5.00s total", "GraphQL", "4.00s", "release cache", "10ms"} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("summary missing %q:\n%s", s, out.String())
+		}
+	}
+}
+
+func TestExportOTLP(t *testing.T) {
+	var got otlpRequest
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer token")
+
+	start := time.Now()
+	spans := []Span{
+		{Kind: KindCommand, Name: "uniforge test", Start: start, End: start.Add(time.Minute)},
+		{Kind: KindExec, Name: "Unity tests", Start: start, End: start.Add(50 * time.Second), Err: "exit status 2", Attrs: map[string]string{"version": "2022.3.10f1"}},
+	}
+	if err := ExportOTLP(server.URL, spans, "uniforge", "1.0.0"); err != nil {
+		t.Fatalf("ExportOTLP() error = %v", err)
+	}
+
+	if auth != "Bearer token" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	out := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(out) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(out))
+	}
+	root, child := out[0], out[1]
+	if len(root.TraceID) != 32 || len(root.SpanID) != 16 || root.ParentSpanID != "" {
+		t.Errorf("root span IDs = %+v", root)
+	}
+	if child.TraceID != root.TraceID || child.ParentSpanID != root.SpanID {
+		t.Errorf("child is not part of the command trace: %+v", child)
+	}
+	if child.Kind != otlpKindClient || child.Status == nil || child.Status.Code != otlpStatusError {
+		t.Errorf("child kind/status = %d/%+v", child.Kind, child.Status)
+	}
+}
+
+func TestOTLPEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	if got := OTLPEndpoint(); got != "" {
+		t.Errorf("OTLPEndpoint() = %q, want empty", got)
+	}
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+	if got := OTLPEndpoint(); got != "http://collector:4318/v1/traces" {
+		t.Errorf("OTLPEndpoint() = %q", got)
+	}
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://traces:4318/custom")
+	if got := OTLPEndpoint(); got != "http://traces:4318/custom" {
+		t.Errorf("OTLPEndpoint() = %q", got)
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// colorEnabled is decided by SetNoColor; until then color follows the environment
+var colorEnabled = useColor(false, os.Getenv, isTTY(), ansiConsole)
+
+// ColorEnabled reports whether output may contain ANSI colors and styles.
+// Commands that write their own escape codes (e.g. log formatting) must check it.
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+// SetNoColor applies --no-color and the color environment variables to every
+// lipgloss style, table and TUI. Call it once, before any output.
+func SetNoColor(disabled bool) {
+	colorEnabled = useColor(disabled, os.Getenv, isTTY(), ansiConsole)
+	applyColorProfile()
+}
+
+// applyColorProfile makes lipgloss and the debug logger follow colorEnabled
+func applyColorProfile() {
+	profile := lipgloss.ColorProfile()
+	switch {
+	case !colorEnabled:
+		profile = termenv.Ascii
+	case profile == termenv.Ascii:
+		// Forced with CLICOLOR_FORCE while redirected
+		profile = termenv.ANSI256
+	}
+	lipgloss.SetColorProfile(profile)
+	logger.SetColorProfile(profile)
+}
+
+// useColor decides whether to emit color:
+//   - --no-color or NO_COLOR (any value) disable it
+//   - CLICOLOR_FORCE (other than "0") enables it, even when output is redirected
+//   - CLICOLOR=0 or TERM=dumb disable it
+//   - otherwise stdout must be a terminal that understands ANSI sequences
+//     (a legacy Windows console does not)
+func useColor(noColorFlag bool, getenv func(string) string, terminal bool, ansiSupported func() bool) bool {
+	if noColorFlag || getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	if getenv("CLICOLOR") == "0" || getenv("TERM") == "dumb" {
+		return false
+	}
+	return terminal && ansiSupported()
+}
+
+// MarkSelected prefixes the selected row of a list with ">" when colors are
+// disabled, where the highlight style alone would leave it indistinguishable
+func MarkSelected(line string) string {
+	if colorEnabled {
+		return line
+	}
+	if strings.HasPrefix(line, " ") {
+		return ">" + line[1:]
+	}
+	return "> " + line
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package ui
+
+// ansiConsole reports whether the terminal understands ANSI sequences, which
+// every supported terminal outside Windows does
+func ansiConsole() bool {
+	return true
+}
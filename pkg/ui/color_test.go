@@ -0,0 +1,53 @@
+package ui
+
+import "testing"
+
+func TestUseColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     bool
+		env      map[string]string
+		terminal bool
+		ansi     bool
+		want     bool
+	}{
+		{name: "terminal", terminal: true, ansi: true, want: true},
+		{name: "redirected", terminal: false, ansi: true, want: false},
+		{name: "no-color flag", flag: true, terminal: true, ansi: true, want: false},
+		{name: "NO_COLOR", env: map[string]string{"NO_COLOR": "1"}, terminal: true, ansi: true, want: false},
+		{name: "NO_COLOR wins over CLICOLOR_FORCE", env: map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, terminal: true, ansi: true, want: false},
+		{name: "CLICOLOR_FORCE when redirected", env: map[string]string{"CLICOLOR_FORCE": "1"}, terminal: false, want: true},
+		{name: "CLICOLOR_FORCE=0", env: map[string]string{"CLICOLOR_FORCE": "0"}, terminal: false, want: false},
+		{name: "CLICOLOR=0", env: map[string]string{"CLICOLOR": "0"}, terminal: true, ansi: true, want: false},
+		{name: "dumb terminal", env: map[string]string{"TERM": "dumb"}, terminal: true, ansi: true, want: false},
+		{name: "legacy console", terminal: true, ansi: false, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			ansi := func() bool { return tt.ansi }
+			if got := useColor(tt.flag, getenv, tt.terminal, ansi); got != tt.want {
+				t.Errorf("useColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkSelected(t *testing.T) {
+	orig := colorEnabled
+	t.Cleanup(func() { colorEnabled = orig })
+
+	colorEnabled = true
+	if got := MarkSelected(" 2022.3"); got != " 2022.3" {
+		t.Errorf("MarkSelected() with color = %q", got)
+	}
+
+	colorEnabled = false
+	if got := MarkSelected(" 2022.3"); got != ">2022.3" {
+		t.Errorf("MarkSelected() = %q, want %q", got, ">2022.3")
+	}
+	if got := MarkSelected("2022.3"); got != "> 2022.3" {
+		t.Errorf("MarkSelected() = %q, want %q", got, "> 2022.3")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package ui
+
+import (
+	"os"
+
+	"github.com/muesli/termenv"
+)
+
+// ansiConsole enables virtual terminal processing on the console. It fails on
+// legacy consoles (before Windows 10), which cannot render ANSI sequences.
+func ansiConsole() bool {
+	_, err := termenv.EnableVirtualTerminalProcessing(termenv.NewOutput(os.Stdout))
+	return err == nil
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// ErrInputRequired is returned when a prompt is needed but input is disabled
+// (--no-input) or stdin/stdout is not a terminal
+var ErrInputRequired = errs.New(errs.InputRequired, "confirmation required but running non-interactively (use --yes to proceed)")
+
+var (
+	// assumeYes answers every confirmation with yes (--yes)
+	assumeYes = false
+
+	// noInput disables all prompts (--no-input)
+	noInput = false
+
+	// promptInput is where answers are read from; replaced in tests
+	promptInput io.Reader = os.Stdin
+
+	// terminalCheck reports whether both stdin and stdout are terminals; replaced in tests
+	terminalCheck = func() bool {
+		return isTTY() && (isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd()))
+	}
+)
+
+// SetAssumeYes makes Confirm answer yes without prompting
+func SetAssumeYes(enabled bool) {
+	assumeYes = enabled
+}
+
+// SetNoInput disables interactive prompts
+func SetNoInput(enabled bool) {
+	noInput = enabled
+}
+
+// CanPrompt returns whether the user can be asked for input: prompts are not
+// disabled with --no-input and both stdin and stdout are terminals
+func CanPrompt() bool {
+	return !noInput && terminalCheck()
+}
+
+// Confirm asks a yes/no question, defaulting to no. With --yes it returns true
+// without asking. When prompting is not possible it returns ErrInputRequired
+// instead of waiting for input, so scripts never hang.
+func Confirm(format string, args ...any) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if !CanPrompt() {
+		return false, ErrInputRequired
+	}
+
+	fmt.Printf("%s [y/N]: ", fmt.Sprintf(format, args...))
+	response, err := bufio.NewReader(promptInput).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", nil
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// setPromptState overrides prompt settings for a test and restores them afterwards
+func setPromptState(t *testing.T, yes, disabled, terminal bool, input string) {
+	t.Helper()
+	origYes, origNoInput, origInput, origCheck := assumeYes, noInput, promptInput, terminalCheck
+	t.Cleanup(func() {
+		assumeYes, noInput, promptInput, terminalCheck = origYes, origNoInput, origInput, origCheck
+	})
+
+	assumeYes = yes
+	noInput = disabled
+	promptInput = strings.NewReader(input)
+	terminalCheck = func() bool { return terminal }
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		yes      bool
+		noInput  bool
+		terminal bool
+		input    string
+		want     bool
+		wantErr  error
+	}{
+		{name: "yes answer", terminal: true, input: "y\n", want: true},
+		{name: "full yes answer", terminal: true, input: " YES \n", want: true},
+		{name: "default is no", terminal: true, input: "\n", want: false},
+		{name: "eof is no", terminal: true, input: "", want: false},
+		{name: "assume yes", yes: true, want: true},
+		{name: "assume yes wins over no-input", yes: true, noInput: true, want: true},
+		{name: "no terminal", terminal: false, input: "y\n", wantErr: ErrInputRequired},
+		{name: "no-input", noInput: true, terminal: true, input: "y\n", wantErr: ErrInputRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPromptState(t, tt.yes, tt.noInput, tt.terminal, tt.input)
+
+			var got bool
+			var err error
+			captureStdout(t, func() { got, err = Confirm("Delete %d files?", 3) })
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Confirm() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Confirm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
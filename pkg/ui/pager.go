@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// noPager prints long output directly instead of through a pager (--no-pager)
+var noPager = false
+
+// SetNoPager disables the pager
+func SetNoPager(disabled bool) {
+	noPager = disabled
+}
+
+// TerminalSize returns the size of the terminal stdout is connected to
+func TerminalSize() (width, height int, ok bool) {
+	if !isTTY() {
+		return 0, 0, false
+	}
+	width, height, err := term.GetSize(os.Stdout.Fd())
+	if err != nil || width <= 0 || height <= 0 {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// Page prints content, through a pager when stdout is a terminal and the
+// content does not fit on one screen
+func Page(content string) error {
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	_, height, ok := TerminalSize()
+	pager := pagerCommand(os.Getenv)
+	if noPager || !ok || len(pager) == 0 || strings.Count(content, "\n") < height {
+		fmt.Print(content)
+		return nil
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if os.Getenv("LESS") == "" {
+		// Keep colors, and leave the output on screen when quitting
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// Quitting the pager early is not an error
+			return nil
+		}
+		Debug("Pager failed, printing directly", "pager", pager[0], "error", err)
+		fmt.Print(content)
+	}
+	return nil
+}
+
+// pagerCommand returns the pager to use: UNIFORGE_PAGER, then PAGER, then
+// less. A pager of "cat" disables paging.
+func pagerCommand(getenv func(string) string) []string {
+	pager := getenv("UNIFORGE_PAGER")
+	if pager == "" {
+		pager = getenv("PAGER")
+	}
+	if pager == "" {
+		if runtime.GOOS == "windows" {
+			if _, err := exec.LookPath("less"); err != nil {
+				return nil
+			}
+		}
+		pager = "less -R"
+	}
+	fields := strings.Fields(pager)
+	if len(fields) == 0 || fields[0] == "cat" {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,376 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	progressBarWidth     = 30
+	progressRefresh      = 100 * time.Millisecond
+	progressPlainStep    = 10               // Non-TTY: print every 10 percentage points
+	progressPlainPeriod  = 10 * time.Second // Non-TTY: or at least this often
+	progressMinETASample = time.Second      // Don't show an ETA before this much elapsed
+)
+
+var (
+	progressFillStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+	progressEmptyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	progressSpinStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+)
+
+// Progress reports the progress of a long-running operation.
+//
+// On a terminal it renders a live view: a bar with counters and ETA when the
+// total is known, a spinner otherwise, and one indented line per nested step.
+// When stdout is not a terminal it prints a plain line every 10 percentage
+// points (or every 10 seconds), so CI logs show progress without control codes.
+//
+// All methods are safe for concurrent use.
+type Progress struct {
+	root     *Progress
+	mu       *sync.Mutex // Shared by a whole tree
+	depth    int
+	title    string
+	total    int64
+	current  int64
+	bytes    bool
+	start    time.Time
+	done     bool
+	err      error
+	children []*Progress
+
+	// Non-TTY state
+	lastPercent int
+	lastPrint   time.Time
+
+	// Root only
+	program  *tea.Program
+	finished chan struct{}
+}
+
+// ProgressOption configures a Progress
+type ProgressOption func(*Progress)
+
+// WithBytes formats counters as byte sizes (e.g. "12.3 MB / 1.2 GB")
+func WithBytes() ProgressOption {
+	return func(p *Progress) {
+		p.bytes = true
+	}
+}
+
+// NewProgress starts reporting an operation. A total of 0 means the size is unknown.
+// Call Done when the operation finishes.
+func NewProgress(title string, total int64, opts ...ProgressOption) *Progress {
+	p := &Progress{
+		mu:          &sync.Mutex{},
+		title:       title,
+		total:       total,
+		start:       time.Now(),
+		lastPercent: -1,
+		lastPrint:   time.Now(),
+	}
+	p.root = p
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if isTTY() {
+		p.finished = make(chan struct{})
+		p.program = tea.NewProgram(progressModel{root: p},
+			tea.WithInput(nil),
+			tea.WithoutSignalHandler(),
+		)
+		go func() {
+			_, _ = p.program.Run()
+			close(p.finished)
+		}()
+	} else {
+		Print("%s...", title)
+	}
+	return p
+}
+
+// Step starts a nested step shown below this operation
+func (p *Progress) Step(title string, total int64, opts ...ProgressOption) *Progress {
+	step := &Progress{
+		root:        p.root,
+		mu:          p.mu,
+		depth:       p.depth + 1,
+		title:       title,
+		total:       total,
+		start:       time.Now(),
+		lastPercent: -1,
+		lastPrint:   time.Now(),
+	}
+	for _, opt := range opts {
+		opt(step)
+	}
+
+	p.mu.Lock()
+	p.children = append(p.children, step)
+	p.mu.Unlock()
+
+	if p.root.program == nil {
+		Print("%s%s...", step.indent(), title)
+	}
+	return step
+}
+
+// Add advances the counter by n
+func (p *Progress) Add(n int64) {
+	p.mu.Lock()
+	p.current += n
+	p.mu.Unlock()
+	p.printPlain()
+}
+
+// Set sets the counter to n
+func (p *Progress) Set(n int64) {
+	p.mu.Lock()
+	p.current = n
+	p.mu.Unlock()
+	p.printPlain()
+}
+
+// SetTotal changes the total, e.g. once a download reports its size
+func (p *Progress) SetTotal(total int64) {
+	p.mu.Lock()
+	p.total = total
+	p.mu.Unlock()
+}
+
+// Write counts written bytes, so a Progress can be used with io.TeeReader or io.MultiWriter
+func (p *Progress) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Println prints a message above the live view (or as a plain line without a terminal)
+func (p *Progress) Println(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if p.root.program != nil {
+		p.root.program.Println(msg)
+		return
+	}
+	Print("%s", msg)
+}
+
+// Done marks the operation (or step) as finished. For the top-level operation
+// it stops the live view and prints a final success or error line.
+func (p *Progress) Done(err error) {
+	elapsed, ok := p.stop(err)
+	if !ok {
+		return
+	}
+	if p == p.root || p.root.program == nil {
+		p.printDone(elapsed)
+	}
+}
+
+// stop marks p as finished and, for the top-level operation, waits for the live
+// view to exit. It returns false if p was already finished.
+func (p *Progress) stop(err error) (time.Duration, bool) {
+	p.mu.Lock()
+	if p.done {
+		p.mu.Unlock()
+		return 0, false
+	}
+	p.done = true
+	p.err = err
+	if err == nil && p.total > 0 {
+		p.current = p.total
+	}
+	elapsed := time.Since(p.start).Round(100 * time.Millisecond)
+	p.mu.Unlock()
+
+	if p == p.root && p.program != nil {
+		p.program.Send(progressDoneMsg{})
+		<-p.finished
+	}
+	return elapsed, true
+}
+
+func (p *Progress) printDone(elapsed time.Duration) {
+	switch {
+	case p != p.root && p.err != nil:
+		Print("%s%s: failed: %v", p.indent(), p.title, p.err)
+	case p != p.root:
+		Print("%s%s: done (%s)", p.indent(), p.title, elapsed)
+	case p.err != nil:
+		// The error itself is returned to the caller, which reports it
+		Error("%s failed", p.title)
+	default:
+		Success("%s (%s)", p.title, elapsed)
+	}
+}
+
+// printPlain prints a percentage line in non-TTY mode when enough has changed
+func (p *Progress) printPlain() {
+	if p.root.program != nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	percent := -1
+	if p.total > 0 {
+		percent = int(p.current * 100 / p.total)
+	}
+	due := time.Since(p.lastPrint) >= progressPlainPeriod
+	stepped := percent >= 0 && percent >= p.lastPercent+progressPlainStep
+	if !due && !stepped {
+		return
+	}
+
+	if percent >= 0 {
+		p.lastPercent = percent - percent%progressPlainStep
+	}
+	p.lastPrint = time.Now()
+	fmt.Fprintf(os.Stdout, "%s%s: %s\n", p.indent(), p.title, p.statusLocked())
+}
+
+// indent returns the indentation for the nesting depth of p
+func (p *Progress) indent() string {
+	return strings.Repeat("  ", p.depth)
+}
+
+// statusLocked formats percentage, counters and ETA. p.mu must be held.
+func (p *Progress) statusLocked() string {
+	elapsed := time.Since(p.start)
+	if p.total <= 0 {
+		if p.current > 0 {
+			return fmt.Sprintf("%s (%s)", p.formatCount(p.current), formatElapsed(elapsed))
+		}
+		return formatElapsed(elapsed)
+	}
+
+	percent := float64(p.current) * 100 / float64(p.total)
+	status := fmt.Sprintf("%3.0f%% %s / %s", percent, p.formatCount(p.current), p.formatCount(p.total))
+	if eta, ok := estimateETA(p.current, p.total, elapsed); ok {
+		status += ", ETA " + formatElapsed(eta)
+	}
+	return status
+}
+
+func (p *Progress) formatCount(n int64) string {
+	if p.bytes {
+		return FormatBytes(n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// estimateETA extrapolates the remaining time from the average rate so far
+func estimateETA(current, total int64, elapsed time.Duration) (time.Duration, bool) {
+	if current <= 0 || current >= total || elapsed < progressMinETASample {
+		return 0, false
+	}
+	remaining := float64(total-current) * float64(elapsed) / float64(current)
+	return time.Duration(remaining), true
+}
+
+// FormatBytes formats a byte count using binary units, e.g. "1.5 KB" or
+// "3.0 GB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatElapsed formats a duration as e.g. "45s" or "3m05s"
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// renderBar draws a bar filled to current/total
+func renderBar(current, total int64, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = int(float64(width) * float64(min(current, total)) / float64(total))
+	}
+	return progressFillStyle.Render(strings.Repeat("█", filled)) +
+		progressEmptyStyle.Render(strings.Repeat("░", width-filled))
+}
+
+// progressModel renders a Progress tree with bubbletea
+type progressModel struct {
+	root *Progress
+	quit bool
+}
+
+type progressTickMsg struct{}
+type progressDoneMsg struct{}
+
+func progressTick() tea.Cmd {
+	return tea.Tick(progressRefresh, func(time.Time) tea.Msg { return progressTickMsg{} })
+}
+
+func (m progressModel) Init() tea.Cmd {
+	return progressTick()
+}
+
+func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case progressDoneMsg:
+		m.quit = true
+		return m, tea.Quit
+	case progressTickMsg:
+		return m, progressTick()
+	}
+	return m, nil
+}
+
+func (m progressModel) View() string {
+	if m.quit {
+		return ""
+	}
+	m.root.mu.Lock()
+	defer m.root.mu.Unlock()
+
+	var b strings.Builder
+	m.root.renderLocked(&b)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderLocked writes the line of p and its steps. p.mu must be held.
+func (p *Progress) renderLocked(b *strings.Builder) {
+	indent := p.indent()
+	switch {
+	case p.done && p.err != nil:
+		fmt.Fprintf(b, "%s%s %s\n", indent, errorStyle.Render("✗"), p.title)
+	case p.done:
+		fmt.Fprintf(b, "%s%s %s\n", indent, successStyle.Render("✓"), p.title)
+	case p.total > 0:
+		fmt.Fprintf(b, "%s%s %s %s\n", indent, p.title, renderBar(p.current, p.total, progressBarWidth), mutedStyle.Render(p.statusLocked()))
+	default:
+		frames := spinner.Dot.Frames
+		frame := frames[int(time.Since(p.start)/spinner.Dot.FPS)%len(frames)]
+		fmt.Fprintf(b, "%s%s %s %s\n", indent, progressSpinStyle.Render(frame), p.title, mutedStyle.Render(p.statusLocked()))
+	}
+
+	for _, c := range p.children {
+		c.renderLocked(b)
+	}
+}
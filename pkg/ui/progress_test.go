@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{512, "512 B"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Second, "45s"},
+		{3*time.Minute + 5*time.Second, "3m05s"},
+		{2*time.Hour + 7*time.Minute, "2h07m"},
+	}
+	for _, tt := range tests {
+		if got := formatElapsed(tt.d); got != tt.want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestEstimateETA(t *testing.T) {
+	eta, ok := estimateETA(25, 100, 10*time.Second)
+	if !ok || eta != 30*time.Second {
+		t.Errorf("estimateETA(25, 100, 10s) = %v, %v, want 30s", eta, ok)
+	}
+	if _, ok := estimateETA(0, 100, 10*time.Second); ok {
+		t.Error("estimateETA() without progress should not report an ETA")
+	}
+	if _, ok := estimateETA(50, 100, 100*time.Millisecond); ok {
+		t.Error("estimateETA() before the minimum sample should not report an ETA")
+	}
+}
+
+// captureStdout runs fn and returns what it wrote to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+	_ = w.Close()
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestProgress_PlainOutput(t *testing.T) {
+	out := captureStdout(t, func() {
+		p := NewProgress("Downloading", 100)
+		step := p.Step("Editor", 1000, WithBytes())
+		for i := 0; i < 10; i++ {
+			p.Add(5) // Reaches 50%
+		}
+		step.Add(1000)
+		step.Done(nil)
+		p.Done(nil)
+	})
+
+	for _, want := range []string{
+		"Downloading...\n",
+		"  Editor...\n",
+		"Downloading:  10% 10 / 100\n",
+		"Downloading:  50% 50 / 100\n",
+		"  Editor: 100% 1000 B / 1000 B\n",
+		"  Editor: done (",
+		"✓ Downloading (",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Downloading:  15%") {
+		t.Errorf("output should only print every %d percentage points:\n%s", progressPlainStep, out)
+	}
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// minColumnWidth is the narrowest a flexible column is truncated to
+const minColumnWidth = 8
+
+// Column is a column of a Table
+type Column struct {
+	Name     string // Name used with --columns, e.g. "version"
+	Header   string
+	Flexible bool // Truncated when the table is wider than the terminal
+	KeepEnd  bool // Truncate the start instead of the end, for paths
+}
+
+// Table is a listing table whose columns can be selected, that is truncated
+// to the terminal width and paged when taller than the terminal
+type Table struct {
+	Columns     []Column
+	Rows        [][]string // One value per column
+	HeaderStyle lipgloss.Style
+	// Style returns the style of a cell; col indexes Columns, whatever is selected
+	Style func(row, col int) lipgloss.Style
+
+	selected []int
+}
+
+// ColumnNames returns the names of the columns, comma-separated
+func (t *Table) ColumnNames() string {
+	names := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Select shows only the named columns, in the given order. No names shows every column.
+func (t *Table) Select(names []string) error {
+	t.selected = nil
+	for _, name := range names {
+		index := -1
+		for i, c := range t.Columns {
+			if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return errs.New(errs.Usage, "unknown column %q (available: %s)", name, t.ColumnNames())
+		}
+		t.selected = append(t.selected, index)
+	}
+	return nil
+}
+
+// visible returns the indexes of the columns to show
+func (t *Table) visible() []int {
+	if len(t.selected) > 0 {
+		return t.selected
+	}
+	all := make([]int, len(t.Columns))
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
+
+// Render returns the table, truncating flexible columns so that it fits in
+// width cells. A width of 0 or less does not truncate.
+func (t *Table) Render(width int) string {
+	cols := t.visible()
+	widths := make([]int, len(cols))
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		headers[i] = t.Columns[col].Header
+		widths[i] = lipgloss.Width(headers[i])
+		for _, row := range t.Rows {
+			widths[i] = max(widths[i], lipgloss.Width(row[col]))
+		}
+	}
+	if width > 0 {
+		// The hidden border puts a space before, between and after the columns
+		t.fitWidths(cols, widths, width-len(cols)-1)
+	}
+
+	rows := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		rows[r] = make([]string, len(cols))
+		for i, col := range cols {
+			rows[r][i] = Truncate(row[col], widths[i], t.Columns[col].KeepEnd)
+		}
+	}
+
+	tbl := table.New().
+		Headers(headers...).
+		Rows(rows...).
+		Border(lipgloss.HiddenBorder()).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return t.HeaderStyle
+			}
+			if t.Style == nil {
+				return lipgloss.NewStyle()
+			}
+			return t.Style(row, cols[col])
+		})
+	return tbl.String()
+}
+
+// fitWidths narrows the widest flexible column, one cell at a time, until the
+// columns fit in avail or every flexible column is at its minimum
+func (t *Table) fitWidths(cols []int, widths []int, avail int) {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > avail {
+		widest := -1
+		for i, col := range cols {
+			c := t.Columns[col]
+			if !c.Flexible || widths[i] <= max(minColumnWidth, lipgloss.Width(c.Header)) {
+				continue
+			}
+			if widest < 0 || widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// Print writes the table truncated to the terminal width, through a pager when
+// it is taller than the terminal
+func (t *Table) Print() error {
+	width, _, _ := TerminalSize()
+	return Page(t.Render(width))
+}
+
+// Truncate shortens s to width cells, marking the cut with "…". With keepEnd
+// the start is cut instead, which keeps the meaningful end of paths.
+func Truncate(s string, width int, keepEnd bool) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	kept := 0
+	n := 0
+	for i := range runes {
+		r := runes[i]
+		if keepEnd {
+			r = runes[len(runes)-1-i]
+		}
+		w := lipgloss.Width(string(r))
+		if kept+w > width-1 {
+			break
+		}
+		kept += w
+		n++
+	}
+	if keepEnd {
+		return "…" + string(runes[len(runes)-n:])
+	}
+	return string(runes[:n]) + "…"
+}
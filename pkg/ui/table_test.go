@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func newTestTable() *Table {
+	return &Table{
+		Columns: []Column{
+			{Name: "name", Header: "NAME", Flexible: true},
+			{Name: "version", Header: "VERSION"},
+			{Name: "path", Header: "PATH", Flexible: true, KeepEnd: true},
+		},
+		Rows: [][]string{
+			{"Space Game", "2022.3.10f1", "/home/dev/projects/clients/space-game"},
+			{"Demo", "6000.0.40f1", "/home/dev/demo"},
+		},
+	}
+}
+
+func TestTableRenderFitsWidth(t *testing.T) {
+	tbl := newTestTable()
+
+	full := tbl.Render(0)
+	if !strings.Contains(full, "/home/dev/projects/clients/space-game") {
+		t.Errorf("Render(0) truncated:\n%s", full)
+	}
+
+	narrow := tbl.Render(40)
+	for _, line := range strings.Split(narrow, "\n") {
+		if w := lipgloss.Width(line); w > 40 {
+			t.Errorf("line is %d cells wide, want <= 40: %q", w, line)
+		}
+	}
+	if !strings.Contains(narrow, "2022.3.10f1") || !strings.Contains(narrow, "…nts/space-game") {
+		t.Errorf("Render(40) should keep fixed columns and the end of paths:\n%s", narrow)
+	}
+}
+
+func TestTableSelect(t *testing.T) {
+	tbl := newTestTable()
+	if err := tbl.Select([]string{"PATH", "name"}); err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	// The hidden border adds a blank line above the header
+	header := strings.Fields(strings.Split(tbl.Render(0), "\n")[1])
+	if !slices.Equal(header, []string{"PATH", "NAME"}) {
+		t.Errorf("header = %v, want [PATH NAME]", header)
+	}
+	if strings.Contains(tbl.Render(0), "2022.3.10f1") {
+		t.Error("unselected column was rendered")
+	}
+
+	err := tbl.Select([]string{"size"})
+	if !errors.Is(err, errs.Usage) || !strings.Contains(err.Error(), "name, version, path") {
+		t.Errorf("Select(unknown) error = %v", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s       string
+		width   int
+		keepEnd bool
+		want    string
+	}{
+		{"2022.3.10f1", 20, false, "2022.3.10f1"},
+		{"Space Game", 6, false, "Space…"},
+		{"/home/dev/game", 8, true, "…ev/game"},
+		{"日本語のプロジェクト", 7, false, "日本語…"},
+		{"abc", 0, false, ""},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.width, tt.keepEnd)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d, %v) = %q, want %q", tt.s, tt.width, tt.keepEnd, got, tt.want)
+		}
+		if lipgloss.Width(got) > tt.width {
+			t.Errorf("Truncate(%q, %d) is %d cells wide", tt.s, tt.width, lipgloss.Width(got))
+		}
+	}
+}
+
+func TestPagerCommand(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	if got := pagerCommand(env(map[string]string{"UNIFORGE_PAGER": "most -s", "PAGER": "less"})); !slices.Equal(got, []string{"most", "-s"}) {
+		t.Errorf("UNIFORGE_PAGER: got %v", got)
+	}
+	if got := pagerCommand(env(map[string]string{"PAGER": "cat"})); got != nil {
+		t.Errorf("PAGER=cat: got %v, want no pager", got)
+	}
+	if runtime.GOOS != "windows" {
+		if got := pagerCommand(env(nil)); !slices.Equal(got, []string{"less", "-R"}) {
+			t.Errorf("default: got %v", got)
+		}
+	}
+}
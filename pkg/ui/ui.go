@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -61,6 +60,11 @@ func Error(format string, args ...any) {
 	fmt.Fprintln(os.Stderr, errorStyle.Render("✗ "+fmt.Sprintf(format, args...)))
 }
 
+// Hint prints a remediation hint for an error to stderr
+func Hint(format string, args ...any) {
+	fmt.Fprintln(os.Stderr, mutedStyle.Render("  Hint: "+fmt.Sprintf(format, args...)))
+}
+
 // Muted prints a muted/secondary message
 func Muted(format string, args ...any) {
 	fmt.Println(mutedStyle.Render(fmt.Sprintf(format, args...)))
@@ -173,27 +177,9 @@ func WithSpinnerNoResult(message string, task func() error) error {
 // StartSpinner starts a spinner and returns a stop function
 // Use this for long-running operations where you need more control
 func StartSpinner(message string) func(success bool, resultMsg string) {
-	done := make(chan struct{})
-	s := spinner.New()
-	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-
-	go func() {
-		for {
-			select {
-			case <-done:
-				return
-			default:
-				fmt.Printf("\r%s %s", s.View(), message)
-				time.Sleep(100 * time.Millisecond)
-				s, _ = s.Update(s.Tick())
-			}
-		}
-	}()
-
+	p := NewProgress(message, 0)
 	return func(success bool, resultMsg string) {
-		close(done)
-		fmt.Print("\r\033[K") // Clear line
+		p.stop(nil)
 		if success {
 			Success("%s", resultMsg)
 		} else {
@@ -283,9 +269,9 @@ func (m selectModel) View() string {
 }
 
 // Select displays an interactive selection UI and returns the selected index
-// Returns -1 if cancelled
+// Returns -1 if cancelled, or if there is no terminal or prompts are disabled
 func Select(title string, options []SelectOption) int {
-	if !isTTY() {
+	if noInput || !isTTY() {
 		return -1
 	}
 
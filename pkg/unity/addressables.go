@@ -0,0 +1,315 @@
+package unity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// AddressablesPackage is the Unity package that provides Addressables
+const AddressablesPackage = "com.unity.addressables"
+
+// AddressablesBuildMethod is the static method of the injected editor script
+// that builds the Addressables content
+const AddressablesBuildMethod = "UniForge.AddressablesBuild.Build"
+
+// argAddressablesProfile passes the profile to the injected build method
+const argAddressablesProfile = "-uniforgeAddressablesProfile"
+
+// addressablesScriptDir holds the injected build script while Unity runs; the
+// Editor folder makes Unity compile it into the editor assembly
+var addressablesScriptDir = filepath.Join("Assets", "UniForgeTemp")
+
+// addressablesLogPrefix starts the lines the injected script logs for uniforge
+const addressablesLogPrefix = "[UniForge:Addressables] "
+
+// addressablesBuildScript builds the content with the active or the given
+// profile, logs the written bundles and runs the "Check Duplicate Bundle
+// Dependencies" analyze rule. Fields of the log lines are tab-separated.
+const addressablesBuildScript = `using System;
+using System.IO;
+using UnityEditor;
+using UnityEditor.AddressableAssets;
+using UnityEditor.AddressableAssets.Build;
+using UnityEditor.AddressableAssets.Build.AnalyzeRules;
+using UnityEditor.AddressableAssets.Settings;
+using UnityEngine;
+
+namespace UniForge
+{
+    public static class AddressablesBuild
+    {
+        const string Prefix = "` + addressablesLogPrefix + `";
+
+        public static void Build()
+        {
+            Application.SetStackTraceLogType(LogType.Log, StackTraceLogType.None);
+            var settings = AddressableAssetSettingsDefaultObject.Settings;
+            if (settings == null)
+            {
+                Fail("the project has no Addressables settings; create them in Window > Asset Management > Addressables > Groups");
+                return;
+            }
+
+            var profile = Argument("` + argAddressablesProfile + `");
+            if (!string.IsNullOrEmpty(profile))
+            {
+                var id = settings.profileSettings.GetProfileId(profile);
+                if (string.IsNullOrEmpty(id))
+                {
+                    Fail("profile not found: " + profile);
+                    return;
+                }
+                settings.activeProfileId = id;
+            }
+            Debug.Log(Prefix + "profile\t" + settings.profileSettings.GetProfileName(settings.activeProfileId));
+
+            AddressableAssetSettings.BuildPlayerContent(out AddressablesPlayerBuildResult result);
+            if (!string.IsNullOrEmpty(result.Error))
+            {
+                Fail(result.Error);
+                return;
+            }
+            foreach (var bundle in result.AssetBundleBuildResults)
+            {
+                var size = File.Exists(bundle.FilePath) ? new FileInfo(bundle.FilePath).Length : 0;
+                Debug.Log(Prefix + "bundle\t" + size + "\t" + bundle.FilePath);
+            }
+
+            try
+            {
+                var rule = new CheckBundleDupeDependencies();
+                foreach (var r in rule.RefreshAnalysis(settings))
+                {
+                    // "<group>:<bundle>:...:<asset>"
+                    var parts = r.resultName.Split(':');
+                    if (parts.Length > 1)
+                        Debug.Log(Prefix + "duplicate\t" + parts[parts.Length - 1] + "\t" + parts[0]);
+                }
+                rule.ClearAnalysis();
+            }
+            catch (Exception e)
+            {
+                Debug.LogWarning(Prefix + "warning\tduplicate check failed: " + e.Message);
+            }
+        }
+
+        static string Argument(string name)
+        {
+            var args = Environment.GetCommandLineArgs();
+            for (var i = 0; i < args.Length - 1; i++)
+                if (args[i] == name)
+                    return args[i + 1];
+            return null;
+        }
+
+        static void Fail(string message)
+        {
+            Debug.LogError(Prefix + "error\t" + message);
+            EditorApplication.Exit(1);
+        }
+    }
+}
+`
+
+// AddressablesConfig holds configuration for an Addressables content build
+type AddressablesConfig struct {
+	Profile string // Addressables profile; the active one when empty
+
+	LogFile        string
+	TimeoutSeconds int
+	CIMode         bool
+	ShowTimestamp  bool
+}
+
+// AddressablesBundle is an asset bundle written by the content build
+type AddressablesBundle struct {
+	Path string `json:"path"` // As Unity reports it, usually relative to the project
+	Size int64  `json:"size"`
+}
+
+// AddressablesDuplicate is an asset that is not addressable itself but pulled
+// into bundles of several groups, so it is loaded more than once
+type AddressablesDuplicate struct {
+	Asset string `json:"asset"`
+	Group string `json:"group"`
+}
+
+// AddressablesReport describes an Addressables content build
+type AddressablesReport struct {
+	Profile    string                  `json:"profile"`
+	Bundles    []AddressablesBundle    `json:"bundles"`
+	Duplicates []AddressablesDuplicate `json:"duplicates"`
+	Warnings   []string                `json:"warnings,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+	Duration   time.Duration           `json:"duration"`
+}
+
+// TotalSize returns the size of all bundles
+func (r *AddressablesReport) TotalSize() int64 {
+	var total int64
+	for _, b := range r.Bundles {
+		total += b.Size
+	}
+	return total
+}
+
+// DuplicateAssets returns the duplicated assets with the groups pulling them
+// in, in the order they were reported
+func (r *AddressablesReport) DuplicateAssets() ([]string, map[string][]string) {
+	var assets []string
+	groups := make(map[string][]string)
+	for _, d := range r.Duplicates {
+		if _, ok := groups[d.Asset]; !ok {
+			assets = append(assets, d.Asset)
+		}
+		groups[d.Asset] = append(groups[d.Asset], d.Group)
+	}
+	return assets, groups
+}
+
+// parseAddressablesLog fills the report from the lines of the injected script
+// in a Unity log. Lines are reported once each although Unity may repeat them.
+func parseAddressablesLog(r io.Reader, report *AddressablesReport) {
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		rest, ok := strings.CutPrefix(line, addressablesLogPrefix)
+		if !ok || seen[rest] {
+			continue
+		}
+		seen[rest] = true
+		fields := strings.Split(rest, "\t")
+		switch {
+		case fields[0] == "profile" && len(fields) == 2:
+			report.Profile = fields[1]
+		case fields[0] == "bundle" && len(fields) == 3:
+			size, _ := strconv.ParseInt(fields[1], 10, 64)
+			report.Bundles = append(report.Bundles, AddressablesBundle{Path: strings.ReplaceAll(fields[2], `\`, "/"), Size: size})
+		case fields[0] == "duplicate" && len(fields) == 3:
+			report.Duplicates = append(report.Duplicates, AddressablesDuplicate{Asset: fields[1], Group: fields[2]})
+		case fields[0] == "warning" && len(fields) == 2:
+			report.Warnings = append(report.Warnings, fields[1])
+		case fields[0] == "error" && len(fields) == 2:
+			report.Error = fields[1]
+		}
+	}
+	// Keep draining so the editor never blocks on a full pipe
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// HasAddressables reports whether the project depends on the Addressables package
+func HasAddressables(projectPath string) bool {
+	manifest, err := LoadPackageManifest(projectPath)
+	if err != nil {
+		return false
+	}
+	_, ok := manifest.Dependencies[AddressablesPackage]
+	return ok
+}
+
+// injectAddressablesScript writes the build script into the project and
+// returns a function that removes it again, with the .meta files Unity creates
+func injectAddressablesScript(projectPath string) (func(), error) {
+	dir := filepath.Join(projectPath, addressablesScriptDir)
+	remove := func() {
+		_ = os.RemoveAll(dir)
+		_ = os.Remove(dir + ".meta")
+	}
+	// A script left behind by a killed run is replaced
+	remove()
+	editorDir := filepath.Join(dir, "Editor")
+	if err := os.MkdirAll(editorDir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(editorDir, "UniForgeAddressablesBuild.cs"), []byte(addressablesBuildScript), 0644); err != nil {
+		remove()
+		return nil, err
+	}
+	return remove, nil
+}
+
+// BuildAddressables builds the project's Addressables content in batch mode
+// through an injected editor script, streaming the log like Run. The bundles
+// and duplicated assets the script logs are collected into the report, which
+// is returned with the error when the build failed.
+func (r *Runner) BuildAddressables(config AddressablesConfig) (*AddressablesReport, error) {
+	if !HasAddressables(r.project.Path) {
+		return nil, errs.WithHint(
+			errs.New(errs.NotFound, "%s does not use Addressables", r.project.Name),
+			"Add "+AddressablesPackage+" in the Package Manager first.")
+	}
+
+	remove, err := injectAddressablesScript(r.project.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add the build script: %w", err)
+	}
+	defer remove()
+
+	args := []string{"-executeMethod", AddressablesBuildMethod}
+	if config.Profile != "" {
+		args = append(args, argAddressablesProfile, config.Profile)
+	}
+
+	report := &AddressablesReport{}
+	pr, pw := io.Pipe()
+	parsed := make(chan struct{})
+	go func() {
+		parseAddressablesLog(pr, report)
+		close(parsed)
+	}()
+
+	start := time.Now()
+	err = r.Run(RunConfig{
+		ProjectPath:    r.project.Path,
+		ExtraArgs:      args,
+		LogFile:        config.LogFile,
+		TimeoutSeconds: config.TimeoutSeconds,
+		CIMode:         config.CIMode,
+		ShowTimestamp:  config.ShowTimestamp,
+		Tee:            pw,
+	})
+	_ = pw.Close()
+	<-parsed
+	report.Duration = time.Since(start)
+
+	if report.Error != "" {
+		return report, fmt.Errorf("addressables build failed: %s", report.Error)
+	}
+	return report, err
+}
+
+// addressablesCacheDirs are the Library folders of the Addressables build:
+// the built content and catalogs, and the Scriptable Build Pipeline cache
+var addressablesCacheDirs = []string{
+	filepath.Join("Library", "com.unity.addressables"),
+	filepath.Join("Library", "BuildCache"),
+}
+
+// CleanAddressablesCache removes the Addressables build cache of the project,
+// so the next content build starts from scratch. Returns the freed bytes.
+func CleanAddressablesCache(projectPath string) (int64, error) {
+	var freed int64
+	for _, rel := range addressablesCacheDirs {
+		dir := filepath.Join(projectPath, rel)
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		size, _ := fsutil.DirSize(dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return freed, fmt.Errorf("failed to remove %s: %w", dir, err)
+		}
+		freed += size
+	}
+	return freed, nil
+}
@@ -0,0 +1,96 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAddressablesLog(t *testing.T) {
+	log := `Loading project
+[UniForge:Addressables] profile	Production
+[UniForge:Addressables] bundle	2048	Library/com.unity.addressables/aa/Android/defaultlocalgroup_assets_all.bundle
+[UniForge:Addressables] bundle	512	ServerData\Android\remote_assets_all.bundle
+[UniForge:Addressables] duplicate	Assets/Textures/Shared.png	Default Local Group
+[UniForge:Addressables] duplicate	Assets/Textures/Shared.png	Remote
+[UniForge:Addressables] duplicate	Assets/Textures/Shared.png	Remote
+[UniForge:Addressables] bundle	not-logged-by-uniforge
+Exiting batchmode successfully now!
+`
+	report := &AddressablesReport{}
+	parseAddressablesLog(strings.NewReader(log), report)
+
+	if report.Profile != "Production" {
+		t.Errorf("Profile = %q", report.Profile)
+	}
+	if len(report.Bundles) != 2 || report.TotalSize() != 2560 {
+		t.Fatalf("Bundles = %+v", report.Bundles)
+	}
+	if report.Bundles[1].Path != "ServerData/Android/remote_assets_all.bundle" {
+		t.Errorf("Bundle path = %q", report.Bundles[1].Path)
+	}
+	assets, groups := report.DuplicateAssets()
+	if len(assets) != 1 || len(groups[assets[0]]) != 2 {
+		t.Errorf("DuplicateAssets = %v, %v; want one asset in two groups", assets, groups)
+	}
+	if report.Error != "" {
+		t.Errorf("Error = %q", report.Error)
+	}
+}
+
+func TestParseAddressablesLogError(t *testing.T) {
+	report := &AddressablesReport{}
+	parseAddressablesLog(strings.NewReader("[UniForge:Addressables] error\tprofile not found: Staging\n"), report)
+	if report.Error != "profile not found: Staging" {
+		t.Errorf("Error = %q", report.Error)
+	}
+}
+
+func TestInjectAddressablesScript(t *testing.T) {
+	project := t.TempDir()
+	remove, err := injectAddressablesScript(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(project, addressablesScriptDir, "Editor", "UniForgeAddressablesBuild.cs")
+	data, err := os.ReadFile(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "class AddressablesBuild") {
+		t.Error("script does not define the build method")
+	}
+
+	// Unity creates .meta files next to the injected folder
+	if err := os.WriteFile(filepath.Join(project, addressablesScriptDir+".meta"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	remove()
+	entries, _ := os.ReadDir(filepath.Join(project, "Assets"))
+	if len(entries) != 0 {
+		t.Errorf("Assets still holds %d entries after removal", len(entries))
+	}
+}
+
+func TestCleanAddressablesCache(t *testing.T) {
+	project := t.TempDir()
+	dir := filepath.Join(project, "Library", "com.unity.addressables", "aa")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "catalog.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	freed, err := CleanAddressablesCache(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if freed != 2 {
+		t.Errorf("freed = %d, want 2", freed)
+	}
+	if _, err := os.Stat(filepath.Join(project, "Library", "com.unity.addressables")); !os.IsNotExist(err) {
+		t.Error("build cache was not removed")
+	}
+}
@@ -0,0 +1,633 @@
+package unity
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// ArchiveManifestName is the integrity manifest stored as the last entry of a project archive
+const ArchiveManifestName = ".uniforge-archive.json"
+
+// archiveProjectRecord is the PAX record naming the project at the start of an
+// archive, so a restore knows the directory before reaching the manifest
+const archiveProjectRecord = "UNIFORGE.project"
+
+// DefaultArchiveExcludes are the generated folders left out of every project archive
+var DefaultArchiveExcludes = []string{"/Library", "/Temp", "/obj", "/Logs", "/Build"}
+
+// ArchiveManifest describes the contents of a project archive
+type ArchiveManifest struct {
+	Project      string        `json:"project"`
+	UnityVersion string        `json:"unityVersion,omitempty"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	Excludes     []string      `json:"excludes"`
+	Files        []ArchiveFile `json:"files"`
+}
+
+// ArchiveFile is a file recorded in the manifest
+type ArchiveFile struct {
+	Path   string `json:"path"` // Slash-separated, relative to the project root
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+	Link   string `json:"link,omitempty"` // Target of a symlink
+}
+
+// TotalSize returns the total size of the archived files
+func (m *ArchiveManifest) TotalSize() int64 {
+	var total int64
+	for _, f := range m.Files {
+		total += f.Size
+	}
+	return total
+}
+
+// ProjectArchiver writes a compressed archive of a project without its generated folders
+type ProjectArchiver struct {
+	Project  *Project
+	Excludes []string // Extra patterns, added to DefaultArchiveExcludes
+
+	OnStart func(files int, size int64) // Called once the files to archive are known
+	OnWrite func(n int64)               // Called as file contents are written
+}
+
+// NewProjectArchiver creates an archiver for project
+func NewProjectArchiver(project *Project) *ProjectArchiver {
+	return &ProjectArchiver{Project: project}
+}
+
+// Create writes the archive to output. The compression follows the extension:
+// .tar.zst (needs the zstd command), .tar.gz or .tar.
+func (a *ProjectArchiver) Create(output string) (*ArchiveManifest, error) {
+	compression, err := archiveCompression(output)
+	if err != nil {
+		return nil, err
+	}
+
+	excludes := append(append([]string{}, DefaultArchiveExcludes...), a.Excludes...)
+	outputAbs, err := filepath.Abs(output)
+	if err != nil {
+		return nil, err
+	}
+	files, err := collectArchiveFiles(a.Project.Path, excludes, outputAbs)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+	if a.OnStart != nil {
+		a.OnStart(len(files), total)
+	}
+
+	manifest := &ArchiveManifest{
+		Project:      a.Project.Name,
+		UnityVersion: a.Project.UnityVersion,
+		CreatedAt:    time.Now().UTC(),
+		Excludes:     excludes,
+	}
+	records := map[string]string{archiveProjectRecord: manifest.Project}
+	err = writeArchive(outputAbs, compression, a.Project.Path, files, records, a.OnWrite, func(archived []ArchiveFile) (string, any) {
+		manifest.Files = archived
+		return ArchiveManifestName, manifest
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeArchive writes files below root to a tar archive at output, starting
+// with a global header holding records. The manifest returned by finish, given
+// the archived regular files and symlinks, is the last entry. The archive is
+// written next to output and renamed, so a failed run leaves no truncated file.
+func writeArchive(output, compression, root string, files []archiveEntry, records map[string]string, onWrite func(int64), finish func([]ArchiveFile) (string, any)) error {
+	tmp, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	success := false
+	defer func() {
+		if !success {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	compressed, err := compressWriter(tmp, compression)
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(compressed)
+	global := &tar.Header{
+		Typeflag:   tar.TypeXGlobalHeader,
+		PAXRecords: records,
+	}
+	if err := tw.WriteHeader(global); err != nil {
+		_ = compressed.Close()
+		return err
+	}
+	var archived []ArchiveFile
+	for _, f := range files {
+		entry, err := writeArchiveEntry(tw, root, f, onWrite)
+		if err != nil {
+			_ = compressed.Close()
+			return fmt.Errorf("failed to archive %s: %w", f.Path, err)
+		}
+		if !f.Info.IsDir() {
+			archived = append(archived, entry)
+		}
+	}
+	name, manifest := finish(archived)
+	if err := writeArchiveManifest(tw, name, manifest); err != nil {
+		_ = compressed.Close()
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		_ = compressed.Close()
+		return err
+	}
+	if err := compressed.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), output); err != nil {
+		return err
+	}
+	success = true
+	return nil
+}
+
+// archiveEntry is a file found while walking the project
+type archiveEntry struct {
+	Path string // Slash-separated, relative to the project root
+	Size int64
+	Info fs.FileInfo
+}
+
+// collectArchiveFiles walks root and returns the files and directories to archive
+func collectArchiveFiles(root string, excludes []string, skip ...string) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		for _, s := range skip {
+			if p == s || strings.HasPrefix(filepath.Base(p), "."+filepath.Base(s)+".tmp-") {
+				return nil
+			}
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchArchiveExclude(rel, excludes) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := info.Mode()
+		if !mode.IsRegular() && !mode.IsDir() && mode&fs.ModeSymlink == 0 {
+			ui.Debug("Skipping special file", "path", rel, "mode", mode)
+			return nil
+		}
+		entry := archiveEntry{Path: rel, Info: info}
+		if mode.IsRegular() {
+			entry.Size = info.Size()
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	return entries, err
+}
+
+// matchArchiveExclude reports whether rel matches one of the exclude patterns.
+// As in .gitignore, a pattern starting with "/" or containing "/" is matched
+// against the path from the project root; other patterns match a file or folder
+// name at any depth.
+func matchArchiveExclude(rel string, patterns []string) bool {
+	name := path.Base(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.TrimSpace(pattern), "/")
+		if pattern == "" {
+			continue
+		}
+		if strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(strings.TrimPrefix(pattern, "/"), rel); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// writeArchiveEntry adds one file, directory or symlink below root to the archive
+func writeArchiveEntry(tw *tar.Writer, root string, f archiveEntry, onWrite func(int64)) (ArchiveFile, error) {
+	link := ""
+	if f.Info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(filepath.Join(root, filepath.FromSlash(f.Path)))
+		if err != nil {
+			return ArchiveFile{}, err
+		}
+		link = target
+	}
+	header, err := tar.FileInfoHeader(f.Info, link)
+	if err != nil {
+		return ArchiveFile{}, err
+	}
+	header.Name = f.Path
+	if f.Info.IsDir() {
+		header.Name += "/"
+	}
+	// Owner names differ between machines and are not restored
+	header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+	if err := tw.WriteHeader(header); err != nil {
+		return ArchiveFile{}, err
+	}
+
+	entry := ArchiveFile{Path: f.Path, Link: link}
+	if !f.Info.Mode().IsRegular() {
+		return entry, nil
+	}
+
+	file, err := os.Open(filepath.Join(root, filepath.FromSlash(f.Path)))
+	if err != nil {
+		return ArchiveFile{}, err
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	w := io.MultiWriter(tw, hash)
+	if onWrite != nil {
+		w = io.MultiWriter(w, progressWriter(onWrite))
+	}
+	n, err := io.Copy(w, file)
+	if err != nil {
+		return ArchiveFile{}, err
+	}
+	entry.Size = n
+	entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return entry, nil
+}
+
+// writeArchiveManifest appends the manifest to the archive as name
+func writeArchiveManifest(tw *tar.Writer, name string, manifest any) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// RestoreOptions configures RestoreArchive
+type RestoreOptions struct {
+	Overwrite bool             // Replace files that already exist in the destination
+	OnStart   func(size int64) // Called with the size of the archive file
+	OnRead    func(n int64)    // Called as the archive file is read
+}
+
+// RestoreArchive extracts a project archive into dest and verifies every file
+// against the archive's manifest. An empty dest restores into a directory named
+// after the project in the current directory.
+func RestoreArchive(archive, dest string, opts RestoreOptions) (*ArchiveManifest, error) {
+	compression, err := archiveCompression(archive)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(archive)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errs.New(errs.NotFound, "archive not found: %s", archive)
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	if opts.OnStart != nil {
+		if info, err := file.Stat(); err == nil {
+			opts.OnStart(info.Size())
+		}
+	}
+	var src io.Reader = file
+	if opts.OnRead != nil {
+		src = io.TeeReader(file, progressWriter(opts.OnRead))
+	}
+	decompressed, err := decompressReader(src, compression)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = decompressed.Close() }()
+
+	var manifest *ArchiveManifest
+	hashes := make(map[string]string)
+	tr := tar.NewReader(decompressed)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			if name := filepath.Base(header.PAXRecords[archiveProjectRecord]); dest == "" && name != "." && name != ".." && name != string(filepath.Separator) {
+				dest = name
+			}
+			continue
+		}
+		if dest == "" {
+			return nil, fmt.Errorf("%s does not name its project, pass the directory to restore into", filepath.Base(archive))
+		}
+		if header.Name == ArchiveManifestName {
+			manifest = &ArchiveManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("failed to read archive manifest: %w", err)
+			}
+			continue
+		}
+		hash, err := restoreEntry(tr, header, dest, opts.Overwrite)
+		if err != nil {
+			return nil, err
+		}
+		if hash != "" {
+			hashes[strings.TrimSuffix(header.Name, "/")] = hash
+		}
+	}
+	if err := decompressed.Close(); err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("%s has no manifest, it was not created by 'uniforge project archive'", filepath.Base(archive))
+	}
+	if err := verifyArchive(manifest.Files, hashes); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// restoreEntry writes one archive entry below dest and returns the SHA-256 of a regular file
+func restoreEntry(tr *tar.Reader, header *tar.Header, dest string, overwrite bool) (string, error) {
+	name := path.Clean(strings.TrimSuffix(header.Name, "/"))
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", fmt.Errorf("invalid path in archive: %s", header.Name)
+	}
+	target := filepath.Join(dest, filepath.FromSlash(name))
+	// A symlink restored earlier must not redirect this entry outside dest
+	if err := checkRestoreParents(dest, name); err != nil {
+		return "", err
+	}
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("invalid path in archive: %s is a symlink", header.Name)
+		}
+		return "", os.MkdirAll(target, 0755)
+	case tar.TypeSymlink:
+		if !symlinkInside(name, header.Linkname) {
+			return "", fmt.Errorf("invalid symlink in archive: %s -> %s", header.Name, header.Linkname)
+		}
+		if err := prepareRestoreTarget(target, overwrite); err != nil {
+			return "", err
+		}
+		return "", os.Symlink(header.Linkname, target)
+	case tar.TypeReg:
+		if err := prepareRestoreTarget(target, overwrite); err != nil {
+			return "", err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, header.FileInfo().Mode().Perm())
+		if err != nil {
+			return "", err
+		}
+		hash := sha256.New()
+		_, err = io.Copy(io.MultiWriter(out, hash), tr)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+		_ = os.Chtimes(target, header.ModTime, header.ModTime)
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+	ui.Debug("Skipping unsupported archive entry", "name", header.Name, "type", header.Typeflag)
+	return "", nil
+}
+
+// checkRestoreParents fails when a directory on the way from dest to the
+// slash-separated name is a symlink
+func checkRestoreParents(dest, name string) error {
+	dir := dest
+	parts := strings.Split(name, "/")
+	for _, part := range parts[:len(parts)-1] {
+		dir = filepath.Join(dir, part)
+		info, err := os.Lstat(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("invalid path in archive: %s leads through the symlink %s", name, filepath.ToSlash(strings.TrimPrefix(dir, dest+string(filepath.Separator))))
+		}
+	}
+	return nil
+}
+
+// symlinkInside reports whether the symlink name pointing to linkname stays
+// within the directory it is restored into
+func symlinkInside(name, linkname string) bool {
+	if linkname == "" || path.IsAbs(linkname) || filepath.IsAbs(linkname) || filepath.VolumeName(linkname) != "" {
+		return false
+	}
+	resolved := path.Join(path.Dir(name), filepath.ToSlash(linkname))
+	return resolved != ".." && !strings.HasPrefix(resolved, "../")
+}
+
+// prepareRestoreTarget creates the parent of target and removes an existing file if allowed
+func prepareRestoreTarget(target string, overwrite bool) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if _, err := os.Lstat(target); err == nil {
+		if !overwrite {
+			return errs.WithHint(errs.New(errs.Usage, "%s already exists", target), "Use --force to overwrite existing files, or restore into another directory.")
+		}
+		return os.Remove(target)
+	}
+	return nil
+}
+
+// verifyArchive compares the restored files against the manifest's files
+func verifyArchive(files []ArchiveFile, hashes map[string]string) error {
+	var problems []string
+	for _, f := range files {
+		if f.SHA256 == "" {
+			continue
+		}
+		got, ok := hashes[f.Path]
+		switch {
+		case !ok:
+			problems = append(problems, "missing "+f.Path)
+		case got != f.SHA256:
+			problems = append(problems, "checksum mismatch "+f.Path)
+		}
+		delete(hashes, f.Path)
+	}
+	for p := range hashes {
+		problems = append(problems, "not in manifest "+p)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > 5 {
+		problems = append(problems[:5], fmt.Sprintf("and %d more", len(problems)-5))
+	}
+	return fmt.Errorf("archive failed verification: %s", strings.Join(problems, ", "))
+}
+
+// progressWriter adapts a progress callback to io.Writer
+type progressWriter func(n int64)
+
+func (f progressWriter) Write(b []byte) (int, error) {
+	f(int64(len(b)))
+	return len(b), nil
+}
+
+// archiveCompression returns the compression of an archive path: "zstd", "gzip" or ""
+func archiveCompression(name string) (string, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.zst"), strings.HasSuffix(lower, ".tzst"):
+		return "zstd", nil
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "gzip", nil
+	case strings.HasSuffix(lower, ".tar"):
+		return "", nil
+	}
+	return "", errs.New(errs.Usage, "unsupported archive format: %s (use .tar.zst, .tar.gz or .tar)", filepath.Base(name))
+}
+
+// compressWriter wraps w with the given compression
+func compressWriter(w io.Writer, compression string) (io.WriteCloser, error) {
+	switch compression {
+	case "gzip":
+		return gzip.NewWriter(w), nil
+	case "zstd":
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return nil, errs.WithHint(errs.New(errs.NotInstalled, "zstd is required for .tar.zst archives"), "Install zstd, or write a .tar.gz archive instead.")
+		}
+		cmd := exec.Command("zstd", "-q", "-T0", "-c")
+		cmd.Stdout = w
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start zstd: %w", err)
+		}
+		return &commandWriter{WriteCloser: stdin, cmd: cmd}, nil
+	}
+	return nopWriteCloser{w}, nil
+}
+
+// decompressReader wraps r with the given decompression
+func decompressReader(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case "gzip":
+		return gzip.NewReader(r)
+	case "zstd":
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return nil, errs.New(errs.NotInstalled, "zstd is required for .tar.zst archives")
+		}
+		cmd := exec.Command("zstd", "-q", "-d", "-c")
+		cmd.Stdin = r
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start zstd: %w", err)
+		}
+		return &commandReader{ReadCloser: stdout, cmd: cmd}, nil
+	}
+	return io.NopCloser(r), nil
+}
+
+// commandWriter feeds a compressor's stdin; Close waits for it to finish
+type commandWriter struct {
+	io.WriteCloser
+	cmd *exec.Cmd
+}
+
+func (w *commandWriter) Close() error {
+	err := w.WriteCloser.Close()
+	if waitErr := w.cmd.Wait(); err == nil {
+		err = waitErr
+	}
+	return err
+}
+
+// commandReader reads a decompressor's stdout; Close waits for it to finish
+type commandReader struct {
+	io.ReadCloser
+	cmd    *exec.Cmd
+	closed bool
+}
+
+func (r *commandReader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	// Drain so the decompressor is not blocked writing trailing data
+	_, _ = io.Copy(io.Discard, r.ReadCloser)
+	return r.cmd.Wait()
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
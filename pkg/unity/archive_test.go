@@ -0,0 +1,278 @@
+package unity
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// newArchiveTestProject creates a project with source files and generated folders
+func newArchiveTestProject(t *testing.T) *Project {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "MyGame")
+	files := map[string]string{
+		"Assets/Player.cs":                   "class Player {}",
+		"Assets/Build/BuildScript.cs":        "class BuildScript {}",
+		"Assets/Art/model.blend1":            "backup",
+		"Packages/manifest.json":             `{"dependencies":{}}`,
+		"ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 2022.3.10f1",
+		"Library/ArtifactDB":                 "cache",
+		"Temp/lock":                          "",
+		"Logs/Editor.log":                    "log",
+		"obj/Debug/a.dll":                    "dll",
+		"Build/game.apk":                     "apk",
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	project, err := LoadProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+func TestMatchArchiveExclude(t *testing.T) {
+	patterns := append(append([]string{}, DefaultArchiveExcludes...), "*.blend1", "/Assets/StreamingAssets/")
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{"Library", true},
+		{"Build", true},
+		{"Assets/Build", false},
+		{"Assets/Library", false},
+		{"Assets/Art/model.blend1", true},
+		{"Assets/StreamingAssets", true},
+		{"Assets/Player.cs", false},
+	}
+	for _, tt := range tests {
+		if got := matchArchiveExclude(tt.rel, patterns); got != tt.want {
+			t.Errorf("matchArchiveExclude(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	formats := []string{"MyGame.tar.gz", "MyGame.tar"}
+	if _, err := exec.LookPath("zstd"); err == nil {
+		formats = append(formats, "MyGame.tar.zst")
+	}
+
+	for _, name := range formats {
+		t.Run(name, func(t *testing.T) {
+			project := newArchiveTestProject(t)
+			// Writing into the project must not archive the archive itself
+			output := filepath.Join(project.Path, name)
+
+			archiver := NewProjectArchiver(project)
+			archiver.Excludes = []string{"*.blend1"}
+			var started, written int64
+			archiver.OnStart = func(_ int, size int64) { started = size }
+			archiver.OnWrite = func(n int64) { written += n }
+
+			manifest, err := archiver.Create(output)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if started == 0 || written != started || manifest.TotalSize() != started {
+				t.Errorf("progress: start %d, written %d, manifest %d", started, written, manifest.TotalSize())
+			}
+			var paths []string
+			for _, f := range manifest.Files {
+				paths = append(paths, f.Path)
+			}
+			want := "Assets/Build/BuildScript.cs,Assets/Player.cs,Packages/manifest.json,ProjectSettings/ProjectVersion.txt"
+			if got := strings.Join(paths, ","); got != want {
+				t.Errorf("archived files = %s, want %s", got, want)
+			}
+
+			dest := filepath.Join(t.TempDir(), "restored")
+			restored, err := RestoreArchive(output, dest, RestoreOptions{})
+			if err != nil {
+				t.Fatalf("RestoreArchive() error = %v", err)
+			}
+			if restored.Project != "MyGame" || restored.UnityVersion != "2022.3.10f1" {
+				t.Errorf("manifest = %+v", restored)
+			}
+			data, err := os.ReadFile(filepath.Join(dest, "Assets", "Player.cs"))
+			if err != nil || string(data) != "class Player {}" {
+				t.Errorf("Assets/Player.cs = %q, %v", data, err)
+			}
+			if fileExists(filepath.Join(dest, "Library")) || fileExists(filepath.Join(dest, ArchiveManifestName)) {
+				t.Error("excluded folders or the manifest were restored")
+			}
+
+			// Restoring again needs Overwrite
+			if _, err := RestoreArchive(output, dest, RestoreOptions{}); !errors.Is(err, errs.Usage) {
+				t.Errorf("RestoreArchive() into existing files error = %v, want Usage", err)
+			}
+			if _, err := RestoreArchive(output, dest, RestoreOptions{Overwrite: true}); err != nil {
+				t.Errorf("RestoreArchive(Overwrite) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRestoreArchiveIntoProjectName(t *testing.T) {
+	project := newArchiveTestProject(t)
+	output := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if _, err := NewProjectArchiver(project).Create(output); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if _, err := RestoreArchive(output, "", RestoreOptions{}); err != nil {
+		t.Fatalf("RestoreArchive() error = %v", err)
+	}
+	if !fileExists(filepath.Join(dir, "MyGame", "Assets", "Player.cs")) {
+		t.Error("project not restored into MyGame")
+	}
+}
+
+func TestRestoreArchiveDetectsCorruption(t *testing.T) {
+	project := newArchiveTestProject(t)
+	output := filepath.Join(t.TempDir(), "backup.tar")
+	if _, err := NewProjectArchiver(project).Create(output); err != nil {
+		t.Fatal(err)
+	}
+
+	// Flip the contents of Assets/Player.cs inside the uncompressed archive
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), "class Player {}", "class Hacked {}", 1)
+	if err := os.WriteFile(output, []byte(tampered), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RestoreArchive(output, t.TempDir(), RestoreOptions{})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch Assets/Player.cs") {
+		t.Errorf("RestoreArchive() error = %v, want checksum mismatch", err)
+	}
+}
+
+func TestRestoreArchiveRejectsForeignArchives(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "other.tar.gz")
+	f, err := os.Create(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"../escape.txt", "file.txt"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 1}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte("x")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	_ = f.Close()
+
+	dest := filepath.Join(t.TempDir(), "dest")
+	if _, err := RestoreArchive(output, dest, RestoreOptions{}); err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("RestoreArchive() error = %v, want invalid path", err)
+	}
+	if _, err := RestoreArchive(output, "", RestoreOptions{}); err == nil {
+		t.Error("RestoreArchive() without a project name should fail")
+	}
+}
+
+// writeTestTar writes a gzipped tar of headers, giving regular files the content "x"
+func writeTestTar(t *testing.T, headers ...*tar.Header) string {
+	t.Helper()
+	output := filepath.Join(t.TempDir(), "crafted.tar.gz")
+	f, err := os.Create(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, h := range headers {
+		if h.Typeflag == tar.TypeReg {
+			h.Size = 1
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte("x")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	_ = f.Close()
+	return output
+}
+
+func TestRestoreArchiveRejectsEscapingSymlinks(t *testing.T) {
+	outside := t.TempDir()
+	for _, link := range []string{outside, "../../outside", "Assets/../../outside"} {
+		archive := writeTestTar(t,
+			&tar.Header{Name: "a", Typeflag: tar.TypeSymlink, Linkname: link, Mode: 0777},
+			&tar.Header{Name: "a/x", Typeflag: tar.TypeReg, Mode: 0644},
+		)
+		dest := filepath.Join(t.TempDir(), "dest")
+		if _, err := RestoreArchive(archive, dest, RestoreOptions{}); err == nil || !strings.Contains(err.Error(), "invalid symlink") {
+			t.Errorf("RestoreArchive() with a -> %s error = %v, want invalid symlink", link, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outside, "x")); !os.IsNotExist(err) {
+		t.Error("archive wrote outside the restore directory")
+	}
+}
+
+func TestRestoreArchiveRefusesEntriesThroughSymlinks(t *testing.T) {
+	// The link stays inside, but nothing may be written through it
+	archive := writeTestTar(t,
+		&tar.Header{Name: "Assets/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "Assets", Mode: 0777},
+		&tar.Header{Name: "link/x", Typeflag: tar.TypeReg, Mode: 0644},
+	)
+	dest := filepath.Join(t.TempDir(), "dest")
+	if _, err := RestoreArchive(archive, dest, RestoreOptions{}); err == nil || !strings.Contains(err.Error(), "symlink link") {
+		t.Errorf("RestoreArchive() error = %v, want refusal to write through link", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "Assets", "x")); !os.IsNotExist(err) {
+		t.Error("archive wrote through a symlink")
+	}
+
+	// A symlink replaced by a directory entry is refused as well
+	archive = writeTestTar(t,
+		&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: ".", Mode: 0777},
+		&tar.Header{Name: "link/", Typeflag: tar.TypeDir, Mode: 0755},
+	)
+	dest = filepath.Join(t.TempDir(), "dest")
+	if _, err := RestoreArchive(archive, dest, RestoreOptions{}); err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("RestoreArchive() error = %v, want invalid path", err)
+	}
+}
+
+func TestArchiveCompression(t *testing.T) {
+	if _, err := archiveCompression("backup.zip"); !errors.Is(err, errs.Usage) {
+		t.Errorf("archiveCompression(.zip) error = %v, want Usage", err)
+	}
+	if c, err := archiveCompression("Backup.TGZ"); err != nil || c != "gzip" {
+		t.Errorf("archiveCompression(.TGZ) = %q, %v", c, err)
+	}
+}
@@ -0,0 +1,314 @@
+package unity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// AssemblyDefinition is an .asmdef file of a project
+type AssemblyDefinition struct {
+	Name                  string   `json:"name"`
+	RootNamespace         string   `json:"rootNamespace"`
+	References            []string `json:"references"` // Assembly names or "GUID:<guid of the .asmdef>"
+	IncludePlatforms      []string `json:"includePlatforms"`
+	OverrideReferences    bool     `json:"overrideReferences"`
+	PrecompiledReferences []string `json:"precompiledReferences"` // DLL file names, used with OverrideReferences
+
+	Path    string `json:"-"` // Relative to the project, with forward slashes
+	GUID    string `json:"-"` // From the .asmdef.meta
+	Package string `json:"-"` // Embedded or local package containing the file, "" under Assets
+}
+
+// IsEditorOnly reports whether the assembly is only compiled for the editor
+func (a *AssemblyDefinition) IsEditorOnly() bool {
+	return len(a.IncludePlatforms) == 1 && a.IncludePlatforms[0] == "Editor"
+}
+
+// LoadAssemblyDefinitions reads the .asmdef files under Assets and Packages
+// of the project at projectPath, skipping folders Unity ignores
+func LoadAssemblyDefinitions(projectPath string) ([]AssemblyDefinition, error) {
+	var assemblies []AssemblyDefinition
+	err := walkAssetRoots(projectPath, []string{"Assets", "Packages"}, func(path string) error {
+		if filepath.Ext(path) != ".asmdef" {
+			return nil
+		}
+		asm, err := readAssemblyDefinition(path)
+		if err != nil {
+			return err
+		}
+		if asm.Name == "" {
+			return nil
+		}
+		relPath, _ := filepath.Rel(projectPath, path)
+		asm.Path = filepath.ToSlash(relPath)
+		asm.Package = owningPackage(projectPath, path)
+		assemblies = append(assemblies, *asm)
+		return nil
+	})
+	return assemblies, err
+}
+
+func readAssemblyDefinition(path string) (*AssemblyDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var asm AssemblyDefinition
+	if err := json.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &asm); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if guid, err := extractGUID(path + ".meta"); err == nil {
+		asm.GUID = guid
+	}
+	return &asm, nil
+}
+
+// walkAssetRoots calls fn for every file under the given project folders,
+// skipping hidden folders and those ending in ~ like Unity does
+func walkAssetRoots(projectPath string, roots []string, fn func(path string) error) error {
+	for _, root := range roots {
+		rootPath := filepath.Join(projectPath, root)
+		err := filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if d.IsDir() {
+				if name := d.Name(); path != rootPath && (strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			return fn(path)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsmdefIssue is a problem AnalyzeAssemblies found in an assembly definition
+// or precompiled DLL
+type AsmdefIssue struct {
+	Path    string `json:"path"`           // Relative to the project
+	Line    int    `json:"line,omitempty"` // 1-based, 0 if unknown
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Warning bool   `json:"warning,omitempty"` // Compiles, but probably not what was meant
+}
+
+// Location returns "path:line" for editors and terminals to jump to
+func (i AsmdefIssue) Location() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d", i.Path, i.Line)
+	}
+	return i.Path
+}
+
+// Rules reported by AnalyzeAssemblies
+const (
+	AsmdefRuleDuplicateName           = "duplicate-name"
+	AsmdefRuleRootNamespace           = "root-namespace"
+	AsmdefRuleMissingReference        = "missing-reference"
+	AsmdefRuleEditorReference         = "editor-reference"
+	AsmdefRuleMissingPrecompiled      = "missing-precompiled"
+	AsmdefRuleUnreferencedPrecompiled = "unreferenced-precompiled"
+)
+
+var namespacePattern = regexp.MustCompile(`^@?[A-Za-z_][A-Za-z0-9_]*(\.@?[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// AnalyzeAssemblies checks the assembly definitions of the project at
+// projectPath for names used twice, invalid root namespaces, references that
+// resolve to nothing, runtime assemblies referencing editor-only ones, and
+// precompiled DLLs that are missing or that no assembly references.
+//
+// Assemblies and DLLs of registry packages are only known once Unity has
+// filled Library/PackageCache; until then references to them are not reported.
+func AnalyzeAssemblies(projectPath string) ([]AsmdefIssue, error) {
+	assemblies, err := LoadAssemblyDefinitions(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]*AssemblyDefinition)
+	byGUID := make(map[string]*AssemblyDefinition)
+	for i := range assemblies {
+		known[assemblies[i].Name] = &assemblies[i]
+		if assemblies[i].GUID != "" {
+			byGUID[assemblies[i].GUID] = &assemblies[i]
+		}
+	}
+
+	// Assemblies and DLLs of packages Unity has downloaded
+	packageCache := filepath.Join("Library", "PackageCache")
+	_, err = os.Stat(filepath.Join(projectPath, packageCache))
+	cacheAvailable := err == nil
+	var cached []*AssemblyDefinition
+	dlls := make(map[string]bool)
+	if cacheAvailable {
+		err := walkAssetRoots(projectPath, []string{packageCache}, func(path string) error {
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".asmdef":
+				if asm, err := readAssemblyDefinition(path); err == nil && asm.Name != "" {
+					cached = append(cached, asm)
+				}
+			case ".dll":
+				dlls[filepath.Base(path)] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, asm := range cached {
+		if _, ok := known[asm.Name]; !ok {
+			known[asm.Name] = asm
+		}
+		if _, ok := byGUID[asm.GUID]; !ok && asm.GUID != "" {
+			byGUID[asm.GUID] = asm
+		}
+	}
+
+	// Precompiled DLLs of the project; explicitly referenced ones are only
+	// visible to assemblies that list them
+	explicitDLLs := make(map[string]string)
+	err = walkAssetRoots(projectPath, []string{"Assets", "Packages"}, func(path string) error {
+		if !strings.EqualFold(filepath.Ext(path), ".dll") {
+			return nil
+		}
+		name := filepath.Base(path)
+		dlls[name] = true
+		if isExplicitlyReferenced(path + ".meta") {
+			relPath, _ := filepath.Rel(projectPath, path)
+			explicitDLLs[name] = filepath.ToSlash(relPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []AsmdefIssue
+	lines := make(map[string][]byte)
+	issue := func(asm *AssemblyDefinition, rule, needle string, format string, args ...any) {
+		content, ok := lines[asm.Path]
+		if !ok {
+			content, _ = os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(asm.Path)))
+			lines[asm.Path] = content
+		}
+		issues = append(issues, AsmdefIssue{
+			Path:    asm.Path,
+			Line:    lineOf(content, needle),
+			Rule:    rule,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	names := make(map[string][]string)
+	for _, asm := range assemblies {
+		names[asm.Name] = append(names[asm.Name], asm.Path)
+	}
+
+	referencedDLLs := make(map[string]bool)
+	for i := range assemblies {
+		asm := &assemblies[i]
+		nameJSON := jsonString(asm.Name)
+
+		if paths := names[asm.Name]; len(paths) > 1 {
+			others := slices.DeleteFunc(slices.Clone(paths), func(p string) bool { return p == asm.Path })
+			issue(asm, AsmdefRuleDuplicateName, nameJSON, "assembly name %q is also used by %s", asm.Name, strings.Join(others, ", "))
+		}
+
+		if asm.RootNamespace != "" && !namespacePattern.MatchString(asm.RootNamespace) {
+			issue(asm, AsmdefRuleRootNamespace, jsonString(asm.RootNamespace), "root namespace %q is not a valid C# namespace", asm.RootNamespace)
+		}
+
+		for _, ref := range asm.References {
+			var target *AssemblyDefinition
+			if guid, ok := strings.CutPrefix(ref, "GUID:"); ok {
+				target = byGUID[guid]
+			} else {
+				target = known[ref]
+			}
+			if target == nil {
+				if cacheAvailable {
+					issue(asm, AsmdefRuleMissingReference, jsonString(ref), "reference %s does not match any assembly definition", ref)
+				}
+				continue
+			}
+			if target.IsEditorOnly() && !asm.IsEditorOnly() {
+				issue(asm, AsmdefRuleEditorReference, jsonString(ref),
+					"runtime assembly %s references editor-only assembly %s; player builds will fail", asm.Name, target.Name)
+			}
+		}
+
+		// precompiledReferences only apply with overrideReferences
+		if !asm.OverrideReferences {
+			continue
+		}
+		for _, dll := range asm.PrecompiledReferences {
+			referencedDLLs[dll] = true
+			if !dlls[dll] && cacheAvailable {
+				issue(asm, AsmdefRuleMissingPrecompiled, jsonString(dll), "precompiled reference %s was not found in the project", dll)
+			}
+		}
+	}
+
+	for name, path := range explicitDLLs {
+		if !referencedDLLs[name] {
+			issues = append(issues, AsmdefIssue{
+				Path:    path,
+				Rule:    AsmdefRuleUnreferencedPrecompiled,
+				Message: fmt.Sprintf("%s is set to be explicitly referenced, but no assembly definition lists it", name),
+				Warning: true,
+			})
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b AsmdefIssue) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return a.Line - b.Line
+	})
+	return issues, nil
+}
+
+// isExplicitlyReferenced reads the PluginImporter setting of a DLL's .meta
+func isExplicitlyReferenced(metaPath string) bool {
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return false
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if strings.TrimSpace(line) == "isExplicitlyReferenced: 1" {
+			return true
+		}
+	}
+	return false
+}
+
+// lineOf returns the 1-based line of the first occurrence of needle in content, or 0
+func lineOf(content []byte, needle string) int {
+	i := bytes.Index(content, []byte(needle))
+	if i < 0 {
+		return 0
+	}
+	return bytes.Count(content[:i], []byte("\n")) + 1
+}
+
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
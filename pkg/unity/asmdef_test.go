@@ -0,0 +1,89 @@
+package unity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeAssemblies(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"Assets/Game/Game.asmdef": `{
+	"name": "Game",
+	"rootNamespace": "My-Game",
+	"references": [
+		"Game.Editor",
+		"GUID:0123456789abcdef0123456789abcdef",
+		"Unity.TextMeshPro",
+		"Missing.Assembly"
+	],
+	"overrideReferences": true,
+	"precompiledReferences": ["Newtonsoft.Json.dll", "Gone.dll"]
+}`,
+		"Assets/Game/Editor/Game.Editor.asmdef":   `{"name": "Game.Editor", "rootNamespace": "Game.Editor", "references": ["Game"], "includePlatforms": ["Editor"]}`,
+		"Assets/Game/Tools/Tools.asmdef":          `{"name": "Game.Tools", "includePlatforms": ["Editor"]}`,
+		"Assets/Game/Tools/Tools.asmdef.meta":     "fileFormatVersion: 2\nguid: 0123456789abcdef0123456789abcdef\n",
+		"Assets/Copy/Game.asmdef":                 `{"name": "Game"}`,
+		"Assets/Plugins/Newtonsoft.Json.dll":      "",
+		"Assets/Plugins/Newtonsoft.Json.dll.meta": "PluginImporter:\n  isExplicitlyReferenced: 1\n",
+		"Assets/Plugins/Unused.dll":               "",
+		"Assets/Plugins/Unused.dll.meta":          "PluginImporter:\n  isExplicitlyReferenced: 1\n",
+		"Assets/Plugins/Implicit.dll":             "",
+		"Assets/Plugins/Implicit.dll.meta":        "PluginImporter:\n  isExplicitlyReferenced: 0\n",
+		"Library/PackageCache/com.unity.textmeshpro@3.0.6/Scripts/Runtime/Unity.TextMeshPro.asmdef": `{"name": "Unity.TextMeshPro"}`,
+	})
+
+	issues, err := AnalyzeAssemblies(dir)
+	if err != nil {
+		t.Fatalf("AnalyzeAssemblies() error = %v", err)
+	}
+
+	got := make(map[string]AsmdefIssue)
+	for _, issue := range issues {
+		got[issue.Rule+" "+issue.Location()] = issue
+	}
+	want := map[string]bool{
+		"duplicate-name Assets/Copy/Game.asmdef:1":           false,
+		"duplicate-name Assets/Game/Game.asmdef:2":           false,
+		"root-namespace Assets/Game/Game.asmdef:3":           false,
+		"editor-reference Assets/Game/Game.asmdef:5":         false,
+		"editor-reference Assets/Game/Game.asmdef:6":         false, // By GUID
+		"missing-reference Assets/Game/Game.asmdef:8":        false,
+		"missing-precompiled Assets/Game/Game.asmdef:11":     false,
+		"unreferenced-precompiled Assets/Plugins/Unused.dll": true,
+	}
+	for key, warning := range want {
+		issue, ok := got[key]
+		if !ok {
+			t.Errorf("missing issue %s", key)
+			continue
+		}
+		if issue.Warning != warning {
+			t.Errorf("%s: Warning = %v, want %v", key, issue.Warning, warning)
+		}
+	}
+	if len(issues) != len(want) {
+		for _, issue := range issues {
+			t.Logf("%s %s: %s", issue.Rule, issue.Location(), issue.Message)
+		}
+		t.Errorf("got %d issues, want %d", len(issues), len(want))
+	}
+	if msg := got["duplicate-name Assets/Game/Game.asmdef:2"].Message; !strings.Contains(msg, "Assets/Copy/Game.asmdef") {
+		t.Errorf("duplicate-name message = %q, want the other file", msg)
+	}
+}
+
+func TestAnalyzeAssembliesWithoutPackageCache(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"Assets/Game.asmdef": `{"name": "Game", "references": ["Unity.TextMeshPro"], "overrideReferences": true, "precompiledReferences": ["nunit.framework.dll"]}`,
+	})
+
+	issues, err := AnalyzeAssemblies(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("references to packages Unity has not downloaded yet were reported: %+v", issues)
+	}
+}
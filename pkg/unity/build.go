@@ -0,0 +1,431 @@
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/upload"
+	"gopkg.in/yaml.v3"
+)
+
+// BuildConfigFile is the build matrix definition inside ProjectConfigDir
+const BuildConfigFile = "build.yaml"
+
+// argCustomBuildPath tells the build method where to write its artifacts
+const argCustomBuildPath = "-customBuildPath"
+
+// Defaults for build.yaml
+const (
+	defaultBuildOutput  = "Builds"
+	defaultBuildTimeout = 3600
+)
+
+// buildWorkDir holds project mirrors for parallel lanes, inside the output directory
+const buildWorkDir = ".work"
+
+// BuildConfig is the contents of .uniforge/build.yaml
+type BuildConfig struct {
+	Method   string         `yaml:"method"`   // Default static build method (-executeMethod)
+	Output   string         `yaml:"output"`   // Output directory, relative to the project
+	Parallel bool           `yaml:"parallel"` // Build different editor versions concurrently
+	Timeout  int            `yaml:"timeout"`  // Per-target timeout in seconds
+	Upload   *upload.Config `yaml:"upload"`   // Default upload destination of the artifacts
+	Targets  []BuildTarget  `yaml:"targets"`
+}
+
+// BuildTarget is one entry of the build matrix
+type BuildTarget struct {
+	Name   string         `yaml:"name"`   // Unique name, used for the output directory
+	Target string         `yaml:"target"` // Unity build target (-buildTarget)
+	Editor string         `yaml:"editor"` // Editor version spec, defaults to the project's version
+	Method string         `yaml:"method"` // Overrides BuildConfig.Method
+	Args   []string       `yaml:"args"`   // Extra Unity arguments
+	Upload *upload.Config `yaml:"upload"` // Overrides BuildConfig.Upload
+}
+
+// BuildConfigPath returns the path of the project's build.yaml
+func BuildConfigPath(projectPath string) string {
+	return filepath.Join(projectPath, ProjectConfigDir, BuildConfigFile)
+}
+
+// LoadBuildConfig reads and validates the project's build.yaml, filling in defaults
+func LoadBuildConfig(projectPath string) (*BuildConfig, error) {
+	path := BuildConfigPath(projectPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("build matrix not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read build matrix: %w", err)
+	}
+
+	var config BuildConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if config.Output == "" {
+		config.Output = defaultBuildOutput
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultBuildTimeout
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Validate checks that every target has a unique name, a build target and a method
+func (c *BuildConfig) Validate() error {
+	if len(c.Targets) == 0 {
+		return fmt.Errorf("no targets defined")
+	}
+
+	seen := make(map[string]bool)
+	for i, t := range c.Targets {
+		if t.Name == "" {
+			return fmt.Errorf("target #%d has no name", i+1)
+		}
+		if strings.ContainsAny(t.Name, `/\`) || t.Name == "." || t.Name == ".." {
+			return fmt.Errorf("target name %q cannot be used as a directory name", t.Name)
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("duplicate target name: %s", t.Name)
+		}
+		seen[t.Name] = true
+
+		if t.Target == "" {
+			return fmt.Errorf("target %s has no build target", t.Name)
+		}
+		if t.Method == "" && c.Method == "" {
+			return fmt.Errorf("target %s has no build method (set method globally or per target)", t.Name)
+		}
+		if dest := c.UploadFor(t); dest != nil {
+			if err := dest.Validate(); err != nil {
+				return fmt.Errorf("target %s: %w", t.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UploadFor returns where the artifacts of t are uploaded, or nil if they are not
+func (c *BuildConfig) UploadFor(t BuildTarget) *upload.Config {
+	if t.Upload != nil {
+		return t.Upload
+	}
+	return c.Upload
+}
+
+// Select returns the targets with the given names, in matrix order
+func (c *BuildConfig) Select(names []string) ([]BuildTarget, error) {
+	if len(names) == 0 {
+		return c.Targets, nil
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var selected []BuildTarget
+	for _, t := range c.Targets {
+		if wanted[t.Name] {
+			selected = append(selected, t)
+			delete(wanted, t.Name)
+		}
+	}
+	if len(wanted) > 0 {
+		var missing []string
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("unknown build target: %s", strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
+
+// BuildJob is a matrix entry with its editor version resolved
+type BuildJob struct {
+	Target  BuildTarget
+	Version string
+}
+
+// BuildResult is the outcome of one matrix entry
+type BuildResult struct {
+	Name        string        `json:"name"`
+	Target      string        `json:"target"`
+	Version     string        `json:"editorVersion"`
+	OutputDir   string        `json:"outputDir"`
+	LogFile     string        `json:"logFile"`
+	Diagnostics string        `json:"diagnosticsFile,omitempty"` // Compiler errors and warnings as JSON
+	Duration    time.Duration `json:"-"`
+	Seconds     float64       `json:"durationSeconds"`
+	Error       string        `json:"error,omitempty"`
+	Upload      string        `json:"upload,omitempty"` // URI the artifacts are uploaded to
+}
+
+// Succeeded returns true if the build finished without error
+func (r BuildResult) Succeeded() bool {
+	return r.Error == ""
+}
+
+// BuildMatrix runs build jobs for a project. Jobs run one after another unless
+// Parallel is set, in which case jobs for different editor versions run concurrently.
+// Unity locks a project while it is open, so every lane except the first builds
+// from a mirror of the project kept in <output>/.work/<version>.
+type BuildMatrix struct {
+	Project   *Project
+	Config    *BuildConfig
+	OutputDir string // Absolute output directory
+
+	// CIMode and ShowNoise are passed to the Unity run of every job
+	CIMode    bool
+	ShowNoise []logger.NoiseCategory
+
+	// OnStart and OnFinish are called as each job starts and finishes (may be nil).
+	// They can be called concurrently in parallel mode.
+	OnStart  func(job BuildJob)
+	OnFinish func(result BuildResult)
+}
+
+// Run executes jobs and returns their results in job order
+func (m *BuildMatrix) Run(jobs []BuildJob) []BuildResult {
+	results := make([]BuildResult, len(jobs))
+
+	if !m.Config.Parallel {
+		for i, job := range jobs {
+			results[i] = m.runJob(m.Project.Path, job)
+		}
+		return results
+	}
+
+	// Group jobs into lanes by editor version, keeping the first occurrence order
+	var versions []string
+	lanes := make(map[string][]int)
+	for i, job := range jobs {
+		if _, ok := lanes[job.Version]; !ok {
+			versions = append(versions, job.Version)
+		}
+		lanes[job.Version] = append(lanes[job.Version], i)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for laneIndex, version := range versions {
+		wg.Add(1)
+		go func(laneIndex int, version string) {
+			defer wg.Done()
+
+			projectPath := m.Project.Path
+			if laneIndex > 0 {
+				mirror := filepath.Join(m.OutputDir, buildWorkDir, version, filepath.Base(m.Project.Path))
+				if err := MirrorProject(m.Project.Path, mirror, m.OutputDir); err != nil {
+					mu.Lock()
+					for _, i := range lanes[version] {
+						results[i] = m.failedResult(jobs[i], fmt.Errorf("failed to prepare project copy: %w", err))
+						if m.OnFinish != nil {
+							m.OnFinish(results[i])
+						}
+					}
+					mu.Unlock()
+					return
+				}
+				projectPath = mirror
+			}
+
+			for _, i := range lanes[version] {
+				result := m.runJob(projectPath, jobs[i])
+				mu.Lock()
+				results[i] = result
+				mu.Unlock()
+			}
+		}(laneIndex, version)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runJob builds a single matrix entry from the project at projectPath
+func (m *BuildMatrix) runJob(projectPath string, job BuildJob) BuildResult {
+	if m.OnStart != nil {
+		m.OnStart(job)
+	}
+
+	result := m.newResult(job)
+	start := time.Now()
+	err := m.build(projectPath, job, result)
+	result.Duration = time.Since(start)
+	result.Seconds = result.Duration.Seconds()
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	if m.OnFinish != nil {
+		m.OnFinish(result)
+	}
+	return result
+}
+
+func (m *BuildMatrix) build(projectPath string, job BuildJob, result BuildResult) error {
+	if err := os.MkdirAll(result.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	method := job.Target.Method
+	if method == "" {
+		method = m.Config.Method
+	}
+	args := []string{
+		"-buildTarget", job.Target.Target,
+		"-executeMethod", method,
+		argCustomBuildPath, result.OutputDir,
+	}
+	args = append(args, job.Target.Args...)
+
+	project := *m.Project
+	project.Path = projectPath
+	project.UnityVersion = job.Version
+
+	ui.Debug("Building matrix target", "name", job.Target.Name, "version", job.Version, "project", projectPath)
+	return NewRunner(&project).Run(RunConfig{
+		ProjectPath:    projectPath,
+		ExtraArgs:      args,
+		LogFile:        result.LogFile,
+		TimeoutSeconds: m.Config.Timeout,
+		CIMode:         m.CIMode,
+		ShowNoise:      m.ShowNoise,
+
+		DiagnosticsFile: result.Diagnostics,
+	})
+}
+
+func (m *BuildMatrix) newResult(job BuildJob) BuildResult {
+	outputDir := filepath.Join(m.OutputDir, job.Target.Name)
+	return BuildResult{
+		Name:        job.Target.Name,
+		Target:      job.Target.Target,
+		Version:     job.Version,
+		OutputDir:   outputDir,
+		LogFile:     filepath.Join(outputDir, "build.log"),
+		Diagnostics: filepath.Join(outputDir, "diagnostics.json"),
+	}
+}
+
+func (m *BuildMatrix) failedResult(job BuildJob, err error) BuildResult {
+	result := m.newResult(job)
+	result.Diagnostics = "" // Never built
+	result.Error = err.Error()
+	return result
+}
+
+// mirrorSkipDirs are top-level project directories that are never mirrored
+var mirrorSkipDirs = map[string]bool{
+	"Library":      true,
+	"Temp":         true,
+	"Logs":         true,
+	"obj":          true,
+	"UserSettings": true,
+	".git":         true,
+}
+
+// MirrorProject makes dst an up-to-date copy of the project at src, copying only
+// changed files and removing files that no longer exist in src. Generated folders
+// such as Library are skipped, so a mirror keeps its own import cache between runs.
+// exclude (e.g. the build output directory) is skipped as well.
+func MirrorProject(src, dst, exclude string) error {
+	skip := func(rel string, path string) bool {
+		top := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+		return mirrorSkipDirs[top] || (exclude != "" && filepath.Clean(path) == filepath.Clean(exclude))
+	}
+
+	copied := make(map[string]bool)
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return os.MkdirAll(dst, 0755)
+		}
+		if skip(rel, path) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		copied[rel] = true
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if existing, err := os.Stat(target); err == nil && existing.Size() == info.Size() && existing.ModTime().Equal(info.ModTime()) {
+			return nil
+		}
+		return copyFileWithTime(path, target, info)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Remove files deleted from the source since the last mirror
+	var stale []string
+	err = filepath.Walk(dst, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dst, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if mirrorSkipDirs[strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !copied[rel] {
+			stale = append(stale, path)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFileWithTime copies a file and preserves its modification time,
+// which MirrorProject uses to detect changes
+func copyFileWithTime(src, dst string, info os.FileInfo) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
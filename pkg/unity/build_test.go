@@ -0,0 +1,148 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeBuildConfig(t *testing.T, projectPath, content string) {
+	t.Helper()
+	dir := filepath.Join(projectPath, ProjectConfigDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, BuildConfigFile), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write build.yaml: %v", err)
+	}
+}
+
+func TestLoadBuildConfig(t *testing.T) {
+	projectPath := t.TempDir()
+	writeBuildConfig(t, projectPath, `
+method: Build.Perform
+upload:
+  provider: s3
+  bucket: builds
+  prefix: "{project}/{timestamp}"
+targets:
+  - name: android
+    target: Android
+    upload: {provider: gcs, bucket: mobile-builds}
+  - name: win-2023
+    target: StandaloneWindows64
+    editor: "2023.2"
+    method: Build.Windows
+    args: [-development]
+`)
+
+	config, err := LoadBuildConfig(projectPath)
+	if err != nil {
+		t.Fatalf("LoadBuildConfig() error = %v", err)
+	}
+	if config.Output != defaultBuildOutput || config.Timeout != defaultBuildTimeout {
+		t.Errorf("defaults not applied: output=%q timeout=%d", config.Output, config.Timeout)
+	}
+	if len(config.Targets) != 2 || config.Targets[1].Editor != "2023.2" || config.Targets[1].Args[0] != "-development" {
+		t.Errorf("Targets = %+v", config.Targets)
+	}
+
+	if dest := config.UploadFor(config.Targets[0]); dest == nil || dest.Provider != "gcs" || dest.Bucket != "mobile-builds" {
+		t.Errorf("UploadFor(android) = %+v", dest)
+	}
+	if dest := config.UploadFor(config.Targets[1]); dest == nil || dest.Bucket != "builds" || dest.Prefix != "{project}/{timestamp}" {
+		t.Errorf("UploadFor(win-2023) = %+v", dest)
+	}
+
+	selected, err := config.Select([]string{"win-2023"})
+	if err != nil || len(selected) != 1 || selected[0].Name != "win-2023" {
+		t.Errorf("Select() = %v, %v", selected, err)
+	}
+	if _, err := config.Select([]string{"ios"}); err == nil {
+		t.Error("Select() with unknown name should return an error")
+	}
+}
+
+func TestLoadBuildConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no targets", "method: Build.Perform\n", "no targets"},
+		{"duplicate", "method: M\ntargets:\n  - {name: a, target: Android}\n  - {name: a, target: iOS}\n", "duplicate"},
+		{"no method", "targets:\n  - {name: a, target: Android}\n", "no build method"},
+		{"no build target", "method: M\ntargets:\n  - {name: a}\n", "no build target"},
+		{"bad name", "method: M\ntargets:\n  - {name: a/b, target: Android}\n", "directory name"},
+		{"bad upload", "method: M\nupload: {provider: ftp, bucket: b}\ntargets:\n  - {name: a, target: Android}\n", "unknown upload provider"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectPath := t.TempDir()
+			writeBuildConfig(t, projectPath, tt.content)
+			_, err := LoadBuildConfig(projectPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadBuildConfig() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadBuildConfig(t.TempDir()); err == nil {
+		t.Error("LoadBuildConfig() without build.yaml should return an error")
+	}
+}
+
+func TestMirrorProject(t *testing.T) {
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "mirror")
+	output := filepath.Join(src, "Builds")
+
+	files := map[string]string{
+		"Assets/Player.cs":                   "class Player {}",
+		"Assets/Old.cs":                      "class Old {}",
+		"ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 2022.3.10f1",
+		"Library/ArtifactDB":                 "cache",
+		"Builds/android/build.log":           "log",
+	}
+	for rel, content := range files {
+		path := filepath.Join(src, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := MirrorProject(src, dst, output); err != nil {
+		t.Fatalf("MirrorProject() error = %v", err)
+	}
+	if !fileExists(filepath.Join(dst, "Assets", "Player.cs")) || !fileExists(filepath.Join(dst, "ProjectSettings", "ProjectVersion.txt")) {
+		t.Error("project files should be mirrored")
+	}
+	if fileExists(filepath.Join(dst, "Library", "ArtifactDB")) || fileExists(filepath.Join(dst, "Builds", "android", "build.log")) {
+		t.Error("Library and the output directory should not be mirrored")
+	}
+
+	// The mirror's own Library survives, deleted source files are removed
+	if err := os.MkdirAll(filepath.Join(dst, "Library"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dst, "Library", "mirror-cache"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(src, "Assets", "Old.cs")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MirrorProject(src, dst, output); err != nil {
+		t.Fatalf("MirrorProject() second run error = %v", err)
+	}
+	if fileExists(filepath.Join(dst, "Assets", "Old.cs")) {
+		t.Error("deleted source file should be removed from the mirror")
+	}
+	if !fileExists(filepath.Join(dst, "Library", "mirror-cache")) {
+		t.Error("mirror Library should be kept")
+	}
+}
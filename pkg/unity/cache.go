@@ -0,0 +1,29 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// UPMCacheRoot returns the Unity Package Manager global cache directory.
+// UPM_CACHE_ROOT overrides the platform default, as it does for Unity itself.
+func UPMCacheRoot() string {
+	if root := os.Getenv("UPM_CACHE_ROOT"); root != "" {
+		return root
+	}
+	home, _ := os.UserHomeDir()
+	return upmCacheRootFor(runtime.GOOS, home, os.Getenv("LOCALAPPDATA"))
+}
+
+// upmCacheRootFor returns the default UPM cache directory for goos
+func upmCacheRootFor(goos, home, localAppData string) string {
+	switch goos {
+	case "darwin":
+		return filepath.Join(home, "Library", "Unity", "cache")
+	case "windows":
+		return filepath.Join(localAppData, "Unity", "cache")
+	default:
+		return filepath.Join(home, ".config", "unity3d", "cache")
+	}
+}
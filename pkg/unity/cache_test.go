@@ -0,0 +1,22 @@
+package unity
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestUPMCacheRootFor(t *testing.T) {
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"darwin", filepath.Join("/home/dev", "Library", "Unity", "cache")},
+		{"windows", filepath.Join("/appdata/local", "Unity", "cache")},
+		{"linux", filepath.Join("/home/dev", ".config", "unity3d", "cache")},
+	}
+	for _, tt := range tests {
+		if got := upmCacheRootFor(tt.goos, "/home/dev", "/appdata/local"); got != tt.want {
+			t.Errorf("upmCacheRootFor(%q) = %q, want %q", tt.goos, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/neptaco/uniforge/pkg/trace"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
 
@@ -67,6 +68,8 @@ func GetChangesetForVersion(version string) (string, error) {
 	majorMinor := parts[0] + "." + parts[1]
 
 	ui.Debug("Fetching changeset from Unity API", "version", version)
+	span := trace.Start(trace.KindHTTP, "GraphQL GetChangeset", "version", version)
+	defer span.End()
 
 	// Prepare GraphQL query
 	query := `query GetRelease($limit: Int, $skip: Int, $version: String!, $stream: [UnityReleaseStream!]) {
@@ -0,0 +1,194 @@
+package unity
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// DefaultCompileMethod is run with -executeMethod after the scripts compiled.
+// Unity compiles while opening the project and aborts batch mode on compiler
+// errors before any method runs, so a built-in method that does nothing keeps
+// the editor from doing more than compiling.
+const DefaultCompileMethod = "UnityEditor.EditorApplication.QueuePlayerLoopUpdate"
+
+// CompileConfig holds configuration for a compile check
+type CompileConfig struct {
+	ProjectPath    string
+	Method         string // Defaults to DefaultCompileMethod
+	LogFile        string
+	TimeoutSeconds int
+	CIMode         bool
+	ShowTimestamp  bool
+	ShowNoise      []logger.NoiseCategory // Noise categories to show, see RunConfig
+}
+
+// CompilerMessage is a C# compiler error or warning from the Unity log
+type CompilerMessage struct {
+	File     string `json:"file"` // Relative to the project, as Unity prints it
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Code     string `json:"code"`     // e.g. CS0246
+	Message  string `json:"message"`
+}
+
+// Location returns "file:line:column"
+func (m CompilerMessage) Location() string {
+	return fmt.Sprintf("%s:%d:%d", m.File, m.Line, m.Column)
+}
+
+// CompileResult lists the compiler messages of a compile check
+type CompileResult struct {
+	Errors   []CompilerMessage `json:"errors"`
+	Warnings []CompilerMessage `json:"warnings"`
+	Duration time.Duration     `json:"duration"`
+}
+
+// ParseCompilerMessage parses a C# compiler message from a Unity log line
+func ParseCompilerMessage(line string) (CompilerMessage, bool) {
+	d, ok := logger.ParseDiagnostic(line)
+	if !ok {
+		return CompilerMessage{}, false
+	}
+	return CompilerMessage{
+		File:     filepath.ToSlash(d.File),
+		Line:     d.Line,
+		Column:   d.Column,
+		Severity: d.Severity,
+		Code:     d.Code,
+		Message:  d.Message,
+	}, true
+}
+
+// compilerMessageCollector records the distinct compiler messages written to it.
+// Unity prints each message again in its summary, so duplicates are dropped.
+type compilerMessageCollector struct {
+	seen   map[CompilerMessage]bool
+	result CompileResult
+}
+
+func (c *compilerMessageCollector) collect(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		msg, ok := ParseCompilerMessage(scanner.Text())
+		if !ok {
+			continue
+		}
+		if !c.seen[msg] {
+			c.seen[msg] = true
+			if msg.Severity == "error" {
+				c.result.Errors = append(c.result.Errors, msg)
+			} else {
+				c.result.Warnings = append(c.result.Warnings, msg)
+			}
+		}
+	}
+	// Keep draining so the editor never blocks on a full pipe
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// Compile opens the project in batch mode so Unity compiles its scripts, then
+// quits. The log is shown through the usual formatter while compiler messages
+// are collected into the result. An error is returned when Unity could not be
+// run or failed; compiler errors alone are reported in the result.
+func (r *Runner) Compile(config CompileConfig) (*CompileResult, error) {
+	editorPath, err := r.editor.GetPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Unity Editor path: %w", err)
+	}
+
+	absProjectPath, err := filepath.Abs(config.ProjectPath)
+	if err != nil {
+		absProjectPath = config.ProjectPath
+	}
+
+	method := config.Method
+	if method == "" {
+		method = DefaultCompileMethod
+	}
+	// Unity always logs to stdout so compiler messages can be collected;
+	// the logger saves the log file
+	args := r.buildArgs(absProjectPath, RunConfig{ExtraArgs: []string{"-executeMethod", method}})
+
+	timeout := config.TimeoutSeconds
+	if timeout == 0 {
+		timeout = 1800 // Default 30 minutes, enough for a first import
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	env, err := ProjectEnviron(absProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, editorPath, args...)
+	cmd.Env = env
+
+	log := logger.NewWithOptions(config.LogFile,
+		logger.WithCIMode(config.CIMode),
+		logger.WithShowTime(config.ShowTimestamp),
+		logger.WithFormatter(batchFormatter(absProjectPath, config.ShowNoise)),
+		// The result lists the compiler messages
+		logger.WithHideDiagnostics(true),
+	)
+
+	collector := &compilerMessageCollector{seen: make(map[CompilerMessage]bool)}
+	pr, pw := io.Pipe()
+	collected := make(chan struct{})
+	go func() {
+		collector.collect(pr)
+		close(collected)
+	}()
+
+	crash := watchForCrash(r.project, "")
+	cmd.Stdout = io.MultiWriter(log, pw, crash.tail)
+	cmd.Stderr = cmd.Stdout
+	cmd.Dir = filepath.Dir(absProjectPath)
+
+	ui.Debug("Compiling scripts", "path", editorPath, "args", strings.Join(args, " "))
+
+	span := trace.Start(trace.KindExec, "Unity compile", "version", r.project.UnityVersion)
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		_ = pw.Close()
+		_ = log.Close()
+		return nil, startError(runtime.GOOS, editorPath, err)
+	}
+	runErr := cmd.Wait()
+	span.Fail(runErr)
+	span.End()
+
+	_ = pw.Close()
+	<-collected
+	_ = log.Close()
+
+	result := &collector.result
+	result.Duration = time.Since(start)
+
+	if runErr != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return result, fmt.Errorf("compile timeout after %d seconds", timeout)
+		}
+		if crashErr := crash.check(runErr); crashErr != nil {
+			return result, crashErr
+		}
+		// Unity exits with 1 on compiler errors, which the result describes
+		if len(result.Errors) == 0 {
+			return result, fmt.Errorf("unity execution failed: %w", runErr)
+		}
+	}
+	return result, nil
+}
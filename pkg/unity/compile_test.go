@@ -0,0 +1,51 @@
+package unity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCompilerMessage(t *testing.T) {
+	tests := []struct {
+		line string
+		want CompilerMessage
+		ok   bool
+	}{
+		{
+			"Assets/Scripts/Player.cs(12,5): error CS0246: The type or namespace name 'Foo' could not be found",
+			CompilerMessage{File: "Assets/Scripts/Player.cs", Line: 12, Column: 5, Severity: "error", Code: "CS0246", Message: "The type or namespace name 'Foo' could not be found"},
+			true,
+		},
+		{
+			"  Packages/com.studio.tools/Runtime/A B.cs(1,1): warning CS0168: The variable 'e' is declared but never used",
+			CompilerMessage{File: "Packages/com.studio.tools/Runtime/A B.cs", Line: 1, Column: 1, Severity: "warning", Code: "CS0168", Message: "The variable 'e' is declared but never used"},
+			true,
+		},
+		{"Scripts have compiler errors.", CompilerMessage{}, false},
+		{"Assets/Readme.cs was imported", CompilerMessage{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseCompilerMessage(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseCompilerMessage(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCompilerMessageCollector(t *testing.T) {
+	log := `Starting compilation
+Assets/A.cs(3,1): error CS1002: ; expected
+Assets/B.cs(7,9): warning CS0414: The field 'B.x' is assigned but its value is never used
+Scripts have compiler errors.
+Assets/A.cs(3,1): error CS1002: ; expected
+`
+	c := &compilerMessageCollector{seen: make(map[CompilerMessage]bool)}
+	c.collect(strings.NewReader(log))
+
+	if len(c.result.Errors) != 1 || c.result.Errors[0].Location() != "Assets/A.cs:3:1" {
+		t.Errorf("Errors = %+v, want the error once", c.result.Errors)
+	}
+	if len(c.result.Warnings) != 1 || c.result.Warnings[0].Code != "CS0414" {
+		t.Errorf("Warnings = %+v", c.result.Warnings)
+	}
+}
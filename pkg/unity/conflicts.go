@@ -0,0 +1,267 @@
+package unity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// yamlConflictExts are the YAML-serialized asset types scanned for merge conflicts
+var yamlConflictExts = map[string]bool{
+	".unity":  true,
+	".prefab": true,
+	".asset":  true,
+}
+
+// YAMLObject identifies an object inside a Unity YAML file (one "--- !u!" document)
+type YAMLObject struct {
+	ClassID    string // Unity class ID, e.g. "1" for GameObject
+	ClassName  string // e.g. "GameObject", "Transform", "MonoBehaviour"
+	FileID     string // Local file ID ("&12345" in the document header)
+	Name       string // m_Name, if set
+	GameObject string // Name of the owning GameObject for components
+}
+
+// String returns a readable description such as "Transform on 'Player' (&400000)"
+func (o YAMLObject) String() string {
+	label := o.ClassName
+	if label == "" {
+		label = "!u!" + o.ClassID
+	}
+	switch {
+	case o.Name != "":
+		label += fmt.Sprintf(" '%s'", o.Name)
+	case o.GameObject != "":
+		label += fmt.Sprintf(" on '%s'", o.GameObject)
+	}
+	return fmt.Sprintf("%s (&%s)", label, o.FileID)
+}
+
+// ConflictHunk is one <<<<<<< ... >>>>>>> block and the objects it touches
+type ConflictHunk struct {
+	StartLine int // 1-based line of <<<<<<<
+	EndLine   int // 1-based line of >>>>>>>
+	Objects   []YAMLObject
+}
+
+// FileConflicts lists the conflict hunks of a single file
+type FileConflicts struct {
+	Path  string // Relative to the scanned root
+	Hunks []ConflictHunk
+}
+
+// FindYAMLConflicts scans .unity, .prefab and .asset files under root for git conflict markers
+func FindYAMLConflicts(root string) ([]FileConflicts, error) {
+	var result []FileConflicts
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// ProjectSettings/*.asset can conflict too, unlike meta checking
+			if path != root && excludedDirs[info.Name()] && info.Name() != "ProjectSettings" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !yamlConflictExts[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hunks := ParseYAMLConflicts(data)
+		if len(hunks) == 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		result = append(result, FileConflicts{Path: rel, Hunks: hunks})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for conflicts: %w", err)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Path < result[j].Path })
+	return result, nil
+}
+
+// yamlDocument is a parsed "--- !u!<classID> &<fileID>" document with its line range
+type yamlDocument struct {
+	object       YAMLObject
+	startLine    int
+	gameObjectID string
+}
+
+// ParseYAMLConflicts finds conflict hunks in a Unity YAML file and resolves the
+// objects each hunk touches: the object it starts in plus any object headers inside it
+func ParseYAMLConflicts(data []byte) []ConflictHunk {
+	var docs []*yamlDocument
+	var hunks []ConflictHunk
+	var current *yamlDocument
+	hunkStart := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	const maxCapacity = 16 * 1024 * 1024
+	scanner.Buffer(make([]byte, 64*1024), maxCapacity)
+
+	lineNo := 0
+	expectClass := false
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			hunkStart = lineNo
+			continue
+		case strings.HasPrefix(line, ">>>>>>>"):
+			if hunkStart > 0 {
+				hunks = append(hunks, ConflictHunk{StartLine: hunkStart, EndLine: lineNo})
+				hunkStart = 0
+			}
+			continue
+		case strings.HasPrefix(line, "--- !u!"):
+			current = parseDocumentHeader(line, lineNo)
+			docs = append(docs, current)
+			expectClass = true
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if expectClass {
+			expectClass = false
+			if !strings.HasPrefix(line, " ") && strings.HasSuffix(trimmed, ":") {
+				current.object.ClassName = strings.TrimSuffix(trimmed, ":")
+				continue
+			}
+		}
+		if value, ok := strings.CutPrefix(trimmed, "m_Name:"); ok && current.object.Name == "" {
+			current.object.Name = strings.TrimSpace(value)
+		} else if value, ok := strings.CutPrefix(trimmed, "m_GameObject:"); ok {
+			current.gameObjectID = parseFileIDRef(value)
+		}
+	}
+
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	// Resolve component owners to GameObject names
+	names := make(map[string]string)
+	for _, d := range docs {
+		if d.object.ClassName == "GameObject" && d.object.Name != "" {
+			names[d.object.FileID] = d.object.Name
+		}
+	}
+	for _, d := range docs {
+		if d.gameObjectID != "" {
+			d.object.GameObject = names[d.gameObjectID]
+		}
+	}
+
+	for i := range hunks {
+		seen := make(map[string]bool)
+		for j, d := range docs {
+			// The document containing the hunk start, or documents starting inside the hunk
+			containsStart := d.startLine <= hunks[i].StartLine && (j+1 == len(docs) || docs[j+1].startLine > hunks[i].StartLine)
+			startsInside := d.startLine > hunks[i].StartLine && d.startLine < hunks[i].EndLine
+			if (containsStart || startsInside) && !seen[d.object.FileID] {
+				seen[d.object.FileID] = true
+				hunks[i].Objects = append(hunks[i].Objects, d.object)
+			}
+		}
+	}
+
+	return hunks
+}
+
+// parseDocumentHeader parses "--- !u!114 &1234567" (optionally followed by "stripped")
+func parseDocumentHeader(line string, lineNo int) *yamlDocument {
+	doc := &yamlDocument{startLine: lineNo}
+	for _, field := range strings.Fields(strings.TrimPrefix(line, "---")) {
+		if classID, ok := strings.CutPrefix(field, "!u!"); ok {
+			doc.object.ClassID = classID
+		} else if fileID, ok := strings.CutPrefix(field, "&"); ok {
+			doc.object.FileID = fileID
+		}
+	}
+	return doc
+}
+
+// parseFileIDRef extracts N from "{fileID: N}"
+func parseFileIDRef(value string) string {
+	_, rest, ok := strings.Cut(value, "fileID:")
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimSpace(rest)
+	if end := strings.IndexAny(rest, ",} "); end >= 0 {
+		rest = rest[:end]
+	}
+	if rest == "0" {
+		return ""
+	}
+	return rest
+}
+
+// SmartMerge resolves a conflicted file with UnityYAMLMerge using the base, ours and
+// theirs versions from the git index. The file is overwritten only if the merge succeeds.
+func SmartMerge(yamlMergePath, repoDir, relPath string) error {
+	tmpDir, err := os.MkdirTemp("", "uniforge-merge-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// Index stages: 1 = common ancestor, 2 = ours, 3 = theirs
+	stages := map[string]string{"1": "base", "2": "local", "3": "remote"}
+	files := make(map[string]string)
+	for stage, name := range stages {
+		// "./" makes the path relative to repoDir instead of the repository root
+		gitPath := "./" + filepath.ToSlash(relPath)
+		out, err := exec.Command("git", "-C", repoDir, "show", ":"+stage+":"+gitPath).Output()
+		if err != nil {
+			return fmt.Errorf("failed to read %s version of %s from git index: %w", name, relPath, err)
+		}
+		files[name] = filepath.Join(tmpDir, name+filepath.Ext(relPath))
+		if err := os.WriteFile(files[name], out, 0644); err != nil {
+			return err
+		}
+	}
+
+	merged := filepath.Join(tmpDir, "merged"+filepath.Ext(relPath))
+	args := []string{"merge", "-p", files["base"], files["remote"], files["local"], merged}
+	ui.Debug("Running UnityYAMLMerge", "path", yamlMergePath, "args", strings.Join(args, " "))
+
+	output, err := exec.Command(yamlMergePath, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("UnityYAMLMerge could not resolve %s: %w\n%s", relPath, err, strings.TrimSpace(string(output)))
+	}
+
+	data, err := os.ReadFile(merged)
+	if err != nil {
+		return fmt.Errorf("failed to read merge result: %w", err)
+	}
+	if len(ParseYAMLConflicts(data)) > 0 {
+		return fmt.Errorf("UnityYAMLMerge left conflicts in %s", relPath)
+	}
+	return os.WriteFile(filepath.Join(repoDir, relPath), data, 0644)
+}
@@ -0,0 +1,123 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const conflictedScene = `%YAML 1.1
+%TAG !u! tag:unity3d.com,2011:
+--- !u!1 &100
+GameObject:
+  m_ObjectHideFlags: 0
+  m_Name: Player
+--- !u!4 &400
+Transform:
+  m_GameObject: {fileID: 100}
+<<<<<<< HEAD
+  m_LocalPosition: {x: 0, y: 1, z: 0}
+=======
+  m_LocalPosition: {x: 0, y: 2, z: 0}
+>>>>>>> feature
+--- !u!1 &200
+GameObject:
+  m_Name: Enemy
+<<<<<<< HEAD
+  m_IsActive: 1
+--- !u!114 &300
+MonoBehaviour:
+  m_GameObject: {fileID: 200}
+=======
+  m_IsActive: 0
+>>>>>>> feature
+`
+
+func TestParseYAMLConflicts(t *testing.T) {
+	hunks := ParseYAMLConflicts([]byte(conflictedScene))
+	if len(hunks) != 2 {
+		t.Fatalf("ParseYAMLConflicts() returned %d hunks, want 2", len(hunks))
+	}
+
+	first := hunks[0]
+	if first.StartLine != 10 || first.EndLine != 14 {
+		t.Errorf("first hunk lines = %d-%d, want 10-14", first.StartLine, first.EndLine)
+	}
+	if len(first.Objects) != 1 || first.Objects[0].String() != "Transform on 'Player' (&400)" {
+		t.Errorf("first hunk objects = %v, want [Transform on 'Player' (&400)]", first.Objects)
+	}
+
+	second := hunks[1]
+	var got []string
+	for _, o := range second.Objects {
+		got = append(got, o.String())
+	}
+	want := []string{"GameObject 'Enemy' (&200)", "MonoBehaviour on 'Enemy' (&300)"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("second hunk objects = %v, want %v", got, want)
+	}
+}
+
+func TestParseYAMLConflicts_NoConflicts(t *testing.T) {
+	data := "--- !u!1 &100\nGameObject:\n  m_Name: Player\n"
+	if hunks := ParseYAMLConflicts([]byte(data)); hunks != nil {
+		t.Errorf("ParseYAMLConflicts() = %v, want nil", hunks)
+	}
+}
+
+func TestFindYAMLConflicts(t *testing.T) {
+	_, root := setupTestProject(t)
+
+	scenesDir := filepath.Join(root, "Assets", "Scenes")
+	if err := os.MkdirAll(scenesDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	files := map[string]string{
+		filepath.Join(scenesDir, "Main.unity"):            conflictedScene,
+		filepath.Join(scenesDir, "Clean.unity"):           "--- !u!1 &100\nGameObject:\n",
+		filepath.Join(scenesDir, "Notes.txt"):             "<<<<<<< HEAD\n>>>>>>> feature\n",
+		filepath.Join(root, "Library", "Cached.asset"):    conflictedScene,
+		filepath.Join(root, "ProjectSettings", "X.asset"): conflictedScene,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	conflicts, err := FindYAMLConflicts(root)
+	if err != nil {
+		t.Fatalf("FindYAMLConflicts() error = %v", err)
+	}
+
+	want := []string{filepath.Join("Assets", "Scenes", "Main.unity"), filepath.Join("ProjectSettings", "X.asset")}
+	if len(conflicts) != len(want) {
+		t.Fatalf("FindYAMLConflicts() = %v, want files %v", conflicts, want)
+	}
+	for i, c := range conflicts {
+		if c.Path != want[i] {
+			t.Errorf("conflicts[%d].Path = %q, want %q", i, c.Path, want[i])
+		}
+	}
+}
+
+func TestYAMLMergePathFor(t *testing.T) {
+	tests := []struct {
+		goos       string
+		editorPath string
+		want       string
+	}{
+		{"darwin", "/Apps/6000.0.30f1/Unity.app/Contents/MacOS/Unity", "/Apps/6000.0.30f1/Unity.app/Contents/Tools/UnityYAMLMerge"},
+		{"linux", "/opt/6000.0.30f1/Editor/Unity", "/opt/6000.0.30f1/Editor/Data/Tools/UnityYAMLMerge"},
+	}
+
+	for _, tt := range tests {
+		got := filepath.ToSlash(yamlMergePathFor(tt.goos, filepath.FromSlash(tt.editorPath)))
+		if got != tt.want {
+			t.Errorf("yamlMergePathFor(%s) = %q, want %q", tt.goos, got, tt.want)
+		}
+	}
+}
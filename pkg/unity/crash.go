@@ -0,0 +1,376 @@
+package unity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"github.com/neptaco/uniforge/pkg/paths"
+	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/watchdog"
+)
+
+const (
+	crashManifestFile = "crash.json"
+	crashLogLines     = 1000 // Lines of the editor log kept in a bundle
+	crashFrameCount   = 8    // Stack frames in the summary
+)
+
+// CrashBundle is a directory of artifacts collected after an editor crash
+type CrashBundle struct {
+	ID            string    `json:"id"`
+	Time          time.Time `json:"time"`
+	Project       string    `json:"project,omitempty"`
+	EditorVersion string    `json:"editorVersion,omitempty"`
+	Reason        string    `json:"reason,omitempty"` // How the editor ended, e.g. "signal: segmentation fault"
+	Frames        []string  `json:"frames,omitempty"` // Top of the crash stack trace from the log
+	Files         []string  `json:"files"`            // Collected files, relative to Dir
+	Dir           string    `json:"-"`
+}
+
+// CrashInfo describes a crash to collect artifacts for
+type CrashInfo struct {
+	Project       string    // Project path
+	EditorVersion string    // Editor version
+	Reason        string    // How the editor ended, if known
+	Since         time.Time // When the session started; older crash dumps are ignored
+	Log           []string  // Captured log output; empty reads LogFile
+	LogFile       string    // Log to take the tail of; empty for the default Editor.log
+}
+
+// crashArtifactSource is where Unity or the OS leaves crash reports
+type crashArtifactSource struct {
+	dir    string
+	prefix string // Only entries with this name prefix; "" for all
+}
+
+func crashArtifactSources() []crashArtifactSource {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		return []crashArtifactSource{
+			{dir: filepath.Join(home, "Library", "Logs", "DiagnosticReports"), prefix: "Unity"},
+		}
+	default:
+		// Unity's crash handler writes a folder per crash with the dump, logs and crash.json
+		return []crashArtifactSource{
+			{dir: filepath.Join(os.TempDir(), "Unity", "Editor", "Crashes")},
+		}
+	}
+}
+
+// CrashesDir returns the directory crash bundles are stored in
+func CrashesDir() string {
+	return filepath.Join(paths.LogDir(), "crashes")
+}
+
+// CollectCrash creates a crash bundle with the tail of the editor log, crash
+// reports written since info.Since and a description of the system
+func CollectCrash(info CrashInfo) (*CrashBundle, error) {
+	return collectCrash(CrashesDir(), crashArtifactSources(), info)
+}
+
+func collectCrash(root string, sources []crashArtifactSource, info CrashInfo) (*CrashBundle, error) {
+	now := time.Now()
+	id := now.Format("20060102-150405")
+	if info.Project != "" {
+		id += "-" + filepath.Base(info.Project)
+	}
+	bundle := &CrashBundle{
+		ID:            id,
+		Time:          now.UTC(),
+		Project:       info.Project,
+		EditorVersion: info.EditorVersion,
+		Reason:        info.Reason,
+		Files:         []string{},
+		Dir:           filepath.Join(root, id),
+	}
+	if err := os.MkdirAll(bundle.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create crash bundle: %w", err)
+	}
+
+	lines := info.Log
+	if len(lines) == 0 {
+		logFile := info.LogFile
+		if logFile == "" || logFile == "-" {
+			logFile, _ = GetEditorLogPath()
+		}
+		var err error
+		if lines, err = tailLines(logFile, crashLogLines); err != nil {
+			ui.Debug("Failed to read editor log", "path", logFile, "error", err)
+		}
+	}
+	if len(lines) > 0 {
+		bundle.Frames = CrashFrames(lines)
+		if err := os.WriteFile(filepath.Join(bundle.Dir, "Editor.log"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			return nil, err
+		}
+		bundle.Files = append(bundle.Files, "Editor.log")
+	}
+
+	// Allow for clock skew between the crash handler and the session start
+	since := info.Since.Add(-time.Minute)
+	for _, source := range sources {
+		entries, err := os.ReadDir(source.dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			fi, err := entry.Info()
+			if err != nil || !strings.HasPrefix(entry.Name(), source.prefix) || fi.ModTime().Before(since) {
+				continue
+			}
+			copied, err := copyTree(filepath.Join(source.dir, entry.Name()), filepath.Join(bundle.Dir, "reports", entry.Name()))
+			if err != nil {
+				ui.Debug("Failed to copy crash report", "path", entry.Name(), "error", err)
+			}
+			for _, rel := range copied {
+				bundle.Files = append(bundle.Files, filepath.ToSlash(filepath.Join("reports", entry.Name(), rel)))
+			}
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(bundle.Dir, "sysinfo.txt"), []byte(systemInfo(info)), 0644); err != nil {
+		return nil, err
+	}
+	bundle.Files = append(bundle.Files, "sysinfo.txt")
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := fsutil.WriteFileAtomic(filepath.Join(bundle.Dir, crashManifestFile), data, 0644); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// ListCrashBundles returns the collected crash bundles, newest first
+func ListCrashBundles() ([]*CrashBundle, error) {
+	return listCrashBundles(CrashesDir())
+}
+
+func listCrashBundles(root string) ([]*CrashBundle, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var bundles []*CrashBundle
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		bundle, err := readCrashBundle(filepath.Join(root, entry.Name()))
+		if err != nil {
+			ui.Debug("Skipping crash bundle", "path", entry.Name(), "error", err)
+			continue
+		}
+		bundles = append(bundles, bundle)
+	}
+	slices.SortFunc(bundles, func(a, b *CrashBundle) int { return b.Time.Compare(a.Time) })
+	return bundles, nil
+}
+
+// LoadCrashBundle returns the bundle with id, or the newest for "latest"
+func LoadCrashBundle(id string) (*CrashBundle, error) {
+	if id != "latest" {
+		bundle, err := readCrashBundle(filepath.Join(CrashesDir(), filepath.Base(id)))
+		if os.IsNotExist(err) {
+			return nil, errs.WithHint(errs.New(errs.NotFound, "crash bundle not found: %s", id), "'uniforge crash list' shows collected bundles.")
+		}
+		return bundle, err
+	}
+	bundles, err := ListCrashBundles()
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, errs.New(errs.NotFound, "no crash bundles collected")
+	}
+	return bundles[0], nil
+}
+
+func readCrashBundle(dir string) (*CrashBundle, error) {
+	data, err := os.ReadFile(filepath.Join(dir, crashManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var bundle CrashBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid crash bundle %s: %w", dir, err)
+	}
+	bundle.Dir = dir
+	return &bundle, nil
+}
+
+var (
+	// Lines after which Unity prints the native stack of a crash
+	crashStackStart = regexp.MustCompile(`Obtained \d+ stack frames|OUTPUTTING STACK TRACE|Native stacktrace:|Crash!!!`)
+	crashStackEnd   = regexp.MustCompile(`END OF STACKTRACE|Debug info from gdb:|Managed Stacktrace:`)
+	crashSeparator  = regexp.MustCompile(`^[=\-\s]*$`)
+)
+
+// CrashFrames returns the first frames of the last crash stack trace in a log
+func CrashFrames(lines []string) []string {
+	start := -1
+	for i, line := range lines {
+		if crashStackStart.MatchString(line) {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	var frames []string
+	for _, line := range lines[start+1:] {
+		if crashStackEnd.MatchString(line) || len(frames) == crashFrameCount {
+			break
+		}
+		line = strings.TrimSpace(line)
+		if crashSeparator.MatchString(line) || crashStackStart.MatchString(line) {
+			continue
+		}
+		frames = append(frames, line)
+	}
+	return frames
+}
+
+// tailLines returns the last n lines of a file
+func tailLines(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	tail := watchdog.NewTailBuffer(n)
+	if _, err := io.Copy(tail, f); err != nil {
+		return nil, err
+	}
+	return tail.Lines(), nil
+}
+
+func systemInfo(info CrashInfo) string {
+	hostname, _ := os.Hostname()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Time:    %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Project: %s\n", info.Project)
+	fmt.Fprintf(&b, "Editor:  %s\n", info.EditorVersion)
+	fmt.Fprintf(&b, "Reason:  %s\n", info.Reason)
+	fmt.Fprintf(&b, "OS:      %s/%s %s\n", runtime.GOOS, runtime.GOARCH, osVersion())
+	fmt.Fprintf(&b, "CPUs:    %d\n", runtime.NumCPU())
+	fmt.Fprintf(&b, "Host:    %s\n", hostname)
+	return b.String()
+}
+
+// copyTree copies a file or directory and returns the copied files relative to dst
+func copyTree(src, dst string) ([]string, error) {
+	var copied []string
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if err := copyFile(path, target); err != nil {
+			return err
+		}
+		if rel == "." {
+			rel = ""
+		}
+		copied = append(copied, rel)
+		return nil
+	})
+	return copied, err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// crashWatch keeps the end of a batch run's output so that a bundle can be
+// collected if the editor crashes
+type crashWatch struct {
+	project *Project
+	logFile string
+	start   time.Time
+	tail    *watchdog.TailBuffer
+}
+
+func watchForCrash(project *Project, logFile string) *crashWatch {
+	return &crashWatch{project: project, logFile: logFile, start: time.Now(), tail: watchdog.NewTailBuffer(crashLogLines)}
+}
+
+// check collects a crash bundle and prints a summary when err is the exit of
+// a crashed editor, and returns the error to report instead; nil otherwise
+func (w *crashWatch) check(err error) error {
+	reason, ok := crashReason(err)
+	if !ok {
+		return nil
+	}
+	bundle, collectErr := CollectCrash(CrashInfo{
+		Project:       w.project.Path,
+		EditorVersion: w.project.UnityVersion,
+		Reason:        reason,
+		Since:         w.start,
+		Log:           w.tail.Lines(),
+		LogFile:       w.logFile,
+	})
+	if collectErr != nil {
+		return fmt.Errorf("unity crashed (%s); collecting crash artifacts failed: %w", reason, collectErr)
+	}
+	PrintCrashSummary(bundle)
+	return fmt.Errorf("unity crashed (%s); artifacts saved to %s", reason, bundle.Dir)
+}
+
+// PrintCrashSummary prints the reason and top stack frames of a crash
+func PrintCrashSummary(bundle *CrashBundle) {
+	title := "Unity " + bundle.EditorVersion
+	if bundle.Reason != "" {
+		title += " (" + bundle.Reason + ")"
+	}
+	ui.Error("%s crashed", strings.Join(strings.Fields(title), " "))
+	if len(bundle.Frames) == 0 {
+		ui.Muted("  No stack trace found in the log")
+		return
+	}
+	for _, frame := range bundle.Frames {
+		ui.Print("  %s", frame)
+	}
+}
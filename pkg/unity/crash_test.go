@@ -0,0 +1,123 @@
+package unity
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrashFrames(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		want []string
+	}{
+		{
+			name: "linux",
+			log: `Refreshing native plugins
+Crash!!!
+SymInit: Symbol-SearchPath: ...
+Obtained 3 stack frames.
+#0  0x007f2b1c0b9420 in funlockfile
+#1  0x007f2b16d0e5c1 in Transform::GetPosition()
+#2  0x007f2b16a01234 in MonoBehaviour::CallUpdateMethod(int)
+Debug info from gdb:
+#0 unrelated`,
+			want: []string{"#0  0x007f2b1c0b9420 in funlockfile", "#1  0x007f2b16d0e5c1 in Transform::GetPosition()", "#2  0x007f2b16a01234 in MonoBehaviour::CallUpdateMethod(int)"},
+		},
+		{
+			name: "windows",
+			log: `========== OUTPUTTING STACK TRACE ==================
+
+0x00007FF6A1B2C3D4 (Unity) Transform::GetPosition
+0x00007FF6A1B2C3E5 (Unity) Behaviour::Update
+
+========== END OF STACKTRACE ===========
+Unrelated line`,
+			want: []string{"0x00007FF6A1B2C3D4 (Unity) Transform::GetPosition", "0x00007FF6A1B2C3E5 (Unity) Behaviour::Update"},
+		},
+		{
+			name: "no crash",
+			log:  "Exiting batchmode successfully now!",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CrashFrames(strings.Split(tt.log, "\n"))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CrashFrames() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectCrash(t *testing.T) {
+	root := t.TempDir()
+	reports := t.TempDir()
+	since := time.Now()
+
+	writeTestFiles(t, reports, map[string]string{
+		"Crash_new/crash.dmp":  "dump",
+		"Crash_new/error.log":  "error",
+		"Crash_old/crash.dmp":  "old dump",
+		"Other_new/report.txt": "not unity",
+	})
+	old := since.Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(reports, "Crash_old"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := collectCrash(root, []crashArtifactSource{{dir: reports, prefix: "Crash_"}}, CrashInfo{
+		Project:       "/path/to/MyGame",
+		EditorVersion: "2022.3.10f1",
+		Reason:        "signal: segmentation fault",
+		Since:         since,
+		Log:           []string{"Crash!!!", "Obtained 1 stack frames.", "#0 0x1 in Foo"},
+	})
+	if err != nil {
+		t.Fatalf("collectCrash() error = %v", err)
+	}
+
+	want := []string{"Editor.log", "reports/Crash_new/crash.dmp", "reports/Crash_new/error.log", "sysinfo.txt"}
+	if !slices.Equal(bundle.Files, want) {
+		t.Errorf("Files = %q, want %q", bundle.Files, want)
+	}
+	if !slices.Equal(bundle.Frames, []string{"#0 0x1 in Foo"}) {
+		t.Errorf("Frames = %q", bundle.Frames)
+	}
+	if !strings.HasSuffix(bundle.ID, "-MyGame") {
+		t.Errorf("ID = %q, want the project name suffix", bundle.ID)
+	}
+	for _, f := range want {
+		if _, err := os.Stat(filepath.Join(bundle.Dir, f)); err != nil {
+			t.Errorf("%s not collected: %v", f, err)
+		}
+	}
+
+	bundles, err := listCrashBundles(root)
+	if err != nil {
+		t.Fatalf("listCrashBundles() error = %v", err)
+	}
+	if len(bundles) != 1 || bundles[0].ID != bundle.ID || bundles[0].Reason != bundle.Reason {
+		t.Errorf("listCrashBundles() = %+v", bundles)
+	}
+}
+
+func TestCrashReason(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	err := exec.Command("sh", "-c", "kill -SEGV $$").Run()
+	if reason, ok := crashReason(err); !ok || !strings.Contains(reason, "segmentation") {
+		t.Errorf("crashReason(SIGSEGV) = %q, %v", reason, ok)
+	}
+	err = exec.Command("sh", "-c", "exit 1").Run()
+	if reason, ok := crashReason(err); ok {
+		t.Errorf("crashReason(exit 1) = %q, want no crash", reason)
+	}
+}
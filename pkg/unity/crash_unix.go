@@ -0,0 +1,40 @@
+//go:build !windows
+
+package unity
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// crashSignals are the signals a crashing editor dies from
+var crashSignals = []syscall.Signal{syscall.SIGSEGV, syscall.SIGBUS, syscall.SIGABRT, syscall.SIGILL, syscall.SIGFPE, syscall.SIGTRAP}
+
+// crashReason describes how the editor crashed when err is the exit of a
+// crashed process
+func crashReason(err error) (string, bool) {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return "", false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return "", false
+	}
+	for _, sig := range crashSignals {
+		if status.Signal() == sig {
+			return "signal: " + sig.String(), true
+		}
+	}
+	return "", false
+}
+
+func osVersion() string {
+	out, err := exec.Command("uname", "-srm").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// crashReason describes how the editor crashed when err is the exit of a
+// crashed process. Crashed processes exit with an NTSTATUS error code such as
+// 0xC0000005 (access violation).
+func crashReason(err error) (string, bool) {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return "", false
+	}
+	code := uint32(exitErr.ExitCode())
+	if code < 0xC0000000 {
+		return "", false
+	}
+	return fmt.Sprintf("exit code 0x%08X", code), true
+}
+
+func osVersion() string {
+	out, err := exec.Command("cmd", "/c", "ver").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -10,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/neptaco/uniforge/pkg/errs"
 	"github.com/neptaco/uniforge/pkg/hub"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
@@ -47,7 +48,7 @@ func (e *Editor) GetPath() (string, error) {
 	// Fallback: try Hub CLI to list installed editors
 	editors, err := hubClient.ListInstalledEditors()
 	if err != nil {
-		return "", fmt.Errorf("unity editor %s not found. install path: %s, hub error: %w", e.Version, installPath, err)
+		return "", errs.New(errs.NotInstalled, "unity editor %s not found. install path: %s, hub error: %w", e.Version, installPath, err)
 	}
 
 	for _, editor := range editors {
@@ -57,7 +58,7 @@ func (e *Editor) GetPath() (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("unity editor %s not found, please install it using: uniforge editor install %s", e.Version, e.Version)
+	return "", errs.New(errs.NotInstalled, "unity editor %s not found, please install it using: uniforge editor install %s", e.Version, e.Version)
 }
 
 func fileExists(path string) bool {
@@ -86,6 +87,34 @@ func (e *Editor) getExecutablePath(installPath string) string {
 	}
 }
 
+// YAMLMergePath returns the path to the UnityYAMLMerge tool bundled with the editor
+func (e *Editor) YAMLMergePath() (string, error) {
+	editorPath, err := e.GetPath()
+	if err != nil {
+		return "", err
+	}
+
+	toolPath := yamlMergePathFor(runtime.GOOS, editorPath)
+	if !fileExists(toolPath) {
+		return "", fmt.Errorf("UnityYAMLMerge not found at %s", toolPath)
+	}
+	return toolPath, nil
+}
+
+// yamlMergePathFor derives the UnityYAMLMerge location from the editor executable path
+func yamlMergePathFor(goos, editorPath string) string {
+	switch goos {
+	case "darwin":
+		// Unity.app/Contents/MacOS/Unity -> Unity.app/Contents/Tools/UnityYAMLMerge
+		return filepath.Join(filepath.Dir(filepath.Dir(editorPath)), "Tools", "UnityYAMLMerge")
+	case "windows":
+		// Editor/Unity.exe -> Editor/Data/Tools/UnityYAMLMerge.exe
+		return filepath.Join(filepath.Dir(editorPath), "Data", "Tools", "UnityYAMLMerge.exe")
+	default:
+		return filepath.Join(filepath.Dir(editorPath), "Data", "Tools", "UnityYAMLMerge")
+	}
+}
+
 func (e *Editor) Exists() bool {
 	path, err := e.GetPath()
 	if err != nil {
@@ -98,6 +127,11 @@ func (e *Editor) Exists() bool {
 
 // Open starts the Unity Editor with the specified project in GUI mode
 func (e *Editor) Open(projectPath string) error {
+	return e.OpenWithOptions(projectPath, nil)
+}
+
+// OpenWithOptions starts the Unity Editor in GUI mode with additional launch options
+func (e *Editor) OpenWithOptions(projectPath string, opts *LaunchOptions) error {
 	editorPath, err := e.GetPath()
 	if err != nil {
 		return fmt.Errorf("failed to get Unity Editor path: %w", err)
@@ -109,18 +143,37 @@ func (e *Editor) Open(projectPath string) error {
 	}
 
 	args := []string{"-projectPath", absProjectPath}
+	args = append(args, opts.EditorArgs()...)
+	args = append(args, hubBypassArgs(runtime.GOOS, args)...)
 
 	ui.Debug("Opening Unity Editor", "path", editorPath, "args", strings.Join(args, " "))
 
+	env, err := ProjectEnviron(absProjectPath)
+	if err != nil {
+		return err
+	}
 	cmd := exec.Command(editorPath, args...)
+	cmd.Env = env
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start Unity Editor: %w", err)
+		return startError(runtime.GOOS, editorPath, err)
 	}
 
 	ui.Debug("Unity Editor started", "pid", cmd.Process.Pid)
+
+	// Unity.exe fails without a window when Hub takes over or rights are missing
+	if runtime.GOOS == "windows" {
+		if exited, err := waitEarlyExit(cmd, earlyExitWindow); exited {
+			return earlyExitError(e.Version, editorPath, err)
+		}
+	}
+
+	// Keeps uniforge's launch history and Unity Hub's recent ordering
+	if err := hub.NewClient().RecordProjectOpen(absProjectPath, e.Version); err != nil {
+		ui.Debug("Failed to record project open", "error", err)
+	}
 	return nil
 }
 
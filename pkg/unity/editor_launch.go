@@ -0,0 +1,99 @@
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+)
+
+// Unity.exe started directly on Windows may hand its launch over to Unity Hub,
+// which restarts it, sometimes in a loop, or exit without a word when it needs
+// rights the user does not have. The helpers below keep the launch with
+// uniforge and turn such failures into errors with a hint.
+
+// errorElevationRequired is ERROR_ELEVATION_REQUIRED, returned when starting an
+// executable that requires administrator rights
+const errorElevationRequired = syscall.Errno(740)
+
+// earlyExitWindow is how long Open watches a started editor on Windows for an
+// exit that would otherwise go unnoticed
+var earlyExitWindow = 3 * time.Second
+
+// hubBypassArgs returns the arguments that keep Unity.exe from handing its
+// launch over to Unity Hub, unless args already decide about Hub
+func hubBypassArgs(goos string, args []string) []string {
+	if goos != "windows" {
+		return nil
+	}
+	for _, arg := range args {
+		if strings.EqualFold(arg, "-useHub") || strings.EqualFold(arg, "-hubIPC") {
+			return nil
+		}
+	}
+	return []string{"-useHub", "false"}
+}
+
+// startError describes a failed start of the editor at editorPath
+func startError(goos, editorPath string, err error) error {
+	if goos == "windows" && errors.Is(err, errorElevationRequired) {
+		return errs.WithHint(
+			fmt.Errorf("unity at %s requires administrator rights to start: %w", editorPath, err),
+			"Run uniforge from an elevated terminal, or install the editor where you can write, e.g. with UNIFORGE_EDITOR_BASE_PATH.")
+	}
+	return fmt.Errorf("failed to start Unity: %w", err)
+}
+
+// needsElevation reports whether the current user cannot write to the
+// directory the editor at editorPath is installed in, e.g. Program Files
+// without administrator rights
+func needsElevation(editorPath string) bool {
+	f, err := os.CreateTemp(hub.EditorDir(editorPath), ".uniforge-write-test-*")
+	if err != nil {
+		return errors.Is(err, os.ErrPermission)
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return false
+}
+
+// waitEarlyExit waits up to window for cmd to exit. Returns whether it exited
+// and how.
+func waitEarlyExit(cmd *exec.Cmd, window time.Duration) (bool, error) {
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		return true, err
+	case <-time.After(window):
+		return false, nil
+	}
+}
+
+// earlyExitError describes an editor that exited right after it was started
+func earlyExitError(version, editorPath string, exitErr error) error {
+	logHint := "See Editor.log for why."
+	if logPath, err := GetEditorLogPath(); err == nil {
+		logHint = fmt.Sprintf("See %s for why.", logPath)
+	}
+
+	if needsElevation(editorPath) {
+		return errs.WithHint(
+			fmt.Errorf("unity %s exited right after starting; its install directory %s is not writable without administrator rights", version, hub.EditorDir(editorPath)),
+			"Run uniforge from an elevated terminal, or reinstall the editor where you can write. "+logHint)
+	}
+	if exitErr != nil {
+		return errs.WithHint(fmt.Errorf("unity %s exited right after starting: %w", version, exitErr), logHint)
+	}
+	// A clean exit is Unity handing the launch to Hub or to an editor that
+	// already has the project open
+	return errs.WithHint(
+		fmt.Errorf("unity %s exited right after starting", version),
+		"Unity Hub or an editor that already has the project open may have taken over. To let Unity involve Hub, pass it yourself, e.g. 'project open <project> -- -useHub -hubIPC'. "+logHint)
+}
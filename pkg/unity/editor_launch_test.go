@@ -0,0 +1,69 @@
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestHubBypassArgs(t *testing.T) {
+	tests := []struct {
+		goos string
+		args []string
+		want []string
+	}{
+		{goos: "windows", args: []string{"-projectPath", "C:/p"}, want: []string{"-useHub", "false"}},
+		{goos: "windows", args: []string{"-projectPath", "C:/p", "-useHub", "-hubIPC"}},
+		{goos: "windows", args: []string{"-hubipc"}},
+		{goos: "darwin", args: []string{"-projectPath", "/p"}},
+		{goos: "linux", args: []string{"-projectPath", "/p"}},
+	}
+	for _, tt := range tests {
+		if got := hubBypassArgs(tt.goos, tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("hubBypassArgs(%s, %v) = %v, want %v", tt.goos, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestStartError(t *testing.T) {
+	elevation := fmt.Errorf("fork/exec: %w", errorElevationRequired)
+	if err := startError("windows", `C:\Program Files\Unity\Editor\Unity.exe`, elevation); errs.Hint(err) == "" {
+		t.Errorf("startError() = %v, want a hint about elevation", err)
+	}
+	if err := startError("linux", "/opt/Unity/Editor/Unity", elevation); errs.Hint(err) != "" {
+		t.Errorf("startError() on linux = %v with hint %q, want none", err, errs.Hint(err))
+	}
+	notFound := errors.New("file not found")
+	if err := startError("windows", "Unity.exe", notFound); !errors.Is(err, notFound) {
+		t.Errorf("startError() = %v, want it to wrap the cause", err)
+	}
+}
+
+func TestNeedsElevation(t *testing.T) {
+	dir := t.TempDir()
+	editor := filepath.Join(dir, "Editor", "Unity")
+	if needsElevation(editor) {
+		t.Error("needsElevation() = true for a writable directory")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("write test left files behind: %v", entries)
+	}
+}
+
+func TestWaitEarlyExit(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	exited, err := waitEarlyExit(cmd, 30*time.Second)
+	if !exited || err != nil {
+		t.Errorf("waitEarlyExit() = %v, %v; want a clean exit", exited, err)
+	}
+}
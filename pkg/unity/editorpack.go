@@ -0,0 +1,327 @@
+package unity
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// EditorPackManifestName is the manifest stored as the last entry of an editor pack
+const EditorPackManifestName = ".uniforge-editor.json"
+
+// PAX records at the start of an editor pack, so an unpack can check the pack
+// before extracting gigabytes
+const (
+	editorPackVersionRecord  = "UNIFORGE.editor"
+	editorPackPlatformRecord = "UNIFORGE.platform"
+)
+
+// EditorPackManifest describes an editor pack
+type EditorPackManifest struct {
+	Version      string        `json:"version"`
+	Changeset    string        `json:"changeset,omitempty"`
+	Platform     string        `json:"platform"` // GOOS the editor runs on
+	Architecture string        `json:"architecture,omitempty"`
+	Modules      []string      `json:"modules"`            // Hub module IDs included
+	Excluded     []string      `json:"excluded,omitempty"` // Installed module IDs left out
+	CreatedAt    time.Time     `json:"createdAt"`
+	Files        []ArchiveFile `json:"files"`
+}
+
+// TotalSize returns the total size of the packed files
+func (m *EditorPackManifest) TotalSize() int64 {
+	var total int64
+	for _, f := range m.Files {
+		total += f.Size
+	}
+	return total
+}
+
+// EditorPacker writes an installed editor into a relocatable archive
+type EditorPacker struct {
+	Client  *hub.Client
+	Version string
+	Modules []string // Modules to include; nil includes every installed module
+
+	OnStart func(files int, size int64) // Called once the files to pack are known
+	OnWrite func(n int64)               // Called as file contents are written
+}
+
+// Create writes the pack to output. The compression follows the extension:
+// .tar.zst (needs the zstd command), .tar.gz or .tar.
+func (p *EditorPacker) Create(output string) (*EditorPackManifest, error) {
+	compression, err := archiveCompression(output)
+	if err != nil {
+		return nil, err
+	}
+	installed, editorPath, err := p.Client.IsEditorInstalled(p.Version)
+	if err != nil {
+		return nil, err
+	}
+	if !installed {
+		return nil, errs.New(errs.NotInstalled, "unity %s is not installed", p.Version)
+	}
+	root := hub.EditorDir(editorPath)
+
+	manifest := &EditorPackManifest{
+		Version:      p.Version,
+		Changeset:    p.Client.GetEditorChangeset(editorPath),
+		Platform:     runtime.GOOS,
+		Architecture: p.editorArchitecture(editorPath),
+		CreatedAt:    time.Now().UTC(),
+	}
+	excludes, err := p.selectModules(editorPath, root, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	outputAbs, err := filepath.Abs(output)
+	if err != nil {
+		return nil, err
+	}
+	files, err := collectArchiveFiles(root, excludes, outputAbs)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.Size
+	}
+	if p.OnStart != nil {
+		p.OnStart(len(files), total)
+	}
+
+	records := map[string]string{
+		editorPackVersionRecord:  p.Version,
+		editorPackPlatformRecord: runtime.GOOS,
+	}
+	err = writeArchive(outputAbs, compression, root, files, records, p.OnWrite, func(archived []ArchiveFile) (string, any) {
+		manifest.Files = archived
+		return EditorPackManifestName, manifest
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// selectModules fills the modules of the manifest and returns the exclude
+// patterns leaving out the installed modules that were not selected
+func (p *EditorPacker) selectModules(editorPath, root string, manifest *EditorPackManifest) ([]string, error) {
+	installed := p.Client.GetInstalledModules(editorPath)
+	selected := make(map[string]bool)
+	if p.Modules == nil {
+		for id := range installed {
+			selected[id] = true
+		}
+	}
+	for _, module := range p.Modules {
+		id := hub.ModuleID(module)
+		if !installed[id] {
+			return nil, errs.New(errs.NotInstalled, "module %s is not installed for Unity %s", module, p.Version)
+		}
+		selected[id] = true
+	}
+
+	// Modules sharing a directory (mono and il2cpp) keep it if either is selected
+	keep := make(map[string]bool)
+	for id := range selected {
+		if dir := p.Client.ModuleDir(editorPath, id); dir != "" {
+			keep[dir] = true
+		}
+	}
+	var excludes []string
+	for id := range installed {
+		if selected[id] {
+			manifest.Modules = append(manifest.Modules, id)
+			continue
+		}
+		manifest.Excluded = append(manifest.Excluded, id)
+		dir := p.Client.ModuleDir(editorPath, id)
+		if dir == "" || keep[dir] {
+			continue
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		excludes = append(excludes, "/"+filepath.ToSlash(rel))
+	}
+	sort.Strings(manifest.Modules)
+	sort.Strings(manifest.Excluded)
+	if manifest.Modules == nil {
+		manifest.Modules = []string{}
+	}
+	return excludes, nil
+}
+
+// editorArchitecture returns the architecture recorded for the editor at
+// editorPath; a version can be installed for several architectures
+func (p *EditorPacker) editorArchitecture(editorPath string) string {
+	editors, err := p.Client.ListInstalledEditors()
+	if err != nil {
+		return ""
+	}
+	for _, e := range editors {
+		if e.Version == p.Version && e.Path == editorPath {
+			return e.Architecture
+		}
+	}
+	return ""
+}
+
+// UnpackOptions configures UnpackEditor
+type UnpackOptions struct {
+	Dest      string           // Directory editors are installed in; "" uses the client's install root
+	Overwrite bool             // Replace an editor of the same version in Dest
+	OnStart   func(size int64) // Called with the size of the pack file
+	OnRead    func(n int64)    // Called as the pack file is read
+}
+
+// UnpackEditor installs the editor of a pack written by EditorPacker into
+// <dest>/<version>, verifying every file against the pack's manifest, and
+// returns the manifest and the path of the installed editor
+func UnpackEditor(client *hub.Client, archive string, opts UnpackOptions) (manifest *EditorPackManifest, editorPath string, err error) {
+	compression, err := archiveCompression(archive)
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := os.Open(archive)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", errs.New(errs.NotFound, "editor pack not found: %s", archive)
+		}
+		return nil, "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	if opts.OnStart != nil {
+		if info, err := file.Stat(); err == nil {
+			opts.OnStart(info.Size())
+		}
+	}
+	var src io.Reader = file
+	if opts.OnRead != nil {
+		src = io.TeeReader(file, progressWriter(opts.OnRead))
+	}
+	decompressed, err := decompressReader(src, compression)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = decompressed.Close() }()
+
+	tr := tar.NewReader(decompressed)
+	version, err := readEditorPackHeader(tr, filepath.Base(archive))
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { client.RecordJournal(hub.JournalUnpack, version, filepath.Base(archive), err) }()
+
+	root := opts.Dest
+	if root == "" {
+		if root, err = client.EditorInstallRoot(); err != nil {
+			return nil, "", err
+		}
+	}
+	editorDir := filepath.Join(root, version)
+	if _, err := os.Stat(editorDir); err == nil && !opts.Overwrite {
+		return nil, "", errs.WithHint(
+			errs.New(errs.Usage, "%s already exists", editorDir),
+			"Use --force to replace it, or unpack into another directory with --dest.")
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, "", fmt.Errorf("failed to create install directory: %w", err)
+	}
+
+	// Extract into a staging directory so an interrupted unpack never looks installed
+	staging := filepath.Join(root, "."+version+".partial")
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, "", fmt.Errorf("failed to clean up previous unpack: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	hashes := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read editor pack: %w", err)
+		}
+		if header.Name == EditorPackManifestName {
+			manifest = &EditorPackManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, "", fmt.Errorf("failed to read editor pack manifest: %w", err)
+			}
+			continue
+		}
+		hash, err := restoreEntry(tr, header, staging, false)
+		if err != nil {
+			return nil, "", err
+		}
+		if hash != "" {
+			hashes[strings.TrimSuffix(header.Name, "/")] = hash
+		}
+	}
+	if err := decompressed.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to decompress editor pack: %w", err)
+	}
+	if manifest == nil {
+		return nil, "", fmt.Errorf("%s has no manifest, it is incomplete", filepath.Base(archive))
+	}
+	if err := verifyArchive(manifest.Files, hashes); err != nil {
+		return manifest, "", err
+	}
+	if !fileExists(hub.EditorPathIn(staging)) {
+		return manifest, "", fmt.Errorf("%s does not contain an editor", filepath.Base(archive))
+	}
+
+	if err := os.RemoveAll(editorDir); err != nil {
+		return manifest, "", fmt.Errorf("failed to replace existing editor: %w", err)
+	}
+	if err := os.Rename(staging, editorDir); err != nil {
+		return manifest, "", fmt.Errorf("failed to move editor into place: %w", err)
+	}
+	editorPath = hub.EditorPathIn(editorDir)
+
+	// modules.json still lists the modules left out of the pack as installed
+	if len(manifest.Excluded) > 0 {
+		if err := client.SetModulesInstalled(editorPath, version, manifest.Excluded, false); err != nil {
+			ui.Warn("Failed to update modules.json: %v", err)
+		}
+	}
+	client.InvalidateEditors()
+	return manifest, editorPath, nil
+}
+
+// readEditorPackHeader reads the global header of an editor pack and returns
+// the packed version, refusing packs made for another platform
+func readEditorPackHeader(tr *tar.Reader, name string) (string, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return "", fmt.Errorf("failed to read editor pack: %w", err)
+	}
+	version := ""
+	if header.Typeflag == tar.TypeXGlobalHeader {
+		version = header.PAXRecords[editorPackVersionRecord]
+	}
+	if version == "" || filepath.Base(version) != version || strings.HasPrefix(version, ".") {
+		return "", errs.New(errs.Usage, "%s is not an editor pack (create one with 'uniforge editor pack')", name)
+	}
+	if platform := header.PAXRecords[editorPackPlatformRecord]; platform != runtime.GOOS {
+		return "", errs.New(errs.Usage, "%s contains Unity %s for %s and cannot be installed on %s", name, version, platform, runtime.GOOS)
+	}
+	return version, nil
+}
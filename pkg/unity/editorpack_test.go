@@ -0,0 +1,123 @@
+package unity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+)
+
+// newPackTestClient installs a fake 2022.3.10f1 editor with Android and iOS
+// support and returns a client that sees only it
+func newPackTestClient(t *testing.T) (*hub.Client, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("editor layout differs on Windows")
+	}
+	base := t.TempDir()
+	installRoot := filepath.Join(base, "Editors")
+	client := hub.NewClientWithOptions(hub.ClientOptions{
+		HubDataDir:  filepath.Join(base, "UnityHub"),
+		EditorPaths: []string{installRoot},
+		CacheDir:    filepath.Join(base, "cache"),
+	})
+
+	editorPath := hub.EditorPathIn(filepath.Join(installRoot, "2022.3.10f1"))
+	files := map[string]string{
+		editorPath: "#!/bin/sh\n",
+		filepath.Join(client.ModuleDir(editorPath, "android"), "module.asset"): "android",
+		filepath.Join(client.ModuleDir(editorPath, "ios"), "module.asset"):     "ios",
+		filepath.Join(hub.EditorDir(editorPath), "modules.json"):               `[{"id": "android", "isInstalled": true}, {"id": "ios", "isInstalled": true}]`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return client, base
+}
+
+func TestEditorPackRoundTrip(t *testing.T) {
+	client, base := newPackTestClient(t)
+
+	output := filepath.Join(base, "unity.tar.gz")
+	packer := &EditorPacker{Client: client, Version: "2022.3.10f1", Modules: []string{"android"}}
+	manifest, err := packer.Create(output)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !slices.Equal(manifest.Modules, []string{"android"}) || !slices.Equal(manifest.Excluded, []string{"ios"}) {
+		t.Errorf("modules = %v, excluded = %v", manifest.Modules, manifest.Excluded)
+	}
+	if manifest.Platform != runtime.GOOS {
+		t.Errorf("Platform = %q", manifest.Platform)
+	}
+
+	dest := filepath.Join(base, "Agent")
+	unpacked, editorPath, err := UnpackEditor(client, output, UnpackOptions{Dest: dest})
+	if err != nil {
+		t.Fatalf("UnpackEditor() error = %v", err)
+	}
+	if editorPath != hub.EditorPathIn(filepath.Join(dest, "2022.3.10f1")) {
+		t.Errorf("editorPath = %q", editorPath)
+	}
+	if len(unpacked.Files) != len(manifest.Files) {
+		t.Errorf("unpacked %d files, packed %d", len(unpacked.Files), len(manifest.Files))
+	}
+	if info, err := os.Stat(editorPath); err != nil || runtime.GOOS != "darwin" && info.Mode()&0100 == 0 {
+		t.Errorf("editor not executable: %v", err)
+	}
+	if _, err := os.Stat(client.ModuleDir(editorPath, "ios")); !os.IsNotExist(err) {
+		t.Errorf("iOS support was packed: %v", err)
+	}
+	installed := client.GetInstalledModules(editorPath)
+	if !installed["android"] || installed["ios"] {
+		t.Errorf("installed modules = %v, want android only", installed)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".2022.3.10f1.partial")); !os.IsNotExist(err) {
+		t.Error("staging directory left behind")
+	}
+
+	// Unpacking again needs --force
+	if _, _, err := UnpackEditor(client, output, UnpackOptions{Dest: dest}); !errors.Is(err, errs.Usage) {
+		t.Errorf("second UnpackEditor() error = %v, want Usage", err)
+	}
+	if _, _, err := UnpackEditor(client, output, UnpackOptions{Dest: dest, Overwrite: true}); err != nil {
+		t.Errorf("UnpackEditor() with Overwrite error = %v", err)
+	}
+
+	entries, err := client.LoadJournal()
+	if err != nil || len(entries) != 3 || entries[0].Op != hub.JournalUnpack || !entries[1].Failed() {
+		t.Errorf("journal = %+v, %v", entries, err)
+	}
+}
+
+func TestEditorPackErrors(t *testing.T) {
+	client, base := newPackTestClient(t)
+	output := filepath.Join(base, "unity.tar.gz")
+
+	if _, err := (&EditorPacker{Client: client, Version: "6000.0.1f1"}).Create(output); !errors.Is(err, errs.NotInstalled) {
+		t.Errorf("Create() of a missing editor error = %v, want NotInstalled", err)
+	}
+	if _, err := (&EditorPacker{Client: client, Version: "2022.3.10f1", Modules: []string{"webgl"}}).Create(output); !errors.Is(err, errs.NotInstalled) {
+		t.Errorf("Create() with a missing module error = %v, want NotInstalled", err)
+	}
+
+	// A project archive is not an editor pack
+	project := newArchiveTestProject(t)
+	archive := filepath.Join(base, "project.tar.gz")
+	if _, err := NewProjectArchiver(project).Create(archive); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := UnpackEditor(client, archive, UnpackOptions{Dest: filepath.Join(base, "Agent")}); !errors.Is(err, errs.Usage) {
+		t.Errorf("UnpackEditor() of a project archive error = %v, want Usage", err)
+	}
+}
@@ -0,0 +1,188 @@
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfigFile holds the environment of a project's Unity runs, inside ProjectConfigDir
+const EnvConfigFile = "env.yaml"
+
+// EnvProfileEnv names the env.yaml profile to apply on top of its env
+const EnvProfileEnv = "UNIFORGE_ENV_PROFILE"
+
+// envAppliedEnv holds the project whose env.yaml the environment has, so
+// values such as PATH=${PATH}:... are not applied twice
+const envAppliedEnv = "UNIFORGE_ENV_APPLIED"
+
+// EnvConfig is a project's env.yaml: variables set whenever uniforge starts
+// Unity or a task step for the project, and named profiles layered on top,
+// e.g. proxy settings for the office:
+//
+//	env:
+//	  ANDROID_SDK_ROOT: /opt/android-sdk
+//	  GRADLE_USER_HOME: ${HOME}/.gradle-unity
+//	profiles:
+//	  office:
+//	    HTTPS_PROXY: http://proxy.example.com:8080
+//
+// Values may refer to the inherited environment and to variables set before
+// them as $NAME or ${NAME}; $$ is a literal $.
+type EnvConfig struct {
+	Env      map[string]string            `yaml:"env,omitempty"`
+	Profiles map[string]map[string]string `yaml:"profiles,omitempty"`
+}
+
+// EnvVar is a variable env.yaml sets
+type EnvVar struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"` // "env" or "profile <name>"
+}
+
+// EnvConfigPath returns the path of the project's env.yaml
+func EnvConfigPath(projectPath string) string {
+	return filepath.Join(projectPath, ProjectConfigDir, EnvConfigFile)
+}
+
+// LoadEnvConfig reads the project's env.yaml. A project without one yields an
+// empty config.
+func LoadEnvConfig(projectPath string) (*EnvConfig, error) {
+	data, err := os.ReadFile(EnvConfigPath(projectPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &EnvConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read environment config: %w", err)
+	}
+
+	var config EnvConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvConfigPath(projectPath), err)
+	}
+	return &config, nil
+}
+
+// ProfileNames returns the names of the profiles, sorted
+func (c *EnvConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// envLayer is a set of variables of env.yaml and where they come from
+type envLayer struct {
+	source string
+	vars   map[string]string
+}
+
+// Resolve returns the variables the config sets on top of base (KEY=VALUE),
+// with profile applied after env and values expanded. An empty profile
+// applies env only. A profile the config does not define is an error, unless
+// the config has no profiles at all, so a profile chosen for every project
+// does not break projects without one.
+func (c *EnvConfig) Resolve(base []string, profile string) ([]EnvVar, error) {
+	layers := []envLayer{{"env", c.Env}}
+	if profile != "" {
+		vars, ok := c.Profiles[profile]
+		if !ok && len(c.Profiles) > 0 {
+			return nil, errs.WithHint(
+				errs.New(errs.NotFound, "unknown environment profile: %s", profile),
+				"Profiles in env.yaml: "+strings.Join(c.ProfileNames(), ", "))
+		}
+		layers = append(layers, envLayer{"profile " + profile, vars})
+	}
+
+	env := append([]string(nil), base...)
+	var result []EnvVar
+	for _, layer := range layers {
+		names := make([]string, 0, len(layer.vars))
+		for name := range layer.vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			value := os.Expand(layer.vars[name], EnvLookup(env))
+			env = append(env, name+"="+value)
+			result = append(result, EnvVar{Name: name, Value: value, Source: layer.source})
+		}
+	}
+	return result, nil
+}
+
+// ProjectEnvVars returns the variables the project's env.yaml sets on top of
+// the current environment with profile applied. Nothing is set again in a
+// process started with them already, e.g. a uniforge task step.
+func ProjectEnvVars(projectPath, profile string) ([]EnvVar, error) {
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		absPath = projectPath
+	}
+	if os.Getenv(envAppliedEnv) == absPath {
+		return nil, nil
+	}
+	config, err := LoadEnvConfig(absPath)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := config.Resolve(os.Environ(), profile)
+	if err != nil || len(vars) == 0 {
+		return vars, err
+	}
+	return append(vars, EnvVar{Name: envAppliedEnv, Value: absPath, Source: "uniforge"}), nil
+}
+
+// ProjectEnviron returns the environment for a process uniforge starts for the
+// project: the current environment with the project's env.yaml applied, using
+// the profile named by UNIFORGE_ENV_PROFILE
+func ProjectEnviron(projectPath string) ([]string, error) {
+	vars, err := ProjectEnvVars(projectPath, os.Getenv(EnvProfileEnv))
+	if err != nil {
+		return nil, err
+	}
+	return append(os.Environ(), EnvStrings(vars)...), nil
+}
+
+// EnvStrings returns vars as KEY=VALUE
+func EnvStrings(vars []EnvVar) []string {
+	env := make([]string, 0, len(vars))
+	for _, v := range vars {
+		env = append(env, v.Name+"="+v.Value)
+	}
+	return env
+}
+
+// EnvLookup returns a lookup of the last value of each variable in env
+// (KEY=VALUE) for os.Expand, as exec.Cmd uses the last value of a duplicated
+// variable
+func EnvLookup(env []string) func(string) string {
+	return func(name string) string {
+		// $$ is a literal $
+		if name == "$" {
+			return "$"
+		}
+		for i := len(env) - 1; i >= 0; i-- {
+			k, v, ok := strings.Cut(env[i], "=")
+			if !ok {
+				continue
+			}
+			// Windows environment variable names are case-insensitive
+			if k == name || (runtime.GOOS == "windows" && strings.EqualFold(k, name)) {
+				return v
+			}
+		}
+		return ""
+	}
+}
@@ -0,0 +1,115 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestEnvConfigResolve(t *testing.T) {
+	config := &EnvConfig{
+		Env: map[string]string{
+			"GRADLE_USER_HOME": "${HOME}/.gradle-unity",
+			"PATH":             "$PATH:/opt/tools",
+			"PRICE":            "$$5",
+		},
+		Profiles: map[string]map[string]string{
+			"office": {
+				"HTTPS_PROXY": "http://proxy:8080",
+				"HTTP_PROXY":  "${HTTPS_PROXY}",
+				"PATH":        "/opt/office:$PATH",
+			},
+		},
+	}
+	base := []string{"HOME=/home/dev", "PATH=/usr/bin"}
+
+	vars, err := config.Resolve(base, "office")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []EnvVar{
+		{Name: "GRADLE_USER_HOME", Value: "/home/dev/.gradle-unity", Source: "env"},
+		{Name: "PATH", Value: "/usr/bin:/opt/tools", Source: "env"},
+		{Name: "PRICE", Value: "$5", Source: "env"},
+		{Name: "HTTPS_PROXY", Value: "http://proxy:8080", Source: "profile office"},
+		{Name: "HTTP_PROXY", Value: "http://proxy:8080", Source: "profile office"},
+		{Name: "PATH", Value: "/opt/office:/usr/bin:/opt/tools", Source: "profile office"},
+	}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("Resolve() = %+v\nwant %+v", vars, want)
+	}
+
+	if vars, err := config.Resolve(base, ""); err != nil || len(vars) != 3 {
+		t.Errorf("Resolve() without profile = %+v, %v; want the 3 env variables", vars, err)
+	}
+
+	_, err = config.Resolve(base, "home")
+	if errs.CategoryOf(err) != errs.NotFound || !strings.Contains(errs.Hint(err), "office") {
+		t.Errorf("Resolve(unknown profile) error = %v, hint %q", err, errs.Hint(err))
+	}
+
+	// A profile chosen for all projects does not break one without profiles
+	plain := &EnvConfig{Env: map[string]string{"A": "1"}}
+	if vars, err := plain.Resolve(base, "office"); err != nil || len(vars) != 1 {
+		t.Errorf("Resolve() = %+v, %v; want A only", vars, err)
+	}
+}
+
+func TestProjectEnviron(t *testing.T) {
+	project := t.TempDir()
+	t.Setenv("UNIFORGE_TEST_DIR", "/base")
+	t.Setenv(EnvProfileEnv, "")
+	t.Setenv(envAppliedEnv, "")
+
+	// No env.yaml: the environment is passed on as is
+	env, err := ProjectEnviron(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(env) != len(os.Environ()) {
+		t.Errorf("ProjectEnviron() added %d variables without env.yaml", len(env)-len(os.Environ()))
+	}
+
+	configYAML := "env:\n  UNIFORGE_TEST_DIR: ${UNIFORGE_TEST_DIR}/sub\n"
+	if err := os.MkdirAll(filepath.Join(project, ProjectConfigDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(EnvConfigPath(project), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	env, err = ProjectEnviron(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EnvLookup(env)("UNIFORGE_TEST_DIR"); got != "/base/sub" {
+		t.Errorf("UNIFORGE_TEST_DIR = %q, want /base/sub", got)
+	}
+
+	// A process started with the environment does not apply it again
+	t.Setenv("UNIFORGE_TEST_DIR", "/base/sub")
+	t.Setenv(envAppliedEnv, EnvLookup(env)(envAppliedEnv))
+	env, err = ProjectEnviron(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EnvLookup(env)("UNIFORGE_TEST_DIR"); got != "/base/sub" {
+		t.Errorf("UNIFORGE_TEST_DIR = %q after a second apply, want /base/sub", got)
+	}
+}
+
+func TestLoadEnvConfigInvalid(t *testing.T) {
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ProjectConfigDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(EnvConfigPath(project), []byte("env: [1, 2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEnvConfig(project); err == nil {
+		t.Error("LoadEnvConfig() accepted invalid YAML")
+	}
+}
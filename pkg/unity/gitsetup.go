@@ -0,0 +1,122 @@
+package unity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/git"
+)
+
+// gitSetupBlock names the managed blocks written by GitSetup
+const gitSetupBlock = "unity"
+
+// unityGitignore lists generated and user-local files of a Unity project.
+// Paths are anchored to the project root where the .gitignore is written.
+const unityGitignore = `/[Ll]ibrary/
+/[Tt]emp/
+/[Oo]bj/
+/[Bb]uild/
+/[Bb]uilds/
+/[Ll]ogs/
+/[Uu]ser[Ss]ettings/
+/[Mm]emoryCaptures/
+/[Rr]ecordings/
+/.uniforge/status.json
+
+# Asset meta data should only be ignored when the corresponding asset is also ignored
+!/[Aa]ssets/**/*.meta
+
+# Autogenerated IDE and solution files
+.vs/
+.vscode/
+.idea/
+.gradle/
+ExportedObj/
+.consulo/
+*.csproj
+*.unityproj
+*.sln
+*.suo
+*.tmp
+*.user
+*.userprefs
+*.pidb
+*.booproj
+*.svd
+*.pdb
+*.mdb
+*.opendb
+*.VC.db
+*.pidb.meta
+*.pdb.meta
+*.mdb.meta
+
+# Crash reports and builds
+sysinfo.txt
+crashlytics-build.properties
+*.apk
+*.aab
+*.unitypackage
+*.app
+
+# Addressables and Burst
+/[Aa]ssets/[Aa]ddressable[Aa]ssets[Dd]ata/*/*.bin*
+/[Aa]ssets/[Ss]treamingAssets/aa.meta
+/[Aa]ssets/[Ss]treamingAssets/aa/*
+*_BurstDebugInformation_DoNotShip/
+
+# OS files
+.DS_Store
+Thumbs.db
+`
+
+// lfsPatterns are binary asset types stored in Git LFS
+var lfsPatterns = []string{
+	// Images
+	"*.psd", "*.png", "*.jpg", "*.jpeg", "*.tga", "*.tif", "*.tiff", "*.exr", "*.hdr", "*.gif", "*.bmp",
+	// Audio
+	"*.wav", "*.mp3", "*.ogg", "*.aif", "*.aiff",
+	// Video
+	"*.mp4", "*.mov", "*.webm",
+	// 3D models
+	"*.fbx", "*.obj", "*.blend", "*.max", "*.ma", "*.mb",
+	// Fonts
+	"*.ttf", "*.otf",
+	// Native plugins and archives
+	"*.so", "*.a", "*.dylib", "*.zip",
+}
+
+// lfsAttributes returns the .gitattributes lines tracking lfsPatterns with LFS
+func lfsAttributes() string {
+	var b strings.Builder
+	for _, pattern := range lfsPatterns {
+		fmt.Fprintf(&b, "%s filter=lfs diff=lfs merge=lfs -text\n", pattern)
+	}
+	return b.String()
+}
+
+// GitSetup writes uniforge-managed blocks with a canonical Unity .gitignore and
+// Git LFS .gitattributes to the project root. Lines outside the blocks are never
+// modified. Returns the files that were created or updated.
+func GitSetup(projectPath string) ([]string, error) {
+	files := []struct {
+		name    string
+		content string
+	}{
+		{".gitignore", unityGitignore},
+		{".gitattributes", lfsAttributes()},
+	}
+
+	var changed []string
+	for _, f := range files {
+		updated, err := git.WriteBlock(filepath.Join(projectPath, f.name), gitSetupBlock, f.content)
+		if err != nil {
+			return changed, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		if updated {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,74 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitSetup(t *testing.T) {
+	_, root := setupTestProject(t)
+
+	userIgnore := "# Team rules\n/Secrets/\n"
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte(userIgnore), 0644); err != nil {
+		t.Fatalf("Failed to write .gitignore: %v", err)
+	}
+
+	changed, err := GitSetup(root)
+	if err != nil {
+		t.Fatalf("GitSetup() error = %v", err)
+	}
+	if strings.Join(changed, ",") != ".gitignore,.gitattributes" {
+		t.Errorf("GitSetup() changed = %v, want both files", changed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		t.Fatalf("Failed to read .gitignore: %v", err)
+	}
+	if !strings.HasPrefix(string(data), userIgnore) {
+		t.Errorf(".gitignore should keep user rules, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "/[Ll]ibrary/") {
+		t.Errorf(".gitignore should ignore Library, got:\n%s", data)
+	}
+
+	data, err = os.ReadFile(filepath.Join(root, ".gitattributes"))
+	if err != nil {
+		t.Fatalf("Failed to read .gitattributes: %v", err)
+	}
+	if !strings.Contains(string(data), "*.psd filter=lfs diff=lfs merge=lfs -text") {
+		t.Errorf(".gitattributes should track PSD files with LFS, got:\n%s", data)
+	}
+
+	// Running again changes nothing
+	changed, err = GitSetup(root)
+	if err != nil {
+		t.Fatalf("GitSetup() error = %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("GitSetup() second run changed = %v, want none", changed)
+	}
+}
+
+func TestFindProjectRoot(t *testing.T) {
+	_, root := setupTestProject(t)
+
+	scripts := filepath.Join(root, "Assets", "Scripts")
+	if err := os.MkdirAll(scripts, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	got, err := FindProjectRoot(scripts)
+	if err != nil {
+		t.Fatalf("FindProjectRoot() error = %v", err)
+	}
+	if got != root {
+		t.Errorf("FindProjectRoot() = %q, want %q", got, root)
+	}
+
+	if _, err := FindProjectRoot(t.TempDir()); err == nil {
+		t.Error("FindProjectRoot() outside a project should fail")
+	}
+}
@@ -0,0 +1,331 @@
+package unity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// GraphNodeKind tells packages and assemblies apart in a DependencyGraph
+type GraphNodeKind string
+
+const (
+	GraphNodePackage  GraphNodeKind = "package"
+	GraphNodeAssembly GraphNodeKind = "assembly"
+)
+
+// GraphNode is a package or an assembly definition
+type GraphNode struct {
+	ID      string        `json:"id"`
+	Kind    GraphNodeKind `json:"kind"`
+	Name    string        `json:"name"`
+	Version string        `json:"version,omitempty"` // Packages only
+	Source  string        `json:"source,omitempty"`  // Packages only: registry, embedded, local, git, builtin
+	Direct  bool          `json:"direct,omitempty"`  // Package listed in manifest.json
+	Package string        `json:"package,omitempty"` // Assemblies only: package the .asmdef belongs to
+	Path    string        `json:"path,omitempty"`    // Assemblies only: .asmdef relative to the project
+}
+
+// GraphEdge is a dependency of From on To
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DependencyGraph holds a project's packages, assemblies and their dependencies.
+// Packages depend on packages, assemblies reference assemblies and packages
+// contain their assemblies.
+type DependencyGraph struct {
+	Nodes  []GraphNode `json:"nodes"`
+	Edges  []GraphEdge `json:"edges"`
+	Cycles [][]string  `json:"cycles,omitempty"` // Assembly names referencing each other in a loop
+}
+
+// GraphOptions selects what BuildDependencyGraph includes
+type GraphOptions struct {
+	NoPackages   bool // Only assemblies
+	NoAssemblies bool // Only packages
+}
+
+// packagesLock is the part of Packages/packages-lock.json the graph needs
+type packagesLock struct {
+	Dependencies map[string]struct {
+		Version      string            `json:"version"`
+		Source       string            `json:"source"`
+		Dependencies map[string]string `json:"dependencies"`
+	} `json:"dependencies"`
+}
+
+func packageNodeID(name string) string  { return "package:" + name }
+func assemblyNodeID(name string) string { return "assembly:" + name }
+
+// BuildDependencyGraph reads Packages/manifest.json, Packages/packages-lock.json
+// and the .asmdef files under Assets and Packages of the project at projectPath.
+// Assemblies of packages outside the project (registry, git) are not scanned;
+// references to them appear as assemblies without a path.
+func BuildDependencyGraph(projectPath string, opts GraphOptions) (*DependencyGraph, error) {
+	graph := &DependencyGraph{}
+	nodes := make(map[string]int)
+	addNode := func(node GraphNode) *GraphNode {
+		if i, ok := nodes[node.ID]; ok {
+			return &graph.Nodes[i]
+		}
+		nodes[node.ID] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, node)
+		return &graph.Nodes[len(graph.Nodes)-1]
+	}
+
+	if !opts.NoPackages {
+		if err := addPackages(projectPath, graph, addNode); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.NoAssemblies {
+		if err := addAssemblies(projectPath, graph, addNode, !opts.NoPackages); err != nil {
+			return nil, err
+		}
+		graph.Cycles = findAssemblyCycles(graph)
+	}
+
+	slices.SortFunc(graph.Nodes, func(a, b GraphNode) int { return strings.Compare(a.ID, b.ID) })
+	slices.SortFunc(graph.Edges, func(a, b GraphEdge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	graph.Edges = slices.Compact(graph.Edges)
+	return graph, nil
+}
+
+func addPackages(projectPath string, graph *DependencyGraph, addNode func(GraphNode) *GraphNode) error {
+	manifest, err := LoadPackageManifest(projectPath)
+	if err != nil {
+		return err
+	}
+	for name, version := range manifest.Dependencies {
+		addNode(GraphNode{ID: packageNodeID(name), Kind: GraphNodePackage, Name: name, Version: version, Direct: true})
+	}
+
+	// Without a lock file only the direct dependencies are known
+	lockPath := filepath.Join(projectPath, "Packages", "packages-lock.json")
+	data, err := os.ReadFile(lockPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var lock packagesLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", lockPath, err)
+	}
+	for name, entry := range lock.Dependencies {
+		node := addNode(GraphNode{ID: packageNodeID(name), Kind: GraphNodePackage, Name: name})
+		node.Version = entry.Version
+		node.Source = entry.Source
+		for dep, version := range entry.Dependencies {
+			addNode(GraphNode{ID: packageNodeID(dep), Kind: GraphNodePackage, Name: dep, Version: version})
+			graph.Edges = append(graph.Edges, GraphEdge{From: packageNodeID(name), To: packageNodeID(dep)})
+		}
+	}
+	return nil
+}
+
+func addAssemblies(projectPath string, graph *DependencyGraph, addNode func(GraphNode) *GraphNode, withPackages bool) error {
+	assemblies, err := LoadAssemblyDefinitions(projectPath)
+	if err != nil {
+		return err
+	}
+	byGUID := make(map[string]string)
+	for _, asm := range assemblies {
+		if asm.GUID != "" {
+			byGUID[asm.GUID] = asm.Name
+		}
+	}
+
+	for _, asm := range assemblies {
+		node := addNode(GraphNode{ID: assemblyNodeID(asm.Name), Kind: GraphNodeAssembly, Name: asm.Name})
+		node.Path = asm.Path
+		node.Package = asm.Package
+		if asm.Package != "" && withPackages {
+			addNode(GraphNode{ID: packageNodeID(asm.Package), Kind: GraphNodePackage, Name: asm.Package})
+			graph.Edges = append(graph.Edges, GraphEdge{From: packageNodeID(asm.Package), To: assemblyNodeID(asm.Name)})
+		}
+
+		for _, ref := range asm.References {
+			if guid, ok := strings.CutPrefix(ref, "GUID:"); ok {
+				name, known := byGUID[guid]
+				if !known {
+					// An assembly of a package outside the project
+					continue
+				}
+				ref = name
+			}
+			addNode(GraphNode{ID: assemblyNodeID(ref), Kind: GraphNodeAssembly, Name: ref})
+			graph.Edges = append(graph.Edges, GraphEdge{From: assemblyNodeID(asm.Name), To: assemblyNodeID(ref)})
+		}
+	}
+	return nil
+}
+
+// owningPackage returns the name in the package.json of the embedded or local
+// package containing path, or "" for assemblies under Assets
+func owningPackage(projectPath, path string) string {
+	packagesDir := filepath.Join(projectPath, "Packages")
+	for dir := filepath.Dir(path); strings.HasPrefix(dir, packagesDir+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		if err != nil {
+			continue
+		}
+		var pkg struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Name != "" {
+			return pkg.Name
+		}
+	}
+	return ""
+}
+
+// findAssemblyCycles returns the strongly connected components of the assembly
+// references that contain a loop, each sorted by name
+func findAssemblyCycles(graph *DependencyGraph) [][]string {
+	refs := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		from, ok := strings.CutPrefix(edge.From, "assembly:")
+		if !ok {
+			continue
+		}
+		if to, ok := strings.CutPrefix(edge.To, "assembly:"); ok {
+			refs[from] = append(refs[from], to)
+		}
+	}
+
+	// Tarjan's algorithm
+	var (
+		cycles  [][]string
+		stack   []string
+		onStack = make(map[string]bool)
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+	)
+	var visit func(name string)
+	visit = func(name string) {
+		index[name] = len(index)
+		lowlink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, ref := range refs[name] {
+			if _, seen := index[ref]; !seen {
+				visit(ref)
+				lowlink[name] = min(lowlink[name], lowlink[ref])
+			} else if onStack[ref] {
+				lowlink[name] = min(lowlink[name], index[ref])
+			}
+		}
+
+		if lowlink[name] != index[name] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == name {
+				break
+			}
+		}
+		if len(component) > 1 || slices.Contains(refs[name], name) {
+			slices.Sort(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if _, seen := index[name]; !seen {
+			visit(name)
+		}
+	}
+	slices.SortFunc(cycles, func(a, b []string) int { return strings.Compare(a[0], b[0]) })
+	return cycles
+}
+
+// DOT renders the graph for Graphviz. Packages are boxes, assemblies ellipses
+// and assemblies in a cycle are red.
+func (g *DependencyGraph) DOT() string {
+	inCycle := g.cycleMembers()
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n  rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		attrs := fmt.Sprintf("label=%q", node.label())
+		if node.Kind == GraphNodePackage {
+			attrs += ", shape=box"
+		}
+		if inCycle[node.ID] {
+			attrs += ", color=red"
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", node.ID, attrs)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %q -> %q;\n", edge.From, edge.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart for Markdown documentation
+func (g *DependencyGraph) Mermaid() string {
+	inCycle := g.cycleMembers()
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, node := range g.Nodes {
+		// Mermaid IDs cannot contain dots, so nodes are numbered
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+		label := strings.ReplaceAll(node.label(), `"`, "#quot;")
+		if node.Kind == GraphNodePackage {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, label)
+		} else {
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", id, label)
+		}
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+	}
+	for _, node := range g.Nodes {
+		if inCycle[node.ID] {
+			fmt.Fprintf(&b, "  style %s stroke:#f00\n", ids[node.ID])
+		}
+	}
+	return b.String()
+}
+
+func (n GraphNode) label() string {
+	if n.Version != "" {
+		return n.Name + "@" + n.Version
+	}
+	return n.Name
+}
+
+func (g *DependencyGraph) cycleMembers() map[string]bool {
+	members := make(map[string]bool)
+	for _, cycle := range g.Cycles {
+		for _, name := range cycle {
+			members[assemblyNodeID(name)] = true
+		}
+	}
+	return members
+}
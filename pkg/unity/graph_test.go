@@ -0,0 +1,137 @@
+package unity
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeGraphProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"Packages/manifest.json": `{"dependencies": {"com.unity.ugui": "1.0.0", "com.studio.tools": "file:com.studio.tools"}}`,
+		"Packages/packages-lock.json": `{"dependencies": {
+			"com.unity.ugui": {"version": "1.0.0", "depth": 0, "source": "builtin", "dependencies": {"com.unity.modules.ui": "1.0.0"}},
+			"com.unity.modules.ui": {"version": "1.0.0", "depth": 1, "source": "builtin", "dependencies": {}},
+			"com.studio.tools": {"version": "file:com.studio.tools", "depth": 0, "source": "embedded", "dependencies": {}}
+		}}`,
+		"Packages/com.studio.tools/package.json":                `{"name": "com.studio.tools", "version": "0.1.0"}`,
+		"Packages/com.studio.tools/Runtime/Studio.Tools.asmdef": `{"name": "Studio.Tools", "references": ["Unity.TextMeshPro"]}`,
+		"Assets/Scripts/Game.asmdef":                            "\xef\xbb\xbf" + `{"name": "Game", "references": ["GUID:0123456789abcdef0123456789abcdef", "Studio.Tools", "GUID:ffffffffffffffffffffffffffffffff"]}`,
+		"Assets/Scripts/Core/Game.Core.asmdef":                  `{"name": "Game.Core", "references": ["Game.Util"]}`,
+		"Assets/Scripts/Core/Game.Core.asmdef.meta":             "fileFormatVersion: 2\nguid: 0123456789abcdef0123456789abcdef\n",
+		"Assets/Scripts/Util/Game.Util.asmdef":                  `{"name": "Game.Util", "references": ["Game.Core"]}`,
+		"Assets/Scripts/Self/Game.Self.asmdef":                  `{"name": "Game.Self", "references": ["Game.Self"]}`,
+		"Assets/Samples~/Sample.asmdef":                         `{"name": "Sample", "references": ["Game"]}`,
+	})
+	return dir
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	graph, err := BuildDependencyGraph(writeGraphProject(t), GraphOptions{})
+	if err != nil {
+		t.Fatalf("BuildDependencyGraph() error = %v", err)
+	}
+
+	nodes := make(map[string]GraphNode)
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+	if len(nodes) != 9 {
+		t.Errorf("got %d nodes, want 9: %+v", len(nodes), graph.Nodes)
+	}
+	if ugui := nodes["package:com.unity.ugui"]; !ugui.Direct || ugui.Source != "builtin" {
+		t.Errorf("com.unity.ugui = %+v", ugui)
+	}
+	if ui := nodes["package:com.unity.modules.ui"]; ui.Direct || ui.Version != "1.0.0" {
+		t.Errorf("com.unity.modules.ui = %+v", ui)
+	}
+	if tools := nodes["assembly:Studio.Tools"]; tools.Package != "com.studio.tools" || tools.Path != "Packages/com.studio.tools/Runtime/Studio.Tools.asmdef" {
+		t.Errorf("Studio.Tools = %+v", tools)
+	}
+	if tmp, ok := nodes["assembly:Unity.TextMeshPro"]; !ok || tmp.Path != "" {
+		t.Errorf("Unity.TextMeshPro = %+v, want a node without a path", tmp)
+	}
+	if _, ok := nodes["assembly:Sample"]; ok {
+		t.Error("assemblies in folders ending in ~ should be skipped")
+	}
+
+	for _, want := range []GraphEdge{
+		{From: "package:com.unity.ugui", To: "package:com.unity.modules.ui"},
+		{From: "package:com.studio.tools", To: "assembly:Studio.Tools"},
+		{From: "assembly:Game", To: "assembly:Game.Core"}, // Referenced by GUID
+		{From: "assembly:Game", To: "assembly:Studio.Tools"},
+	} {
+		if !slices.Contains(graph.Edges, want) {
+			t.Errorf("missing edge %s -> %s", want.From, want.To)
+		}
+	}
+
+	wantCycles := [][]string{{"Game.Core", "Game.Util"}, {"Game.Self"}}
+	if !slices.EqualFunc(graph.Cycles, wantCycles, slices.Equal) {
+		t.Errorf("Cycles = %v, want %v", graph.Cycles, wantCycles)
+	}
+}
+
+func TestBuildDependencyGraphOptions(t *testing.T) {
+	dir := writeGraphProject(t)
+
+	graph, err := BuildDependencyGraph(dir, GraphOptions{NoPackages: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, node := range graph.Nodes {
+		if node.Kind == GraphNodePackage {
+			t.Errorf("NoPackages graph has package %s", node.Name)
+		}
+	}
+
+	graph, err = BuildDependencyGraph(dir, GraphOptions{NoAssemblies: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Nodes) != 3 || len(graph.Cycles) != 0 {
+		t.Errorf("NoAssemblies graph = %+v", graph)
+	}
+}
+
+func TestDependencyGraphRender(t *testing.T) {
+	graph := &DependencyGraph{
+		Nodes: []GraphNode{
+			{ID: "assembly:A", Kind: GraphNodeAssembly, Name: "A"},
+			{ID: "assembly:B", Kind: GraphNodeAssembly, Name: "B"},
+			{ID: "package:com.x", Kind: GraphNodePackage, Name: "com.x", Version: "1.0.0"},
+		},
+		Edges: []GraphEdge{
+			{From: "assembly:A", To: "assembly:B"},
+			{From: "assembly:B", To: "assembly:A"},
+			{From: "package:com.x", To: "assembly:A"},
+		},
+		Cycles: [][]string{{"A", "B"}},
+	}
+
+	dot := graph.DOT()
+	for _, want := range []string{
+		`"assembly:A" [label="A", color=red];`,
+		`"package:com.x" [label="com.x@1.0.0", shape=box];`,
+		`"package:com.x" -> "assembly:A";`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT() missing %q:\n%s", want, dot)
+		}
+	}
+
+	mermaid := graph.Mermaid()
+	for _, want := range []string{
+		"flowchart LR\n",
+		`n0(["A"])`,
+		`n2["com.x@1.0.0"]`,
+		"n2 --> n0",
+		"style n1 stroke:#f00",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Mermaid() missing %q:\n%s", want, mermaid)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package unity
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// guidPattern matches Unity asset GUIDs (32 lowercase hex characters)
+var guidPattern = regexp.MustCompile(`[0-9a-f]{32}`)
+
+// binarySniffLen is how many leading bytes are checked for NUL to detect binary files
+const binarySniffLen = 8000
+
+// GUIDRemapResult holds the outcome of RegenerateGUIDs
+type GUIDRemapResult struct {
+	Remapped       map[string]string // old GUID -> new GUID
+	RewrittenFiles []string          // Files whose contents changed, relative to the folder
+}
+
+// RegenerateGUIDs assigns a fresh GUID to every .meta file under folder and rewrites
+// all references to the old GUIDs in text-serialized files under the same folder.
+// This makes a copied subtree safe to import next to the assets it was copied from.
+// References from outside the folder are not touched. With dryRun, no files are written.
+func RegenerateGUIDs(folder string, dryRun bool) (*GUIDRemapResult, error) {
+	info, err := os.Stat(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access folder: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", folder)
+	}
+
+	result := &GUIDRemapResult{
+		Remapped:       make(map[string]string),
+		RewrittenFiles: []string{},
+	}
+
+	// Pass 1: assign new GUIDs to every .meta file
+	var files []string
+	err = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != folder && excludedDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files = append(files, path)
+		if !strings.HasSuffix(path, ".meta") {
+			return nil
+		}
+
+		guid, err := extractGUID(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if guid == "" || result.Remapped[guid] != "" {
+			return nil
+		}
+		newGUID, err := NewGUID()
+		if err != nil {
+			return err
+		}
+		result.Remapped[guid] = newGUID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk folder: %w", err)
+	}
+
+	if len(result.Remapped) == 0 {
+		return result, nil
+	}
+
+	// Pass 2: rewrite references (including the guid line of each .meta itself)
+	for _, path := range files {
+		changed, err := rewriteGUIDs(path, result.Remapped, dryRun)
+		if err != nil {
+			return result, err
+		}
+		if changed {
+			rel, err := filepath.Rel(folder, path)
+			if err != nil {
+				rel = path
+			}
+			result.RewrittenFiles = append(result.RewrittenFiles, rel)
+		}
+	}
+
+	sort.Strings(result.RewrittenFiles)
+	return result, nil
+}
+
+// rewriteGUIDs replaces mapped GUIDs in a text file. Binary files are skipped.
+func rewriteGUIDs(path string, remap map[string]string, dryRun bool) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if bytes.IndexByte(data[:min(len(data), binarySniffLen)], 0) >= 0 {
+		return false, nil
+	}
+
+	changed := false
+	updated := guidPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		if newGUID, ok := remap[string(match)]; ok {
+			changed = true
+			return []byte(newGUID)
+		}
+		return match
+	})
+	if !changed || dryRun {
+		return changed, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if err := fsutil.WriteFileAtomic(path, updated, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// NewGUID returns a random GUID in Unity's format (32 lowercase hex characters)
+func NewGUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate GUID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,110 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testMaterialGUID = "11111111111111111111111111111111"
+	testPrefabGUID   = "22222222222222222222222222222222"
+	testExternalGUID = "33333333333333333333333333333333"
+)
+
+func TestRegenerateGUIDs(t *testing.T) {
+	folder := t.TempDir()
+	createAssetWithMeta(t, folder, "Red.mat", testMaterialGUID)
+	createAssetWithMeta(t, folder, "Cube.prefab", testPrefabGUID)
+
+	// The prefab references the material inside the folder and a script outside it
+	prefab := "m_Materials:\n  - {fileID: 2100000, guid: " + testMaterialGUID + ", type: 2}\n" +
+		"m_Script: {fileID: 11500000, guid: " + testExternalGUID + ", type: 3}\n"
+	prefabPath := filepath.Join(folder, "Cube.prefab")
+	if err := os.WriteFile(prefabPath, []byte(prefab), 0644); err != nil {
+		t.Fatalf("Failed to write prefab: %v", err)
+	}
+
+	result, err := RegenerateGUIDs(folder, false)
+	if err != nil {
+		t.Fatalf("RegenerateGUIDs() error = %v", err)
+	}
+
+	if len(result.Remapped) != 2 {
+		t.Fatalf("Remapped = %v, want 2 entries", result.Remapped)
+	}
+	newMaterialGUID := result.Remapped[testMaterialGUID]
+	if len(newMaterialGUID) != 32 || newMaterialGUID == testMaterialGUID {
+		t.Errorf("new material GUID = %q, want a fresh 32 character GUID", newMaterialGUID)
+	}
+
+	guid, err := extractGUID(filepath.Join(folder, "Red.mat.meta"))
+	if err != nil {
+		t.Fatalf("extractGUID() error = %v", err)
+	}
+	if guid != newMaterialGUID {
+		t.Errorf("meta GUID = %q, want %q", guid, newMaterialGUID)
+	}
+
+	data, err := os.ReadFile(prefabPath)
+	if err != nil {
+		t.Fatalf("Failed to read prefab: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "guid: "+newMaterialGUID) {
+		t.Errorf("prefab should reference the new material GUID, got:\n%s", content)
+	}
+	if !strings.Contains(content, "guid: "+testExternalGUID) {
+		t.Errorf("prefab should keep the external script GUID, got:\n%s", content)
+	}
+
+	want := []string{"Cube.prefab", "Cube.prefab.meta", "Red.mat.meta"}
+	if strings.Join(result.RewrittenFiles, ",") != strings.Join(want, ",") {
+		t.Errorf("RewrittenFiles = %v, want %v", result.RewrittenFiles, want)
+	}
+}
+
+func TestRegenerateGUIDs_DryRun(t *testing.T) {
+	folder := t.TempDir()
+	createAssetWithMeta(t, folder, "Red.mat", testMaterialGUID)
+
+	result, err := RegenerateGUIDs(folder, true)
+	if err != nil {
+		t.Fatalf("RegenerateGUIDs() error = %v", err)
+	}
+	if len(result.RewrittenFiles) != 1 {
+		t.Errorf("RewrittenFiles = %v, want 1 entry", result.RewrittenFiles)
+	}
+
+	guid, err := extractGUID(filepath.Join(folder, "Red.mat.meta"))
+	if err != nil {
+		t.Fatalf("extractGUID() error = %v", err)
+	}
+	if guid != testMaterialGUID {
+		t.Errorf("dry run modified the meta file, GUID = %q", guid)
+	}
+}
+
+func TestRegenerateGUIDs_SkipsBinaryFiles(t *testing.T) {
+	folder := t.TempDir()
+	createAssetWithMeta(t, folder, "Red.mat", testMaterialGUID)
+
+	binary := append([]byte{0, 1, 2}, []byte(testMaterialGUID)...)
+	binaryPath := filepath.Join(folder, "texture.png")
+	if err := os.WriteFile(binaryPath, binary, 0644); err != nil {
+		t.Fatalf("Failed to write binary file: %v", err)
+	}
+
+	if _, err := RegenerateGUIDs(folder, false); err != nil {
+		t.Fatalf("RegenerateGUIDs() error = %v", err)
+	}
+
+	data, err := os.ReadFile(binaryPath)
+	if err != nil {
+		t.Fatalf("Failed to read binary file: %v", err)
+	}
+	if string(data) != string(binary) {
+		t.Error("binary file should not be modified")
+	}
+}
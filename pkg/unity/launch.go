@@ -0,0 +1,158 @@
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+	"gopkg.in/yaml.v3"
+)
+
+// Per-project UniForge state lives in <project>/.uniforge
+const (
+	ProjectConfigDir = ".uniforge"
+	LaunchConfigFile = "launch.yaml"
+)
+
+// argBuildTarget is the Unity command line argument of LaunchOptions.BuildTarget
+const argBuildTarget = "-buildTarget"
+
+// LaunchOptions are extra Unity Editor arguments remembered per project
+type LaunchOptions struct {
+	BuildTarget string   `yaml:"buildTarget,omitempty"`
+	ExtraArgs   []string `yaml:"args,omitempty"`
+}
+
+// LaunchOptionsPath returns the path of the project's launch.yaml
+func LaunchOptionsPath(projectPath string) string {
+	return filepath.Join(projectPath, ProjectConfigDir, LaunchConfigFile)
+}
+
+// LoadLaunchOptions reads the saved launch options of a project.
+// A project without launch.yaml yields empty options.
+func LoadLaunchOptions(projectPath string) (*LaunchOptions, error) {
+	data, err := os.ReadFile(LaunchOptionsPath(projectPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &LaunchOptions{}, nil
+		}
+		return nil, fmt.Errorf("failed to read launch options: %w", err)
+	}
+
+	var opts LaunchOptions
+	if err := yaml.Unmarshal(data, &opts); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", LaunchOptionsPath(projectPath), err)
+	}
+	return &opts, nil
+}
+
+// SaveLaunchOptions writes launch options to the project's launch.yaml.
+// Empty options remove the file.
+func SaveLaunchOptions(projectPath string, opts *LaunchOptions) error {
+	path := LaunchOptionsPath(projectPath)
+
+	if opts == nil || opts.IsEmpty() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove launch options: %w", err)
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(opts)
+	if err != nil {
+		return fmt.Errorf("failed to encode launch options: %w", err)
+	}
+	if err := fsutil.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write launch options: %w", err)
+	}
+	return nil
+}
+
+// IsEmpty returns true if no option is set
+func (o *LaunchOptions) IsEmpty() bool {
+	return o.BuildTarget == "" && len(o.ExtraArgs) == 0
+}
+
+// EditorArgs returns the Unity command line arguments for these options.
+// Passthrough arguments are appended last so they can override the others.
+func (o *LaunchOptions) EditorArgs() []string {
+	if o == nil {
+		return nil
+	}
+
+	var args []string
+	if o.BuildTarget != "" {
+		args = append(args, argBuildTarget, o.BuildTarget)
+	}
+	return append(args, o.ExtraArgs...)
+}
+
+// enterPlayModeDisableDomainReload is the domain reload bit of
+// m_EnterPlayModeOptions (EnterPlayModeOptions.DisableDomainReload)
+const enterPlayModeDisableDomainReload = 1
+
+// SetDomainReload turns domain reload on entering Play Mode on or off in the
+// project's ProjectSettings/EditorSettings.asset, as the Enter Play Mode
+// Settings of the Editor settings do. Unity has no command line argument for
+// it, and the setting is shared through version control like the others.
+func SetDomainReload(projectPath string, enabled bool) error {
+	path := filepath.Join(projectPath, "ProjectSettings", "EditorSettings.asset")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read EditorSettings.asset: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	enabledLine, optionsLine := -1, -1
+	options := 0
+	for i, line := range lines {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "m_EnterPlayModeOptionsEnabled":
+			enabledLine = i
+		case "m_EnterPlayModeOptions":
+			optionsLine = i
+			options, _ = strconv.Atoi(strings.TrimSpace(value))
+		}
+	}
+	if enabledLine < 0 || optionsLine < 0 {
+		return errs.WithHint(
+			errs.New(errs.Usage, "%s has no Enter Play Mode settings", path),
+			"Domain reload can be turned off from Unity 2019.3; open the project once to upgrade its settings.")
+	}
+
+	optionsEnabled := 1
+	if enabled {
+		options &^= enterPlayModeDisableDomainReload
+		if options == 0 {
+			optionsEnabled = 0
+		}
+	} else {
+		options |= enterPlayModeDisableDomainReload
+	}
+	lines[enabledLine] = setAssetValue(lines[enabledLine], strconv.Itoa(optionsEnabled))
+	lines[optionsLine] = setAssetValue(lines[optionsLine], strconv.Itoa(options))
+
+	if err := fsutil.WriteFileAtomic(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write EditorSettings.asset: %w", err)
+	}
+	return nil
+}
+
+// setAssetValue replaces the value of a "  key: value" line, keeping its
+// indentation and line ending
+func setAssetValue(line, value string) string {
+	key, _, _ := strings.Cut(line, ":")
+	if strings.HasSuffix(line, "\r") {
+		value += "\r"
+	}
+	return key + ": " + value
+}
@@ -0,0 +1,115 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestLaunchOptions_EditorArgs(t *testing.T) {
+	opts := &LaunchOptions{
+		BuildTarget: "Android",
+		ExtraArgs:   []string{"-force-vulkan"},
+	}
+
+	want := []string{"-buildTarget", "Android", "-force-vulkan"}
+	if got := opts.EditorArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("EditorArgs() = %v, want %v", got, want)
+	}
+
+	var nilOpts *LaunchOptions
+	if got := nilOpts.EditorArgs(); len(got) != 0 {
+		t.Errorf("EditorArgs() on nil = %v, want empty", got)
+	}
+}
+
+func TestLaunchOptions_SaveAndLoad(t *testing.T) {
+	projectPath := t.TempDir()
+
+	// Missing launch.yaml yields empty options
+	opts, err := LoadLaunchOptions(projectPath)
+	if err != nil {
+		t.Fatalf("LoadLaunchOptions() error = %v", err)
+	}
+	if !opts.IsEmpty() {
+		t.Errorf("LoadLaunchOptions() = %+v, want empty", opts)
+	}
+
+	saved := &LaunchOptions{BuildTarget: "iOS", ExtraArgs: []string{"-logFile", "-"}}
+	if err := SaveLaunchOptions(projectPath, saved); err != nil {
+		t.Fatalf("SaveLaunchOptions() error = %v", err)
+	}
+
+	loaded, err := LoadLaunchOptions(projectPath)
+	if err != nil {
+		t.Fatalf("LoadLaunchOptions() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, saved) {
+		t.Errorf("LoadLaunchOptions() = %+v, want %+v", loaded, saved)
+	}
+
+	// Saving empty options removes the file
+	if err := SaveLaunchOptions(projectPath, &LaunchOptions{}); err != nil {
+		t.Fatalf("SaveLaunchOptions() error = %v", err)
+	}
+	if _, err := os.Stat(LaunchOptionsPath(projectPath)); !os.IsNotExist(err) {
+		t.Errorf("launch.yaml should be removed, stat error = %v", err)
+	}
+}
+
+func TestSetDomainReload(t *testing.T) {
+	projectPath := t.TempDir()
+	settingsDir := filepath.Join(projectPath, "ProjectSettings")
+	if err := os.MkdirAll(settingsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(settingsDir, "EditorSettings.asset")
+	asset := `%YAML 1.1
+%TAG !u! tag:unity3d.com,2011:
+--- !u!159 &1
+EditorSettings:
+  m_ObjectHideFlags: 0
+  m_SerializationMode: 2
+  m_EnterPlayModeOptionsEnabled: 0
+  m_EnterPlayModeOptions: 2
+`
+	if err := os.WriteFile(path, []byte(asset), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func() string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	if err := SetDomainReload(projectPath, false); err != nil {
+		t.Fatalf("SetDomainReload(false) error = %v", err)
+	}
+	want := strings.Replace(strings.Replace(asset, "OptionsEnabled: 0", "OptionsEnabled: 1", 1), "Options: 2", "Options: 3", 1)
+	if got := read(); got != want {
+		t.Errorf("after disabling:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Scene reload stays disabled, so the options stay enabled
+	if err := SetDomainReload(projectPath, true); err != nil {
+		t.Fatalf("SetDomainReload(true) error = %v", err)
+	}
+	if got := read(); !strings.Contains(got, "m_EnterPlayModeOptionsEnabled: 1\n  m_EnterPlayModeOptions: 2\n") {
+		t.Errorf("after enabling:\n%s", got)
+	}
+
+	if err := os.WriteFile(path, []byte("EditorSettings:\n  m_SerializationMode: 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDomainReload(projectPath, false); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("SetDomainReload() on old settings error = %v, want a usage error", err)
+	}
+}
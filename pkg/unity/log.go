@@ -1,9 +1,11 @@
 package unity
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 )
 
@@ -35,3 +37,60 @@ func GetEditorLogPath() (string, error) {
 		return "", fmt.Errorf("unsupported OS: %s", runtime.GOOS)
 	}
 }
+
+// upmLogName is the Package Manager's log, written next to Editor.log
+const upmLogName = "upm.log"
+
+// assetImportWorkerLogPattern matches the logs of the asset import workers in
+// a project's Logs folder, but not the -prev copies of a previous session
+var assetImportWorkerLogPattern = regexp.MustCompile(`^AssetImportWorker\d+\.log$`)
+
+// GetEditorPrevLogPath returns the path to the log of the previous editor
+// session, which Unity keeps next to Editor.log
+func GetEditorPrevLogPath() (string, error) {
+	editorLog, err := GetEditorLogPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(editorLog), "Editor-prev.log"), nil
+}
+
+// GetUpmLogPath returns the path to the Package Manager log, next to Editor.log
+func GetUpmLogPath() (string, error) {
+	editorLog, err := GetEditorLogPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(editorLog), upmLogName), nil
+}
+
+// ProjectLogsDir returns the folder Unity writes a project's own logs to
+func ProjectLogsDir(projectPath string) string {
+	return filepath.Join(projectPath, "Logs")
+}
+
+// IsAssetImportWorkerLog reports whether name is the log file of an asset
+// import worker, e.g. AssetImportWorker0.log
+func IsAssetImportWorkerLog(name string) bool {
+	return assetImportWorkerLogPattern.MatchString(name)
+}
+
+// AssetImportWorkerLogs returns the asset import worker logs in the project's
+// Logs folder, sorted
+func AssetImportWorkerLogs(projectPath string) ([]string, error) {
+	entries, err := os.ReadDir(ProjectLogsDir(projectPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read project logs: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && IsAssetImportWorkerLog(entry.Name()) {
+			paths = append(paths, filepath.Join(ProjectLogsDir(projectPath), entry.Name()))
+		}
+	}
+	return paths, nil
+}
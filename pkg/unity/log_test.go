@@ -0,0 +1,34 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestAssetImportWorkerLogs(t *testing.T) {
+	project := t.TempDir()
+	if logs, err := AssetImportWorkerLogs(project); err != nil || len(logs) != 0 {
+		t.Errorf("AssetImportWorkerLogs() without Logs = %v, %v; want none", logs, err)
+	}
+
+	logsDir := ProjectLogsDir(project)
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"AssetImportWorker1.log", "AssetImportWorker0.log", "AssetImportWorker0-prev.log", "shadercompiler-UnityShaderCompiler0.log"} {
+		if err := os.WriteFile(filepath.Join(logsDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logs, err := AssetImportWorkerLogs(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(logsDir, "AssetImportWorker0.log"), filepath.Join(logsDir, "AssetImportWorker1.log")}
+	if !slices.Equal(logs, want) {
+		t.Errorf("AssetImportWorkerLogs() = %v, want %v", logs, want)
+	}
+}
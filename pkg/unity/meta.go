@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+
+	"github.com/neptaco/uniforge/pkg/trace"
 )
 
 // MetaCheckResult holds the result of meta file checking
@@ -13,21 +16,76 @@ type MetaCheckResult struct {
 	MissingMeta    []string            // Assets without .meta files
 	OrphanMeta     []string            // .meta files without corresponding assets
 	DuplicateGUIDs map[string][]string // GUID -> list of files with that GUID
+	Portability    []PortabilityIssue  // Asset paths that break on other platforms
+	EmptyFolders   []string            // Folders without files, which git does not keep
+	InvalidMeta    []InvalidMeta       // .meta files whose contents do not fit their asset
+}
+
+// MetaFileFormatVersion is the fileFormatVersion Unity writes to .meta files
+const MetaFileFormatVersion = "2"
+
+// Kinds of invalid .meta files
+const (
+	InvalidMetaFolderAsset   = "folder-asset"        // folderAsset does not match whether the asset is a folder
+	InvalidMetaFormatVersion = "file-format-version" // fileFormatVersion is missing or not MetaFileFormatVersion
+)
+
+// InvalidMeta is a .meta file whose contents do not fit its asset
+type InvalidMeta struct {
+	Path   string // .meta file, relative to the project
+	Kind   string
+	Detail string
+}
+
+// IsError reports whether Unity misreads the asset; an unexpected format version only may
+func (m InvalidMeta) IsError() bool {
+	return m.Kind == InvalidMetaFolderAsset
 }
 
-// HasErrors returns true if there are any errors (missing meta or duplicate GUIDs)
+// HasErrors returns true if there are any errors (missing meta, duplicate GUIDs,
+// case collisions, names invalid on Windows or wrong folderAsset flags)
 func (r *MetaCheckResult) HasErrors() bool {
-	return len(r.MissingMeta) > 0 || len(r.DuplicateGUIDs) > 0
+	if len(r.MissingMeta) > 0 || len(r.DuplicateGUIDs) > 0 {
+		return true
+	}
+	for _, issue := range r.Portability {
+		if issue.IsError() {
+			return true
+		}
+	}
+	for _, meta := range r.InvalidMeta {
+		if meta.IsError() {
+			return true
+		}
+	}
+	return false
 }
 
-// HasWarnings returns true if there are any warnings (orphan meta)
+// HasWarnings returns true if there are any warnings (orphan meta, long paths,
+// empty folders or unexpected format versions)
 func (r *MetaCheckResult) HasWarnings() bool {
-	return len(r.OrphanMeta) > 0
+	if len(r.OrphanMeta) > 0 || len(r.EmptyFolders) > 0 {
+		return true
+	}
+	for _, issue := range r.Portability {
+		if !issue.IsError() {
+			return true
+		}
+	}
+	for _, meta := range r.InvalidMeta {
+		if !meta.IsError() {
+			return true
+		}
+	}
+	return false
 }
 
 // MetaChecker checks Unity project meta file integrity
 type MetaChecker struct {
 	project *Project
+
+	// OnScan is called for every file examined by Check (may be nil)
+	OnScan func(relPath string)
 }
 
 // NewMetaChecker creates a new MetaChecker
@@ -56,6 +114,7 @@ var excludedFiles = map[string]bool{
 	".gitattributes":     true,
 	".DS_Store":          true,
 	"Thumbs.db":          true,
+	".gitkeep":           true, // Keeps empty folders in git; Unity ignores hidden files
 	"manifest.json":      true, // Packages/manifest.json
 	"packages-lock.json": true, // Packages/packages-lock.json
 }
@@ -68,16 +127,24 @@ var metaRequiredRoots = map[string]bool{
 
 // Check performs meta file integrity check
 func (c *MetaChecker) Check() (*MetaCheckResult, error) {
+	span := trace.Start(trace.KindScan, "meta check")
+	defer span.End()
+
 	result := &MetaCheckResult{
 		MissingMeta:    []string{},
 		OrphanMeta:     []string{},
 		DuplicateGUIDs: make(map[string][]string),
+		Portability:    []PortabilityIssue{},
+		EmptyFolders:   []string{},
+		InvalidMeta:    []InvalidMeta{},
 	}
 
 	// Track all assets and meta files
-	assets := make(map[string]bool)  // asset path -> exists
-	metas := make(map[string]bool)   // meta path -> exists
-	guids := make(map[string]string) // GUID -> first file path
+	assets := make(map[string]bool)        // asset path -> exists
+	metas := make(map[string]bool)         // meta path -> exists
+	guids := make(map[string]string)       // GUID -> first file path
+	folders := make(map[string]bool)       // folder path -> contains a file (at any depth)
+	headers := make(map[string]metaHeader) // meta path -> header
 
 	// Walk the project directory
 	err := filepath.Walk(c.project.Path, func(path string, info os.FileInfo, err error) error {
@@ -105,17 +172,29 @@ func (c *MetaChecker) Check() (*MetaCheckResult, error) {
 			// Only track directories inside Assets/ or Packages/
 			if isInsideMetaRequiredRoot(relPath) {
 				assets[relPath] = true
+				folders[relPath] = false
 			}
 			return nil
 		}
 
+		if c.OnScan != nil {
+			c.OnScan(relPath)
+		}
+
 		// Skip files not inside Assets/ or Packages/
 		if !isInsideMetaRequiredRoot(relPath) {
 			return nil
 		}
 
-		// Check if file should be excluded
+		// Git keeps a folder if it contains any file but OS clutter, which is usually ignored
 		baseName := filepath.Base(path)
+		if baseName != ".DS_Store" && baseName != "Thumbs.db" {
+			for dir := filepath.Dir(relPath); isInsideMetaRequiredRoot(dir); dir = filepath.Dir(dir) {
+				folders[dir] = true
+			}
+		}
+
+		// Check if file should be excluded
 		if excludedFiles[baseName] {
 			return nil
 		}
@@ -125,8 +204,11 @@ func (c *MetaChecker) Check() (*MetaCheckResult, error) {
 			metas[relPath] = true
 
 			// Extract GUID from meta file
-			guid, err := extractGUID(path)
-			if err == nil && guid != "" {
+			header, err := readMetaHeader(path)
+			if err == nil {
+				headers[relPath] = header
+			}
+			if guid := header.GUID; err == nil && guid != "" {
 				if existingPath, exists := guids[guid]; exists {
 					// Duplicate GUID found
 					if _, ok := result.DuplicateGUIDs[guid]; !ok {
@@ -149,13 +231,58 @@ func (c *MetaChecker) Check() (*MetaCheckResult, error) {
 	}
 
 	// Check for missing meta files
+	assetPaths := make([]string, 0, len(assets))
 	for asset := range assets {
+		assetPaths = append(assetPaths, asset)
 		metaPath := asset + ".meta"
 		if !metas[metaPath] {
 			result.MissingMeta = append(result.MissingMeta, asset)
 		}
 	}
 
+	// Check that the assets can be checked out on every platform
+	result.Portability = append(result.Portability, checkPortability(assetPaths)...)
+
+	// Check for folders git will not keep; only the outermost of nested empty folders is listed
+	for folder, hasFiles := range folders {
+		if hasFiles {
+			continue
+		}
+		if parentHasFiles, tracked := folders[filepath.Dir(folder)]; tracked && !parentHasFiles {
+			continue // Listed with its parent
+		}
+		result.EmptyFolders = append(result.EmptyFolders, folder)
+	}
+	sort.Strings(result.EmptyFolders)
+
+	// Check that each .meta file describes its asset
+	for meta, header := range headers {
+		asset := strings.TrimSuffix(meta, ".meta")
+		if !assets[asset] {
+			continue // Orphan
+		}
+		if _, isFolder := folders[asset]; isFolder != header.FolderAsset {
+			detail := "asset is a folder but folderAsset is not yes"
+			if !isFolder {
+				detail = "asset is a file but folderAsset is yes"
+			}
+			result.InvalidMeta = append(result.InvalidMeta, InvalidMeta{Path: meta, Kind: InvalidMetaFolderAsset, Detail: detail})
+		}
+		if header.FileFormatVersion != MetaFileFormatVersion {
+			detail := "fileFormatVersion is missing"
+			if header.FileFormatVersion != "" {
+				detail = fmt.Sprintf("fileFormatVersion is %s, expected %s", header.FileFormatVersion, MetaFileFormatVersion)
+			}
+			result.InvalidMeta = append(result.InvalidMeta, InvalidMeta{Path: meta, Kind: InvalidMetaFormatVersion, Detail: detail})
+		}
+	}
+	sort.Slice(result.InvalidMeta, func(a, b int) bool {
+		if result.InvalidMeta[a].Path != result.InvalidMeta[b].Path {
+			return result.InvalidMeta[a].Path < result.InvalidMeta[b].Path
+		}
+		return result.InvalidMeta[a].Kind < result.InvalidMeta[b].Kind
+	})
+
 	// Check for orphan meta files
 	for meta := range metas {
 		// Get asset path by removing .meta suffix
@@ -186,41 +313,63 @@ func (c *MetaChecker) Fix(dryRun bool) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if dryRun {
+		return append([]string{}, result.OrphanMeta...), nil
+	}
+	return c.RemoveMeta(result.OrphanMeta)
+}
 
+// RemoveMeta deletes the given .meta files (relative to the project), e.g. the
+// orphans left in a result after ApplyBaseline. Returns list of deleted files.
+func (c *MetaChecker) RemoveMeta(paths []string) ([]string, error) {
 	deleted := []string{}
-	for _, orphan := range result.OrphanMeta {
-		fullPath := filepath.Join(c.project.Path, orphan)
-		if dryRun {
-			deleted = append(deleted, orphan)
-		} else {
-			if err := os.Remove(fullPath); err != nil {
-				return deleted, fmt.Errorf("failed to remove %s: %w", orphan, err)
-			}
-			deleted = append(deleted, orphan)
+	for _, path := range paths {
+		if err := os.Remove(filepath.Join(c.project.Path, path)); err != nil {
+			return deleted, fmt.Errorf("failed to remove %s: %w", path, err)
 		}
+		deleted = append(deleted, path)
 	}
-
 	return deleted, nil
 }
 
+// metaHeader is what the checks need from a .meta file
+type metaHeader struct {
+	GUID              string
+	FileFormatVersion string
+	FolderAsset       bool
+}
+
 // extractGUID reads a .meta file and extracts the GUID
 func extractGUID(metaPath string) (string, error) {
+	header, err := readMetaHeader(metaPath)
+	return header.GUID, err
+}
+
+// readMetaHeader reads the top-level guid, fileFormatVersion and folderAsset of a .meta file
+func readMetaHeader(metaPath string) (metaHeader, error) {
+	var header metaHeader
 	file, err := os.Open(metaPath)
 	if err != nil {
-		return "", err
+		return header, err
 	}
 	defer func() { _ = file.Close() }()
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "guid:") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				return strings.TrimSpace(parts[1]), nil
-			}
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "guid":
+			header.GUID = value
+		case "fileFormatVersion":
+			header.FileFormatVersion = value
+		case "folderAsset":
+			header.FolderAsset = value == "yes"
 		}
 	}
 
-	return "", scanner.Err()
+	return header, scanner.Err()
 }
@@ -0,0 +1,117 @@
+package unity
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// PortablePathLimit is the longest project-relative asset path that is
+// reported as portable. Windows limits absolute paths to 260 characters
+// (MAX_PATH); this leaves room for the folder the project is cloned into.
+const PortablePathLimit = 200
+
+// Kinds of portability issues
+const (
+	PortabilityCaseCollision = "case-collision" // Differs from a sibling only in case
+	PortabilityInvalidName   = "invalid-name"   // Not a valid file name on Windows
+	PortabilityPathTooLong   = "path-too-long"  // Longer than PortablePathLimit
+)
+
+// PortabilityIssue is an asset path that breaks the project on another platform
+type PortabilityIssue struct {
+	Path   string // Relative to the project
+	Kind   string
+	Detail string // e.g. "collides with Assets/foo.cs"
+}
+
+// IsError reports whether the issue breaks imports; long paths only may
+func (i PortabilityIssue) IsError() bool {
+	return i.Kind != PortabilityPathTooLong
+}
+
+// windowsInvalidChars cannot appear in Windows file names
+const windowsInvalidChars = `<>:"\|?*`
+
+// windowsReservedNames are device names that Windows does not allow as file
+// names, with or without an extension
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// checkPortability finds asset paths (files and folders, relative to the
+// project) that collide on case-insensitive file systems or are invalid on
+// Windows. Collisions are only looked for among siblings, so that a colliding
+// folder is reported once rather than for each of its files.
+func checkPortability(paths []string) []PortabilityIssue {
+	var issues []PortabilityIssue
+
+	siblings := make(map[string][]string) // parent + lower-case name -> paths
+	for _, path := range paths {
+		key := filepath.Dir(path) + string(filepath.Separator) + strings.ToLower(filepath.Base(path))
+		siblings[key] = append(siblings[key], path)
+
+		if reason := invalidWindowsName(filepath.Base(path)); reason != "" {
+			issues = append(issues, PortabilityIssue{Path: path, Kind: PortabilityInvalidName, Detail: reason})
+		}
+		if n := utf8.RuneCountInString(path); n > PortablePathLimit {
+			issues = append(issues, PortabilityIssue{
+				Path:   path,
+				Kind:   PortabilityPathTooLong,
+				Detail: fmt.Sprintf("%d characters, over %d", n, PortablePathLimit),
+			})
+		}
+	}
+
+	for _, group := range siblings {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Strings(group)
+		for _, path := range group {
+			var others []string
+			for _, other := range group {
+				if other != path {
+					others = append(others, filepath.ToSlash(other))
+				}
+			}
+			issues = append(issues, PortabilityIssue{
+				Path:   path,
+				Kind:   PortabilityCaseCollision,
+				Detail: "collides with " + strings.Join(others, ", "),
+			})
+		}
+	}
+
+	sort.Slice(issues, func(a, b int) bool {
+		if issues[a].Path != issues[b].Path {
+			return issues[a].Path < issues[b].Path
+		}
+		return issues[a].Kind < issues[b].Kind
+	})
+	return issues
+}
+
+// invalidWindowsName returns why name cannot be used on Windows, or ""
+func invalidWindowsName(name string) string {
+	for _, r := range name {
+		if r < 0x20 {
+			return "contains a control character"
+		}
+		if strings.ContainsRune(windowsInvalidChars, r) {
+			return fmt.Sprintf("contains %q, which Windows does not allow", r)
+		}
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return "ends with a dot or space, which Windows strips"
+	}
+	stem, _, _ := strings.Cut(name, ".")
+	if windowsReservedNames[strings.ToUpper(strings.TrimRight(stem, " "))] {
+		return fmt.Sprintf("%s is a reserved device name on Windows", stem)
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestInvalidWindowsName(t *testing.T) {
+	tests := []struct {
+		name    string
+		invalid bool
+	}{
+		{"Player.cs", false},
+		{"Consoles.prefab", false},
+		{"My Scene.unity", false},
+		{"What?.png", true},
+		{"a:b.txt", true},
+		{"back\\slash.cs", true},
+		{"CON", true},
+		{"aux.cs", true},
+		{"Com1.asset", true},
+		{"COM10.asset", false},
+		{"trailing.", true},
+		{"trailing ", true},
+		{"tab\tname", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := invalidWindowsName(tt.name) != ""; got != tt.invalid {
+				t.Errorf("invalidWindowsName(%q) = %q, want invalid %v", tt.name, invalidWindowsName(tt.name), tt.invalid)
+			}
+		})
+	}
+}
+
+func TestCheckPortability(t *testing.T) {
+	long := filepath.Join("Assets", strings.Repeat("x", PortablePathLimit))
+	issues := checkPortability([]string{
+		filepath.Join("Assets", "Foo.cs"),
+		filepath.Join("Assets", "foo.cs"),
+		filepath.Join("Assets", "Bar"),
+		filepath.Join("Assets", "bar"),
+		filepath.Join("Assets", "Bar", "a.cs"),
+		filepath.Join("Assets", "bar", "a.cs"),
+		filepath.Join("Assets", "nul.txt"),
+		long,
+	})
+
+	kinds := make(map[string]string)
+	for _, issue := range issues {
+		kinds[filepath.ToSlash(issue.Path)] += issue.Kind
+	}
+	want := map[string]string{
+		"Assets/Bar":           PortabilityCaseCollision,
+		"Assets/bar":           PortabilityCaseCollision,
+		"Assets/Foo.cs":        PortabilityCaseCollision,
+		"Assets/foo.cs":        PortabilityCaseCollision,
+		"Assets/nul.txt":       PortabilityInvalidName,
+		filepath.ToSlash(long): PortabilityPathTooLong,
+	}
+	if len(kinds) != len(want) {
+		t.Errorf("issues = %+v", issues)
+	}
+	for path, kind := range want {
+		if kinds[path] != kind {
+			t.Errorf("%s: kind %q, want %q", path, kinds[path], kind)
+		}
+	}
+	for _, issue := range issues {
+		if filepath.ToSlash(issue.Path) == "Assets/Foo.cs" && issue.Detail != "collides with Assets/foo.cs" {
+			t.Errorf("Detail = %q", issue.Detail)
+		}
+	}
+}
+
+func TestMetaChecker_Check_Portability(t *testing.T) {
+	project, tempDir := setupTestProject(t)
+	assetsDir := filepath.Join(tempDir, "Assets")
+
+	createAssetWithMeta(t, assetsDir, "Foo.cs", "guid1")
+	if _, err := os.Stat(filepath.Join(assetsDir, "FOO.CS")); err == nil {
+		t.Skip("file system is case-insensitive")
+	}
+	createAssetWithMeta(t, assetsDir, "foo.cs", "guid2")
+
+	checker := NewMetaChecker(project)
+	result, err := checker.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(result.Portability) != 2 || result.Portability[0].Kind != PortabilityCaseCollision {
+		t.Fatalf("Portability = %+v, want a case collision", result.Portability)
+	}
+	if !result.HasErrors() {
+		t.Error("Expected HasErrors() to return true")
+	}
+
+	if runtime.GOOS != "windows" {
+		createAssetWithMeta(t, assetsDir, "Icon?.png", "guid3")
+		result, err = checker.Check()
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		found := false
+		for _, issue := range result.Portability {
+			found = found || (issue.Kind == PortabilityInvalidName && filepath.Base(issue.Path) == "Icon?.png")
+		}
+		if !found {
+			t.Errorf("Portability = %+v, want Icon?.png as an invalid name", result.Portability)
+		}
+	}
+}
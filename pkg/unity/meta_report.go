@@ -0,0 +1,344 @@
+package unity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// MetaBaselineFile lists the meta issues accepted in a project, inside ProjectConfigDir
+const MetaBaselineFile = "meta-baseline.json"
+
+// Meta check rules
+const (
+	MetaRuleMissing       = "missing-meta"
+	MetaRuleOrphan        = "orphan-meta"
+	MetaRuleDuplicateGUID = "duplicate-guid"
+	MetaRuleEmptyFolder   = "empty-folder"
+)
+
+// Severities of meta issues
+const (
+	MetaSeverityError   = "error"
+	MetaSeverityWarning = "warning"
+)
+
+// MetaIssue is one problem found by the meta check
+type MetaIssue struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`             // Relative to the project, with forward slashes
+	GUID     string `json:"guid,omitempty"`   // Set for duplicate-guid
+	Detail   string `json:"detail,omitempty"` // Set for portability issues and invalid .meta files
+}
+
+func (i MetaIssue) key() string {
+	return i.Rule + "\x00" + i.Path + "\x00" + i.GUID
+}
+
+// Message describes the issue in a sentence
+func (i MetaIssue) Message() string {
+	switch i.Rule {
+	case MetaRuleMissing:
+		return fmt.Sprintf("%s has no .meta file", i.Path)
+	case MetaRuleOrphan:
+		return fmt.Sprintf("%s has no matching asset", i.Path)
+	case MetaRuleDuplicateGUID:
+		return fmt.Sprintf("%s shares GUID %s with another .meta file", i.Path, i.GUID)
+	case MetaRuleEmptyFolder:
+		return fmt.Sprintf("%s is empty and will not be kept by git", i.Path)
+	case PortabilityCaseCollision, PortabilityInvalidName, PortabilityPathTooLong:
+		return fmt.Sprintf("%s %s", i.Path, i.Detail)
+	case InvalidMetaFolderAsset, InvalidMetaFormatVersion:
+		return fmt.Sprintf("%s: %s", i.Path, i.Detail)
+	}
+	return i.Path
+}
+
+// Issues lists every problem of the result, sorted by rule and path
+func (r *MetaCheckResult) Issues() []MetaIssue {
+	var issues []MetaIssue
+	for _, path := range r.MissingMeta {
+		issues = append(issues, MetaIssue{Rule: MetaRuleMissing, Severity: MetaSeverityError, Path: filepath.ToSlash(path)})
+	}
+	for guid, files := range r.DuplicateGUIDs {
+		for _, path := range files {
+			issues = append(issues, MetaIssue{Rule: MetaRuleDuplicateGUID, Severity: MetaSeverityError, Path: filepath.ToSlash(path), GUID: guid})
+		}
+	}
+	for _, path := range r.OrphanMeta {
+		issues = append(issues, MetaIssue{Rule: MetaRuleOrphan, Severity: MetaSeverityWarning, Path: filepath.ToSlash(path)})
+	}
+	for _, p := range r.Portability {
+		severity := MetaSeverityError
+		if !p.IsError() {
+			severity = MetaSeverityWarning
+		}
+		issues = append(issues, MetaIssue{Rule: p.Kind, Severity: severity, Path: filepath.ToSlash(p.Path), Detail: p.Detail})
+	}
+	for _, path := range r.EmptyFolders {
+		issues = append(issues, MetaIssue{Rule: MetaRuleEmptyFolder, Severity: MetaSeverityWarning, Path: filepath.ToSlash(path)})
+	}
+	for _, m := range r.InvalidMeta {
+		severity := MetaSeverityError
+		if !m.IsError() {
+			severity = MetaSeverityWarning
+		}
+		issues = append(issues, MetaIssue{Rule: m.Kind, Severity: severity, Path: filepath.ToSlash(m.Path), Detail: m.Detail})
+	}
+	sort.Slice(issues, func(a, b int) bool {
+		if issues[a].Rule != issues[b].Rule {
+			return issues[a].Rule < issues[b].Rule
+		}
+		if issues[a].Path != issues[b].Path {
+			return issues[a].Path < issues[b].Path
+		}
+		return issues[a].GUID < issues[b].GUID
+	})
+	return issues
+}
+
+// MetaBaseline is the contents of .uniforge/meta-baseline.json: issues that
+// existed when the project adopted the meta check and no longer fail it
+type MetaBaseline struct {
+	Issues []MetaIssue `json:"issues"`
+}
+
+// MetaBaselinePath returns the path of the project's meta baseline
+func MetaBaselinePath(projectPath string) string {
+	return filepath.Join(projectPath, ProjectConfigDir, MetaBaselineFile)
+}
+
+// LoadMetaBaseline reads a meta baseline. A missing file yields nil.
+func LoadMetaBaseline(path string) (*MetaBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read meta baseline: %w", err)
+	}
+	var baseline MetaBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &baseline, nil
+}
+
+// SaveMetaBaseline writes the issues of result as the baseline at path
+func SaveMetaBaseline(path string, result *MetaCheckResult) (*MetaBaseline, error) {
+	baseline := &MetaBaseline{Issues: result.Issues()}
+	if baseline.Issues == nil {
+		baseline.Issues = []MetaIssue{}
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := fsutil.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write meta baseline: %w", err)
+	}
+	return baseline, nil
+}
+
+// ApplyBaseline removes the issues listed in baseline from the result. A
+// duplicate GUID is only removed when all of its files are listed, so that a
+// new copy of a grandfathered GUID still fails. It returns how many issues were
+// removed and how many baseline entries no longer occur (they were fixed).
+func (r *MetaCheckResult) ApplyBaseline(baseline *MetaBaseline) (suppressed, fixed int) {
+	if baseline == nil {
+		return 0, 0
+	}
+	accepted := make(map[string]bool, len(baseline.Issues))
+	for _, issue := range baseline.Issues {
+		accepted[issue.key()] = true
+	}
+	seen := make(map[string]bool)
+
+	keep := func(paths []string, rule string) []string {
+		kept := []string{}
+		for _, path := range paths {
+			key := MetaIssue{Rule: rule, Path: filepath.ToSlash(path)}.key()
+			if accepted[key] {
+				seen[key] = true
+				suppressed++
+				continue
+			}
+			kept = append(kept, path)
+		}
+		return kept
+	}
+	r.MissingMeta = keep(r.MissingMeta, MetaRuleMissing)
+	r.OrphanMeta = keep(r.OrphanMeta, MetaRuleOrphan)
+	r.EmptyFolders = keep(r.EmptyFolders, MetaRuleEmptyFolder)
+
+	portability := []PortabilityIssue{}
+	for _, p := range r.Portability {
+		key := MetaIssue{Rule: p.Kind, Path: filepath.ToSlash(p.Path)}.key()
+		if accepted[key] {
+			seen[key] = true
+			suppressed++
+			continue
+		}
+		portability = append(portability, p)
+	}
+	r.Portability = portability
+
+	invalid := []InvalidMeta{}
+	for _, m := range r.InvalidMeta {
+		key := MetaIssue{Rule: m.Kind, Path: filepath.ToSlash(m.Path)}.key()
+		if accepted[key] {
+			seen[key] = true
+			suppressed++
+			continue
+		}
+		invalid = append(invalid, m)
+	}
+	r.InvalidMeta = invalid
+
+	for guid, files := range r.DuplicateGUIDs {
+		all := true
+		for _, path := range files {
+			key := MetaIssue{Rule: MetaRuleDuplicateGUID, Path: filepath.ToSlash(path), GUID: guid}.key()
+			if accepted[key] {
+				seen[key] = true
+			} else {
+				all = false
+			}
+		}
+		if all {
+			delete(r.DuplicateGUIDs, guid)
+			suppressed += len(files)
+		}
+	}
+
+	for key := range accepted {
+		if !seen[key] {
+			fixed++
+		}
+	}
+	return suppressed, fixed
+}
+
+// SARIF 2.1.0 log, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool               sarifTool                   `json:"tool"`
+		OriginalURIBaseIDs map[string]sarifArtifactLoc `json:"originalUriBaseIds,omitempty"`
+		Results            []sarifResult               `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version,omitempty"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string             `json:"id"`
+		ShortDescription     sarifMessage       `json:"shortDescription"`
+		DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	}
+	sarifConfiguration struct {
+		Level string `json:"level"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLoc `json:"artifactLocation"`
+	}
+	sarifArtifactLoc struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId,omitempty"`
+	}
+)
+
+// metaRules describes the rules for SARIF consumers
+var metaRules = []sarifRule{
+	{ID: MetaRuleMissing, ShortDescription: sarifMessage{Text: "Asset without a .meta file"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityError}},
+	{ID: MetaRuleDuplicateGUID, ShortDescription: sarifMessage{Text: "Several .meta files with the same GUID"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityError}},
+	{ID: MetaRuleOrphan, ShortDescription: sarifMessage{Text: ".meta file without an asset"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityWarning}},
+	{ID: PortabilityCaseCollision, ShortDescription: sarifMessage{Text: "Asset names that differ only in case"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityError}},
+	{ID: PortabilityInvalidName, ShortDescription: sarifMessage{Text: "Asset name that is invalid on Windows"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityError}},
+	{ID: PortabilityPathTooLong, ShortDescription: sarifMessage{Text: "Asset path too long for Windows"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityWarning}},
+	{ID: MetaRuleEmptyFolder, ShortDescription: sarifMessage{Text: "Empty folder that git does not keep"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityWarning}},
+	{ID: InvalidMetaFolderAsset, ShortDescription: sarifMessage{Text: "folderAsset does not match the asset"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityError}},
+	{ID: InvalidMetaFormatVersion, ShortDescription: sarifMessage{Text: "Unexpected .meta fileFormatVersion"}, DefaultConfiguration: sarifConfiguration{Level: MetaSeverityWarning}},
+}
+
+// MetaReport is the meta check of one project
+type MetaReport struct {
+	ProjectPath string
+	Issues      []MetaIssue
+}
+
+// MetaSARIF renders reports as a SARIF log for code scanning tools, one run
+// per project. Locations are relative to the project directory (uriBaseId
+// PROJECTROOT).
+func MetaSARIF(toolVersion string, reports ...MetaReport) ([]byte, error) {
+	runs := make([]sarifRun, 0, len(reports))
+	for _, report := range reports {
+		run := sarifRun{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "uniforge",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/neptaco/uniforge",
+				Rules:          metaRules,
+			}},
+			Results: []sarifResult{},
+		}
+		if abs, err := filepath.Abs(report.ProjectPath); err == nil {
+			root := filepath.ToSlash(abs)
+			if !strings.HasPrefix(root, "/") {
+				root = "/" + root // Windows drive, e.g. file:///C:/Projects/Game/
+			}
+			rootURL := url.URL{Scheme: "file", Path: strings.TrimSuffix(root, "/") + "/"}
+			run.OriginalURIBaseIDs = map[string]sarifArtifactLoc{"PROJECTROOT": {URI: rootURL.String()}}
+		}
+		for _, issue := range report.Issues {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  issue.Rule,
+				Level:   issue.Severity,
+				Message: sarifMessage{Text: issue.Message()},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLoc{URI: (&url.URL{Path: issue.Path}).String(), URIBaseID: "PROJECTROOT"},
+				}}},
+			})
+		}
+		runs = append(runs, run)
+	}
+
+	data, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    runs,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,159 @@
+package unity
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetaCheckResult_Issues(t *testing.T) {
+	result := &MetaCheckResult{
+		MissingMeta:    []string{filepath.Join("Assets", "B.cs"), filepath.Join("Assets", "A.cs")},
+		OrphanMeta:     []string{filepath.Join("Assets", "Old.cs.meta")},
+		DuplicateGUIDs: map[string][]string{"abc": {filepath.Join("Assets", "X.mat.meta"), filepath.Join("Assets", "Y.mat.meta")}},
+	}
+
+	issues := result.Issues()
+	want := []MetaIssue{
+		{Rule: MetaRuleDuplicateGUID, Severity: MetaSeverityError, Path: "Assets/X.mat.meta", GUID: "abc"},
+		{Rule: MetaRuleDuplicateGUID, Severity: MetaSeverityError, Path: "Assets/Y.mat.meta", GUID: "abc"},
+		{Rule: MetaRuleMissing, Severity: MetaSeverityError, Path: "Assets/A.cs"},
+		{Rule: MetaRuleMissing, Severity: MetaSeverityError, Path: "Assets/B.cs"},
+		{Rule: MetaRuleOrphan, Severity: MetaSeverityWarning, Path: "Assets/Old.cs.meta"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Issues() = %v, want %v", issues, want)
+	}
+	for i := range want {
+		if issues[i] != want[i] {
+			t.Errorf("Issues()[%d] = %+v, want %+v", i, issues[i], want[i])
+		}
+	}
+}
+
+func TestMetaBaseline(t *testing.T) {
+	project, tempDir := setupTestProject(t)
+	assetsDir := filepath.Join(tempDir, "Assets")
+
+	createAssetWithoutMeta(t, assetsDir, "Legacy.cs")
+	createOrphanMeta(t, assetsDir, "Gone.cs", "orphan1")
+	createAssetWithMeta(t, assetsDir, "A.mat", "dup")
+	createAssetWithMeta(t, assetsDir, "B.mat", "dup")
+
+	checker := NewMetaChecker(project)
+	result, err := checker.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	path := MetaBaselinePath(project.Path)
+	if _, err := SaveMetaBaseline(path, result); err != nil {
+		t.Fatalf("SaveMetaBaseline failed: %v", err)
+	}
+	baseline, err := LoadMetaBaseline(path)
+	if err != nil || baseline == nil {
+		t.Fatalf("LoadMetaBaseline() = %v, %v", baseline, err)
+	}
+	if len(baseline.Issues) != 4 {
+		t.Fatalf("baseline has %d issues, want 4: %+v", len(baseline.Issues), baseline.Issues)
+	}
+
+	// Everything is grandfathered
+	suppressed, fixed := result.ApplyBaseline(baseline)
+	if suppressed != 4 || fixed != 0 || result.HasErrors() || result.HasWarnings() {
+		t.Errorf("ApplyBaseline() = %d, %d; result %+v", suppressed, fixed, result)
+	}
+
+	// A new missing meta and a third copy of the duplicate GUID fail; the fixed orphan is reported
+	createAssetWithoutMeta(t, assetsDir, "New.cs")
+	createAssetWithMeta(t, assetsDir, "C.mat", "dup")
+	if err := os.Remove(filepath.Join(assetsDir, "Gone.cs.meta")); err != nil {
+		t.Fatal(err)
+	}
+	result, err = checker.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	suppressed, fixed = result.ApplyBaseline(baseline)
+	if suppressed != 1 || fixed != 1 {
+		t.Errorf("ApplyBaseline() = %d suppressed, %d fixed; want 1, 1", suppressed, fixed)
+	}
+	if len(result.MissingMeta) != 1 || filepath.Base(result.MissingMeta[0]) != "New.cs" {
+		t.Errorf("MissingMeta = %v, want only New.cs", result.MissingMeta)
+	}
+	if len(result.DuplicateGUIDs["dup"]) != 3 {
+		t.Errorf("DuplicateGUIDs = %v, want the whole group of 3", result.DuplicateGUIDs)
+	}
+}
+
+func TestLoadMetaBaselineMissing(t *testing.T) {
+	baseline, err := LoadMetaBaseline(filepath.Join(t.TempDir(), MetaBaselineFile))
+	if baseline != nil || err != nil {
+		t.Errorf("LoadMetaBaseline() = %v, %v; want nil, nil", baseline, err)
+	}
+}
+
+func TestMetaSARIF(t *testing.T) {
+	issues := []MetaIssue{
+		{Rule: MetaRuleMissing, Severity: MetaSeverityError, Path: "Assets/My Script.cs"},
+		{Rule: MetaRuleOrphan, Severity: MetaSeverityWarning, Path: "Assets/Old.cs.meta"},
+	}
+	data, err := MetaSARIF("1.2.0", MetaReport{ProjectPath: t.TempDir(), Issues: issues})
+	if err != nil {
+		t.Fatalf("MetaSARIF failed: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			OriginalURIBaseIDs map[string]struct {
+				URI string `json:"uri"`
+			} `json:"originalUriBaseIds"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI       string `json:"uri"`
+							URIBaseID string `json:"uriBaseId"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF: %s", data)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "uniforge" || len(run.Tool.Driver.Rules) != 9 {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if root := run.OriginalURIBaseIDs["PROJECTROOT"].URI; !strings.HasPrefix(root, "file:///") || !strings.HasSuffix(root, "/") {
+		t.Errorf("PROJECTROOT = %q", root)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(run.Results))
+	}
+	loc := run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation
+	if run.Results[0].Level != "error" || loc.URI != "Assets/My%20Script.cs" || loc.URIBaseID != "PROJECTROOT" {
+		t.Errorf("result = %+v, location %+v", run.Results[0], loc)
+	}
+	if run.Results[1].Level != "warning" {
+		t.Errorf("orphan level = %q, want warning", run.Results[1].Level)
+	}
+}
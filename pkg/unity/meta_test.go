@@ -333,3 +333,94 @@ func TestExtractGUID(t *testing.T) {
 		})
 	}
 }
+
+func TestMetaChecker_Check_EmptyFolders(t *testing.T) {
+	project, tempDir := setupTestProject(t)
+	assetsDir := filepath.Join(tempDir, "Assets")
+
+	writeFolderMeta := func(rel string) {
+		if err := os.MkdirAll(filepath.Join(assetsDir, rel), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "fileFormatVersion: 2\nguid: " + filepath.Base(rel) + "\nfolderAsset: yes\n"
+		if err := os.WriteFile(filepath.Join(assetsDir, rel+".meta"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFolderMeta("Empty")
+	writeFolderMeta(filepath.Join("Empty", "Nested"))
+	writeFolderMeta("Kept")
+	createAssetWithoutMeta(t, filepath.Join(assetsDir, "Kept"), ".gitkeep")
+	writeFolderMeta("Scripts")
+	createAssetWithMeta(t, filepath.Join(assetsDir, "Scripts"), "Player.cs", "player")
+
+	checker := NewMetaChecker(project)
+	result, err := checker.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// Empty itself is kept by git because it holds Nested.meta
+	want := filepath.Join("Assets", "Empty", "Nested")
+	if len(result.EmptyFolders) != 1 || result.EmptyFolders[0] != want {
+		t.Errorf("EmptyFolders = %v, want only %s", result.EmptyFolders, want)
+	}
+	if len(result.MissingMeta) != 0 || len(result.InvalidMeta) != 0 {
+		t.Errorf("MissingMeta = %v, InvalidMeta = %v; want none", result.MissingMeta, result.InvalidMeta)
+	}
+	if result.HasErrors() || !result.HasWarnings() {
+		t.Errorf("HasErrors() = %v, HasWarnings() = %v; want false, true", result.HasErrors(), result.HasWarnings())
+	}
+
+	// Of nested folders without any file, only the outermost is listed
+	if err := os.MkdirAll(filepath.Join(assetsDir, "Hollow", "Inner"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	result, err = checker.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(result.EmptyFolders) != 2 || result.EmptyFolders[1] != filepath.Join("Assets", "Hollow") {
+		t.Errorf("EmptyFolders = %v, want Assets/Empty/Nested and Assets/Hollow", result.EmptyFolders)
+	}
+}
+
+func TestMetaChecker_Check_InvalidMeta(t *testing.T) {
+	project, tempDir := setupTestProject(t)
+	assetsDir := filepath.Join(tempDir, "Assets")
+
+	// A folder whose .meta lacks folderAsset, and a file whose .meta claims to be a folder
+	if err := os.MkdirAll(filepath.Join(assetsDir, "Art"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	createAssetWithMeta(t, filepath.Join(assetsDir, "Art"), "Hero.png", "hero")
+	if err := os.WriteFile(filepath.Join(assetsDir, "Art.meta"), []byte("fileFormatVersion: 2\nguid: art\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	createAssetWithoutMeta(t, assetsDir, "Notes.txt")
+	if err := os.WriteFile(filepath.Join(assetsDir, "Notes.txt.meta"), []byte("fileFormatVersion: 3\nguid: notes\nfolderAsset: yes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := NewMetaChecker(project)
+	result, err := checker.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, meta := range result.InvalidMeta {
+		got[filepath.Base(meta.Path)+" "+meta.Kind] = meta.Detail
+	}
+	for _, key := range []string{"Art.meta folder-asset", "Notes.txt.meta folder-asset", "Notes.txt.meta file-format-version"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("missing %s in %+v", key, result.InvalidMeta)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("InvalidMeta = %+v, want 3 issues", result.InvalidMeta)
+	}
+	if !result.HasErrors() {
+		t.Error("Expected HasErrors() to return true")
+	}
+}
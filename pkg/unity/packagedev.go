@@ -0,0 +1,496 @@
+package unity
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// PackageLinksFile records the manifest entries replaced by 'package link',
+// in the project's .uniforge folder
+const PackageLinksFile = "package-links.json"
+
+// packageNameMaxLength is the longest package name the Package Manager accepts
+const packageNameMaxLength = 214
+
+var (
+	packageNamePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	packageVersionPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+	unityMajorMinor       = regexp.MustCompile(`^\d+\.\d+$`)
+	unityReleasePattern   = regexp.MustCompile(`^\d+[abfp]\d+$`)
+)
+
+// packTime is the modification time of the files in a package tarball, so
+// packing the same files twice gives the same archive (npm uses the same date)
+var packTime = time.Date(1985, 10, 26, 8, 15, 0, 0, time.UTC)
+
+// PackageInfo is the package.json of a UPM package
+type PackageInfo struct {
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	DisplayName  string            `json:"displayName"`
+	Description  string            `json:"description"`
+	Unity        string            `json:"unity"`
+	UnityRelease string            `json:"unityRelease"`
+	Dependencies map[string]string `json:"dependencies"`
+
+	Dir string `json:"-"` // Folder holding package.json
+}
+
+// LoadPackageInfo reads the package.json of the package in dir
+func LoadPackageInfo(dir string) (*PackageInfo, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	path := filepath.Join(absDir, "package.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errs.New(errs.NotFound, "not a UPM package: package.json not found at %s", path)
+		}
+		return nil, err
+	}
+	var info PackageInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	info.Dir = absDir
+	return &info, nil
+}
+
+// PackageProblem is a finding of PackageInfo.Validate
+type PackageProblem struct {
+	Field   string // package.json field, or "" for files of the package
+	Message string
+	Warning bool // The Package Manager still imports the package
+}
+
+func (p PackageProblem) String() string {
+	if p.Field == "" {
+		return p.Message
+	}
+	return p.Field + ": " + p.Message
+}
+
+// Validate checks package.json against the rules of the Package Manager and
+// the package files for assets Unity cannot import without a .meta file
+func (p *PackageInfo) Validate() []PackageProblem {
+	var problems []PackageProblem
+	add := func(field, message string, warning bool) {
+		problems = append(problems, PackageProblem{Field: field, Message: message, Warning: warning})
+	}
+
+	switch {
+	case p.Name == "":
+		add("name", "is required", false)
+	case len(p.Name) > packageNameMaxLength:
+		add("name", fmt.Sprintf("is longer than %d characters", packageNameMaxLength), false)
+	case !packageNamePattern.MatchString(p.Name):
+		add("name", fmt.Sprintf("%q may only contain lowercase letters, digits, '-', '_' and '.'", p.Name), false)
+	case !strings.Contains(p.Name, "."):
+		add("name", fmt.Sprintf("%q should use reverse domain notation, e.g. com.company.%s", p.Name, p.Name), true)
+	}
+
+	if p.Version == "" {
+		add("version", "is required", false)
+	} else if !packageVersionPattern.MatchString(p.Version) {
+		add("version", fmt.Sprintf("%q is not a semantic version (MAJOR.MINOR.PATCH)", p.Version), false)
+	}
+
+	if p.Unity != "" && !unityMajorMinor.MatchString(p.Unity) {
+		add("unity", fmt.Sprintf("%q must be a Unity major.minor version, e.g. 2022.3", p.Unity), false)
+	}
+	if p.UnityRelease != "" {
+		if p.Unity == "" {
+			add("unityRelease", "requires the unity field", false)
+		} else if !unityReleasePattern.MatchString(p.UnityRelease) {
+			add("unityRelease", fmt.Sprintf("%q must be a Unity release, e.g. 10f1", p.UnityRelease), false)
+		}
+	}
+	if p.DisplayName == "" {
+		add("displayName", "is missing; the Package Manager window shows the package name instead", true)
+	}
+
+	names := make([]string, 0, len(p.Dependencies))
+	for name := range p.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !packageVersionPattern.MatchString(p.Dependencies[name]) {
+			add("dependencies", fmt.Sprintf("%s: %q is not a semantic version; packages can only depend on registry versions", name, p.Dependencies[name]), false)
+		}
+	}
+
+	if p.Dir != "" {
+		for _, missing := range p.missingMeta() {
+			add("", fmt.Sprintf("%s has no .meta file; Unity will not import it from an immutable package", missing), true)
+		}
+	}
+	return problems
+}
+
+// missingMeta returns the package assets without a .meta file. Hidden files
+// and folders ending in "~" (e.g. Samples~, Documentation~) are not imported
+// and need none.
+func (p *PackageInfo) missingMeta() []string {
+	var missing []string
+	_ = filepath.WalkDir(p.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == p.Dir {
+			return nil
+		}
+		name := d.Name()
+		if isIgnoredPackageName(name) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(name, ".meta") || excludedFiles[name] {
+			return nil
+		}
+		if !fileExists(path + ".meta") {
+			rel, _ := filepath.Rel(p.Dir, path)
+			missing = append(missing, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return missing
+}
+
+// isIgnoredPackageName reports whether Unity skips a file or folder of a package
+func isIgnoredPackageName(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") || name == "node_modules"
+}
+
+// HasPackageErrors reports whether problems include anything other than warnings
+func HasPackageErrors(problems []PackageProblem) bool {
+	for _, p := range problems {
+		if !p.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+// manifestDocument is Packages/manifest.json kept as raw JSON, so changing the
+// dependencies leaves scopedRegistries, testables and other keys intact
+type manifestDocument struct {
+	path         string
+	doc          map[string]json.RawMessage
+	Dependencies map[string]string
+}
+
+func loadManifestDocument(projectPath string) (*manifestDocument, error) {
+	path := filepath.Join(projectPath, "Packages", "manifest.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errs.New(errs.NotFound, "package manifest not found: %s", path)
+		}
+		return nil, err
+	}
+	m := &manifestDocument{path: path}
+	if err := json.Unmarshal(data, &m.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if m.doc == nil {
+		m.doc = make(map[string]json.RawMessage)
+	}
+	if raw, ok := m.doc["dependencies"]; ok {
+		if err := json.Unmarshal(raw, &m.Dependencies); err != nil {
+			return nil, fmt.Errorf("failed to parse dependencies of %s: %w", path, err)
+		}
+	}
+	if m.Dependencies == nil {
+		m.Dependencies = make(map[string]string)
+	}
+	return m, nil
+}
+
+// save writes the manifest back with the two-space indentation Unity uses
+func (m *manifestDocument) save() error {
+	deps, err := marshalManifestJSON(m.Dependencies, "")
+	if err != nil {
+		return err
+	}
+	m.doc["dependencies"] = deps
+
+	data, err := marshalManifestJSON(m.doc, "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(m.path, data, 0644)
+}
+
+// marshalManifestJSON encodes v without escaping <, > and &, which appear in
+// version ranges and URLs
+func marshalManifestJSON(v any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// PackageLink is a manifest entry replaced by 'package link'
+type PackageLink struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`               // Absolute folder of the linked package
+	Previous string `json:"previous,omitempty"` // Manifest value before linking; "" if the package was not listed
+}
+
+// PackageLinksPath returns where the links of a project are recorded
+func PackageLinksPath(projectPath string) string {
+	return filepath.Join(projectPath, ProjectConfigDir, PackageLinksFile)
+}
+
+// LoadPackageLinks returns the packages linked into a project, by name
+func LoadPackageLinks(projectPath string) (map[string]PackageLink, error) {
+	links := make(map[string]PackageLink)
+	data, err := os.ReadFile(PackageLinksPath(projectPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return links, nil
+		}
+		return nil, err
+	}
+	var list []PackageLink
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PackageLinksPath(projectPath), err)
+	}
+	for _, link := range list {
+		links[link.Name] = link
+	}
+	return links, nil
+}
+
+func savePackageLinks(projectPath string, links map[string]PackageLink) error {
+	path := PackageLinksPath(projectPath)
+	if len(links) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	list := make([]PackageLink, 0, len(links))
+	for _, link := range links {
+		list = append(list, link)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, append(data, '\n'), 0644)
+}
+
+// LinkPackage points the manifest entry of the package in packageDir at its
+// folder with a file: reference relative to the project's Packages folder, and
+// records the replaced value for UnlinkPackage. Returns the manifest value.
+func LinkPackage(projectPath, packageDir string) (*PackageInfo, string, error) {
+	info, err := LoadPackageInfo(packageDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.Name == "" {
+		return nil, "", errs.New(errs.Usage, "package.json in %s has no name", info.Dir)
+	}
+	manifest, err := loadManifestDocument(projectPath)
+	if err != nil {
+		return nil, "", err
+	}
+	links, err := LoadPackageLinks(projectPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ref := "file:" + packageFileReference(filepath.Join(projectPath, "Packages"), info.Dir)
+	link, relinked := links[info.Name]
+	if !relinked {
+		link = PackageLink{Name: info.Name, Previous: manifest.Dependencies[info.Name]}
+	}
+	link.Path = info.Dir
+	links[info.Name] = link
+	manifest.Dependencies[info.Name] = ref
+
+	// Record the link first, so a failed manifest write can still be reverted
+	if err := savePackageLinks(projectPath, links); err != nil {
+		return nil, "", err
+	}
+	if err := manifest.save(); err != nil {
+		return nil, "", err
+	}
+	return info, ref, nil
+}
+
+// packageFileReference returns the path of dir as seen from the Packages
+// folder, with forward slashes. Folders on another Windows drive stay absolute.
+func packageFileReference(packagesDir, dir string) string {
+	absPackages, err := filepath.Abs(packagesDir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	rel, err := filepath.Rel(absPackages, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// UnlinkPackage restores the manifest entry LinkPackage replaced, removing the
+// dependency if the package was not listed before. Returns the restored value.
+func UnlinkPackage(projectPath, name string) (PackageLink, error) {
+	links, err := LoadPackageLinks(projectPath)
+	if err != nil {
+		return PackageLink{}, err
+	}
+	link, ok := links[name]
+	if !ok {
+		return PackageLink{}, errs.WithHint(
+			errs.New(errs.NotFound, "package %s is not linked into %s", name, projectPath),
+			"Packages linked with 'uniforge package link' are recorded in "+PackageLinksPath(projectPath)+".")
+	}
+	manifest, err := loadManifestDocument(projectPath)
+	if err != nil {
+		return PackageLink{}, err
+	}
+	if link.Previous == "" {
+		delete(manifest.Dependencies, name)
+	} else {
+		manifest.Dependencies[name] = link.Previous
+	}
+	if err := manifest.save(); err != nil {
+		return PackageLink{}, err
+	}
+	delete(links, name)
+	return link, savePackageLinks(projectPath, links)
+}
+
+// PackTarballName returns the npm-style tarball name of a package, e.g. com.company.tool-1.2.0.tgz
+func PackTarballName(info *PackageInfo) string {
+	return info.Name + "-" + info.Version + ".tgz"
+}
+
+// PackPackage writes the package in dir to outputDir as a tarball the Package
+// Manager can install (files below "package/", as npm pack does). Packing fails
+// if Validate finds errors; the warnings are returned with the tarball path.
+func PackPackage(dir, outputDir string) (string, []PackageProblem, error) {
+	info, err := LoadPackageInfo(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	problems := info.Validate()
+	if HasPackageErrors(problems) {
+		var lines []string
+		for _, p := range problems {
+			if !p.Warning {
+				lines = append(lines, "  "+p.String())
+			}
+		}
+		return "", problems, errs.New(errs.Usage, "invalid package.json in %s:\n%s", info.Dir, strings.Join(lines, "\n"))
+	}
+
+	output, err := filepath.Abs(filepath.Join(outputDir, PackTarballName(info)))
+	if err != nil {
+		return "", problems, err
+	}
+	if err := writePackageTarball(info.Dir, output); err != nil {
+		return "", problems, err
+	}
+	return output, problems, nil
+}
+
+// writePackageTarball archives the files of root below "package/" into output
+func writePackageTarball(root, output string) error {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root {
+			return nil
+		}
+		// Hidden files, node_modules and the tarball itself, e.g. when packing into
+		// the package folder. Folders ending in "~" are packed: Samples~ ships samples.
+		if isIgnoredPackageName(d.Name()) && !strings.HasSuffix(d.Name(), "~") || p == output {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		if err := addPackageFile(tw, file, path.Join("package", filepath.ToSlash(rel))); err != nil {
+			return fmt.Errorf("failed to pack %s: %w", rel, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(output, buf.Bytes(), 0644)
+}
+
+func addPackageFile(tw *tar.Writer, file, name string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	mode := int64(0644)
+	if info.Mode()&0111 != 0 {
+		mode = 0755
+	}
+	header := &tar.Header{
+		Name:     name,
+		Mode:     mode,
+		Size:     info.Size(),
+		ModTime:  packTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,256 @@
+package unity
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeTestPackage(t *testing.T, dir, packageJSON string) {
+	t.Helper()
+	writeTestFiles(t, dir, map[string]string{
+		"package.json":              packageJSON,
+		"package.json.meta":         "guid: 1",
+		"Runtime.meta":              "guid: 2",
+		"Runtime/Tool.cs":           "class Tool {}",
+		"Runtime/Tool.cs.meta":      "guid: 3",
+		"Samples~/Demo/Demo.cs":     "class Demo {}",
+		".git/HEAD":                 "ref: refs/heads/main",
+		"node_modules/dep/index.js": "",
+	})
+}
+
+func TestPackageInfoValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     PackageInfo
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			info: PackageInfo{Name: "com.company.tool", Version: "1.2.0-pre.1", DisplayName: "Tool", Unity: "2022.3", UnityRelease: "10f1", Dependencies: map[string]string{"com.unity.ugui": "1.0.0"}},
+		},
+		{
+			name:   "missing fields",
+			info:   PackageInfo{DisplayName: "Tool"},
+			errors: []string{"name", "version"},
+		},
+		{
+			name:     "invalid values",
+			info:     PackageInfo{Name: "Com.Company.Tool", Version: "1.2", Unity: "2022", UnityRelease: "10", Dependencies: map[string]string{"com.other": "file:../other"}},
+			errors:   []string{"name", "version", "unity", "unityRelease", "dependencies"},
+			warnings: []string{"displayName"},
+		},
+		{
+			name:     "not reverse domain",
+			info:     PackageInfo{Name: "tool", Version: "1.0.0", DisplayName: "Tool", UnityRelease: "10f1"},
+			errors:   []string{"unityRelease"},
+			warnings: []string{"name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errors, warnings []string
+			for _, p := range tt.info.Validate() {
+				if p.Warning {
+					warnings = append(warnings, p.Field)
+				} else {
+					errors = append(errors, p.Field)
+				}
+			}
+			if strings.Join(errors, ",") != strings.Join(tt.errors, ",") {
+				t.Errorf("errors = %v, want %v", errors, tt.errors)
+			}
+			if strings.Join(warnings, ",") != strings.Join(tt.warnings, ",") {
+				t.Errorf("warnings = %v, want %v", warnings, tt.warnings)
+			}
+			if HasPackageErrors(tt.info.Validate()) != (len(tt.errors) > 0) {
+				t.Errorf("HasPackageErrors() = %v", !(len(tt.errors) > 0))
+			}
+		})
+	}
+}
+
+func TestPackageInfoValidateMissingMeta(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, `{"name": "com.company.tool", "version": "1.0.0", "displayName": "Tool"}`)
+	writeTestFiles(t, dir, map[string]string{"Editor/Window.cs": ""})
+
+	info, err := LoadPackageInfo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, p := range info.Validate() {
+		messages = append(messages, p.String())
+	}
+	sort.Strings(messages)
+	want := []string{
+		"Editor has no .meta file; Unity will not import it from an immutable package",
+		"Editor/Window.cs has no .meta file; Unity will not import it from an immutable package",
+	}
+	if strings.Join(messages, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems = %q, want %q", messages, want)
+	}
+}
+
+func TestLinkPackage(t *testing.T) {
+	root := t.TempDir()
+	projectPath := filepath.Join(root, "TestProject")
+	packageDir := filepath.Join(root, "tool")
+	writeTestPackage(t, packageDir, `{"name": "com.company.tool", "version": "1.0.0"}`)
+	original := `{
+  "dependencies": {
+    "com.company.tool": "1.0.0",
+    "com.unity.ugui": "1.0.0"
+  },
+  "scopedRegistries": [
+    {
+      "name": "Company",
+      "url": "https://npm.example.com",
+      "scopes": ["com.company"]
+    }
+  ]
+}
+`
+	writeTestFiles(t, projectPath, map[string]string{"Packages/manifest.json": original})
+
+	info, ref, err := LinkPackage(projectPath, packageDir)
+	if err != nil {
+		t.Fatalf("LinkPackage() error = %v", err)
+	}
+	if info.Name != "com.company.tool" || ref != "file:../../tool" {
+		t.Errorf("LinkPackage() = %s, %q", info.Name, ref)
+	}
+	manifest, err := LoadPackageManifest(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Dependencies["com.company.tool"] != "file:../../tool" || manifest.Dependencies["com.unity.ugui"] != "1.0.0" {
+		t.Errorf("dependencies after link = %v", manifest.Dependencies)
+	}
+	data, _ := os.ReadFile(filepath.Join(projectPath, "Packages", "manifest.json"))
+	var doc struct {
+		ScopedRegistries []struct {
+			URL string `json:"url"`
+		} `json:"scopedRegistries"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil || len(doc.ScopedRegistries) != 1 || doc.ScopedRegistries[0].URL != "https://npm.example.com" {
+		t.Errorf("scopedRegistries not kept:\n%s", data)
+	}
+
+	// Linking again keeps the version from before the first link
+	if _, _, err := LinkPackage(projectPath, packageDir); err != nil {
+		t.Fatal(err)
+	}
+	link, err := UnlinkPackage(projectPath, "com.company.tool")
+	if err != nil {
+		t.Fatalf("UnlinkPackage() error = %v", err)
+	}
+	if link.Previous != "1.0.0" {
+		t.Errorf("Previous = %q, want 1.0.0", link.Previous)
+	}
+	manifest, _ = LoadPackageManifest(projectPath)
+	if manifest.Dependencies["com.company.tool"] != "1.0.0" {
+		t.Errorf("dependency after unlink = %q", manifest.Dependencies["com.company.tool"])
+	}
+	if fileExists(PackageLinksPath(projectPath)) {
+		t.Error("links file should be removed with the last link")
+	}
+
+	if _, err := UnlinkPackage(projectPath, "com.company.tool"); err == nil {
+		t.Error("UnlinkPackage() of a package that is not linked should fail")
+	}
+}
+
+func TestUnlinkPackageRemovesNewDependency(t *testing.T) {
+	root := t.TempDir()
+	projectPath := filepath.Join(root, "TestProject")
+	packageDir := filepath.Join(root, "tool")
+	writeTestPackage(t, packageDir, `{"name": "com.company.tool", "version": "1.0.0"}`)
+	writeTestFiles(t, projectPath, map[string]string{"Packages/manifest.json": `{"dependencies": {"com.company.other": "https://example.com/other.tgz?a=1&b=2"}}`})
+
+	if _, _, err := LinkPackage(projectPath, packageDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UnlinkPackage(projectPath, "com.company.tool"); err != nil {
+		t.Fatal(err)
+	}
+	manifest, _ := LoadPackageManifest(projectPath)
+	if _, ok := manifest.Dependencies["com.company.tool"]; ok {
+		t.Errorf("dependencies after unlink = %v", manifest.Dependencies)
+	}
+	data, _ := os.ReadFile(filepath.Join(projectPath, "Packages", "manifest.json"))
+	if !strings.Contains(string(data), "a=1&b=2") {
+		t.Errorf("manifest should not escape '&':\n%s", data)
+	}
+}
+
+func TestPackPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, `{"name": "com.company.tool", "version": "1.2.0", "displayName": "Tool"}`)
+	out := filepath.Join(t.TempDir(), "dist")
+
+	output, problems, err := PackPackage(dir, out)
+	if err != nil {
+		t.Fatalf("PackPackage() error = %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("problems = %v", problems)
+	}
+	if filepath.Base(output) != "com.company.tool-1.2.0.tgz" {
+		t.Errorf("output = %s", output)
+	}
+
+	file, err := os.Open(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+	}
+	want := []string{
+		"package/Runtime/Tool.cs",
+		"package/Runtime/Tool.cs.meta",
+		"package/Runtime.meta",
+		"package/Samples~/Demo/Demo.cs",
+		"package/package.json",
+		"package/package.json.meta",
+	}
+	if strings.Join(names, "\n") != strings.Join(want, "\n") {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+}
+
+func TestPackPackageInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPackage(t, dir, `{"name": "Tool", "version": "1"}`)
+
+	_, _, err := PackPackage(dir, dir)
+	if err == nil {
+		t.Fatal("PackPackage() of an invalid package should fail")
+	}
+	if !strings.Contains(err.Error(), "name:") || !strings.Contains(err.Error(), "version:") {
+		t.Errorf("error = %v", err)
+	}
+	if fileExists(filepath.Join(dir, "Tool-1.tgz")) {
+		t.Error("no tarball should be written")
+	}
+}
@@ -0,0 +1,182 @@
+package unity
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// packageRegistryURL is the Unity package registry; replaced in tests
+var packageRegistryURL = "https://packages.unity.com"
+
+// PackageManifest is the project's Packages/manifest.json
+type PackageManifest struct {
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// LoadPackageManifest reads Packages/manifest.json of the project at projectPath
+func LoadPackageManifest(projectPath string) (*PackageManifest, error) {
+	path := filepath.Join(projectPath, "Packages", "manifest.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errs.New(errs.NotFound, "package manifest not found: %s", path)
+		}
+		return nil, err
+	}
+	var manifest PackageManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// IsRegistryVersion reports whether a manifest dependency is a registry version
+// rather than a file:, git or tarball reference
+func IsRegistryVersion(version string) bool {
+	return version != "" && !strings.Contains(version, ":") && !strings.Contains(version, "/")
+}
+
+// PackageManagerDir returns the PackageManager resources folder of the editor
+func (e *Editor) PackageManagerDir() (string, error) {
+	editorPath, err := e.GetPath()
+	if err != nil {
+		return "", err
+	}
+	return packageManagerDirFor(runtime.GOOS, editorPath), nil
+}
+
+// packageManagerDirFor derives the PackageManager resources folder from the editor executable path
+func packageManagerDirFor(goos, editorPath string) string {
+	if goos == "darwin" {
+		// Unity.app/Contents/MacOS/Unity -> Unity.app/Contents/Resources/PackageManager
+		return filepath.Join(filepath.Dir(filepath.Dir(editorPath)), "Resources", "PackageManager")
+	}
+	// Editor/Unity(.exe) -> Editor/Data/Resources/PackageManager
+	return filepath.Join(filepath.Dir(editorPath), "Data", "Resources", "PackageManager")
+}
+
+// EditorPackageVersions returns the package versions an editor ships with or
+// pins: the packages in BuiltInPackages and the editor manifest, by name
+func EditorPackageVersions(packageManagerDir string) map[string]string {
+	versions := make(map[string]string)
+
+	// The editor manifest lists the versions the editor selects by default. Its
+	// layout changed between releases, so accept "name": "1.0.0" as well as
+	// "name": {"version": "1.0.0"}, at the top level or below "dependencies"/"packages".
+	if data, err := os.ReadFile(filepath.Join(packageManagerDir, "Editor", "manifest.json")); err == nil {
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(data, &doc); err != nil {
+			ui.Debug("Failed to parse editor package manifest", "error", err)
+		}
+		collectEditorManifestVersions(doc, versions)
+		for _, key := range []string{"dependencies", "packages"} {
+			var nested map[string]json.RawMessage
+			if raw, ok := doc[key]; ok && json.Unmarshal(raw, &nested) == nil {
+				collectEditorManifestVersions(nested, versions)
+			}
+		}
+	}
+
+	// Packages bundled with the editor always win
+	entries, _ := os.ReadDir(filepath.Join(packageManagerDir, "BuiltInPackages"))
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(packageManagerDir, "BuiltInPackages", entry.Name(), "package.json"))
+		if err != nil {
+			continue
+		}
+		var pkg struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Name != "" && pkg.Version != "" {
+			versions[pkg.Name] = pkg.Version
+		}
+	}
+	return versions
+}
+
+func collectEditorManifestVersions(doc map[string]json.RawMessage, versions map[string]string) {
+	for name, raw := range doc {
+		if !strings.HasPrefix(name, "com.") {
+			continue
+		}
+		var version string
+		if json.Unmarshal(raw, &version) == nil {
+			versions[name] = version
+			continue
+		}
+		var entry struct {
+			Version        string `json:"version"`
+			DefaultVersion string `json:"defaultVersion"`
+		}
+		if json.Unmarshal(raw, &entry) == nil {
+			if entry.Version != "" {
+				versions[name] = entry.Version
+			} else if entry.DefaultVersion != "" {
+				versions[name] = entry.DefaultVersion
+			}
+		}
+	}
+}
+
+// RegistryPackage is the registry metadata of a package
+type RegistryPackage struct {
+	Name     string                     `json:"name"`
+	Versions map[string]RegistryVersion `json:"versions"`
+}
+
+// RegistryVersion is one published version of a package
+type RegistryVersion struct {
+	Unity        string `json:"unity"`        // Minimum Unity major.minor, e.g. "2021.3"
+	UnityRelease string `json:"unityRelease"` // Optional minimum patch, e.g. "10f1"
+	Deprecated   string `json:"deprecated"`   // Deprecation message, if any
+}
+
+// MinimumUnity returns the oldest Unity version the package version supports, or ""
+func (v RegistryVersion) MinimumUnity() string {
+	if v.Unity == "" {
+		return ""
+	}
+	if v.UnityRelease != "" {
+		return v.Unity + "." + v.UnityRelease
+	}
+	return v.Unity
+}
+
+// FetchRegistryPackage fetches the metadata of a package from the Unity registry
+func FetchRegistryPackage(name string) (*RegistryPackage, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(packageRegistryURL + "/" + url.PathEscape(name))
+	if err != nil {
+		return nil, errs.New(errs.NetworkUnavailable, "failed to fetch %s from the package registry: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errs.New(errs.NotFound, "package %s is not in the Unity registry", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s from the package registry: %s", name, resp.Status)
+	}
+
+	var pkg RegistryPackage
+	if err := json.NewDecoder(resp.Body).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse registry metadata of %s: %w", name, err)
+	}
+	return &pkg, nil
+}
+
+// isPrerelease reports whether a package version is a preview or pre-release
+func isPrerelease(version string) bool {
+	return strings.Contains(version, "-")
+}
@@ -3,9 +3,12 @@ package unity
 import (
 	"bufio"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
 )
 
 type Project struct {
@@ -23,7 +26,7 @@ func LoadProject(projectPath string) (*Project, error) {
 
 	versionFile := filepath.Join(absPath, "ProjectSettings", "ProjectVersion.txt")
 	if _, err := os.Stat(versionFile); os.IsNotExist(err) {
-		return nil, fmt.Errorf("not a Unity project: ProjectVersion.txt not found at %s", versionFile)
+		return nil, errs.New(errs.NotFound, "not a Unity project: ProjectVersion.txt not found at %s", versionFile)
 	}
 
 	version, changeset, err := readUnityVersionWithChangeset(versionFile)
@@ -39,6 +42,73 @@ func LoadProject(projectPath string) (*Project, error) {
 	}, nil
 }
 
+// FindProjectRoot walks up from dir to the nearest directory containing
+// ProjectSettings/ProjectVersion.txt
+func FindProjectRoot(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	for current := absDir; ; {
+		if fileExists(filepath.Join(current, "ProjectSettings", "ProjectVersion.txt")) {
+			return current, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("not inside a Unity project: %s", absDir)
+		}
+		current = parent
+	}
+}
+
+// DiskUsage is the size of a project on disk, split by top-level folder
+type DiskUsage struct {
+	Assets  int64 `json:"assets"`
+	Library int64 `json:"library"`
+	Other   int64 `json:"other"` // Packages, ProjectSettings, Logs, Temp, ...
+}
+
+// Total returns the size of the whole project
+func (u DiskUsage) Total() int64 {
+	return u.Assets + u.Library + u.Other
+}
+
+// MeasureDiskUsage adds up the sizes of the project's files. Symbolic links are
+// not followed, and folders that cannot be read are skipped.
+func MeasureDiskUsage(projectPath string) (DiskUsage, error) {
+	var usage DiskUsage
+	if _, err := os.Stat(projectPath); err != nil {
+		return usage, err
+	}
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && path != projectPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(projectPath, path)
+		switch strings.SplitN(filepath.ToSlash(rel), "/", 2)[0] {
+		case "Assets":
+			usage.Assets += info.Size()
+		case "Library":
+			usage.Library += info.Size()
+		default:
+			usage.Other += info.Size()
+		}
+		return nil
+	})
+	return usage, err
+}
+
 func readUnityVersion(versionFile string) (string, error) {
 	version, _, err := readUnityVersionWithChangeset(versionFile)
 	return version, err
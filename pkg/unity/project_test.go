@@ -128,3 +128,119 @@ func TestReadUnityVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadPlayerSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	projectSettingsDir := filepath.Join(tempDir, "ProjectSettings")
+	if err := os.MkdirAll(projectSettingsDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+
+	content := `PlayerSettings:
+  companyName: My Company
+  productName: Space Game
+  bundleVersion: 1.2.0
+  applicationIdentifier:
+    Android: com.example.spacegame
+    iPhone: com.example.spacegame.ios
+  buildNumber: {}
+  scriptingBackend:
+    Android: 1
+    Standalone: 0
+  cloudProjectId: 5f0c2c0e-1b2a-4d3e-9f40-123456789abc
+  organizationId: space-studio
+`
+	if err := os.WriteFile(filepath.Join(projectSettingsDir, "ProjectSettings.asset"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write settings file: %v", err)
+	}
+
+	settings, err := LoadPlayerSettings(tempDir)
+	if err != nil {
+		t.Fatalf("LoadPlayerSettings failed: %v", err)
+	}
+
+	if settings.BundleVersion != "1.2.0" {
+		t.Errorf("Expected bundleVersion 1.2.0, got %s", settings.BundleVersion)
+	}
+	if id := settings.ApplicationIdentifier("Android"); id != "com.example.spacegame" {
+		t.Errorf("Expected Android identifier com.example.spacegame, got %s", id)
+	}
+	if id := settings.ApplicationIdentifier("iPhone"); id != "com.example.spacegame.ios" {
+		t.Errorf("Expected iPhone identifier com.example.spacegame.ios, got %s", id)
+	}
+	if id := settings.ApplicationIdentifier("Standalone"); id != "com.MyCompany.SpaceGame" {
+		t.Errorf("Expected default identifier com.MyCompany.SpaceGame, got %s", id)
+	}
+	if !settings.UsesIL2CPP("Android") || settings.UsesIL2CPP("Standalone") || !settings.UsesIL2CPP("iPhone") {
+		t.Errorf("ScriptingBackends = %v", settings.ScriptingBackends)
+	}
+	if settings.CloudProjectID != "5f0c2c0e-1b2a-4d3e-9f40-123456789abc" || settings.OrganizationID != "space-studio" {
+		t.Errorf("Cloud link = %q / %q", settings.CloudProjectID, settings.OrganizationID)
+	}
+}
+
+func TestFindProjects(t *testing.T) {
+	root := t.TempDir()
+	version := "m_EditorVersion: 2022.3.10f1\n"
+	writeTestFiles(t, root, map[string]string{
+		"game/ProjectSettings/ProjectVersion.txt":                   version,
+		"game/Packages/nested/ProjectSettings/ProjectVersion.txt":   version, // inside a project
+		"clients/a/app/ProjectSettings/ProjectVersion.txt":          version,
+		"clients/a/app/deeper/x/ProjectSettings/ProjectVersion.txt": version,
+		".hidden/ProjectSettings/ProjectVersion.txt":                version,
+		"node_modules/pkg/ProjectSettings/ProjectVersion.txt":       version,
+		"too/deep/for/the/limit/ProjectSettings/ProjectVersion.txt": version,
+		"notes.txt": "",
+	})
+
+	projects, err := FindProjects(root, 4)
+	if err != nil {
+		t.Fatalf("FindProjects() error = %v", err)
+	}
+	var got []string
+	for _, p := range projects {
+		rel, _ := filepath.Rel(root, p.Path)
+		got = append(got, filepath.ToSlash(rel))
+		if p.UnityVersion != "2022.3.10f1" {
+			t.Errorf("%s: UnityVersion = %q", rel, p.UnityVersion)
+		}
+	}
+	want := []string{"clients/a/app", "game"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("FindProjects() = %v, want %v", got, want)
+	}
+
+	all, err := FindProjects(root, 0)
+	if err != nil {
+		t.Fatalf("FindProjects() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("FindProjects() without depth limit found %d projects, want 3", len(all))
+	}
+}
+
+func TestMeasureDiskUsage(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"Assets/Scenes/Main.unity":           "12345",
+		"Assets/Scenes/Main.unity.meta":      "123",
+		"Library/ArtifactDB":                 "1234567890",
+		"ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 2022.3.10f1\n",
+		"Packages/manifest.json":             "{}",
+	})
+
+	usage, err := MeasureDiskUsage(root)
+	if err != nil {
+		t.Fatalf("MeasureDiskUsage() error = %v", err)
+	}
+	if usage.Assets != 8 || usage.Library != 10 || usage.Other != 31 {
+		t.Errorf("MeasureDiskUsage() = %+v", usage)
+	}
+	if usage.Total() != 49 {
+		t.Errorf("Total() = %d, want 49", usage.Total())
+	}
+
+	if _, err := MeasureDiskUsage(filepath.Join(root, "missing")); err == nil {
+		t.Error("MeasureDiskUsage() of a missing project should return an error")
+	}
+}
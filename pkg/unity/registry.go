@@ -0,0 +1,236 @@
+package unity
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// ScopedRegistry is an entry of scopedRegistries in Packages/manifest.json
+type ScopedRegistry struct {
+	Name             string   `json:"name"`
+	URL              string   `json:"url"`
+	Scopes           []string `json:"scopes"`
+	OverrideBuiltIns bool     `json:"overrideBuiltIns,omitempty"`
+}
+
+// NormalizeRegistryURL validates a registry URL and drops a trailing slash,
+// so the manifest and .upmconfig.toml name the registry the same way
+func NormalizeRegistryURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errs.New(errs.Usage, "invalid registry URL: %q (must be an http or https URL)", raw)
+	}
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// Validate checks the fields the Package Manager requires
+func (r ScopedRegistry) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errs.New(errs.Usage, "registry name is required")
+	}
+	if _, err := NormalizeRegistryURL(r.URL); err != nil {
+		return err
+	}
+	if len(r.Scopes) == 0 {
+		return errs.New(errs.Usage, "registry %s needs at least one scope, e.g. com.company", r.Name)
+	}
+	for _, scope := range r.Scopes {
+		if !packageNamePattern.MatchString(scope) {
+			return errs.New(errs.Usage, "invalid scope %q: use a lowercase package name prefix, e.g. com.company", scope)
+		}
+	}
+	return nil
+}
+
+// scopedRegistries returns the scopedRegistries of the manifest
+func (m *manifestDocument) scopedRegistries() ([]ScopedRegistry, error) {
+	var registries []ScopedRegistry
+	if raw, ok := m.doc["scopedRegistries"]; ok {
+		if err := json.Unmarshal(raw, &registries); err != nil {
+			return nil, fmt.Errorf("failed to parse scopedRegistries of %s: %w", m.path, err)
+		}
+	}
+	return registries, nil
+}
+
+// setScopedRegistries replaces the scopedRegistries of the manifest; none removes the key
+func (m *manifestDocument) setScopedRegistries(registries []ScopedRegistry) error {
+	if len(registries) == 0 {
+		delete(m.doc, "scopedRegistries")
+		return nil
+	}
+	data, err := marshalManifestJSON(registries, "")
+	if err != nil {
+		return err
+	}
+	m.doc["scopedRegistries"] = data
+	return nil
+}
+
+// ListScopedRegistries returns the scoped registries of the project at projectPath
+func ListScopedRegistries(projectPath string) ([]ScopedRegistry, error) {
+	manifest, err := loadManifestDocument(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.scopedRegistries()
+}
+
+// AddScopedRegistry adds a registry to the project's manifest. An entry with
+// the same name or URL is replaced. A scope may only belong to one registry,
+// so scopes claimed by another entry are an error. Reports whether an entry
+// was replaced.
+func AddScopedRegistry(projectPath string, registry ScopedRegistry) (bool, error) {
+	if err := registry.Validate(); err != nil {
+		return false, err
+	}
+	registry.URL, _ = NormalizeRegistryURL(registry.URL)
+	sort.Strings(registry.Scopes)
+
+	manifest, err := loadManifestDocument(projectPath)
+	if err != nil {
+		return false, err
+	}
+	registries, err := manifest.scopedRegistries()
+	if err != nil {
+		return false, err
+	}
+
+	replaced := false
+	var kept []ScopedRegistry
+	for _, existing := range registries {
+		if existing.Name == registry.Name || strings.TrimSuffix(existing.URL, "/") == registry.URL {
+			replaced = true
+			continue
+		}
+		for _, scope := range registry.Scopes {
+			for _, other := range existing.Scopes {
+				if scope == other {
+					return false, errs.New(errs.Usage, "scope %s already belongs to registry %s", scope, existing.Name)
+				}
+			}
+		}
+		kept = append(kept, existing)
+	}
+	if err := manifest.setScopedRegistries(append(kept, registry)); err != nil {
+		return false, err
+	}
+	return replaced, manifest.save()
+}
+
+// RemoveScopedRegistry removes the registry with the given name or URL from
+// the project's manifest and returns it
+func RemoveScopedRegistry(projectPath, nameOrURL string) (ScopedRegistry, error) {
+	manifest, err := loadManifestDocument(projectPath)
+	if err != nil {
+		return ScopedRegistry{}, err
+	}
+	registries, err := manifest.scopedRegistries()
+	if err != nil {
+		return ScopedRegistry{}, err
+	}
+	target := strings.TrimSuffix(nameOrURL, "/")
+	for i, registry := range registries {
+		if registry.Name != nameOrURL && strings.TrimSuffix(registry.URL, "/") != target {
+			continue
+		}
+		if err := manifest.setScopedRegistries(append(registries[:i:i], registries[i+1:]...)); err != nil {
+			return ScopedRegistry{}, err
+		}
+		return registry, manifest.save()
+	}
+	return ScopedRegistry{}, errs.New(errs.NotFound, "no scoped registry named %s in %s", nameOrURL, manifest.path)
+}
+
+// registryGet requests path (with query) from the npm registry at registryURL,
+// with token if not empty
+func registryGet(registryURL, path, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(registryURL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errs.New(errs.NetworkUnavailable, "failed to reach registry %s: %w", registryURL, err)
+	}
+	return resp, nil
+}
+
+// registryAuthError describes a rejected request to the registry at registryURL
+func registryAuthError(registryURL, status, token string) error {
+	err := fmt.Errorf("registry %s rejected the request: %s", registryURL, status)
+	if token == "" {
+		return errs.WithHint(err, "The registry needs credentials; store a token with 'uniforge upm auth set "+registryURL+"'.")
+	}
+	return errs.WithHint(err, "Check that the token is valid and can read the registry.")
+}
+
+// VerifyRegistry checks that the npm registry at registryURL answers the
+// search endpoint the Package Manager window uses, searching for scope if not
+// empty, with token if not empty
+func VerifyRegistry(registryURL, scope, token string) error {
+	query := url.Values{"size": {"1"}}
+	if scope != "" {
+		query.Set("text", scope)
+	}
+	resp, err := registryGet(registryURL, "/-/v1/search?"+query.Encode(), token)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return registryAuthError(registryURL, resp.Status, token)
+	default:
+		return fmt.Errorf("registry %s does not answer /-/v1/search: %s", registryURL, resp.Status)
+	}
+
+	var result struct {
+		Objects *json.RawMessage `json:"objects"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.Objects == nil {
+		return fmt.Errorf("registry %s did not return npm search results; is it an npm registry?", registryURL)
+	}
+	return nil
+}
+
+// VerifyRegistryToken checks that the registry at registryURL accepts token
+// and returns the user it belongs to. Registries without npm's /-/whoami
+// endpoint are checked with an authenticated search instead, and return "".
+func VerifyRegistryToken(registryURL, token string) (string, error) {
+	resp, err := registryGet(registryURL, "/-/whoami", token)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", registryAuthError(registryURL, resp.Status, token)
+	default:
+		return "", VerifyRegistry(registryURL, "", token)
+	}
+
+	var result struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse the /-/whoami answer of %s: %w", registryURL, err)
+	}
+	return result.Username, nil
+}
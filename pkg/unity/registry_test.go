@@ -0,0 +1,156 @@
+package unity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestNormalizeRegistryURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "https://npm.example.com/", want: "https://npm.example.com"},
+		{in: "http://localhost:4873", want: "http://localhost:4873"},
+		{in: "npm.example.com", wantErr: true},
+		{in: "ftp://npm.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeRegistryURL(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeRegistryURL(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestScopedRegistries(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTestFiles(t, projectPath, map[string]string{
+		"Packages/manifest.json": `{"dependencies": {"com.unity.ugui": "1.0.0"}, "testables": ["com.company.tool"]}`,
+	})
+
+	company := ScopedRegistry{Name: "Company", URL: "https://npm.example.com/", Scopes: []string{"com.company.b", "com.company.a"}}
+	replaced, err := AddScopedRegistry(projectPath, company)
+	if err != nil || replaced {
+		t.Fatalf("AddScopedRegistry() = %v, %v", replaced, err)
+	}
+	if _, err := AddScopedRegistry(projectPath, ScopedRegistry{Name: "OpenUPM", URL: "https://package.openupm.com", Scopes: []string{"com.cysharp"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A scope belongs to one registry
+	_, err = AddScopedRegistry(projectPath, ScopedRegistry{Name: "Other", URL: "https://other.example.com", Scopes: []string{"com.company.a"}})
+	if errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("AddScopedRegistry() with a taken scope error = %v", err)
+	}
+
+	// The same URL replaces the entry
+	replaced, err = AddScopedRegistry(projectPath, ScopedRegistry{Name: "Company npm", URL: "https://npm.example.com", Scopes: []string{"com.company"}})
+	if err != nil || !replaced {
+		t.Fatalf("AddScopedRegistry() replacing = %v, %v", replaced, err)
+	}
+
+	registries, err := ListScopedRegistries(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registries) != 2 || registries[0].Name != "OpenUPM" || registries[1].Name != "Company npm" || registries[1].URL != "https://npm.example.com" {
+		t.Errorf("registries = %+v", registries)
+	}
+
+	removed, err := RemoveScopedRegistry(projectPath, "https://package.openupm.com/")
+	if err != nil || removed.Name != "OpenUPM" {
+		t.Fatalf("RemoveScopedRegistry() = %+v, %v", removed, err)
+	}
+	if _, err := RemoveScopedRegistry(projectPath, "Company npm"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RemoveScopedRegistry(projectPath, "Company npm"); errs.CategoryOf(err) != errs.NotFound {
+		t.Errorf("RemoveScopedRegistry() of a missing registry error = %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(projectPath, "Packages", "manifest.json"))
+	if strings.Contains(string(data), "scopedRegistries") || !strings.Contains(string(data), "testables") {
+		t.Errorf("manifest after removing all registries:\n%s", data)
+	}
+}
+
+func TestScopedRegistryValidate(t *testing.T) {
+	tests := []ScopedRegistry{
+		{URL: "https://npm.example.com", Scopes: []string{"com.company"}},
+		{Name: "Company", URL: "npm.example.com", Scopes: []string{"com.company"}},
+		{Name: "Company", URL: "https://npm.example.com"},
+		{Name: "Company", URL: "https://npm.example.com", Scopes: []string{"Com.Company"}},
+	}
+	for _, r := range tests {
+		if err := r.Validate(); errs.CategoryOf(err) != errs.Usage {
+			t.Errorf("Validate(%+v) error = %v, want a usage error", r, err)
+		}
+	}
+}
+
+func TestVerifyRegistry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path != "/-/v1/search":
+			http.NotFound(w, r)
+		case r.URL.Query().Get("text") != "com.company":
+			http.Error(w, "bad query", http.StatusBadRequest)
+		case r.Header.Get("Authorization") != "Bearer secret":
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		default:
+			_, _ = w.Write([]byte(`{"objects": [], "total": 0}`))
+		}
+	}))
+	defer server.Close()
+
+	if err := VerifyRegistry(server.URL+"/", "com.company", "secret"); err != nil {
+		t.Errorf("VerifyRegistry() error = %v", err)
+	}
+	err := VerifyRegistry(server.URL, "com.company", "")
+	if err == nil || errs.Hint(err) == "" {
+		t.Errorf("VerifyRegistry() without a token error = %v, want a hint", err)
+	}
+	if err := VerifyRegistry(server.URL+"/other", "com.company", "secret"); err == nil {
+		t.Error("VerifyRegistry() of a server without the search endpoint should fail")
+	}
+}
+
+func TestVerifyRegistryToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/npm/-/whoami":
+			_, _ = w.Write([]byte(`{"username": "ci-bot"}`))
+		case "/npm/-/v1/search", "/plain/-/v1/search":
+			_, _ = w.Write([]byte(`{"objects": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	user, err := VerifyRegistryToken(server.URL+"/npm", "secret")
+	if err != nil || user != "ci-bot" {
+		t.Errorf("VerifyRegistryToken() = %q, %v", user, err)
+	}
+
+	// No /-/whoami: an authenticated search
+	user, err = VerifyRegistryToken(server.URL+"/plain", "secret")
+	if err != nil || user != "" {
+		t.Errorf("VerifyRegistryToken() without whoami = %q, %v", user, err)
+	}
+
+	if _, err := VerifyRegistryToken(server.URL+"/npm", "wrong"); err == nil || errs.Hint(err) == "" {
+		t.Errorf("VerifyRegistryToken() with a bad token error = %v, want a hint", err)
+	}
+}
@@ -2,14 +2,19 @@ package unity
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/trace"
 	"github.com/neptaco/uniforge/pkg/ui"
+	"github.com/neptaco/uniforge/pkg/watchdog"
 )
 
 // RunConfig holds configuration for running Unity in batch mode
@@ -20,6 +25,21 @@ type RunConfig struct {
 	TimeoutSeconds int
 	CIMode         bool
 	ShowTimestamp  bool
+
+	// IdleTimeoutSeconds kills Unity when it logs nothing for this long, or
+	// goes quiet after a known hang signature (0 disables the watchdog)
+	IdleTimeoutSeconds int
+
+	// Tee also receives Unity's output, e.g. to parse it while it is shown
+	Tee io.Writer
+
+	// ShowNoise lists the noise categories shown, which are otherwise
+	// suppressed outside CI mode
+	ShowNoise []logger.NoiseCategory
+
+	// DiagnosticsFile receives the compiler errors and warnings of the run as
+	// JSON, grouped and deduplicated (empty: not written)
+	DiagnosticsFile string
 }
 
 // Runner handles Unity batch execution
@@ -36,6 +56,18 @@ func NewRunner(project *Project) *Runner {
 	}
 }
 
+// batchFormatter returns the formatter of Unity's output for the project: noise
+// is hidden, except for the categories in showNoise, script locations link to
+// the IDE and colors follow --no-color
+func batchFormatter(projectPath string, showNoise []logger.NoiseCategory) *logger.Formatter {
+	return logger.NewFormatter(
+		logger.WithNoColor(!ui.ColorEnabled()),
+		logger.WithLinker(ScriptLinker(projectPath)),
+		logger.WithHideNoise(true),
+		logger.WithShowNoise(showNoise),
+	)
+}
+
 // Run executes Unity in batch mode with the specified configuration
 func (r *Runner) Run(config RunConfig) error {
 	editorPath, err := r.editor.GetPath()
@@ -58,30 +90,59 @@ func (r *Runner) Run(config RunConfig) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
 
+	env, err := ProjectEnviron(absProjectPath)
+	if err != nil {
+		return err
+	}
 	cmd := exec.CommandContext(ctx, editorPath, args...)
+	cmd.Env = env
 
 	log := logger.NewWithOptions(config.LogFile,
 		logger.WithCIMode(config.CIMode),
 		logger.WithShowTime(config.ShowTimestamp),
+		logger.WithFormatter(batchFormatter(absProjectPath, config.ShowNoise)),
+		logger.WithDiagnosticsFile(config.DiagnosticsFile),
 	)
 	defer func() { _ = log.Close() }()
 
-	cmd.Stdout = log
-	cmd.Stderr = log
+	crash := watchForCrash(r.project, config.LogFile)
+	cmd.Stdout = io.MultiWriter(log, crash.tail)
+	if config.Tee != nil {
+		cmd.Stdout = io.MultiWriter(log, crash.tail, config.Tee)
+	}
+	cmd.Stderr = cmd.Stdout
 
 	projectDir := filepath.Dir(absProjectPath)
 	cmd.Dir = projectDir
 
 	ui.Debug("Running Unity", "path", editorPath, "args", strings.Join(args, " "))
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start Unity: %w", err)
+	span := trace.Start(trace.KindExec, "Unity batch mode", "version", r.project.UnityVersion)
+	if config.IdleTimeoutSeconds > 0 {
+		err = watchdog.Run(cmd, watchdog.Config{
+			IdleTimeout: time.Duration(config.IdleTimeoutSeconds) * time.Second,
+			LogFile:     config.LogFile,
+		})
+	} else {
+		if err := cmd.Start(); err != nil {
+			return startError(runtime.GOOS, editorPath, err)
+		}
+		err = cmd.Wait()
 	}
+	span.Fail(err)
+	span.End()
 
-	if err := cmd.Wait(); err != nil {
+	if err != nil {
+		var stall *watchdog.StallError
+		if errors.As(err, &stall) {
+			return err
+		}
 		if ctx.Err() == context.DeadlineExceeded {
 			return fmt.Errorf("execution timeout after %d seconds", timeout)
 		}
+		if crashErr := crash.check(err); crashErr != nil {
+			return crashErr
+		}
 		return fmt.Errorf("unity execution failed: %w", err)
 	}
 
@@ -0,0 +1,71 @@
+package unity
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// scanSkipDirs are directories that never contain Unity projects worth listing
+var scanSkipDirs = map[string]bool{
+	"node_modules": true,
+	"Library":      true,
+	"Temp":         true,
+	"obj":          true,
+}
+
+// FindProjects walks root and returns the Unity projects below it, identified
+// by ProjectSettings/ProjectVersion.txt. Projects are not searched for nested
+// projects, and hidden directories and those deeper than maxDepth below root
+// (0 for no limit) are skipped. Unreadable directories are logged and skipped.
+func FindProjects(root string, maxDepth int) ([]*Project, error) {
+	span := trace.Start(trace.KindScan, "find projects", "root", root)
+	defer span.End()
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*Project
+	err = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == absRoot {
+				return err
+			}
+			ui.Debug("Skipping unreadable directory", "path", path, "error", err)
+			return fs.SkipDir
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != absRoot && (strings.HasPrefix(d.Name(), ".") || scanSkipDirs[d.Name()]) {
+			return fs.SkipDir
+		}
+
+		if fileExists(filepath.Join(path, "ProjectSettings", "ProjectVersion.txt")) {
+			project, err := LoadProject(path)
+			if err != nil {
+				ui.Debug("Skipping project", "path", path, "error", err)
+			} else {
+				projects = append(projects, project)
+			}
+			return fs.SkipDir
+		}
+
+		if maxDepth > 0 && path != absRoot {
+			rel, _ := filepath.Rel(absRoot, path)
+			if strings.Count(rel, string(filepath.Separator))+1 >= maxDepth {
+				return fs.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
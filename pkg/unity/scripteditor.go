@@ -0,0 +1,202 @@
+package unity
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/editorprefs"
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// SyncSolutionMethod regenerates the .sln and .csproj files with the generator
+// of the external script editor selected in the preferences
+const SyncSolutionMethod = "UnityEditor.SyncVS.SyncSolution"
+
+// Editor preferences holding Unity's "External Script Editor"
+const (
+	prefScriptsDefaultApp = "kScriptsDefaultApp"
+	prefScriptEditorArgs  = "kScriptEditorArgs"
+)
+
+// ScriptEditor is an IDE Unity can use as its external script editor
+type ScriptEditor struct {
+	Name    string   // uniforge name: rider, cursor, code, vs
+	Title   string   // Display name
+	Package string   // Unity package that integrates the IDE
+	Args    string   // Arguments Unity passes when opening a file
+	scheme  string   // URL scheme that opens a file at a line, e.g. vscode://file/<path>:<line>
+	apps    []string // macOS application bundles
+	windows []string // Windows executables, relative to Program Files or the user's programs
+	command []string // Commands on PATH
+}
+
+// scriptEditors are the IDEs uniforge can configure
+var scriptEditors = []ScriptEditor{
+	{
+		Name: "rider", Title: "JetBrains Rider", Package: "com.unity.ide.rider",
+		apps:    []string{"Rider.app"},
+		command: []string{"rider", "rider64", "rider.sh"},
+	},
+	{
+		Name: "cursor", Title: "Cursor", Package: "com.unity.ide.visualstudio",
+		Args:    `"$(ProjectPath)" -g "$(File)":$(Line):$(Column)`,
+		scheme:  "cursor",
+		apps:    []string{"Cursor.app"},
+		windows: []string{`Programs\cursor\Cursor.exe`},
+		command: []string{"cursor"},
+	},
+	{
+		Name: "code", Title: "Visual Studio Code", Package: "com.unity.ide.visualstudio",
+		Args:    `"$(ProjectPath)" -g "$(File)":$(Line):$(Column)`,
+		scheme:  "vscode",
+		apps:    []string{"Visual Studio Code.app"},
+		windows: []string{`Programs\Microsoft VS Code\Code.exe`, `Microsoft VS Code\Code.exe`},
+		command: []string{"code"},
+	},
+	{
+		Name: "vs", Title: "Visual Studio", Package: "com.unity.ide.visualstudio",
+		windows: []string{
+			`Microsoft Visual Studio\2022\Community\Common7\IDE\devenv.exe`,
+			`Microsoft Visual Studio\2022\Professional\Common7\IDE\devenv.exe`,
+			`Microsoft Visual Studio\2022\Enterprise\Common7\IDE\devenv.exe`,
+		},
+	},
+}
+
+// ScriptEditorNames returns the names FindScriptEditor accepts
+func ScriptEditorNames() []string {
+	names := make([]string, len(scriptEditors))
+	for i, e := range scriptEditors {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// FindScriptEditor returns the IDE called name and the path Unity should
+// launch it with: an application bundle on macOS, an executable elsewhere
+func FindScriptEditor(name string) (*ScriptEditor, string, error) {
+	i := slices.IndexFunc(scriptEditors, func(e ScriptEditor) bool { return e.Name == name })
+	if i < 0 {
+		return nil, "", errs.New(errs.Usage, "unknown IDE %q (use one of: %s)", name, strings.Join(ScriptEditorNames(), ", "))
+	}
+	editor := scriptEditors[i]
+	if path := editor.find(runtime.GOOS); path != "" {
+		return &editor, path, nil
+	}
+	return nil, "", errs.New(errs.NotFound, "%s was not found on this machine", editor.Title)
+}
+
+func (e *ScriptEditor) find(goos string) string {
+	home, _ := os.UserHomeDir()
+	var candidates []string
+	switch goos {
+	case "darwin":
+		for _, app := range e.apps {
+			candidates = append(candidates, filepath.Join("/Applications", app), filepath.Join(home, "Applications", app))
+		}
+	case "windows":
+		roots := []string{os.Getenv("LOCALAPPDATA"), os.Getenv("ProgramFiles"), os.Getenv("ProgramFiles(x86)")}
+		for _, exe := range e.windows {
+			for _, root := range roots {
+				if root != "" {
+					candidates = append(candidates, filepath.Join(root, exe))
+				}
+			}
+		}
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	for _, command := range e.command {
+		path, err := exec.LookPath(command)
+		if err != nil {
+			continue
+		}
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		return path
+	}
+	return ""
+}
+
+// ScriptEditorPrefs returns the editor preferences that make Unity open
+// scripts with editor at path. Unity shares them between all editor versions.
+func ScriptEditorPrefs(editor *ScriptEditor, path string) []editorprefs.Pref {
+	prefs := []editorprefs.Pref{{Key: prefScriptsDefaultApp, Type: editorprefs.TypeString, Value: path}}
+	if editor.Args != "" {
+		// Unity keeps the arguments per application
+		prefs = append(prefs, editorprefs.Pref{Key: prefScriptEditorArgs + path, Type: editorprefs.TypeString, Value: editor.Args})
+	}
+	return prefs
+}
+
+// HasScriptEditorPackage reports whether the project's manifest includes the
+// package that integrates editor, without which Unity cannot generate its
+// project files
+func HasScriptEditorPackage(projectPath string, editor *ScriptEditor) bool {
+	manifest, err := LoadPackageManifest(projectPath)
+	if err != nil {
+		return false
+	}
+	_, ok := manifest.Dependencies[editor.Package]
+	return ok
+}
+
+// DetectedScriptEditorName returns the name of the IDE uniforge opens projects
+// with, see hub.ExternalEditor
+func DetectedScriptEditorName() string {
+	fields := strings.Fields(hub.ExternalEditor())
+	if len(fields) == 0 {
+		return ""
+	}
+	// UNIFORGE_EDITOR may be a path or carry arguments
+	return strings.TrimSuffix(filepath.Base(fields[0]), ".exe")
+}
+
+// ScriptURL returns a URL that opens the script at path, relative to the
+// project, at line in the IDE called ide: a vscode:// or cursor:// URL for the
+// IDEs that register one, a file URL otherwise. Returns "" for a script the
+// project does not have, e.g. one of a package in Library/PackageCache.
+func ScriptURL(projectPath, ide, path string, line int) string {
+	absPath := filepath.Join(projectPath, filepath.FromSlash(path))
+	if _, err := os.Stat(absPath); err != nil {
+		return ""
+	}
+	urlPath := filepath.ToSlash(absPath)
+	if !strings.HasPrefix(urlPath, "/") {
+		urlPath = "/" + urlPath // C:/... on Windows
+	}
+
+	for _, e := range scriptEditors {
+		if e.Name == ide && e.scheme != "" {
+			return (&url.URL{Scheme: e.scheme, Host: "file", Path: fmt.Sprintf("%s:%d", urlPath, line)}).String()
+		}
+	}
+	return (&url.URL{Scheme: "file", Path: urlPath}).String()
+}
+
+// ScriptLinker returns a logger.Linker that links script locations in the
+// project's logs to the detected IDE. Links are left out without colors, as
+// output that is not for a terminal has no use for them.
+func ScriptLinker(projectPath string) logger.Linker {
+	if !ui.ColorEnabled() {
+		return nil
+	}
+	ide := DetectedScriptEditorName()
+	return func(path string, line int) string {
+		return ScriptURL(projectPath, ide, path, line)
+	}
+}
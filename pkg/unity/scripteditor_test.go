@@ -0,0 +1,81 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+func TestFindScriptEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the IDE command")
+	}
+	bin := t.TempDir()
+	writeTestFiles(t, bin, map[string]string{"code": "#!/bin/sh\n"})
+	if err := os.Chmod(filepath.Join(bin, "code"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	t.Setenv("HOME", t.TempDir())
+
+	editor, path, err := FindScriptEditor("code")
+	if runtime.GOOS == "darwin" && err == nil && filepath.Ext(path) == ".app" {
+		t.Skip("Visual Studio Code is installed on this machine")
+	}
+	if err != nil || editor.Name != "code" || path != filepath.Join(bin, "code") {
+		t.Errorf("FindScriptEditor(code) = %v, %q, %v", editor, path, err)
+	}
+
+	if _, _, err := FindScriptEditor("notepad"); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("FindScriptEditor(notepad) error = %v, want a usage error", err)
+	}
+}
+
+func TestScriptEditorPrefs(t *testing.T) {
+	editor := &scriptEditors[2] // code
+	prefs := ScriptEditorPrefs(editor, "/usr/bin/code")
+	if len(prefs) != 2 || prefs[0].Key != "kScriptsDefaultApp" || prefs[0].Value != "/usr/bin/code" || prefs[1].Key != "kScriptEditorArgs/usr/bin/code" {
+		t.Errorf("ScriptEditorPrefs() = %+v", prefs)
+	}
+
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"Packages/manifest.json": `{"dependencies": {"com.unity.ide.visualstudio": "2.0.22"}}`,
+	})
+	if !HasScriptEditorPackage(dir, editor) {
+		t.Error("HasScriptEditorPackage(code) = false, want true")
+	}
+	if HasScriptEditorPackage(dir, &scriptEditors[0]) {
+		t.Error("HasScriptEditorPackage(rider) = true, want false")
+	}
+}
+
+func TestScriptURL(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "My Game")
+	writeTestFiles(t, dir, map[string]string{"Assets/Scripts/Player.cs": "class Player {}"})
+	urlPath := filepath.ToSlash(dir)
+	if !strings.HasPrefix(urlPath, "/") {
+		urlPath = "/" + urlPath
+	}
+	escaped := strings.ReplaceAll(urlPath, " ", "%20")
+
+	tests := []struct {
+		ide  string
+		path string
+		want string
+	}{
+		{ide: "code", path: "Assets/Scripts/Player.cs", want: "vscode://file" + escaped + "/Assets/Scripts/Player.cs:30"},
+		{ide: "cursor", path: "Assets/Scripts/Player.cs", want: "cursor://file" + escaped + "/Assets/Scripts/Player.cs:30"},
+		{ide: "rider", path: "Assets/Scripts/Player.cs", want: "file://" + escaped + "/Assets/Scripts/Player.cs"},
+		{ide: "code", path: "Packages/com.example.tools/Runtime/Tool.cs"},
+	}
+	for _, tt := range tests {
+		if got := ScriptURL(dir, tt.ide, tt.path, 30); got != tt.want {
+			t.Errorf("ScriptURL(%s, %s) = %q, want %q", tt.ide, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package unity
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PlayerSettings holds the subset of ProjectSettings.asset used by UniForge
+type PlayerSettings struct {
+	ProductName            string
+	CompanyName            string
+	BundleVersion          string
+	ApplicationIdentifiers map[string]string // Keyed by build target group (Android, iPhone, Standalone, ...)
+	ScriptingBackends      map[string]string // Keyed by build target group; "0" is Mono, "1" is IL2CPP
+	CloudProjectID         string            // Linked Unity Cloud project, empty if not linked
+	OrganizationID         string            // Organization of the linked project
+}
+
+// LoadPlayerSettings reads ProjectSettings/ProjectSettings.asset of the project
+func LoadPlayerSettings(projectPath string) (*PlayerSettings, error) {
+	settingsFile := filepath.Join(projectPath, "ProjectSettings", "ProjectSettings.asset")
+
+	file, err := os.Open(settingsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ProjectSettings.asset: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	settings := &PlayerSettings{
+		ApplicationIdentifiers: make(map[string]string),
+		ScriptingBackends:      make(map[string]string),
+	}
+
+	// Per-group map being read, nil outside of one
+	var inMap map[string]string
+	scanner := bufio.NewScanner(file)
+	const maxCapacity = 1024 * 1024
+	scanner.Buffer(make([]byte, maxCapacity), maxCapacity)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Entries of the per-group maps are indented one level deeper
+		if inMap != nil {
+			if strings.HasPrefix(line, "    ") {
+				if key, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+					inMap[key] = strings.TrimSpace(value)
+				}
+				continue
+			}
+			inMap = nil
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "productName":
+			settings.ProductName = value
+		case "companyName":
+			settings.CompanyName = value
+		case "bundleVersion":
+			settings.BundleVersion = value
+		case "cloudProjectId":
+			settings.CloudProjectID = value
+		case "organizationId":
+			settings.OrganizationID = value
+		case "applicationIdentifier", "scriptingBackend":
+			// Empty map is serialized inline as "{}"
+			if value == "" {
+				inMap = settings.ApplicationIdentifiers
+				if key == "scriptingBackend" {
+					inMap = settings.ScriptingBackends
+				}
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ProjectSettings.asset: %w", err)
+	}
+
+	return settings, nil
+}
+
+// ApplicationIdentifier returns the application identifier for the build target group.
+// Falls back to Unity's default "com.<CompanyName>.<ProductName>" when not set.
+func (s *PlayerSettings) ApplicationIdentifier(targetGroup string) string {
+	if id := s.ApplicationIdentifiers[targetGroup]; id != "" {
+		return id
+	}
+	return "com." + sanitizeIdentifier(s.CompanyName) + "." + sanitizeIdentifier(s.ProductName)
+}
+
+// sanitizeIdentifier removes characters that are not allowed in an identifier segment
+func sanitizeIdentifier(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// scriptingBackendIL2CPP is the scriptingBackend value of IL2CPP
+const scriptingBackendIL2CPP = "1"
+
+// alwaysIL2CPP are the build target groups without a Mono player
+var alwaysIL2CPP = map[string]bool{"iPhone": true, "tvOS": true, "WebGL": true, "VisionOS": true}
+
+// UsesIL2CPP reports whether players of the build target group are built with
+// IL2CPP. Groups missing from the settings use Mono, Unity's default.
+func (s *PlayerSettings) UsesIL2CPP(targetGroup string) bool {
+	return alwaysIL2CPP[targetGroup] || s.ScriptingBackends[targetGroup] == scriptingBackendIL2CPP
+}
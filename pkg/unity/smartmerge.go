@@ -0,0 +1,91 @@
+package unity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/git"
+)
+
+// SmartMergeDriver is the git merge driver name used for UnityYAMLMerge
+const SmartMergeDriver = "unityyamlmerge"
+
+// smartMergeBlock names the managed block in .gitattributes
+const smartMergeBlock = "smart-merge"
+
+// smartMergePatterns are the YAML asset types merged with UnityYAMLMerge
+var smartMergePatterns = []string{"*.unity", "*.prefab", "*.asset"}
+
+// SmartMergeChange describes one setting that is missing or out of date
+type SmartMergeChange struct {
+	Target string // ".git/config" or ".gitattributes"
+	Detail string
+}
+
+// smartMergeConfig returns the git config entries of the merge driver
+func smartMergeConfig(yamlMergePath string) [][2]string {
+	section := "merge." + SmartMergeDriver
+	return [][2]string{
+		{section + ".name", "Unity SmartMerge (UnityYAMLMerge)"},
+		{section + ".driver", fmt.Sprintf("'%s' merge -p %%O %%B %%A %%A", filepath.ToSlash(yamlMergePath))},
+		{section + ".recursive", "binary"},
+	}
+}
+
+// smartMergeAttributes returns the .gitattributes lines routing YAML assets to the driver
+func smartMergeAttributes() string {
+	var b strings.Builder
+	for _, pattern := range smartMergePatterns {
+		fmt.Fprintf(&b, "%s merge=%s\n", pattern, SmartMergeDriver)
+	}
+	return b.String()
+}
+
+// CheckSmartMerge reports which settings differ from the expected UnityYAMLMerge setup
+func CheckSmartMerge(repoRoot, yamlMergePath string) ([]SmartMergeChange, error) {
+	var changes []SmartMergeChange
+
+	for _, entry := range smartMergeConfig(yamlMergePath) {
+		current, err := git.ConfigGet(repoRoot, entry[0])
+		if err != nil {
+			return nil, err
+		}
+		if current != entry[1] {
+			changes = append(changes, SmartMergeChange{Target: ".git/config", Detail: fmt.Sprintf("%s = %s", entry[0], entry[1])})
+		}
+	}
+
+	attributes := smartMergeAttributes()
+	current, _, err := git.ReadBlock(filepath.Join(repoRoot, ".gitattributes"), smartMergeBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .gitattributes: %w", err)
+	}
+	if current != attributes {
+		for _, line := range strings.Split(strings.TrimSpace(attributes), "\n") {
+			changes = append(changes, SmartMergeChange{Target: ".gitattributes", Detail: line})
+		}
+	}
+
+	return changes, nil
+}
+
+// SetupSmartMerge configures the UnityYAMLMerge merge driver in .git/config and
+// .gitattributes. Already correct settings are left untouched.
+func SetupSmartMerge(repoRoot, yamlMergePath string) ([]SmartMergeChange, error) {
+	changes, err := CheckSmartMerge(repoRoot, yamlMergePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range smartMergeConfig(yamlMergePath) {
+		if err := git.ConfigSet(repoRoot, entry[0], entry[1]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := git.WriteBlock(filepath.Join(repoRoot, ".gitattributes"), smartMergeBlock, smartMergeAttributes()); err != nil {
+		return nil, fmt.Errorf("failed to write .gitattributes: %w", err)
+	}
+
+	return changes, nil
+}
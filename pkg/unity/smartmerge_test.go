@@ -0,0 +1,52 @@
+package unity
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestSetupSmartMerge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+
+	const yamlMerge = "/opt/unity/Editor/Data/Tools/UnityYAMLMerge"
+
+	changes, err := CheckSmartMerge(repo, yamlMerge)
+	if err != nil {
+		t.Fatalf("CheckSmartMerge() error = %v", err)
+	}
+	if len(changes) == 0 {
+		t.Fatal("CheckSmartMerge() should report missing settings in a fresh repository")
+	}
+
+	applied, err := SetupSmartMerge(repo, yamlMerge)
+	if err != nil {
+		t.Fatalf("SetupSmartMerge() error = %v", err)
+	}
+	if len(applied) != len(changes) {
+		t.Errorf("SetupSmartMerge() applied %d changes, want %d", len(applied), len(changes))
+	}
+
+	// Setup is idempotent
+	changes, err = CheckSmartMerge(repo, yamlMerge)
+	if err != nil {
+		t.Fatalf("CheckSmartMerge() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("CheckSmartMerge() after setup = %v, want none", changes)
+	}
+
+	out, err := exec.Command("git", "-C", repo, "check-attr", "merge", "Assets/Main.unity").Output()
+	if err != nil {
+		t.Fatalf("git check-attr failed: %v", err)
+	}
+	if got := string(out); got != "Assets/Main.unity: merge: "+SmartMergeDriver+"\n" {
+		t.Errorf("git check-attr = %q", got)
+	}
+}
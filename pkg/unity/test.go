@@ -3,12 +3,15 @@ package unity
 import (
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/neptaco/uniforge/pkg/logger"
+	"github.com/neptaco/uniforge/pkg/trace"
 	"github.com/neptaco/uniforge/pkg/ui"
 )
 
@@ -30,6 +33,7 @@ type TestConfig struct {
 	TimeoutSeconds int
 	CIMode         bool
 	ShowTimestamp  bool
+	ShowNoise      []logger.NoiseCategory // Noise categories to show, see RunConfig
 }
 
 // TestRunner handles Unity test execution
@@ -68,30 +72,43 @@ func (t *TestRunner) RunTests(config TestConfig) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
 
+	env, err := ProjectEnviron(absProjectPath)
+	if err != nil {
+		return err
+	}
 	cmd := exec.CommandContext(ctx, editorPath, args...)
+	cmd.Env = env
 
 	log := logger.NewWithOptions(config.LogFile,
 		logger.WithCIMode(config.CIMode),
 		logger.WithShowTime(config.ShowTimestamp),
+		logger.WithFormatter(batchFormatter(absProjectPath, config.ShowNoise)),
 	)
 	defer func() { _ = log.Close() }()
 
-	cmd.Stdout = log
-	cmd.Stderr = log
+	crash := watchForCrash(t.project, config.LogFile)
+	cmd.Stdout = io.MultiWriter(log, crash.tail)
+	cmd.Stderr = cmd.Stdout
 
 	projectDir := filepath.Dir(absProjectPath)
 	cmd.Dir = projectDir
 
 	ui.Debug("Running Unity tests", "path", editorPath, "args", strings.Join(args, " "))
 
+	span := trace.Start(trace.KindExec, "Unity tests", "version", t.project.UnityVersion, "platform", string(config.Platform))
+	defer span.End()
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start Unity: %w", err)
+		return startError(runtime.GOOS, editorPath, err)
 	}
 
 	if err := cmd.Wait(); err != nil {
+		span.Fail(err)
 		if ctx.Err() == context.DeadlineExceeded {
 			return fmt.Errorf("test timeout after %d seconds", timeout)
 		}
+		if crashErr := crash.check(err); crashErr != nil {
+			return crashErr
+		}
 		return fmt.Errorf("tests failed: %w", err)
 	}
 
@@ -0,0 +1,388 @@
+package unity
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/editorprefs"
+	"github.com/neptaco/uniforge/pkg/hub"
+)
+
+// BurstPackage is the Unity package of the Burst compiler
+const BurstPackage = "com.unity.burst"
+
+// ToolchainIssue is a prerequisite missing on this machine for building a player
+type ToolchainIssue struct {
+	Target  string `json:"target"` // Unity build target, e.g. Android
+	Problem string `json:"problem"`
+	Remedy  string `json:"remedy"`  // What to install or configure
+	Warning bool   `json:"warning"` // The build itself can run; a later step needs it
+}
+
+// String returns "target: problem"
+func (i ToolchainIssue) String() string {
+	return i.Target + ": " + i.Problem
+}
+
+// ToolchainTarget is a player build whose native toolchains are checked
+type ToolchainTarget struct {
+	Target         string // Unity build target (-buildTarget)
+	Version        string // Editor version the player is built with
+	IL2CPP         bool   // Scripting backend is IL2CPP
+	Burst          bool   // The project uses Burst
+	LinuxToolchain bool   // The project has a com.unity.toolchain.* package
+}
+
+// buildTargets maps -buildTarget names and their aliases, lowercase, to the
+// target name Unity reports
+var buildTargets = map[string]string{
+	"android":             "Android",
+	"ios":                 "iOS",
+	"tvos":                "tvOS",
+	"visionos":            "VisionOS",
+	"webgl":               "WebGL",
+	"win":                 "StandaloneWindows",
+	"standalonewindows":   "StandaloneWindows",
+	"win64":               "StandaloneWindows64",
+	"standalonewindows64": "StandaloneWindows64",
+	"osxuniversal":        "StandaloneOSX",
+	"standaloneosx":       "StandaloneOSX",
+	"linux64":             "StandaloneLinux64",
+	"standalonelinux64":   "StandaloneLinux64",
+}
+
+// buildTargetGroups maps build targets to the group PlayerSettings are kept for
+var buildTargetGroups = map[string]string{
+	"Android":             "Android",
+	"iOS":                 "iPhone",
+	"tvOS":                "tvOS",
+	"VisionOS":            "VisionOS",
+	"WebGL":               "WebGL",
+	"StandaloneWindows":   "Standalone",
+	"StandaloneWindows64": "Standalone",
+	"StandaloneOSX":       "Standalone",
+	"StandaloneLinux64":   "Standalone",
+}
+
+// standaloneHosts maps standalone targets to the GOOS their editor module is
+// built into and their native toolchain runs on
+var standaloneHosts = map[string]string{
+	"StandaloneWindows":   "windows",
+	"StandaloneWindows64": "windows",
+	"StandaloneOSX":       "darwin",
+	"StandaloneLinux64":   "linux",
+}
+
+// standaloneModules maps GOOS to the prefix of its standalone module IDs
+var standaloneModules = map[string]string{"windows": "windows", "darwin": "mac", "linux": "linux"}
+
+// androidNDKs are the NDK releases Unity versions are built against, by version
+// prefix; the first match wins
+var androidNDKs = []struct {
+	prefix  string
+	release string
+	major   int
+}{
+	{"2019.", "r19", 19},
+	{"2020.", "r19", 19},
+	{"2021.", "r21d", 21},
+	{"2022.", "r23b", 23},
+	{"2023.", "r23b", 23},
+	{"6000.0.", "r23b", 23},
+	{"6000.", "r27c", 27},
+}
+
+// toolchainHost is what the preflight asks about this machine; replaced in tests
+type toolchainHost struct {
+	goos     string
+	getenv   func(string) string
+	lookPath func(string) (string, error)
+	output   func(name string, args ...string) (string, error)
+	exists   func(string) bool
+	prefs    func() map[string]string // Editor preferences
+}
+
+// toolchainEditor is the installed editor a player is built with
+type toolchainEditor struct {
+	version string
+	modules map[string]bool // Installed module IDs
+	engines string          // PlaybackEngines directory
+}
+
+func systemToolchainHost() toolchainHost {
+	return toolchainHost{
+		goos:     runtime.GOOS,
+		getenv:   os.Getenv,
+		lookPath: exec.LookPath,
+		output: func(name string, args ...string) (string, error) {
+			out, err := exec.Command(name, args...).Output()
+			return strings.TrimSpace(string(out)), err
+		},
+		exists: fileExists,
+		prefs: func() map[string]string {
+			prefs := make(map[string]string)
+			list, _ := editorprefs.Read()
+			for _, p := range list {
+				prefs[p.Key] = p.Value
+			}
+			return prefs
+		},
+	}
+}
+
+// NormalizeBuildTarget returns the Unity name of a -buildTarget value such as
+// "win64" or "android", or "" when it is not a known target
+func NormalizeBuildTarget(target string) string {
+	return buildTargets[strings.ToLower(target)]
+}
+
+// NewToolchainTarget reads the scripting backend of target and whether Burst
+// is used from the project at projectPath
+func NewToolchainTarget(projectPath, target, version string) ToolchainTarget {
+	t := ToolchainTarget{Target: target, Version: version}
+	group := buildTargetGroups[NormalizeBuildTarget(target)]
+	if settings, err := LoadPlayerSettings(projectPath); err == nil {
+		t.IL2CPP = settings.UsesIL2CPP(group)
+	} else {
+		t.IL2CPP = alwaysIL2CPP[group]
+	}
+	packages := projectPackages(projectPath)
+	t.Burst = packages[BurstPackage]
+	for name := range packages {
+		if strings.HasPrefix(name, "com.unity.toolchain.") {
+			t.LinuxToolchain = true
+		}
+	}
+	return t
+}
+
+// projectPackages returns the names of the direct and indirect packages of the
+// project, from the manifest and the lock file
+func projectPackages(projectPath string) map[string]bool {
+	names := make(map[string]bool)
+	if manifest, err := LoadPackageManifest(projectPath); err == nil {
+		for name := range manifest.Dependencies {
+			names[name] = true
+		}
+	}
+	var lock packagesLock
+	if data, err := os.ReadFile(filepath.Join(projectPath, "Packages", "packages-lock.json")); err == nil {
+		if json.Unmarshal(data, &lock) == nil {
+			for name := range lock.Dependencies {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// CheckToolchain reports what this machine lacks to build the player of t:
+// the editor and its platform module, and the native toolchains IL2CPP and
+// Burst compile with. Unknown build targets are not checked.
+func CheckToolchain(t ToolchainTarget) []ToolchainIssue {
+	target := NormalizeBuildTarget(t.Target)
+	if target == "" {
+		return nil
+	}
+	hubClient := hub.NewClient()
+	installed, editorPath, err := hubClient.IsEditorInstalled(t.Version)
+	if err != nil || !installed {
+		remedy := "uniforge editor install " + t.Version
+		if module := targetModule(target, t.IL2CPP, runtime.GOOS); module != "" {
+			remedy += " --modules " + module
+		}
+		return []ToolchainIssue{{Target: t.Target, Problem: fmt.Sprintf("Unity %s is not installed", t.Version), Remedy: remedy}}
+	}
+	editor := toolchainEditor{
+		version: t.Version,
+		modules: hubClient.GetInstalledModules(editorPath),
+		engines: hubClient.GetPlaybackEnginesPath(editorPath),
+	}
+	return checkToolchain(t, editor, systemToolchainHost())
+}
+
+// targetModule returns the name 'editor install --modules' takes for the
+// module that builds target with the given scripting backend on goos, or ""
+// when the editor has it built in
+func targetModule(target string, il2cpp bool, goos string) string {
+	if host, ok := standaloneHosts[target]; ok {
+		switch {
+		case il2cpp:
+			return standaloneModules[host]
+		case host != goos:
+			return standaloneModules[host] + "-mono"
+		}
+		return ""
+	}
+	switch target {
+	case "Android":
+		return "android"
+	case "iOS":
+		return "ios"
+	case "tvOS":
+		return "appletv"
+	case "WebGL":
+		return "webgl"
+	}
+	return ""
+}
+
+func checkToolchain(t ToolchainTarget, editor toolchainEditor, host toolchainHost) []ToolchainIssue {
+	target := NormalizeBuildTarget(t.Target)
+	var issues []ToolchainIssue
+	add := func(warning bool, remedy, format string, args ...any) {
+		issues = append(issues, ToolchainIssue{Target: t.Target, Problem: fmt.Sprintf(format, args...), Remedy: remedy, Warning: warning})
+	}
+
+	if module := targetModule(target, t.IL2CPP, host.goos); module != "" && !editor.modules[hub.ModuleID(module)] {
+		add(false, fmt.Sprintf("uniforge editor install %s --modules %s", editor.version, module),
+			"the %s module is not installed for Unity %s", module, editor.version)
+	}
+
+	native := t.IL2CPP || t.Burst
+	switch target {
+	case "StandaloneWindows", "StandaloneWindows64", "StandaloneOSX":
+		if !native {
+			break
+		}
+		if standaloneHosts[target] != host.goos {
+			if t.IL2CPP {
+				add(false, "Build this target on a "+hostName(standaloneHosts[target])+" machine, or switch it to Mono",
+					"IL2CPP players for %s can only be built on %s", hostName(standaloneHosts[target]), hostName(standaloneHosts[target]))
+			}
+			break
+		}
+		if host.goos == "windows" {
+			issues = append(issues, checkMSVC(t.Target, host)...)
+		} else {
+			issues = append(issues, checkXcodeCLT(t.Target, host)...)
+		}
+	case "StandaloneLinux64":
+		if t.IL2CPP && !t.LinuxToolchain {
+			add(true, "Add "+linuxToolchainPackage(host.goos)+" in the Package Manager",
+				"no Linux IL2CPP toolchain package in the project; Unity has to download one during the build")
+		}
+	case "Android":
+		if native {
+			issues = append(issues, checkAndroidNDK(t.Target, editor, host)...)
+		}
+	case "iOS", "tvOS", "VisionOS":
+		if host.goos != "darwin" {
+			add(true, "Build the generated Xcode project on a Mac", "the Xcode project Unity generates can only be built on macOS")
+		} else if _, err := host.lookPath("xcodebuild"); err != nil {
+			add(true, "Install Xcode from the App Store and run 'sudo xcode-select -s /Applications/Xcode.app'",
+				"Xcode is not installed; it builds the generated project")
+		}
+	}
+	return issues
+}
+
+// checkMSVC checks for Visual Studio with the C++ build tools and a Windows SDK
+func checkMSVC(target string, host toolchainHost) []ToolchainIssue {
+	programFiles := host.getenv("ProgramFiles(x86)")
+	vswhere := filepath.Join(programFiles, "Microsoft Visual Studio", "Installer", "vswhere.exe")
+	if !host.exists(vswhere) {
+		return []ToolchainIssue{{Target: target, Problem: "Visual Studio is not installed",
+			Remedy: `Install Visual Studio 2019 or later with the "Desktop development with C++" workload`}}
+	}
+	path, err := host.output(vswhere, "-latest", "-products", "*",
+		"-requires", "Microsoft.VisualStudio.Component.VC.Tools.x86.x64", "-property", "installationPath")
+	if err != nil || path == "" {
+		return []ToolchainIssue{{Target: target, Problem: "Visual Studio has no C++ build tools (MSVC)",
+			Remedy: `Add the "Desktop development with C++" workload in the Visual Studio Installer`}}
+	}
+	if !host.exists(filepath.Join(programFiles, "Windows Kits", "10", "Include")) {
+		return []ToolchainIssue{{Target: target, Problem: "no Windows SDK is installed",
+			Remedy: "Add a Windows 10 or 11 SDK under Individual components in the Visual Studio Installer"}}
+	}
+	return nil
+}
+
+// checkXcodeCLT checks for the Xcode Command Line Tools
+func checkXcodeCLT(target string, host toolchainHost) []ToolchainIssue {
+	if path, err := host.output("xcode-select", "-p"); err != nil || path == "" {
+		return []ToolchainIssue{{Target: target, Problem: "the Xcode Command Line Tools are not installed",
+			Remedy: "Run 'xcode-select --install'"}}
+	}
+	return nil
+}
+
+// checkAndroidNDK checks that the NDK Unity uses exists and is the release the
+// editor version is built against
+func checkAndroidNDK(target string, editor toolchainEditor, host toolchainHost) []ToolchainIssue {
+	ndk := filepath.Join(editor.engines, "AndroidPlayer", "NDK")
+	source := "installed with the Android module"
+	// Unity uses the NDK of External Tools when "installed with Unity" is unchecked
+	prefs := host.prefs()
+	if custom := prefs["AndroidNdkRoot"]; custom != "" && prefs["NdkUseEmbedded"] == "0" {
+		ndk, source = custom, "set in Preferences > External Tools"
+	}
+
+	revision, err := readNDKRevision(ndk)
+	if err != nil {
+		return []ToolchainIssue{{Target: target, Problem: fmt.Sprintf("no Android NDK at %s (%s)", ndk, source),
+			Remedy: "Add \"Android SDK & NDK Tools\" to Unity " + editor.version + " in Unity Hub, or select an NDK in Preferences > External Tools"}}
+	}
+	for _, want := range androidNDKs {
+		if !strings.HasPrefix(editor.version, want.prefix) {
+			continue
+		}
+		major, _ := strconv.Atoi(strings.SplitN(revision, ".", 2)[0])
+		if major != want.major {
+			return []ToolchainIssue{{Target: target,
+				Problem: fmt.Sprintf("Unity %s needs Android NDK %s, but the NDK %s is %s", editor.version, want.release, source, revision),
+				Remedy:  fmt.Sprintf("Select NDK %s in Preferences > External Tools, or check \"installed with Unity\" there", want.release)}}
+		}
+		break
+	}
+	return nil
+}
+
+// readNDKRevision returns Pkg.Revision of the NDK's source.properties, e.g. 23.1.7779620
+func readNDKRevision(ndk string) (string, error) {
+	f, err := os.Open(filepath.Join(ndk, "source.properties"))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok && strings.TrimSpace(key) == "Pkg.Revision" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	return "", fmt.Errorf("no Pkg.Revision in %s", f.Name())
+}
+
+// hostName returns the display name of goos
+func hostName(goos string) string {
+	switch goos {
+	case "windows":
+		return "Windows"
+	case "darwin":
+		return "macOS"
+	default:
+		return "Linux"
+	}
+}
+
+// linuxToolchainPackage returns the package that builds Linux IL2CPP players on goos
+func linuxToolchainPackage(goos string) string {
+	switch goos {
+	case "windows":
+		return "com.unity.toolchain.win-x86_64-linux-x86_64"
+	case "darwin":
+		return "com.unity.toolchain.macos-x86_64-linux-x86_64"
+	default:
+		return "com.unity.toolchain.linux-x86_64"
+	}
+}
@@ -0,0 +1,155 @@
+package unity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeToolchainHost is a machine with the given files and command outputs
+func fakeToolchainHost(goos string, files []string, outputs map[string]string) toolchainHost {
+	return toolchainHost{
+		goos:   goos,
+		getenv: func(key string) string { return map[string]string{"ProgramFiles(x86)": `C:\PF86`}[key] },
+		lookPath: func(name string) (string, error) {
+			if _, ok := outputs[name]; ok {
+				return "/usr/bin/" + name, nil
+			}
+			return "", errors.New("not found")
+		},
+		output: func(name string, args ...string) (string, error) {
+			if out, ok := outputs[filepath.Base(name)]; ok {
+				return out, nil
+			}
+			return "", errors.New("exit status 1")
+		},
+		exists: func(path string) bool {
+			for _, f := range files {
+				if path == f {
+					return true
+				}
+			}
+			return false
+		},
+		prefs: func() map[string]string { return nil },
+	}
+}
+
+func writeNDK(t *testing.T, engines, revision string) {
+	t.Helper()
+	dir := filepath.Join(engines, "AndroidPlayer", "NDK")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "Pkg.Desc = Android NDK\nPkg.Revision = " + revision + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "source.properties"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func problems(issues []ToolchainIssue) string {
+	var s []string
+	for _, i := range issues {
+		s = append(s, i.Problem)
+	}
+	return strings.Join(s, "; ")
+}
+
+func TestCheckToolchainAndroidNDK(t *testing.T) {
+	engines := t.TempDir()
+	writeNDK(t, engines, "21.3.6528147")
+	host := fakeToolchainHost("linux", nil, nil)
+	target := ToolchainTarget{Target: "Android", IL2CPP: true}
+
+	editor := toolchainEditor{version: "2022.3.10f1", modules: map[string]bool{"android": true}, engines: engines}
+	issues := checkToolchain(target, editor, host)
+	if len(issues) != 1 || !strings.Contains(issues[0].Problem, "needs Android NDK r23b") {
+		t.Errorf("2022.3 with NDK r21: %s", problems(issues))
+	}
+
+	editor.version = "2021.3.30f1"
+	if issues := checkToolchain(target, editor, host); len(issues) != 0 {
+		t.Errorf("2021.3 with NDK r21: %s", problems(issues))
+	}
+
+	// Mono players need no NDK
+	editor.engines = t.TempDir()
+	if issues := checkToolchain(ToolchainTarget{Target: "android"}, editor, host); len(issues) != 0 {
+		t.Errorf("Mono: %s", problems(issues))
+	}
+	if issues := checkToolchain(target, editor, host); len(issues) != 1 || !strings.Contains(issues[0].Problem, "no Android NDK") {
+		t.Errorf("without NDK: %s", problems(issues))
+	}
+}
+
+func TestCheckToolchainModules(t *testing.T) {
+	host := fakeToolchainHost("darwin", nil, map[string]string{"xcode-select": "/Library/Developer/CommandLineTools"})
+	editor := toolchainEditor{version: "6000.0.23f1", modules: map[string]bool{}}
+
+	issues := checkToolchain(ToolchainTarget{Target: "StandaloneOSX", IL2CPP: true}, editor, host)
+	if len(issues) != 1 || issues[0].Remedy != "uniforge editor install 6000.0.23f1 --modules mac" {
+		t.Errorf("missing mac-il2cpp: %+v", issues)
+	}
+	// The Mono player of the host is part of the editor
+	if issues := checkToolchain(ToolchainTarget{Target: "OSXUniversal"}, editor, host); len(issues) != 0 {
+		t.Errorf("Mono on macOS: %s", problems(issues))
+	}
+	issues = checkToolchain(ToolchainTarget{Target: "Win64"}, editor, host)
+	if len(issues) != 1 || !strings.Contains(issues[0].Remedy, "--modules windows-mono") {
+		t.Errorf("Windows Mono on macOS: %+v", issues)
+	}
+}
+
+func TestCheckToolchainHosts(t *testing.T) {
+	editor := toolchainEditor{version: "2022.3.10f1", modules: map[string]bool{"windows-il2cpp": true, "mac-il2cpp": true, "ios": true}}
+	windows := ToolchainTarget{Target: "StandaloneWindows64", IL2CPP: true}
+
+	issues := checkToolchain(windows, editor, fakeToolchainHost("darwin", nil, nil))
+	if len(issues) != 1 || !strings.Contains(issues[0].Problem, "only be built on Windows") {
+		t.Errorf("Windows IL2CPP on macOS: %s", problems(issues))
+	}
+
+	vswhere := filepath.Join(`C:\PF86`, "Microsoft Visual Studio", "Installer", "vswhere.exe")
+	sdk := filepath.Join(`C:\PF86`, "Windows Kits", "10", "Include")
+	issues = checkToolchain(windows, editor, fakeToolchainHost("windows", []string{vswhere}, nil))
+	if len(issues) != 1 || !strings.Contains(issues[0].Problem, "no C++ build tools") {
+		t.Errorf("without MSVC: %s", problems(issues))
+	}
+	host := fakeToolchainHost("windows", []string{vswhere, sdk}, map[string]string{"vswhere.exe": `C:\VS\2022\Community`})
+	if issues := checkToolchain(windows, editor, host); len(issues) != 0 {
+		t.Errorf("with MSVC: %s", problems(issues))
+	}
+	// Burst needs the toolchain for Mono players too
+	if issues := checkToolchain(ToolchainTarget{Target: "Win64", Burst: true}, editor, fakeToolchainHost("windows", nil, nil)); len(issues) != 1 {
+		t.Errorf("Burst without Visual Studio: %s", problems(issues))
+	}
+
+	mac := ToolchainTarget{Target: "StandaloneOSX", IL2CPP: true}
+	if issues := checkToolchain(mac, editor, fakeToolchainHost("darwin", nil, nil)); len(issues) != 1 || !strings.Contains(issues[0].Remedy, "xcode-select --install") {
+		t.Errorf("without Xcode CLT: %s", problems(issues))
+	}
+
+	issues = checkToolchain(ToolchainTarget{Target: "iOS", IL2CPP: true}, editor, fakeToolchainHost("windows", nil, nil))
+	if len(issues) != 1 || !issues[0].Warning {
+		t.Errorf("iOS on Windows: %+v", issues)
+	}
+}
+
+func TestNewToolchainTarget(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"ProjectSettings/ProjectSettings.asset": "PlayerSettings:\n  scriptingBackend:\n    Android: 1\n",
+		"Packages/manifest.json":                `{"dependencies": {"com.unity.entities": "1.0.16"}}`,
+		"Packages/packages-lock.json":           `{"dependencies": {"com.unity.entities": {"version": "1.0.16"}, "com.unity.burst": {"version": "1.8.8"}}}`,
+	})
+
+	android := NewToolchainTarget(root, "android", "2022.3.10f1")
+	if !android.IL2CPP || !android.Burst || android.LinuxToolchain {
+		t.Errorf("Android = %+v", android)
+	}
+	if win := NewToolchainTarget(root, "Win64", "2022.3.10f1"); win.IL2CPP {
+		t.Errorf("Win64 = %+v, want Mono", win)
+	}
+}
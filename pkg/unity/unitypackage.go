@@ -0,0 +1,182 @@
+package unity
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// UnityPackageExt is the extension of asset packages exported by Unity
+const UnityPackageExt = ".unitypackage"
+
+// Unity command line arguments for asset packages
+const (
+	argExportPackage = "-exportPackage"
+	argImportPackage = "-importPackage"
+)
+
+// ExportPackageConfig holds configuration for exporting a .unitypackage
+type ExportPackageConfig struct {
+	Paths  []string // Asset folders or files, relative to the project (e.g. Assets/MyLib)
+	Output string   // .unitypackage to write
+
+	LogFile        string
+	TimeoutSeconds int
+	CIMode         bool
+	ShowTimestamp  bool
+}
+
+// ImportPackageConfig holds configuration for importing a .unitypackage
+type ImportPackageConfig struct {
+	Package string // .unitypackage to import
+
+	LogFile        string
+	TimeoutSeconds int
+	CIMode         bool
+	ShowTimestamp  bool
+}
+
+// ValidateExportPaths checks that the paths to export exist below Assets or
+// Packages of the project and returns them slash-separated, as Unity expects
+func ValidateExportPaths(projectPath string, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, errs.New(errs.Usage, "no paths to export")
+	}
+	var result []string
+	for _, p := range paths {
+		rel := path.Clean(filepath.ToSlash(p))
+		root := strings.SplitN(rel, "/", 2)[0]
+		if filepath.IsAbs(p) || (root != "Assets" && root != "Packages") {
+			return nil, errs.New(errs.Usage, "cannot export %s: paths must be relative to the project and start with Assets/ or Packages/", p)
+		}
+		if !fileExists(filepath.Join(projectPath, filepath.FromSlash(rel))) {
+			return nil, errs.WithHint(
+				errs.New(errs.NotFound, "cannot export %s: not found in %s", p, projectPath),
+				"Paths are relative to the project folder, e.g. Assets/MyLib.")
+		}
+		result = append(result, rel)
+	}
+	return result, nil
+}
+
+// ExportPackage exports asset folders or files of the project into a
+// .unitypackage in batch mode, streaming the log like Run. Returns the asset
+// paths of the written package.
+func (r *Runner) ExportPackage(config ExportPackageConfig) ([]string, error) {
+	paths, err := ValidateExportPaths(r.project.Path, config.Paths)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(filepath.Ext(config.Output), UnityPackageExt) {
+		return nil, errs.New(errs.Usage, "output must be a %s file: %s", UnityPackageExt, config.Output)
+	}
+	output, err := filepath.Abs(config.Output)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return nil, err
+	}
+	// An old package left in place would hide a failed export
+	if err := os.Remove(output); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	args := append([]string{argExportPackage}, paths...)
+	args = append(args, output)
+	err = r.Run(RunConfig{
+		ProjectPath:    r.project.Path,
+		ExtraArgs:      args,
+		LogFile:        config.LogFile,
+		TimeoutSeconds: config.TimeoutSeconds,
+		CIMode:         config.CIMode,
+		ShowTimestamp:  config.ShowTimestamp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !fileExists(output) {
+		return nil, fmt.Errorf("unity exited without writing %s; check the log for export errors", output)
+	}
+	return ReadUnityPackage(output)
+}
+
+// ImportPackage imports a .unitypackage into the project in batch mode,
+// streaming the log like Run. Returns the asset paths of the package.
+func (r *Runner) ImportPackage(config ImportPackageConfig) ([]string, error) {
+	pkg, err := filepath.Abs(config.Package)
+	if err != nil {
+		return nil, err
+	}
+	assets, err := ReadUnityPackage(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.Run(RunConfig{
+		ProjectPath:    r.project.Path,
+		ExtraArgs:      []string{argImportPackage, pkg},
+		LogFile:        config.LogFile,
+		TimeoutSeconds: config.TimeoutSeconds,
+		CIMode:         config.CIMode,
+		ShowTimestamp:  config.ShowTimestamp,
+	})
+	return assets, err
+}
+
+// ReadUnityPackage returns the asset paths stored in a .unitypackage, sorted.
+// The package is a gzipped tar with a folder per asset GUID holding the asset,
+// its .meta and a "pathname" file with the asset's path in the project.
+func ReadUnityPackage(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errs.New(errs.NotFound, "package not found: %s", file)
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("not a .unitypackage: %s: %w", file, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var assets []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if path.Base(strings.TrimPrefix(header.Name, "./")) != "pathname" {
+			continue
+		}
+		// The path is the first line; older versions append a line with "00"
+		line, err := bufio.NewReader(tr).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if asset := strings.TrimSpace(line); asset != "" {
+			assets = append(assets, asset)
+		}
+	}
+	if len(assets) == 0 {
+		return nil, errs.New(errs.Usage, "not a .unitypackage: %s contains no assets", file)
+	}
+	sort.Strings(assets)
+	return assets, nil
+}
@@ -0,0 +1,192 @@
+package unity
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// writeTestUnityPackage writes a .unitypackage holding the given asset paths
+func writeTestUnityPackage(t *testing.T, file string, assets ...string) {
+	t.Helper()
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for i, asset := range assets {
+		guid := strings.Repeat(string(rune('a'+i)), 32)
+		for name, content := range map[string]string{
+			"asset":      "content",
+			"asset.meta": "guid: " + guid,
+			"pathname":   asset + "\n00",
+		} {
+			if err := tw.WriteHeader(&tar.Header{Name: "./" + guid + "/" + name, Mode: 0644, Size: int64(len(content))}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateExportPaths(t *testing.T) {
+	projectPath := t.TempDir()
+	writeTestFiles(t, projectPath, map[string]string{
+		"Assets/MyLib/Tool.cs":               "",
+		"Packages/com.company.tool/Tool.cs":  "",
+		"ProjectSettings/ProjectVersion.txt": "",
+	})
+
+	got, err := ValidateExportPaths(projectPath, []string{"Assets/MyLib/", filepath.Join("Packages", "com.company.tool")})
+	if err != nil {
+		t.Fatalf("ValidateExportPaths() error = %v", err)
+	}
+	if strings.Join(got, ",") != "Assets/MyLib,Packages/com.company.tool" {
+		t.Errorf("ValidateExportPaths() = %v", got)
+	}
+
+	tests := map[string]*errs.Category{
+		"Assets/Missing":    errs.NotFound,
+		"ProjectSettings":   errs.Usage,
+		"Assets/../Library": errs.Usage,
+		"../Other/Assets/A": errs.Usage,
+		filepath.Join(projectPath, "Assets", "MyLib"): errs.Usage,
+	}
+	for p, want := range tests {
+		if _, err := ValidateExportPaths(projectPath, []string{p}); errs.CategoryOf(err) != want {
+			t.Errorf("ValidateExportPaths(%q) error = %v, want %s", p, err, want)
+		}
+	}
+	if _, err := ValidateExportPaths(projectPath, nil); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("ValidateExportPaths(nil) error = %v", err)
+	}
+}
+
+func TestReadUnityPackage(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "MyLib.unitypackage")
+	writeTestUnityPackage(t, file, "Assets/MyLib/Tool.cs", "Assets/MyLib")
+
+	assets, err := ReadUnityPackage(file)
+	if err != nil {
+		t.Fatalf("ReadUnityPackage() error = %v", err)
+	}
+	if strings.Join(assets, ",") != "Assets/MyLib,Assets/MyLib/Tool.cs" {
+		t.Errorf("ReadUnityPackage() = %v", assets)
+	}
+
+	notPackage := filepath.Join(dir, "notes.unitypackage")
+	if err := os.WriteFile(notPackage, []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadUnityPackage(notPackage); err == nil {
+		t.Error("ReadUnityPackage() of a text file should fail")
+	}
+	if _, err := ReadUnityPackage(filepath.Join(dir, "missing.unitypackage")); errs.CategoryOf(err) != errs.NotFound {
+		t.Errorf("ReadUnityPackage() of a missing file error = %v", err)
+	}
+}
+
+// newUnityPackageTestRunner returns a runner whose editor is a script that
+// records its arguments and copies fixture to the last argument
+func newUnityPackageTestRunner(t *testing.T, fixture string) (*Runner, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	editor := filepath.Join(dir, "Unity")
+	script := `#!/bin/sh
+echo "$@" > "` + argsFile + `"
+for last; do :; done
+case "$last" in *.unitypackage) [ -f "$last" ] || cp "` + fixture + `" "$last" ;; esac
+echo "Exiting batchmode successfully now!"
+`
+	if err := os.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	projectPath := filepath.Join(dir, "MyGame")
+	writeTestFiles(t, projectPath, map[string]string{"Assets/MyLib/Tool.cs": ""})
+	project := &Project{Path: projectPath, UnityVersion: "2022.3.10f1", Name: "MyGame"}
+	return &Runner{project: project, editor: &Editor{Version: project.UnityVersion, Path: editor}}, argsFile
+}
+
+func TestExportPackage(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "fixture.unitypackage")
+	writeTestUnityPackage(t, fixture, "Assets/MyLib", "Assets/MyLib/Tool.cs")
+	runner, argsFile := newUnityPackageTestRunner(t, fixture)
+
+	output := filepath.Join(t.TempDir(), "dist", "MyLib.unitypackage")
+	assets, err := runner.ExportPackage(ExportPackageConfig{Paths: []string{"Assets/MyLib"}, Output: output})
+	if err != nil {
+		t.Fatalf("ExportPackage() error = %v", err)
+	}
+	if len(assets) != 2 {
+		t.Errorf("ExportPackage() = %v", assets)
+	}
+	args, _ := os.ReadFile(argsFile)
+	if !strings.Contains(string(args), "-batchmode") || !strings.Contains(string(args), "-exportPackage Assets/MyLib "+output) {
+		t.Errorf("editor args = %s", args)
+	}
+
+	if _, err := runner.ExportPackage(ExportPackageConfig{Paths: []string{"Assets/MyLib"}, Output: "MyLib.zip"}); errs.CategoryOf(err) != errs.Usage {
+		t.Errorf("ExportPackage() to a .zip error = %v", err)
+	}
+}
+
+func TestExportPackageNotWritten(t *testing.T) {
+	// The fixture does not exist, so the editor writes nothing
+	runner, _ := newUnityPackageTestRunner(t, filepath.Join(t.TempDir(), "missing"))
+	_, err := runner.ExportPackage(ExportPackageConfig{Paths: []string{"Assets/MyLib"}, Output: filepath.Join(t.TempDir(), "MyLib.unitypackage")})
+	if err == nil || !strings.Contains(err.Error(), "without writing") {
+		t.Errorf("ExportPackage() error = %v", err)
+	}
+}
+
+func TestImportPackage(t *testing.T) {
+	pkg := filepath.Join(t.TempDir(), "Tool.unitypackage")
+	writeTestUnityPackage(t, pkg, "Assets/Tool/Tool.cs")
+	runner, argsFile := newUnityPackageTestRunner(t, pkg)
+
+	assets, err := runner.ImportPackage(ImportPackageConfig{Package: pkg})
+	if err != nil {
+		t.Fatalf("ImportPackage() error = %v", err)
+	}
+	if strings.Join(assets, ",") != "Assets/Tool/Tool.cs" {
+		t.Errorf("ImportPackage() = %v", assets)
+	}
+	args, _ := os.ReadFile(argsFile)
+	if !strings.Contains(string(args), "-importPackage "+pkg) {
+		t.Errorf("editor args = %s", args)
+	}
+
+	// Unity is not started for a file that is not a package
+	if err := os.Remove(argsFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.ImportPackage(ImportPackageConfig{Package: filepath.Join(t.TempDir(), "missing.unitypackage")}); err == nil {
+		t.Error("ImportPackage() of a missing file should fail")
+	}
+	if fileExists(argsFile) {
+		t.Error("the editor should not run for a missing package")
+	}
+}
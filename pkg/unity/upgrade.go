@@ -0,0 +1,342 @@
+package unity
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/hub"
+	"github.com/neptaco/uniforge/pkg/semver"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Package statuses of an upgrade report
+const (
+	PackageUnchanged       = "unchanged"        // Keeps its version on the target editor
+	PackageUpgraded        = "upgraded"         // The target editor moves it to another version
+	PackageUpdateAvailable = "update-available" // A newer version supports the target editor
+	PackageIncompatible    = "incompatible"     // The version requires a newer editor than the target
+	PackageLocal           = "local"            // Embedded, file:, git or tarball dependency
+	PackageUnknown         = "unknown"          // Not found in the registry
+)
+
+// maxAPILocations caps the locations listed per obsolete API
+const maxAPILocations = 5
+
+// registryConcurrency limits parallel registry requests
+const registryConcurrency = 6
+
+// PackageChange describes what happens to a package dependency on the target editor
+type PackageChange struct {
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Target  string `json:"target,omitempty"` // Version used on the target editor, if it differs
+	Status  string `json:"status"`
+	Note    string `json:"note,omitempty"`
+}
+
+// APIUsage is an obsolete Unity API used by the project's scripts
+type APIUsage struct {
+	Symbol      string   `json:"symbol"`
+	Since       string   `json:"since"`
+	Removed     bool     `json:"removed"` // Removed rather than only deprecated
+	Replacement string   `json:"replacement,omitempty"`
+	Count       int      `json:"count"`
+	Locations   []string `json:"locations"` // At most maxAPILocations "path:line" entries
+}
+
+// UpgradeReport lists what changes when a project moves to another editor version
+type UpgradeReport struct {
+	Project     string          `json:"project"`
+	FromVersion string          `json:"fromVersion"`
+	ToVersion   string          `json:"toVersion"`
+	Packages    []PackageChange `json:"packages"`
+	APIs        []APIUsage      `json:"apis"`
+	Warnings    []string        `json:"warnings,omitempty"`
+}
+
+// UpgradeReportOptions configures BuildUpgradeReport
+type UpgradeReportOptions struct {
+	// EditorPackages are the package versions of the target editor, from
+	// EditorPackageVersions. Nil when the target editor is not installed.
+	EditorPackages map[string]string
+	// Offline skips the package registry
+	Offline bool
+}
+
+// obsoleteAPI is an API Unity deprecated or removed in a given version
+type obsoleteAPI struct {
+	symbol      string
+	pattern     *regexp.Regexp
+	since       string
+	removed     bool
+	replacement string
+}
+
+// obsoleteAPIs lists well-known APIs that break or warn after an upgrade
+var obsoleteAPIs = []obsoleteAPI{
+	{"Application.LoadLevel", regexp.MustCompile(`\bApplication\.LoadLevel(Async|Additive|AdditiveAsync)?\s*\(`), "5.3", false, "SceneManager.LoadScene"},
+	{"EditorApplication.playmodeStateChanged", regexp.MustCompile(`\bEditorApplication\.playmodeStateChanged\b`), "2017.2", false, "EditorApplication.playModeStateChanged"},
+	{"WWW", regexp.MustCompile(`\bnew\s+WWW\s*\(`), "2018.3", false, "UnityWebRequest"},
+	{"UnityEngine.Experimental.UIElements", regexp.MustCompile(`\bUnity(Engine|Editor)\.Experimental\.UIElements\b`), "2019.1", true, "UnityEngine.UIElements"},
+	{"GUIText", regexp.MustCompile(`\bGUIText\b`), "2019.2", true, "UI Text or TextMeshPro"},
+	{"GUITexture", regexp.MustCompile(`\bGUITexture\b`), "2019.2", true, "UI Image"},
+	{"PlayerSettings.virtualRealitySupported", regexp.MustCompile(`\bPlayerSettings\.virtualRealitySupported\b`), "2019.3", false, "XR Plug-in Management"},
+	{"UnityWebRequest.isNetworkError", regexp.MustCompile(`\.isNetworkError\b`), "2020.2", false, "UnityWebRequest.result"},
+	{"UnityWebRequest.isHttpError", regexp.MustCompile(`\.isHttpError\b`), "2020.2", false, "UnityWebRequest.result"},
+	{"Physics.autoSimulation", regexp.MustCompile(`\bPhysics\.autoSimulation\b`), "2022.2", false, "Physics.simulationMode"},
+	{"Object.FindObjectsOfType", regexp.MustCompile(`\bFindObjectsOfType\b`), "2023.1", false, "FindObjectsByType"},
+	{"Object.FindObjectOfType", regexp.MustCompile(`\bFindObjectOfType\b`), "2023.1", false, "FindFirstObjectByType or FindAnyObjectByType"},
+	{"PlayerSettings.GetScriptingDefineSymbolsForGroup", regexp.MustCompile(`\bPlayerSettings\.[GS]etScriptingDefineSymbolsForGroup\b`), "2023.1", false, "PlayerSettings.Get/SetScriptingDefineSymbols with NamedBuildTarget"},
+	{"PhysicMaterial", regexp.MustCompile(`\bPhysicMaterial\b`), "6000.0", false, "PhysicsMaterial"},
+	{"Rigidbody.angularDrag", regexp.MustCompile(`\.angularDrag\b`), "6000.0", false, "Rigidbody.angularDamping"},
+}
+
+// BuildUpgradeReport compares the project against the target editor version: the
+// package versions the editor ships with, the registry's compatibility data and
+// the obsolete APIs between the two versions
+func BuildUpgradeReport(project *Project, target string, opts UpgradeReportOptions) (*UpgradeReport, error) {
+	manifest, err := LoadPackageManifest(project.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UpgradeReport{
+		Project:     project.Path,
+		FromVersion: project.UnityVersion,
+		ToVersion:   target,
+	}
+	if opts.EditorPackages == nil {
+		report.Warnings = append(report.Warnings, "Target editor is not installed; versions it moves packages to are unknown")
+	}
+
+	registry := map[string]*RegistryPackage{}
+	offline := opts.Offline
+	if !offline {
+		registry, err = fetchRegistryPackages(manifest.Dependencies)
+		if err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+			offline = true
+		}
+	}
+
+	names := make([]string, 0, len(manifest.Dependencies))
+	for name := range manifest.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		report.Packages = append(report.Packages, comparePackage(name, manifest.Dependencies[name], target, opts.EditorPackages, registry[name], offline))
+	}
+
+	apis, err := scanObsoleteAPIs(project.Path, project.UnityVersion, target)
+	if err != nil {
+		return nil, err
+	}
+	report.APIs = apis
+	return report, nil
+}
+
+// comparePackage decides what happens to one dependency on the target editor
+func comparePackage(name, current, target string, editorPackages map[string]string, registry *RegistryPackage, offline bool) PackageChange {
+	change := PackageChange{Name: name, Current: current, Status: PackageUnchanged}
+	if !IsRegistryVersion(current) {
+		change.Status = PackageLocal
+		return change
+	}
+
+	// The editor moves packages it ships or pins to at least its own version
+	if v, ok := editorPackages[name]; ok && semver.Compare(v, current) > 0 {
+		change.Status = PackageUpgraded
+		change.Target = v
+		change.Note = "upgraded by the editor"
+		return change
+	}
+
+	if registry == nil {
+		if !offline && strings.HasPrefix(name, "com.unity.") && !strings.HasPrefix(name, "com.unity.modules.") {
+			change.Status = PackageUnknown
+			change.Note = "not found in the registry"
+		}
+		return change
+	}
+
+	if v, ok := registry.Versions[current]; ok {
+		if v.Deprecated != "" {
+			change.Note = "deprecated: " + v.Deprecated
+		}
+		if minimum := v.MinimumUnity(); minimum != "" && !unityAtLeast(target, minimum) {
+			change.Status = PackageIncompatible
+			change.Note = fmt.Sprintf("requires Unity %s", minimum)
+			if latest := latestCompatibleVersion(registry, target); latest != "" {
+				change.Target = latest
+				change.Note += fmt.Sprintf(", use %s", latest)
+			}
+			return change
+		}
+	}
+
+	if latest := latestCompatibleVersion(registry, target); latest != "" && semver.Compare(latest, current) > 0 {
+		change.Status = PackageUpdateAvailable
+		change.Target = latest
+		if change.Note == "" {
+			change.Note = "newer version supports the target editor"
+		}
+	}
+	return change
+}
+
+// latestCompatibleVersion returns the newest released version that supports the
+// target editor, or ""
+func latestCompatibleVersion(pkg *RegistryPackage, target string) string {
+	latest := ""
+	for version, v := range pkg.Versions {
+		if isPrerelease(version) || v.Deprecated != "" {
+			continue
+		}
+		if minimum := v.MinimumUnity(); minimum != "" && !unityAtLeast(target, minimum) {
+			continue
+		}
+		if latest == "" || semver.Compare(version, latest) > 0 {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// unityAtLeast reports whether version is minimum or newer. A minimum without a
+// patch release such as "2021.3" covers every release of that stream.
+func unityAtLeast(version, minimum string) bool {
+	if strings.Count(minimum, ".") < 2 {
+		minimum += ".0a0"
+	}
+	return hub.CompareVersions(version, minimum) >= 0
+}
+
+// fetchRegistryPackages fetches registry metadata of the Unity packages among
+// dependencies. Built-in modules are skipped since they have no registry entry.
+// A network failure is returned along with whatever was fetched.
+func fetchRegistryPackages(dependencies map[string]string) (map[string]*RegistryPackage, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		packages = make(map[string]*RegistryPackage)
+		netErr   error
+		sem      = make(chan struct{}, registryConcurrency)
+	)
+	for name, version := range dependencies {
+		if !strings.HasPrefix(name, "com.unity.") || strings.HasPrefix(name, "com.unity.modules.") || !IsRegistryVersion(version) {
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			pkg, err := FetchRegistryPackage(name)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				packages[name] = pkg
+			case errors.Is(err, errs.NetworkUnavailable):
+				netErr = err
+			default:
+				ui.Debug("Registry lookup failed", "package", name, "error", err)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	if netErr != nil {
+		return packages, errs.WithHint(
+			errs.New(errs.NetworkUnavailable, "package registry unavailable, compatibility not checked: %w", netErr),
+			"Use --offline to skip the registry.")
+	}
+	return packages, nil
+}
+
+// scanObsoleteAPIs greps the project's scripts for APIs deprecated or removed
+// after from, up to and including target
+func scanObsoleteAPIs(projectPath, from, target string) ([]APIUsage, error) {
+	var apis []obsoleteAPI
+	for _, api := range obsoleteAPIs {
+		if (from == "" || !unityAtLeast(from, api.since)) && unityAtLeast(target, api.since) {
+			apis = append(apis, api)
+		}
+	}
+	if len(apis) == 0 {
+		return nil, nil
+	}
+
+	usages := make([]APIUsage, len(apis))
+	for i, api := range apis {
+		usages[i] = APIUsage{Symbol: api.symbol, Since: api.since, Removed: api.removed, Replacement: api.replacement}
+	}
+
+	// Embedded packages are part of the project; registry packages are not
+	for _, dir := range []string{"Assets", "Packages"} {
+		root := filepath.Join(projectPath, dir)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".cs") {
+				return nil
+			}
+			rel, _ := filepath.Rel(projectPath, path)
+			return scanScript(path, filepath.ToSlash(rel), apis, usages)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var found []APIUsage
+	for _, usage := range usages {
+		if usage.Count > 0 {
+			found = append(found, usage)
+		}
+	}
+	return found, nil
+}
+
+// scanScript records the obsolete APIs used in one script
+func scanScript(path, rel string, apis []obsoleteAPI, usages []APIUsage) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if trimmed := strings.TrimSpace(text); strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		for i, api := range apis {
+			if !api.pattern.MatchString(text) {
+				continue
+			}
+			usages[i].Count++
+			if len(usages[i].Locations) < maxAPILocations {
+				usages[i].Locations = append(usages[i].Locations, fmt.Sprintf("%s:%d", rel, line))
+			}
+		}
+	}
+	return scanner.Err()
+}
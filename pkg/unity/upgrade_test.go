@@ -0,0 +1,198 @@
+package unity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEditorPackageVersions(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"Editor/manifest.json": `{
+			"com.unity.timeline": "1.7.6",
+			"dependencies": {"com.unity.test-framework": {"version": "1.3.9"}},
+			"packages": {"com.unity.ugui": {"defaultVersion": "1.0.0"}}
+		}`,
+		"BuiltInPackages/com.unity.ugui/package.json": `{"name": "com.unity.ugui", "version": "2.0.0"}`,
+	})
+
+	got := EditorPackageVersions(dir)
+	want := map[string]string{
+		"com.unity.timeline":       "1.7.6",
+		"com.unity.test-framework": "1.3.9",
+		"com.unity.ugui":           "2.0.0",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("EditorPackageVersions() = %v, want %v", got, want)
+	}
+	for name, version := range want {
+		if got[name] != version {
+			t.Errorf("%s = %q, want %q", name, got[name], version)
+		}
+	}
+}
+
+func TestPackageManagerDirFor(t *testing.T) {
+	tests := []struct {
+		goos       string
+		editorPath string
+		want       string
+	}{
+		{"darwin", "/Apps/6000.0.1f1/Unity.app/Contents/MacOS/Unity", "/Apps/6000.0.1f1/Unity.app/Contents/Resources/PackageManager"},
+		{"linux", "/opt/6000.0.1f1/Editor/Unity", "/opt/6000.0.1f1/Editor/Data/Resources/PackageManager"},
+		{"windows", "C:/Unity/6000.0.1f1/Editor/Unity.exe", "C:/Unity/6000.0.1f1/Editor/Data/Resources/PackageManager"},
+	}
+	for _, tt := range tests {
+		got := filepath.ToSlash(packageManagerDirFor(tt.goos, filepath.FromSlash(tt.editorPath)))
+		if got != tt.want {
+			t.Errorf("packageManagerDirFor(%s) = %q, want %q", tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestUnityAtLeast(t *testing.T) {
+	tests := []struct {
+		version, minimum string
+		want             bool
+	}{
+		{"2022.3.1f1", "2022.3", true},
+		{"2022.2.20f1", "2022.3", false},
+		{"2022.3.9f1", "2022.3.10f1", false},
+		{"6000.0.23f1", "2023.1", true},
+	}
+	for _, tt := range tests {
+		if got := unityAtLeast(tt.version, tt.minimum); got != tt.want {
+			t.Errorf("unityAtLeast(%q, %q) = %v, want %v", tt.version, tt.minimum, got, tt.want)
+		}
+	}
+}
+
+func TestBuildUpgradeReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/com.unity.inputsystem":
+			_, _ = w.Write([]byte(`{"name": "com.unity.inputsystem", "versions": {
+				"1.5.0": {"unity": "2019.4"},
+				"1.7.0": {"unity": "2019.4"},
+				"1.8.0-pre.1": {"unity": "2019.4"},
+				"1.11.0": {"unity": "6000.1"}
+			}}`))
+		case "/com.unity.cinemachine":
+			_, _ = w.Write([]byte(`{"name": "com.unity.cinemachine", "versions": {
+				"3.1.0": {"unity": "2022.3", "unityRelease": "40f1"}
+			}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	oldURL := packageRegistryURL
+	packageRegistryURL = server.URL
+	defer func() { packageRegistryURL = oldURL }()
+
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"Packages/manifest.json": `{"dependencies": {
+			"com.unity.inputsystem": "1.5.0",
+			"com.unity.cinemachine": "3.1.0",
+			"com.unity.ugui": "1.0.0",
+			"com.unity.gone": "1.0.0",
+			"com.unity.modules.audio": "1.0.0",
+			"com.example.tool": "file:../tool"
+		}}`,
+	})
+	project := &Project{Path: root, UnityVersion: "2021.3.10f1", Name: "Game"}
+
+	report, err := BuildUpgradeReport(project, "6000.0.23f1", UpgradeReportOptions{
+		EditorPackages: map[string]string{"com.unity.ugui": "2.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("BuildUpgradeReport() error = %v", err)
+	}
+
+	status := map[string]PackageChange{}
+	for _, p := range report.Packages {
+		status[p.Name] = p
+	}
+	wantStatus := map[string]string{
+		"com.unity.inputsystem":   PackageUpdateAvailable,
+		"com.unity.cinemachine":   PackageUnchanged,
+		"com.unity.ugui":          PackageUpgraded,
+		"com.unity.gone":          PackageUnknown,
+		"com.unity.modules.audio": PackageUnchanged,
+		"com.example.tool":        PackageLocal,
+	}
+	for name, want := range wantStatus {
+		if got := status[name].Status; got != want {
+			t.Errorf("%s status = %q, want %q", name, got, want)
+		}
+	}
+	if got := status["com.unity.inputsystem"].Target; got != "1.7.0" {
+		t.Errorf("inputsystem target = %q, want 1.7.0", got)
+	}
+
+	// Downgrading below the minimum of cinemachine 3.1.0
+	report, err = BuildUpgradeReport(project, "2022.3.20f1", UpgradeReportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range report.Packages {
+		if p.Name == "com.unity.cinemachine" && p.Status != PackageIncompatible {
+			t.Errorf("cinemachine status = %q, want incompatible", p.Status)
+		}
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "not installed") {
+		t.Errorf("warnings = %v", report.Warnings)
+	}
+}
+
+func TestScanObsoleteAPIs(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"Packages/manifest.json":                       `{"dependencies": {}}`,
+		"Assets/Scripts/Enemy.cs":                      "var players = FindObjectsOfType<Player>();\n// FindObjectOfType in a comment\nvar p = FindObjectOfType<Player>();\n",
+		"Assets/Scripts/Old.cs":                        "GUIText label;\n",
+		"Packages/com.example.embedded/Runtime/Net.cs": "if (req.isNetworkError) {}\n",
+	})
+
+	apis, err := scanObsoleteAPIs(root, "2021.3.10f1", "6000.0.23f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var symbols []string
+	for _, api := range apis {
+		symbols = append(symbols, api.Symbol)
+	}
+	// GUIText was removed before 2021.3, isNetworkError too
+	if got, want := strings.Join(symbols, ","), "Object.FindObjectsOfType,Object.FindObjectOfType"; got != want {
+		t.Errorf("symbols = %s, want %s", got, want)
+	}
+	if len(apis) == 2 && (apis[1].Count != 1 || apis[1].Locations[0] != "Assets/Scripts/Enemy.cs:3") {
+		t.Errorf("FindObjectOfType = %+v", apis[1])
+	}
+
+	apis, err = scanObsoleteAPIs(root, "2019.1.0f1", "2021.3.10f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apis) != 2 || apis[0].Symbol != "GUIText" || apis[1].Locations[0] != "Packages/com.example.embedded/Runtime/Net.cs:1" {
+		t.Errorf("apis = %+v", apis)
+	}
+}
@@ -0,0 +1,151 @@
+package unity
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/fsutil"
+)
+
+// upmConfigEnv overrides the location of the user's .upmconfig.toml, as in Unity
+const upmConfigEnv = "UPM_USER_CONFIG_FILE"
+
+// upmAuthSection matches the header of a registry's credentials, e.g.
+// [npmAuth."https://npm.example.com"]
+var upmAuthSection = regexp.MustCompile(`^\s*\[\s*npmAuth\s*\.\s*"([^"]+)"\s*\]\s*(#.*)?$`)
+
+// UPMAuth is the credentials of a scoped registry in .upmconfig.toml
+type UPMAuth struct {
+	Token      string
+	Email      string
+	AlwaysAuth bool
+}
+
+// UPMConfigPath returns the user's .upmconfig.toml: $UPM_USER_CONFIG_FILE, or
+// .upmconfig.toml in the home folder (%USERPROFILE% on Windows)
+func UPMConfigPath() (string, error) {
+	if path := os.Getenv(upmConfigEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the home directory: %w", err)
+	}
+	return filepath.Join(home, ".upmconfig.toml"), nil
+}
+
+// LoadUPMAuth returns the registries with credentials in the config at path,
+// by URL. A missing file has none.
+func LoadUPMAuth(path string) (map[string]UPMAuth, error) {
+	auths := make(map[string]UPMAuth)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return auths, nil
+		}
+		return nil, err
+	}
+
+	current := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			current = ""
+			if match := upmAuthSection.FindStringSubmatch(trimmed); match != nil {
+				current = match[1]
+				auths[current] = UPMAuth{}
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			continue
+		}
+		auth := auths[current]
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "token", "_auth":
+			auth.Token = unquoteTOML(value)
+		case "email":
+			auth.Email = unquoteTOML(value)
+		case "alwaysAuth":
+			auth.AlwaysAuth = value == "true"
+		}
+		auths[current] = auth
+	}
+	return auths, nil
+}
+
+// SetUPMAuth stores the credentials of the registry at url in the config at
+// path, replacing earlier credentials of the registry and keeping everything else
+func SetUPMAuth(path, url string, auth UPMAuth) error {
+	lines, err := upmConfigLinesWithout(path, url)
+	if err != nil {
+		return err
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, fmt.Sprintf("[npmAuth.%s]", strconv.Quote(url)))
+	lines = append(lines, "token = "+strconv.Quote(auth.Token))
+	if auth.Email != "" {
+		lines = append(lines, "email = "+strconv.Quote(auth.Email))
+	}
+	lines = append(lines, "alwaysAuth = "+strconv.FormatBool(auth.AlwaysAuth))
+
+	// The file holds secrets
+	return fsutil.WriteFileAtomic(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
+
+// upmConfigLinesWithout returns the lines of the config at path without the
+// section of the registry at url
+func upmConfigLinesWithout(path, url string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var lines []string
+	skipping := false
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			match := upmAuthSection.FindStringSubmatch(trimmed)
+			skipping = match != nil && match[1] == url
+		}
+		if !skipping {
+			lines = append(lines, strings.TrimSuffix(line, "\r"))
+		}
+	}
+	return lines, nil
+}
+
+// unquoteTOML returns the value of a TOML string, dropping a trailing comment
+func unquoteTOML(value string) string {
+	if strings.HasPrefix(value, `"`) {
+		if s, err := strconv.QuotedPrefix(value); err == nil {
+			if unquoted, err := strconv.Unquote(s); err == nil {
+				return unquoted
+			}
+		}
+	}
+	if strings.HasPrefix(value, "'") {
+		if end := strings.Index(value[1:], "'"); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	value, _, _ = strings.Cut(value, "#")
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,74 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUPMConfigPath(t *testing.T) {
+	t.Setenv(upmConfigEnv, "/etc/upm/custom.toml")
+	if got, _ := UPMConfigPath(); got != "/etc/upm/custom.toml" {
+		t.Errorf("UPMConfigPath() = %q", got)
+	}
+	t.Setenv(upmConfigEnv, "")
+	if got, _ := UPMConfigPath(); filepath.Base(got) != ".upmconfig.toml" {
+		t.Errorf("UPMConfigPath() = %q", got)
+	}
+}
+
+func TestSetUPMAuth(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".upmconfig.toml")
+	existing := `# Company registries
+[npmAuth."https://npm.example.com"]
+token = "old"
+alwaysAuth = true
+
+[npmAuth."https://other.example.com"]
+_auth = 'b64'  # basic auth
+email = "ci@example.com"
+`
+	if err := os.WriteFile(path, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetUPMAuth(path, "https://npm.example.com", UPMAuth{Token: "new", AlwaysAuth: true}); err != nil {
+		t.Fatalf("SetUPMAuth() error = %v", err)
+	}
+	if err := SetUPMAuth(path, "https://third.example.com", UPMAuth{Token: "t3", Email: "me@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	auths, err := LoadUPMAuth(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auths) != 3 {
+		t.Fatalf("auths = %+v", auths)
+	}
+	if a := auths["https://npm.example.com"]; a.Token != "new" || !a.AlwaysAuth {
+		t.Errorf("npm.example.com = %+v", a)
+	}
+	if a := auths["https://other.example.com"]; a.Token != "b64" || a.Email != "ci@example.com" {
+		t.Errorf("other.example.com = %+v", a)
+	}
+	if a := auths["https://third.example.com"]; a.Token != "t3" || a.Email != "me@example.com" || a.AlwaysAuth {
+		t.Errorf("third.example.com = %+v", a)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# Company registries\n") || strings.Count(string(data), "npm.example.com") != 1 {
+		t.Errorf("config:\n%s", data)
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0077 != 0 && os.PathSeparator == '/' {
+		t.Errorf("config mode = %v, want private", info.Mode().Perm())
+	}
+}
+
+func TestLoadUPMAuthMissing(t *testing.T) {
+	auths, err := LoadUPMAuth(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil || len(auths) != 0 {
+		t.Errorf("LoadUPMAuth() = %v, %v", auths, err)
+	}
+}
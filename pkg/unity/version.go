@@ -0,0 +1,13 @@
+package unity
+
+import "github.com/neptaco/uniforge/pkg/hub"
+
+// Version is a parsed Unity version such as "2022.3.60f1" or "2021.3.14f1c1".
+// It is defined in pkg/hub, which compares release versions and cannot import
+// this package.
+type Version = hub.Version
+
+// ParseVersion parses a full Unity version
+func ParseVersion(s string) (Version, error) {
+	return hub.ParseVersion(s)
+}
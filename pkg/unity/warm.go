@@ -0,0 +1,149 @@
+package unity
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/trace"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// WarmConfig holds configuration for a Library warm-up
+type WarmConfig struct {
+	ProjectPath            string
+	BuildTarget            string // Import for this target (-buildTarget); empty for the project's active target
+	DisableAssemblyUpdater bool   // Skip the API updater for precompiled assemblies
+	LogFile                string // Save the full log here
+	TimeoutSeconds         int
+}
+
+// WarmResult describes a finished warm-up
+type WarmResult struct {
+	Imported int // Distinct assets Unity imported
+	Duration time.Duration
+	LogTail  []string // Last lines of the log, for reporting failures
+}
+
+// importPattern matches "Start importing Assets/Foo.png using Guid(...)"
+var importPattern = regexp.MustCompile(`Start importing (.+?) using Guid\(`)
+
+// ParseImportLine returns the asset path of a Unity import log line
+func ParseImportLine(line string) (string, bool) {
+	m := importPattern.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// Warm opens the project in batch mode and quits once Unity has imported its
+// assets, filling the Library folder. The log is not shown; progress is called
+// with the number of assets imported so far.
+func (r *Runner) Warm(config WarmConfig, progress func(imported int)) (*WarmResult, error) {
+	editorPath, err := r.editor.GetPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Unity Editor path: %w", err)
+	}
+
+	absProjectPath, err := filepath.Abs(config.ProjectPath)
+	if err != nil {
+		absProjectPath = config.ProjectPath
+	}
+
+	var extra []string
+	if config.BuildTarget != "" {
+		extra = append(extra, argBuildTarget, config.BuildTarget)
+	}
+	if config.DisableAssemblyUpdater {
+		extra = append(extra, "-disable-assembly-updater")
+	}
+	args := r.buildArgs(absProjectPath, RunConfig{ExtraArgs: extra})
+
+	timeout := config.TimeoutSeconds
+	if timeout == 0 {
+		timeout = 7200 // Default 2 hours, first imports of large projects are slow
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	env, err := ProjectEnviron(absProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, editorPath, args...)
+	cmd.Env = env
+	cmd.Dir = filepath.Dir(absProjectPath)
+
+	crash := watchForCrash(r.project, "")
+	writers := []io.Writer{crash.tail}
+	if config.LogFile != "" {
+		logFile, err := os.Create(config.LogFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log file: %w", err)
+		}
+		defer func() { _ = logFile.Close() }()
+		writers = append(writers, logFile)
+	}
+
+	imported := 0
+	pr, pw := io.Pipe()
+	counted := make(chan struct{})
+	go func() {
+		seen := make(map[string]bool)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			asset, ok := ParseImportLine(scanner.Text())
+			if !ok || seen[asset] {
+				continue
+			}
+			seen[asset] = true
+			imported++
+			if progress != nil {
+				progress(imported)
+			}
+		}
+		_, _ = io.Copy(io.Discard, pr)
+		close(counted)
+	}()
+
+	cmd.Stdout = io.MultiWriter(append(writers, pw)...)
+	cmd.Stderr = cmd.Stdout
+
+	ui.Debug("Warming Library", "path", editorPath, "args", strings.Join(args, " "))
+
+	span := trace.Start(trace.KindExec, "Unity import", "version", r.project.UnityVersion)
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		_ = pw.Close()
+		return nil, startError(runtime.GOOS, editorPath, err)
+	}
+	runErr := cmd.Wait()
+	span.Fail(runErr)
+	span.End()
+	_ = pw.Close()
+	<-counted
+
+	lines := crash.tail.Lines()
+	result := &WarmResult{Imported: imported, Duration: time.Since(start), LogTail: lines[max(0, len(lines)-30):]}
+	if runErr != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return result, fmt.Errorf("warm-up timeout after %d seconds", timeout)
+		}
+		if crashErr := crash.check(runErr); crashErr != nil {
+			return result, crashErr
+		}
+		return result, fmt.Errorf("unity execution failed: %w", runErr)
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseImportLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{"Start importing Assets/Scenes/Main.unity using Guid(9fc0d4010bbf28b4594072e72b8655ab) Importer(-1,00000000000000000000000000000000)  -> (artifact id: 'abc') in 0.01 seconds", "Assets/Scenes/Main.unity", true},
+		{"[Worker0] Start importing Assets/Textures/My Icon.png using Guid(0123) Importer(1,2)", "Assets/Textures/My Icon.png", true},
+		{"Refreshing native plugins compatible for Editor in 2.1 ms", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseImportLine(tt.line)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseImportLine(%q) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWarm(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	editor := filepath.Join(dir, "Unity")
+	script := `#!/bin/sh
+echo "$@" > "` + argsFile + `"
+echo "Start importing Assets/A.png using Guid(1) Importer(1,2)"
+echo "Start importing Assets/B.prefab using Guid(2) Importer(1,2)"
+echo "Start importing Assets/A.png using Guid(1) Importer(1,2)"
+echo "Exiting batchmode successfully now!"
+`
+	if err := os.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &Project{Path: filepath.Join(dir, "MyGame"), UnityVersion: "2022.3.10f1", Name: "MyGame"}
+	runner := &Runner{project: project, editor: &Editor{Version: project.UnityVersion, Path: editor}}
+
+	var reported []int
+	logFile := filepath.Join(dir, "warm.log")
+	result, err := runner.Warm(WarmConfig{
+		ProjectPath:            project.Path,
+		BuildTarget:            "Android",
+		DisableAssemblyUpdater: true,
+		LogFile:                logFile,
+	}, func(imported int) { reported = append(reported, imported) })
+	if err != nil {
+		t.Fatalf("Warm() error = %v", err)
+	}
+
+	if result.Imported != 2 || !slices.Equal(reported, []int{1, 2}) {
+		t.Errorf("Imported = %d, reported %v; want 2 distinct assets", result.Imported, reported)
+	}
+	args, _ := os.ReadFile(argsFile)
+	for _, want := range []string{"-batchmode", "-quit", "-buildTarget Android", "-disable-assembly-updater"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("args %q missing %q", args, want)
+		}
+	}
+	if data, _ := os.ReadFile(logFile); !strings.Contains(string(data), "Exiting batchmode") {
+		t.Errorf("log file = %q, want the full log", data)
+	}
+}
@@ -0,0 +1,114 @@
+package unity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceConfigFile lists the Unity projects of a repository, inside ProjectConfigDir
+const WorkspaceConfigFile = "workspace.yaml"
+
+// Workspace is a folder, usually a repository, holding several Unity projects
+type Workspace struct {
+	Root     string   // Folder containing .uniforge/workspace.yaml
+	Projects []string // Absolute project paths, in the order of workspace.yaml
+}
+
+// workspaceConfig is the contents of .uniforge/workspace.yaml
+type workspaceConfig struct {
+	// Project folders relative to the workspace root; glob patterns such as
+	// "samples/*" match every Unity project they expand to
+	Projects []string `yaml:"projects"`
+}
+
+// WorkspaceConfigPath returns the path of the workspace.yaml of root
+func WorkspaceConfigPath(root string) string {
+	return filepath.Join(root, ProjectConfigDir, WorkspaceConfigFile)
+}
+
+// FindWorkspace walks up from dir to the nearest folder with a .uniforge/workspace.yaml
+func FindWorkspace(dir string) (*Workspace, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	for current := absDir; ; {
+		if fileExists(WorkspaceConfigPath(current)) {
+			return LoadWorkspace(current)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, errs.WithHint(
+				errs.New(errs.NotFound, "no %s found in %s or its parents", filepath.Join(ProjectConfigDir, WorkspaceConfigFile), absDir),
+				"List the projects of the repository in .uniforge/workspace.yaml, e.g.\n  projects:\n    - client\n    - samples/*",
+			)
+		}
+		current = parent
+	}
+}
+
+// LoadWorkspace reads the workspace.yaml of root. Every listed path must be a
+// Unity project; a glob pattern must match at least one.
+func LoadWorkspace(root string) (*Workspace, error) {
+	path := WorkspaceConfigPath(root)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errs.New(errs.NotFound, "workspace not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read workspace: %w", err)
+	}
+
+	var config workspaceConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(config.Projects) == 0 {
+		return nil, fmt.Errorf("invalid %s: no projects listed", path)
+	}
+
+	ws := &Workspace{Root: root}
+	seen := make(map[string]bool)
+	for _, entry := range config.Projects {
+		pattern := filepath.FromSlash(entry)
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(root, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: bad pattern %q: %w", path, entry, err)
+		}
+		sort.Strings(matches)
+
+		found := false
+		for _, match := range matches {
+			if !fileExists(filepath.Join(match, "ProjectSettings", "ProjectVersion.txt")) {
+				continue
+			}
+			found = true
+			if !seen[match] {
+				seen[match] = true
+				ws.Projects = append(ws.Projects, match)
+			}
+		}
+		if !found {
+			return nil, errs.New(errs.NotFound, "invalid %s: %q is not a Unity project", path, entry)
+		}
+	}
+	return ws, nil
+}
+
+// Rel returns a project path relative to the workspace root, for display
+func (w *Workspace) Rel(projectPath string) string {
+	if rel, err := filepath.Rel(w.Root, projectPath); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return projectPath
+}
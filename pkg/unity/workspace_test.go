@@ -0,0 +1,79 @@
+package unity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createWorkspaceProject(t *testing.T, dir string) {
+	t.Helper()
+	settings := filepath.Join(dir, "ProjectSettings")
+	if err := os.MkdirAll(settings, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(settings, "ProjectVersion.txt"), []byte("m_EditorVersion: 2022.3.10f1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeWorkspaceConfig(t *testing.T, root, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, ProjectConfigDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(WorkspaceConfigPath(root), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindWorkspace(t *testing.T) {
+	root := t.TempDir()
+	createWorkspaceProject(t, filepath.Join(root, "client"))
+	createWorkspaceProject(t, filepath.Join(root, "samples", "b"))
+	createWorkspaceProject(t, filepath.Join(root, "samples", "a"))
+	if err := os.MkdirAll(filepath.Join(root, "samples", "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeWorkspaceConfig(t, root, "projects:\n  - client\n  - samples/*\n  - client\n")
+
+	// Found from a folder inside one of the projects
+	ws, err := FindWorkspace(filepath.Join(root, "client", "ProjectSettings"))
+	if err != nil {
+		t.Fatalf("FindWorkspace() error = %v", err)
+	}
+	want := []string{"client", "samples/a", "samples/b"}
+	if len(ws.Projects) != len(want) {
+		t.Fatalf("Projects = %v, want %v", ws.Projects, want)
+	}
+	for i, p := range ws.Projects {
+		if ws.Rel(p) != want[i] {
+			t.Errorf("Projects[%d] = %s, want %s", i, ws.Rel(p), want[i])
+		}
+	}
+}
+
+func TestLoadWorkspaceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no projects", "projects: []\n"},
+		{"not a project", "projects:\n  - missing\n"},
+		{"pattern without projects", "projects:\n  - tools/*\n"},
+		{"invalid yaml", "projects: [\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeWorkspaceConfig(t, root, tt.content)
+			if _, err := LoadWorkspace(root); err == nil {
+				t.Error("LoadWorkspace() should fail")
+			}
+		})
+	}
+
+	if _, err := FindWorkspace(t.TempDir()); err == nil {
+		t.Error("FindWorkspace() should fail without workspace.yaml")
+	}
+}
@@ -0,0 +1,248 @@
+package upload
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// gcsEndpoint is the Cloud Storage API
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// gcsScope is the OAuth scope needed to write objects
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// googleTokenURL is used when a credentials file does not name a token endpoint
+const googleTokenURL = "https://oauth2.googleapis.com/token"
+
+// gcsUploader uploads with the JSON API's simple media upload
+type gcsUploader struct {
+	bucket   string
+	endpoint string
+	token    func(ctx context.Context) (string, error)
+	client   *http.Client
+}
+
+// newGCS reads GOOGLE_OAUTH_ACCESS_TOKEN, or the credentials file named by
+// GOOGLE_APPLICATION_CREDENTIALS, or gcloud's application default credentials
+func newGCS(c Config) (*gcsUploader, error) {
+	u := &gcsUploader{
+		bucket:   c.Bucket,
+		endpoint: gcsEndpoint,
+		client:   &http.Client{},
+	}
+	if c.Endpoint != "" {
+		u.endpoint = strings.TrimSuffix(c.Endpoint, "/")
+	} else if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		u.endpoint = "http://" + strings.TrimPrefix(host, "http://")
+	}
+
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		u.token = func(context.Context) (string, error) { return token, nil }
+		return u, nil
+	}
+	file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if file == "" {
+		file = gcloudCredentialsFile()
+	}
+	creds, err := loadGoogleCredentials(file)
+	if err != nil {
+		return nil, err
+	}
+	u.token = creds.tokenSource(u.client)
+	return u, nil
+}
+
+func (u *gcsUploader) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	token, err := u.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a Google access token: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s", u.endpoint, url.PathEscape(u.bucket), url.QueryEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return checkResponse(resp)
+}
+
+func (u *gcsUploader) URI(key string) string {
+	return "gs://" + u.bucket + "/" + key
+}
+
+func (u *gcsUploader) URL(key string) string {
+	return u.endpoint + "/" + u.bucket + "/" + uriEncode(key, false)
+}
+
+// googleCredentials is a service account key or gcloud user credentials file
+type googleCredentials struct {
+	Type         string `json:"type"` // service_account or authorized_user
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// gcloudCredentialsFile is where 'gcloud auth application-default login' saves credentials
+func gcloudCredentialsFile() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+}
+
+func loadGoogleCredentials(file string) (*googleCredentials, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errs.WithHint(
+				fmt.Errorf("no Google Cloud credentials found"),
+				"Set GOOGLE_APPLICATION_CREDENTIALS to a service account key file, set GOOGLE_OAUTH_ACCESS_TOKEN, or run 'gcloud auth application-default login'.")
+		}
+		return nil, fmt.Errorf("failed to read Google credentials: %w", err)
+	}
+	var creds googleCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if creds.TokenURI == "" {
+		creds.TokenURI = googleTokenURL
+	}
+	switch creds.Type {
+	case "service_account":
+		if creds.ClientEmail == "" || creds.PrivateKey == "" {
+			return nil, fmt.Errorf("%s is missing client_email or private_key", file)
+		}
+	case "authorized_user":
+		if creds.RefreshToken == "" {
+			return nil, fmt.Errorf("%s is missing refresh_token", file)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Google credentials type %q in %s", creds.Type, file)
+	}
+	return &creds, nil
+}
+
+// tokenSource returns a function that exchanges the credentials for an access
+// token, reusing it until shortly before it expires
+func (c *googleCredentials) tokenSource(client *http.Client) func(ctx context.Context) (string, error) {
+	var mu sync.Mutex
+	var token string
+	var expires time.Time
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if token != "" && time.Now().Before(expires) {
+			return token, nil
+		}
+
+		form := url.Values{}
+		if c.Type == "service_account" {
+			assertion, err := c.signedJWT(time.Now())
+			if err != nil {
+				return "", err
+			}
+			form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+			form.Set("assertion", assertion)
+		} else {
+			form.Set("grant_type", "refresh_token")
+			form.Set("client_id", c.ClientID)
+			form.Set("client_secret", c.ClientSecret)
+			form.Set("refresh_token", c.RefreshToken)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURI, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		var result struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return "", err
+		}
+		token = result.AccessToken
+		expires = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - time.Minute)
+		return token, nil
+	}
+}
+
+// signedJWT returns the RS256 signed assertion of a service account
+func (c *googleCredentials) signedJWT(now time.Time) (string, error) {
+	block, _ := pem.Decode([]byte(c.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("invalid private key of %s", c.ClientEmail)
+	}
+	var key *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("private key of %s is not an RSA key", c.ClientEmail)
+		}
+		key = rsaKey
+	} else if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+		return "", fmt.Errorf("invalid private key of %s: %w", c.ClientEmail, err)
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   c.ClientEmail,
+		"scope": gcsScope,
+		"aud":   c.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(signature), nil
+}
@@ -0,0 +1,211 @@
+package upload
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+)
+
+// s3MaxPutSize is the largest object S3 accepts in a single PUT
+const s3MaxPutSize = 5 << 30
+
+// unsignedPayload skips hashing the body, which S3 allows over HTTPS
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// s3Credentials are AWS access keys
+type s3Credentials struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+}
+
+// s3Uploader uploads with signature version 4 signed PUT requests
+type s3Uploader struct {
+	bucket   string
+	region   string
+	endpoint *url.URL // nil for AWS
+	creds    s3Credentials
+	client   *http.Client
+	now      func() time.Time
+}
+
+// newS3 reads the AWS_* variables the AWS CLI uses
+func newS3(c Config) (*s3Uploader, error) {
+	creds := s3Credentials{
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return nil, errs.WithHint(
+			fmt.Errorf("no S3 credentials for bucket %s", c.Bucket),
+			"Set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY (and AWS_SESSION_TOKEN for temporary credentials).")
+	}
+
+	u := &s3Uploader{
+		bucket: c.Bucket,
+		region: firstNonEmpty(c.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1"),
+		creds:  creds,
+		client: &http.Client{},
+		now:    time.Now,
+	}
+	if endpoint := firstNonEmpty(c.Endpoint, os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL")); endpoint != "" {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint: %s", endpoint)
+		}
+		u.endpoint = parsed
+	}
+	return u, nil
+}
+
+// objectURL uses virtual-hosted style on AWS and path style on custom
+// endpoints, which S3-compatible servers support more widely
+func (u *s3Uploader) objectURL(key string) *url.URL {
+	if u.endpoint == nil {
+		return &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", u.bucket, u.region), Path: "/" + key}
+	}
+	return u.endpoint.JoinPath(u.bucket, key)
+}
+
+func (u *s3Uploader) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if size > s3MaxPutSize {
+		return fmt.Errorf("%s is larger than 5 GiB, the largest object S3 accepts in one upload", key)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.objectURL(key).String(), body)
+	if err != nil {
+		return err
+	}
+	// Send the path exactly as it is signed
+	req.URL.RawPath = uriEncode(req.URL.Path, false)
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("Content-Type", contentType)
+	signV4(req, u.creds, u.region, "s3", unsignedPayload, u.now())
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return checkResponse(resp)
+}
+
+func (u *s3Uploader) URI(key string) string {
+	return "s3://" + u.bucket + "/" + key
+}
+
+func (u *s3Uploader) URL(key string) string {
+	return u.objectURL(key).String()
+}
+
+// signV4 adds an AWS signature version 4 Authorization header to req, signing
+// the host and every header already set
+func signV4(req *http.Request, creds s3Credentials, region, service, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := amzDate[:8]
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery sorts and encodes query parameters for signing
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(v, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters, and
+// slashes unless encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
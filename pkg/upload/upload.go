@@ -0,0 +1,169 @@
+// Package upload pushes build artifacts to cloud storage buckets
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/trace"
+)
+
+// Providers
+const (
+	ProviderS3  = "s3"  // Amazon S3 and S3-compatible storage (MinIO, Cloudflare R2, ...)
+	ProviderGCS = "gcs" // Google Cloud Storage
+)
+
+// Config is an upload destination, configured as upload: in build.yaml
+type Config struct {
+	Provider string `yaml:"provider"` // s3 or gcs
+	Bucket   string `yaml:"bucket"`
+	Prefix   string `yaml:"prefix"`   // Key prefix, may contain placeholders expanded by the caller
+	Endpoint string `yaml:"endpoint"` // Custom endpoint of S3-compatible storage or a GCS emulator
+	Region   string `yaml:"region"`   // S3 region, defaults to AWS_REGION or us-east-1
+}
+
+// Validate checks that the provider is known and a bucket is set
+func (c Config) Validate() error {
+	if c.Provider != ProviderS3 && c.Provider != ProviderGCS {
+		return fmt.Errorf("unknown upload provider %q (use %s or %s)", c.Provider, ProviderS3, ProviderGCS)
+	}
+	if c.Bucket == "" {
+		return fmt.Errorf("upload has no bucket")
+	}
+	if strings.ContainsAny(c.Bucket, "/ ") {
+		return fmt.Errorf("invalid bucket name %q", c.Bucket)
+	}
+	return nil
+}
+
+// Uploader writes objects to a bucket
+type Uploader interface {
+	// Put uploads size bytes of body as key
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// URI returns the provider URI of key, e.g. s3://bucket/key
+	URI(key string) string
+	// URL returns the HTTPS URL of key
+	URL(key string) string
+}
+
+// New returns an Uploader for the destination, reading credentials from the
+// environment the way the provider's own tools do
+func New(c Config) (Uploader, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	switch c.Provider {
+	case ProviderS3:
+		return newS3(c)
+	default:
+		return newGCS(c)
+	}
+}
+
+// Object is an uploaded file
+type Object struct {
+	Key  string `json:"key"`
+	Size int64  `json:"size"`
+	URI  string `json:"uri"`
+}
+
+// Key joins a prefix and a relative path into an object key
+func Key(prefix, rel string) string {
+	return strings.TrimPrefix(path.Join(strings.Trim(prefix, "/"), filepath.ToSlash(rel)), "/")
+}
+
+// File uploads a single file as key
+func File(ctx context.Context, u Uploader, file, key string) (Object, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return Object{}, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return Object{}, err
+	}
+
+	span := trace.Start(trace.KindHTTP, "upload", "key", key)
+	defer span.End()
+	if err := u.Put(ctx, key, f, info.Size(), contentType(file)); err != nil {
+		span.Fail(err)
+		return Object{}, fmt.Errorf("failed to upload %s: %w", filepath.Base(file), err)
+	}
+	return Object{Key: key, Size: info.Size(), URI: u.URI(key)}, nil
+}
+
+// Dir uploads every regular file under dir to prefix/<relative path>.
+// onUploaded (may be nil) is called after each file is uploaded.
+func Dir(ctx context.Context, u Uploader, dir, prefix string, onUploaded func(object Object)) ([]Object, error) {
+	var objects []Object
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		object, err := File(ctx, u, p, Key(prefix, rel))
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+		if onUploaded != nil {
+			onUploaded(object)
+		}
+		return nil
+	})
+	return objects, err
+}
+
+// contentType guesses the content type from the file extension
+func contentType(file string) string {
+	if t := mime.TypeByExtension(filepath.Ext(file)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// checkResponse turns an error response into an error with the service's message
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	msg := strings.TrimSpace(string(body))
+	// S3 reports <Error><Code/><Message/></Error>, GCS {"error": {"message"}}
+	var xmlErr struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	var jsonErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if xml.Unmarshal(body, &xmlErr) == nil && xmlErr.Code != "" {
+		msg = xmlErr.Code + ": " + xmlErr.Message
+	} else if json.Unmarshal(body, &jsonErr) == nil && jsonErr.Error.Message != "" {
+		msg = jsonErr.Error.Message
+	}
+	if msg == "" {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, msg)
+}
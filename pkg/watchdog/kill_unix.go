@@ -0,0 +1,23 @@
+//go:build !windows
+
+package watchdog
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// prepare starts cmd in its own process group so that killTree reaches the
+// processes it spawns, such as Unity's asset import workers
+func prepare(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+func killTree(cmd *exec.Cmd) {
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+		_ = cmd.Process.Kill()
+	}
+}
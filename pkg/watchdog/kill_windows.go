@@ -0,0 +1,18 @@
+//go:build windows
+
+package watchdog
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+func prepare(cmd *exec.Cmd) {}
+
+// killTree kills the process and the processes it spawned, such as Unity's
+// asset import workers
+func killTree(cmd *exec.Cmd) {
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run(); err != nil {
+		_ = cmd.Process.Kill()
+	}
+}
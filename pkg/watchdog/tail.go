@@ -0,0 +1,58 @@
+package watchdog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// TailBuffer keeps the last lines written to it. Lines are split on '\n' with
+// a trailing '\r' dropped.
+type TailBuffer struct {
+	mu      sync.Mutex
+	max     int
+	lines   []string
+	partial []byte            // Incomplete last line
+	onLine  func(line string) // Called with each complete line, under mu
+}
+
+// NewTailBuffer returns a TailBuffer keeping the last max lines
+func NewTailBuffer(max int) *TailBuffer {
+	return &TailBuffer{max: max}
+}
+
+// Write records output; it never fails
+func (t *TailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	data := append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimRight(string(data[:i]), "\r")
+		t.lines = append(t.lines, line)
+		if t.onLine != nil {
+			t.onLine(line)
+		}
+		data = data[i+1:]
+	}
+	if len(t.lines) > t.max {
+		t.lines = append([]string(nil), t.lines[len(t.lines)-t.max:]...)
+	}
+	t.partial = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// Lines returns the kept lines, including an unterminated last line
+func (t *TailBuffer) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := append([]string(nil), t.lines...)
+	if len(t.partial) > 0 {
+		lines = append(lines, string(t.partial))
+	}
+	return lines
+}
@@ -0,0 +1,247 @@
+// Package watchdog stops batch jobs that hang: a process is killed when it
+// writes no output for a while, or goes quiet right after printing a known hang
+// signature such as a license prompt or a stalled import worker
+package watchdog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// Defaults for Config fields left zero
+const (
+	DefaultIdleTimeout      = 15 * time.Minute
+	DefaultSignatureTimeout = 2 * time.Minute
+	DefaultTailLines        = 50
+)
+
+// pollInterval is how often the process and the log file are checked
+var pollInterval = time.Second
+
+// Signature is log output after which a quiet Unity is most likely hung
+type Signature struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Hint    string
+}
+
+// DefaultSignatures are the hang signatures of Unity batch mode
+var DefaultSignatures = []Signature{
+	{
+		Name:    "license",
+		Pattern: regexp.MustCompile(`(?i)no valid unity (editor )?license|license is not (yet )?activated|licensing.*(handshake|connection).*(failed|timed out)`),
+		Hint:    "Unity is waiting for a license. Check 'uniforge license status' on this machine.",
+	},
+	{
+		Name:    "import-worker",
+		Pattern: regexp.MustCompile(`(?i)import ?worker.*(timed out|timeout|not responding|crashed)`),
+		Hint:    "An asset import worker stalled. Deleting Library/ and re-importing often helps.",
+	},
+	{
+		Name:    "shader-compiler",
+		Pattern: regexp.MustCompile(`(?i)shader ?compiler.*(timed out|not responding|crashed)`),
+		Hint:    "The shader compiler stalled.",
+	},
+}
+
+// Config controls when a process is considered hung
+type Config struct {
+	IdleTimeout      time.Duration // Kill after no output for this long
+	SignatureTimeout time.Duration // Kill after a hang signature and no output for this long
+	TailLines        int           // Last lines of output kept for the report
+	LogFile          string        // Also watch this file, for tools writing their log to a file (Unity's -logFile <path>)
+	Signatures       []Signature   // nil for DefaultSignatures
+}
+
+// StallError reports a process killed by the watchdog
+type StallError struct {
+	Idle      time.Duration
+	Signature *Signature // Set when a hang signature preceded the silence
+	Tail      []string   // Last lines of output
+}
+
+func (e *StallError) Error() string {
+	if e.Signature != nil {
+		return fmt.Sprintf("killed after %s without output following a %s hang signature", e.Idle.Round(time.Second), e.Signature.Name)
+	}
+	return fmt.Sprintf("killed after %s without output", e.Idle.Round(time.Second))
+}
+
+// Watchdog tracks the output of a process. Write output to it and call check
+// periodically, or use Run.
+type Watchdog struct {
+	config Config
+
+	mu           sync.Mutex
+	lastActivity time.Time
+	armed        *Signature // Signature seen in the latest lines
+	tail         *TailBuffer
+}
+
+// New returns a Watchdog, filling in defaults for zero Config fields
+func New(config Config) *Watchdog {
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = DefaultIdleTimeout
+	}
+	if config.SignatureTimeout <= 0 {
+		config.SignatureTimeout = min(DefaultSignatureTimeout, config.IdleTimeout)
+	}
+	if config.TailLines <= 0 {
+		config.TailLines = DefaultTailLines
+	}
+	if config.Signatures == nil {
+		config.Signatures = DefaultSignatures
+	}
+	w := &Watchdog{config: config, lastActivity: time.Now(), tail: NewTailBuffer(config.TailLines)}
+	w.tail.onLine = w.matchLine
+	return w
+}
+
+// Write records output; it never fails
+func (w *Watchdog) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(p) > 0 {
+		w.lastActivity = time.Now()
+	}
+	return w.tail.Write(p)
+}
+
+// matchLine arms the signature matching a complete line; w.mu is held by Write
+func (w *Watchdog) matchLine(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	// A signature stays armed until Unity prints something else
+	w.armed = nil
+	for i := range w.config.Signatures {
+		if w.config.Signatures[i].Pattern.MatchString(line) {
+			w.armed = &w.config.Signatures[i]
+			break
+		}
+	}
+}
+
+// Tail returns the last lines of output, including an unterminated last line
+func (w *Watchdog) Tail() []string {
+	return w.tail.Lines()
+}
+
+// check returns a StallError when the process counts as hung at now
+func (w *Watchdog) check(now time.Time) *StallError {
+	w.mu.Lock()
+	idle := now.Sub(w.lastActivity)
+	armed := w.armed
+	w.mu.Unlock()
+
+	switch {
+	case armed != nil && idle >= w.config.SignatureTimeout:
+		return &StallError{Idle: idle, Signature: armed, Tail: w.Tail()}
+	case idle >= w.config.IdleTimeout:
+		return &StallError{Idle: idle, Tail: w.Tail()}
+	}
+	return nil
+}
+
+// Run starts cmd with its output also going to the watchdog and waits for it.
+// A hung process is killed together with its children and a *StallError is
+// returned. Interrupting uniforge kills the process as well, since it runs in
+// its own process group.
+func Run(cmd *exec.Cmd, config Config) error {
+	w := New(config)
+	// exec shares one pipe when Stdout and Stderr are the same writer; tee it
+	// once so both streams keep going through a single writer
+	sameWriter := cmd.Stderr == cmd.Stdout
+	cmd.Stdout = teeWriter(cmd.Stdout, w)
+	if sameWriter {
+		cmd.Stderr = cmd.Stdout
+	} else {
+		cmd.Stderr = teeWriter(cmd.Stderr, w)
+	}
+	prepare(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	file := &fileFollower{path: w.config.LogFile}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case sig := <-interrupt:
+			killTree(cmd)
+			<-done
+			return fmt.Errorf("interrupted by %s", sig)
+		case now := <-ticker.C:
+			file.follow(w)
+			if stall := w.check(now); stall != nil {
+				ui.Debug("Watchdog killing process", "pid", cmd.Process.Pid, "reason", stall)
+				killTree(cmd)
+				<-done
+				return stall
+			}
+		}
+	}
+}
+
+func teeWriter(dst io.Writer, w *Watchdog) io.Writer {
+	if dst == nil {
+		return w
+	}
+	return io.MultiWriter(dst, w)
+}
+
+// fileFollower feeds what is appended to a log file into the watchdog
+type fileFollower struct {
+	path   string
+	offset int64
+}
+
+func (f *fileFollower) follow(w *Watchdog) {
+	if f.path == "" {
+		return
+	}
+	file, err := os.Open(f.path)
+	if err != nil {
+		return // Not created yet
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+	if info.Size() < f.offset {
+		f.offset = 0 // Truncated or replaced
+	}
+	if info.Size() == f.offset {
+		return
+	}
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		return
+	}
+	n, _ := io.Copy(w, file)
+	f.offset += n
+}
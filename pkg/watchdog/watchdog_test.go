@@ -0,0 +1,138 @@
+package watchdog
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatchdogIdle(t *testing.T) {
+	w := New(Config{IdleTimeout: time.Minute})
+	start := w.lastActivity
+
+	if stall := w.check(start.Add(59 * time.Second)); stall != nil {
+		t.Errorf("check() before the idle timeout = %v, want nil", stall)
+	}
+	stall := w.check(start.Add(time.Minute))
+	if stall == nil || stall.Signature != nil {
+		t.Fatalf("check() after the idle timeout = %+v, want a stall without signature", stall)
+	}
+}
+
+func TestWatchdogSignature(t *testing.T) {
+	w := New(Config{IdleTimeout: time.Hour, SignatureTimeout: time.Minute})
+
+	_, _ = w.Write([]byte("Refreshing native plugins\n[Licensing::Module] No valid Unity Editor license found.\n"))
+	stall := w.check(w.lastActivity.Add(time.Minute))
+	if stall == nil || stall.Signature == nil || stall.Signature.Name != "license" {
+		t.Fatalf("check() = %+v, want a license stall", stall)
+	}
+
+	// Output after the signature disarms it
+	_, _ = w.Write([]byte("Licensing client connected\n"))
+	if stall := w.check(w.lastActivity.Add(time.Minute)); stall != nil {
+		t.Errorf("check() after further output = %v, want nil", stall)
+	}
+}
+
+func TestWatchdogTail(t *testing.T) {
+	w := New(Config{TailLines: 2})
+	_, _ = w.Write([]byte("one\r\ntwo\nthr"))
+	_, _ = w.Write([]byte("ee\nfour"))
+
+	if got, want := w.Tail(), []string{"two", "three", "four"}; !slices.Equal(got, want) {
+		t.Errorf("Tail() = %q, want %q", got, want)
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	tail := NewTailBuffer(2)
+	_, _ = tail.Write([]byte("one\ntwo\r\nth"))
+	_, _ = tail.Write([]byte("ree\nfour"))
+	if got, want := tail.Lines(), []string{"two", "three", "four"}; !slices.Equal(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultSignatures(t *testing.T) {
+	tests := map[string]string{
+		"No valid Unity Editor license found. Please activate your license.":     "license",
+		"[Worker3] AssetImportWorker timed out waiting for the editor":           "import-worker",
+		"Shader compiler: compile Hidden/Foo - Timed out while compiling shader": "shader-compiler",
+	}
+	for line, want := range tests {
+		w := New(Config{})
+		_, _ = w.Write([]byte(line + "\n"))
+		if w.armed == nil || w.armed.Name != want {
+			t.Errorf("%q: armed = %v, want %s", line, w.armed, want)
+		}
+	}
+
+	w := New(Config{})
+	_, _ = w.Write([]byte("Start importing Assets/Textures/icon.png using Guid(abc)\n"))
+	if w.armed != nil {
+		t.Errorf("ordinary import line armed %s", w.armed.Name)
+	}
+}
+
+func TestRunKillsHungProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = 20 * time.Millisecond
+
+	start := time.Now()
+	err := Run(exec.Command("sh", "-c", "echo working; sleep 30 & wait"), Config{IdleTimeout: 200 * time.Millisecond})
+
+	var stall *StallError
+	if !errors.As(err, &stall) {
+		t.Fatalf("Run() error = %v, want *StallError", err)
+	}
+	if !slices.Equal(stall.Tail, []string{"working"}) {
+		t.Errorf("Tail = %q, want [working]", stall.Tail)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Run() took %s, want the process killed soon after the idle timeout", elapsed)
+	}
+}
+
+func TestRunFollowsLogFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = 20 * time.Millisecond
+
+	logFile := filepath.Join(t.TempDir(), "editor.log")
+	// Writes to the log file only, more often than the idle timeout
+	script := `for i in 1 2 3 4 5 6; do echo line$i >> "$0"; sleep 0.1; done`
+	err := Run(exec.Command("sh", "-c", script, logFile), Config{IdleTimeout: 500 * time.Millisecond, LogFile: logFile})
+	if err != nil {
+		t.Fatalf("Run() error = %v, want the active process to finish", err)
+	}
+}
+
+func TestRunSharedOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	var out bytes.Buffer
+	cmd := exec.Command("sh", "-c", "echo one; echo two >&2; echo three")
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := Run(cmd, Config{IdleTimeout: 5 * time.Second}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if cmd.Stderr != cmd.Stdout {
+		t.Error("Run() gave stdout and stderr separate writers, want the shared one kept shared")
+	}
+	if got, want := out.String(), "one\ntwo\nthree\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
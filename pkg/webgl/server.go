@@ -0,0 +1,87 @@
+// Package webgl serves Unity WebGL builds locally
+package webgl
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// contentTypes maps Unity WebGL payload extensions to MIME types
+var contentTypes = map[string]string{
+	".wasm": "application/wasm",
+	".js":   "application/javascript",
+	".data": "application/octet-stream",
+	".json": "application/json",
+	".html": "text/html; charset=utf-8",
+	".css":  "text/css; charset=utf-8",
+	".mem":  "application/octet-stream",
+}
+
+// encodings maps compressed file extensions to Content-Encoding values
+var encodings = map[string]string{
+	".br": "br",
+	".gz": "gzip",
+}
+
+// Handler returns an http.Handler that serves a WebGL build directory with
+// the headers Unity's loader expects for compressed payloads
+func Handler(root string) http.Handler {
+	fileServer := http.FileServer(http.Dir(root))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Path
+		if encoding, contentType, ok := CompressedHeaders(name); ok {
+			w.Header().Set("Content-Encoding", encoding)
+			w.Header().Set("Content-Type", contentType)
+		} else if strings.HasSuffix(name, ".wasm") {
+			w.Header().Set("Content-Type", "application/wasm")
+		}
+
+		// Builds are rebuilt often; never let the browser serve a stale loader
+		w.Header().Set("Cache-Control", "no-store")
+		fileServer.ServeHTTP(w, r)
+	})
+}
+
+// CompressedHeaders returns the Content-Encoding and Content-Type for a
+// compressed Unity payload such as "Build/game.wasm.br"
+func CompressedHeaders(name string) (encoding, contentType string, ok bool) {
+	ext := filepath.Ext(name)
+	encoding, ok = encodings[ext]
+	if !ok {
+		return "", "", false
+	}
+
+	inner := strings.TrimSuffix(name, ext)
+	return encoding, ContentType(inner), true
+}
+
+// ContentType returns the MIME type for an uncompressed file name
+func ContentType(name string) string {
+	if t, ok := contentTypes[filepath.Ext(name)]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// ValidateBuildDir checks that dir looks like a Unity WebGL build
+func ValidateBuildDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("build directory not found: %s", dir)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "index.html")); err != nil {
+		return fmt.Errorf("index.html not found in %s (is this a WebGL build?)", dir)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package webgl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompressedHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantOK      bool
+		encoding    string
+		contentType string
+	}{
+		{"Build/game.wasm.br", true, "br", "application/wasm"},
+		{"Build/game.framework.js.gz", true, "gzip", "application/javascript"},
+		{"Build/game.data.br", true, "br", "application/octet-stream"},
+		{"Build/game.loader.js", false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoding, contentType, ok := CompressedHeaders(tt.name)
+			if ok != tt.wantOK {
+				t.Fatalf("CompressedHeaders(%q) ok = %v, want %v", tt.name, ok, tt.wantOK)
+			}
+			if encoding != tt.encoding || contentType != tt.contentType {
+				t.Errorf("CompressedHeaders(%q) = (%q, %q), want (%q, %q)", tt.name, encoding, contentType, tt.encoding, tt.contentType)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	root := t.TempDir()
+	buildDir := filepath.Join(root, "Build")
+	if err := os.MkdirAll(buildDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"game.wasm.br", "game.wasm"} {
+		if err := os.WriteFile(filepath.Join(buildDir, name), []byte("payload"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := Handler(root)
+
+	t.Run("brotli wasm", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/Build/game.wasm.br", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", rec.Code)
+		}
+		if got := rec.Header().Get("Content-Encoding"); got != "br" {
+			t.Errorf("Expected Content-Encoding br, got %q", got)
+		}
+		if got := rec.Header().Get("Content-Type"); got != "application/wasm" {
+			t.Errorf("Expected Content-Type application/wasm, got %q", got)
+		}
+	})
+
+	t.Run("uncompressed wasm", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/Build/game.wasm", nil))
+
+		if got := rec.Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("Expected no Content-Encoding, got %q", got)
+		}
+		if got := rec.Header().Get("Content-Type"); got != "application/wasm" {
+			t.Errorf("Expected Content-Type application/wasm, got %q", got)
+		}
+	})
+}
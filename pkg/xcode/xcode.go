@@ -0,0 +1,222 @@
+// Package xcode wraps xcodebuild for archiving and exporting Unity iOS builds
+package xcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/neptaco/uniforge/pkg/errs"
+	"github.com/neptaco/uniforge/pkg/ui"
+)
+
+// DefaultScheme is the scheme generated by Unity for iOS builds
+const DefaultScheme = "Unity-iPhone"
+
+// Project is an Xcode project generated by a Unity iOS build
+type Project struct {
+	Dir       string // Build output directory
+	Workspace string // Path to .xcworkspace (set when CocoaPods is used)
+	Project   string // Path to .xcodeproj
+}
+
+// ArchiveOptions configures an xcodebuild archive
+type ArchiveOptions struct {
+	Scheme        string
+	Configuration string
+	ArchivePath   string
+	Verbose       bool // Print all xcodebuild output instead of errors and warnings only
+}
+
+// SigningError indicates that xcodebuild failed because of code signing
+type SigningError struct {
+	Messages []string
+}
+
+func (e *SigningError) Error() string {
+	return "code signing failed:\n  " + strings.Join(e.Messages, "\n  ")
+}
+
+// FindProject detects the Xcode workspace or project in dir
+func FindProject(dir string) (*Project, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	p := &Project{Dir: absDir}
+
+	workspace := filepath.Join(absDir, DefaultScheme+".xcworkspace")
+	if fileExists(workspace) {
+		p.Workspace = workspace
+	}
+
+	project := filepath.Join(absDir, DefaultScheme+".xcodeproj")
+	if fileExists(project) {
+		p.Project = project
+	}
+
+	if p.Workspace == "" && p.Project == "" {
+		return nil, fmt.Errorf("no Xcode project found in %s (expected %s.xcodeproj)", absDir, DefaultScheme)
+	}
+
+	return p, nil
+}
+
+// IsXcodeProjectDir returns true if dir contains a Unity-generated Xcode project
+func IsXcodeProjectDir(dir string) bool {
+	return fileExists(filepath.Join(dir, DefaultScheme+".xcodeproj"))
+}
+
+// Archive runs `xcodebuild archive` and returns the .xcarchive path
+func (p *Project) Archive(opts ArchiveOptions) (string, error) {
+	if opts.Scheme == "" {
+		opts.Scheme = DefaultScheme
+	}
+	if opts.Configuration == "" {
+		opts.Configuration = "Release"
+	}
+	if opts.ArchivePath == "" {
+		opts.ArchivePath = filepath.Join(p.Dir, "build", opts.Scheme+".xcarchive")
+	}
+
+	args := []string{"archive"}
+	if p.Workspace != "" {
+		args = append(args, "-workspace", p.Workspace)
+	} else {
+		args = append(args, "-project", p.Project)
+	}
+	args = append(args,
+		"-scheme", opts.Scheme,
+		"-configuration", opts.Configuration,
+		"-destination", "generic/platform=iOS",
+		"-archivePath", opts.ArchivePath,
+		"-allowProvisioningUpdates",
+	)
+
+	if err := runXcodebuild(args, opts.Verbose); err != nil {
+		return "", err
+	}
+
+	return opts.ArchivePath, nil
+}
+
+// ExportIPA exports an .ipa from an archive using exportOptions.plist
+func ExportIPA(archivePath, exportOptionsPlist, exportPath string, verbose bool) error {
+	if !fileExists(exportOptionsPlist) {
+		return fmt.Errorf("export options plist not found: %s", exportOptionsPlist)
+	}
+
+	args := []string{
+		"-exportArchive",
+		"-archivePath", archivePath,
+		"-exportOptionsPlist", exportOptionsPlist,
+		"-exportPath", exportPath,
+		"-allowProvisioningUpdates",
+	}
+
+	return runXcodebuild(args, verbose)
+}
+
+// runXcodebuild runs xcodebuild, printing errors and warnings and collecting signing failures
+func runXcodebuild(args []string, verbose bool) error {
+	xcodebuild, err := exec.LookPath("xcodebuild")
+	if err != nil {
+		return errs.New(errs.NotInstalled, "xcodebuild not found (install Xcode command line tools)")
+	}
+
+	ui.Debug("Running xcodebuild", "args", strings.Join(args, " "))
+
+	cmd := exec.Command(xcodebuild, args...)
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start xcodebuild: %w", err)
+	}
+
+	var signing []string
+	var scanErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		const maxCapacity = 1024 * 1024
+		scanner.Buffer(make([]byte, maxCapacity), maxCapacity)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if IsSigningError(line) {
+				signing = append(signing, strings.TrimSpace(line))
+			}
+			if verbose || isDiagnostic(line) {
+				fmt.Println(line)
+			}
+		}
+		if scanErr = scanner.Err(); scanErr != nil {
+			// Keep reading so xcodebuild does not block on a full pipe
+			_, _ = io.Copy(io.Discard, pr)
+		}
+	}()
+
+	waitErr := cmd.Wait()
+	_ = pw.Close()
+	<-done
+
+	if waitErr != nil {
+		if len(signing) > 0 {
+			return &SigningError{Messages: signing}
+		}
+		if scanErr != nil {
+			ui.Warn("Failed to read xcodebuild output: %v", scanErr)
+		}
+		return fmt.Errorf("xcodebuild failed: %w", waitErr)
+	}
+	if scanErr != nil {
+		return fmt.Errorf("failed to read xcodebuild output: %w", scanErr)
+	}
+	return nil
+}
+
+// signingPatterns are lowercase substrings found in xcodebuild code signing
+// failures
+var signingPatterns = []string{
+	"requires a development team",
+	"no profiles for",
+	"no signing certificate",
+	"provisioning profile",
+	"doesn't include signing certificate",
+	"code signing error",
+	"code signing is required",
+}
+
+// IsSigningError returns true if an xcodebuild output line reports a signing
+// problem. Case is ignored, as xcodebuild writes both "error:" and "Code
+// Signing Error:".
+func IsSigningError(line string) bool {
+	line = strings.ToLower(line)
+	if !strings.Contains(line, "error") {
+		return false
+	}
+	for _, p := range signingPatterns {
+		if strings.Contains(line, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDiagnostic(line string) bool {
+	return strings.Contains(line, "error:") ||
+		strings.Contains(line, "warning:") ||
+		strings.HasPrefix(line, "** ")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,70 @@
+package xcode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProject(t *testing.T) {
+	t.Run("xcodeproj only", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.Mkdir(filepath.Join(dir, "Unity-iPhone.xcodeproj"), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := FindProject(dir)
+		if err != nil {
+			t.Fatalf("FindProject failed: %v", err)
+		}
+		if p.Workspace != "" {
+			t.Errorf("Expected no workspace, got %s", p.Workspace)
+		}
+		if filepath.Base(p.Project) != "Unity-iPhone.xcodeproj" {
+			t.Errorf("Unexpected project: %s", p.Project)
+		}
+	})
+
+	t.Run("workspace preferred", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"Unity-iPhone.xcodeproj", "Unity-iPhone.xcworkspace"} {
+			if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		p, err := FindProject(dir)
+		if err != nil {
+			t.Fatalf("FindProject failed: %v", err)
+		}
+		if filepath.Base(p.Workspace) != "Unity-iPhone.xcworkspace" {
+			t.Errorf("Expected workspace to be detected, got %q", p.Workspace)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := FindProject(t.TempDir()); err == nil {
+			t.Error("Expected error for empty directory")
+		}
+	})
+}
+
+func TestIsSigningError(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{`error: Signing for "Unity-iPhone" requires a development team. Select a development team in the Signing & Capabilities editor.`, true},
+		{`error: No profiles for 'com.example.game' were found`, true},
+		{`Code Signing Error: No code signing identities found: No valid signing identities were found.`, true},
+		{`ERROR: Provisioning profile "Game" has expired`, true},
+		{`warning: Provisioning profile is about to expire`, false},
+		{`error: linker command failed with exit code 1`, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSigningError(tt.line); got != tt.want {
+			t.Errorf("IsSigningError(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}